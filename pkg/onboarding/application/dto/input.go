@@ -3,6 +3,7 @@ package dto
 import (
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
 )
@@ -32,6 +33,23 @@ type SignUpInput struct {
 	OTP         *string         `json:"otp"`
 }
 
+// EmailSignUpInput is used to create an account with an email address and password. The OTP is
+// the one that was sent to the email address to verify it
+type EmailSignUpInput struct {
+	EmailAddress *string         `json:"emailAddress"`
+	Password     *string         `json:"password"`
+	Flavour      feedlib.Flavour `json:"flavour"`
+	OTP          *string         `json:"otp"`
+}
+
+// SocialSignUpInput is used to create an account with an ID token that a social login provider
+// issued to the user. The email address of the account becomes the user's primary email address
+type SocialSignUpInput struct {
+	Provider profileutils.LoginProviderType `json:"provider"`
+	IDToken  *string                        `json:"idToken"`
+	Flavour  feedlib.Flavour                `json:"flavour"`
+}
+
 // PhoneNumberPayload used when verifying a phone number.
 type PhoneNumberPayload struct {
 	PhoneNumber *string `json:"phoneNumber"`
@@ -50,11 +68,48 @@ type ChangePINRequest struct {
 	OTP         string `json:"otp"`
 }
 
-// LoginPayload used when calling the REST API to log a user in
+// LoginPayload used when calling the REST API to log a user in. PRO users who log in with a
+// second factor add a code from their authenticator app or one of their recovery codes
 type LoginPayload struct {
 	PhoneNumber *string         `json:"phoneNumber"`
 	PIN         *string         `json:"pin"`
 	Flavour     feedlib.Flavour `json:"flavour"`
+	TOTPCode    *string         `json:"totpCode,omitempty"`
+}
+
+// EmailLoginPayload is used when calling the REST API to log a user in with their email address
+// and password. PRO users who log in with a second factor add a code from their authenticator app
+// or one of their recovery codes
+type EmailLoginPayload struct {
+	EmailAddress *string         `json:"emailAddress"`
+	Password     *string         `json:"password"`
+	Flavour      feedlib.Flavour `json:"flavour"`
+	TOTPCode     *string         `json:"totpCode,omitempty"`
+}
+
+// SocialLoginPayload is used when calling the REST API to log a user in with an ID token that a
+// social login provider issued to them. PRO users who log in with a second factor add a code from
+// their authenticator app or one of their recovery codes
+type SocialLoginPayload struct {
+	Provider profileutils.LoginProviderType `json:"provider"`
+	IDToken  *string                        `json:"idToken"`
+	Flavour  feedlib.Flavour                `json:"flavour"`
+	TOTPCode *string                        `json:"totpCode,omitempty"`
+}
+
+// ResetPasswordPayload is used when calling the REST API to choose a new password. The OTP is the
+// one that was sent to the email address to verify it
+type ResetPasswordPayload struct {
+	EmailAddress *string `json:"emailAddress"`
+	OTP          *string `json:"otp"`
+	Password     *string `json:"password"`
+}
+
+// EnrolTOTPPayload is used when calling the REST API to set up an authenticator app that the
+// roles of a user require before they can log in
+type EnrolTOTPPayload struct {
+	PhoneNumber *string `json:"phoneNumber"`
+	PIN         *string `json:"pin"`
 }
 
 // SendRetryOTPPayload is used when calling the REST API to resend an otp
@@ -78,6 +133,12 @@ type RefreshTokenPayload struct {
 	RefreshToken *string `json:"refreshToken"`
 }
 
+// ReportLoginPayload is used when calling the REST API to report a login that the user did not
+// make. The token is the one in the "this wasn't me" link of the login alert
+type ReportLoginPayload struct {
+	Token *string `json:"token"`
+}
+
 // UIDPayload is the user ID used in some inter-service requests
 type UIDPayload struct {
 	UID *string `json:"uid"`
@@ -179,6 +240,20 @@ type CheckPermissionPayload struct {
 	Permission *profileutils.Permission `json:"permission"`
 }
 
+// TokenIntrospectionPayload is the payload used when introspecting the bearer token of a user.
+// The flavour is used for Firebase ID tokens, which do not say which app the user logged in to
+type TokenIntrospectionPayload struct {
+	Token   *string          `json:"token"`
+	Flavour *feedlib.Flavour `json:"flavour,omitempty"`
+}
+
+// WebhookEndpointInput is the input required to register a webhook endpoint
+type WebhookEndpointInput struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+}
+
 // RoleRevocationInput is the input when revoking a user's role
 type RoleRevocationInput struct {
 	ProfileID string
@@ -199,3 +274,45 @@ type RegisterUserInput struct {
 	RoleIDs        []string          `json:"roleIDs,omitempty"`
 	WelcomeMessage *string           `json:"welcomeMessage,omitempty"`
 }
+
+// ListingInput is the payload used to request a page of user profiles or roles
+type ListingInput struct {
+	Pagination *firebasetools.PaginationInput `json:"pagination,omitempty"`
+	Filter     *firebasetools.FilterInput     `json:"filter,omitempty"`
+	Sort       *firebasetools.SortInput       `json:"sort,omitempty"`
+}
+
+// ListingFilter is a validated condition that the records of a listing should meet
+type ListingFilter struct {
+	FieldName string
+	Operation enumutils.Operation
+	// Value is a string, a bool or a time.Time depending on the field
+	Value interface{}
+}
+
+// ListingCursor is the position of a record in a sorted listing
+type ListingCursor struct {
+	ID string
+	// Value is the value of the sort field of the record. It is a string or a time.Time
+	Value interface{}
+}
+
+// ListingQuery is a validated request for a page of a listing.
+//
+// Records are read in the order of the sort field and then the ID, starting after the cursor.
+// When Backward is set the order is reversed so that the records before the cursor are read.
+// Repositories read up to one record more than the limit so that it can be told whether
+// there are more records beyond the page
+type ListingQuery struct {
+	Filters    []*ListingFilter
+	SortField  string
+	Descending bool
+	Backward   bool
+	Cursor     *ListingCursor
+	Limit      int
+}
+
+// ReadDescending tells whether the records should be read in descending order
+func (q ListingQuery) ReadDescending() bool {
+	return q.Descending != q.Backward
+}
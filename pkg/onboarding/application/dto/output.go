@@ -1,6 +1,10 @@
 package dto
 
 import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/profileutils"
 )
@@ -71,3 +75,39 @@ type RegisteredUserResponse struct {
 	DisplayName string `json:"displayName,omitempty"`
 	PhoneNumber string `json:"phoneNumber,omitempty"`
 }
+
+// UserProfileEdge is a user profile in a page of user profiles
+type UserProfileEdge struct {
+	Cursor string                    `json:"cursor"`
+	Node   *profileutils.UserProfile `json:"node"`
+}
+
+// UserProfileConnection is a page of user profiles
+type UserProfileConnection struct {
+	Edges    []*UserProfileEdge      `json:"edges"`
+	PageInfo *firebasetools.PageInfo `json:"pageInfo"`
+}
+
+// RoleEdge is a role in a page of roles
+type RoleEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *RoleOutput `json:"node"`
+}
+
+// RoleConnection is a page of roles
+type RoleConnection struct {
+	Edges    []*RoleEdge             `json:"edges"`
+	PageInfo *firebasetools.PageInfo `json:"pageInfo"`
+}
+
+// TokenIntrospection is what the service knows about the user of a bearer token. A token is not
+// active when it is not valid or its user profile is suspended
+type TokenIntrospection struct {
+	Active    bool            `json:"active"`
+	UID       string          `json:"uid,omitempty"`
+	ProfileID string          `json:"profileID,omitempty"`
+	Suspended bool            `json:"suspended"`
+	Flavour   feedlib.Flavour `json:"flavour,omitempty"`
+	Scopes    []string        `json:"scopes,omitempty"`
+	ExpiresAt *time.Time      `json:"expiresAt,omitempty"`
+}
@@ -1,7 +1,9 @@
 package exceptions
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/feedlib"
@@ -374,6 +376,15 @@ func RoleNotValid(err error) error {
 	}
 }
 
+// LoggedInUserIsNotAdminError returns an error when an action that is reserved for admins is
+// attempted by a user who is not an admin
+func LoggedInUserIsNotAdminError() error {
+	return &errorcodeutil.CustomError{
+		Message: LoggedInUserIsNotAdminErrMsg,
+		Code:    int(errorcodeutil.LoggedInUserIsNotAnAdmin),
+	}
+}
+
 // NavigationActionsError return an error when user navigation actions can not be manipulated
 func NavigationActionsError(err error) error {
 	return &errorcodeutil.CustomError{
@@ -382,3 +393,370 @@ func NavigationActionsError(err error) error {
 		Code:    int(errorcodeutil.NavigationActionsError),
 	}
 }
+
+// InvalidListingQueryError returns an error when the pagination, filter or sort parameters
+// of a listing are not valid
+func InvalidListingQueryError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: InvalidListingQueryErrMsg,
+		Code:    int(errorcodeutil.UndefinedArguments),
+	}
+}
+
+// InvalidWebhookEndpointError returns an error when the URL, secret or event types of a
+// webhook endpoint are not valid
+func InvalidWebhookEndpointError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: InvalidWebhookEndpointErrMsg,
+		Code:    int(errorcodeutil.UndefinedArguments),
+	}
+}
+
+// PINLockedError returns an error when a PIN is used while it is locked after too many failed attempts
+func PINLockedError(lockedUntil time.Time) error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the PIN is locked until %s", lockedUntil.Format(time.RFC3339)),
+		Message: PINLockedErrMsg,
+		Code:    PINLocked,
+	}
+}
+
+// PINAttemptsThrottledError returns an error when a PIN is attempted again before the delay that
+// follows a failed attempt has passed
+func PINAttemptsThrottledError(retryAfter time.Duration) error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the PIN can be attempted again in %s", retryAfter.Round(time.Second)),
+		Message: PINAttemptsThrottledErrMsg,
+		Code:    PINAttemptsThrottled,
+	}
+}
+
+// PINReusedError returns an error when a new PIN matches the current PIN or one of the PINs
+// that were used before it
+func PINReusedError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the PIN matches a recently used PIN"),
+		Message: PINReusedErrMsg,
+		Code:    PINReused,
+	}
+}
+
+// TempPINExpiredError returns an error when a temporary PIN is used after it has expired
+func TempPINExpiredError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the temporary PIN has expired"),
+		Message: TempPINExpiredErrMsg,
+		Code:    TempPINExpired,
+	}
+}
+
+// WeakPINError returns an error when a PIN is rejected by the PIN policy. The wrapped error
+// describes the rule that the PIN broke
+func WeakPINError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: WeakPINErrMsg,
+		Code:    WeakPIN,
+	}
+}
+
+// SessionRevokedError returns an error when the refresh token of a session that has been signed
+// out is exchanged for a new ID token
+func SessionRevokedError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the session has been revoked"),
+		Message: SessionRevokedErrMsg,
+		Code:    SessionRevoked,
+	}
+}
+
+// SessionNotFoundError returns an error when a refresh token is exchanged for an access token
+// signed by the service but the session that it belongs to, and so the flavour that the user
+// logged in to, is not known
+func SessionNotFoundError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the refresh token does not belong to a known session"),
+		Message: SessionNotFoundErrMsg,
+		Code:    SessionNotFound,
+	}
+}
+
+// PINResetRequiredError returns an error when a PIN that must be reset with an OTP is used
+func PINResetRequiredError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the PIN must be reset"),
+		Message: PINResetRequiredErrMsg,
+		Code:    PINResetRequired,
+	}
+}
+
+// InvalidLoginAlertTokenError returns an error when the token of a "this wasn't me" link has
+// expired or does not belong to any login alert
+func InvalidLoginAlertTokenError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: InvalidLoginAlertTokenErrMsg,
+		Code:    InvalidLoginAlertToken,
+	}
+}
+
+// TwoFactorRequiredError returns an error when a login needs a code from an authenticator app
+// that was not provided
+func TwoFactorRequiredError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("a second factor code is required"),
+		Message: TwoFactorRequiredErrMsg,
+		Code:    TwoFactorRequired,
+	}
+}
+
+// InvalidTwoFactorCodeError returns an error when a second factor code is wrong, has expired or
+// has already been used
+func InvalidTwoFactorCodeError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the second factor code was not accepted"),
+		Message: InvalidTwoFactorCodeErrMsg,
+		Code:    InvalidTwoFactorCode,
+	}
+}
+
+// TwoFactorEnrolmentRequiredError returns an error when the roles of a user require a second
+// factor that the user has not set up, or that they try to remove
+func TwoFactorEnrolmentRequiredError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: TwoFactorEnrolmentRequiredErrMsg,
+		Code:    TwoFactorEnrolmentRequired,
+	}
+}
+
+// TOTPAlreadyEnrolledError returns an error when a user who has a confirmed authenticator app
+// tries to enrol another one
+func TOTPAlreadyEnrolledError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the user has already enrolled an authenticator app"),
+		Message: TOTPAlreadyEnrolledErrMsg,
+		Code:    TOTPAlreadyEnrolled,
+	}
+}
+
+// TOTPNotEnrolledError returns an error when a user who has no authenticator app tries to
+// confirm, use or remove it
+func TOTPNotEnrolledError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the user has not enrolled an authenticator app"),
+		Message: TOTPNotEnrolledErrMsg,
+		Code:    TOTPNotEnrolled,
+	}
+}
+
+// WeakPasswordError returns an error when a password is rejected by the password policy
+func WeakPasswordError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: WeakPasswordErrMsg,
+		Code:    WeakPassword,
+	}
+}
+
+// PasswordMismatchError returns an error when an email address and password do not match an
+// email login. It is also returned for email addresses that have no email login, so that it
+// can't be used to find out which email addresses are registered
+func PasswordMismatchError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("wrong email login credentials supplied"),
+		Message: PasswordMismatchErrMsg,
+		Code:    PasswordMismatch,
+	}
+}
+
+// PasswordLockedError returns an error when an email login is used while it is locked after too
+// many failed attempts
+func PasswordLockedError(lockedUntil time.Time) error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the password is locked until %s", lockedUntil.Format(time.RFC3339)),
+		Message: PasswordLockedErrMsg,
+		Code:    PasswordLocked,
+	}
+}
+
+// PasswordAttemptsThrottledError returns an error when a password is attempted again before the
+// delay that follows a failed attempt has passed
+func PasswordAttemptsThrottledError(retryAfter time.Duration) error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the password can be attempted again in %s", retryAfter.Round(time.Second)),
+		Message: PasswordAttemptsThrottledErrMsg,
+		Code:    PasswordAttemptsThrottled,
+	}
+}
+
+// PasswordResetRequiredError returns an error when a password that must be reset with an OTP is
+// used
+func PasswordResetRequiredError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the password must be reset"),
+		Message: PasswordResetRequiredErrMsg,
+		Code:    PasswordResetRequired,
+	}
+}
+
+// InvalidEmailAddressError returns an error when an email address is not well formed
+func InvalidEmailAddressError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("invalid email address"),
+		Message: InvalidEmailAddressErrMsg,
+		Code:    InvalidEmailAddress,
+	}
+}
+
+// InvalidSocialTokenError returns an error when the ID token of a social login provider can't be
+// verified
+func InvalidSocialTokenError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: InvalidSocialTokenErrMsg,
+		Code:    InvalidSocialToken,
+	}
+}
+
+// SocialAccountInUseError returns an error when an account with a social login provider is linked
+// to another user profile
+func SocialAccountInUseError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the social account is linked to another profile"),
+		Message: SocialAccountInUseErrMsg,
+		Code:    SocialAccountInUse,
+	}
+}
+
+// SocialAccountNotLinkedError returns an error when a social login provider that is not linked to
+// a profile is unlinked
+func SocialAccountNotLinkedError(provider string) error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("%s is not linked to the profile", provider),
+		Message: SocialAccountNotLinkedErrMsg,
+		Code:    SocialAccountNotLinked,
+	}
+}
+
+// SocialEmailRequiredError returns an error when a social login provider does not share a
+// verified email address for a signup
+func SocialEmailRequiredError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the social account has no verified email address"),
+		Message: SocialEmailRequiredErrMsg,
+		Code:    SocialEmailRequired,
+	}
+}
+
+// LastLoginMethodError returns an error when a user tries to remove the only way they can log in
+func LastLoginMethodError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the profile has no other login method"),
+		Message: LastLoginMethodErrMsg,
+		Code:    LastLoginMethod,
+	}
+}
+
+// NotAnonymousUserError returns an error when a login that is not anonymous, or that already
+// has an account, is upgraded into an account
+func NotAnonymousUserError() error {
+	return &errorcodeutil.CustomError{
+		Err:     fmt.Errorf("the logged in user is not an anonymous user without a profile"),
+		Message: NotAnonymousUserErrMsg,
+		Code:    NotAnonymousUser,
+	}
+}
+
+// InvalidProfileMergeError returns an error when a user profile is merged into itself or into a
+// profile that has already been merged into another one
+func InvalidProfileMergeError(err error) error {
+	return &errorcodeutil.CustomError{
+		Err:     err,
+		Message: InvalidProfileMergeErrMsg,
+		Code:    InvalidProfileMerge,
+	}
+}
+
+// ConflictError is returned when a write is rejected because the record has been changed
+// by another request since it was read. The write can be retried after reading the record again
+type ConflictError struct {
+	errorcodeutil.CustomError
+}
+
+// ProfileUpdateConflictError returns an error when a user profile has been changed by
+// another request since it was read
+func ProfileUpdateConflictError(err error) error {
+	return &ConflictError{
+		CustomError: errorcodeutil.CustomError{
+			Err:     err,
+			Message: ProfileUpdateConflictErrMsg,
+			Code:    int(errorcodeutil.Internal),
+		},
+	}
+}
+
+// IsProfileNotFoundError checks whether an error is returned because a user profile does not exist
+func IsProfileNotFoundError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) && customErr.Code == int(errorcodeutil.ProfileNotFound)
+}
+
+// IsPINNotFoundError checks whether an error is returned because a user profile has no PIN
+func IsPINNotFoundError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) && customErr.Code == int(errorcodeutil.PINNotFound)
+}
+
+// IsPINLockedError checks whether an error is returned because a PIN is locked or its
+// attempts are throttled
+func IsPINLockedError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) &&
+		(customErr.Code == PINLocked || customErr.Code == PINAttemptsThrottled)
+}
+
+// IsPasswordLockedError checks whether an error is returned because an email login is locked or
+// its attempts are throttled
+func IsPasswordLockedError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) &&
+		(customErr.Code == PasswordLocked || customErr.Code == PasswordAttemptsThrottled)
+}
+
+// IsSessionRevokedError checks whether an error is returned because a session has been signed out
+func IsSessionRevokedError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) && customErr.Code == SessionRevoked
+}
+
+// IsSessionNotFoundError checks whether an error is returned because the session of a refresh
+// token is not known
+func IsSessionNotFoundError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) && customErr.Code == SessionNotFound
+}
+
+// IsConflictError checks whether an error is a ConflictError
+func IsConflictError(err error) bool {
+	var conflictErr *ConflictError
+	return errors.As(err, &conflictErr)
+}
+
+// IsInvalidInputError checks whether an error is returned because the arguments of a request,
+// such as the pagination, filter or sort parameters of a listing, are not valid
+func IsInvalidInputError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) && customErr.Code == int(errorcodeutil.UndefinedArguments)
+}
+
+// IsPermissionDeniedError checks whether an error is returned because the logged in user is not
+// allowed to do what they asked for
+func IsPermissionDeniedError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return errors.As(err, &customErr) &&
+		(customErr.Code == int(errorcodeutil.RoleNotValid) ||
+			customErr.Code == int(errorcodeutil.LoggedInUserIsNotAnAdmin))
+}
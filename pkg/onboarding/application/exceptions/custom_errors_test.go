@@ -3,6 +3,7 @@ package exceptions_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
@@ -83,4 +84,123 @@ func TestCustomErrors(t *testing.T) {
 	assert.NotNil(t, err)
 	err = exceptions.NavigationActionsError(fmt.Errorf("error"))
 	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsConflictError(err))
+
+	err = exceptions.ProfileUpdateConflictError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+	assert.True(t, exceptions.IsConflictError(err))
+	assert.True(t, exceptions.IsConflictError(fmt.Errorf("unable to update profile: %w", err)))
+
+	err = exceptions.InvalidListingQueryError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.InvalidWebhookEndpointError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.PINLockedError(time.Now())
+	assert.NotNil(t, err)
+	assert.True(t, exceptions.IsPINLockedError(fmt.Errorf("unable to login: %w", err)))
+	err = exceptions.PINAttemptsThrottledError(time.Second)
+	assert.True(t, exceptions.IsPINLockedError(err))
+	assert.False(t, exceptions.IsPINLockedError(exceptions.PinMismatchError(nil)))
+
+	err = exceptions.PINReusedError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+
+	err = exceptions.TempPINExpiredError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+
+	err = exceptions.WeakPINError(fmt.Errorf("the PIN is a common PIN"))
+	assert.NotNil(t, err)
+
+	err = exceptions.SessionRevokedError()
+	assert.True(t, exceptions.IsSessionRevokedError(fmt.Errorf("unable to refresh token: %w", err)))
+	assert.False(t, exceptions.IsSessionRevokedError(exceptions.PinMismatchError(nil)))
+
+	err = exceptions.SessionNotFoundError()
+	assert.True(t, exceptions.IsSessionNotFoundError(fmt.Errorf("unable to refresh token: %w", err)))
+	assert.False(t, exceptions.IsSessionNotFoundError(exceptions.SessionRevokedError()))
+
+	err = exceptions.PINResetRequiredError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+
+	err = exceptions.PasswordResetRequiredError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPasswordLockedError(err))
+
+	err = exceptions.InvalidLoginAlertTokenError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.TwoFactorRequiredError()
+	assert.NotNil(t, err)
+
+	err = exceptions.InvalidTwoFactorCodeError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+
+	err = exceptions.TwoFactorEnrolmentRequiredError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPAlreadyEnrolledError()
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPNotEnrolledError()
+	assert.NotNil(t, err)
+
+	err = exceptions.WeakPasswordError(fmt.Errorf("the password is too short"))
+	assert.NotNil(t, err)
+
+	err = exceptions.PasswordMismatchError()
+	assert.NotNil(t, err)
+
+	err = exceptions.PasswordLockedError(time.Now())
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+
+	err = exceptions.PasswordAttemptsThrottledError(time.Second)
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPINLockedError(err))
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+
+	err = exceptions.InvalidEmailAddressError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsPasswordLockedError(err))
+
+	err = exceptions.InvalidSocialTokenError(fmt.Errorf("the token has expired"))
+	assert.NotNil(t, err)
+
+	err = exceptions.SocialAccountInUseError()
+	assert.NotNil(t, err)
+
+	err = exceptions.SocialAccountNotLinkedError("SOCIAL_GOOGLE")
+	assert.NotNil(t, err)
+
+	err = exceptions.SocialEmailRequiredError()
+	assert.NotNil(t, err)
+
+	err = exceptions.LastLoginMethodError()
+	assert.NotNil(t, err)
+
+	err = exceptions.NotAnonymousUserError()
+	assert.NotNil(t, err)
+
+	err = exceptions.InvalidProfileMergeError(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.LoggedInUserIsNotAdminError()
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsProfileNotFoundError(err))
+	err = exceptions.ProfileNotFoundError(fmt.Errorf("error"))
+	assert.True(t, exceptions.IsProfileNotFoundError(fmt.Errorf("unable to get profile: %w", err)))
+	assert.False(t, exceptions.IsPINNotFoundError(err))
+	assert.True(t, exceptions.IsPINNotFoundError(exceptions.PinNotFoundError(fmt.Errorf("error"))))
+	assert.True(t, exceptions.IsInvalidInputError(exceptions.InvalidListingQueryError(fmt.Errorf("error"))))
+	assert.False(t, exceptions.IsInvalidInputError(err))
+	assert.True(t, exceptions.IsPermissionDeniedError(exceptions.RoleNotValid(fmt.Errorf("error"))))
+	assert.True(t, exceptions.IsPermissionDeniedError(exceptions.LoggedInUserIsNotAdminError()))
+	assert.False(t, exceptions.IsPermissionDeniedError(err))
 }
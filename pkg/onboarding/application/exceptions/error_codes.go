@@ -0,0 +1,99 @@
+package exceptions
+
+import "github.com/savannahghi/errorcodeutil"
+
+// the codes of the errors that are specific to this service. They follow the codes that are
+// shared through errorcodeutil so that clients can tell them apart
+const (
+	// PINLocked means that the PIN has been locked after too many failed attempts.
+	// It can be unlocked by resetting the PIN with an OTP or by an admin
+	PINLocked = int(errorcodeutil.HasNoHistoricalClaimsError) + 1000 + iota
+
+	// PINAttemptsThrottled means that the PIN was attempted again too soon after a failed attempt
+	PINAttemptsThrottled
+
+	// PINReused means that a new PIN matches the current PIN or one of the PINs used before it
+	PINReused
+
+	// TempPINExpired means that a temporary PIN was used after it expired. An admin can reissue it
+	TempPINExpired
+
+	// WeakPIN means that a PIN was rejected by the PIN policy because it is easy to guess
+	WeakPIN
+
+	// SessionRevoked means that a refresh token belongs to a session that has been signed out
+	SessionRevoked
+
+	// PINResetRequired means that the PIN can't be used until it is reset with an OTP
+	PINResetRequired
+
+	// InvalidLoginAlertToken means that a "this wasn't me" link has expired or does not exist
+	InvalidLoginAlertToken
+
+	// TwoFactorRequired means that a code from an authenticator app is needed to log in
+	TwoFactorRequired
+
+	// InvalidTwoFactorCode means that a second factor code is wrong, has expired or has been used
+	InvalidTwoFactorCode
+
+	// TwoFactorEnrolmentRequired means that the roles of a user require them to log in with a
+	// second factor that they have not set up
+	TwoFactorEnrolmentRequired
+
+	// TOTPAlreadyEnrolled means that a user who already has an authenticator app tried to add another
+	TOTPAlreadyEnrolled
+
+	// TOTPNotEnrolled means that a user who has no authenticator app tried to use or change it
+	TOTPNotEnrolled
+
+	// WeakPassword means that a password was rejected by the password policy
+	WeakPassword
+
+	// PasswordMismatch means that an email address and password do not match an email login
+	PasswordMismatch
+
+	// PasswordLocked means that an email login was locked after too many failed attempts.
+	// It can be unlocked by resetting the password with an OTP
+	PasswordLocked
+
+	// InvalidEmailAddress means that an email address is not well formed
+	InvalidEmailAddress
+
+	// InvalidSocialToken means that the ID token of a social login provider could not be verified
+	InvalidSocialToken
+
+	// SocialAccountInUse means that an account with a social login provider is linked to another
+	// user profile
+	SocialAccountInUse
+
+	// SocialAccountNotLinked means that a user tried to unlink a social login provider that is
+	// not linked to their profile
+	SocialAccountNotLinked
+
+	// SocialEmailRequired means that a user tried to sign up with a social login provider that
+	// did not share a verified email address
+	SocialEmailRequired
+
+	// LastLoginMethod means that a user tried to remove the only way they can log in
+	LastLoginMethod
+
+	// NotAnonymousUser means that a user who is not signed in anonymously, or whose anonymous
+	// login already has an account, tried to upgrade it into an account
+	NotAnonymousUser
+
+	// InvalidProfileMerge means that an admin tried to merge a user profile into itself or into
+	// a profile that has already been merged into another one
+	InvalidProfileMerge
+
+	// SessionNotFound means that a refresh token does not belong to a session that says which
+	// flavour the user logged in to
+	SessionNotFound
+
+	// PasswordAttemptsThrottled means that the password of an email login was attempted again
+	// too soon after a failed attempt
+	PasswordAttemptsThrottled
+
+	// PasswordResetRequired means that the password of an email login can't be used until it is
+	// reset with an OTP
+	PasswordResetRequired
+)
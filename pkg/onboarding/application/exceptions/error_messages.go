@@ -139,4 +139,105 @@ const (
 
 	//NavActionsError is an error message displayed when the system cannot update navigation actions
 	NavActionsError = "navigation actions not updated"
+
+	// LoggedInUserIsNotAdminErrMsg is displayed when an admin action is attempted by a user who
+	// is not an admin
+	LoggedInUserIsNotAdminErrMsg = "the logged in user is not an admin"
+
+	// ProfileUpdateConflictErrMsg is displayed when a user profile has been changed by another
+	// request since it was read
+	ProfileUpdateConflictErrMsg = "the user profile has been updated by another request, please try again"
+
+	// InvalidListingQueryErrMsg is displayed when the pagination, filter or sort parameters of a
+	// listing are not valid
+	InvalidListingQueryErrMsg = "invalid pagination, filter or sort parameters"
+
+	// InvalidWebhookEndpointErrMsg is displayed when the URL, secret or event types of a webhook
+	// endpoint are not valid
+	InvalidWebhookEndpointErrMsg = "invalid webhook endpoint"
+
+	// PINLockedErrMsg is displayed when a PIN is used while it is locked after too many failed attempts
+	PINLockedErrMsg = "your PIN has been locked after too many failed attempts. Reset it to unlock it or try again later"
+
+	// PINAttemptsThrottledErrMsg is displayed when a PIN is attempted again too soon after a failed attempt
+	PINAttemptsThrottledErrMsg = "too many failed PIN attempts, please wait before trying again"
+
+	// PINReusedErrMsg is displayed when a new PIN matches the current PIN or a recently used PIN
+	PINReusedErrMsg = "the new PIN has been used recently, please choose a different PIN"
+
+	// TempPINExpiredErrMsg is displayed when a temporary PIN is used after it has expired
+	TempPINExpiredErrMsg = "your temporary PIN has expired. Reset your PIN or ask for a new temporary PIN"
+
+	// WeakPINErrMsg is displayed when a PIN is rejected because it is easy to guess
+	WeakPINErrMsg = "the PIN is too easy to guess, please choose a different PIN"
+
+	// SessionRevokedErrMsg is displayed when a refresh token of a signed out session is used
+	SessionRevokedErrMsg = "you have been signed out on this device, please log in again"
+
+	// PINResetRequiredErrMsg is displayed when a PIN that must be reset is used
+	PINResetRequiredErrMsg = "your PIN must be reset before you can log in. Reset it with an OTP sent to your phone"
+
+	// InvalidLoginAlertTokenErrMsg is displayed when a "this wasn't me" link has expired or is not valid
+	InvalidLoginAlertTokenErrMsg = "this link has expired or is not valid"
+
+	// TwoFactorRequiredErrMsg is displayed when a login needs a code from an authenticator app
+	TwoFactorRequiredErrMsg = "enter the code from your authenticator app to log in"
+
+	// InvalidTwoFactorCodeErrMsg is displayed when a second factor code is not accepted
+	InvalidTwoFactorCodeErrMsg = "the code is not valid or has already been used"
+
+	// TwoFactorEnrolmentRequiredErrMsg is displayed when the roles of a user require a second factor
+	TwoFactorEnrolmentRequiredErrMsg = "your role requires two factor authentication. Set up an authenticator app to continue"
+
+	// TOTPAlreadyEnrolledErrMsg is displayed when a user who has an authenticator app tries to add another
+	TOTPAlreadyEnrolledErrMsg = "two factor authentication is already set up"
+
+	// TOTPNotEnrolledErrMsg is displayed when a user who has no authenticator app tries to use it
+	TOTPNotEnrolledErrMsg = "two factor authentication is not set up"
+
+	// WeakPasswordErrMsg is displayed when a password is rejected by the password policy
+	WeakPasswordErrMsg = "the password is too easy to guess, please choose a different password"
+
+	// PasswordMismatchErrMsg is displayed when an email address and password do not match
+	PasswordMismatchErrMsg = "the email address or password is incorrect"
+
+	// PasswordLockedErrMsg is displayed when an email login is locked after too many failed attempts
+	PasswordLockedErrMsg = "too many failed attempts. Reset your password with an OTP sent to your email or try again later"
+
+	// InvalidEmailAddressErrMsg is displayed when an email address is not well formed
+	InvalidEmailAddressErrMsg = "the email address is not valid"
+
+	// InvalidSocialTokenErrMsg is displayed when the ID token of a social login provider is rejected
+	InvalidSocialTokenErrMsg = "unable to verify your sign in, please try again"
+
+	// SocialAccountInUseErrMsg is displayed when a social account is linked to another profile
+	SocialAccountInUseErrMsg = "this account is already linked to another user"
+
+	// SocialAccountNotLinkedErrMsg is displayed when a social login provider that is not linked
+	// is unlinked
+	SocialAccountNotLinkedErrMsg = "this sign in provider is not linked to your account"
+
+	// SocialEmailRequiredErrMsg is displayed when a social login provider does not share a
+	// verified email address at signup
+	SocialEmailRequiredErrMsg = "please allow access to a verified email address to sign up"
+
+	// LastLoginMethodErrMsg is displayed when a user tries to remove their only login method
+	LastLoginMethodErrMsg = "add another way to sign in before removing this one"
+
+	// NotAnonymousUserErrMsg is displayed when a login that is not anonymous is upgraded into
+	// an account
+	NotAnonymousUserErrMsg = "only a guest session can be turned into an account"
+
+	// InvalidProfileMergeErrMsg is displayed when a user profile can't be merged into another one
+	InvalidProfileMergeErrMsg = "a user profile can only be merged into another active user profile"
+
+	// SessionNotFoundErrMsg is displayed when a refresh token can't be matched to a session
+	SessionNotFoundErrMsg = "your session could not be found, please log in again"
+
+	// PasswordAttemptsThrottledErrMsg is displayed when a password is attempted again too soon
+	// after a failed attempt
+	PasswordAttemptsThrottledErrMsg = "too many failed attempts, please wait before trying again"
+
+	// PasswordResetRequiredErrMsg is displayed when a password that must be reset is used
+	PasswordResetRequiredErrMsg = "your password must be reset before you can log in. Reset it with an OTP sent to your email"
 )
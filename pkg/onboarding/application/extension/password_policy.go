@@ -0,0 +1,169 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+)
+
+const (
+	// PasswordMinLengthEnvVarName is the env var that sets the minimum length of passwords
+	PasswordMinLengthEnvVarName = "PASSWORD_MIN_LENGTH"
+
+	// defaultPasswordMinLength is the minimum length of passwords when `PASSWORD_MIN_LENGTH` is
+	// not set. It can't be set any lower
+	defaultPasswordMinLength = 8
+
+	// maxPasswordLength caps the length of passwords so that hashing them stays cheap
+	maxPasswordLength = 128
+)
+
+// CommonPasswords are passwords that are chosen so often that they are among the first ones to be
+// guessed. They are compared regardless of their case
+var CommonPasswords = []string{
+	"password", "password1", "password12", "password123", "passw0rd", "p@ssw0rd", "p@ssword",
+	"12345678", "123456789", "1234567890", "87654321", "11111111", "00000000", "12341234",
+	"qwertyui", "qwerty123", "qwertyuiop", "1q2w3e4r", "1qaz2wsx", "zaq12wsx", "asdfghjk",
+	"iloveyou", "iloveyou1", "sunshine", "princess", "football", "baseball", "welcome1",
+	"welcome123", "letmein1", "trustno1", "superman", "starwars", "abc12345", "abcd1234",
+	"changeme", "admin123", "administrator", "computer", "internet", "whatever",
+}
+
+// PasswordPolicyInput is a password together with the details of the user who chose it, so that
+// the password can be checked against what is known about the user
+type PasswordPolicyInput struct {
+	Password     string
+	EmailAddress string
+}
+
+// PasswordRule checks a password against a single requirement
+type PasswordRule interface {
+	Check(input PasswordPolicyInput) error
+}
+
+// PasswordRuleFunc adapts a function to a PasswordRule
+type PasswordRuleFunc func(input PasswordPolicyInput) error
+
+// Check calls the function with the input
+func (f PasswordRuleFunc) Check(input PasswordPolicyInput) error {
+	return f(input)
+}
+
+// PasswordPolicy decides whether a user can choose a password
+type PasswordPolicy interface {
+	ValidatePassword(input PasswordPolicyInput) error
+}
+
+// PasswordPolicyImpl rejects a password that breaks any of its rules
+type PasswordPolicyImpl struct {
+	rules []PasswordRule
+}
+
+// NewPasswordPolicyImpl returns a password policy that checks passwords against the provided
+// rules, in order
+func NewPasswordPolicyImpl(rules ...PasswordRule) PasswordPolicy {
+	return &PasswordPolicyImpl{rules: rules}
+}
+
+// NewDefaultPasswordPolicy returns the password policy that is applied when users sign up with
+// an email address or set or reset their password
+func NewDefaultPasswordPolicy() PasswordPolicy {
+	return NewPasswordPolicyImpl(
+		PasswordLengthRule(PasswordMinLength()),
+		PasswordCharactersRule(),
+		CommonPasswordRule(CommonPasswords...),
+		EmailAddressRule(),
+	)
+}
+
+// ValidatePassword returns the error of the first rule that the password breaks
+func (p *PasswordPolicyImpl) ValidatePassword(input PasswordPolicyInput) error {
+	for _, rule := range p.rules {
+		if err := rule.Check(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PasswordMinLength returns the minimum length of passwords. It is read from the
+// `PASSWORD_MIN_LENGTH` env var. A length that is not set, or that is shorter than 8 or longer
+// than 128, falls back to 8
+func PasswordMinLength() int {
+	length, err := strconv.Atoi(os.Getenv(PasswordMinLengthEnvVarName))
+	if err != nil || length < defaultPasswordMinLength || length > maxPasswordLength {
+		return defaultPasswordMinLength
+	}
+	return length
+}
+
+// PasswordLengthRule rejects a password that has fewer characters than the minimum length or more
+// than 128 characters
+func PasswordLengthRule(minLength int) PasswordRule {
+	return PasswordRuleFunc(func(input PasswordPolicyInput) error {
+		length := utf8.RuneCountInString(input.Password)
+		if length < minLength || length > maxPasswordLength {
+			return exceptions.WeakPasswordError(
+				fmt.Errorf("the password should have between %d and %d characters", minLength, maxPasswordLength),
+			)
+		}
+		return nil
+	})
+}
+
+// PasswordCharactersRule rejects a password that is only letters or has no letters at all
+func PasswordCharactersRule() PasswordRule {
+	return PasswordRuleFunc(func(input PasswordPolicyInput) error {
+		letters := 0
+		for _, r := range input.Password {
+			if unicode.IsLetter(r) {
+				letters++
+			}
+		}
+		if letters == 0 || letters == utf8.RuneCountInString(input.Password) {
+			return exceptions.WeakPasswordError(
+				fmt.Errorf("the password should have both letters and other characters"),
+			)
+		}
+		return nil
+	})
+}
+
+// CommonPasswordRule rejects a password that is on a deny-list, regardless of its case
+func CommonPasswordRule(passwords ...string) PasswordRule {
+	denied := map[string]bool{}
+	for _, password := range passwords {
+		denied[strings.ToLower(password)] = true
+	}
+	return PasswordRuleFunc(func(input PasswordPolicyInput) error {
+		if denied[strings.ToLower(input.Password)] {
+			return exceptions.WeakPasswordError(fmt.Errorf("the password is a commonly used password"))
+		}
+		return nil
+	})
+}
+
+// EmailAddressRule rejects a password that has the user's email address, or the part of it
+// before the @, in it
+func EmailAddressRule() PasswordRule {
+	return PasswordRuleFunc(func(input PasswordPolicyInput) error {
+		email := strings.ToLower(strings.TrimSpace(input.EmailAddress))
+		if email == "" {
+			return nil
+		}
+		name := email
+		if at := strings.LastIndex(email, "@"); at >= 0 {
+			name = email[:at]
+		}
+		password := strings.ToLower(input.Password)
+		if strings.Contains(password, email) || (len(name) >= 3 && strings.Contains(password, name)) {
+			return exceptions.WeakPasswordError(fmt.Errorf("the password has the email address in it"))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package extension_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyImpl_ValidatePassword(t *testing.T) {
+	policy := extension.NewPasswordPolicyImpl(
+		extension.PasswordLengthRule(10),
+		extension.PasswordCharactersRule(),
+		extension.CommonPasswordRule(extension.CommonPasswords...),
+		extension.EmailAddressRule(),
+	)
+
+	tests := []struct {
+		name    string
+		input   extension.PasswordPolicyInput
+		wantErr bool
+	}{
+		{name: "strong password", input: extension.PasswordPolicyInput{Password: "kettle-2-lantern"}, wantErr: false},
+		{name: "too short", input: extension.PasswordPolicyInput{Password: "kettle-2"}, wantErr: true},
+		{name: "too long", input: extension.PasswordPolicyInput{Password: strings.Repeat("kettle-2", 17)}, wantErr: true},
+		{name: "only letters", input: extension.PasswordPolicyInput{Password: "kettlelantern"}, wantErr: true},
+		{name: "no letters", input: extension.PasswordPolicyInput{Password: "2791648350"}, wantErr: true},
+		{name: "common password", input: extension.PasswordPolicyInput{Password: "Welcome123"}, wantErr: true},
+		{
+			name:    "has the name of the email address",
+			input:   extension.PasswordPolicyInput{Password: "Wanjiku.Kamau1", EmailAddress: "wanjiku.kamau@example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "unrelated to the email address",
+			input:   extension.PasswordPolicyInput{Password: "kettle-2-lantern", EmailAddress: "wanjiku.kamau@example.com"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.ValidatePassword(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PasswordPolicyImpl.ValidatePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyImpl_CustomRules(t *testing.T) {
+	noSpaces := extension.PasswordRuleFunc(func(input extension.PasswordPolicyInput) error {
+		if strings.Contains(input.Password, " ") {
+			return fmt.Errorf("the password has a space")
+		}
+		return nil
+	})
+	policy := extension.NewPasswordPolicyImpl(noSpaces)
+
+	assert.Nil(t, policy.ValidatePassword(extension.PasswordPolicyInput{Password: "kettle-2"}))
+	assert.NotNil(t, policy.ValidatePassword(extension.PasswordPolicyInput{Password: "kettle 2"}))
+	assert.Nil(t, extension.NewPasswordPolicyImpl().ValidatePassword(extension.PasswordPolicyInput{Password: "password"}))
+}
+
+func TestPasswordMinLength(t *testing.T) {
+	t.Setenv(extension.PasswordMinLengthEnvVarName, "12")
+	assert.Equal(t, 12, extension.PasswordMinLength())
+
+	t.Setenv(extension.PasswordMinLengthEnvVarName, "6")
+	assert.Equal(t, 8, extension.PasswordMinLength())
+}
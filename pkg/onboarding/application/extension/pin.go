@@ -49,11 +49,19 @@ var DefaultHashFunction = sha512.New
 // Options is a struct for custom values of salt length, number of iterations, the encoded key's length,
 // and the hash function being used. If set to `nil`, default options are used:
 // &Options{ 256, 10000, 512, "sha512" }
+//
+// Algorithm selects the PIN hasher. An empty algorithm uses PBKDF2 with the options above.
+// Memory and Threads are only used by argon2id, and Cost only by bcrypt
 type Options struct {
 	SaltLen      int
 	Iterations   int
 	KeyLen       int
 	HashFunction func() hash.Hash
+
+	Algorithm string
+	Memory    int
+	Threads   int
+	Cost      int
 }
 
 // NewPINExtensionImpl ...
@@ -82,9 +90,11 @@ func (p PINExtensionImpl) EncryptPIN(rawPwd string, options *Options) (string, s
 		encodedPwd := pbkdf2.Key([]byte(rawPwd), salt, defaultIterations, DefaultKeyLen, DefaultHashFunction)
 		return string(salt), hex.EncodeToString(encodedPwd)
 	}
-	salt := generateSalt(options.SaltLen)
-	encodedPwd := pbkdf2.Key([]byte(rawPwd), salt, options.Iterations, options.KeyLen, options.HashFunction)
-	return string(salt), hex.EncodeToString(encodedPwd)
+	hasher, ok := pinHasher(options.Algorithm)
+	if !ok {
+		return "", ""
+	}
+	return hasher.Hash(rawPwd, options)
 }
 
 // ComparePIN takes four arguments, the raw password, its generated salt, the encoded password,
@@ -92,9 +102,13 @@ func (p PINExtensionImpl) EncryptPIN(rawPwd string, options *Options) (string, s
 // Passing `nil` as the last argument resorts to default options.
 func (p PINExtensionImpl) ComparePIN(rawPwd string, salt string, encodedPwd string, options *Options) bool {
 	if options == nil {
-		return encodedPwd == hex.EncodeToString(pbkdf2.Key([]byte(rawPwd), []byte(salt), defaultIterations, DefaultKeyLen, DefaultHashFunction))
+		return constantTimeEqual(encodedPwd, hex.EncodeToString(pbkdf2.Key([]byte(rawPwd), []byte(salt), defaultIterations, DefaultKeyLen, DefaultHashFunction)))
+	}
+	hasher, ok := pinHasher(options.Algorithm)
+	if !ok {
+		return false
 	}
-	return encodedPwd == hex.EncodeToString(pbkdf2.Key([]byte(rawPwd), []byte(salt), options.Iterations, options.KeyLen, options.HashFunction))
+	return hasher.Compare(rawPwd, salt, encodedPwd, options)
 }
 
 // GenerateTempPIN generates a temporary One Time PIN for a user
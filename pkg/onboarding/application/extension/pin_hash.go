@@ -0,0 +1,233 @@
+package extension
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// the algorithms that PINs can be hashed with
+const (
+	PBKDF2SHA512Algorithm = "pbkdf2-sha512"
+	Argon2idAlgorithm     = "argon2id"
+	BcryptAlgorithm       = "bcrypt"
+)
+
+const (
+	// PINHashAlgorithmEnvVarName is the env var that sets the algorithm that new PINs are hashed
+	// with. It defaults to argon2id
+	PINHashAlgorithmEnvVarName = "PIN_HASH_ALGORITHM"
+
+	// PINHashCostEnvVarName is the env var that raises the cost of the PIN hashing algorithm.
+	// It is the iteration count of PBKDF2, the time cost of argon2id or the cost factor of bcrypt
+	PINHashCostEnvVarName = "PIN_HASH_COST"
+
+	// recommended PBKDF2-HMAC-SHA512 iteration count
+	pbkdf2Iterations = 210000
+
+	// recommended argon2id parameters i.e 19 MiB of memory, 2 iterations and 1 thread
+	argon2Iterations = 2
+	argon2Memory     = 19 * 1024
+	argon2Threads    = 1
+	argon2SaltLen    = 16
+	argon2KeyLen     = 32
+)
+
+// PINHasher hashes PINs with a single algorithm
+type PINHasher interface {
+	Hash(rawPwd string, options *Options) (string, string)
+	Compare(rawPwd string, salt string, encodedPwd string, options *Options) bool
+}
+
+var (
+	pinHashersMu sync.RWMutex
+	pinHashers   = map[string]PINHasher{
+		"":                    pbkdf2Hasher{},
+		PBKDF2SHA512Algorithm: pbkdf2Hasher{},
+		Argon2idAlgorithm:     argon2idHasher{},
+		BcryptAlgorithm:       bcryptHasher{},
+	}
+)
+
+// RegisterPINHasher makes a PIN hasher available under the provided algorithm name, replacing the
+// hasher that was registered for it before
+func RegisterPINHasher(algorithm string, hasher PINHasher) {
+	pinHashersMu.Lock()
+	defer pinHashersMu.Unlock()
+	pinHashers[algorithm] = hasher
+}
+
+func pinHasher(algorithm string) (PINHasher, bool) {
+	pinHashersMu.RLock()
+	defer pinHashersMu.RUnlock()
+	hasher, ok := pinHashers[algorithm]
+	return hasher, ok
+}
+
+// CurrentPINHashOptions returns the options that new PINs are hashed with. They are read from
+// the `PIN_HASH_ALGORITHM` and `PIN_HASH_COST` env vars
+func CurrentPINHashOptions() *Options {
+	// a cost that is not set or not valid is raised to the recommended minimum below
+	cost, _ := strconv.Atoi(os.Getenv(PINHashCostEnvVarName))
+
+	switch strings.ToLower(os.Getenv(PINHashAlgorithmEnvVarName)) {
+	case PBKDF2SHA512Algorithm:
+		if cost < pbkdf2Iterations {
+			cost = pbkdf2Iterations
+		}
+		return &Options{
+			Algorithm:    PBKDF2SHA512Algorithm,
+			SaltLen:      DefaultSaltLen,
+			Iterations:   cost,
+			KeyLen:       DefaultKeyLen,
+			HashFunction: DefaultHashFunction,
+		}
+	case BcryptAlgorithm:
+		if cost < bcrypt.DefaultCost || cost > bcrypt.MaxCost {
+			cost = bcrypt.DefaultCost
+		}
+		return &Options{Algorithm: BcryptAlgorithm, Cost: cost}
+	default:
+		if cost < argon2Iterations {
+			cost = argon2Iterations
+		}
+		return &Options{
+			Algorithm:  Argon2idAlgorithm,
+			SaltLen:    argon2SaltLen,
+			Iterations: cost,
+			Memory:     argon2Memory,
+			Threads:    argon2Threads,
+			KeyLen:     argon2KeyLen,
+		}
+	}
+}
+
+// PINHashOptions returns the options that a PIN stored with the provided scheme is compared
+// with. PINs without a scheme use the legacy default options, so `nil` is returned for them
+func PINHashOptions(scheme *domain.PINHashScheme) *Options {
+	if scheme == nil {
+		return nil
+	}
+	return &Options{
+		Algorithm:    scheme.Algorithm,
+		SaltLen:      scheme.SaltLen,
+		Iterations:   scheme.Iterations,
+		KeyLen:       scheme.KeyLen,
+		HashFunction: DefaultHashFunction,
+		Memory:       scheme.Memory,
+		Threads:      scheme.Threads,
+		Cost:         scheme.Cost,
+	}
+}
+
+// NewPINHashScheme returns the scheme that is stored with a PIN that was hashed with the
+// provided options
+func NewPINHashScheme(options *Options) *domain.PINHashScheme {
+	if options == nil {
+		return &domain.PINHashScheme{
+			Algorithm:  PBKDF2SHA512Algorithm,
+			Iterations: defaultIterations,
+			SaltLen:    DefaultSaltLen,
+			KeyLen:     DefaultKeyLen,
+		}
+	}
+	algorithm := options.Algorithm
+	if algorithm == "" {
+		algorithm = PBKDF2SHA512Algorithm
+	}
+	return &domain.PINHashScheme{
+		Algorithm:  algorithm,
+		Iterations: options.Iterations,
+		Memory:     options.Memory,
+		Threads:    options.Threads,
+		Cost:       options.Cost,
+		SaltLen:    options.SaltLen,
+		KeyLen:     options.KeyLen,
+	}
+}
+
+// PINNeedsRehash checks whether a PIN that is stored with the provided scheme should be hashed
+// again with the current options
+func PINNeedsRehash(scheme *domain.PINHashScheme, current *Options) bool {
+	stored := NewPINHashScheme(PINHashOptions(scheme))
+	return *stored != *NewPINHashScheme(current)
+}
+
+func constantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// pbkdf2Hasher hashes PINs with PBKDF2. It was the only algorithm before the scheme of a PIN was
+// stored
+type pbkdf2Hasher struct{}
+
+func (pbkdf2Hasher) key(rawPwd string, salt []byte, options *Options) []byte {
+	hashFunction := options.HashFunction
+	if hashFunction == nil {
+		hashFunction = DefaultHashFunction
+	}
+	return pbkdf2.Key([]byte(rawPwd), salt, options.Iterations, options.KeyLen, hashFunction)
+}
+
+func (h pbkdf2Hasher) Hash(rawPwd string, options *Options) (string, string) {
+	salt := generateSalt(options.SaltLen)
+	return string(salt), hex.EncodeToString(h.key(rawPwd, salt, options))
+}
+
+func (h pbkdf2Hasher) Compare(rawPwd string, salt string, encodedPwd string, options *Options) bool {
+	return constantTimeEqual(encodedPwd, hex.EncodeToString(h.key(rawPwd, []byte(salt), options)))
+}
+
+// argon2idHasher hashes PINs with argon2id
+type argon2idHasher struct{}
+
+func (argon2idHasher) key(rawPwd string, salt []byte, options *Options) []byte {
+	return argon2.IDKey(
+		[]byte(rawPwd),
+		salt,
+		uint32(options.Iterations),
+		uint32(options.Memory),
+		uint8(options.Threads),
+		uint32(options.KeyLen),
+	)
+}
+
+func (h argon2idHasher) Hash(rawPwd string, options *Options) (string, string) {
+	if options.Iterations <= 0 || options.Threads <= 0 || options.KeyLen <= 0 {
+		return "", ""
+	}
+	salt := generateSalt(options.SaltLen)
+	return string(salt), hex.EncodeToString(h.key(rawPwd, salt, options))
+}
+
+func (h argon2idHasher) Compare(rawPwd string, salt string, encodedPwd string, options *Options) bool {
+	if options.Iterations <= 0 || options.Threads <= 0 || options.KeyLen <= 0 {
+		return false
+	}
+	return constantTimeEqual(encodedPwd, hex.EncodeToString(h.key(rawPwd, []byte(salt), options)))
+}
+
+// bcryptHasher hashes PINs with bcrypt. The salt is part of the encoded PIN, so no separate salt
+// is returned
+type bcryptHasher struct{}
+
+func (bcryptHasher) Hash(rawPwd string, options *Options) (string, string) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte(rawPwd), options.Cost)
+	if err != nil {
+		return "", ""
+	}
+	return "", string(encoded)
+}
+
+func (bcryptHasher) Compare(rawPwd string, salt string, encodedPwd string, options *Options) bool {
+	return bcrypt.CompareHashAndPassword([]byte(encodedPwd), []byte(rawPwd)) == nil
+}
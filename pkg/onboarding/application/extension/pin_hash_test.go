@@ -0,0 +1,122 @@
+package extension_test
+
+import (
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPINExtensionImpl_HashAlgorithms(t *testing.T) {
+	pin := extension.NewPINExtensionImpl()
+	tests := []struct {
+		name    string
+		options *extension.Options
+	}{
+		{name: "legacy defaults", options: nil},
+		{
+			name: "pbkdf2-sha512",
+			options: &extension.Options{
+				Algorithm:  extension.PBKDF2SHA512Algorithm,
+				SaltLen:    16,
+				Iterations: 100,
+				KeyLen:     32,
+			},
+		},
+		{
+			name: "argon2id",
+			options: &extension.Options{
+				Algorithm:  extension.Argon2idAlgorithm,
+				SaltLen:    16,
+				Iterations: 1,
+				Memory:     1024,
+				Threads:    1,
+				KeyLen:     32,
+			},
+		},
+		{name: "bcrypt", options: &extension.Options{Algorithm: extension.BcryptAlgorithm, Cost: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			salt, encoded := pin.EncryptPIN("2791", tt.options)
+			assert.NotEmpty(t, encoded)
+			assert.True(t, pin.ComparePIN("2791", salt, encoded, tt.options))
+			assert.False(t, pin.ComparePIN("2792", salt, encoded, tt.options))
+
+			// the stored scheme is enough to compare the PIN again
+			options := extension.PINHashOptions(extension.NewPINHashScheme(tt.options))
+			assert.True(t, pin.ComparePIN("2791", salt, encoded, options))
+		})
+	}
+
+	salt, encoded := pin.EncryptPIN("2791", &extension.Options{Algorithm: "unknown"})
+	assert.Empty(t, salt)
+	assert.Empty(t, encoded)
+	assert.False(t, pin.ComparePIN("2791", "", "", &extension.Options{Algorithm: "unknown"}))
+}
+
+type reversedPINHasher struct{}
+
+func (reversedPINHasher) Hash(rawPwd string, options *extension.Options) (string, string) {
+	return "", reverse(rawPwd)
+}
+
+func (reversedPINHasher) Compare(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+	return reverse(rawPwd) == encodedPwd
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func TestRegisterPINHasher(t *testing.T) {
+	extension.RegisterPINHasher("reversed", reversedPINHasher{})
+	options := &extension.Options{Algorithm: "reversed"}
+
+	pin := extension.NewPINExtensionImpl()
+	salt, encoded := pin.EncryptPIN("2791", options)
+	assert.Equal(t, "1972", encoded)
+	assert.True(t, pin.ComparePIN("2791", salt, encoded, options))
+}
+
+func TestCurrentPINHashOptions(t *testing.T) {
+	t.Setenv(extension.PINHashAlgorithmEnvVarName, "")
+	t.Setenv(extension.PINHashCostEnvVarName, "")
+	options := extension.CurrentPINHashOptions()
+	assert.Equal(t, extension.Argon2idAlgorithm, options.Algorithm)
+	assert.Equal(t, 2, options.Iterations)
+
+	t.Setenv(extension.PINHashCostEnvVarName, "3")
+	assert.Equal(t, 3, extension.CurrentPINHashOptions().Iterations)
+
+	t.Setenv(extension.PINHashAlgorithmEnvVarName, "bcrypt")
+	t.Setenv(extension.PINHashCostEnvVarName, "12")
+	options = extension.CurrentPINHashOptions()
+	assert.Equal(t, extension.BcryptAlgorithm, options.Algorithm)
+	assert.Equal(t, 12, options.Cost)
+
+	// a cost below the recommended minimum is raised
+	t.Setenv(extension.PINHashAlgorithmEnvVarName, "pbkdf2-sha512")
+	t.Setenv(extension.PINHashCostEnvVarName, "1000")
+	assert.Equal(t, 210000, extension.CurrentPINHashOptions().Iterations)
+}
+
+func TestPINNeedsRehash(t *testing.T) {
+	t.Setenv(extension.PINHashAlgorithmEnvVarName, "")
+	t.Setenv(extension.PINHashCostEnvVarName, "")
+	current := extension.CurrentPINHashOptions()
+
+	assert.True(t, extension.PINNeedsRehash(nil, current))
+	assert.False(t, extension.PINNeedsRehash(nil, nil))
+	assert.False(t, extension.PINNeedsRehash(extension.NewPINHashScheme(current), current))
+
+	weaker := extension.NewPINHashScheme(current)
+	weaker.Iterations = 1
+	assert.True(t, extension.PINNeedsRehash(weaker, current))
+	assert.True(t, extension.PINNeedsRehash(&domain.PINHashScheme{Algorithm: extension.BcryptAlgorithm, Cost: 10}, current))
+}
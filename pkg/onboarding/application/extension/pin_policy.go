@@ -0,0 +1,239 @@
+package extension
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/scalarutils"
+)
+
+const (
+	// ProPINMinLengthEnvVarName is the env var that sets the minimum length of the PINs of PRO users
+	ProPINMinLengthEnvVarName = "PRO_PIN_MIN_LENGTH"
+
+	// ConsumerPINMinLengthEnvVarName is the env var that sets the minimum length of the PINs of
+	// CONSUMER users
+	ConsumerPINMinLengthEnvVarName = "CONSUMER_PIN_MIN_LENGTH"
+)
+
+// CommonPINs are PINs that are chosen so often that they are among the first ones to be guessed
+var CommonPINs = []string{
+	"1234", "1111", "0000", "1212", "7777", "1004", "2000", "4444", "2222", "6969",
+	"9999", "3333", "5555", "6666", "1122", "1313", "8888", "4321", "2001", "1010",
+	"2580", "0852", "1230", "1998", "1999", "2468", "1357", "1984", "1969", "5683",
+	"12345", "54321", "11111", "00000", "13579", "24680",
+	"123456", "654321", "111111", "000000", "121212", "112233", "123123", "159753",
+	"147258", "696969", "123321", "666666", "888888", "252525",
+}
+
+// PINPolicyInput is a PIN together with the details of the user who chose it, so that the PIN
+// can be checked against what is known about the user
+type PINPolicyInput struct {
+	PIN string
+
+	// Flavours are the apps that the user signs in to. The strictest rules of the flavours apply
+	Flavours []feedlib.Flavour
+
+	DateOfBirth *scalarutils.Date
+	PhoneNumber string
+}
+
+// PINRule checks a PIN against a single requirement
+type PINRule interface {
+	Check(input PINPolicyInput) error
+}
+
+// PINRuleFunc adapts a function to a PINRule
+type PINRuleFunc func(input PINPolicyInput) error
+
+// Check calls the function with the input
+func (f PINRuleFunc) Check(input PINPolicyInput) error {
+	return f(input)
+}
+
+// PINPolicy decides whether a user can choose a PIN
+type PINPolicy interface {
+	ValidatePIN(input PINPolicyInput) error
+}
+
+// PINPolicyImpl rejects a PIN that breaks any of its rules
+type PINPolicyImpl struct {
+	rules []PINRule
+}
+
+// NewPINPolicyImpl returns a PIN policy that checks PINs against the provided rules, in order
+func NewPINPolicyImpl(rules ...PINRule) PINPolicy {
+	return &PINPolicyImpl{rules: rules}
+}
+
+// NewDefaultPINPolicy returns the PIN policy that is applied when users set, change or reset
+// their PIN
+func NewDefaultPINPolicy() PINPolicy {
+	return NewPINPolicyImpl(
+		PINDigitsRule(),
+		PINLengthRule(PINMinLengths()),
+		CommonPINRule(CommonPINs...),
+		RepeatedDigitsRule(),
+		SequentialDigitsRule(),
+		DateOfBirthRule(),
+		PhoneNumberTailRule(),
+	)
+}
+
+// ValidatePIN returns the error of the first rule that the PIN breaks
+func (p *PINPolicyImpl) ValidatePIN(input PINPolicyInput) error {
+	for _, rule := range p.rules {
+		if err := rule.Check(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PINMinLengths returns the minimum length of a PIN for every flavour. It is read from the
+// `PRO_PIN_MIN_LENGTH` and `CONSUMER_PIN_MIN_LENGTH` env vars. Lengths that are not set, or
+// that are not between 4 and 6, fall back to 4
+func PINMinLengths() map[feedlib.Flavour]int {
+	lengths := map[feedlib.Flavour]int{}
+	for flavour, envVarName := range map[feedlib.Flavour]string{
+		feedlib.FlavourPro:      ProPINMinLengthEnvVarName,
+		feedlib.FlavourConsumer: ConsumerPINMinLengthEnvVarName,
+	} {
+		length, err := strconv.Atoi(os.Getenv(envVarName))
+		if err != nil || length < minPinLength || length > maxPinLength {
+			length = minPinLength
+		}
+		lengths[flavour] = length
+	}
+	return lengths
+}
+
+// PINDigitsRule rejects a PIN that has characters other than digits
+func PINDigitsRule() PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		for _, r := range input.PIN {
+			if r < '0' || r > '9' {
+				return exceptions.ValidatePINDigitsError(fmt.Errorf("the PIN should only have digits"))
+			}
+		}
+		return nil
+	})
+}
+
+// PINLengthRule rejects a PIN that is longer than 6 digits or shorter than the minimum length of
+// the user's flavours. Users without a flavour get the CONSUMER minimum length
+func PINLengthRule(minLengths map[feedlib.Flavour]int) PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		flavours := input.Flavours
+		if len(flavours) == 0 {
+			flavours = []feedlib.Flavour{feedlib.FlavourConsumer}
+		}
+		minLength := minPinLength
+		for _, flavour := range flavours {
+			if minLengths[flavour] > minLength {
+				minLength = minLengths[flavour]
+			}
+		}
+		if len(input.PIN) < minLength || len(input.PIN) > maxPinLength {
+			return exceptions.ValidatePINLengthError(
+				fmt.Errorf("the PIN should have between %d and %d digits", minLength, maxPinLength),
+			)
+		}
+		return nil
+	})
+}
+
+// CommonPINRule rejects a PIN that is on a deny-list
+func CommonPINRule(pins ...string) PINRule {
+	denied := map[string]bool{}
+	for _, pin := range pins {
+		denied[pin] = true
+	}
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		if denied[input.PIN] {
+			return exceptions.WeakPINError(fmt.Errorf("the PIN is a commonly used PIN"))
+		}
+		return nil
+	})
+}
+
+// RepeatedDigitsRule rejects a PIN that repeats a shorter group of digits e.g 0000, 4545 or 123123
+func RepeatedDigitsRule() PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		pin := input.PIN
+		for size := 1; size <= len(pin)/2; size++ {
+			if len(pin)%size == 0 && strings.Repeat(pin[:size], len(pin)/size) == pin {
+				return exceptions.WeakPINError(fmt.Errorf("the PIN repeats the same digits"))
+			}
+		}
+		return nil
+	})
+}
+
+// SequentialDigitsRule rejects a PIN whose digits count up or down e.g 3456 or 9876
+func SequentialDigitsRule() PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		pin := input.PIN
+		if len(pin) < 2 {
+			return nil
+		}
+		ascending, descending := true, true
+		for i := 1; i < len(pin); i++ {
+			step := int(pin[i]) - int(pin[i-1])
+			ascending = ascending && step == 1
+			descending = descending && step == -1
+		}
+		if ascending || descending {
+			return exceptions.WeakPINError(fmt.Errorf("the PIN is a sequence of digits"))
+		}
+		return nil
+	})
+}
+
+// DateOfBirthRule rejects a PIN that can be derived from the user's date of birth, such as their
+// birth year or their birthday
+func DateOfBirthRule() PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		dob := input.DateOfBirth
+		if dob == nil || dob.Year == 0 {
+			return nil
+		}
+		year := fmt.Sprintf("%04d", dob.Year)
+		day := fmt.Sprintf("%02d", dob.Day)
+		month := fmt.Sprintf("%02d", dob.Month)
+		shortYear := year[len(year)-2:]
+		derived := []string{
+			year,
+			day + month,
+			month + day,
+			month + shortYear,
+			day + month + shortYear,
+			month + day + shortYear,
+			shortYear + month + day,
+			month + year,
+		}
+		for _, pin := range derived {
+			if input.PIN == pin {
+				return exceptions.WeakPINError(fmt.Errorf("the PIN matches the date of birth"))
+			}
+		}
+		return nil
+	})
+}
+
+// PhoneNumberTailRule rejects a PIN that is the last digits of the user's phone number
+func PhoneNumberTailRule() PINRule {
+	return PINRuleFunc(func(input PINPolicyInput) error {
+		if input.PIN == "" || len(input.PhoneNumber) < len(input.PIN) {
+			return nil
+		}
+		if strings.HasSuffix(input.PhoneNumber, input.PIN) {
+			return exceptions.WeakPINError(fmt.Errorf("the PIN matches the end of the phone number"))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,88 @@
+package extension_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/scalarutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPINPolicyImpl_ValidatePIN(t *testing.T) {
+	policy := extension.NewPINPolicyImpl(
+		extension.PINDigitsRule(),
+		extension.PINLengthRule(map[feedlib.Flavour]int{
+			feedlib.FlavourPro:      6,
+			feedlib.FlavourConsumer: 4,
+		}),
+		extension.CommonPINRule(extension.CommonPINs...),
+		extension.RepeatedDigitsRule(),
+		extension.SequentialDigitsRule(),
+		extension.DateOfBirthRule(),
+		extension.PhoneNumberTailRule(),
+	)
+	dob := &scalarutils.Date{Year: 1987, Month: 3, Day: 9}
+
+	tests := []struct {
+		name    string
+		input   extension.PINPolicyInput
+		wantErr bool
+	}{
+		{name: "strong consumer PIN", input: extension.PINPolicyInput{PIN: "2791"}, wantErr: false},
+		{name: "strong PRO PIN", input: extension.PINPolicyInput{PIN: "279164", Flavours: []feedlib.Flavour{feedlib.FlavourPro}}, wantErr: false},
+		{name: "not digits", input: extension.PINPolicyInput{PIN: "27a1"}, wantErr: true},
+		{name: "too short", input: extension.PINPolicyInput{PIN: "279"}, wantErr: true},
+		{name: "too long", input: extension.PINPolicyInput{PIN: "2791648"}, wantErr: true},
+		{
+			name:    "too short for a PRO user",
+			input:   extension.PINPolicyInput{PIN: "2791", Flavours: []feedlib.Flavour{feedlib.FlavourConsumer, feedlib.FlavourPro}},
+			wantErr: true,
+		},
+		{name: "common PIN", input: extension.PINPolicyInput{PIN: "2580"}, wantErr: true},
+		{name: "repeated digit", input: extension.PINPolicyInput{PIN: "77777"}, wantErr: true},
+		{name: "repeated group", input: extension.PINPolicyInput{PIN: "4545"}, wantErr: true},
+		{name: "ascending sequence", input: extension.PINPolicyInput{PIN: "3456"}, wantErr: true},
+		{name: "descending sequence", input: extension.PINPolicyInput{PIN: "98765"}, wantErr: true},
+		{name: "birth year", input: extension.PINPolicyInput{PIN: "1987", DateOfBirth: dob}, wantErr: true},
+		{name: "birthday", input: extension.PINPolicyInput{PIN: "0903", DateOfBirth: dob}, wantErr: true},
+		{name: "full date of birth", input: extension.PINPolicyInput{PIN: "870309", DateOfBirth: dob}, wantErr: true},
+		{name: "unrelated to the date of birth", input: extension.PINPolicyInput{PIN: "2791", DateOfBirth: dob}, wantErr: false},
+		{name: "phone number tail", input: extension.PINPolicyInput{PIN: "3344", PhoneNumber: "+254711223344"}, wantErr: true},
+		{name: "unrelated to the phone number", input: extension.PINPolicyInput{PIN: "2791", PhoneNumber: "+254711223344"}, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.ValidatePIN(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PINPolicyImpl.ValidatePIN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPINPolicyImpl_CustomRules(t *testing.T) {
+	noNines := extension.PINRuleFunc(func(input extension.PINPolicyInput) error {
+		for _, r := range input.PIN {
+			if r == '9' {
+				return fmt.Errorf("the PIN has a nine")
+			}
+		}
+		return nil
+	})
+	policy := extension.NewPINPolicyImpl(noNines)
+
+	assert.Nil(t, policy.ValidatePIN(extension.PINPolicyInput{PIN: "1234"}))
+	assert.NotNil(t, policy.ValidatePIN(extension.PINPolicyInput{PIN: "2791"}))
+	assert.Nil(t, extension.NewPINPolicyImpl().ValidatePIN(extension.PINPolicyInput{PIN: "0000"}))
+}
+
+func TestPINMinLengths(t *testing.T) {
+	t.Setenv(extension.ProPINMinLengthEnvVarName, "6")
+	t.Setenv(extension.ConsumerPINMinLengthEnvVarName, "9")
+
+	lengths := extension.PINMinLengths()
+	assert.Equal(t, 6, lengths[feedlib.FlavourPro])
+	assert.Equal(t, 4, lengths[feedlib.FlavourConsumer])
+}
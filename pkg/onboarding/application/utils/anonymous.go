@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+// anonymousUIDPrefix marks the UIDs of the auth users that are created for anonymous logins
+const anonymousUIDPrefix = "anonymous" + socialUIDSeparator
+
+// AnonymousUID returns a new UID for the auth user of an anonymous login. Every anonymous login
+// gets its own auth user so that it can later be upgraded into an account
+func AnonymousUID() string {
+	return anonymousUIDPrefix + uuid.New().String()
+}
+
+// IsAnonymousUID checks whether a UID was returned by AnonymousUID
+func IsAnonymousUID(uid string) bool {
+	return strings.HasPrefix(uid, anonymousUIDPrefix) && len(uid) > len(anonymousUIDPrefix)
+}
+
+// UserUpgradedEvent returns the `user.upgraded` event of a profile that was created for the
+// anonymous user with the provided UID
+func UserUpgradedEvent(uid string, profile *profileutils.UserProfile) (*domain.OutboxEvent, error) {
+	return NewOutboxEvent(
+		domain.EventTypeUserUpgraded,
+		profile.ID,
+		domain.UserUpgradedEvent{ProfileID: profile.ID, UID: uid, PrimaryPhone: profile.PrimaryPhone},
+	)
+}
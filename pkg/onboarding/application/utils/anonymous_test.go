@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymousUID(t *testing.T) {
+	uid := utils.AnonymousUID()
+	assert.True(t, utils.IsAnonymousUID(uid))
+	assert.NotEqual(t, uid, utils.AnonymousUID())
+
+	_, ok := utils.SocialLoginProvider(uid)
+	assert.False(t, ok)
+
+	for _, uid := range []string{"", "uid-1", "anonymous:", "social_google:110248495921238986420"} {
+		assert.False(t, utils.IsAnonymousUID(uid), uid)
+	}
+}
+
+func TestUserUpgradedEvent(t *testing.T) {
+	phone := "+254711223344"
+	profile := &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone}
+
+	event, err := utils.UserUpgradedEvent("anonymous:uid-1", profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.EventTypeUserUpgraded, event.Type)
+	assert.Equal(t, "profile-1", event.ProfileID)
+
+	envelope := domain.Event{}
+	assert.Nil(t, json.Unmarshal([]byte(event.Payload), &envelope))
+	data := domain.UserUpgradedEvent{}
+	assert.Nil(t, json.Unmarshal(envelope.Data, &data))
+	assert.Equal(t, domain.UserUpgradedEvent{ProfileID: "profile-1", UID: "anonymous:uid-1", PrimaryPhone: &phone}, data)
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+const (
+	// KeyEncryptionKeyEnvVarName is the env var with the key that the signing keys and the TOTP
+	// secrets are encrypted with at rest. It is a base64 encoded 256 bit AES key
+	KeyEncryptionKeyEnvVarName = "SIGNING_KEY_ENCRYPTION_KEY"
+
+	// keyEncryptionKeySize is the size in bytes of the key encryption key and of the data keys
+	// that each value is encrypted with
+	keyEncryptionKeySize = 32
+)
+
+// KeyEncryptionKey reads the key that the secrets are encrypted with from the
+// `SIGNING_KEY_ENCRYPTION_KEY` env var. A random key is used when the in memory repository runs
+// without one
+func KeyEncryptionKey() ([]byte, error) {
+	encoded := strings.TrimSpace(os.Getenv(KeyEncryptionKeyEnvVarName))
+	if encoded == "" {
+		if os.Getenv(domain.Repo) == domain.MemoryRepository {
+			return NewKeyEncryptionKey()
+		}
+		return nil, fmt.Errorf("%s is not set", KeyEncryptionKeyEnvVarName)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not base64 encoded: %w", KeyEncryptionKeyEnvVarName, err)
+	}
+	if len(key) != keyEncryptionKeySize {
+		return nil, fmt.Errorf(
+			"%s should be %d bytes long, got %d",
+			KeyEncryptionKeyEnvVarName,
+			keyEncryptionKeySize,
+			len(key),
+		)
+	}
+	return key, nil
+}
+
+// NewKeyEncryptionKey generates a random key to encrypt the secrets with
+func NewKeyEncryptionKey() ([]byte, error) {
+	return randomKey()
+}
+
+// SealEnvelope encrypts a value with a new data key, which is in turn encrypted with the key
+// encryption key. Both are bound to the additional data, e.g. the ID of the record that they are
+// stored in, so that they can't be swapped with those of another record
+func SealEnvelope(
+	keyEncryptionKey []byte,
+	plaintext []byte,
+	additionalData string,
+) (ciphertext string, encryptedDataKey string, err error) {
+	dataKey, err := randomKey()
+	if err != nil {
+		return "", "", err
+	}
+	ciphertext, err = seal(dataKey, plaintext, additionalData)
+	if err != nil {
+		return "", "", err
+	}
+	encryptedDataKey, err = seal(keyEncryptionKey, dataKey, additionalData)
+	if err != nil {
+		return "", "", err
+	}
+	return ciphertext, encryptedDataKey, nil
+}
+
+// OpenEnvelope decrypts a value encrypted by SealEnvelope
+func OpenEnvelope(
+	keyEncryptionKey []byte,
+	ciphertext string,
+	encryptedDataKey string,
+	additionalData string,
+) ([]byte, error) {
+	dataKey, err := open(keyEncryptionKey, encryptedDataKey, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the data key: %w", err)
+	}
+	plaintext, err := open(dataKey, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// randomKey generates a random 256 bit AES key
+func randomKey() ([]byte, error) {
+	key := make([]byte, keyEncryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("unable to generate an encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// seal encrypts a value with AES-GCM. The nonce is prepended to the base64 encoded ciphertext
+func seal(key []byte, plaintext []byte, additionalData string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("unable to generate a nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(additionalData))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a value encrypted by seal
+func open(key []byte, encoded string, additionalData string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("the ciphertext is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(additionalData))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
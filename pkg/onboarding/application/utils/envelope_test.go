@@ -0,0 +1,71 @@
+package utils_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealEnvelope(t *testing.T) {
+	kek, err := utils.NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	ciphertext, encryptedDataKey, err := utils.SealEnvelope(kek, []byte("a secret"), "record-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	opened, err := utils.OpenEnvelope(kek, ciphertext, encryptedDataKey, "record-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "a secret", string(opened))
+
+	// each value gets its own data key
+	_, otherDataKey, err := utils.SealEnvelope(kek, []byte("a secret"), "record-1")
+	assert.Nil(t, err)
+	assert.NotEqual(t, encryptedDataKey, otherDataKey)
+
+	_, err = utils.OpenEnvelope(kek, ciphertext, encryptedDataKey, "record-2")
+	assert.NotNil(t, err)
+	_, err = utils.OpenEnvelope(kek, ciphertext, otherDataKey, "record-1")
+	assert.NotNil(t, err)
+	_, err = utils.OpenEnvelope(kek, "not base64", encryptedDataKey, "record-1")
+	assert.NotNil(t, err)
+	_, err = utils.OpenEnvelope([]byte("too-short"), ciphertext, encryptedDataKey, "record-1")
+	assert.NotNil(t, err)
+}
+
+func TestKeyEncryptionKey(t *testing.T) {
+	kek, err := utils.NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	t.Setenv(domain.Repo, domain.FirebaseRepository)
+	t.Setenv(utils.KeyEncryptionKeyEnvVarName, "")
+	_, err = utils.KeyEncryptionKey()
+	assert.NotNil(t, err)
+
+	t.Setenv(utils.KeyEncryptionKeyEnvVarName, "not base64")
+	_, err = utils.KeyEncryptionKey()
+	assert.NotNil(t, err)
+
+	t.Setenv(utils.KeyEncryptionKeyEnvVarName, base64.StdEncoding.EncodeToString([]byte("too-short")))
+	_, err = utils.KeyEncryptionKey()
+	assert.NotNil(t, err)
+
+	t.Setenv(utils.KeyEncryptionKeyEnvVarName, base64.StdEncoding.EncodeToString(kek))
+	found, err := utils.KeyEncryptionKey()
+	assert.Nil(t, err)
+	assert.Equal(t, kek, found)
+
+	// the in memory repository runs without one
+	t.Setenv(domain.Repo, domain.MemoryRepository)
+	t.Setenv(utils.KeyEncryptionKeyEnvVarName, "")
+	found, err = utils.KeyEncryptionKey()
+	assert.Nil(t, err)
+	assert.Len(t, found, len(kek))
+}
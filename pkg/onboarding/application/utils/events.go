@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// NewOutboxEvent creates an outbox event with the data of a domain event wrapped in the
+// envelope that is published
+func NewOutboxEvent(eventType domain.EventType, profileID string, data interface{}) (*domain.OutboxEvent, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s event data: %w", eventType, err)
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	event := domain.Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		Version:    domain.EventSchemaVersion,
+		OccurredAt: now,
+		Data:       encoded,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s event: %w", eventType, err)
+	}
+
+	return &domain.OutboxEvent{
+		ID:        event.ID,
+		Type:      eventType,
+		ProfileID: profileID,
+		Payload:   string(payload),
+		Created:   now,
+	}, nil
+}
+
+// ProfileEvents returns the domain events of a write of a user profile. It compares the
+// profile before and after the write, a nil `before` being a new profile. A new profile
+// only publishes `user.created` and the roles it was created with
+func ProfileEvents(
+	before *profileutils.UserProfile,
+	after *profileutils.UserProfile,
+) ([]*domain.OutboxEvent, error) {
+	if after == nil {
+		return nil, nil
+	}
+
+	type eventData struct {
+		eventType domain.EventType
+		data      interface{}
+	}
+	changes := []eventData{}
+
+	created := before == nil
+	if created {
+		uid := ""
+		if len(after.VerifiedUIDS) > 0 {
+			uid = after.VerifiedUIDS[0]
+		}
+		changes = append(changes, eventData{
+			eventType: domain.EventTypeUserCreated,
+			data: domain.UserCreatedEvent{
+				ProfileID:    after.ID,
+				UID:          uid,
+				PrimaryPhone: after.PrimaryPhone,
+				UserName:     after.UserName,
+			},
+		})
+		before = &profileutils.UserProfile{}
+	}
+
+	if !created && before.Suspended != after.Suspended {
+		changes = append(changes, eventData{
+			eventType: domain.EventTypeUserSuspended,
+			data: domain.UserSuspendedEvent{
+				ProfileID: after.ID,
+				Suspended: after.Suspended,
+			},
+		})
+	}
+
+	for _, roleID := range after.Roles {
+		if !converterandformatter.StringSliceContains(before.Roles, roleID) {
+			changes = append(changes, eventData{
+				eventType: domain.EventTypeRoleAssigned,
+				data:      domain.RoleAssignedEvent{ProfileID: after.ID, RoleID: roleID},
+			})
+		}
+	}
+	for _, roleID := range before.Roles {
+		if !converterandformatter.StringSliceContains(after.Roles, roleID) {
+			changes = append(changes, eventData{
+				eventType: domain.EventTypeRoleRevoked,
+				data:      domain.RoleRevokedEvent{ProfileID: after.ID, RoleID: roleID},
+			})
+		}
+	}
+
+	if !created && contactsChanged(before, after) {
+		changes = append(changes, eventData{
+			eventType: domain.EventTypeContactChanged,
+			data: domain.ContactChangedEvent{
+				ProfileID:               after.ID,
+				PrimaryPhone:            after.PrimaryPhone,
+				PrimaryEmailAddress:     after.PrimaryEmailAddress,
+				SecondaryPhoneNumbers:   after.SecondaryPhoneNumbers,
+				SecondaryEmailAddresses: after.SecondaryEmailAddresses,
+			},
+		})
+	}
+
+	events := []*domain.OutboxEvent{}
+	for _, change := range changes {
+		event, err := NewOutboxEvent(change.eventType, after.ID, change.data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// PINChangedEvent returns the `pin.changed` event of a write of a user's PIN
+func PINChangedEvent(pin *domain.PIN) (*domain.OutboxEvent, error) {
+	return NewOutboxEvent(
+		domain.EventTypePINChanged,
+		pin.ProfileID,
+		domain.PINChangedEvent{ProfileID: pin.ProfileID, IsOTP: pin.IsOTP},
+	)
+}
+
+func contactsChanged(before *profileutils.UserProfile, after *profileutils.UserProfile) bool {
+	return !stringPointersEqual(before.PrimaryPhone, after.PrimaryPhone) ||
+		!stringPointersEqual(before.PrimaryEmailAddress, after.PrimaryEmailAddress) ||
+		!stringSlicesEqual(before.SecondaryPhoneNumbers, after.SecondaryPhoneNumbers) ||
+		!stringSlicesEqual(before.SecondaryEmailAddresses, after.SecondaryEmailAddresses)
+}
+
+func stringPointersEqual(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func stringSlicesEqual(a []string, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
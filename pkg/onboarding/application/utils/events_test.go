@@ -0,0 +1,124 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOutboxEvent(t *testing.T) {
+	event, err := utils.NewOutboxEvent(
+		domain.EventTypeRoleAssigned,
+		"profile-1",
+		domain.RoleAssignedEvent{ProfileID: "profile-1", RoleID: "role-1"},
+	)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.EventTypeRoleAssigned, event.Type)
+	assert.Equal(t, "profile-1", event.ProfileID)
+	assert.Nil(t, event.Published)
+
+	envelope := domain.Event{}
+	if err := json.Unmarshal([]byte(event.Payload), &envelope); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, event.ID, envelope.ID)
+	assert.Equal(t, domain.EventSchemaVersion, envelope.Version)
+
+	data := domain.RoleAssignedEvent{}
+	if err := json.Unmarshal(envelope.Data, &data); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "role-1", data.RoleID)
+
+	_, err = utils.NewOutboxEvent(domain.EventTypeRoleAssigned, "profile-1", make(chan int))
+	assert.NotNil(t, err)
+}
+
+func TestProfileEvents(t *testing.T) {
+	phone := "+254711223344"
+	newPhone := "+254722334455"
+	before := &profileutils.UserProfile{
+		ID:           "profile-1",
+		VerifiedUIDS: []string{"uid-1"},
+		PrimaryPhone: &phone,
+		Roles:        []string{"role-1", "role-2"},
+	}
+
+	tests := []struct {
+		name   string
+		before *profileutils.UserProfile
+		after  *profileutils.UserProfile
+		want   []domain.EventType
+	}{
+		{
+			name:   "Happy case:new profile",
+			before: nil,
+			after:  before,
+			want: []domain.EventType{
+				domain.EventTypeUserCreated,
+				domain.EventTypeRoleAssigned,
+				domain.EventTypeRoleAssigned,
+			},
+		},
+		{
+			name:   "Happy case:suspended with changed roles",
+			before: before,
+			after: &profileutils.UserProfile{
+				ID:           "profile-1",
+				VerifiedUIDS: []string{"uid-1"},
+				PrimaryPhone: &phone,
+				Roles:        []string{"role-2", "role-3"},
+				Suspended:    true,
+			},
+			want: []domain.EventType{
+				domain.EventTypeUserSuspended,
+				domain.EventTypeRoleAssigned,
+				domain.EventTypeRoleRevoked,
+			},
+		},
+		{
+			name:   "Happy case:changed contacts",
+			before: before,
+			after: &profileutils.UserProfile{
+				ID:                    "profile-1",
+				VerifiedUIDS:          []string{"uid-1"},
+				PrimaryPhone:          &newPhone,
+				SecondaryPhoneNumbers: []string{phone},
+				Roles:                 []string{"role-1", "role-2"},
+			},
+			want: []domain.EventType{domain.EventTypeContactChanged},
+		},
+		{
+			name:   "Happy case:no published changes",
+			before: before,
+			after: &profileutils.UserProfile{
+				ID:                    "profile-1",
+				VerifiedUIDS:          []string{"uid-1", "uid-2"},
+				PrimaryPhone:          &phone,
+				SecondaryPhoneNumbers: []string{},
+				Roles:                 []string{"role-1", "role-2"},
+			},
+			want: []domain.EventType{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := utils.ProfileEvents(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("error not expected got %v", err)
+			}
+			got := []domain.EventType{}
+			for _, event := range events {
+				assert.Equal(t, "profile-1", event.ProfileID)
+				got = append(got, event.Type)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -25,6 +25,84 @@ func CheckIdentifierExists(profile *profileutils.UserProfile, UID string) bool {
 	return converterandformatter.StringSliceContains(foundVerifiedUIDs, UID)
 }
 
+// AddLoginUID adds the verified identifier and the verified UID of a login UID to a profile that
+// doesn't have them. It reports whether the profile has changed
+func AddLoginUID(profile *profileutils.UserProfile, identifier profileutils.VerifiedIdentifier) bool {
+	added := false
+	if !CheckIdentifierExists(profile, identifier.UID) {
+		profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, identifier)
+		added = true
+	}
+	if !converterandformatter.StringSliceContains(profile.VerifiedUIDS, identifier.UID) {
+		profile.VerifiedUIDS = append(profile.VerifiedUIDS, identifier.UID)
+		added = true
+	}
+	return added
+}
+
+// RemoveLoginUID removes the verified identifier and the verified UID of a login UID from a
+// profile. It reports whether the profile had either of them
+func RemoveLoginUID(profile *profileutils.UserProfile, UID string) bool {
+	removed := false
+	identifiers := []profileutils.VerifiedIdentifier{}
+	for _, identifier := range profile.VerifiedIdentifiers {
+		if identifier.UID == UID {
+			removed = true
+			continue
+		}
+		identifiers = append(identifiers, identifier)
+	}
+	uids := []string{}
+	for _, uid := range profile.VerifiedUIDS {
+		if uid == UID {
+			removed = true
+			continue
+		}
+		uids = append(uids, uid)
+	}
+	profile.VerifiedIdentifiers = identifiers
+	profile.VerifiedUIDS = uids
+	return removed
+}
+
+// ReplacePrimaryPhoneNumber makes the phone number the primary phone number of a profile. The
+// previous primary phone number is kept as a secondary phone number
+func ReplacePrimaryPhoneNumber(profile *profileutils.UserProfile, phoneNumber string) {
+	profile.SecondaryPhoneNumbers = replacePrimary(
+		profile.PrimaryPhone,
+		profile.SecondaryPhoneNumbers,
+		phoneNumber,
+	)
+	profile.PrimaryPhone = &phoneNumber
+}
+
+// ReplacePrimaryEmailAddress makes the email address the primary email address of a profile. The
+// previous primary email address is kept as a secondary email address
+func ReplacePrimaryEmailAddress(profile *profileutils.UserProfile, emailAddress string) {
+	profile.SecondaryEmailAddresses = replacePrimary(
+		profile.PrimaryEmailAddress,
+		profile.SecondaryEmailAddresses,
+		emailAddress,
+	)
+	profile.PrimaryEmailAddress = &emailAddress
+}
+
+// replacePrimary returns the secondary contacts without the new primary contact and with the
+// previous primary contact
+func replacePrimary(previous *string, secondaries []string, primary string) []string {
+	result := []string{}
+	for _, secondary := range secondaries {
+		if secondary == primary || (previous != nil && secondary == *previous) {
+			continue
+		}
+		result = append(result, secondary)
+	}
+	if previous != nil && *previous != primary {
+		result = append(result, *previous)
+	}
+	return result
+}
+
 // IsFavNavAction checks if user has book marked the provided navaction
 func IsFavNavAction(u *profileutils.UserProfile, title string) bool {
 	if len(u.FavNavActions) == 0 {
@@ -77,6 +77,90 @@ func TestFindNumber(t *testing.T) {
 	}
 }
 
+func TestReplacePrimaryPhoneNumber(t *testing.T) {
+	primary := "+254700000001"
+	tests := []struct {
+		name          string
+		profile       *profileutils.UserProfile
+		phoneNumber   string
+		wantSecondary []string
+	}{
+		{
+			name: "happy case - the previous primary becomes a secondary",
+			profile: &profileutils.UserProfile{
+				PrimaryPhone:          &primary,
+				SecondaryPhoneNumbers: []string{"+254700000002"},
+			},
+			phoneNumber:   "+254700000003",
+			wantSecondary: []string{"+254700000002", primary},
+		},
+		{
+			name: "happy case - a secondary becomes the primary",
+			profile: &profileutils.UserProfile{
+				PrimaryPhone:          &primary,
+				SecondaryPhoneNumbers: []string{"+254700000002", "+254700000003"},
+			},
+			phoneNumber:   "+254700000002",
+			wantSecondary: []string{"+254700000003", primary},
+		},
+		{
+			name: "happy case - the primary is set again",
+			profile: &profileutils.UserProfile{
+				PrimaryPhone:          &primary,
+				SecondaryPhoneNumbers: []string{"+254700000002"},
+			},
+			phoneNumber:   primary,
+			wantSecondary: []string{"+254700000002"},
+		},
+		{
+			name:          "happy case - no previous primary",
+			profile:       &profileutils.UserProfile{},
+			phoneNumber:   primary,
+			wantSecondary: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.ReplacePrimaryPhoneNumber(tt.profile, tt.phoneNumber)
+			assert.Equal(t, tt.phoneNumber, *tt.profile.PrimaryPhone)
+			assert.Equal(t, tt.wantSecondary, tt.profile.SecondaryPhoneNumbers)
+		})
+	}
+}
+
+func TestReplacePrimaryEmailAddress(t *testing.T) {
+	primary := "primary@example.com"
+	tests := []struct {
+		name          string
+		profile       *profileutils.UserProfile
+		emailAddress  string
+		wantSecondary []string
+	}{
+		{
+			name: "happy case - the previous primary becomes a secondary",
+			profile: &profileutils.UserProfile{
+				PrimaryEmailAddress:     &primary,
+				SecondaryEmailAddresses: []string{"new@example.com", "other@example.com"},
+			},
+			emailAddress:  "new@example.com",
+			wantSecondary: []string{"other@example.com", primary},
+		},
+		{
+			name:          "happy case - no previous primary",
+			profile:       &profileutils.UserProfile{},
+			emailAddress:  primary,
+			wantSecondary: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.ReplacePrimaryEmailAddress(tt.profile, tt.emailAddress)
+			assert.Equal(t, tt.emailAddress, *tt.profile.PrimaryEmailAddress)
+			assert.Equal(t, tt.wantSecondary, tt.profile.SecondaryEmailAddresses)
+		})
+	}
+}
+
 func TestUniquePermissionsArray(t *testing.T) {
 	duplicated := []profileutils.PermissionType{}
 	duplicated = append(duplicated, profileutils.DefaultAdminPermissions...)
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// NormalizeIdentifier returns the form of an identifier that its reservation is keyed by.
+// Phone numbers are converted to the international format while email addresses and
+// usernames are compared regardless of their case. Social UIDs are kept as they are since
+// the subjects that providers issue are case sensitive
+func NormalizeIdentifier(kind domain.IdentifierKind, identifier string) string {
+	identifier = strings.TrimSpace(identifier)
+	switch kind {
+	case domain.IdentifierKindPhone:
+		normalized, err := converterandformatter.NormalizeMSISDN(identifier)
+		if err != nil {
+			// the stored value is still reserved as is so that it can not be claimed twice
+			return identifier
+		}
+		return *normalized
+	case domain.IdentifierKindSocial:
+		return identifier
+	default:
+		return strings.ToLower(identifier)
+	}
+}
+
+// IdentifierReservationID returns the id of the reservation of an identifier. Every
+// backend keys reservations by this id which makes a second reservation of the same identifier fail
+func IdentifierReservationID(kind domain.IdentifierKind, identifier string) string {
+	sum := sha256.Sum256([]byte(NormalizeIdentifier(kind, identifier)))
+	return fmt.Sprintf("%s-%x", strings.ToLower(string(kind)), sum)
+}
+
+// NewIdentifierReservation creates the reservation of an identifier for a user profile
+func NewIdentifierReservation(
+	kind domain.IdentifierKind,
+	identifier string,
+	profileID string,
+) *domain.IdentifierReservation {
+	return &domain.IdentifierReservation{
+		ID:         IdentifierReservationID(kind, identifier),
+		Kind:       kind,
+		Identifier: NormalizeIdentifier(kind, identifier),
+		ProfileID:  profileID,
+		Created:    time.Now().In(pubsubtools.TimeLocation),
+	}
+}
+
+// ProfileIdentifierReservations returns the reservations of the phone numbers, email addresses,
+// username and linked social accounts of a user profile ordered by their id
+func ProfileIdentifierReservations(profile *profileutils.UserProfile) []*domain.IdentifierReservation {
+	if profile == nil {
+		return nil
+	}
+
+	reservations := map[string]*domain.IdentifierReservation{}
+	add := func(kind domain.IdentifierKind, identifiers ...string) {
+		for _, identifier := range identifiers {
+			if strings.TrimSpace(identifier) == "" {
+				continue
+			}
+			reservation := NewIdentifierReservation(kind, identifier, profile.ID)
+			reservations[reservation.ID] = reservation
+		}
+	}
+	if profile.PrimaryPhone != nil {
+		add(domain.IdentifierKindPhone, *profile.PrimaryPhone)
+	}
+	add(domain.IdentifierKindPhone, profile.SecondaryPhoneNumbers...)
+	if profile.PrimaryEmailAddress != nil {
+		add(domain.IdentifierKindEmail, *profile.PrimaryEmailAddress)
+	}
+	add(domain.IdentifierKindEmail, profile.SecondaryEmailAddresses...)
+	if profile.UserName != nil {
+		add(domain.IdentifierKindUsername, *profile.UserName)
+	}
+	for _, identifier := range profile.VerifiedIdentifiers {
+		if _, ok := SocialLoginProvider(identifier.UID); ok {
+			add(domain.IdentifierKindSocial, identifier.UID)
+		}
+	}
+
+	ids := []string{}
+	for id := range reservations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sorted := []*domain.IdentifierReservation{}
+	for _, id := range ids {
+		sorted = append(sorted, reservations[id])
+	}
+	return sorted
+}
+
+// IdentifierReservationChanges compares the identifiers of a user profile before and after
+// a write. It returns the reservations that the write should add and the ones it frees.
+// A nil `before` is a new profile while a nil `after` is a profile that is being removed
+func IdentifierReservationChanges(
+	before *profileutils.UserProfile,
+	after *profileutils.UserProfile,
+) (reserve []*domain.IdentifierReservation, release []*domain.IdentifierReservation) {
+	previous := ProfileIdentifierReservations(before)
+	current := ProfileIdentifierReservations(after)
+
+	previousIDs := map[string]bool{}
+	for _, reservation := range previous {
+		previousIDs[reservation.ID] = true
+	}
+	currentIDs := map[string]bool{}
+	for _, reservation := range current {
+		currentIDs[reservation.ID] = true
+		if !previousIDs[reservation.ID] {
+			reserve = append(reserve, reservation)
+		}
+	}
+	for _, reservation := range previous {
+		if !currentIDs[reservation.ID] {
+			release = append(release, reservation)
+		}
+	}
+	return reserve, release
+}
+
+// IdentifierInUseError returns the error for an identifier that is reserved by another user profile
+func IdentifierInUseError(kind domain.IdentifierKind) error {
+	switch kind {
+	case domain.IdentifierKindEmail:
+		return exceptions.CheckEmailExistError()
+	case domain.IdentifierKindUsername:
+		return exceptions.UsernameInUseError()
+	case domain.IdentifierKindSocial:
+		return exceptions.SocialAccountInUseError()
+	default:
+		return exceptions.CheckPhoneNumberExistError()
+	}
+}
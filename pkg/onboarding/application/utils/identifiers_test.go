@@ -0,0 +1,118 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       domain.IdentifierKind
+		identifier string
+		want       string
+	}{
+		{
+			name:       "Happy case:local phone number",
+			kind:       domain.IdentifierKindPhone,
+			identifier: "0711223344",
+			want:       "+254711223344",
+		},
+		{
+			name:       "Happy case:international phone number",
+			kind:       domain.IdentifierKindPhone,
+			identifier: " +254711223344 ",
+			want:       "+254711223344",
+		},
+		{
+			name:       "Happy case:email address",
+			kind:       domain.IdentifierKindEmail,
+			identifier: "Jane.Doe@Example.com",
+			want:       "jane.doe@example.com",
+		},
+		{
+			name:       "Happy case:username",
+			kind:       domain.IdentifierKindUsername,
+			identifier: "JaneDoe ",
+			want:       "janedoe",
+		},
+		{
+			name:       "Happy case:social UID keeps its case",
+			kind:       domain.IdentifierKindSocial,
+			identifier: "social_apple:001234.AbCd",
+			want:       "social_apple:001234.AbCd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.NormalizeIdentifier(tt.kind, tt.identifier); got != tt.want {
+				t.Errorf("NormalizeIdentifier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentifierReservationChanges(t *testing.T) {
+	phone := "+254711223344"
+	email := "jane@example.com"
+	userName := "jane"
+	before := &profileutils.UserProfile{
+		ID:                    "profile-1",
+		PrimaryPhone:          &phone,
+		SecondaryPhoneNumbers: []string{"+254722334455"},
+		UserName:              &userName,
+	}
+	after := &profileutils.UserProfile{
+		ID:                    "profile-1",
+		PrimaryPhone:          &phone,
+		SecondaryPhoneNumbers: []string{"0733445566"},
+		PrimaryEmailAddress:   &email,
+		UserName:              &userName,
+	}
+
+	reserve, release := utils.IdentifierReservationChanges(before, after)
+	assert.ElementsMatch(t, []string{
+		utils.IdentifierReservationID(domain.IdentifierKindPhone, "+254733445566"),
+		utils.IdentifierReservationID(domain.IdentifierKindEmail, email),
+	}, reservationIDs(reserve))
+	assert.Equal(t, []string{
+		utils.IdentifierReservationID(domain.IdentifierKindPhone, "+254722334455"),
+	}, reservationIDs(release))
+	for _, reservation := range reserve {
+		assert.Equal(t, "profile-1", reservation.ProfileID)
+	}
+
+	// a new profile reserves all of its identifiers
+	reserve, release = utils.IdentifierReservationChanges(nil, before)
+	assert.Len(t, reserve, 3)
+	assert.Empty(t, release)
+
+	// a removed profile releases all of its identifiers
+	reserve, release = utils.IdentifierReservationChanges(before, nil)
+	assert.Empty(t, reserve)
+	assert.Len(t, release, 3)
+
+	// linking a social account reserves its UID but not the UIDs of other login providers
+	linked := *before
+	linked.VerifiedIdentifiers = []profileutils.VerifiedIdentifier{
+		{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone},
+		{UID: "social_google:110248495921238986420", LoginProvider: profileutils.LoginProviderTypeSocialGoogle},
+	}
+	reserve, release = utils.IdentifierReservationChanges(before, &linked)
+	assert.Equal(t, []string{
+		utils.IdentifierReservationID(domain.IdentifierKindSocial, "social_google:110248495921238986420"),
+	}, reservationIDs(reserve))
+	assert.Empty(t, release)
+}
+
+func reservationIDs(reservations []*domain.IdentifierReservation) []string {
+	ids := []string{}
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.ID)
+	}
+	return ids
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+const (
+	// LoginAlertTTLEnvVarName is the env var that sets how long the "this wasn't me" link of a
+	// login alert works after it is sent e.g `168h`
+	LoginAlertTTLEnvVarName = "LOGIN_ALERT_TTL"
+
+	// DefaultLoginAlertTTL is how long the "this wasn't me" link of a login alert works when
+	// `LOGIN_ALERT_TTL` is not set or is not valid
+	DefaultLoginAlertTTL = 7 * 24 * time.Hour
+
+	// LoginAlertURLEnvVarName is the env var that sets the address that the "this wasn't me" link
+	// of a login alert points to. The token of the alert is added to it as the `token` query
+	// parameter. It defaults to the `report_login` endpoint of this service
+	LoginAlertURLEnvVarName = "LOGIN_ALERT_URL"
+
+	// ReportLoginPath is the path of the endpoint that the "this wasn't me" link is handled by
+	ReportLoginPath = "/report_login"
+
+	serviceHostEnvVarName = "SERVICE_HOST"
+
+	// loginAlertTokenBytes is the number of random bytes in the token of a "this wasn't me" link
+	loginAlertTokenBytes = 32
+
+	// the prefix lengths of the networks that IP addresses are grouped into. A login from an
+	// address outside the networks that a user logged in from before is alerted
+	ipv4RangeBits = 24
+	ipv6RangeBits = 48
+)
+
+// LoginAlertTTL returns how long the "this wasn't me" link of a login alert works after it is sent
+func LoginAlertTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv(LoginAlertTTLEnvVarName))
+	if err != nil || ttl <= 0 {
+		return DefaultLoginAlertTTL
+	}
+	return ttl
+}
+
+// IPRange returns the network that an IP address belongs to, in CIDR notation. IPv4 addresses are
+// grouped into /24 networks and IPv6 addresses into /48 networks. It is empty for addresses that
+// can't be parsed
+func IPRange(ipAddress string) string {
+	ip := net.ParseIP(strings.TrimSpace(ipAddress))
+	if ip == nil {
+		return ""
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		mask := net.CIDRMask(ipv4RangeBits, 8*net.IPv4len)
+		return (&net.IPNet{IP: ipv4.Mask(mask), Mask: mask}).String()
+	}
+	mask := net.CIDRMask(ipv6RangeBits, 8*net.IPv6len)
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// LoginAlertReasons returns why a login from the provided device should be alerted, given the
+// sessions that the user had before it. The first login of a user is not alerted, and neither
+// is a device or IP address that the app did not report
+func LoginAlertReasons(previous []*domain.Session, info domain.ClientInfo) []domain.LoginAlertReason {
+	if len(previous) == 0 {
+		return nil
+	}
+
+	ipRange := IPRange(info.IPAddress)
+	seenDevice, seenIPRange := false, false
+	for _, session := range previous {
+		seenDevice = seenDevice || session.DeviceID == info.DeviceID
+		seenIPRange = seenIPRange || IPRange(session.IPAddress) == ipRange
+	}
+
+	reasons := []domain.LoginAlertReason{}
+	if info.DeviceID != "" && !seenDevice {
+		reasons = append(reasons, domain.LoginAlertReasonNewDevice)
+	}
+	if ipRange != "" && !seenIPRange {
+		reasons = append(reasons, domain.LoginAlertReasonNewIPRange)
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return reasons
+}
+
+// NewLoginAlert returns the alert of a login from the provided device together with the token of
+// its "this wasn't me" link. Only the hash of the token is kept in the alert
+func NewLoginAlert(
+	profileID string,
+	sessionID string,
+	info domain.ClientInfo,
+	reasons []domain.LoginAlertReason,
+	now time.Time,
+) (*domain.LoginAlert, string, error) {
+	b := make([]byte, loginAlertTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("unable to generate a login alert token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return &domain.LoginAlert{
+		ID:        uuid.New().String(),
+		ProfileID: profileID,
+		SessionID: sessionID,
+		TokenHash: HashLoginAlertToken(token),
+		Reasons:   reasons,
+		DeviceID:  info.DeviceID,
+		Platform:  info.Platform,
+		IPAddress: info.IPAddress,
+		UserAgent: info.UserAgent,
+		Created:   now,
+		ExpiresAt: now.Add(LoginAlertTTL()),
+	}, token, nil
+}
+
+// HashLoginAlertToken returns the hash that a login alert is found by the token of its
+// "this wasn't me" link with
+func HashLoginAlertToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoginAlertLink returns the "this wasn't me" link of a login alert
+func LoginAlertLink(token string) string {
+	address := os.Getenv(LoginAlertURLEnvVarName)
+	if address == "" {
+		address = strings.TrimSuffix(os.Getenv(serviceHostEnvVarName), "/") + ReportLoginPath
+	}
+
+	link, err := url.Parse(address)
+	if err != nil {
+		return fmt.Sprintf("%s?token=%s", address, url.QueryEscape(token))
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// LoginAlertText returns the message that tells a user about a login, with its "this wasn't me" link
+func LoginAlertText(firstName string, alert *domain.LoginAlert, link string) string {
+	where := "a new network"
+	if alert.HasReason(domain.LoginAlertReasonNewDevice) {
+		where = "a new device"
+		if alert.Platform != "" {
+			where = fmt.Sprintf("a new %s device", alert.Platform)
+		}
+	}
+	if firstName == "" {
+		firstName = "there"
+	}
+	return fmt.Sprintf(domain.LoginAlertMessage, firstName, where, link)
+}
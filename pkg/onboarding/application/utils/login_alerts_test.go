@@ -0,0 +1,134 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		ipAddress string
+		want      string
+	}{
+		{name: "IPv4 address", ipAddress: "196.201.214.17", want: "196.201.214.0/24"},
+		{name: "IPv6 address", ipAddress: "2c0f:fe38:2405:1:2::7", want: "2c0f:fe38:2405::/48"},
+		{name: "IPv4 mapped IPv6 address", ipAddress: "::ffff:196.201.214.17", want: "196.201.214.0/24"},
+		{name: "not an address", ipAddress: "unknown", want: ""},
+		{name: "empty", ipAddress: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.IPRange(tt.ipAddress))
+		})
+	}
+}
+
+func TestLoginAlertReasons(t *testing.T) {
+	previous := []*domain.Session{
+		{ID: "session-1", DeviceID: "device-1", IPAddress: "196.201.214.17"},
+		{ID: "session-2", DeviceID: "device-2", IPAddress: "41.90.64.3"},
+	}
+
+	tests := []struct {
+		name     string
+		previous []*domain.Session
+		info     domain.ClientInfo
+		want     []domain.LoginAlertReason
+	}{
+		{
+			name:     "first login",
+			previous: nil,
+			info:     domain.ClientInfo{DeviceID: "device-3", IPAddress: "105.160.1.1"},
+			want:     nil,
+		},
+		{
+			name:     "known device in a known network",
+			previous: previous,
+			info:     domain.ClientInfo{DeviceID: "device-2", IPAddress: "196.201.214.200"},
+			want:     nil,
+		},
+		{
+			name:     "new device",
+			previous: previous,
+			info:     domain.ClientInfo{DeviceID: "device-3", IPAddress: "41.90.64.3"},
+			want:     []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice},
+		},
+		{
+			name:     "known device in a new network",
+			previous: previous,
+			info:     domain.ClientInfo{DeviceID: "device-1", IPAddress: "105.160.1.1"},
+			want:     []domain.LoginAlertReason{domain.LoginAlertReasonNewIPRange},
+		},
+		{
+			name:     "new device in a new network",
+			previous: previous,
+			info:     domain.ClientInfo{DeviceID: "device-3", IPAddress: "105.160.1.1"},
+			want:     []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice, domain.LoginAlertReasonNewIPRange},
+		},
+		{
+			name:     "device and address not reported",
+			previous: previous,
+			info:     domain.ClientInfo{},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.LoginAlertReasons(tt.previous, tt.info))
+		})
+	}
+}
+
+func TestNewLoginAlert(t *testing.T) {
+	t.Setenv(utils.LoginAlertTTLEnvVarName, "")
+	now := time.Now()
+	info := domain.ClientInfo{DeviceID: "device-3", Platform: "android", IPAddress: "105.160.1.1"}
+	reasons := []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice}
+
+	alert, token, err := utils.NewLoginAlert("profile-1", "session-3", info, reasons, now)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, alert.ID)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, utils.HashLoginAlertToken(token), alert.TokenHash)
+	assert.NotEqual(t, token, alert.TokenHash)
+	assert.Equal(t, "session-3", alert.SessionID)
+	assert.Equal(t, "android", alert.Platform)
+	assert.Equal(t, now.Add(utils.DefaultLoginAlertTTL), alert.ExpiresAt)
+	assert.False(t, alert.IsExpired(now))
+	assert.True(t, alert.IsExpired(alert.ExpiresAt))
+
+	_, other, err := utils.NewLoginAlert("profile-1", "session-3", info, reasons, now)
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestLoginAlertLink(t *testing.T) {
+	t.Setenv(utils.LoginAlertURLEnvVarName, "")
+	t.Setenv("SERVICE_HOST", "https://onboarding.example.com/")
+	assert.Equal(t, "https://onboarding.example.com/report_login?token=abc-123", utils.LoginAlertLink("abc-123"))
+
+	t.Setenv(utils.LoginAlertURLEnvVarName, "https://example.com/not-me?lang=en")
+	assert.Equal(t, "https://example.com/not-me?lang=en&token=abc-123", utils.LoginAlertLink("abc-123"))
+}
+
+func TestLoginAlertText(t *testing.T) {
+	alert := &domain.LoginAlert{
+		Platform: "android",
+		Reasons:  []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice, domain.LoginAlertReasonNewIPRange},
+	}
+	text := utils.LoginAlertText("Jane", alert, "https://example.com/not-me")
+	assert.True(t, strings.HasPrefix(text, "Hi Jane,"))
+	assert.Contains(t, text, "a new android device")
+	assert.Contains(t, text, "https://example.com/not-me")
+
+	alert = &domain.LoginAlert{Reasons: []domain.LoginAlertReason{domain.LoginAlertReasonNewIPRange}}
+	text = utils.LoginAlertText("", alert, "https://example.com/not-me")
+	assert.True(t, strings.HasPrefix(text, "Hi there,"))
+	assert.Contains(t, text, "a new network")
+}
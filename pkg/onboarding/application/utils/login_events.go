@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/errorcodeutil"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// NewLoginEvent returns the login event of an attempt to log in from the provided device. The
+// user is filled in as they are found and the outcome once the attempt is over
+func NewLoginEvent(method domain.LoginMethod, info domain.ClientInfo, now time.Time) *domain.LoginEvent {
+	return &domain.LoginEvent{
+		ID:        uuid.New().String(),
+		Method:    method,
+		Outcome:   domain.LoginOutcomeSucceeded,
+		DeviceID:  info.DeviceID,
+		IPAddress: info.IPAddress,
+		UserAgent: info.UserAgent,
+		Timestamp: now,
+	}
+}
+
+// SetLoginOutcome records whether an attempt to log in succeeded. The attempt failed if there is
+// an error; the reason is derived from the error
+func SetLoginOutcome(event *domain.LoginEvent, loginErr error) {
+	event.Outcome = domain.LoginOutcomeSucceeded
+	event.Reason = ""
+	if loginErr != nil {
+		event.Outcome = domain.LoginOutcomeFailed
+		event.Reason = LoginFailureReason(loginErr)
+	}
+}
+
+// LoginFailureReason returns why an attempt to log in failed with the provided error
+func LoginFailureReason(err error) domain.LoginFailureReason {
+	var customErr *errorcodeutil.CustomError
+	if !errors.As(err, &customErr) {
+		return domain.LoginFailureReasonOther
+	}
+	switch customErr.Code {
+	case int(errorcodeutil.PINMismatch):
+		return domain.LoginFailureReasonPINMismatch
+	case exceptions.PINLocked, exceptions.PINAttemptsThrottled:
+		return domain.LoginFailureReasonPINLocked
+	case exceptions.TempPINExpired:
+		return domain.LoginFailureReasonPINExpired
+	case exceptions.PINResetRequired:
+		return domain.LoginFailureReasonPINReset
+	case int(errorcodeutil.ProfileSuspended):
+		return domain.LoginFailureReasonSuspended
+	case int(errorcodeutil.ProfileNotFound), int(errorcodeutil.UserNotFound), int(errorcodeutil.PINNotFound):
+		return domain.LoginFailureReasonNotFound
+	case exceptions.SessionRevoked:
+		return domain.LoginFailureReasonSessionRevoked
+	case exceptions.TwoFactorRequired, exceptions.InvalidTwoFactorCode, exceptions.TwoFactorEnrolmentRequired:
+		return domain.LoginFailureReasonSecondFactor
+	case exceptions.PasswordMismatch:
+		return domain.LoginFailureReasonPasswordMismatch
+	case exceptions.PasswordLocked, exceptions.PasswordAttemptsThrottled:
+		return domain.LoginFailureReasonPasswordLocked
+	case exceptions.PasswordResetRequired:
+		return domain.LoginFailureReasonPasswordReset
+	case exceptions.InvalidSocialToken:
+		return domain.LoginFailureReasonSocialToken
+	default:
+		return domain.LoginFailureReasonOther
+	}
+}
@@ -0,0 +1,60 @@
+package utils_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want domain.LoginFailureReason
+	}{
+		{name: "PIN mismatch", err: exceptions.PinMismatchError(fmt.Errorf("wrong PIN")), want: domain.LoginFailureReasonPINMismatch},
+		{name: "PIN locked", err: exceptions.PINLockedError(time.Now()), want: domain.LoginFailureReasonPINLocked},
+		{name: "PIN attempts throttled", err: exceptions.PINAttemptsThrottledError(time.Second), want: domain.LoginFailureReasonPINLocked},
+		{name: "temporary PIN expired", err: exceptions.TempPINExpiredError(), want: domain.LoginFailureReasonPINExpired},
+		{name: "PIN must be reset", err: exceptions.PINResetRequiredError(), want: domain.LoginFailureReasonPINReset},
+		{name: "wrong second factor code", err: exceptions.InvalidTwoFactorCodeError(), want: domain.LoginFailureReasonSecondFactor},
+		{name: "wrong password", err: exceptions.PasswordMismatchError(), want: domain.LoginFailureReasonPasswordMismatch},
+		{name: "locked password", err: exceptions.PasswordLockedError(time.Now()), want: domain.LoginFailureReasonPasswordLocked},
+		{name: "password attempts throttled", err: exceptions.PasswordAttemptsThrottledError(time.Second), want: domain.LoginFailureReasonPasswordLocked},
+		{name: "password must be reset", err: exceptions.PasswordResetRequiredError(), want: domain.LoginFailureReasonPasswordReset},
+		{name: "invalid social token", err: exceptions.InvalidSocialTokenError(fmt.Errorf("expired")), want: domain.LoginFailureReasonSocialToken},
+		{name: "suspended profile", err: exceptions.ProfileSuspendFoundError(), want: domain.LoginFailureReasonSuspended},
+		{name: "profile not found", err: exceptions.ProfileNotFoundError(fmt.Errorf("not found")), want: domain.LoginFailureReasonNotFound},
+		{name: "PIN not found", err: exceptions.PinNotFoundError(nil), want: domain.LoginFailureReasonNotFound},
+		{name: "session revoked", err: exceptions.SessionRevokedError(), want: domain.LoginFailureReasonSessionRevoked},
+		{name: "wrapped error", err: fmt.Errorf("login: %w", exceptions.ProfileSuspendFoundError()), want: domain.LoginFailureReasonSuspended},
+		{name: "other error", err: fmt.Errorf("invalid refresh token"), want: domain.LoginFailureReasonOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.LoginFailureReason(tt.err))
+		})
+	}
+}
+
+func TestSetLoginOutcome(t *testing.T) {
+	now := time.Now()
+	info := domain.ClientInfo{DeviceID: "device-1", IPAddress: "196.201.214.1", UserAgent: "bewell/2.1.0"}
+	event := utils.NewLoginEvent(domain.LoginMethodPhone, info, now)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, "196.201.214.1", event.IPAddress)
+	assert.Equal(t, now, event.Timestamp)
+
+	utils.SetLoginOutcome(event, exceptions.PinMismatchError(fmt.Errorf("wrong PIN")))
+	assert.Equal(t, domain.LoginOutcomeFailed, event.Outcome)
+	assert.Equal(t, domain.LoginFailureReasonPINMismatch, event.Reason)
+
+	utils.SetLoginOutcome(event, nil)
+	assert.Equal(t, domain.LoginOutcomeSucceeded, event.Outcome)
+	assert.Empty(t, event.Reason)
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+// MergeUserProfiles returns the surviving profile of merging a duplicate user profile into it.
+// The contacts, verified identifiers, UIDs, roles, permissions and push tokens of both profiles are
+// combined. The primary phone number and email address of the duplicate become secondary ones
+// of the survivor, unless the survivor has none. Everything else is kept from the survivor
+func MergeUserProfiles(
+	survivor *profileutils.UserProfile,
+	merged *profileutils.UserProfile,
+) (*profileutils.UserProfile, error) {
+	data, err := json.Marshal(survivor)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal user profile: %w", err)
+	}
+	result := &profileutils.UserProfile{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("unable to copy user profile: %w", err)
+	}
+
+	phones := append([]string{}, result.SecondaryPhoneNumbers...)
+	if merged.PrimaryPhone != nil && *merged.PrimaryPhone != "" {
+		if result.PrimaryPhone == nil || *result.PrimaryPhone == "" {
+			phone := *merged.PrimaryPhone
+			result.PrimaryPhone = &phone
+		} else {
+			phones = append(phones, *merged.PrimaryPhone)
+		}
+	}
+	phones = append(phones, merged.SecondaryPhoneNumbers...)
+	result.SecondaryPhoneNumbers = secondaryIdentifiers(domain.IdentifierKindPhone, result.PrimaryPhone, phones)
+
+	emails := append([]string{}, result.SecondaryEmailAddresses...)
+	if merged.PrimaryEmailAddress != nil && *merged.PrimaryEmailAddress != "" {
+		if result.PrimaryEmailAddress == nil || *result.PrimaryEmailAddress == "" {
+			email := *merged.PrimaryEmailAddress
+			result.PrimaryEmailAddress = &email
+		} else {
+			emails = append(emails, *merged.PrimaryEmailAddress)
+		}
+	}
+	emails = append(emails, merged.SecondaryEmailAddresses...)
+	result.SecondaryEmailAddresses = secondaryIdentifiers(
+		domain.IdentifierKindEmail,
+		result.PrimaryEmailAddress,
+		emails,
+	)
+
+	for _, identifier := range merged.VerifiedIdentifiers {
+		if !CheckIdentifierExists(result, identifier.UID) {
+			result.VerifiedIdentifiers = append(result.VerifiedIdentifiers, identifier)
+		}
+	}
+	result.VerifiedUIDS = RemoveDuplicateStrings(append(result.VerifiedUIDS, merged.VerifiedUIDS...))
+	result.Roles = RemoveDuplicateStrings(append(result.Roles, merged.Roles...))
+	result.Permissions = UniquePermissionsArray(append(result.Permissions, merged.Permissions...))
+	result.PushTokens = RemoveDuplicateStrings(append(result.PushTokens, merged.PushTokens...))
+	if result.Role == "" {
+		result.Role = merged.Role
+	}
+	return result, nil
+}
+
+// UserMergedEvent returns the `user.merged` event of merging a duplicate user profile into a survivor
+func UserMergedEvent(merge *domain.ProfileMerge) (*domain.OutboxEvent, error) {
+	return NewOutboxEvent(
+		domain.EventTypeUserMerged,
+		merge.SurvivorID,
+		domain.UserMergedEvent{ProfileID: merge.SurvivorID, MergedProfileID: merge.MergedProfileID},
+	)
+}
+
+// NewProfileTombstone returns the tombstone that a merge leaves behind for the merged profile
+func NewProfileTombstone(merge *domain.ProfileMerge) *domain.ProfileTombstone {
+	return &domain.ProfileTombstone{
+		ProfileID:  merge.MergedProfileID,
+		MergedInto: merge.SurvivorID,
+		MergeID:    merge.ID,
+		Created:    merge.Created,
+	}
+}
+
+// secondaryIdentifiers drops the primary identifier and the duplicates from a list of secondary
+// phone numbers or email addresses. Identifiers are compared in the form that they are reserved in
+func secondaryIdentifiers(kind domain.IdentifierKind, primary *string, identifiers []string) []string {
+	seen := map[string]bool{}
+	if primary != nil {
+		seen[NormalizeIdentifier(kind, *primary)] = true
+	}
+	secondary := []string{}
+	for _, identifier := range identifiers {
+		normalized := NormalizeIdentifier(kind, identifier)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		secondary = append(secondary, identifier)
+	}
+	return secondary
+}
@@ -0,0 +1,108 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeUserProfiles(t *testing.T) {
+	survivorPhone := "+254711223344"
+	survivorEmail := "jane@example.com"
+	userName := "jane"
+	survivor := &profileutils.UserProfile{
+		ID:                    "survivor",
+		UserName:              &userName,
+		PrimaryPhone:          &survivorPhone,
+		PrimaryEmailAddress:   &survivorEmail,
+		SecondaryPhoneNumbers: []string{"+254722000000"},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{
+			{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone},
+		},
+		VerifiedUIDS: []string{"uid-1"},
+		Roles:        []string{"role-1"},
+		PushTokens:   []string{"token-1"},
+	}
+
+	mergedPhone := "0722000001"
+	mergedEmail := "JANE@example.com"
+	mergedUserName := "jane2"
+	merged := &profileutils.UserProfile{
+		ID:                      "merged",
+		UserName:                &mergedUserName,
+		PrimaryPhone:            &mergedPhone,
+		PrimaryEmailAddress:     &mergedEmail,
+		SecondaryPhoneNumbers:   []string{"0722000000"},
+		SecondaryEmailAddresses: []string{"jane@work.example.com"},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{
+			{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone},
+			{UID: "uid-2", LoginProvider: profileutils.LoginProviderTypePhone},
+		},
+		VerifiedUIDS: []string{"uid-1", "uid-2"},
+		Roles:        []string{"role-1", "role-2"},
+		Role:         profileutils.RoleTypeEmployee,
+		PushTokens:   []string{"token-2"},
+	}
+
+	result, err := utils.MergeUserProfiles(survivor, merged)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "survivor", result.ID)
+	assert.Equal(t, &userName, result.UserName)
+	assert.Equal(t, survivorPhone, *result.PrimaryPhone)
+	assert.Equal(t, []string{"+254722000000", "0722000001"}, result.SecondaryPhoneNumbers)
+	assert.Equal(t, survivorEmail, *result.PrimaryEmailAddress)
+	assert.Equal(t, []string{"jane@work.example.com"}, result.SecondaryEmailAddresses)
+	assert.Len(t, result.VerifiedIdentifiers, 2)
+	assert.Equal(t, []string{"uid-1", "uid-2"}, result.VerifiedUIDS)
+	assert.Equal(t, []string{"role-1", "role-2"}, result.Roles)
+	assert.Equal(t, []string{"token-1", "token-2"}, result.PushTokens)
+	assert.Equal(t, profileutils.RoleTypeEmployee, result.Role)
+
+	// the survivor is not changed
+	assert.Equal(t, []string{"uid-1"}, survivor.VerifiedUIDS)
+	assert.Equal(t, []string{"+254722000000"}, survivor.SecondaryPhoneNumbers)
+
+	// a survivor without contacts takes over the primary contacts of the duplicate
+	result, err = utils.MergeUserProfiles(&profileutils.UserProfile{ID: "survivor"}, merged)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, mergedPhone, *result.PrimaryPhone)
+	assert.Equal(t, mergedEmail, *result.PrimaryEmailAddress)
+	assert.Equal(t, []string{"0722000000"}, result.SecondaryPhoneNumbers)
+	assert.Nil(t, result.UserName)
+}
+
+func TestUserMergedEvent(t *testing.T) {
+	merge := &domain.ProfileMerge{
+		ID:              "merge-1",
+		SurvivorID:      "survivor",
+		MergedProfileID: "merged",
+		Created:         time.Now(),
+	}
+
+	event, err := utils.UserMergedEvent(merge)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.EventTypeUserMerged, event.Type)
+	assert.Equal(t, "survivor", event.ProfileID)
+
+	envelope := domain.Event{}
+	assert.Nil(t, json.Unmarshal([]byte(event.Payload), &envelope))
+	data := domain.UserMergedEvent{}
+	assert.Nil(t, json.Unmarshal(envelope.Data, &data))
+	assert.Equal(t, domain.UserMergedEvent{ProfileID: "survivor", MergedProfileID: "merged"}, data)
+
+	tombstone := utils.NewProfileTombstone(merge)
+	assert.Equal(t, "merged", tombstone.ProfileID)
+	assert.Equal(t, "survivor", tombstone.MergedInto)
+	assert.Equal(t, "merge-1", tombstone.MergeID)
+}
@@ -0,0 +1,577 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/profileutils"
+)
+
+// The fields that user profile and role listings can be filtered or sorted by
+const (
+	// ListingFieldID is the ID of a record. Listings are always sorted by it last
+	ListingFieldID = "id"
+
+	// ListingFieldCreated is the time a record was created
+	ListingFieldCreated = "created"
+
+	// ListingFieldUserName is the user name of a user profile
+	ListingFieldUserName = "userName"
+
+	// ListingFieldRole is the ID of a role that has been assigned to a user profile
+	ListingFieldRole = "role"
+
+	// ListingFieldSuspended is the suspended status of a user profile
+	ListingFieldSuspended = "suspended"
+
+	// ListingFieldFlavour is the app a user profile has been used with. A profile is listed under
+	// every flavour whose app version has been recorded on it
+	ListingFieldFlavour = "flavour"
+
+	// ListingFieldName is the name of a role
+	ListingFieldName = "name"
+
+	// ListingFieldActive is the active status of a role
+	ListingFieldActive = "active"
+)
+
+// MaxListingPageSize is the largest page of a listing that can be requested
+const MaxListingPageSize = firebasetools.DefaultPageSize
+
+// listingFilterSpec describes how a listing can be filtered by a field
+type listingFilterSpec struct {
+	fieldType  enumutils.FieldType
+	operations []enumutils.Operation
+}
+
+var (
+	equalityOperations = []enumutils.Operation{enumutils.OperationEqual}
+
+	comparisonOperations = []enumutils.Operation{
+		enumutils.OperationLessThan,
+		enumutils.OperationLessThanOrEqualTo,
+		enumutils.OperationEqual,
+		enumutils.OperationGreaterThan,
+		enumutils.OperationGreaterThanOrEqualTo,
+	}
+
+	userProfileListingFilters = map[string]listingFilterSpec{
+		ListingFieldRole: {
+			fieldType:  enumutils.FieldTypeString,
+			operations: []enumutils.Operation{enumutils.OperationEqual, enumutils.OperationContains},
+		},
+		ListingFieldSuspended: {fieldType: enumutils.FieldTypeBoolean, operations: equalityOperations},
+		ListingFieldCreated:   {fieldType: enumutils.FieldTypeTimestamp, operations: comparisonOperations},
+		ListingFieldFlavour:   {fieldType: enumutils.FieldTypeString, operations: equalityOperations},
+	}
+
+	userProfileListingSortFields = []string{ListingFieldID, ListingFieldCreated, ListingFieldUserName}
+
+	roleListingFilters = map[string]listingFilterSpec{
+		ListingFieldActive:  {fieldType: enumutils.FieldTypeBoolean, operations: equalityOperations},
+		ListingFieldCreated: {fieldType: enumutils.FieldTypeTimestamp, operations: comparisonOperations},
+	}
+
+	roleListingSortFields = []string{ListingFieldID, ListingFieldCreated, ListingFieldName}
+)
+
+// listingCursor is the encoded form of a dto.ListingCursor
+type listingCursor struct {
+	SortField string `json:"f"`
+	ID        string `json:"i"`
+	Value     string `json:"v,omitempty"`
+}
+
+// NewUserProfileListingQuery validates the pagination, filter and sort parameters of a user
+// profile listing. Profiles can be filtered by role, suspended status, creation time and flavour
+// and sorted by ID, creation time or user name
+func NewUserProfileListingQuery(
+	pagination *firebasetools.PaginationInput,
+	filter *firebasetools.FilterInput,
+	sort *firebasetools.SortInput,
+) (*dto.ListingQuery, error) {
+	return newListingQuery(
+		pagination,
+		filter,
+		sort,
+		userProfileListingFilters,
+		userProfileListingSortFields,
+	)
+}
+
+// NewRoleListingQuery validates the pagination, filter and sort parameters of a role listing.
+// Roles can be filtered by active status and creation time and sorted by ID, creation time or name
+func NewRoleListingQuery(
+	pagination *firebasetools.PaginationInput,
+	filter *firebasetools.FilterInput,
+	sort *firebasetools.SortInput,
+) (*dto.ListingQuery, error) {
+	return newListingQuery(pagination, filter, sort, roleListingFilters, roleListingSortFields)
+}
+
+func newListingQuery(
+	pagination *firebasetools.PaginationInput,
+	filter *firebasetools.FilterInput,
+	sort *firebasetools.SortInput,
+	filters map[string]listingFilterSpec,
+	sortFields []string,
+) (*dto.ListingQuery, error) {
+	if err := firebasetools.ValidatePaginationParameters(pagination); err != nil {
+		return nil, exceptions.InvalidListingQueryError(err)
+	}
+
+	query := &dto.ListingQuery{
+		SortField: ListingFieldID,
+		Limit:     firebasetools.DefaultPageSize,
+	}
+
+	cursor := ""
+	if pagination != nil {
+		if pagination.First < 0 || pagination.Last < 0 {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("`first` and `last` cannot be negative"),
+			)
+		}
+		if pagination.Last > 0 {
+			if pagination.After != "" {
+				return nil, exceptions.InvalidListingQueryError(
+					fmt.Errorf("`after` can only be used together with `first`"),
+				)
+			}
+			query.Backward = true
+			query.Limit = pagination.Last
+			cursor = pagination.Before
+		} else {
+			if pagination.Before != "" {
+				return nil, exceptions.InvalidListingQueryError(
+					fmt.Errorf("`before` can only be used together with `last`"),
+				)
+			}
+			if pagination.First > 0 {
+				query.Limit = pagination.First
+			}
+			cursor = pagination.After
+		}
+		if query.Limit > MaxListingPageSize {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("a page can have at most %d records", MaxListingPageSize),
+			)
+		}
+	}
+
+	if sort != nil && len(sort.SortBy) > 0 {
+		if len(sort.SortBy) > 1 {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("a listing can only be sorted by one field"),
+			)
+		}
+		param := sort.SortBy[0]
+		if param == nil || !converterandformatter.StringSliceContains(sortFields, param.FieldName) {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("a listing can only be sorted by one of %v", sortFields),
+			)
+		}
+		if !param.SortOrder.IsValid() {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("invalid sort order %q", param.SortOrder),
+			)
+		}
+		query.SortField = param.FieldName
+		query.Descending = param.SortOrder == enumutils.SortOrderDesc
+	}
+
+	if filter != nil {
+		if filter.Search != nil && *filter.Search != "" {
+			return nil, exceptions.InvalidListingQueryError(
+				fmt.Errorf("search is not supported in listings, use `filterBy` instead"),
+			)
+		}
+		for _, param := range filter.FilterBy {
+			if param == nil {
+				continue
+			}
+			listingFilter, err := newListingFilter(param, filters)
+			if err != nil {
+				return nil, exceptions.InvalidListingQueryError(err)
+			}
+			query.Filters = append(query.Filters, listingFilter)
+		}
+	}
+
+	if cursor != "" {
+		decoded, err := decodeListingCursor(cursor, query.SortField)
+		if err != nil {
+			return nil, exceptions.InvalidListingQueryError(err)
+		}
+		query.Cursor = decoded
+	}
+
+	return query, nil
+}
+
+func newListingFilter(
+	param *firebasetools.FilterParam,
+	filters map[string]listingFilterSpec,
+) (*dto.ListingFilter, error) {
+	spec, ok := filters[param.FieldName]
+	if !ok {
+		return nil, fmt.Errorf("a listing cannot be filtered by %q", param.FieldName)
+	}
+	if param.FieldType != spec.fieldType {
+		return nil, fmt.Errorf("%q should be filtered as a %s field", param.FieldName, spec.fieldType)
+	}
+
+	allowed := false
+	for _, op := range spec.operations {
+		if op == param.ComparisonOperation {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf(
+			"%q cannot be filtered with the %s operation",
+			param.FieldName,
+			param.ComparisonOperation,
+		)
+	}
+
+	value, err := parseListingFilterValue(spec.fieldType, param.FieldValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", param.FieldName, err)
+	}
+	if param.FieldName == ListingFieldFlavour {
+		if !feedlib.Flavour(value.(string)).IsValid() {
+			return nil, fmt.Errorf("invalid flavour %q", value)
+		}
+	}
+
+	return &dto.ListingFilter{
+		FieldName: param.FieldName,
+		Operation: param.ComparisonOperation,
+		Value:     value,
+	}, nil
+}
+
+func parseListingFilterValue(fieldType enumutils.FieldType, value interface{}) (interface{}, error) {
+	switch fieldType {
+	case enumutils.FieldTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+		return nil, fmt.Errorf("expected a boolean")
+
+	case enumutils.FieldTypeTimestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t, nil
+			}
+			return time.Parse("2006-01-02", v)
+		}
+		return nil, fmt.Errorf("expected an RFC3339 timestamp or a date")
+
+	default:
+		v, ok := value.(string)
+		if !ok || v == "" {
+			return nil, fmt.Errorf("expected a string")
+		}
+		return v, nil
+	}
+}
+
+func encodeListingCursor(sortField string, id string, value interface{}) string {
+	cursor := listingCursor{SortField: sortField, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		cursor.Value = v.UTC().Format(time.RFC3339Nano)
+	case string:
+		if sortField != ListingFieldID {
+			cursor.Value = v
+		}
+	}
+	// marshalling a struct of strings does not fail
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListingCursor(encoded string, sortField string) (*dto.ListingCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	cursor := listingCursor{}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if cursor.SortField != sortField {
+		return nil, fmt.Errorf("the cursor belongs to a listing sorted by %q", cursor.SortField)
+	}
+
+	decoded := &dto.ListingCursor{ID: cursor.ID, Value: cursor.ID}
+	switch sortField {
+	case ListingFieldID:
+	case ListingFieldCreated:
+		created, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		decoded.Value = created
+	default:
+		decoded.Value = cursor.Value
+	}
+	return decoded, nil
+}
+
+// UserProfileListingValue returns the value of the field of a user profile that a listing is sorted by
+func UserProfileListingValue(profile *profileutils.UserProfile, field string) interface{} {
+	switch field {
+	case ListingFieldCreated:
+		if profile.Created == nil {
+			return time.Time{}
+		}
+		return *profile.Created
+	case ListingFieldUserName:
+		if profile.UserName == nil {
+			return ""
+		}
+		return *profile.UserName
+	default:
+		return profile.ID
+	}
+}
+
+// RoleListingValue returns the value of the field of a role that a listing is sorted by
+func RoleListingValue(role *profileutils.Role, field string) interface{} {
+	switch field {
+	case ListingFieldCreated:
+		return role.Created
+	case ListingFieldName:
+		return role.Name
+	default:
+		return role.ID
+	}
+}
+
+// CompareListingValues compares two values of a field that a listing is sorted by. It returns
+// -1, 0 or +1 when the first value is less than, equal to or greater than the second one
+func CompareListingValues(a, b interface{}) int {
+	switch x := a.(type) {
+	case time.Time:
+		y, _ := b.(time.Time)
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		}
+		return 0
+	case string:
+		y, _ := b.(string)
+		return strings.Compare(x, y)
+	}
+	return 0
+}
+
+// CompareListingPositions compares the positions of two records in the order that the records
+// of a listing query are read in
+func CompareListingPositions(
+	query dto.ListingQuery,
+	aValue interface{},
+	aID string,
+	bValue interface{},
+	bID string,
+) int {
+	c := CompareListingValues(aValue, bValue)
+	if c == 0 {
+		c = strings.Compare(aID, bID)
+	}
+	if query.ReadDescending() {
+		return -c
+	}
+	return c
+}
+
+// MatchesUserProfileFilters checks whether a user profile meets all the filters of a listing query
+func MatchesUserProfileFilters(profile *profileutils.UserProfile, filters []*dto.ListingFilter) bool {
+	for _, filter := range filters {
+		switch filter.FieldName {
+		case ListingFieldRole:
+			role, _ := filter.Value.(string)
+			if !converterandformatter.StringSliceContains(profile.Roles, role) {
+				return false
+			}
+		case ListingFieldSuspended:
+			if profile.Suspended != filter.Value {
+				return false
+			}
+		case ListingFieldCreated:
+			if !matchesListingTime(UserProfileListingValue(profile, ListingFieldCreated), filter) {
+				return false
+			}
+		case ListingFieldFlavour:
+			if !UserProfileHasFlavour(profile, feedlib.Flavour(fmt.Sprint(filter.Value))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MatchesRoleFilters checks whether a role meets all the filters of a listing query
+func MatchesRoleFilters(role *profileutils.Role, filters []*dto.ListingFilter) bool {
+	for _, filter := range filters {
+		switch filter.FieldName {
+		case ListingFieldActive:
+			if role.Active != filter.Value {
+				return false
+			}
+		case ListingFieldCreated:
+			if !matchesListingTime(role.Created, filter) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// UserProfileHasFlavour checks whether a user profile has been used with the app of a flavour
+func UserProfileHasFlavour(profile *profileutils.UserProfile, flavour feedlib.Flavour) bool {
+	switch flavour {
+	case feedlib.FlavourPro:
+		return profile.PROAppVersion != nil && *profile.PROAppVersion != ""
+	case feedlib.FlavourConsumer:
+		return profile.ConsumerAppVersion != nil && *profile.ConsumerAppVersion != ""
+	}
+	return false
+}
+
+func matchesListingTime(value interface{}, filter *dto.ListingFilter) bool {
+	c := CompareListingValues(value, filter.Value)
+	switch filter.Operation {
+	case enumutils.OperationLessThan:
+		return c < 0
+	case enumutils.OperationLessThanOrEqualTo:
+		return c <= 0
+	case enumutils.OperationGreaterThan:
+		return c > 0
+	case enumutils.OperationGreaterThanOrEqualTo:
+		return c >= 0
+	}
+	return c == 0
+}
+
+// listingPage works out how many of the records read for a listing query belong to the page and
+// whether there are pages before and after it
+func listingPage(query *dto.ListingQuery, read int) (int, *firebasetools.PageInfo) {
+	count := read
+	more := read > query.Limit
+	if more {
+		count = query.Limit
+	}
+
+	pageInfo := &firebasetools.PageInfo{}
+	if query.Backward {
+		pageInfo.HasPreviousPage = more
+		pageInfo.HasNextPage = query.Cursor != nil
+	} else {
+		pageInfo.HasNextPage = more
+		pageInfo.HasPreviousPage = query.Cursor != nil
+	}
+	return count, pageInfo
+}
+
+// pageIndex returns the index in a page of the i-th record read for a listing query.
+// Records read backwards are put back in the order of the listing
+func pageIndex(query *dto.ListingQuery, count int, i int) int {
+	if query.Backward {
+		return count - 1 - i
+	}
+	return i
+}
+
+// NewUserProfileConnection builds a page of user profiles from the profiles read for a listing query
+func NewUserProfileConnection(
+	query *dto.ListingQuery,
+	profiles []*profileutils.UserProfile,
+) *dto.UserProfileConnection {
+	count, pageInfo := listingPage(query, len(profiles))
+
+	edges := make([]*dto.UserProfileEdge, count)
+	for i, profile := range profiles[:count] {
+		edges[pageIndex(query, count, i)] = &dto.UserProfileEdge{
+			Cursor: encodeListingCursor(
+				query.SortField,
+				profile.ID,
+				UserProfileListingValue(profile, query.SortField),
+			),
+			Node: profile,
+		}
+	}
+	if count > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[count-1].Cursor
+	}
+
+	return &dto.UserProfileConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}
+}
+
+// NewRoleConnection builds a page of roles from the roles read for a listing query. The users of
+// the roles are not loaded; they are listed by filtering a user profile listing by role
+func NewRoleConnection(
+	ctx context.Context,
+	query *dto.ListingQuery,
+	roles []*profileutils.Role,
+) (*dto.RoleConnection, error) {
+	count, pageInfo := listingPage(query, len(roles))
+
+	edges := make([]*dto.RoleEdge, count)
+	for i, role := range roles[:count] {
+		perms, err := role.Permissions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		edges[pageIndex(query, count, i)] = &dto.RoleEdge{
+			Cursor: encodeListingCursor(
+				query.SortField,
+				role.ID,
+				RoleListingValue(role, query.SortField),
+			),
+			Node: &dto.RoleOutput{
+				ID:          role.ID,
+				Name:        role.Name,
+				Description: role.Description,
+				Active:      role.Active,
+				Scopes:      role.Scopes,
+				Permissions: perms,
+			},
+		}
+	}
+	if count > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[count-1].Cursor
+	}
+
+	return &dto.RoleConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
@@ -0,0 +1,329 @@
+package utils_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUserProfileListingQuery(t *testing.T) {
+	search := "name"
+	tests := []struct {
+		name       string
+		pagination *firebasetools.PaginationInput
+		filter     *firebasetools.FilterInput
+		sort       *firebasetools.SortInput
+		want       *dto.ListingQuery
+		wantErr    bool
+	}{
+		{
+			name: "Happy case:defaults",
+			want: &dto.ListingQuery{
+				SortField: utils.ListingFieldID,
+				Limit:     firebasetools.DefaultPageSize,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Happy case:backward page sorted by creation time",
+			pagination: &firebasetools.PaginationInput{Last: 10},
+			filter: &firebasetools.FilterInput{
+				FilterBy: []*firebasetools.FilterParam{
+					{
+						FieldName:           utils.ListingFieldSuspended,
+						FieldType:           enumutils.FieldTypeBoolean,
+						ComparisonOperation: enumutils.OperationEqual,
+						FieldValue:          "false",
+					},
+					{
+						FieldName:           utils.ListingFieldCreated,
+						FieldType:           enumutils.FieldTypeTimestamp,
+						ComparisonOperation: enumutils.OperationGreaterThanOrEqualTo,
+						FieldValue:          "2021-01-01",
+					},
+					{
+						FieldName:           utils.ListingFieldFlavour,
+						FieldType:           enumutils.FieldTypeString,
+						ComparisonOperation: enumutils.OperationEqual,
+						FieldValue:          "PRO",
+					},
+				},
+			},
+			sort: &firebasetools.SortInput{
+				SortBy: []*firebasetools.SortParam{
+					{FieldName: utils.ListingFieldCreated, SortOrder: enumutils.SortOrderDesc},
+				},
+			},
+			want: &dto.ListingQuery{
+				Filters: []*dto.ListingFilter{
+					{
+						FieldName: utils.ListingFieldSuspended,
+						Operation: enumutils.OperationEqual,
+						Value:     false,
+					},
+					{
+						FieldName: utils.ListingFieldCreated,
+						Operation: enumutils.OperationGreaterThanOrEqualTo,
+						Value:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						FieldName: utils.ListingFieldFlavour,
+						Operation: enumutils.OperationEqual,
+						Value:     "PRO",
+					},
+				},
+				SortField:  utils.ListingFieldCreated,
+				Descending: true,
+				Backward:   true,
+				Limit:      10,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Sad case:first and last",
+			pagination: &firebasetools.PaginationInput{First: 10, Last: 10},
+			wantErr:    true,
+		},
+		{
+			name:       "Sad case:before without last",
+			pagination: &firebasetools.PaginationInput{First: 10, Before: "cursor"},
+			wantErr:    true,
+		},
+		{
+			name:       "Sad case:page too large",
+			pagination: &firebasetools.PaginationInput{First: utils.MaxListingPageSize + 1},
+			wantErr:    true,
+		},
+		{
+			name:       "Sad case:invalid cursor",
+			pagination: &firebasetools.PaginationInput{First: 10, After: "not a cursor"},
+			wantErr:    true,
+		},
+		{
+			name: "Sad case:unknown sort field",
+			sort: &firebasetools.SortInput{
+				SortBy: []*firebasetools.SortParam{
+					{FieldName: "primaryPhone", SortOrder: enumutils.SortOrderAsc},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case:unknown filter field",
+			filter: &firebasetools.FilterInput{
+				FilterBy: []*firebasetools.FilterParam{
+					{
+						FieldName:           "primaryPhone",
+						FieldType:           enumutils.FieldTypeString,
+						ComparisonOperation: enumutils.OperationEqual,
+						FieldValue:          "+254711223344",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case:unsupported operation",
+			filter: &firebasetools.FilterInput{
+				FilterBy: []*firebasetools.FilterParam{
+					{
+						FieldName:           utils.ListingFieldSuspended,
+						FieldType:           enumutils.FieldTypeBoolean,
+						ComparisonOperation: enumutils.OperationGreaterThan,
+						FieldValue:          "true",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case:invalid flavour",
+			filter: &firebasetools.FilterInput{
+				FilterBy: []*firebasetools.FilterParam{
+					{
+						FieldName:           utils.ListingFieldFlavour,
+						FieldType:           enumutils.FieldTypeString,
+						ComparisonOperation: enumutils.OperationEqual,
+						FieldValue:          "ADMIN",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case:search",
+			filter:  &firebasetools.FilterInput{Search: &search},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.NewUserProfileListingQuery(tt.pagination, tt.filter, tt.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewUserProfileListingQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewUserProfileConnection(t *testing.T) {
+	profiles := []*profileutils.UserProfile{}
+	for i := 1; i <= 5; i++ {
+		profiles = append(profiles, &profileutils.UserProfile{ID: fmt.Sprintf("profile-%d", i)})
+	}
+
+	first, err := utils.NewUserProfileListingQuery(&firebasetools.PaginationInput{First: 2}, nil, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	page := utils.NewUserProfileConnection(first, profiles[:3])
+	assert.Len(t, page.Edges, 2)
+	assert.Equal(t, "profile-1", page.Edges[0].Node.ID)
+	assert.True(t, page.PageInfo.HasNextPage)
+	assert.False(t, page.PageInfo.HasPreviousPage)
+
+	second, err := utils.NewUserProfileListingQuery(
+		&firebasetools.PaginationInput{First: 2, After: *page.PageInfo.EndCursor},
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, &dto.ListingCursor{ID: "profile-2", Value: "profile-2"}, second.Cursor)
+
+	// a backward page is read from the end and returned in the order of the listing
+	last, err := utils.NewUserProfileListingQuery(&firebasetools.PaginationInput{Last: 2}, nil, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	page = utils.NewUserProfileConnection(last, []*profileutils.UserProfile{profiles[4], profiles[3]})
+	assert.Len(t, page.Edges, 2)
+	assert.Equal(t, "profile-4", page.Edges[0].Node.ID)
+	assert.Equal(t, "profile-5", page.Edges[1].Node.ID)
+	assert.False(t, page.PageInfo.HasPreviousPage)
+	assert.False(t, page.PageInfo.HasNextPage)
+
+	// cursors can not be used with a different sort order
+	_, err = utils.NewUserProfileListingQuery(
+		&firebasetools.PaginationInput{First: 2, After: *page.PageInfo.EndCursor},
+		nil,
+		&firebasetools.SortInput{
+			SortBy: []*firebasetools.SortParam{
+				{FieldName: utils.ListingFieldCreated, SortOrder: enumutils.SortOrderAsc},
+			},
+		},
+	)
+	assert.NotNil(t, err)
+}
+
+func TestNewRoleConnection(t *testing.T) {
+	created := time.Date(2021, 6, 1, 10, 0, 0, 123456789, time.UTC)
+	roles := []*profileutils.Role{
+		{ID: "role-1", Name: "Admin", Active: true, Created: created, Scopes: []string{"role.view"}},
+	}
+
+	query, err := utils.NewRoleListingQuery(
+		nil,
+		nil,
+		&firebasetools.SortInput{
+			SortBy: []*firebasetools.SortParam{
+				{FieldName: utils.ListingFieldCreated, SortOrder: enumutils.SortOrderAsc},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	page, err := utils.NewRoleConnection(context.Background(), query, roles)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, page.Edges, 1)
+	assert.Equal(t, "Admin", page.Edges[0].Node.Name)
+	assert.NotEmpty(t, page.Edges[0].Node.Permissions)
+	assert.Nil(t, page.Edges[0].Node.Users)
+
+	next, err := utils.NewRoleListingQuery(
+		&firebasetools.PaginationInput{After: page.Edges[0].Cursor},
+		nil,
+		&firebasetools.SortInput{
+			SortBy: []*firebasetools.SortParam{
+				{FieldName: utils.ListingFieldCreated, SortOrder: enumutils.SortOrderAsc},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, &dto.ListingCursor{ID: "role-1", Value: created}, next.Cursor)
+}
+
+func TestMatchesUserProfileFilters(t *testing.T) {
+	created := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	version := "1.0.0"
+	profile := &profileutils.UserProfile{
+		ID:            "profile-1",
+		Roles:         []string{"role-1"},
+		Created:       &created,
+		PROAppVersion: &version,
+	}
+
+	tests := []struct {
+		name    string
+		filters []*dto.ListingFilter
+		want    bool
+	}{
+		{
+			name: "Happy case:matching filters",
+			filters: []*dto.ListingFilter{
+				{FieldName: utils.ListingFieldRole, Operation: enumutils.OperationEqual, Value: "role-1"},
+				{FieldName: utils.ListingFieldSuspended, Operation: enumutils.OperationEqual, Value: false},
+				{FieldName: utils.ListingFieldCreated, Operation: enumutils.OperationLessThan, Value: created.Add(time.Hour)},
+				{FieldName: utils.ListingFieldFlavour, Operation: enumutils.OperationEqual, Value: string(feedlib.FlavourPro)},
+			},
+			want: true,
+		},
+		{
+			name: "Sad case:other role",
+			filters: []*dto.ListingFilter{
+				{FieldName: utils.ListingFieldRole, Operation: enumutils.OperationEqual, Value: "role-2"},
+			},
+			want: false,
+		},
+		{
+			name: "Sad case:created later",
+			filters: []*dto.ListingFilter{
+				{FieldName: utils.ListingFieldCreated, Operation: enumutils.OperationGreaterThan, Value: created},
+			},
+			want: false,
+		},
+		{
+			name: "Sad case:other flavour",
+			filters: []*dto.ListingFilter{
+				{FieldName: utils.ListingFieldFlavour, Operation: enumutils.OperationEqual, Value: string(feedlib.FlavourConsumer)},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.MatchesUserProfileFilters(profile, tt.filters); got != tt.want {
+				t.Errorf("MatchesUserProfileFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// NormalizeEmailAddress returns the form of an email address that email logins are keyed by
+func NormalizeEmailAddress(emailAddress string) string {
+	return NormalizeIdentifier(domain.IdentifierKindEmail, emailAddress)
+}
+
+// IsEmailAddress checks whether an identifier that a user logs in with is an email address
+func IsEmailAddress(identifier string) bool {
+	return govalidator.IsEmail(identifier)
+}
+
+// CheckPasswordAttempt returns an error when a password can't be attempted at the provided time,
+// either because it must be reset, because it is locked or because the delay that follows a failed
+// attempt has not passed. Failed attempts are delayed in the same way as those of a PIN
+func CheckPasswordAttempt(password *domain.Password, now time.Time) error {
+	if password.ResetRequired {
+		return exceptions.PasswordResetRequiredError()
+	}
+	if password.IsLocked(now) {
+		return exceptions.PasswordLockedError(*password.LockedUntil)
+	}
+	if password.LockedUntil == nil && password.LastFailedAttempt != nil {
+		retryAt := password.LastFailedAttempt.Add(PINAttemptDelay(password.FailedAttempts))
+		if now.Before(retryAt) {
+			return exceptions.PasswordAttemptsThrottledError(retryAt.Sub(now))
+		}
+	}
+	return nil
+}
+
+// ReservePasswordAttempt counts an attempt to use a password as failed before the password is
+// compared, so that concurrent attempts can't all pass CheckPasswordAttempt. It returns the error
+// of CheckPasswordAttempt and leaves the password unchanged when the password can't be attempted.
+// The count is cleared once the password matches
+func ReservePasswordAttempt(password *domain.Password, attemptedAt time.Time) error {
+	if err := CheckPasswordAttempt(password, attemptedAt); err != nil {
+		return err
+	}
+	RecordFailedPasswordAttempt(password, attemptedAt)
+	return nil
+}
+
+// RecordFailedPasswordAttempt counts a failed attempt to use a password. A password is locked
+// after as many failed attempts in a row, and for as long, as a PIN. The attempts are counted
+// afresh after a lockout expires
+func RecordFailedPasswordAttempt(password *domain.Password, attemptedAt time.Time) {
+	if password.LockedUntil != nil && !password.IsLocked(attemptedAt) {
+		password.FailedAttempts = 0
+		password.LockedUntil = nil
+	}
+	password.FailedAttempts++
+	password.LastFailedAttempt = &attemptedAt
+	if password.FailedAttempts >= MaxPINAttempts && password.LockedUntil == nil {
+		lockedUntil := attemptedAt.Add(PINLockoutDuration)
+		password.LockedUntil = &lockedUntil
+	}
+}
+
+// ClearPasswordAttempts forgets the failed attempts to use a password, unlocking it
+func ClearPasswordAttempts(password *domain.Password) {
+	password.FailedAttempts = 0
+	password.LastFailedAttempt = nil
+	password.LockedUntil = nil
+}
+
+// RequirePasswordReset stops a password from being used until it is reset with an OTP
+func RequirePasswordReset(password *domain.Password) {
+	password.ResetRequired = true
+}
+
+// ReplacePasswordHash swaps the hash, salt and scheme of a stored password for the ones of
+// `rehashed`, which is the same password hashed with a newer scheme. Nothing is changed when the
+// stored hash is no longer `previousPasswordHash`, since that means the password was changed
+// after it was rehashed
+func ReplacePasswordHash(stored *domain.Password, previousPasswordHash string, rehashed *domain.Password) {
+	if stored.PasswordHash != previousPasswordHash {
+		return
+	}
+	stored.PasswordHash = rehashed.PasswordHash
+	stored.Salt = rehashed.Salt
+	stored.Scheme = rehashed.Scheme
+}
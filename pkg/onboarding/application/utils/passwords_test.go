@@ -0,0 +1,72 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordFailedPasswordAttempt(t *testing.T) {
+	now := time.Now()
+	password := &domain.Password{ID: "password-1", ProfileID: "profile-1"}
+
+	for i := 1; i < utils.MaxPINAttempts; i++ {
+		utils.RecordFailedPasswordAttempt(password, now)
+		assert.Equal(t, i, password.FailedAttempts)
+		assert.Nil(t, utils.CheckPasswordAttempt(password, now.Add(utils.PINAttemptDelay(i))))
+	}
+
+	// the next attempt is delayed as it is for a PIN
+	err := utils.CheckPasswordAttempt(password, now)
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+	assert.Equal(t, domain.LoginFailureReasonPasswordLocked, utils.LoginFailureReason(err))
+
+	utils.RecordFailedPasswordAttempt(password, now)
+	assert.True(t, password.IsLocked(now))
+	err = utils.CheckPasswordAttempt(password, now)
+	assert.NotNil(t, err)
+	assert.Equal(t, domain.LoginFailureReasonPasswordLocked, utils.LoginFailureReason(err))
+
+	// the attempts are counted afresh once the lockout expires
+	later := password.LockedUntil.Add(time.Minute)
+	assert.Nil(t, utils.CheckPasswordAttempt(password, later))
+	utils.RecordFailedPasswordAttempt(password, later)
+	assert.Equal(t, 1, password.FailedAttempts)
+	assert.Nil(t, password.LockedUntil)
+
+	utils.ClearPasswordAttempts(password)
+	assert.Equal(t, 0, password.FailedAttempts)
+	assert.Nil(t, password.LastFailedAttempt)
+}
+
+func TestReservePasswordAttempt(t *testing.T) {
+	now := time.Now()
+
+	password := &domain.Password{ProfileID: "profile-1"}
+	assert.Nil(t, utils.ReservePasswordAttempt(password, now))
+	assert.Equal(t, 1, password.FailedAttempts)
+	assert.Equal(t, now, *password.LastFailedAttempt)
+
+	lockedUntil := now.Add(time.Minute)
+	locked := &domain.Password{ProfileID: "profile-1", FailedAttempts: utils.MaxPINAttempts, LockedUntil: &lockedUntil}
+	assert.NotNil(t, utils.ReservePasswordAttempt(locked, now))
+	assert.Equal(t, utils.MaxPINAttempts, locked.FailedAttempts)
+
+	// a password that must be reset is refused without counting the attempt
+	reset := &domain.Password{ProfileID: "profile-1"}
+	utils.RequirePasswordReset(reset)
+	err := utils.ReservePasswordAttempt(reset, now)
+	assert.Equal(t, domain.LoginFailureReasonPasswordReset, utils.LoginFailureReason(err))
+	assert.Equal(t, 0, reset.FailedAttempts)
+}
+
+func TestEmailAddresses(t *testing.T) {
+	assert.Equal(t, "wanjiku@example.com", utils.NormalizeEmailAddress(" Wanjiku@Example.com "))
+	assert.True(t, utils.IsEmailAddress("wanjiku@example.com"))
+	assert.False(t, utils.IsEmailAddress("+254711223344"))
+	assert.False(t, utils.IsEmailAddress(exceptions.PasswordMismatchErrMsg))
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/pubsubtools"
+)
+
+const (
+	// MaxPINAttempts is the number of consecutive failed attempts after which a PIN is locked
+	MaxPINAttempts = 5
+
+	// PINLockoutDuration is how long a PIN stays locked unless it is reset or unlocked by an admin
+	PINLockoutDuration = 30 * time.Minute
+
+	// pinAttemptDelay is the delay after the second consecutive failed attempt. It doubles after
+	// every other failed attempt
+	pinAttemptDelay = time.Second
+
+	// maxPINAttemptDelay caps the delay between failed attempts
+	maxPINAttemptDelay = 30 * time.Second
+)
+
+// PINAttemptDelay is how long a PIN can't be attempted after it has failed `failedAttempts`
+// times in a row. The first failed attempt is not delayed
+func PINAttemptDelay(failedAttempts int) time.Duration {
+	if failedAttempts < 2 {
+		return 0
+	}
+	delay := pinAttemptDelay
+	for i := 2; i < failedAttempts; i++ {
+		delay *= 2
+		if delay >= maxPINAttemptDelay {
+			return maxPINAttemptDelay
+		}
+	}
+	return delay
+}
+
+// CheckPINAttempt returns an error when a PIN can't be attempted at the provided time, either
+// because it must be reset, because it is locked or because the delay that follows a failed
+// attempt has not passed
+func CheckPINAttempt(pin *domain.PIN, now time.Time) error {
+	if pin.ResetRequired {
+		return exceptions.PINResetRequiredError()
+	}
+	if pin.IsLocked(now) {
+		return exceptions.PINLockedError(*pin.LockedUntil)
+	}
+	if pin.LockedUntil == nil && pin.LastFailedAttempt != nil {
+		retryAt := pin.LastFailedAttempt.Add(PINAttemptDelay(pin.FailedAttempts))
+		if now.Before(retryAt) {
+			return exceptions.PINAttemptsThrottledError(retryAt.Sub(now))
+		}
+	}
+	return nil
+}
+
+// ReservePINAttempt counts an attempt to use a PIN as failed before the PIN is compared, so that
+// concurrent attempts can't all pass CheckPINAttempt. It returns the error of CheckPINAttempt and
+// leaves the PIN unchanged when the PIN can't be attempted. The count is cleared once the PIN
+// matches
+func ReservePINAttempt(pin *domain.PIN, attemptedAt time.Time) error {
+	if err := CheckPINAttempt(pin, attemptedAt); err != nil {
+		return err
+	}
+	RecordFailedPINAttempt(pin, attemptedAt)
+	return nil
+}
+
+// RecordFailedPINAttempt counts a failed attempt to use a PIN and locks the PIN once it has
+// failed MaxPINAttempts times in a row. The attempts are counted afresh after a lockout expires
+func RecordFailedPINAttempt(pin *domain.PIN, attemptedAt time.Time) {
+	if pin.LockedUntil != nil && !pin.IsLocked(attemptedAt) {
+		pin.FailedAttempts = 0
+		pin.LockedUntil = nil
+	}
+	pin.FailedAttempts++
+	pin.LastFailedAttempt = &attemptedAt
+	if pin.FailedAttempts >= MaxPINAttempts && pin.LockedUntil == nil {
+		lockedUntil := attemptedAt.Add(PINLockoutDuration)
+		pin.LockedUntil = &lockedUntil
+	}
+}
+
+// ClearPINAttempts forgets the failed attempts to use a PIN, unlocking it
+func ClearPINAttempts(pin *domain.PIN) {
+	pin.FailedAttempts = 0
+	pin.LastFailedAttempt = nil
+	pin.LockedUntil = nil
+}
+
+// RequirePINReset stops a PIN from being used until it is reset with an OTP
+func RequirePINReset(pin *domain.PIN) {
+	pin.ResetRequired = true
+}
+
+// PINAttemptEvents returns the `pin.locked` or `pin.unlocked` event of a change to the failed
+// attempts of a PIN. It compares the PIN before and after the change
+func PINAttemptEvents(before *domain.PIN, after *domain.PIN) ([]*domain.OutboxEvent, error) {
+	now := time.Now().In(pubsubtools.TimeLocation)
+	switch {
+	case after.LockedUntil != nil && before.LockedUntil == nil,
+		after.LockedUntil != nil && !before.LockedUntil.Equal(*after.LockedUntil):
+		event, err := NewOutboxEvent(
+			domain.EventTypePINLocked,
+			after.ProfileID,
+			domain.PINLockedEvent{
+				ProfileID:      after.ProfileID,
+				FailedAttempts: after.FailedAttempts,
+				LockedUntil:    *after.LockedUntil,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.OutboxEvent{event}, nil
+
+	case after.LockedUntil == nil && before.IsLocked(now):
+		event, err := NewOutboxEvent(
+			domain.EventTypePINUnlocked,
+			after.ProfileID,
+			domain.PINUnlockedEvent{ProfileID: after.ProfileID},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.OutboxEvent{event}, nil
+	}
+	return nil, nil
+}
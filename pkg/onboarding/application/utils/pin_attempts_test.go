@@ -0,0 +1,163 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPINAttemptDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		failedAttempts int
+		want           time.Duration
+	}{
+		{name: "no failed attempts", failedAttempts: 0, want: 0},
+		{name: "first failed attempt", failedAttempts: 1, want: 0},
+		{name: "second failed attempt", failedAttempts: 2, want: time.Second},
+		{name: "third failed attempt", failedAttempts: 3, want: 2 * time.Second},
+		{name: "fourth failed attempt", failedAttempts: 4, want: 4 * time.Second},
+		{name: "capped delay", failedAttempts: 20, want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.PINAttemptDelay(tt.failedAttempts))
+		})
+	}
+}
+
+func TestRecordFailedPINAttempt(t *testing.T) {
+	now := time.Now()
+	pin := &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}
+
+	for i := 1; i < utils.MaxPINAttempts; i++ {
+		utils.RecordFailedPINAttempt(pin, now)
+		assert.Equal(t, i, pin.FailedAttempts)
+		assert.Nil(t, pin.LockedUntil)
+	}
+
+	utils.RecordFailedPINAttempt(pin, now)
+	assert.Equal(t, utils.MaxPINAttempts, pin.FailedAttempts)
+	if assert.NotNil(t, pin.LockedUntil) {
+		assert.True(t, pin.LockedUntil.Equal(now.Add(utils.PINLockoutDuration)))
+	}
+	assert.True(t, pin.IsLocked(now))
+
+	// a failed attempt while locked does not extend the lockout
+	lockedUntil := *pin.LockedUntil
+	utils.RecordFailedPINAttempt(pin, now.Add(time.Minute))
+	assert.True(t, pin.LockedUntil.Equal(lockedUntil))
+
+	// the attempts are counted afresh once the lockout expires
+	later := lockedUntil.Add(time.Minute)
+	assert.False(t, pin.IsLocked(later))
+	utils.RecordFailedPINAttempt(pin, later)
+	assert.Equal(t, 1, pin.FailedAttempts)
+	assert.Nil(t, pin.LockedUntil)
+
+	utils.ClearPINAttempts(pin)
+	assert.Equal(t, 0, pin.FailedAttempts)
+	assert.Nil(t, pin.LastFailedAttempt)
+	assert.Nil(t, pin.LockedUntil)
+}
+
+func TestCheckPINAttempt(t *testing.T) {
+	now := time.Now()
+	justNow := now.Add(-500 * time.Millisecond)
+	lockedUntil := now.Add(time.Minute)
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		pin        *domain.PIN
+		wantErr    bool
+		wantLocked bool
+	}{
+		{
+			name: "no failed attempts",
+			pin:  &domain.PIN{ProfileID: "profile-1"},
+		},
+		{
+			name: "first failed attempt is not delayed",
+			pin:  &domain.PIN{ProfileID: "profile-1", FailedAttempts: 1, LastFailedAttempt: &justNow},
+		},
+		{
+			name:       "attempt within the delay",
+			pin:        &domain.PIN{ProfileID: "profile-1", FailedAttempts: 3, LastFailedAttempt: &justNow},
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name: "attempt after the delay",
+			pin:  &domain.PIN{ProfileID: "profile-1", FailedAttempts: 3, LastFailedAttempt: &expired},
+		},
+		{
+			name:       "locked pin",
+			pin:        &domain.PIN{ProfileID: "profile-1", FailedAttempts: 5, LastFailedAttempt: &justNow, LockedUntil: &lockedUntil},
+			wantErr:    true,
+			wantLocked: true,
+		},
+		{
+			name: "expired lockout",
+			pin:  &domain.PIN{ProfileID: "profile-1", FailedAttempts: 5, LastFailedAttempt: &expired, LockedUntil: &expired},
+		},
+		{
+			name:    "pin that must be reset",
+			pin:     &domain.PIN{ProfileID: "profile-1", ResetRequired: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.CheckPINAttempt(tt.pin, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPINAttempt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantLocked, exceptions.IsPINLockedError(err))
+		})
+	}
+}
+
+func TestReservePINAttempt(t *testing.T) {
+	now := time.Now()
+
+	pin := &domain.PIN{ProfileID: "profile-1"}
+	assert.Nil(t, utils.ReservePINAttempt(pin, now))
+	assert.Equal(t, 1, pin.FailedAttempts)
+	assert.Equal(t, now, *pin.LastFailedAttempt)
+
+	lockedUntil := now.Add(time.Minute)
+	locked := &domain.PIN{ProfileID: "profile-1", FailedAttempts: utils.MaxPINAttempts, LockedUntil: &lockedUntil}
+	assert.NotNil(t, utils.ReservePINAttempt(locked, now))
+	assert.Equal(t, utils.MaxPINAttempts, locked.FailedAttempts)
+}
+
+func TestPINAttemptEvents(t *testing.T) {
+	now := time.Now()
+	lockedUntil := now.Add(time.Minute)
+
+	unlocked := &domain.PIN{ProfileID: "profile-1", FailedAttempts: 4}
+	locked := &domain.PIN{ProfileID: "profile-1", FailedAttempts: 5, LockedUntil: &lockedUntil}
+
+	events, err := utils.PINAttemptEvents(unlocked, locked)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.EventTypePINLocked, events[0].Type)
+		assert.Equal(t, "profile-1", events[0].ProfileID)
+	}
+
+	events, err = utils.PINAttemptEvents(locked, &domain.PIN{ProfileID: "profile-1"})
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.EventTypePINUnlocked, events[0].Type)
+	}
+
+	events, err = utils.PINAttemptEvents(unlocked, &domain.PIN{ProfileID: "profile-1", FailedAttempts: 5})
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+}
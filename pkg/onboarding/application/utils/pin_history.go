@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+const (
+	// PINHistorySizeEnvVarName is the env var that sets how many previous PINs are kept for
+	// every profile. A new PIN can't match the current PIN or any of the previous PINs
+	PINHistorySizeEnvVarName = "PIN_HISTORY_SIZE"
+
+	// DefaultPINHistorySize is the number of previous PINs that are kept when
+	// `PIN_HISTORY_SIZE` is not set or is not valid
+	DefaultPINHistorySize = 5
+)
+
+// PINHistorySize returns the number of previous PINs that are kept for every profile
+func PINHistorySize() int {
+	size, err := strconv.Atoi(os.Getenv(PINHistorySizeEnvVarName))
+	if err != nil || size < 0 {
+		return DefaultPINHistorySize
+	}
+	return size
+}
+
+// RotatePINHistory returns the history of a PIN that replaces `current` at the provided time.
+// The current PIN is added to the front of its history, which is trimmed to `size` entries.
+// Temporary PINs are not kept since they were never chosen by the user
+func RotatePINHistory(current *domain.PIN, size int, replaced time.Time) []domain.PINHistoryEntry {
+	history := []domain.PINHistoryEntry{}
+	if current == nil || size <= 0 {
+		return history
+	}
+	if !current.IsOTP && current.PINNumber != "" {
+		history = append(history, domain.PINHistoryEntry{
+			PINNumber: current.PINNumber,
+			Salt:      current.Salt,
+			Scheme:    current.Scheme,
+			Replaced:  replaced,
+		})
+	}
+	history = append(history, current.History...)
+	if len(history) > size {
+		history = history[:size]
+	}
+	return history
+}
+
+// ReplacePINHash swaps the hash, salt and scheme of a stored PIN for the ones of `rehashed`,
+// which is the same PIN hashed with a newer scheme. Nothing is changed when the stored hash is
+// no longer `previousPINNumber`, since that means the PIN was changed after it was rehashed
+func ReplacePINHash(stored *domain.PIN, previousPINNumber string, rehashed *domain.PIN) {
+	if stored.PINNumber != previousPINNumber {
+		return
+	}
+	stored.PINNumber = rehashed.PINNumber
+	stored.Salt = rehashed.Salt
+	stored.Scheme = rehashed.Scheme
+}
@@ -0,0 +1,84 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPINHistorySize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "not set", value: "", want: utils.DefaultPINHistorySize},
+		{name: "configured", value: "3", want: 3},
+		{name: "disabled", value: "0", want: 0},
+		{name: "negative", value: "-1", want: utils.DefaultPINHistorySize},
+		{name: "not a number", value: "three", want: utils.DefaultPINHistorySize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(utils.PINHistorySizeEnvVarName, tt.value)
+			assert.Equal(t, tt.want, utils.PINHistorySize())
+		})
+	}
+}
+
+func TestRotatePINHistory(t *testing.T) {
+	now := time.Now()
+	current := &domain.PIN{
+		ProfileID: "profile-1",
+		PINNumber: "third",
+		Salt:      "salt-3",
+		History: []domain.PINHistoryEntry{
+			{PINNumber: "second", Salt: "salt-2"},
+			{PINNumber: "first", Salt: "salt-1"},
+		},
+	}
+
+	history := utils.RotatePINHistory(current, 5, now)
+	assert.Equal(t, []domain.PINHistoryEntry{
+		{PINNumber: "third", Salt: "salt-3", Replaced: now},
+		{PINNumber: "second", Salt: "salt-2"},
+		{PINNumber: "first", Salt: "salt-1"},
+	}, history)
+
+	// the oldest PINs are dropped
+	history = utils.RotatePINHistory(current, 2, now)
+	assert.Equal(t, []domain.PINHistoryEntry{
+		{PINNumber: "third", Salt: "salt-3", Replaced: now},
+		{PINNumber: "second", Salt: "salt-2"},
+	}, history)
+
+	assert.Empty(t, utils.RotatePINHistory(current, 0, now))
+	assert.Empty(t, utils.RotatePINHistory(nil, 5, now))
+
+	// temporary PINs are not kept
+	temporary := &domain.PIN{PINNumber: "temporary", Salt: "salt", IsOTP: true, History: current.History}
+	assert.Equal(t, current.History, utils.RotatePINHistory(temporary, 5, now))
+}
+
+func TestReplacePINHash(t *testing.T) {
+	scheme := &domain.PINHashScheme{Algorithm: "argon2id", Iterations: 2}
+	rehashed := &domain.PIN{PINNumber: "rehashed", Salt: "new-salt", Scheme: scheme}
+
+	stored := &domain.PIN{ID: "pin-1", PINNumber: "legacy", Salt: "salt", IsOTP: true, FailedAttempts: 1}
+	utils.ReplacePINHash(stored, "legacy", rehashed)
+	assert.Equal(t, &domain.PIN{
+		ID:             "pin-1",
+		PINNumber:      "rehashed",
+		Salt:           "new-salt",
+		Scheme:         scheme,
+		IsOTP:          true,
+		FailedAttempts: 1,
+	}, stored)
+
+	changed := &domain.PIN{PINNumber: "changed", Salt: "salt"}
+	utils.ReplacePINHash(changed, "legacy", rehashed)
+	assert.Equal(t, &domain.PIN{PINNumber: "changed", Salt: "salt"}, changed)
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/profileutils"
+)
+
+type profileVersionContextKey struct{}
+
+// expectedProfileVersion is the version of a user profile that a write is based on
+type expectedProfileVersion struct {
+	profileID string
+	version   string
+}
+
+// ProfileVersion returns a fingerprint of the state of a user profile. Two reads of a profile
+// return the same version as long as nothing has been written to the profile in between
+func ProfileVersion(profile *profileutils.UserProfile) (string, error) {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal user profile: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// WithExpectedProfileVersion returns a copy of the context that makes the user profile writes
+// done with it conditional on the profile still being in the state it was read in.
+// It should be called right after reading the profile, before the profile is changed
+func WithExpectedProfileVersion(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+) (context.Context, error) {
+	version, err := ProfileVersion(profile)
+	if err != nil {
+		return ctx, exceptions.InternalServerError(err)
+	}
+	return context.WithValue(
+		ctx,
+		profileVersionContextKey{},
+		expectedProfileVersion{profileID: profile.ID, version: version},
+	), nil
+}
+
+// CheckProfileVersion returns a conflict error when the context expects the profile to be in a
+// different state than the one it has been read in
+func CheckProfileVersion(ctx context.Context, profile *profileutils.UserProfile) error {
+	expected, ok := ctx.Value(profileVersionContextKey{}).(expectedProfileVersion)
+	if !ok || expected.profileID != profile.ID {
+		return nil
+	}
+	version, err := ProfileVersion(profile)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	if version != expected.version {
+		return exceptions.ProfileUpdateConflictError(
+			fmt.Errorf("user profile %s has been updated since it was read", profile.ID),
+		)
+	}
+	return nil
+}
+
+// RequireProfileVersion is CheckProfileVersion for the writes that are derived from an earlier read
+// of the profile. They are refused when the context has no expected version of the profile, so
+// that a caller can't skip the check by forgetting to call WithExpectedProfileVersion
+func RequireProfileVersion(ctx context.Context, profile *profileutils.UserProfile) error {
+	expected, ok := ctx.Value(profileVersionContextKey{}).(expectedProfileVersion)
+	if !ok || expected.profileID != profile.ID {
+		return exceptions.InternalServerError(
+			fmt.Errorf("the expected version of user profile %s is required", profile.ID),
+		)
+	}
+	// this is a wrapped error. No need to wrap it again
+	return CheckProfileVersion(ctx, profile)
+}
+
+// CheckProfileUnchanged returns a conflict error when a stored user profile is no longer in the
+// state that it was read in. It is used by writes that are based on more than one profile
+func CheckProfileUnchanged(stored *profileutils.UserProfile, read *profileutils.UserProfile) error {
+	storedVersion, err := ProfileVersion(stored)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	readVersion, err := ProfileVersion(read)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	if storedVersion != readVersion {
+		return exceptions.ProfileUpdateConflictError(
+			fmt.Errorf("user profile %s has been updated since it was read", stored.ID),
+		)
+	}
+	return nil
+}
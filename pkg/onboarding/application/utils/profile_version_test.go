@@ -0,0 +1,106 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckProfileVersion(t *testing.T) {
+	ctx := context.Background()
+	phone := "+254711223344"
+	profile := &profileutils.UserProfile{
+		ID:                    "profile-1",
+		PrimaryPhone:          &phone,
+		SecondaryPhoneNumbers: []string{"+254722334455"},
+	}
+
+	versionedCtx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	changed := *profile
+	changed.SecondaryPhoneNumbers = []string{"+254722334455", "+254733445566"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		profile *profileutils.UserProfile
+		wantErr bool
+	}{
+		{
+			name:    "Happy case:no expected version",
+			ctx:     ctx,
+			profile: &changed,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case:profile is unchanged",
+			ctx:     versionedCtx,
+			profile: &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone, SecondaryPhoneNumbers: []string{"+254722334455"}},
+			wantErr: false,
+		},
+		{
+			name:    "Happy case:version of another profile",
+			ctx:     versionedCtx,
+			profile: &profileutils.UserProfile{ID: "profile-2"},
+			wantErr: false,
+		},
+		{
+			name:    "Sad case:profile has changed",
+			ctx:     versionedCtx,
+			profile: &changed,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.CheckProfileVersion(tt.ctx, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckProfileVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.True(t, exceptions.IsConflictError(err))
+			}
+		})
+	}
+}
+
+func TestCheckProfileUnchanged(t *testing.T) {
+	phone := "+254711223344"
+	profile := &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone}
+
+	assert.Nil(t, utils.CheckProfileUnchanged(profile, &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone}))
+
+	err := utils.CheckProfileUnchanged(profile, &profileutils.UserProfile{ID: "profile-1"})
+	assert.True(t, exceptions.IsConflictError(err))
+}
+
+func TestRequireProfileVersion(t *testing.T) {
+	ctx := context.Background()
+	profile := &profileutils.UserProfile{ID: "profile-1", SecondaryPhoneNumbers: []string{"+254722334455"}}
+
+	versionedCtx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Nil(t, utils.RequireProfileVersion(versionedCtx, profile))
+
+	err = utils.RequireProfileVersion(ctx, profile)
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsConflictError(err))
+
+	err = utils.RequireProfileVersion(versionedCtx, &profileutils.UserProfile{ID: "profile-2"})
+	assert.NotNil(t, err)
+
+	changed := *profile
+	changed.SecondaryPhoneNumbers = []string{"+254733445566"}
+	err = utils.RequireProfileVersion(versionedCtx, &changed)
+	assert.True(t, exceptions.IsConflictError(err))
+}
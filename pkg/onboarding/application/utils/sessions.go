@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// the request headers that the apps describe the device they run on with
+const (
+	DeviceIDHeader   = "X-Device-ID"
+	PlatformHeader   = "X-Platform"
+	AppVersionHeader = "X-App-Version"
+)
+
+const (
+	// TrustedProxyCountEnvVarName is the env var that sets the number of proxies, such as load
+	// balancers, that requests reach the service through. Each of them adds the address that it
+	// received the request from to the `X-Forwarded-For` header
+	TrustedProxyCountEnvVarName = "TRUSTED_PROXY_COUNT"
+
+	// DefaultTrustedProxyCount is the number of proxies in front of the service when
+	// `TRUSTED_PROXY_COUNT` is not set or is not valid. The service is deployed behind one load
+	// balancer
+	DefaultTrustedProxyCount = 1
+)
+
+type clientInfoContextKey struct{}
+
+// TrustedProxyCount returns the number of proxies that requests reach the service through
+func TrustedProxyCount() int {
+	count, err := strconv.Atoi(os.Getenv(TrustedProxyCountEnvVarName))
+	if err != nil || count < 0 {
+		return DefaultTrustedProxyCount
+	}
+	return count
+}
+
+// ClientInfoFromRequest reads the device and app that a request is made from. The IP address is
+// the one that the outermost trusted proxy received the request from, that is the entry of the
+// `X-Forwarded-For` header that is that many hops from the right. The entries to the left of it
+// are sent by the client and are not trusted. It is the address that the request was received
+// from when the service is not behind a proxy, or when the header has fewer entries than there
+// are proxies
+func ClientInfoFromRequest(r *http.Request) domain.ClientInfo {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ipAddress := host
+
+	if proxies := TrustedProxyCount(); proxies > 0 {
+		hops := []string{}
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(header, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		if len(hops) >= proxies && hops[len(hops)-proxies] != "" {
+			ipAddress = hops[len(hops)-proxies]
+		}
+	}
+
+	return domain.ClientInfo{
+		DeviceID:   r.Header.Get(DeviceIDHeader),
+		Platform:   r.Header.Get(PlatformHeader),
+		AppVersion: r.Header.Get(AppVersionHeader),
+		IPAddress:  ipAddress,
+		UserAgent:  r.UserAgent(),
+	}
+}
+
+// WithClientInfo returns a copy of the context that carries the device and app of the request
+func WithClientInfo(ctx context.Context, info domain.ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, info)
+}
+
+// GetClientInfo returns the device and app of the request that the context belongs to. It is
+// empty when the context does not carry them
+func GetClientInfo(ctx context.Context) domain.ClientInfo {
+	info, _ := ctx.Value(clientInfoContextKey{}).(domain.ClientInfo)
+	return info
+}
+
+// ClientInfoMiddleware adds the device and app of every request to the request's context
+func ClientInfoMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithClientInfo(r.Context(), ClientInfoFromRequest(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// HashRefreshToken returns the hash that a session is found by its refresh token with
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewSession returns the session of a device that has just logged in
+func NewSession(
+	profileID string,
+	uid string,
+	refreshToken string,
+	info domain.ClientInfo,
+	now time.Time,
+) *domain.Session {
+	session := &domain.Session{
+		ID:        uuid.New().String(),
+		ProfileID: profileID,
+		UID:       uid,
+		Created:   now,
+	}
+	TouchSession(session, refreshToken, info, now)
+	return session
+}
+
+// TouchSession records that a session has been seen with the provided refresh token. Device
+// details that were not sent are kept from before
+func TouchSession(session *domain.Session, refreshToken string, info domain.ClientInfo, now time.Time) {
+	session.RefreshTokenHash = HashRefreshToken(refreshToken)
+	session.LastSeen = now
+	for _, field := range []struct {
+		value  string
+		stored *string
+	}{
+		{info.DeviceID, &session.DeviceID},
+		{info.Platform, &session.Platform},
+		{info.AppVersion, &session.AppVersion},
+		{info.IPAddress, &session.IPAddress},
+		{info.UserAgent, &session.UserAgent},
+	} {
+		if field.value != "" {
+			*field.stored = field.value
+		}
+	}
+}
+
+// RevokeSessions marks the listed sessions as revoked at the provided time, or all of the sessions
+// when none is listed. It returns the sessions that were not revoked before
+func RevokeSessions(sessions []*domain.Session, sessionIDs []string, revokedAt time.Time) []*domain.Session {
+	listed := map[string]bool{}
+	for _, id := range sessionIDs {
+		listed[id] = true
+	}
+	revoked := []*domain.Session{}
+	for _, session := range sessions {
+		if session.IsRevoked() || (len(sessionIDs) > 0 && !listed[session.ID]) {
+			continue
+		}
+		at := revokedAt
+		session.Revoked = &at
+		revoked = append(revoked, session)
+	}
+	return revoked
+}
+
+// SortSessions orders sessions with the most recently seen first
+func SortSessions(sessions []*domain.Session) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].LastSeen.Equal(sessions[j].LastSeen) {
+			return sessions[i].ID < sessions[j].ID
+		}
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+}
@@ -0,0 +1,122 @@
+package utils_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientInfoFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/login_by_phone", nil)
+	r.RemoteAddr = "10.0.0.1:52000"
+	r.Header.Set("User-Agent", "bewell/2.1.0")
+	r.Header.Set(utils.DeviceIDHeader, "device-1")
+	r.Header.Set(utils.PlatformHeader, "android")
+	r.Header.Set(utils.AppVersionHeader, "2.1.0")
+
+	info := utils.ClientInfoFromRequest(r)
+	assert.Equal(t, domain.ClientInfo{
+		DeviceID:   "device-1",
+		Platform:   "android",
+		AppVersion: "2.1.0",
+		IPAddress:  "10.0.0.1",
+		UserAgent:  "bewell/2.1.0",
+	}, info)
+
+	// one load balancer appends the address of the client to whatever the client sent
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 196.201.214.1")
+	assert.Equal(t, "196.201.214.1", utils.ClientInfoFromRequest(r).IPAddress)
+
+	t.Setenv(utils.TrustedProxyCountEnvVarName, "2")
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 196.201.214.1, 10.0.0.2")
+	assert.Equal(t, "196.201.214.1", utils.ClientInfoFromRequest(r).IPAddress)
+
+	r.Header.Set("X-Forwarded-For", "196.201.214.1")
+	assert.Equal(t, "10.0.0.1", utils.ClientInfoFromRequest(r).IPAddress)
+
+	t.Setenv(utils.TrustedProxyCountEnvVarName, "0")
+	r.Header.Set("X-Forwarded-For", "196.201.214.1")
+	assert.Equal(t, "10.0.0.1", utils.ClientInfoFromRequest(r).IPAddress)
+}
+
+func TestClientInfoMiddleware(t *testing.T) {
+	var got domain.ClientInfo
+	handler := utils.ClientInfoMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = utils.GetClientInfo(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/refresh_token", nil)
+	r.Header.Set(utils.DeviceIDHeader, "device-1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, "device-1", got.DeviceID)
+	assert.Equal(t, domain.ClientInfo{}, utils.GetClientInfo(context.Background()))
+}
+
+func TestTouchSession(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	session := utils.NewSession(
+		"profile-1",
+		"uid-1",
+		"refresh-token-1",
+		domain.ClientInfo{DeviceID: "device-1", Platform: "ios", AppVersion: "2.0.0"},
+		created,
+	)
+	assert.NotEmpty(t, session.ID)
+	assert.Equal(t, utils.HashRefreshToken("refresh-token-1"), session.RefreshTokenHash)
+	assert.NotEqual(t, "refresh-token-1", session.RefreshTokenHash)
+
+	now := time.Now()
+	utils.TouchSession(session, "refresh-token-2", domain.ClientInfo{AppVersion: "2.1.0"}, now)
+	assert.Equal(t, utils.HashRefreshToken("refresh-token-2"), session.RefreshTokenHash)
+	assert.Equal(t, "device-1", session.DeviceID)
+	assert.Equal(t, "ios", session.Platform)
+	assert.Equal(t, "2.1.0", session.AppVersion)
+	assert.Equal(t, created, session.Created)
+	assert.Equal(t, now, session.LastSeen)
+}
+
+func TestRevokeSessions(t *testing.T) {
+	earlier := time.Now().Add(-time.Hour)
+	now := time.Now()
+	newSessions := func() []*domain.Session {
+		return []*domain.Session{
+			{ID: "session-1"},
+			{ID: "session-2", Revoked: &earlier},
+			{ID: "session-3"},
+		}
+	}
+
+	sessions := newSessions()
+	revoked := utils.RevokeSessions(sessions, []string{"session-2", "session-3"}, now)
+	assert.Len(t, revoked, 1)
+	assert.Equal(t, "session-3", revoked[0].ID)
+	assert.False(t, sessions[0].IsRevoked())
+	assert.Equal(t, earlier, *sessions[1].Revoked)
+	assert.Equal(t, now, *sessions[2].Revoked)
+
+	sessions = newSessions()
+	revoked = utils.RevokeSessions(sessions, nil, now)
+	assert.Len(t, revoked, 2)
+	assert.True(t, sessions[0].IsRevoked())
+	assert.Equal(t, earlier, *sessions[1].Revoked)
+}
+
+func TestSortSessions(t *testing.T) {
+	now := time.Now()
+	sessions := []*domain.Session{
+		{ID: "session-1", LastSeen: now.Add(-time.Hour)},
+		{ID: "session-2", LastSeen: now},
+		{ID: "session-3", LastSeen: now.Add(-time.Minute)},
+	}
+	utils.SortSessions(sessions)
+	assert.Equal(t, "session-2", sessions[0].ID)
+	assert.Equal(t, "session-3", sessions[1].ID)
+	assert.Equal(t, "session-1", sessions[2].ID)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/profileutils"
+)
+
+// socialUIDSeparator separates the provider of a social login from the subject in its UID
+const socialUIDSeparator = ":"
+
+// SocialLoginProviders are the login providers that users can sign in with using an ID token that
+// the provider issued
+var SocialLoginProviders = []profileutils.LoginProviderType{
+	profileutils.LoginProviderTypeSocialGoogle,
+	profileutils.LoginProviderTypeAppleFacebook,
+	profileutils.LoginProviderTypeSocialFacebook,
+}
+
+// IsSocialLoginProvider checks whether users sign in with a login provider using its ID tokens
+func IsSocialLoginProvider(provider profileutils.LoginProviderType) bool {
+	for _, p := range SocialLoginProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// SocialUID returns the UID of the auth user of an account with a social login provider. It is
+// derived from the provider and the subject so that the same account always gets the same UID
+func SocialUID(provider profileutils.LoginProviderType, subject string) string {
+	return strings.ToLower(string(provider)) + socialUIDSeparator + subject
+}
+
+// SocialLoginProvider returns the social login provider of a UID returned by SocialUID. It
+// returns false for any other UID
+func SocialLoginProvider(uid string) (profileutils.LoginProviderType, bool) {
+	prefix := strings.SplitN(uid, socialUIDSeparator, 2)
+	if len(prefix) != 2 || prefix[1] == "" {
+		return "", false
+	}
+	for _, provider := range SocialLoginProviders {
+		if strings.ToLower(string(provider)) == prefix[0] {
+			return provider, true
+		}
+	}
+	return "", false
+}
+
+// LinkSocialAccount adds the verified identifier of an account with a social login provider to a
+// profile. A profile can only be linked to one account of each provider. It reports whether the
+// profile has changed
+func LinkSocialAccount(profile *profileutils.UserProfile, identifier profileutils.VerifiedIdentifier) (bool, error) {
+	for _, linked := range profile.VerifiedIdentifiers {
+		if linked.UID == identifier.UID || linked.LoginProvider != identifier.LoginProvider {
+			continue
+		}
+		if provider, ok := SocialLoginProvider(linked.UID); ok && provider == identifier.LoginProvider {
+			return false, exceptions.SocialAccountInUseError()
+		}
+	}
+	return AddLoginUID(profile, identifier), nil
+}
@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSocialUID(t *testing.T) {
+	uid := utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, "110248495921238986420")
+	assert.Equal(t, "social_google:110248495921238986420", uid)
+
+	provider, ok := utils.SocialLoginProvider(uid)
+	assert.True(t, ok)
+	assert.Equal(t, profileutils.LoginProviderTypeSocialGoogle, provider)
+
+	for _, uid := range []string{"", "uid-1", "social_google:", "phone:110248495921238986420", "+254711223344"} {
+		_, ok := utils.SocialLoginProvider(uid)
+		assert.False(t, ok, uid)
+	}
+
+	assert.True(t, utils.IsSocialLoginProvider(profileutils.LoginProviderTypeAppleFacebook))
+	assert.False(t, utils.IsSocialLoginProvider(profileutils.LoginProviderTypePhone))
+}
+
+func TestLinkSocialAccount(t *testing.T) {
+	google := profileutils.VerifiedIdentifier{
+		UID:           utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, "110248495921238986420"),
+		LoginProvider: profileutils.LoginProviderTypeSocialGoogle,
+	}
+	profile := &profileutils.UserProfile{
+		ID:                  "profile-1",
+		VerifiedUIDS:        []string{"uid-1"},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone}},
+	}
+
+	changed, err := utils.LinkSocialAccount(profile, google)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, profile.VerifiedUIDS, google.UID)
+	assert.True(t, utils.CheckIdentifierExists(profile, google.UID))
+
+	// linking the same account again changes nothing
+	changed, err = utils.LinkSocialAccount(profile, google)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	// a profile is linked to one account of each provider
+	other := profileutils.VerifiedIdentifier{
+		UID:           utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, "220248495921238986420"),
+		LoginProvider: profileutils.LoginProviderTypeSocialGoogle,
+	}
+	_, err = utils.LinkSocialAccount(profile, other)
+	assert.Equal(t, exceptions.SocialAccountInUseError().Error(), err.Error())
+	assert.False(t, utils.CheckIdentifierExists(profile, other.UID))
+}
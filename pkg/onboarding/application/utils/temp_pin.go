@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	// TempPINTTLEnvVarName is the env var that sets how long a temporary PIN is accepted after it
+	// is issued e.g `72h`
+	TempPINTTLEnvVarName = "TEMP_PIN_TTL"
+
+	// DefaultTempPINTTL is how long a temporary PIN is accepted when `TEMP_PIN_TTL` is not set or
+	// is not valid
+	DefaultTempPINTTL = 72 * time.Hour
+)
+
+// TempPINTTL returns how long a temporary PIN is accepted after it is issued
+func TempPINTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv(TempPINTTLEnvVarName))
+	if err != nil || ttl <= 0 {
+		return DefaultTempPINTTL
+	}
+	return ttl
+}
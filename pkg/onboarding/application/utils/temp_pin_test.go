@@ -0,0 +1,28 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTempPINTTL(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "not set", value: "", want: utils.DefaultTempPINTTL},
+		{name: "configured", value: "24h", want: 24 * time.Hour},
+		{name: "zero", value: "0s", want: utils.DefaultTempPINTTL},
+		{name: "not a duration", value: "a day", want: utils.DefaultTempPINTTL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(utils.TempPINTTLEnvVarName, tt.value)
+			assert.Equal(t, tt.want, utils.TempPINTTL())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// SortSigningKeys orders signing keys with the newest first. The newest key signs new access tokens
+func SortSigningKeys(keys []*domain.SigningKey) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].Created.Equal(keys[j].Created) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].Created.After(keys[j].Created)
+	})
+}
+
+// HashBearerToken returns the hash that the introspection of a bearer token is cached by, so that
+// the tokens themselves are not kept in memory
+func HashBearerToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,23 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSigningKeys(t *testing.T) {
+	now := time.Now()
+	keys := []*domain.SigningKey{
+		{ID: "key-1", Created: now.Add(-48 * time.Hour)},
+		{ID: "key-2", Created: now},
+		{ID: "key-3", Created: now.Add(-24 * time.Hour)},
+	}
+	utils.SortSigningKeys(keys)
+	assert.Equal(t, "key-2", keys[0].ID)
+	assert.Equal(t, "key-3", keys[1].ID)
+	assert.Equal(t, "key-1", keys[2].ID)
+}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 RFC 6238 authenticator apps default to HMAC-SHA1
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+const (
+	// TOTPIssuerEnvVarName is the env var that sets the name that authenticator apps show
+	// next to the codes of this service
+	TOTPIssuerEnvVarName = "TOTP_ISSUER"
+
+	// DefaultTOTPIssuer is the name that authenticator apps show when `TOTP_ISSUER` is not set
+	DefaultTOTPIssuer = "Be.Well"
+
+	// TOTPPeriod is how long each code is valid for
+	TOTPPeriod = 30 * time.Second
+
+	// TOTPDigits is the number of digits in each code
+	TOTPDigits = 6
+
+	// RecoveryCodeCount is the number of recovery codes that a user is given
+	RecoveryCodeCount = 10
+
+	// totpSecretBytes is the size of the key that the codes are generated with, as recommended
+	// for HMAC-SHA1 by RFC 4226
+	totpSecretBytes = 20
+
+	// totpSkewSteps is the number of time steps before and after the current one whose codes are
+	// accepted, to allow for clocks that are slightly out of sync
+	totpSkewSteps = 1
+
+	// recoveryCodeBytes is the number of random bytes in each recovery code
+	recoveryCodeBytes = 5
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type secondFactorCodeContextKey struct{}
+
+// WithSecondFactorCode returns a copy of the context that carries the second factor code that a
+// user logs in with. The code is either from their authenticator app or one of their recovery codes
+func WithSecondFactorCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, secondFactorCodeContextKey{}, code)
+}
+
+// GetSecondFactorCode returns the second factor code that the context carries. It is empty when
+// the context does not carry one
+func GetSecondFactorCode(ctx context.Context) string {
+	code, _ := ctx.Value(secondFactorCodeContextKey{}).(string)
+	return code
+}
+
+// TOTPIssuer returns the name that authenticator apps show next to the codes of this service
+func TOTPIssuer() string {
+	if issuer := os.Getenv(TOTPIssuerEnvVarName); issuer != "" {
+		return issuer
+	}
+	return DefaultTOTPIssuer
+}
+
+// NewTOTPEnrolment returns a pending TOTP enrolment for a profile together with its recovery codes.
+// Only the hashes of the recovery codes are kept in the enrolment
+func NewTOTPEnrolment(profileID string, now time.Time) (*domain.TOTPEnrolment, []string, error) {
+	key := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("unable to generate a TOTP secret: %w", err)
+	}
+	codes, hashes, err := NewRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &domain.TOTPEnrolment{
+		ID:                 uuid.New().String(),
+		ProfileID:          profileID,
+		Secret:             base32NoPadding.EncodeToString(key),
+		RecoveryCodeHashes: hashes,
+		Created:            now,
+	}, codes, nil
+}
+
+// SealTOTPSecret encrypts the secret of an enrolment with a new data key, which is in turn
+// encrypted with the key encryption key. Both are bound to the enrolment and its profile so that
+// they can't be moved to another profile
+func SealTOTPSecret(keyEncryptionKey []byte, enrolment *domain.TOTPEnrolment) error {
+	encryptedSecret, encryptedDataKey, err := SealEnvelope(
+		keyEncryptionKey,
+		[]byte(enrolment.Secret),
+		totpSecretAdditionalData(enrolment),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt the TOTP secret: %w", err)
+	}
+	enrolment.EncryptedSecret = encryptedSecret
+	enrolment.EncryptedDataKey = encryptedDataKey
+	return nil
+}
+
+// OpenTOTPSecret decrypts the secret of a stored enrolment
+func OpenTOTPSecret(keyEncryptionKey []byte, enrolment *domain.TOTPEnrolment) error {
+	secret, err := OpenEnvelope(
+		keyEncryptionKey,
+		enrolment.EncryptedSecret,
+		enrolment.EncryptedDataKey,
+		totpSecretAdditionalData(enrolment),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to decrypt the TOTP secret: %w", err)
+	}
+	enrolment.Secret = string(secret)
+	return nil
+}
+
+func totpSecretAdditionalData(enrolment *domain.TOTPEnrolment) string {
+	return enrolment.ProfileID + "/" + enrolment.ID
+}
+
+// NewRecoveryCodes returns a new set of recovery codes together with their hashes
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := []string{}
+	hashes := []string{}
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("unable to generate a recovery code: %w", err)
+		}
+		encoded := strings.ToLower(base32NoPadding.EncodeToString(b))
+		code := encoded[:4] + "-" + encoded[4:]
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash that a recovery code is kept as. Case, spaces and dashes are
+// ignored so that a code can be typed the way it is read
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// TOTPProvisioningURI returns the `otpauth://` URI that authenticator apps add an enrolment from,
+// usually by scanning it as a QR code. The account name is what the app shows under the issuer
+func TOTPProvisioningURI(secret string, accountName string) string {
+	issuer := TOTPIssuer()
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	query.Set("period", fmt.Sprintf("%d", int(TOTPPeriod/time.Second)))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
+
+// TOTPStep returns the time step that a time falls in
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// TOTPCode returns the code of a time step, as defined by RFC 6238
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the TOTP secret: %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%modulo), nil
+}
+
+// VerifyTOTPCode checks a code from an authenticator app against an enrolment and returns the time
+// step that it belongs to. Codes of the steps just before and after the current one are accepted.
+// A code whose step is not after the last accepted step is refused so that it can't be replayed
+func VerifyTOTPCode(enrolment *domain.TOTPEnrolment, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	current := TOTPStep(now)
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= enrolment.LastUsedStep {
+			continue
+		}
+		expected, err := TOTPCode(enrolment.Secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// UseRecoveryCode removes a recovery code from an enrolment. It returns false when the code is not
+// one of the recovery codes that have not been used yet
+func UseRecoveryCode(enrolment *domain.TOTPEnrolment, code string) bool {
+	hash := HashRecoveryCode(code)
+	for i, stored := range enrolment.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			enrolment.RecoveryCodeHashes = append(
+				enrolment.RecoveryCodeHashes[:i:i],
+				enrolment.RecoveryCodeHashes[i+1:]...,
+			)
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSecondFactorCode accepts a code from an authenticator app or a recovery code for an
+// enrolment. The enrolment is updated so that the code can't be used again and should be saved
+func CheckSecondFactorCode(enrolment *domain.TOTPEnrolment, code string, now time.Time) bool {
+	if step, ok := VerifyTOTPCode(enrolment, code, now); ok {
+		enrolment.LastUsedStep = step
+		return true
+	}
+	return UseRecoveryCode(enrolment, code)
+}
+
+// RequiresTwoFactor checks whether the policy requires a user with the provided roles to log in
+// with a second factor. Only active roles are considered
+func RequiresTwoFactor(policy *domain.TwoFactorPolicy, roles []profileutils.Role) bool {
+	if policy == nil || len(policy.RequiredScopes) == 0 {
+		return false
+	}
+	required := map[string]bool{}
+	for _, scope := range policy.RequiredScopes {
+		required[scope] = true
+	}
+	for _, scope := range GetUserPermissions(roles) {
+		if required[scope] {
+			return true
+		}
+	}
+	return false
+}
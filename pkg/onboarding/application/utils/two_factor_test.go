@@ -0,0 +1,179 @@
+package utils_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// the secret of the RFC 6238 test vectors, "12345678901234567890", encoded as base32
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// the six digit codes of the SHA1 test vectors in RFC 6238 appendix B
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "59", unix: 59, want: "287082"},
+		{name: "1111111109", unix: 1111111109, want: "081804"},
+		{name: "1111111111", unix: 1111111111, want: "050471"},
+		{name: "1234567890", unix: 1234567890, want: "005924"},
+		{name: "2000000000", unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.TOTPCode(rfcTOTPSecret, utils.TOTPStep(time.Unix(tt.unix, 0)))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := utils.TOTPCode("not base32!", 1)
+	assert.NotNil(t, err)
+}
+
+func TestVerifyTOTPCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := utils.TOTPStep(now)
+	current, _ := utils.TOTPCode(rfcTOTPSecret, step)
+	previous, _ := utils.TOTPCode(rfcTOTPSecret, step-1)
+	stale, _ := utils.TOTPCode(rfcTOTPSecret, step-2)
+
+	enrolment := &domain.TOTPEnrolment{Secret: rfcTOTPSecret}
+	got, ok := utils.VerifyTOTPCode(enrolment, current, now)
+	assert.True(t, ok)
+	assert.Equal(t, step, got)
+
+	_, ok = utils.VerifyTOTPCode(enrolment, previous, now)
+	assert.True(t, ok, "a code of the previous step should be accepted")
+
+	_, ok = utils.VerifyTOTPCode(enrolment, stale, now)
+	assert.False(t, ok, "a code that is two steps old should be refused")
+
+	_, ok = utils.VerifyTOTPCode(enrolment, "12345", now)
+	assert.False(t, ok)
+
+	// an accepted code can't be used again
+	assert.True(t, utils.CheckSecondFactorCode(enrolment, current, now))
+	assert.Equal(t, step, enrolment.LastUsedStep)
+	assert.False(t, utils.CheckSecondFactorCode(enrolment, current, now))
+	assert.False(t, utils.CheckSecondFactorCode(enrolment, previous, now))
+}
+
+func TestNewTOTPEnrolment(t *testing.T) {
+	now := time.Now()
+	enrolment, codes, err := utils.NewTOTPEnrolment("profile-1", now)
+	assert.Nil(t, err)
+	assert.Equal(t, "profile-1", enrolment.ProfileID)
+	assert.False(t, enrolment.IsConfirmed())
+	assert.Len(t, codes, utils.RecoveryCodeCount)
+	assert.Len(t, enrolment.RecoveryCodeHashes, utils.RecoveryCodeCount)
+	assert.NotContains(t, enrolment.RecoveryCodeHashes, codes[0])
+
+	code, err := utils.TOTPCode(enrolment.Secret, utils.TOTPStep(now))
+	assert.Nil(t, err)
+	assert.Len(t, code, utils.TOTPDigits)
+
+	// a recovery code can be used once, however it is typed
+	assert.True(t, utils.CheckSecondFactorCode(enrolment, strings.ToUpper(strings.ReplaceAll(codes[3], "-", " ")), now))
+	assert.Len(t, enrolment.RecoveryCodeHashes, utils.RecoveryCodeCount-1)
+	assert.False(t, utils.UseRecoveryCode(enrolment, codes[3]))
+	assert.True(t, utils.UseRecoveryCode(enrolment, codes[4]))
+}
+
+func TestSealTOTPSecret(t *testing.T) {
+	kek, err := utils.NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	enrolment, _, err := utils.NewTOTPEnrolment("profile-1", time.Now())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := utils.SealTOTPSecret(kek, enrolment); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.NotEmpty(t, enrolment.EncryptedDataKey)
+	assert.NotContains(t, enrolment.EncryptedSecret, enrolment.Secret)
+
+	stored := *enrolment
+	stored.Secret = ""
+	assert.Nil(t, utils.OpenTOTPSecret(kek, &stored))
+	assert.Equal(t, enrolment.Secret, stored.Secret)
+
+	// the secret can't be read with another key or moved to another profile
+	otherKEK, _ := utils.NewKeyEncryptionKey()
+	assert.NotNil(t, utils.OpenTOTPSecret(otherKEK, &stored))
+	moved := stored
+	moved.ProfileID = "profile-2"
+	assert.NotNil(t, utils.OpenTOTPSecret(kek, &moved))
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	t.Setenv(utils.TOTPIssuerEnvVarName, "")
+
+	uri, err := url.Parse(utils.TOTPProvisioningURI(rfcTOTPSecret, "+254711223344"))
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Be.Well:+254711223344", uri.Path)
+	assert.Equal(t, rfcTOTPSecret, uri.Query().Get("secret"))
+	assert.Equal(t, utils.DefaultTOTPIssuer, uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestSecondFactorCode(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", utils.GetSecondFactorCode(ctx))
+	assert.Equal(t, "123456", utils.GetSecondFactorCode(utils.WithSecondFactorCode(ctx, "123456")))
+}
+
+func TestRequiresTwoFactor(t *testing.T) {
+	policy := &domain.TwoFactorPolicy{RequiredScopes: []string{"role.create", "role.assign"}}
+	tests := []struct {
+		name   string
+		policy *domain.TwoFactorPolicy
+		roles  []profileutils.Role
+		want   bool
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+			roles:  []profileutils.Role{{Active: true, Scopes: []string{"role.assign"}}},
+			want:   false,
+		},
+		{
+			name:   "a role holds a required scope",
+			policy: policy,
+			roles:  []profileutils.Role{{Active: true, Scopes: []string{"agent.view"}}, {Active: true, Scopes: []string{"role.assign"}}},
+			want:   true,
+		},
+		{
+			name:   "only an inactive role holds a required scope",
+			policy: policy,
+			roles:  []profileutils.Role{{Active: false, Scopes: []string{"role.assign"}}},
+			want:   false,
+		},
+		{
+			name:   "no role holds a required scope",
+			policy: policy,
+			roles:  []profileutils.Role{{Active: true, Scopes: []string{"agent.view"}}},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.RequiresTwoFactor(tt.policy, tt.roles))
+		})
+	}
+}
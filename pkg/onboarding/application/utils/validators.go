@@ -71,6 +71,52 @@ func ValidateSignUpInput(input *dto.SignUpInput) (*dto.SignUpInput, error) {
 	}, nil
 }
 
+// ValidateEmailSignUpInput checks the input of an email signup and normalizes its email address.
+// The password is checked against the password policy separately
+func ValidateEmailSignUpInput(input *dto.EmailSignUpInput) (*dto.EmailSignUpInput, error) {
+	if !input.Flavour.IsValid() {
+		return nil, exceptions.WrongEnumTypeError(input.Flavour.String())
+	}
+
+	if input.EmailAddress == nil || !IsEmailAddress(strings.TrimSpace(*input.EmailAddress)) {
+		return nil, exceptions.InvalidEmailAddressError()
+	}
+	emailAddress := NormalizeEmailAddress(*input.EmailAddress)
+
+	if input.Password == nil {
+		return nil, exceptions.MissingInputError("password")
+	}
+
+	if input.OTP == nil {
+		return nil, exceptions.MissingInputError("otp")
+	}
+
+	return &dto.EmailSignUpInput{
+		EmailAddress: &emailAddress,
+		Password:     input.Password,
+		Flavour:      input.Flavour,
+		OTP:          input.OTP,
+	}, nil
+}
+
+// ValidateSocialSignUpInput checks the input of a social signup. The ID token is verified by the
+// social login provider separately
+func ValidateSocialSignUpInput(input *dto.SocialSignUpInput) (*dto.SocialSignUpInput, error) {
+	if !input.Flavour.IsValid() {
+		return nil, exceptions.WrongEnumTypeError(input.Flavour.String())
+	}
+
+	if !IsSocialLoginProvider(input.Provider) {
+		return nil, exceptions.WrongEnumTypeError(string(input.Provider))
+	}
+
+	if input.IDToken == nil || strings.TrimSpace(*input.IDToken) == "" {
+		return nil, exceptions.MissingInputError("idToken")
+	}
+
+	return input, nil
+}
+
 // ValidatePIN ...
 func ValidatePIN(pin string) error {
 	validatePINErr := ValidatePINLength(pin)
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+const (
+	// WebhookSignatureHeader is the header that carries the signature of a webhook delivery
+	WebhookSignatureHeader = "X-Onboarding-Signature"
+
+	// WebhookEventHeader is the header that carries the type of the delivered event
+	WebhookEventHeader = "X-Onboarding-Event"
+
+	// WebhookDeliveryHeader is the header that carries the ID of a webhook delivery
+	WebhookDeliveryHeader = "X-Onboarding-Delivery"
+)
+
+// NewWebhookDelivery creates the pending delivery of an outbox event to a webhook endpoint.
+// The ID is derived from the endpoint and the event so that an event is delivered to an
+// endpoint once, however many times it is relayed
+func NewWebhookDelivery(
+	endpoint *domain.WebhookEndpoint,
+	event *domain.OutboxEvent,
+	now time.Time,
+) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:          fmt.Sprintf("%s-%s", endpoint.ID, event.ID),
+		EndpointID:  endpoint.ID,
+		EventID:     event.ID,
+		EventType:   event.Type,
+		Payload:     event.Payload,
+		Status:      domain.WebhookDeliveryStatusPending,
+		NextAttempt: now,
+		Created:     now,
+	}
+}
+
+// SignWebhookPayload returns the signature header value of a webhook delivery. It has the form
+// `t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the secret>`.
+// The timestamp lets receivers reject replayed deliveries
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, webhookMAC(secret, unix, payload))
+}
+
+// VerifyWebhookSignature checks a signature header value created by SignWebhookPayload. The
+// signatures are compared in constant time
+func VerifyWebhookSignature(secret string, signature string, payload []byte) bool {
+	var timestamp, mac string
+	for _, part := range strings.Split(signature, ",") {
+		switch {
+		case strings.HasPrefix(part, "t="):
+			timestamp = strings.TrimPrefix(part, "t=")
+		case strings.HasPrefix(part, "v1="):
+			mac = strings.TrimPrefix(part, "v1=")
+		}
+	}
+	if timestamp == "" || mac == "" {
+		return false
+	}
+	return hmac.Equal([]byte(mac), []byte(webhookMAC(secret, timestamp, payload)))
+}
+
+func webhookMAC(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
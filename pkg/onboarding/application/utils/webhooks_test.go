@@ -0,0 +1,74 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	now := time.Now()
+	endpoint := &domain.WebhookEndpoint{ID: "endpoint-1"}
+	event := &domain.OutboxEvent{ID: "event-1", Type: domain.EventTypeUserCreated, Payload: `{"id":"event-1"}`}
+
+	delivery := utils.NewWebhookDelivery(endpoint, event, now)
+	assert.Equal(t, "endpoint-1-event-1", delivery.ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, event.Payload, delivery.Payload)
+	assert.Equal(t, now, delivery.NextAttempt)
+
+	// the same event and endpoint always make the same delivery
+	assert.Equal(t, delivery.ID, utils.NewWebhookDelivery(endpoint, event, now.Add(time.Hour)).ID)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	payload := []byte(`{"id":"event-1"}`)
+	timestamp := time.Unix(1622541600, 0)
+	signature := utils.SignWebhookPayload("secret", timestamp, payload)
+	assert.Contains(t, signature, "t=1622541600,v1=")
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		payload   []byte
+		want      bool
+	}{
+		{
+			name:      "Happy case:valid signature",
+			secret:    "secret",
+			signature: signature,
+			payload:   payload,
+			want:      true,
+		},
+		{
+			name:      "Sad case:wrong secret",
+			secret:    "another secret",
+			signature: signature,
+			payload:   payload,
+			want:      false,
+		},
+		{
+			name:      "Sad case:changed payload",
+			secret:    "secret",
+			signature: signature,
+			payload:   []byte(`{"id":"event-2"}`),
+			want:      false,
+		},
+		{
+			name:      "Sad case:malformed signature",
+			secret:    "secret",
+			signature: "v1=abc",
+			payload:   payload,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utils.VerifyWebhookSignature(tt.secret, tt.signature, tt.payload))
+		})
+	}
+}
@@ -19,7 +19,20 @@ var (
 
 	//PostgresRepository is the value of the env when using postgres
 	PostgresRepository = "postgres"
+
+	// MemoryRepository is the value of the env when using the in memory repository
+	MemoryRepository = "memory"
 )
 
 //WelcomeMessage is the default message formart for sending temporary PIN to users
 var WelcomeMessage = "Hi %s, welcome to Be.Well. Please use this One Time PIN: %s to log in using your phone number. You will be prompted to set a new PIN on login."
+
+// TempPINReissueMessage is the message format for sending a temporary PIN that was reissued by an admin
+var TempPINReissueMessage = "Hi %s, your new Be.Well One Time PIN is: %s. Please use it to log in using your phone number. You will be prompted to set a new PIN on login."
+
+// LoginAlertSubject is the subject of the email that tells a user about a login from a new device or network
+var LoginAlertSubject = "New login to your Be.Well account"
+
+// LoginAlertMessage is the message format for telling a user about a login from a new device or network.
+// It links to the "this wasn't me" flow
+var LoginAlertMessage = "Hi %s, your Be.Well account was just logged into from %s. If this wasn't you, tap %s to sign out of all your devices and reset your PIN."
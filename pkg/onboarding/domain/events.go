@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies the state change that a domain event describes.
+// It is also the name of the topic that the event is published to
+type EventType string
+
+// the domain events published by the service
+const (
+	EventTypeUserCreated    EventType = "user.created"
+	EventTypeUserSuspended  EventType = "user.suspended"
+	EventTypeRoleAssigned   EventType = "role.assigned"
+	EventTypeRoleRevoked    EventType = "role.revoked"
+	EventTypePINChanged     EventType = "pin.changed"
+	EventTypeContactChanged EventType = "contact.changed"
+	EventTypePINLocked      EventType = "pin.locked"
+	EventTypePINUnlocked    EventType = "pin.unlocked"
+	EventTypeUserUpgraded   EventType = "user.upgraded"
+	EventTypeUserMerged     EventType = "user.merged"
+)
+
+// AllEventTypes is a list of all the domain events published by the service
+var AllEventTypes = []EventType{
+	EventTypeUserCreated,
+	EventTypeUserSuspended,
+	EventTypeRoleAssigned,
+	EventTypeRoleRevoked,
+	EventTypePINChanged,
+	EventTypeContactChanged,
+	EventTypePINLocked,
+	EventTypePINUnlocked,
+	EventTypeUserUpgraded,
+	EventTypeUserMerged,
+}
+
+// EventSchemaVersion is the version of the schema of the published events
+const EventSchemaVersion = "v1"
+
+// OutboxEvent is a domain event that is stored in the outbox together with the state change
+// it describes. The outbox relay publishes it and marks it as published afterwards, so an
+// event can be delivered more than once but is never lost
+type OutboxEvent struct {
+	ID        string    `json:"id"        firestore:"id"`
+	Type      EventType `json:"type"      firestore:"type"`
+	ProfileID string    `json:"profileID" firestore:"profileID"`
+
+	// Payload is the JSON encoded Event that is published
+	Payload string `json:"payload" firestore:"payload"`
+
+	Created time.Time `json:"created" firestore:"created"`
+
+	// Published is when the event was handed over to pubsub. It is nil for pending events
+	Published *time.Time `json:"published,omitempty" firestore:"published"`
+
+	// Attempts is the number of times publishing the event has failed
+	Attempts  int    `json:"attempts"  firestore:"attempts"`
+	LastError string `json:"lastError" firestore:"lastError"`
+}
+
+// Event is the envelope that every domain event is published in. Consumers should use the
+// ID to ignore events that they have already processed
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	Version    string          `json:"version"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// UserCreatedEvent is the data of a `user.created` event
+type UserCreatedEvent struct {
+	ProfileID    string  `json:"profileID"`
+	UID          string  `json:"uid"`
+	PrimaryPhone *string `json:"primaryPhone"`
+	UserName     *string `json:"userName"`
+}
+
+// UserSuspendedEvent is the data of a `user.suspended` event. It is also published
+// when a user is unsuspended
+type UserSuspendedEvent struct {
+	ProfileID string `json:"profileID"`
+	Suspended bool   `json:"suspended"`
+}
+
+// RoleAssignedEvent is the data of a `role.assigned` event
+type RoleAssignedEvent struct {
+	ProfileID string `json:"profileID"`
+	RoleID    string `json:"roleID"`
+}
+
+// RoleRevokedEvent is the data of a `role.revoked` event
+type RoleRevokedEvent struct {
+	ProfileID string `json:"profileID"`
+	RoleID    string `json:"roleID"`
+}
+
+// PINChangedEvent is the data of a `pin.changed` event. The PIN itself is never published
+type PINChangedEvent struct {
+	ProfileID string `json:"profileID"`
+
+	// IsOTP is true when the new PIN is a temporary PIN
+	IsOTP bool `json:"isOTP"`
+}
+
+// ContactChangedEvent is the data of a `contact.changed` event. It has the contacts of the
+// user after the change
+type ContactChangedEvent struct {
+	ProfileID               string   `json:"profileID"`
+	PrimaryPhone            *string  `json:"primaryPhone"`
+	PrimaryEmailAddress     *string  `json:"primaryEmailAddress"`
+	SecondaryPhoneNumbers   []string `json:"secondaryPhoneNumbers"`
+	SecondaryEmailAddresses []string `json:"secondaryEmailAddresses"`
+}
+
+// PINLockedEvent is the data of a `pin.locked` event. It is published when a PIN is locked
+// after too many failed attempts
+type PINLockedEvent struct {
+	ProfileID      string    `json:"profileID"`
+	FailedAttempts int       `json:"failedAttempts"`
+	LockedUntil    time.Time `json:"lockedUntil"`
+}
+
+// PINUnlockedEvent is the data of a `pin.unlocked` event. It is published when a locked PIN
+// is unlocked by an admin. A PIN that is reset is unlocked as well, with a `pin.changed` event
+type PINUnlockedEvent struct {
+	ProfileID string `json:"profileID"`
+}
+
+// UserUpgradedEvent is the data of a `user.upgraded` event. It is published together with the
+// `user.created` event of a profile that was created for an anonymous user. The UID is the one
+// that the anonymous user signed in with, so data kept against it belongs to the profile
+type UserUpgradedEvent struct {
+	ProfileID    string  `json:"profileID"`
+	UID          string  `json:"uid"`
+	PrimaryPhone *string `json:"primaryPhone"`
+}
+
+// UserMergedEvent is the data of a `user.merged` event. It is published when a duplicate user
+// profile is merged into another one. Data kept against the merged profile ID belongs to the
+// surviving profile
+type UserMergedEvent struct {
+	ProfileID       string `json:"profileID"`
+	MergedProfileID string `json:"mergedProfileID"`
+}
+
+// PubSubMessageStatus is the outcome of processing a message received from pubsub
+type PubSubMessageStatus string
+
+// the outcomes of processing a received message
+const (
+	// PubSubMessageStatusProcessed is a message that has been handled. Redeliveries of
+	// the message are ignored
+	PubSubMessageStatusProcessed PubSubMessageStatus = "PROCESSED"
+
+	// PubSubMessageStatusFailed is a message whose handler failed. Pubsub delivers it again
+	PubSubMessageStatusFailed PubSubMessageStatus = "FAILED"
+
+	// PubSubMessageStatusDeadLettered is a message that could not be handled and will not be
+	// retried. It has been published to the dead letter topic
+	PubSubMessageStatusDeadLettered PubSubMessageStatus = "DEAD_LETTERED"
+)
+
+// PubSubMessage is the record of a message received from pubsub. It is keyed by the ID that
+// pubsub gave the message so that a message that is delivered more than once is handled once
+type PubSubMessage struct {
+	ID      string `json:"id"      firestore:"id"`
+	TopicID string `json:"topicID" firestore:"topicID"`
+
+	// Data is the data of the message as it was received
+	Data string `json:"data" firestore:"data"`
+
+	Status PubSubMessageStatus `json:"status" firestore:"status"`
+
+	// Attempts is the number of times the message has been handled
+	Attempts  int    `json:"attempts"  firestore:"attempts"`
+	LastError string `json:"lastError" firestore:"lastError"`
+
+	Created time.Time `json:"created" firestore:"created"`
+	Updated time.Time `json:"updated" firestore:"updated"`
+}
+
+// AccountDeletionRequestedEvent is the data of an `account.deletion.requested` message. Other
+// services publish it when a user asks them to delete their account. The account is found by
+// the UID and, failing that, by the phone number
+type AccountDeletionRequestedEvent struct {
+	UID         string  `json:"uid"`
+	PhoneNumber *string `json:"phoneNumber"`
+	Reason      string  `json:"reason"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// LoginAlertReason is why a successful login is unusual enough for the user to be told about it
+type LoginAlertReason string
+
+// the reasons that a user is alerted about a login
+const (
+	LoginAlertReasonNewDevice  LoginAlertReason = "NEW_DEVICE"
+	LoginAlertReasonNewIPRange LoginAlertReason = "NEW_IP_RANGE"
+)
+
+// LoginAlert is sent to a user when they log in from a device or network that they had not logged
+// in from before. It holds the token of the "this wasn't me" link that is sent with it
+type LoginAlert struct {
+	ID        string `json:"id"        firestore:"id"`
+	ProfileID string `json:"profileID" firestore:"profileID"`
+
+	// SessionID is the session that was created by the login
+	SessionID string `json:"sessionID" firestore:"sessionID"`
+
+	// TokenHash is the SHA-256 hash of the token of the "this wasn't me" link.
+	// The token itself is not stored
+	TokenHash string `json:"tokenHash" firestore:"tokenHash"`
+
+	Reasons   []LoginAlertReason `json:"reasons"   firestore:"reasons"`
+	DeviceID  string             `json:"deviceID"  firestore:"deviceID"`
+	Platform  string             `json:"platform"  firestore:"platform"`
+	IPAddress string             `json:"ipAddress" firestore:"ipAddress"`
+	UserAgent string             `json:"userAgent" firestore:"userAgent"`
+
+	Created time.Time `json:"created" firestore:"created"`
+
+	// ExpiresAt is when the "this wasn't me" link stops working
+	ExpiresAt time.Time `json:"expiresAt" firestore:"expiresAt"`
+
+	// Reported is when the user reported that they did not make the login
+	Reported *time.Time `json:"reported,omitempty" firestore:"reported"`
+}
+
+// IsExpired checks whether the "this wasn't me" link of the alert has expired at the provided time
+func (a *LoginAlert) IsExpired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
+
+// HasReason checks whether the user was alerted for the provided reason
+func (a *LoginAlert) HasReason(reason LoginAlertReason) bool {
+	for _, r := range a.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
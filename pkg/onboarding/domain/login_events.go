@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+)
+
+// LoginMethod is the way that a user attempted to log in
+type LoginMethod string
+
+// the ways that a user can log in
+const (
+	LoginMethodPhone         LoginMethod = "PHONE"
+	LoginMethodAnonymous     LoginMethod = "ANONYMOUS"
+	LoginMethodRefreshToken  LoginMethod = "REFRESH_TOKEN"
+	LoginMethodResumeWithPIN LoginMethod = "RESUME_WITH_PIN"
+	LoginMethodEmail         LoginMethod = "EMAIL"
+	LoginMethodSocial        LoginMethod = "SOCIAL"
+)
+
+// LoginOutcome is whether an attempt to log in succeeded
+type LoginOutcome string
+
+// the outcomes of an attempt to log in
+const (
+	LoginOutcomeSucceeded LoginOutcome = "SUCCEEDED"
+	LoginOutcomeFailed    LoginOutcome = "FAILED"
+)
+
+// LoginFailureReason is why an attempt to log in failed
+type LoginFailureReason string
+
+// the reasons that an attempt to log in can fail for
+const (
+	LoginFailureReasonPINMismatch      LoginFailureReason = "PIN_MISMATCH"
+	LoginFailureReasonPINLocked        LoginFailureReason = "PIN_LOCKED"
+	LoginFailureReasonPINExpired       LoginFailureReason = "PIN_EXPIRED"
+	LoginFailureReasonPINReset         LoginFailureReason = "PIN_RESET_REQUIRED"
+	LoginFailureReasonSuspended        LoginFailureReason = "SUSPENDED"
+	LoginFailureReasonNotFound         LoginFailureReason = "NOT_FOUND"
+	LoginFailureReasonSessionRevoked   LoginFailureReason = "SESSION_REVOKED"
+	LoginFailureReasonSecondFactor     LoginFailureReason = "SECOND_FACTOR"
+	LoginFailureReasonPasswordMismatch LoginFailureReason = "PASSWORD_MISMATCH"
+	LoginFailureReasonPasswordLocked   LoginFailureReason = "PASSWORD_LOCKED"
+	LoginFailureReasonPasswordReset    LoginFailureReason = "PASSWORD_RESET_REQUIRED"
+	LoginFailureReasonSocialToken      LoginFailureReason = "INVALID_SOCIAL_TOKEN"
+	LoginFailureReasonOther            LoginFailureReason = "OTHER"
+)
+
+// LoginEvent records an attempt to log in. Login events are only ever appended; they are
+// the audit trail that security investigations rely on
+type LoginEvent struct {
+	ID      string       `json:"id"      firestore:"id"`
+	Method  LoginMethod  `json:"method"  firestore:"method"`
+	Outcome LoginOutcome `json:"outcome" firestore:"outcome"`
+
+	// Reason is why a failed attempt failed. It is empty for attempts that succeeded
+	Reason LoginFailureReason `json:"reason,omitempty" firestore:"reason"`
+
+	// ProfileID and UID are the user that attempted to log in, when they are known
+	ProfileID string `json:"profileID,omitempty" firestore:"profileID"`
+	UID       string `json:"uid,omitempty"       firestore:"uid"`
+
+	// MaskedPhone is the phone number that was used to log in, with its middle digits masked
+	MaskedPhone string `json:"maskedPhone,omitempty" firestore:"maskedPhone"`
+
+	Flavour   feedlib.Flavour `json:"flavour,omitempty"   firestore:"flavour"`
+	DeviceID  string          `json:"deviceID,omitempty"  firestore:"deviceID"`
+	IPAddress string          `json:"ipAddress,omitempty" firestore:"ipAddress"`
+	UserAgent string          `json:"userAgent,omitempty" firestore:"userAgent"`
+	Timestamp time.Time       `json:"timestamp"           firestore:"timestamp"`
+}
+
+// LoginEventFilter selects the login events that are listed. Unset fields match every event
+type LoginEventFilter struct {
+	// From is the earliest time of the listed events, inclusive
+	From *time.Time
+
+	// To is the latest time of the listed events, exclusive
+	To *time.Time
+
+	Outcome   *LoginOutcome
+	ProfileID *string
+
+	// Limit is the maximum number of events that are listed
+	Limit int
+}
+
+// Matches checks whether a login event is selected by the filter. The limit is not considered
+func (f *LoginEventFilter) Matches(event *LoginEvent) bool {
+	if f.From != nil && event.Timestamp.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !event.Timestamp.Before(*f.To) {
+		return false
+	}
+	if f.Outcome != nil && event.Outcome != *f.Outcome {
+		return false
+	}
+	if f.ProfileID != nil && event.ProfileID != *f.ProfileID {
+		return false
+	}
+	return true
+}
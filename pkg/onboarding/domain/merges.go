@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/profileutils"
+)
+
+// ProfileMerge is the audit entry of merging a duplicate user profile into another one. The
+// merged profile is removed and the surviving profile takes over its contacts, logins, roles
+// and push tokens
+type ProfileMerge struct {
+	ID              string `json:"id"              firestore:"id"`
+	SurvivorID      string `json:"survivorID"      firestore:"survivorID"`
+	MergedProfileID string `json:"mergedProfileID" firestore:"mergedProfileID"`
+
+	// Survivor and MergedProfile are the two profiles as they were before the merge
+	Survivor      *profileutils.UserProfile `json:"survivor"      firestore:"survivor"`
+	MergedProfile *profileutils.UserProfile `json:"mergedProfile" firestore:"mergedProfile"`
+
+	// Result is the surviving profile after the merge
+	Result *profileutils.UserProfile `json:"result" firestore:"result"`
+
+	Reason string `json:"reason" firestore:"reason"`
+
+	// DryRun marks a merge that was only previewed. Such a merge is never stored
+	DryRun bool `json:"dryRun" firestore:"dryRun"`
+
+	// MergedBy is the profile ID of the admin who merged the profiles
+	MergedBy string    `json:"mergedBy" firestore:"mergedBy"`
+	Created  time.Time `json:"created"  firestore:"created"`
+}
+
+// ProfileTombstone is left behind by a user profile that has been merged into another one. It
+// points to the profile that the merged profile lives on in
+type ProfileTombstone struct {
+	ProfileID  string    `json:"profileID"  firestore:"profileID"`
+	MergedInto string    `json:"mergedInto" firestore:"mergedInto"`
+	MergeID    string    `json:"mergeID"    firestore:"mergeID"`
+	Created    time.Time `json:"created"    firestore:"created"`
+}
@@ -14,8 +14,129 @@ type PIN struct {
 	PINNumber string `json:"pinNumber" firestore:"pinNumber"`
 	Salt      string `json:"salt"      firestore:"salt"`
 
+	// Scheme is the algorithm and parameters that the PIN was hashed with. PINs that were saved
+	// without a scheme were hashed with the legacy PBKDF2-SHA512 scheme
+	Scheme *PINHashScheme `json:"scheme,omitempty" firestore:"scheme"`
+
 	// Flags the PIN as temporary and should be changed by user
 	IsOTP bool `json:"isOTP" firestore:"isOTP"`
+
+	// Issued is when a temporary PIN was generated
+	Issued *time.Time `json:"issued,omitempty" firestore:"issued"`
+
+	// ExpiresAt is when a temporary PIN stops being accepted
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" firestore:"expiresAt"`
+
+	// FailedAttempts is the number of consecutive wrong attempts to use the PIN
+	FailedAttempts int `json:"failedAttempts" firestore:"failedAttempts"`
+
+	// LastFailedAttempt is when the PIN was last entered wrongly
+	LastFailedAttempt *time.Time `json:"lastFailedAttempt,omitempty" firestore:"lastFailedAttempt"`
+
+	// LockedUntil is when a PIN that was locked after too many failed attempts can be used again
+	LockedUntil *time.Time `json:"lockedUntil,omitempty" firestore:"lockedUntil"`
+
+	// ResetRequired flags a PIN that can't be used until it is reset with an OTP, e.g. after the
+	// user reported a login that they did not make
+	ResetRequired bool `json:"resetRequired,omitempty" firestore:"resetRequired"`
+
+	// History holds the PINs that were used before this one, most recent first.
+	// A new PIN can't match any of them
+	History []PINHistoryEntry `json:"history,omitempty" firestore:"history"`
+}
+
+// PINHistoryEntry is a PIN that was used before the current PIN of a profile
+type PINHistoryEntry struct {
+	PINNumber string         `json:"pinNumber"        firestore:"pinNumber"`
+	Salt      string         `json:"salt"             firestore:"salt"`
+	Scheme    *PINHashScheme `json:"scheme,omitempty" firestore:"scheme"`
+	Replaced  time.Time      `json:"replaced"         firestore:"replaced"`
+}
+
+// PINHashScheme records how a PIN was hashed so that it can be compared with the same algorithm
+// and parameters, and rehashed once they are outdated
+type PINHashScheme struct {
+	// Algorithm is one of `pbkdf2-sha512`, `argon2id` or `bcrypt`
+	Algorithm string `json:"algorithm" firestore:"algorithm"`
+
+	// Iterations is the iteration count of PBKDF2 or the time cost of argon2id
+	Iterations int `json:"iterations,omitempty" firestore:"iterations"`
+
+	// Memory is the memory cost of argon2id in KiB
+	Memory int `json:"memory,omitempty" firestore:"memory"`
+
+	// Threads is the parallelism of argon2id
+	Threads int `json:"threads,omitempty" firestore:"threads"`
+
+	// Cost is the cost factor of bcrypt
+	Cost int `json:"cost,omitempty" firestore:"cost"`
+
+	SaltLen int `json:"saltLen,omitempty" firestore:"saltLen"`
+	KeyLen  int `json:"keyLen,omitempty"  firestore:"keyLen"`
+}
+
+// IsLocked checks whether the PIN is locked at the provided time
+func (p *PIN) IsLocked(now time.Time) bool {
+	return p.LockedUntil != nil && now.Before(*p.LockedUntil)
+}
+
+// IsExpired checks whether a temporary PIN has expired at the provided time.
+// Temporary PINs that were issued without an expiry time are treated as expired
+func (p *PIN) IsExpired(now time.Time) bool {
+	if !p.IsOTP {
+		return false
+	}
+	return p.ExpiresAt == nil || !now.Before(*p.ExpiresAt)
+}
+
+// UserAccount groups the records that are created when a user is onboarded.
+// They are persisted as a single unit so that a failed signup does not leave a profile
+// without its PIN or communications settings
+type UserAccount struct {
+	// UID identifies the auth user the account signs in with
+	UID string `json:"uid"`
+
+	// NewAuthUser flags the auth user as created together with the account.
+	// Only such auth users are removed when the account is rolled back
+	NewAuthUser bool `json:"newAuthUser"`
+
+	// AnonymousUID is the UID of the anonymous auth user that the account is created for. The
+	// phone number or email address is added to that auth user so that the UID does not change
+	AnonymousUID string `json:"anonymousUID"`
+
+	Profile                *profileutils.UserProfile               `json:"profile"`
+	PIN                    *PIN                                    `json:"pin"`
+	Password               *Password                               `json:"password"`
+	CommunicationsSettings *profileutils.UserCommunicationsSetting `json:"communicationsSettings"`
+}
+
+// IdentifierKind is the kind of identifier that a user profile can be found by
+type IdentifierKind string
+
+// the identifiers that are unique to a single user profile
+const (
+	IdentifierKindPhone    IdentifierKind = "PHONE"
+	IdentifierKindEmail    IdentifierKind = "EMAIL"
+	IdentifierKindUsername IdentifierKind = "USERNAME"
+	IdentifierKindSocial   IdentifierKind = "SOCIAL"
+)
+
+// IdentifierReservation claims a phone number, email address, username or social account for a user profile.
+// There is at most one reservation for every normalized identifier. Reservations are written in
+// the same transaction as the profile so that two profiles can never claim the same identifier
+type IdentifierReservation struct {
+	// ID is derived from the kind and the normalized identifier
+	ID string `json:"id" firestore:"id"`
+
+	Kind IdentifierKind `json:"kind" firestore:"kind"`
+
+	// Identifier is the normalized phone number, email address, username or social UID
+	Identifier string `json:"identifier" firestore:"identifier"`
+
+	// ProfileID is the profile that the identifier belongs to
+	ProfileID string `json:"profileID" firestore:"profileID"`
+
+	Created time.Time `json:"created" firestore:"created"`
 }
 
 // SetPINRequest payload to set PIN information
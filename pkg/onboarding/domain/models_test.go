@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+func TestPIN_IsExpired(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		pin  domain.PIN
+		want bool
+	}{
+		{name: "a PIN chosen by the user does not expire", pin: domain.PIN{ExpiresAt: &earlier}, want: false},
+		{name: "temporary PIN before its expiry", pin: domain.PIN{IsOTP: true, ExpiresAt: &later}, want: false},
+		{name: "temporary PIN after its expiry", pin: domain.PIN{IsOTP: true, ExpiresAt: &earlier}, want: true},
+		{name: "temporary PIN at its expiry", pin: domain.PIN{IsOTP: true, ExpiresAt: &now}, want: true},
+		{name: "temporary PIN without an expiry", pin: domain.PIN{IsOTP: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pin.IsExpired(now); got != tt.want {
+				t.Errorf("PIN.IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
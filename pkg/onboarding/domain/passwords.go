@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/profileutils"
+)
+
+// LoginProviderTypeEmail is the login provider of the verified identifiers of users who log in
+// with an email address and password
+const LoginProviderTypeEmail profileutils.LoginProviderType = "EMAIL"
+
+// Password is the password of an email login. A user profile has at most one email login,
+// which is for the email address that the user verified when they added it
+type Password struct {
+	ID        string `json:"id"        firestore:"id"`
+	ProfileID string `json:"profileID" firestore:"profileID"`
+
+	// EmailAddress is the normalized email address that the user logs in with
+	EmailAddress string `json:"emailAddress" firestore:"emailAddress"`
+
+	PasswordHash string `json:"passwordHash" firestore:"passwordHash"`
+	Salt         string `json:"salt"         firestore:"salt"`
+
+	// Scheme is the algorithm and parameters that the password was hashed with. Passwords are
+	// hashed with the same schemes as PINs
+	Scheme *PINHashScheme `json:"scheme,omitempty" firestore:"scheme"`
+
+	// FailedAttempts is the number of consecutive wrong attempts to use the password
+	FailedAttempts int `json:"failedAttempts" firestore:"failedAttempts"`
+
+	// LastFailedAttempt is when the password was last entered wrongly
+	LastFailedAttempt *time.Time `json:"lastFailedAttempt,omitempty" firestore:"lastFailedAttempt"`
+
+	// LockedUntil is when a password that was locked after too many failed attempts can be
+	// used again
+	LockedUntil *time.Time `json:"lockedUntil,omitempty" firestore:"lockedUntil"`
+
+	// ResetRequired flags a password that can't be used until it is reset with an OTP, e.g. after
+	// the user reported a login that they did not make
+	ResetRequired bool `json:"resetRequired,omitempty" firestore:"resetRequired"`
+
+	Created time.Time `json:"created" firestore:"created"`
+	Updated time.Time `json:"updated" firestore:"updated"`
+}
+
+// IsLocked checks whether the password is locked at the provided time
+func (p *Password) IsLocked(now time.Time) bool {
+	return p.LockedUntil != nil && now.Before(*p.LockedUntil)
+}
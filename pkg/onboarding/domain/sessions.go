@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+)
+
+// ClientInfo describes the device and app that a request is made from. It is sent by the apps
+// in request headers, except for the IP address and user agent
+type ClientInfo struct {
+	DeviceID   string `json:"deviceID"`
+	Platform   string `json:"platform"`
+	AppVersion string `json:"appVersion"`
+	IPAddress  string `json:"ipAddress"`
+	UserAgent  string `json:"userAgent"`
+}
+
+// Session is a device that a user is signed in on. It is created when the user logs in and is
+// seen again every time its refresh token is exchanged for a new ID token
+type Session struct {
+	ID        string `json:"id"        firestore:"id"`
+	ProfileID string `json:"profileID" firestore:"profileID"`
+
+	// UID is the auth user that the session's tokens were issued to
+	UID string `json:"uid" firestore:"uid"`
+
+	// RefreshTokenHash is the SHA-256 hash of the refresh token that the session holds.
+	// The refresh token itself is not stored
+	RefreshTokenHash string `json:"refreshTokenHash" firestore:"refreshTokenHash"`
+
+	DeviceID   string `json:"deviceID"   firestore:"deviceID"`
+	Platform   string `json:"platform"   firestore:"platform"`
+	AppVersion string `json:"appVersion" firestore:"appVersion"`
+	IPAddress  string `json:"ipAddress"  firestore:"ipAddress"`
+	UserAgent  string `json:"userAgent"  firestore:"userAgent"`
+
+	// Flavour is the app that the user logged in to. It is carried over to the access tokens that
+	// are issued when the session's refresh token is exchanged
+	Flavour feedlib.Flavour `json:"flavour,omitempty" firestore:"flavour"`
+
+	Created  time.Time `json:"created"  firestore:"created"`
+	LastSeen time.Time `json:"lastSeen" firestore:"lastSeen"`
+
+	// Revoked is when the user or an admin signed the device out. The refresh token of a revoked
+	// session can't be exchanged for a new ID token
+	Revoked *time.Time `json:"revoked,omitempty" firestore:"revoked"`
+}
+
+// IsRevoked checks whether the session has been signed out
+func (s *Session) IsRevoked() bool {
+	return s.Revoked != nil
+}
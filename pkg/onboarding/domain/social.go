@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"github.com/savannahghi/profileutils"
+)
+
+// SocialIdentity is the account of a user with a social login provider, as asserted by an ID
+// token that the provider issued
+type SocialIdentity struct {
+	Provider profileutils.LoginProviderType `json:"provider"`
+
+	// Subject identifies the user's account with the provider. It never changes, unlike the
+	// email address
+	Subject string `json:"subject"`
+
+	EmailAddress  string `json:"emailAddress,omitempty"`
+	EmailVerified bool   `json:"emailVerified"`
+	Name          string `json:"name,omitempty"`
+}
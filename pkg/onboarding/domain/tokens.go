@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+)
+
+// SigningKey is an RSA key that the service signs its access tokens with. The newest key signs
+// new tokens, older keys are published until the tokens that they signed have expired
+type SigningKey struct {
+	// ID is the `kid` that the tokens signed with the key name
+	ID        string `json:"id"        firestore:"id"`
+	Algorithm string `json:"algorithm" firestore:"algorithm"`
+
+	// EncryptedPrivateKey is the PKCS #1 private key encrypted with the data key of the signing key
+	EncryptedPrivateKey string `json:"encryptedPrivateKey" firestore:"encryptedPrivateKey"`
+
+	// EncryptedDataKey is the data key encrypted with the key encryption key of the service
+	EncryptedDataKey string `json:"encryptedDataKey" firestore:"encryptedDataKey"`
+
+	Created time.Time `json:"created" firestore:"created"`
+
+	// NotAfter is when the key stops being published in the JSON Web Key Set
+	NotAfter time.Time `json:"notAfter" firestore:"notAfter"`
+}
+
+// AccessTokenClaims are what an access token issued by the service says about the user it was
+// issued to
+type AccessTokenClaims struct {
+	UID       string          `json:"uid"`
+	ProfileID string          `json:"profileID"`
+	Flavour   feedlib.Flavour `json:"flavour,omitempty"`
+
+	// SessionID is the session in the sessions registry that the token was issued for. The token
+	// is no longer active once the session is signed out
+	SessionID string `json:"sessionID,omitempty"`
+
+	// Scopes are the permissions of the roles that the user has
+	Scopes []string `json:"scopes"`
+
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AccessToken is a signed access token issued by the service
+type AccessToken struct {
+	Token     string    `json:"token"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// JSONWebKey is the public part of a signing key as published in the JSON Web Key Set
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JSONWebKeySet is the set of public keys that other services verify the access tokens of the
+// service with. It is published at `/.well-known/jwks.json`
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
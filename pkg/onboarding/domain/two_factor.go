@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// TwoFactorPolicyID is the ID of the only two factor policy of the service
+const TwoFactorPolicyID = "default"
+
+// TOTPEnrolment is a user's time-based one-time password (RFC 6238) authenticator. An enrolment is
+// pending until the user confirms it with a code from their authenticator app. Only confirmed
+// enrolments are checked when the user logs in
+type TOTPEnrolment struct {
+	ID        string `json:"id"        firestore:"id"`
+	ProfileID string `json:"profileID" firestore:"profileID"`
+
+	// Secret is the base32 encoded key that the codes are generated with. It is needed to check
+	// the codes so it can't be hashed. It is never stored, only its encrypted form is
+	Secret string `json:"-" firestore:"-"`
+
+	// EncryptedSecret is the secret encrypted with the data key of the enrolment
+	EncryptedSecret string `json:"encryptedSecret" firestore:"encryptedSecret"`
+
+	// EncryptedDataKey is the data key encrypted with the key encryption key of the service
+	EncryptedDataKey string `json:"encryptedDataKey" firestore:"encryptedDataKey"`
+
+	// RecoveryCodeHashes are the SHA-256 hashes of the recovery codes that have not been used yet.
+	// Each recovery code can be used once in place of a code from the authenticator app
+	RecoveryCodeHashes []string `json:"recoveryCodeHashes" firestore:"recoveryCodeHashes"`
+
+	// LastUsedStep is the time step of the last code that was accepted. A code can't be used twice
+	LastUsedStep int64 `json:"lastUsedStep" firestore:"lastUsedStep"`
+
+	Created   time.Time  `json:"created"             firestore:"created"`
+	Confirmed *time.Time `json:"confirmed,omitempty" firestore:"confirmed"`
+}
+
+// IsConfirmed checks whether the user has confirmed the enrolment with a code from their
+// authenticator app
+func (e *TOTPEnrolment) IsConfirmed() bool {
+	return e.Confirmed != nil
+}
+
+// TOTPProvisioning is what a user needs to add a TOTP enrolment to their authenticator app.
+// The recovery codes are only shown once
+type TOTPProvisioning struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// TwoFactorPolicy decides which users must log in with a second factor. A user whose roles hold
+// any of the required scopes can't log in without one
+type TwoFactorPolicy struct {
+	ID             string    `json:"id"             firestore:"id"`
+	RequiredScopes []string  `json:"requiredScopes" firestore:"requiredScopes"`
+	Updated        time.Time `json:"updated"        firestore:"updated"`
+	UpdatedBy      string    `json:"updatedBy"      firestore:"updatedBy"`
+}
+
+// TwoFactorStatus is whether a user has enrolled in, and must use, two factor authentication
+type TwoFactorStatus struct {
+	Enrolled          bool `json:"enrolled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// WebhookEndpoint is an endpoint of a partner system that the domain events are delivered to.
+// It is an alternative to subscribing to the pubsub topics of the events
+type WebhookEndpoint struct {
+	ID  string `json:"id"  firestore:"id"`
+	URL string `json:"url" firestore:"url"`
+
+	// Secret signs the deliveries to the endpoint. It is not exposed through the API
+	Secret string `json:"secret" firestore:"secret"`
+
+	// EventTypes are the events that are delivered to the endpoint
+	EventTypes []EventType `json:"eventTypes" firestore:"eventTypes"`
+
+	Active  bool      `json:"active"  firestore:"active"`
+	Created time.Time `json:"created" firestore:"created"`
+}
+
+// Subscribes checks whether an event type is delivered to the endpoint
+func (e *WebhookEndpoint) Subscribes(eventType EventType) bool {
+	for _, subscribed := range e.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is the state of the delivery of an event to a webhook endpoint
+type WebhookDeliveryStatus string
+
+// the states of a webhook delivery
+const (
+	// WebhookDeliveryStatusPending is a delivery that has not succeeded yet and will be attempted
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "PENDING"
+
+	// WebhookDeliveryStatusSucceeded is a delivery that the endpoint acknowledged
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+
+	// WebhookDeliveryStatusFailed is a delivery that is no longer retried. It can be redelivered manually
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookDelivery is the delivery of a domain event to a webhook endpoint. There is one
+// delivery for every event and endpoint; it is the log of the attempts to deliver the event
+type WebhookDelivery struct {
+	ID         string    `json:"id"         firestore:"id"`
+	EndpointID string    `json:"endpointID" firestore:"endpointID"`
+	EventID    string    `json:"eventID"    firestore:"eventID"`
+	EventType  EventType `json:"eventType"  firestore:"eventType"`
+
+	// Payload is the JSON encoded Event that is delivered
+	Payload string `json:"payload" firestore:"payload"`
+
+	Status   WebhookDeliveryStatus `json:"status"   firestore:"status"`
+	Attempts int                   `json:"attempts" firestore:"attempts"`
+
+	// ResponseStatus is the HTTP status code of the last attempt. It is 0 when the endpoint could not be reached
+	ResponseStatus int    `json:"responseStatus" firestore:"responseStatus"`
+	LastError      string `json:"lastError"      firestore:"lastError"`
+
+	// NextAttempt is when a pending delivery is attempted next
+	NextAttempt time.Time  `json:"nextAttempt"         firestore:"nextAttempt"`
+	Delivered   *time.Time `json:"delivered,omitempty" firestore:"delivered"`
+	Created     time.Time  `json:"created"             firestore:"created"`
+}
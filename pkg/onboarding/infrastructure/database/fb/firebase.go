@@ -11,11 +11,13 @@ import (
 	"net/http"
 	"time"
 
+	"cloud.google.com/go/firestore"
 	"firebase.google.com/go/auth"
 	"github.com/google/uuid"
 
 	"github.com/savannahghi/converterandformatter"
 	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
@@ -46,6 +48,20 @@ const (
 	firebaseExchangeRefreshTokenURL      = "https://securetoken.googleapis.com/v1/token?key="
 	rolesRevocationCollectionName        = "role_revocations"
 	rolesCollectionName                  = "user_roles"
+	identifierReservationsCollectionName = "identifier_reservations"
+	outboxEventsCollectionName           = "outbox_events"
+	pubSubMessagesCollectionName         = "pubsub_messages"
+	webhookEndpointsCollectionName       = "webhook_endpoints"
+	webhookDeliveriesCollectionName      = "webhook_deliveries"
+	sessionsCollectionName               = "sessions"
+	loginEventsCollectionName            = "login_events"
+	loginAlertsCollectionName            = "login_alerts"
+	totpEnrolmentsCollectionName         = "totp_enrolments"
+	twoFactorPoliciesCollectionName      = "two_factor_policies"
+	passwordsCollectionName              = "passwords"
+	profileMergesCollectionName          = "profile_merges"
+	profileTombstonesCollectionName      = "profile_tombstones"
+	signingKeysCollectionName            = "signing_keys"
 )
 
 // Repository accesses and updates an item that is stored on Firebase
@@ -113,6 +129,90 @@ func (fr Repository) GetRolesRevocationCollectionName() string {
 	return suffixed
 }
 
+// GetIdentifierReservationsCollectionName ...
+func (fr Repository) GetIdentifierReservationsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(identifierReservationsCollectionName)
+	return suffixed
+}
+
+// GetOutboxEventsCollectionName ...
+func (fr Repository) GetOutboxEventsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(outboxEventsCollectionName)
+	return suffixed
+}
+
+// GetPubSubMessagesCollectionName ...
+func (fr Repository) GetPubSubMessagesCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(pubSubMessagesCollectionName)
+	return suffixed
+}
+
+// GetWebhookEndpointsCollectionName ...
+func (fr Repository) GetWebhookEndpointsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(webhookEndpointsCollectionName)
+	return suffixed
+}
+
+// GetWebhookDeliveriesCollectionName ...
+func (fr Repository) GetWebhookDeliveriesCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(webhookDeliveriesCollectionName)
+	return suffixed
+}
+
+// GetSessionsCollectionName ...
+func (fr Repository) GetSessionsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(sessionsCollectionName)
+	return suffixed
+}
+
+// GetLoginEventsCollectionName ...
+func (fr Repository) GetLoginEventsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(loginEventsCollectionName)
+	return suffixed
+}
+
+// GetLoginAlertsCollectionName ...
+func (fr Repository) GetLoginAlertsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(loginAlertsCollectionName)
+	return suffixed
+}
+
+// GetTOTPEnrolmentsCollectionName ...
+func (fr Repository) GetTOTPEnrolmentsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(totpEnrolmentsCollectionName)
+	return suffixed
+}
+
+// GetTwoFactorPoliciesCollectionName ...
+func (fr Repository) GetTwoFactorPoliciesCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(twoFactorPoliciesCollectionName)
+	return suffixed
+}
+
+// GetPasswordsCollectionName ...
+func (fr Repository) GetPasswordsCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(passwordsCollectionName)
+	return suffixed
+}
+
+// GetProfileMergesCollectionName ...
+func (fr Repository) GetProfileMergesCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(profileMergesCollectionName)
+	return suffixed
+}
+
+// GetProfileTombstonesCollectionName ...
+func (fr Repository) GetProfileTombstonesCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(profileTombstonesCollectionName)
+	return suffixed
+}
+
+// GetSigningKeysCollectionName ...
+func (fr Repository) GetSigningKeysCollectionName() string {
+	suffixed := firebasetools.SuffixCollection(signingKeysCollectionName)
+	return suffixed
+}
+
 // GetUserProfileByUID retrieves the user profile by UID
 func (fr *Repository) GetUserProfileByUID(
 	ctx context.Context,
@@ -307,37 +407,19 @@ func (fr *Repository) UpdateUserProfileEmail(
 		// this is a wrapped error. No need to wrap it again
 		return err
 	}
-	profile.PrimaryEmailAddress = &email
-
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "primaryPhone",
-		Value:          profile.PrimaryPhone,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, profile.ID, true)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
+	profile.PrimaryEmailAddress = &email
 
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile primary phone number: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -352,6 +434,21 @@ func (fr *Repository) GetUserProfileByID(
 	ctx, span := tracer.Start(ctx, "GetUserProfileByID")
 	defer span.End()
 
+	userProfile, _, err := fr.getUserProfileDocument(ctx, id, suspended)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return userProfile, nil
+}
+
+// getUserProfileDocument fetches the user profile that matches the id together with its document
+func (fr *Repository) getUserProfileDocument(
+	ctx context.Context,
+	id string,
+	suspended bool,
+) (*profileutils.UserProfile, *firestore.DocumentSnapshot, error) {
 	query := &GetAllQuery{
 		CollectionName: fr.GetUserProfileCollectionName(),
 		FieldName:      "id",
@@ -360,22 +457,20 @@ func (fr *Repository) GetUserProfileByID(
 	}
 	docs, err := fr.FirestoreClient.GetAll(ctx, query)
 	if err != nil {
-		utils.RecordSpanError(span, err)
-		return nil, exceptions.InternalServerError(err)
+		return nil, nil, exceptions.InternalServerError(err)
 	}
 	if len(docs) > 1 && serverutils.IsDebug() {
 		log.Printf("> 1 profile with id %s (count: %d)", id, len(docs))
 	}
 
 	if len(docs) == 0 {
-		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+		return nil, nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
 	}
 	dsnap := docs[0]
 	userProfile := &profileutils.UserProfile{}
 	err = dsnap.DataTo(userProfile)
 	if err != nil {
-		utils.RecordSpanError(span, err)
-		return nil, exceptions.InternalServerError(
+		return nil, nil, exceptions.InternalServerError(
 			fmt.Errorf("unable to read user profile: %w", err),
 		)
 	}
@@ -383,10 +478,305 @@ func (fr *Repository) GetUserProfileByID(
 	if !suspended {
 		// never return a suspended user profile
 		if userProfile.Suspended {
-			return nil, exceptions.ProfileSuspendFoundError()
+			return nil, nil, exceptions.ProfileSuspendFoundError()
 		}
 	}
-	return userProfile, nil
+	return userProfile, dsnap, nil
+}
+
+// getUserProfileForUpdate fetches the user profile that matches the id so that it can be changed and
+// written back with updateUserProfileDocument. It returns a conflict error when the profile is no
+// longer in the state the caller read it in
+func (fr *Repository) getUserProfileForUpdate(
+	ctx context.Context,
+	id string,
+	suspended bool,
+) (*profileutils.UserProfile, *firestore.DocumentSnapshot, error) {
+	userProfile, dsnap, err := fr.getUserProfileDocument(ctx, id, suspended)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	if err := utils.CheckProfileVersion(ctx, userProfile); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	return userProfile, dsnap, nil
+}
+
+// getVersionedUserProfileForUpdate is getUserProfileForUpdate for the changes that are derived from
+// an earlier read of the profile. They are refused when the context has no expected version of the
+// profile
+func (fr *Repository) getVersionedUserProfileForUpdate(
+	ctx context.Context,
+	id string,
+	suspended bool,
+) (*profileutils.UserProfile, *firestore.DocumentSnapshot, error) {
+	userProfile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, suspended)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	if err := utils.RequireProfileVersion(ctx, userProfile); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	return userProfile, dsnap, nil
+}
+
+// updateUserProfileDocument writes back a user profile fetched with getUserProfileForUpdate.
+// The write is rejected with a conflict error when the document has changed since it was read.
+// When the phone numbers, email addresses or username of the profile change, their reservations
+// are updated in the same transaction as the profile. So are the domain events of the change added to the outbox
+func (fr *Repository) updateUserProfileDocument(
+	ctx context.Context,
+	dsnap *firestore.DocumentSnapshot,
+	profile *profileutils.UserProfile,
+) error {
+	stored := &profileutils.UserProfile{}
+	if err := dsnap.DataTo(stored); err != nil {
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to read user profile: %w", err),
+		)
+	}
+	events, err := utils.ProfileEvents(stored, profile)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	reserve, release := utils.IdentifierReservationChanges(stored, profile)
+	if len(reserve) > 0 || len(release) > 0 || len(events) > 0 {
+		return fr.updateUserProfileInTransaction(ctx, dsnap, stored, profile, events)
+	}
+
+	updateCommand := &UpdateCommand{
+		CollectionName: fr.GetUserProfileCollectionName(),
+		ID:             dsnap.Ref.ID,
+		Data:           profile,
+		LastUpdateTime: dsnap.UpdateTime,
+	}
+	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	if err != nil {
+		if exceptions.IsConflictError(err) {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to update user profile: %v", err),
+		)
+	}
+	return nil
+}
+
+// updateUserProfileInTransaction writes back a user profile together with the reservations of the
+// identifiers it adds and releases and the domain events of the change
+func (fr *Repository) updateUserProfileInTransaction(
+	ctx context.Context,
+	dsnap *firestore.DocumentSnapshot,
+	stored *profileutils.UserProfile,
+	profile *profileutils.UserProfile,
+	events []*domain.OutboxEvent,
+) error {
+	err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		current, err := tx.Get(&GetSingleQuery{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			Value:          dsnap.Ref.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if current == nil || !current.UpdateTime.Equal(dsnap.UpdateTime) {
+			return exceptions.ProfileUpdateConflictError(
+				fmt.Errorf("document %s has been updated since it was read", dsnap.Ref.ID),
+			)
+		}
+		if err := fr.reserveIdentifiers(tx, stored, profile); err != nil {
+			return err
+		}
+		if err := fr.addOutboxEvents(tx, events...); err != nil {
+			return err
+		}
+		return tx.Update(&UpdateCommand{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			ID:             dsnap.Ref.ID,
+			Data:           profile,
+		})
+	})
+	if err != nil {
+		if isWrappedError(err) {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to update user profile: %v", err),
+		)
+	}
+	return nil
+}
+
+// createUserProfileDocument stores a new user profile together with the reservations of its identifiers
+// and its `user.created` event
+func (fr *Repository) createUserProfileDocument(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+) (*firestore.DocumentRef, error) {
+	events, err := utils.ProfileEvents(nil, profile)
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	var docRef *firestore.DocumentRef
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		if err := fr.reserveIdentifiers(tx, nil, profile); err != nil {
+			return err
+		}
+		if err := fr.addOutboxEvents(tx, events...); err != nil {
+			return err
+		}
+		ref, err := tx.Create(&CreateCommand{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			Data:           profile,
+		})
+		if err != nil {
+			return err
+		}
+		docRef = ref
+		return nil
+	})
+	if err != nil {
+		if isWrappedError(err) {
+			// this is a wrapped error. No need to wrap it again
+			return nil, err
+		}
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to create new user profile: %w", err),
+		)
+	}
+	return docRef, nil
+}
+
+// reserveIdentifiers writes the identifier reservation changes of a profile write as part of the
+// transaction. All the reservations are read before any of them is written so it should be called
+// after the other reads of the transaction. It fails when an identifier is reserved by another profile
+func (fr *Repository) reserveIdentifiers(
+	tx FirestoreTransaction,
+	before *profileutils.UserProfile,
+	after *profileutils.UserProfile,
+) error {
+	reserve, release := utils.IdentifierReservationChanges(before, after)
+
+	reservations := append([]*domain.IdentifierReservation{}, reserve...)
+	reservations = append(reservations, release...)
+
+	released := []*domain.IdentifierReservation{}
+	for _, reservation := range reservations {
+		dsnap, err := tx.Get(&GetSingleQuery{
+			CollectionName: fr.GetIdentifierReservationsCollectionName(),
+			Value:          reservation.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if dsnap == nil {
+			continue
+		}
+		existing := &domain.IdentifierReservation{}
+		if err := dsnap.DataTo(existing); err != nil {
+			return exceptions.InternalServerError(
+				fmt.Errorf("unable to read identifier reservation: %w", err),
+			)
+		}
+		if existing.ProfileID != reservation.ProfileID {
+			if containsReservation(reserve, reservation) {
+				return utils.IdentifierInUseError(reservation.Kind)
+			}
+			// the identifier has been reserved by another profile since it was released
+			continue
+		}
+		if containsReservation(release, reservation) {
+			released = append(released, reservation)
+		}
+	}
+
+	for _, reservation := range released {
+		err := tx.Delete(&DeleteCommand{
+			CollectionName: fr.GetIdentifierReservationsCollectionName(),
+			ID:             reservation.ID,
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+	}
+	for _, reservation := range reserve {
+		err := tx.Update(&UpdateCommand{
+			CollectionName: fr.GetIdentifierReservationsCollectionName(),
+			ID:             reservation.ID,
+			Data:           reservation,
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+	}
+	return nil
+}
+
+// addOutboxEvents adds domain events to the outbox as part of the transaction that
+// makes the state change they describe
+func (fr *Repository) addOutboxEvents(tx FirestoreTransaction, events ...*domain.OutboxEvent) error {
+	for _, event := range events {
+		err := tx.Update(&UpdateCommand{
+			CollectionName: fr.GetOutboxEventsCollectionName(),
+			ID:             event.ID,
+			Data:           event,
+		})
+		if err != nil {
+			return exceptions.InternalServerError(
+				fmt.Errorf("unable to add %s event to the outbox: %w", event.Type, err),
+			)
+		}
+	}
+	return nil
+}
+
+// releaseIdentifiers removes every identifier reservation held by a profile
+func (fr *Repository) releaseIdentifiers(ctx context.Context, profileID string) error {
+	query := &GetAllQuery{
+		CollectionName: fr.GetIdentifierReservationsCollectionName(),
+		FieldName:      "profileID",
+		Value:          profileID,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	for _, doc := range docs {
+		command := &DeleteCommand{
+			CollectionName: fr.GetIdentifierReservationsCollectionName(),
+			ID:             doc.Ref.ID,
+		}
+		if err := fr.FirestoreClient.Delete(ctx, command); err != nil {
+			return exceptions.InternalServerError(err)
+		}
+	}
+	return nil
+}
+
+func containsReservation(
+	reservations []*domain.IdentifierReservation,
+	reservation *domain.IdentifierReservation,
+) bool {
+	for _, r := range reservations {
+		if r == reservation {
+			return true
+		}
+	}
+	return false
+}
+
+// isWrappedError checks whether an error returned by a transaction is one of ours, which are already wrapped
+func isWrappedError(err error) bool {
+	var customErr *errorcodeutil.CustomError
+	return exceptions.IsConflictError(err) || errors.As(err, &customErr)
 }
 
 func (fr *Repository) fetchUserRandomName(ctx context.Context) *string {
@@ -419,6 +809,7 @@ func (fr *Repository) CreateUserProfile(
 	}
 
 	profileID := uuid.New().String()
+	created := time.Now().In(pubsubtools.TimeLocation)
 	pr := &profileutils.UserProfile{
 		ID:           profileID,
 		UserName:     fr.fetchUserRandomName(ctx),
@@ -431,18 +822,14 @@ func (fr *Repository) CreateUserProfile(
 		VerifiedUIDS:  []string{uid},
 		TermsAccepted: true,
 		Suspended:     false,
+		Created:       &created,
 	}
 
-	command := &CreateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		Data:           pr,
-	}
-	docRef, err := fr.FirestoreClient.Create(ctx, command)
+	docRef, err := fr.createUserProfileDocument(ctx, pr)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return nil, exceptions.InternalServerError(
-			fmt.Errorf("unable to create new user profile: %w", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
 	}
 	query := &GetSingleQuery{
 		CollectionName: fr.GetUserProfileCollectionName(),
@@ -514,23 +901,370 @@ func (fr *Repository) CreateDetailedUserProfile(
 	profile.UserName = fr.fetchUserRandomName(ctx)
 	profile.TermsAccepted = true
 	profile.Suspended = false
+	created := time.Now().In(pubsubtools.TimeLocation)
+	profile.Created = &created
 
-	command := &CreateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		Data:           profile,
-	}
-
-	_, err = fr.FirestoreClient.Create(ctx, command)
+	_, err = fr.createUserProfileDocument(ctx, &profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return nil, exceptions.InternalServerError(
-			fmt.Errorf("unable to create new user profile: %w", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
 	}
 
 	return &profile, nil
 }
 
+// CreateUserAccount creates the firebase user, profile, PIN and communications settings of a new user.
+// The firestore records are written in a single transaction. When the transaction fails, the firebase
+// user is removed if it was created here
+func (fr *Repository) CreateUserAccount(
+	ctx context.Context,
+	phoneNumber string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	ctx, span := tracer.Start(ctx, "CreateUserAccount")
+	defer span.End()
+
+	return fr.createUserAccount(ctx, domain.IdentifierKindPhone, phoneNumber, account)
+}
+
+// CreateEmailUserAccount creates the firebase user, profile, password and communications settings
+// of a user who signs up with an email address. The firestore records are written in a single
+// transaction. When the transaction fails, the firebase user is removed if it was created here
+func (fr *Repository) CreateEmailUserAccount(
+	ctx context.Context,
+	emailAddress string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	ctx, span := tracer.Start(ctx, "CreateEmailUserAccount")
+	defer span.End()
+
+	return fr.createUserAccount(ctx, domain.IdentifierKindEmail, emailAddress, account)
+}
+
+// createUserAccount creates an account whose firebase user and profile are identified by a phone
+// number or an email address. The phone number of an account that is created for an anonymous
+// user is added to its firebase user instead
+func (fr *Repository) createUserAccount(
+	ctx context.Context,
+	kind domain.IdentifierKind,
+	identifier string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	ctx, span := tracer.Start(ctx, "createUserAccount")
+	defer span.End()
+
+	getUser := fr.FirebaseClient.GetUserByPhoneNumber
+	params := (&auth.UserToCreate{}).PhoneNumber(identifier)
+	loginProvider := profileutils.LoginProviderTypePhone
+	if kind == domain.IdentifierKindEmail {
+		getUser = fr.FirebaseClient.GetUserByEmail
+		params = (&auth.UserToCreate{}).Email(identifier).EmailVerified(true)
+		loginProvider = domain.LoginProviderTypeEmail
+	}
+
+	newAuthUser, phoneAdded := false, false
+	user, err := getUser(ctx, identifier)
+	if account.AnonymousUID != "" {
+		user, phoneAdded, err = fr.upgradeAnonymousUser(ctx, kind, identifier, account.AnonymousUID, user, err)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return nil, err
+		}
+	} else if err != nil {
+		user, err = fr.FirebaseClient.CreateUser(ctx, params)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		newAuthUser = true
+	}
+	account.UID = user.UID
+	account.NewAuthUser = newAuthUser
+
+	if account.Profile == nil {
+		account.Profile = &profileutils.UserProfile{}
+	}
+	profile := account.Profile
+	profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, profileutils.VerifiedIdentifier{
+		UID:           user.UID,
+		LoginProvider: loginProvider,
+		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+	})
+	profile.VerifiedUIDS = append(profile.VerifiedUIDS, user.UID)
+	profile.ID = uuid.New().String()
+	if kind == domain.IdentifierKindEmail {
+		profile.PrimaryEmailAddress = &identifier
+	} else {
+		profile.PrimaryPhone = &identifier
+	}
+	profile.UserName = fr.fetchUserRandomName(ctx)
+	profile.TermsAccepted = true
+	profile.Suspended = false
+	created := time.Now().In(pubsubtools.TimeLocation)
+	profile.Created = &created
+
+	if account.PIN != nil {
+		account.PIN.ProfileID = profile.ID
+	}
+	if account.Password != nil {
+		account.Password.ProfileID = profile.ID
+	}
+	if account.CommunicationsSettings != nil {
+		account.CommunicationsSettings.ID = uuid.New().String()
+		account.CommunicationsSettings.ProfileID = profile.ID
+	}
+
+	events, err := utils.ProfileEvents(nil, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if account.PIN != nil {
+		event, err := utils.PINChangedEvent(account.PIN)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		events = append(events, event)
+	}
+	if account.AnonymousUID != "" {
+		event, err := utils.UserUpgradedEvent(account.AnonymousUID, profile)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		events = append(events, event)
+	}
+
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		// the phone number or email address is checked within the transaction so that concurrent
+		// signups can not both claim it
+		primaryField, secondaryField := "primaryPhone", "secondaryPhoneNumbers"
+		existsErr := exceptions.CheckPhoneNumberExistError
+		if kind == domain.IdentifierKindEmail {
+			primaryField, secondaryField = "primaryEmailAddress", "secondaryEmailAddresses"
+			existsErr = exceptions.CheckEmailExistError
+		}
+		queries := []*GetAllQuery{
+			{
+				CollectionName: fr.GetUserProfileCollectionName(),
+				FieldName:      primaryField,
+				Value:          identifier,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetUserProfileCollectionName(),
+				FieldName:      secondaryField,
+				Value:          identifier,
+				Operator:       "array-contains",
+			},
+		}
+		for _, query := range queries {
+			docs, err := tx.GetAll(query)
+			if err != nil {
+				return err
+			}
+			if len(docs) > 0 {
+				// the identifier is associated with another user profile, hence can not create an profile with it
+				return existsErr()
+			}
+		}
+		if account.AnonymousUID != "" {
+			// an anonymous user is only upgraded once
+			docs, err := tx.GetAll(&GetAllQuery{
+				CollectionName: fr.GetUserProfileCollectionName(),
+				FieldName:      "verifiedUIDS",
+				Value:          account.AnonymousUID,
+				Operator:       "array-contains",
+			})
+			if err != nil {
+				return err
+			}
+			if len(docs) > 0 {
+				return exceptions.NotAnonymousUserError()
+			}
+		}
+		if err := fr.reserveIdentifiers(tx, nil, profile); err != nil {
+			return err
+		}
+		if err := fr.addOutboxEvents(tx, events...); err != nil {
+			return err
+		}
+
+		commands := []*CreateCommand{{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			Data:           profile,
+		}}
+		if account.PIN != nil {
+			commands = append(commands, &CreateCommand{
+				CollectionName: fr.GetPINsCollectionName(),
+				Data:           account.PIN,
+			})
+		}
+		if account.CommunicationsSettings != nil {
+			commands = append(commands, &CreateCommand{
+				CollectionName: fr.GetCommunicationsSettingsCollectionName(),
+				Data:           account.CommunicationsSettings,
+			})
+		}
+		for _, command := range commands {
+			if _, err := tx.Create(command); err != nil {
+				return err
+			}
+		}
+		if account.Password != nil {
+			// the password is stored under the profile ID so that a profile has at most one
+			return tx.Update(&UpdateCommand{
+				CollectionName: fr.GetPasswordsCollectionName(),
+				ID:             profile.ID,
+				Data:           account.Password,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		if newAuthUser {
+			if deleteErr := fr.FirebaseClient.DeleteUser(ctx, user.UID); deleteErr != nil {
+				utils.RecordSpanError(span, deleteErr)
+				return nil, exceptions.InternalServerError(
+					fmt.Errorf("unable to remove the firebase user of a failed signup: %w", deleteErr),
+				)
+			}
+		}
+		if phoneAdded {
+			// the anonymous user goes back to having no phone number
+			_, updateErr := fr.FirebaseClient.UpdateUser(ctx, user.UID, (&auth.UserToUpdate{}).PhoneNumber(""))
+			if updateErr != nil {
+				utils.RecordSpanError(span, updateErr)
+				return nil, exceptions.InternalServerError(
+					fmt.Errorf("unable to remove the phone number of a failed upgrade: %w", updateErr),
+				)
+			}
+		}
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// upgradeAnonymousUser adds the phone number of an account to the firebase user of the anonymous
+// user that the account is created for. It takes the result of looking up the firebase user of
+// the phone number, which must be the anonymous user if it is found, and reports whether the
+// phone number was added
+func (fr *Repository) upgradeAnonymousUser(
+	ctx context.Context,
+	kind domain.IdentifierKind,
+	phone string,
+	uid string,
+	existing *auth.UserRecord,
+	lookupErr error,
+) (*auth.UserRecord, bool, error) {
+	if kind != domain.IdentifierKindPhone || !utils.IsAnonymousUID(uid) {
+		return nil, false, exceptions.NotAnonymousUserError()
+	}
+	if lookupErr == nil {
+		if existing.UID != uid {
+			// the phone number belongs to another firebase user
+			return nil, false, exceptions.CheckPhoneNumberExistError()
+		}
+		return existing, false, nil
+	}
+	user, err := fr.FirebaseClient.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).PhoneNumber(phone))
+	if err != nil {
+		return nil, false, exceptions.InternalServerError(err)
+	}
+	return user, true, nil
+}
+
+// DeleteUserAccount removes the profile, PIN, password and communications settings of an account
+// created by CreateUserAccount or CreateEmailUserAccount in a single transaction. The firebase user is removed when it was created with the account
+func (fr *Repository) DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error {
+	ctx, span := tracer.Start(ctx, "DeleteUserAccount")
+	defer span.End()
+
+	if account.Profile != nil {
+		queries := []*GetAllQuery{
+			{
+				CollectionName: fr.GetUserProfileCollectionName(),
+				FieldName:      "id",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetPINsCollectionName(),
+				FieldName:      "profileID",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetPasswordsCollectionName(),
+				FieldName:      "profileID",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetCommunicationsSettingsCollectionName(),
+				FieldName:      "profileID",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetIdentifierReservationsCollectionName(),
+				FieldName:      "profileID",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+			{
+				CollectionName: fr.GetOutboxEventsCollectionName(),
+				FieldName:      "profileID",
+				Value:          account.Profile.ID,
+				Operator:       "==",
+			},
+		}
+		err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+			commands := []*DeleteCommand{}
+			for _, query := range queries {
+				docs, err := tx.GetAll(query)
+				if err != nil {
+					return err
+				}
+				for _, doc := range docs {
+					if query.CollectionName == fr.GetOutboxEventsCollectionName() && isPublishedEvent(doc) {
+						// published events can not be taken back
+						continue
+					}
+					commands = append(commands, &DeleteCommand{
+						CollectionName: query.CollectionName,
+						ID:             doc.Ref.ID,
+					})
+				}
+			}
+			for _, command := range commands {
+				if err := tx.Delete(command); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return exceptions.InternalServerError(err)
+		}
+	}
+
+	if account.NewAuthUser {
+		if err := fr.FirebaseClient.DeleteUser(ctx, account.UID); err != nil {
+			utils.RecordSpanError(span, err)
+			return exceptions.InternalServerError(err)
+		}
+	}
+	return nil
+}
+
 //GetUserProfileByPrimaryPhoneNumber fetches a user profile by primary phone number
 func (fr *Repository) GetUserProfileByPrimaryPhoneNumber(
 	ctx context.Context,
@@ -792,17 +1526,15 @@ func (fr *Repository) GetPINByProfileID(
 
 // GenerateAuthCredentialsForAnonymousUser generates auth credentials for the anonymous user. This method is here since we don't
 // want to delegate sign-in of anonymous users to the frontend. This is an effort the over reliance on firebase and lettin us
-// handle all the heavy lifting
+// handle all the heavy lifting. Every anonymous login gets its own firebase user so that it can be
+// upgraded into an account without changing its UID
 func (fr *Repository) GenerateAuthCredentialsForAnonymousUser(
 	ctx context.Context,
 ) (*profileutils.AuthCredentialResponse, error) {
 	ctx, span := tracer.Start(ctx, "GenerateAuthCredentialsForAnonymousUser")
 	defer span.End()
 
-	// todo(dexter) : move anonymousPhoneNumber to base. AnonymousPhoneNumber should NEVER NEVER have a user profile
-	anonymousPhoneNumber := "+254700000000"
-
-	u, err := fr.GetOrCreatePhoneNumberUser(ctx, anonymousPhoneNumber)
+	u, err := fr.FirebaseClient.CreateUser(ctx, (&auth.UserToCreate{}).UID(utils.AnonymousUID()))
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, exceptions.InternalServerError(err)
@@ -830,7 +1562,8 @@ func (fr *Repository) GenerateAuthCredentialsForAnonymousUser(
 	}, nil
 }
 
-// GenerateAuthCredentials gets a Firebase user by phone and creates their tokens
+// GenerateAuthCredentials gets a Firebase user by phone, email address or social login UID and creates
+// their tokens
 func (fr *Repository) GenerateAuthCredentials(
 	ctx context.Context,
 	phone string,
@@ -839,7 +1572,14 @@ func (fr *Repository) GenerateAuthCredentials(
 	ctx, span := tracer.Start(ctx, "GenerateAuthCredentials")
 	defer span.End()
 
-	resp, err := fr.GetOrCreatePhoneNumberUser(ctx, phone)
+	getOrCreateUser := fr.GetOrCreatePhoneNumberUser
+	loginProvider := profileutils.LoginProviderTypePhone
+	if utils.IsEmailAddress(phone) {
+		getOrCreateUser, loginProvider = fr.getOrCreateEmailUser, domain.LoginProviderTypeEmail
+	} else if provider, ok := utils.SocialLoginProvider(phone); ok {
+		getOrCreateUser, loginProvider = fr.getOrCreateSocialUser, provider
+	}
+	resp, err := getOrCreateUser(ctx, phone)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		if auth.IsUserNotFound(err) {
@@ -859,15 +1599,11 @@ func (fr *Repository) GenerateAuthCredentials(
 		return nil, exceptions.AuthenticateTokenError(err)
 	}
 
-	if err := fr.UpdateVerifiedIdentifiers(ctx, profile.ID, []profileutils.VerifiedIdentifier{{
+	if err := fr.addLoginUID(ctx, profile.ID, profileutils.VerifiedIdentifier{
 		UID:           resp.UID,
-		LoginProvider: profileutils.LoginProviderTypePhone,
+		LoginProvider: loginProvider,
 		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
-	}}); err != nil {
-		return nil, exceptions.UpdateProfileError(err)
-	}
-
-	if err := fr.UpdateVerifiedUIDS(ctx, profile.ID, []string{resp.UID}); err != nil {
+	}); err != nil {
 		return nil, exceptions.UpdateProfileError(err)
 	}
 
@@ -919,41 +1655,18 @@ func (fr *Repository) UpdateUserName(ctx context.Context, id string, userName st
 	if v {
 		return exceptions.InternalServerError(fmt.Errorf("%v", exceptions.UsernameInUseErrMsg))
 	}
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
 		return err
 	}
 	profile.UserName = &userName
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile primary phone number: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -969,7 +1682,7 @@ func (fr *Repository) UpdatePrimaryPhoneNumber(
 	ctx, span := tracer.Start(ctx, "UpdatePrimaryPhoneNumber")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -977,35 +1690,11 @@ func (fr *Repository) UpdatePrimaryPhoneNumber(
 	}
 	profile.PrimaryPhone = &phoneNumber
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile primary phone number: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -1016,7 +1705,7 @@ func (fr Repository) UpdateUserRoleIDs(ctx context.Context, id string, roleIDs [
 	ctx, span := tracer.Start(ctx, "UpdateUserRoleIDs")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return err
@@ -1025,35 +1714,11 @@ func (fr Repository) UpdateUserRoleIDs(ctx context.Context, id string, roleIDs [
 	// Add the roles
 	profile.Roles = roleIDs
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(err)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile primary email address: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -1069,7 +1734,7 @@ func (fr *Repository) UpdatePrimaryEmailAddress(
 	ctx, span := tracer.Start(ctx, "UpdatePrimaryEmailAddress")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1077,42 +1742,77 @@ func (fr *Repository) UpdatePrimaryEmailAddress(
 	}
 	profile.PrimaryEmailAddress = &emailAddress
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile primary email address: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
 }
 
-// UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
-// this method should be called after asserting the phone numbers are unique and not associated with another userProfile
-func (fr *Repository) UpdateSecondaryPhoneNumbers(
+// ReplacePrimaryPhoneNumber sets the primary phone number of the profile that matches the id and keeps
+// the previous one as a secondary phone number, in a single write. The write is refused unless the
+// context carries the version of the profile that was read
+func (fr *Repository) ReplacePrimaryPhoneNumber(
+	ctx context.Context,
+	id string,
+	phoneNumber string,
+) error {
+	ctx, span := tracer.Start(ctx, "ReplacePrimaryPhoneNumber")
+	defer span.End()
+
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	utils.ReplacePrimaryPhoneNumber(profile, phoneNumber)
+
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	return nil
+}
+
+// ReplacePrimaryEmailAddress sets the primary email address of the profile that matches the id and keeps
+// the previous one as a secondary email address, in a single write. The write is refused unless the
+// context carries the version of the profile that was read
+func (fr *Repository) ReplacePrimaryEmailAddress(
+	ctx context.Context,
+	id string,
+	emailAddress string,
+) error {
+	ctx, span := tracer.Start(ctx, "ReplacePrimaryEmailAddress")
+	defer span.End()
+
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	utils.ReplacePrimaryEmailAddress(profile, emailAddress)
+
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
+// this method should be called after asserting the phone numbers are unique and not associated with another userProfile
+func (fr *Repository) UpdateSecondaryPhoneNumbers(
 	ctx context.Context,
 	id string,
 	phoneNumbers []string,
@@ -1120,7 +1820,7 @@ func (fr *Repository) UpdateSecondaryPhoneNumbers(
 	ctx, span := tracer.Start(ctx, "UpdateSecondaryPhoneNumbers")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1148,34 +1848,11 @@ func (fr *Repository) UpdateSecondaryPhoneNumbers(
 
 	profile.SecondaryPhoneNumbers = append(profile.SecondaryPhoneNumbers, phoneNumbers...)
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile secondary phone numbers: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -1191,7 +1868,7 @@ func (fr *Repository) UpdateSecondaryEmailAddresses(
 	ctx, span := tracer.Start(ctx, "UpdateSecondaryEmailAddresses")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1228,33 +1905,11 @@ func (fr *Repository) UpdateSecondaryEmailAddresses(
 		profile.SecondaryEmailAddresses,
 		uniqueEmailAddresses...)
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile secondary email address: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 }
@@ -1264,7 +1919,7 @@ func (fr *Repository) UpdateSuspended(ctx context.Context, id string, status boo
 	ctx, span := tracer.Start(ctx, "UpdateSuspended")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, true)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, true)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1272,29 +1927,11 @@ func (fr *Repository) UpdateSuspended(ctx context.Context, id string, status boo
 	}
 	profile.Suspended = status
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(err)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -1306,7 +1943,7 @@ func (fr *Repository) UpdatePhotoUploadID(ctx context.Context, id string, upload
 	ctx, span := tracer.Start(ctx, "UpdatePhotoUploadID")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1314,33 +1951,11 @@ func (fr *Repository) UpdatePhotoUploadID(ctx context.Context, id string, upload
 	}
 	profile.PhotoUploadID = uploadID
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile photo upload id: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -1352,7 +1967,7 @@ func (fr *Repository) UpdatePushTokens(ctx context.Context, id string, pushToken
 	ctx, span := tracer.Start(ctx, "UpdatePushTokens")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1361,33 +1976,11 @@ func (fr *Repository) UpdatePushTokens(ctx context.Context, id string, pushToken
 
 	profile.PushTokens = pushTokens
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile push tokens: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 }
@@ -1401,7 +1994,7 @@ func (fr *Repository) UpdatePermissions(
 	ctx, span := tracer.Start(ctx, "UpdatePermissions")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1431,33 +2024,11 @@ func (fr *Repository) UpdatePermissions(
 
 	profile.Permissions = newPerms
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile permissions: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 
@@ -1468,7 +2039,7 @@ func (fr *Repository) UpdateRole(ctx context.Context, id string, role profileuti
 	ctx, span := tracer.Start(ctx, "UpdateRole")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1478,33 +2049,11 @@ func (fr *Repository) UpdateRole(ctx context.Context, id string, role profileuti
 	profile.Role = role
 	profile.Permissions = role.Permissions()
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user role and permissions: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 
@@ -1516,7 +2065,7 @@ func (fr *Repository) UpdateFavNavActions(
 	id string,
 	favActions []string,
 ) error {
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		// this is a wrapped error. No need to wrap it again
 		return err
@@ -1524,31 +2073,10 @@ func (fr *Repository) UpdateFavNavActions(
 
 	profile.FavNavActions = favActions
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
-	if err != nil {
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user favorite actions: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 }
@@ -1562,7 +2090,7 @@ func (fr *Repository) UpdateBioData(
 	ctx, span := tracer.Start(ctx, "UpdateBioData")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		// this is a wrapped error. No need to wrap it again
@@ -1606,33 +2134,11 @@ func (fr *Repository) UpdateBioData(
 		profile,
 		data,
 	)
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile bio data: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 }
@@ -1648,7 +2154,7 @@ func (fr *Repository) UpdateVerifiedIdentifiers(
 
 	for _, identifier := range identifiers {
 		// for each run, get the user profile. this will ensure the fetch profile always has the latest data
-		profile, err := fr.GetUserProfileByID(ctx, id, false)
+		profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 		if err != nil {
 			utils.RecordSpanError(span, err)
 			// this is a wrapped error. No need to wrap it again
@@ -1662,33 +2168,11 @@ func (fr *Repository) UpdateVerifiedIdentifiers(
 
 			profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, uids...)
 
-			query := &GetAllQuery{
-				CollectionName: fr.GetUserProfileCollectionName(),
-				FieldName:      "id",
-				Value:          profile.ID,
-				Operator:       "==",
-			}
-			docs, err := fr.FirestoreClient.GetAll(ctx, query)
-			if err != nil {
-				utils.RecordSpanError(span, err)
-				return exceptions.InternalServerError(
-					fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-				)
-			}
-			if len(docs) == 0 {
-				return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-			}
-			updateCommand := &UpdateCommand{
-				CollectionName: fr.GetUserProfileCollectionName(),
-				ID:             docs[0].Ref.ID,
-				Data:           profile,
-			}
-			err = fr.FirestoreClient.Update(ctx, updateCommand)
+			err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 			if err != nil {
 				utils.RecordSpanError(span, err)
-				return exceptions.InternalServerError(
-					fmt.Errorf("unable to update user profile verified identifiers: %v", err),
-				)
+				// this is a wrapped error. No need to wrap it again
+				return err
 			}
 			return nil
 
@@ -1705,7 +2189,7 @@ func (fr *Repository) UpdateVerifiedUIDS(ctx context.Context, id string, uids []
 
 	for _, uid := range uids {
 		// for each run, get the user profile. this will ensure the fetch profile always has the latest data
-		profile, err := fr.GetUserProfileByID(ctx, id, false)
+		profile, dsnap, err := fr.getVersionedUserProfileForUpdate(ctx, id, false)
 		if err != nil {
 			utils.RecordSpanError(span, err)
 			// this is a wrapped error. No need to wrap it again
@@ -1719,33 +2203,11 @@ func (fr *Repository) UpdateVerifiedUIDS(ctx context.Context, id string, uids []
 
 			profile.VerifiedUIDS = append(profile.VerifiedUIDS, uids...)
 
-			query := &GetAllQuery{
-				CollectionName: fr.GetUserProfileCollectionName(),
-				FieldName:      "id",
-				Value:          profile.ID,
-				Operator:       "==",
-			}
-			docs, err := fr.FirestoreClient.GetAll(ctx, query)
-			if err != nil {
-				utils.RecordSpanError(span, err)
-				return exceptions.InternalServerError(
-					fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-				)
-			}
-			if len(docs) == 0 {
-				return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
-			}
-			updateCommand := &UpdateCommand{
-				CollectionName: fr.GetUserProfileCollectionName(),
-				ID:             docs[0].Ref.ID,
-				Data:           profile,
-			}
-			err = fr.FirestoreClient.Update(ctx, updateCommand)
+			err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 			if err != nil {
 				utils.RecordSpanError(span, err)
-				return exceptions.InternalServerError(
-					fmt.Errorf("unable to update user profile verified UIDS: %v", err),
-				)
+				// this is a wrapped error. No need to wrap it again
+				return err
 			}
 			return nil
 
@@ -1755,6 +2217,85 @@ func (fr *Repository) UpdateVerifiedUIDS(ctx context.Context, id string, uids []
 	return nil
 }
 
+// addLoginUID adds a login UID to the verified identifiers and the verified UIDs of the profile that
+// matches the id in one write. The UID is added to the profile as it is stored, not as the caller
+// read it
+func (fr *Repository) addLoginUID(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if !utils.AddLoginUID(profile, identifier) {
+		return nil
+	}
+	// this is a wrapped error. No need to wrap it again
+	return fr.updateUserProfileDocument(ctx, dsnap, profile)
+}
+
+// LinkSocialAccount adds the verified identifier and the verified UID of an account with a social
+// login provider to the profile that matches the id in one write. The UID is reserved for the profile
+// in the same write so that the account can not be linked to two profiles
+func (fr *Repository) LinkSocialAccount(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	ctx, span := tracer.Start(ctx, "LinkSocialAccount")
+	defer span.End()
+
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	changed, err := utils.LinkSocialAccount(profile, identifier)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	if err := fr.updateUserProfileDocument(ctx, dsnap, profile); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RemoveVerifiedIdentifier removes the verified identifier and the verified UID of a login UID from
+// the profile that matches the id. The user can no longer log in with that UID
+func (fr *Repository) RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error {
+	ctx, span := tracer.Start(ctx, "RemoveVerifiedIdentifier")
+	defer span.End()
+
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if !utils.RemoveLoginUID(profile, uid) {
+		return nil
+	}
+
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
 // RecordPostVisitSurvey records an end of visit survey
 func (fr *Repository) RecordPostVisitSurvey(
 	ctx context.Context,
@@ -1795,12 +2336,23 @@ func (fr *Repository) SavePIN(ctx context.Context, pin *domain.PIN) (bool, error
 	ctx, span := tracer.Start(ctx, "SavePin")
 	defer span.End()
 
-	// persist the data to a datastore
-	command := &CreateCommand{
-		CollectionName: fr.GetPINsCollectionName(),
-		Data:           pin,
+	event, err := utils.PINChangedEvent(pin)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.AddRecordError(err)
 	}
-	_, err := fr.FirestoreClient.Create(ctx, command)
+
+	// persist the data to a datastore
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		command := &CreateCommand{
+			CollectionName: fr.GetPINsCollectionName(),
+			Data:           pin,
+		}
+		if _, err := tx.Create(command); err != nil {
+			return err
+		}
+		return fr.addOutboxEvents(tx, event)
+	})
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return false, exceptions.AddRecordError(err)
@@ -1809,6 +2361,53 @@ func (fr *Repository) SavePIN(ctx context.Context, pin *domain.PIN) (bool, error
 
 }
 
+// SaveTempPIN replaces the PIN of a profile with a temporary PIN, saving it when the profile has
+// no PIN
+func (fr *Repository) SaveTempPIN(ctx context.Context, pin *domain.PIN) error {
+	ctx, span := tracer.Start(ctx, "SaveTempPIN")
+	defer span.End()
+
+	event, err := utils.PINChangedEvent(pin)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		docs, err := tx.GetAll(&GetAllQuery{
+			CollectionName: fr.GetPINsCollectionName(),
+			FieldName:      "profileID",
+			Value:          pin.ProfileID,
+			Operator:       "==",
+		})
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			err := tx.Delete(&DeleteCommand{
+				CollectionName: fr.GetPINsCollectionName(),
+				ID:             doc.Ref.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		command := &CreateCommand{
+			CollectionName: fr.GetPINsCollectionName(),
+			Data:           pin,
+		}
+		if _, err := tx.Create(command); err != nil {
+			return err
+		}
+		return fr.addOutboxEvents(tx, event)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+	return nil
+}
+
 // UpdatePIN  persist the data of the updated PIN to a datastore
 func (fr *Repository) UpdatePIN(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
 	ctx, span := tracer.Start(ctx, "UpdatePIN")
@@ -1842,12 +2441,23 @@ func (fr *Repository) UpdatePIN(ctx context.Context, id string, pin *domain.PIN)
 		pin.IsOTP = false
 	}
 
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetPINsCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           pin,
+	event, err := utils.PINChangedEvent(pin)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.UpdateProfileError(err)
 	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		updateCommand := &UpdateCommand{
+			CollectionName: fr.GetPINsCollectionName(),
+			ID:             docs[0].Ref.ID,
+			Data:           pin,
+		}
+		if err := tx.Update(updateCommand); err != nil {
+			return err
+		}
+		return fr.addOutboxEvents(tx, event)
+	})
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return false, exceptions.UpdateProfileError(err)
@@ -1857,6 +2467,153 @@ func (fr *Repository) UpdatePIN(ctx context.Context, id string, pin *domain.PIN)
 
 }
 
+// ReservePINAttempt counts an attempt to use the PIN of a profile before the PIN is compared. It
+// refuses the attempt when the PIN is locked, must be reset or was attempted too recently
+func (fr *Repository) ReservePINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	ctx, span := tracer.Start(ctx, "ReservePINAttempt")
+	defer span.End()
+
+	pin, err := fr.updateStoredPIN(ctx, profileID, func(pin *domain.PIN) error {
+		return utils.ReservePINAttempt(pin, attemptedAt)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return pin, nil
+}
+
+// RecordFailedPINAttempt counts a failed attempt to use the PIN of a profile, locking the PIN
+// after too many failed attempts
+func (fr *Repository) RecordFailedPINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	ctx, span := tracer.Start(ctx, "RecordFailedPINAttempt")
+	defer span.End()
+
+	pin, err := fr.updateStoredPIN(ctx, profileID, func(pin *domain.PIN) error {
+		utils.RecordFailedPINAttempt(pin, attemptedAt)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return pin, nil
+}
+
+// ClearPINAttempts forgets the failed attempts to use the PIN of a profile, unlocking the PIN
+func (fr *Repository) ClearPINAttempts(ctx context.Context, profileID string) error {
+	ctx, span := tracer.Start(ctx, "ClearPINAttempts")
+	defer span.End()
+
+	if _, err := fr.updateStoredPIN(ctx, profileID, func(pin *domain.PIN) error {
+		utils.ClearPINAttempts(pin)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RequirePINReset stops the PIN of a profile from being used until it is reset with an OTP
+func (fr *Repository) RequirePINReset(ctx context.Context, profileID string) error {
+	ctx, span := tracer.Start(ctx, "RequirePINReset")
+	defer span.End()
+
+	if _, err := fr.updateStoredPIN(ctx, profileID, func(pin *domain.PIN) error {
+		utils.RequirePINReset(pin)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// UpdatePINHash replaces the hash, salt and hashing scheme of the PIN of a profile with the ones
+// of a rehashed PIN. The rest of the PIN, including whether it is temporary, is kept
+func (fr *Repository) UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+	ctx, span := tracer.Start(ctx, "UpdatePINHash")
+	defer span.End()
+
+	_, err := fr.updateStoredPIN(ctx, pin.ProfileID, func(stored *domain.PIN) error {
+		utils.ReplacePINHash(stored, previousPINNumber, pin)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// updateStoredPIN applies a change to the stored PIN of a profile together with the lockout
+// events of the change. It runs in a transaction so that concurrent attempts are all counted.
+// The PIN is left unchanged when the change returns an error
+func (fr *Repository) updateStoredPIN(
+	ctx context.Context,
+	profileID string,
+	update func(pin *domain.PIN) error,
+) (*domain.PIN, error) {
+	updated := &domain.PIN{}
+	err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		docs, err := tx.GetAll(&GetAllQuery{
+			CollectionName: fr.GetPINsCollectionName(),
+			FieldName:      "profileID",
+			Value:          profileID,
+			Operator:       "==",
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		if len(docs) == 0 {
+			return exceptions.PinNotFoundError(fmt.Errorf("failed to get a user pin"))
+		}
+
+		stored := &domain.PIN{}
+		if err := docs[0].DataTo(stored); err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		*updated = *stored
+		if err := update(updated); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		events, err := utils.PINAttemptEvents(stored, updated)
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+
+		err = tx.Update(&UpdateCommand{
+			CollectionName: fr.GetPINsCollectionName(),
+			ID:             docs[0].Ref.ID,
+			Data:           updated,
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		return fr.addOutboxEvents(tx, events...)
+	})
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return updated, nil
+}
+
 // ExchangeRefreshTokenForIDToken takes a custom Firebase refresh token and tries to fetch
 // an ID token and returns auth credentials if successful
 // Otherwise, an error is returned
@@ -2148,6 +2905,12 @@ func (fr *Repository) PurgeUserByPhoneNumber(ctx context.Context, phone string)
 		}
 	}
 
+	// free the phone numbers, email addresses and username of the user
+	if err := fr.releaseIdentifiers(ctx, profile.ID); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
 	// delete the user from firebase
 	u, err := fr.FirebaseClient.GetUserByPhoneNumber(ctx, phone)
 	if err == nil {
@@ -2204,46 +2967,92 @@ func (fr *Repository) GetOrCreatePhoneNumberUser(
 	}, nil
 }
 
-// HardResetSecondaryPhoneNumbers does a hard reset of user secondary phone numbers.
-// This should be called when retiring specific secondary phone number and passing in
-// the new secondary phone numbers as an argument.
-func (fr *Repository) HardResetSecondaryPhoneNumbers(
+// getOrCreateEmailUser retrieves or creates a Firebase email address user account. The email
+// address should have been verified before
+func (fr *Repository) getOrCreateEmailUser(
 	ctx context.Context,
-	profile *profileutils.UserProfile,
-	newSecondaryPhoneNumbers []string,
-) error {
-	ctx, span := tracer.Start(ctx, "HardResetSecondaryPhoneNumbers")
+	email string,
+) (*dto.CreatedUserResponse, error) {
+	ctx, span := tracer.Start(ctx, "getOrCreateEmailUser")
 	defer span.End()
 
-	profile.SecondaryPhoneNumbers = newSecondaryPhoneNumbers
-
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	user, err := fr.FirebaseClient.GetUserByEmail(ctx, email)
 	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
+		params := (&auth.UserToCreate{}).Email(email).EmailVerified(true)
+		user, err = fr.FirebaseClient.CreateUser(ctx, params)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
 	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
+	return &dto.CreatedUserResponse{
+		UID:         user.UID,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		PhotoURL:    user.PhotoURL,
+		ProviderID:  user.ProviderID,
+	}, nil
+}
+
+// getOrCreateSocialUser retrieves or creates the Firebase user of an account with a social login
+// provider. Its UID is derived from the account, which should have been verified before
+func (fr *Repository) getOrCreateSocialUser(
+	ctx context.Context,
+	uid string,
+) (*dto.CreatedUserResponse, error) {
+	ctx, span := tracer.Start(ctx, "getOrCreateSocialUser")
+	defer span.End()
+
+	user, err := fr.FirebaseClient.GetUser(ctx, uid)
+	if err != nil {
+		user, err = fr.FirebaseClient.CreateUser(ctx, (&auth.UserToCreate{}).UID(uid))
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
 	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
+	return &dto.CreatedUserResponse{
+		UID:         user.UID,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		PhotoURL:    user.PhotoURL,
+		ProviderID:  user.ProviderID,
+	}, nil
+}
+
+// HardResetSecondaryPhoneNumbers does a hard reset of user secondary phone numbers.
+// This should be called when retiring specific secondary phone number and passing in
+// the new secondary phone numbers as an argument.
+func (fr *Repository) HardResetSecondaryPhoneNumbers(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	newSecondaryPhoneNumbers []string,
+) error {
+	ctx, span := tracer.Start(ctx, "HardResetSecondaryPhoneNumbers")
+	defer span.End()
+
+	// the profile is only written back if it is still in the state the caller read it in
+	ctx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
 	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+	_, dsnap, err := fr.getUserProfileForUpdate(ctx, profile.ID, true)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile secondary phone numbers: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	profile.SecondaryPhoneNumbers = newSecondaryPhoneNumbers
+
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -2259,35 +3068,26 @@ func (fr *Repository) HardResetSecondaryEmailAddress(
 	ctx, span := tracer.Start(ctx, "HardResetSecondaryEmailAddress")
 	defer span.End()
 
-	profile.SecondaryEmailAddresses = newSecondaryEmails
-
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	// the profile is only written back if it is still in the state the caller read it in
+	ctx, err := utils.WithExpectedProfileVersion(ctx, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to parse user profile as firebase snapshot: %v", err),
-		)
-	}
-	if len(docs) == 0 {
-		return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
+		return err
 	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
+	_, dsnap, err := fr.getUserProfileForUpdate(ctx, profile.ID, true)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
+
+	profile.SecondaryEmailAddresses = newSecondaryEmails
+
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(
-			fmt.Errorf("unable to update user profile secondary phone numbers: %v", err),
-		)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -2415,7 +3215,7 @@ func (fr *Repository) UpdateAddresses(
 	ctx, span := tracer.Start(ctx, "UpdateAddresses")
 	defer span.End()
 
-	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	profile, dsnap, err := fr.getUserProfileForUpdate(ctx, id, false)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return err
@@ -2434,26 +3234,11 @@ func (fr *Repository) UpdateAddresses(
 		return exceptions.WrongEnumTypeError(addressType.String())
 	}
 
-	query := &GetAllQuery{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		FieldName:      "id",
-		Value:          profile.ID,
-		Operator:       "==",
-	}
-	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	err = fr.updateUserProfileDocument(ctx, dsnap, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(err)
-	}
-	updateCommand := &UpdateCommand{
-		CollectionName: fr.GetUserProfileCollectionName(),
-		ID:             docs[0].Ref.ID,
-		Data:           profile,
-	}
-	err = fr.FirestoreClient.Update(ctx, updateCommand)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return exceptions.InternalServerError(err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 	return nil
 }
@@ -2575,6 +3360,98 @@ func (fr *Repository) ListUserProfiles(
 	return profiles, nil
 }
 
+// ListUserProfilesPage reads the user profiles of a page of a listing.
+// Combining filters or filtering and sorting by different fields needs a composite index
+func (fr *Repository) ListUserProfilesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.UserProfile, error) {
+	ctx, span := tracer.Start(ctx, "ListUserProfilesPage")
+	defer span.End()
+
+	pageQuery := newPageQuery(fr.GetUserProfileCollectionName(), query)
+	for _, filter := range query.Filters {
+		switch filter.FieldName {
+		case utils.ListingFieldRole:
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: "roles",
+				Operator:  "array-contains",
+				Value:     filter.Value,
+			})
+		case utils.ListingFieldSuspended:
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: "suspended",
+				Operator:  "==",
+				Value:     filter.Value,
+			})
+		case utils.ListingFieldCreated:
+			operator, err := firebasetools.OpString(filter.Operation)
+			if err != nil {
+				utils.RecordSpanError(span, err)
+				return nil, exceptions.InvalidListingQueryError(err)
+			}
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: "created",
+				Operator:  operator,
+				Value:     filter.Value,
+			})
+		case utils.ListingFieldFlavour:
+			// the app versions are only set for the flavours a profile has been used with
+			fieldName := "consumerAppVersion"
+			if filter.Value == string(feedlib.FlavourPro) {
+				fieldName = "proAppVersion"
+			}
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: fieldName,
+				Operator:  ">",
+				Value:     "",
+			})
+		}
+	}
+
+	docs, err := fr.FirestoreClient.Query(ctx, pageQuery)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	profiles := []*profileutils.UserProfile{}
+	for _, doc := range docs {
+		profile := &profileutils.UserProfile{}
+		err = doc.DataTo(profile)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read user profile: %w", err),
+			)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// newPageQuery builds the query that reads the documents of a page of a listing. The listing
+// fields that records are sorted by are stored under the same names in firestore
+func newPageQuery(collectionName string, query *dto.ListingQuery) *PageQuery {
+	pageQuery := &PageQuery{
+		CollectionName: collectionName,
+		OrderBy:        []string{utils.ListingFieldID},
+		Descending:     query.ReadDescending(),
+		Limit:          query.Limit + 1,
+	}
+	if query.SortField != utils.ListingFieldID {
+		pageQuery.OrderBy = []string{query.SortField, utils.ListingFieldID}
+	}
+	if query.Cursor != nil {
+		pageQuery.StartAfter = []interface{}{query.Cursor.ID}
+		if query.SortField != utils.ListingFieldID {
+			pageQuery.StartAfter = []interface{}{query.Cursor.Value, query.Cursor.ID}
+		}
+	}
+	return pageQuery
+}
+
 // CreateRole creates a new role and persists it to the database
 func (fr *Repository) CreateRole(
 	ctx context.Context,
@@ -2655,6 +3532,56 @@ func (fr *Repository) GetAllRoles(ctx context.Context) (*[]profileutils.Role, er
 	return &roles, nil
 }
 
+// ListRolesPage reads the roles of a page of a listing
+func (fr *Repository) ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error) {
+	ctx, span := tracer.Start(ctx, "ListRolesPage")
+	defer span.End()
+
+	pageQuery := newPageQuery(fr.GetRolesCollectionName(), query)
+	for _, filter := range query.Filters {
+		switch filter.FieldName {
+		case utils.ListingFieldActive:
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: "active",
+				Operator:  "==",
+				Value:     filter.Value,
+			})
+		case utils.ListingFieldCreated:
+			operator, err := firebasetools.OpString(filter.Operation)
+			if err != nil {
+				utils.RecordSpanError(span, err)
+				return nil, exceptions.InvalidListingQueryError(err)
+			}
+			pageQuery.Filters = append(pageQuery.Filters, QueryFilter{
+				FieldName: "created",
+				Operator:  operator,
+				Value:     filter.Value,
+			})
+		}
+	}
+
+	docs, err := fr.FirestoreClient.Query(ctx, pageQuery)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		err = fmt.Errorf("unable to read role")
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	roles := []*profileutils.Role{}
+	for _, doc := range docs {
+		role := &profileutils.Role{}
+		err := doc.DataTo(role)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			err = fmt.Errorf("unable to read role")
+			return nil, exceptions.InternalServerError(err)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
 // UpdateRoleDetails  updates the details of a role
 func (fr *Repository) UpdateRoleDetails(
 	ctx context.Context,
@@ -2795,7 +3722,13 @@ func (fr *Repository) DeleteRole(
 				newRoles = append(newRoles, userRole)
 			}
 		}
-		err = fr.UpdateUserRoleIDs(ctx, user.ID, newRoles)
+		// the roles are derived from this read of the profile
+		userCtx, err := utils.WithExpectedProfileVersion(ctx, user)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return false, err
+		}
+		err = fr.UpdateUserRoleIDs(userCtx, user.ID, newRoles)
 		if err != nil {
 			utils.RecordSpanError(span, err)
 			return false, exceptions.InternalServerError(err)
@@ -2994,3 +3927,1449 @@ func (fr *Repository) CheckIfUserHasPermission(
 
 	return false, nil
 }
+
+// ListPendingOutboxEvents reads the oldest events that have not been published yet
+func (fr *Repository) ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	ctx, span := tracer.Start(ctx, "ListPendingOutboxEvents")
+	defer span.End()
+
+	query := &PageQuery{
+		CollectionName: fr.GetOutboxEventsCollectionName(),
+		Filters: []QueryFilter{
+			{FieldName: "published", Operator: "==", Value: nil},
+		},
+		OrderBy: []string{"created"},
+		Limit:   limit,
+	}
+	docs, err := fr.FirestoreClient.Query(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	events := []*domain.OutboxEvent{}
+	for _, doc := range docs {
+		event := &domain.OutboxEvent{}
+		if err := doc.DataTo(event); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read outbox event: %w", err),
+			)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventPublished records that an event has been published
+func (fr *Repository) MarkOutboxEventPublished(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "MarkOutboxEventPublished")
+	defer span.End()
+
+	err := fr.updateOutboxEvent(ctx, id, func(event *domain.OutboxEvent) {
+		published := time.Now().In(pubsubtools.TimeLocation)
+		event.Published = &published
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// RecordOutboxEventFailure records a failed attempt to publish an event
+func (fr *Repository) RecordOutboxEventFailure(ctx context.Context, id string, reason string) error {
+	ctx, span := tracer.Start(ctx, "RecordOutboxEventFailure")
+	defer span.End()
+
+	err := fr.updateOutboxEvent(ctx, id, func(event *domain.OutboxEvent) {
+		event.Attempts++
+		event.LastError = reason
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// updateOutboxEvent applies the provided changes to the outbox event that matches the id
+func (fr *Repository) updateOutboxEvent(
+	ctx context.Context,
+	id string,
+	update func(event *domain.OutboxEvent),
+) error {
+	err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		dsnap, err := tx.Get(&GetSingleQuery{
+			CollectionName: fr.GetOutboxEventsCollectionName(),
+			Value:          id,
+		})
+		if err != nil {
+			return err
+		}
+		if dsnap == nil {
+			return fmt.Errorf("outbox event %s not found", id)
+		}
+		event := &domain.OutboxEvent{}
+		if err := dsnap.DataTo(event); err != nil {
+			return fmt.Errorf("unable to read outbox event: %w", err)
+		}
+		update(event)
+		return tx.Update(&UpdateCommand{
+			CollectionName: fr.GetOutboxEventsCollectionName(),
+			ID:             id,
+			Data:           event,
+		})
+	})
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// isPublishedEvent checks whether an outbox event document has been published
+func isPublishedEvent(doc *firestore.DocumentSnapshot) bool {
+	event := &domain.OutboxEvent{}
+	if err := doc.DataTo(event); err != nil {
+		return false
+	}
+	return event.Published != nil
+}
+
+// GetPubSubMessage reads the record of a received message. It returns nil if the message
+// has not been received before
+func (fr *Repository) GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error) {
+	ctx, span := tracer.Start(ctx, "GetPubSubMessage")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetPubSubMessagesCollectionName(),
+		FieldName:      "id",
+		Value:          id,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	message := &domain.PubSubMessage{}
+	if err := docs[0].DataTo(message); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read pubsub message: %w", err),
+		)
+	}
+	return message, nil
+}
+
+// SavePubSubMessage creates or replaces the record of a received message. The document is
+// keyed by the message ID
+func (fr *Repository) SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error {
+	ctx, span := tracer.Start(ctx, "SavePubSubMessage")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetPubSubMessagesCollectionName(),
+		ID:             message.ID,
+		Data:           message,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListFailedPubSubMessages reads the messages that failed or were dead lettered, oldest first
+func (fr *Repository) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	ctx, span := tracer.Start(ctx, "ListFailedPubSubMessages")
+	defer span.End()
+
+	filters := []QueryFilter{
+		{
+			FieldName: "status",
+			Operator:  "in",
+			Value: []string{
+				string(domain.PubSubMessageStatusFailed),
+				string(domain.PubSubMessageStatusDeadLettered),
+			},
+		},
+	}
+	if topicID != nil {
+		filters = append(filters, QueryFilter{FieldName: "topicID", Operator: "==", Value: *topicID})
+	}
+	query := &PageQuery{
+		CollectionName: fr.GetPubSubMessagesCollectionName(),
+		Filters:        filters,
+		OrderBy:        []string{"created"},
+	}
+	docs, err := fr.FirestoreClient.Query(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	messages := []*domain.PubSubMessage{}
+	for _, doc := range docs {
+		message := &domain.PubSubMessage{}
+		if err := doc.DataTo(message); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read pubsub message: %w", err),
+			)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// CreateWebhookEndpoint stores a new webhook endpoint. The document is keyed by the endpoint ID
+func (fr *Repository) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	ctx, span := tracer.Start(ctx, "CreateWebhookEndpoint")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetWebhookEndpointsCollectionName(),
+		ID:             endpoint.ID,
+		Data:           endpoint,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetWebhookEndpoint reads a webhook endpoint
+func (fr *Repository) GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "GetWebhookEndpoint")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetWebhookEndpointsCollectionName(),
+		FieldName:      "id",
+		Value:          id,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook endpoint %s not found", id))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	endpoint := &domain.WebhookEndpoint{}
+	if err := docs[0].DataTo(endpoint); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read webhook endpoint: %w", err),
+		)
+	}
+	return endpoint, nil
+}
+
+// ListWebhookEndpoints reads all the webhook endpoints in the order they were registered
+func (fr *Repository) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookEndpoints")
+	defer span.End()
+
+	query := &PageQuery{
+		CollectionName: fr.GetWebhookEndpointsCollectionName(),
+		OrderBy:        []string{"created"},
+	}
+	docs, err := fr.FirestoreClient.Query(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	endpoints := []*domain.WebhookEndpoint{}
+	for _, doc := range docs {
+		endpoint := &domain.WebhookEndpoint{}
+		if err := doc.DataTo(endpoint); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read webhook endpoint: %w", err),
+			)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint and its delivery log
+func (fr *Repository) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "DeleteWebhookEndpoint")
+	defer span.End()
+
+	if _, err := fr.GetWebhookEndpoint(ctx, id); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+		FieldName:      "endpointID",
+		Value:          id,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	for _, doc := range docs {
+		command := &DeleteCommand{
+			CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+			ID:             doc.Ref.ID,
+		}
+		if err := fr.FirestoreClient.Delete(ctx, command); err != nil {
+			utils.RecordSpanError(span, err)
+			return exceptions.InternalServerError(err)
+		}
+	}
+
+	command := &DeleteCommand{
+		CollectionName: fr.GetWebhookEndpointsCollectionName(),
+		ID:             id,
+	}
+	if err := fr.FirestoreClient.Delete(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints
+// that subscribe to it. Deliveries that already exist are left as they are
+func (fr *Repository) EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error {
+	ctx, span := tracer.Start(ctx, "EnqueueWebhookDeliveries")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetWebhookEndpointsCollectionName(),
+		FieldName:      "eventTypes",
+		Value:          string(event.Type),
+		Operator:       "array-contains",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	deliveries := []*domain.WebhookDelivery{}
+	for _, doc := range docs {
+		endpoint := &domain.WebhookEndpoint{}
+		if err := doc.DataTo(endpoint); err != nil {
+			utils.RecordSpanError(span, err)
+			return exceptions.InternalServerError(
+				fmt.Errorf("unable to read webhook endpoint: %w", err),
+			)
+		}
+		if endpoint.Active {
+			deliveries = append(deliveries, utils.NewWebhookDelivery(endpoint, event, now))
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		missing := []*domain.WebhookDelivery{}
+		for _, delivery := range deliveries {
+			dsnap, err := tx.Get(&GetSingleQuery{
+				CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+				Value:          delivery.ID,
+			})
+			if err != nil {
+				return err
+			}
+			if dsnap == nil {
+				missing = append(missing, delivery)
+			}
+		}
+		for _, delivery := range missing {
+			err := tx.Update(&UpdateCommand{
+				CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+				ID:             delivery.ID,
+				Data:           delivery,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListDueWebhookDeliveries reads the oldest pending deliveries that are due to be attempted
+func (fr *Repository) ListDueWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "ListDueWebhookDeliveries")
+	defer span.End()
+
+	query := &PageQuery{
+		CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+		Filters: []QueryFilter{
+			{FieldName: "status", Operator: "==", Value: string(domain.WebhookDeliveryStatusPending)},
+			{FieldName: "nextAttempt", Operator: "<=", Value: now},
+		},
+		OrderBy: []string{"nextAttempt"},
+		Limit:   limit,
+	}
+	deliveries, err := fr.queryWebhookDeliveries(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDelivery reads a webhook delivery
+func (fr *Repository) GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "GetWebhookDelivery")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+		FieldName:      "id",
+		Value:          id,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook delivery %s not found", id))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	delivery := &domain.WebhookDelivery{}
+	if err := docs[0].DataTo(delivery); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read webhook delivery: %w", err),
+		)
+	}
+	return delivery, nil
+}
+
+// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+func (fr *Repository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := tracer.Start(ctx, "UpdateWebhookDelivery")
+	defer span.End()
+
+	if _, err := fr.GetWebhookDelivery(ctx, delivery.ID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+		ID:             delivery.ID,
+		Data:           delivery,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first
+func (fr *Repository) ListWebhookDeliveries(
+	ctx context.Context,
+	endpointID string,
+	status *domain.WebhookDeliveryStatus,
+) ([]*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookDeliveries")
+	defer span.End()
+
+	filters := []QueryFilter{
+		{FieldName: "endpointID", Operator: "==", Value: endpointID},
+	}
+	if status != nil {
+		filters = append(filters, QueryFilter{FieldName: "status", Operator: "==", Value: string(*status)})
+	}
+	query := &PageQuery{
+		CollectionName: fr.GetWebhookDeliveriesCollectionName(),
+		Filters:        filters,
+		OrderBy:        []string{"created"},
+		Descending:     true,
+	}
+	deliveries, err := fr.queryWebhookDeliveries(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// queryWebhookDeliveries reads the webhook deliveries that match a query
+func (fr *Repository) queryWebhookDeliveries(ctx context.Context, query *PageQuery) ([]*domain.WebhookDelivery, error) {
+	docs, err := fr.FirestoreClient.Query(ctx, query)
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	deliveries := []*domain.WebhookDelivery{}
+	for _, doc := range docs {
+		delivery := &domain.WebhookDelivery{}
+		if err := doc.DataTo(delivery); err != nil {
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read webhook delivery: %w", err),
+			)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// SaveSession creates or replaces a session. The document is keyed by the session ID
+func (fr *Repository) SaveSession(ctx context.Context, session *domain.Session) error {
+	ctx, span := tracer.Start(ctx, "SaveSession")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetSessionsCollectionName(),
+		ID:             session.ID,
+		Data:           session,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetSessionByRefreshTokenHash reads the session that holds a refresh token. It returns nil if
+// no session holds the token
+func (fr *Repository) GetSessionByRefreshTokenHash(
+	ctx context.Context,
+	refreshTokenHash string,
+) (*domain.Session, error) {
+	ctx, span := tracer.Start(ctx, "GetSessionByRefreshTokenHash")
+	defer span.End()
+
+	sessions, err := fr.getSessions(ctx, "refreshTokenHash", refreshTokenHash)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
+// ListSessions reads the sessions of a profile, most recently seen first
+func (fr *Repository) ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	ctx, span := tracer.Start(ctx, "ListSessions")
+	defer span.End()
+
+	sessions, err := fr.getSessions(ctx, "profileID", profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	utils.SortSessions(sessions)
+	return sessions, nil
+}
+
+// RevokeSessions signs out the listed sessions of a profile, or all of its sessions when no
+// session is listed
+func (fr *Repository) RevokeSessions(
+	ctx context.Context,
+	profileID string,
+	sessionIDs []string,
+	revokedAt time.Time,
+) error {
+	ctx, span := tracer.Start(ctx, "RevokeSessions")
+	defer span.End()
+
+	err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		docs, err := tx.GetAll(&GetAllQuery{
+			CollectionName: fr.GetSessionsCollectionName(),
+			FieldName:      "profileID",
+			Value:          profileID,
+			Operator:       "==",
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+
+		sessions := []*domain.Session{}
+		for _, doc := range docs {
+			session := &domain.Session{}
+			if err := doc.DataTo(session); err != nil {
+				return exceptions.InternalServerError(
+					fmt.Errorf("unable to read session: %w", err),
+				)
+			}
+			sessions = append(sessions, session)
+		}
+
+		for _, session := range utils.RevokeSessions(sessions, sessionIDs, revokedAt) {
+			err := tx.Update(&UpdateCommand{
+				CollectionName: fr.GetSessionsCollectionName(),
+				ID:             session.ID,
+				Data:           session,
+			})
+			if err != nil {
+				return exceptions.InternalServerError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RevokeRefreshTokens revokes all the refresh tokens that were issued to a Firebase user
+func (fr *Repository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	ctx, span := tracer.Start(ctx, "RevokeRefreshTokens")
+	defer span.End()
+
+	if err := fr.FirebaseClient.RevokeRefreshTokens(ctx, uid); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to revoke the refresh tokens of user %s: %w", uid, err),
+		)
+	}
+	return nil
+}
+
+func (fr *Repository) getSessions(ctx context.Context, fieldName string, value string) ([]*domain.Session, error) {
+	query := &GetAllQuery{
+		CollectionName: fr.GetSessionsCollectionName(),
+		FieldName:      fieldName,
+		Value:          value,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	sessions := []*domain.Session{}
+	for _, doc := range docs {
+		session := &domain.Session{}
+		if err := doc.DataTo(session); err != nil {
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read session: %w", err),
+			)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// RecordLoginEvent appends an attempt to log in to the audit trail
+func (fr *Repository) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error {
+	ctx, span := tracer.Start(ctx, "RecordLoginEvent")
+	defer span.End()
+
+	command := &CreateCommand{
+		CollectionName: fr.GetLoginEventsCollectionName(),
+		Data:           event,
+	}
+	if _, err := fr.FirestoreClient.Create(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListLoginEvents reads the login events that match a filter, newest first
+func (fr *Repository) ListLoginEvents(
+	ctx context.Context,
+	filter *domain.LoginEventFilter,
+) ([]*domain.LoginEvent, error) {
+	ctx, span := tracer.Start(ctx, "ListLoginEvents")
+	defer span.End()
+
+	filters := []QueryFilter{}
+	if filter.From != nil {
+		filters = append(filters, QueryFilter{FieldName: "timestamp", Operator: ">=", Value: *filter.From})
+	}
+	if filter.To != nil {
+		filters = append(filters, QueryFilter{FieldName: "timestamp", Operator: "<", Value: *filter.To})
+	}
+	if filter.Outcome != nil {
+		filters = append(filters, QueryFilter{FieldName: "outcome", Operator: "==", Value: *filter.Outcome})
+	}
+	if filter.ProfileID != nil {
+		filters = append(filters, QueryFilter{FieldName: "profileID", Operator: "==", Value: *filter.ProfileID})
+	}
+	query := &PageQuery{
+		CollectionName: fr.GetLoginEventsCollectionName(),
+		Filters:        filters,
+		OrderBy:        []string{"timestamp"},
+		Descending:     true,
+		Limit:          filter.Limit,
+	}
+	docs, err := fr.FirestoreClient.Query(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	events := []*domain.LoginEvent{}
+	for _, doc := range docs {
+		event := &domain.LoginEvent{}
+		if err := doc.DataTo(event); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read login event: %w", err),
+			)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// SaveLoginAlert creates or replaces a login alert
+func (fr *Repository) SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error {
+	ctx, span := tracer.Start(ctx, "SaveLoginAlert")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetLoginAlertsCollectionName(),
+		ID:             alert.ID,
+		Data:           alert,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetLoginAlertByTokenHash reads the login alert whose "this wasn't me" token has the provided
+// hash. It returns nil if there is no such alert
+func (fr *Repository) GetLoginAlertByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*domain.LoginAlert, error) {
+	ctx, span := tracer.Start(ctx, "GetLoginAlertByTokenHash")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetLoginAlertsCollectionName(),
+		FieldName:      "tokenHash",
+		Value:          tokenHash,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	alert := &domain.LoginAlert{}
+	if err := docs[0].DataTo(alert); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read login alert: %w", err),
+		)
+	}
+	return alert, nil
+}
+
+// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile. The enrolment is stored
+// under the profile ID so that a profile has at most one
+func (fr *Repository) SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+	ctx, span := tracer.Start(ctx, "SaveTOTPEnrolment")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetTOTPEnrolmentsCollectionName(),
+		ID:             enrolment.ProfileID,
+		Data:           enrolment,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetTOTPEnrolment reads the TOTP enrolment of a profile. It returns nil if the profile has none
+func (fr *Repository) GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+	ctx, span := tracer.Start(ctx, "GetTOTPEnrolment")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetTOTPEnrolmentsCollectionName(),
+		FieldName:      "profileID",
+		Value:          profileID,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	enrolment := &domain.TOTPEnrolment{}
+	if err := docs[0].DataTo(enrolment); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read TOTP enrolment: %w", err),
+		)
+	}
+	return enrolment, nil
+}
+
+// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+func (fr *Repository) DeleteTOTPEnrolment(ctx context.Context, profileID string) error {
+	ctx, span := tracer.Start(ctx, "DeleteTOTPEnrolment")
+	defer span.End()
+
+	command := &DeleteCommand{
+		CollectionName: fr.GetTOTPEnrolmentsCollectionName(),
+		ID:             profileID,
+	}
+	if err := fr.FirestoreClient.Delete(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetTwoFactorPolicy reads the two factor policy. It returns an empty policy if none has been saved
+func (fr *Repository) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	ctx, span := tracer.Start(ctx, "GetTwoFactorPolicy")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetTwoFactorPoliciesCollectionName(),
+		FieldName:      "id",
+		Value:          domain.TwoFactorPolicyID,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return &domain.TwoFactorPolicy{ID: domain.TwoFactorPolicyID, RequiredScopes: []string{}}, nil
+	}
+
+	policy := &domain.TwoFactorPolicy{}
+	if err := docs[0].DataTo(policy); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read two factor policy: %w", err),
+		)
+	}
+	return policy, nil
+}
+
+// SaveTwoFactorPolicy replaces the two factor policy
+func (fr *Repository) SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	ctx, span := tracer.Start(ctx, "SaveTwoFactorPolicy")
+	defer span.End()
+
+	policy.ID = domain.TwoFactorPolicyID
+	command := &UpdateCommand{
+		CollectionName: fr.GetTwoFactorPoliciesCollectionName(),
+		ID:             policy.ID,
+		Data:           policy,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// SavePassword creates or replaces the password of the email login of a profile. The password is
+// stored under the profile ID so that a profile has at most one
+func (fr *Repository) SavePassword(ctx context.Context, password *domain.Password) error {
+	ctx, span := tracer.Start(ctx, "SavePassword")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetPasswordsCollectionName(),
+		ID:             password.ProfileID,
+		Data:           password,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetPasswordByEmailAddress reads the password of the email login of a normalized email address.
+// It returns nil if the email address has no email login
+func (fr *Repository) GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error) {
+	ctx, span := tracer.Start(ctx, "GetPasswordByEmailAddress")
+	defer span.End()
+
+	return fr.getPassword(ctx, "emailAddress", emailAddress)
+}
+
+// GetPasswordByProfileID reads the password of the email login of a profile. It returns nil if
+// the profile has none
+func (fr *Repository) GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error) {
+	ctx, span := tracer.Start(ctx, "GetPasswordByProfileID")
+	defer span.End()
+
+	return fr.getPassword(ctx, "profileID", profileID)
+}
+
+func (fr *Repository) getPassword(ctx context.Context, field string, value string) (*domain.Password, error) {
+	ctx, span := tracer.Start(ctx, "getPassword")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetPasswordsCollectionName(),
+		FieldName:      field,
+		Value:          value,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	password := &domain.Password{}
+	if err := docs[0].DataTo(password); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read password: %w", err),
+		)
+	}
+	return password, nil
+}
+
+// ReservePasswordAttempt counts an attempt to use the password of the email login of a profile
+// before the password is compared, locking the password after too many failed attempts. It refuses
+// the attempt when the password is locked or was attempted too recently
+func (fr *Repository) ReservePasswordAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.Password, error) {
+	ctx, span := tracer.Start(ctx, "ReservePasswordAttempt")
+	defer span.End()
+
+	password, err := fr.updateStoredPassword(ctx, profileID, func(password *domain.Password) error {
+		return utils.ReservePasswordAttempt(password, attemptedAt)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return password, nil
+}
+
+// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of a
+// profile, unlocking the password
+func (fr *Repository) ClearPasswordAttempts(ctx context.Context, profileID string) error {
+	ctx, span := tracer.Start(ctx, "ClearPasswordAttempts")
+	defer span.End()
+
+	if _, err := fr.updateStoredPassword(ctx, profileID, func(password *domain.Password) error {
+		utils.ClearPasswordAttempts(password)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RequirePasswordReset stops the password of the email login of a profile from being used until it
+// is reset with an OTP
+func (fr *Repository) RequirePasswordReset(ctx context.Context, profileID string) error {
+	ctx, span := tracer.Start(ctx, "RequirePasswordReset")
+	defer span.End()
+
+	if _, err := fr.updateStoredPassword(ctx, profileID, func(password *domain.Password) error {
+		utils.RequirePasswordReset(password)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// UpdatePasswordHash replaces the hash, salt and hashing scheme of the password of a profile with
+// the ones of a rehashed password. The rest of the password, including its failed attempts, is kept
+func (fr *Repository) UpdatePasswordHash(
+	ctx context.Context,
+	password *domain.Password,
+	previousPasswordHash string,
+) error {
+	ctx, span := tracer.Start(ctx, "UpdatePasswordHash")
+	defer span.End()
+
+	_, err := fr.updateStoredPassword(ctx, password.ProfileID, func(stored *domain.Password) error {
+		utils.ReplacePasswordHash(stored, previousPasswordHash, password)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// updateStoredPassword applies a change to the stored password of a profile in a transaction, so
+// that concurrent changes are not lost
+func (fr *Repository) updateStoredPassword(
+	ctx context.Context,
+	profileID string,
+	update func(password *domain.Password) error,
+) (*domain.Password, error) {
+	updated := &domain.Password{}
+	err := fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		docs, err := tx.GetAll(&GetAllQuery{
+			CollectionName: fr.GetPasswordsCollectionName(),
+			FieldName:      "profileID",
+			Value:          profileID,
+			Operator:       "==",
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		if len(docs) == 0 {
+			return exceptions.InternalServerError(fmt.Errorf("profile %s has no password", profileID))
+		}
+
+		stored := &domain.Password{}
+		if err := docs[0].DataTo(stored); err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		*updated = *stored
+		if err := update(updated); err != nil {
+			return err
+		}
+
+		err = tx.Update(&UpdateCommand{
+			CollectionName: fr.GetPasswordsCollectionName(),
+			ID:             docs[0].Ref.ID,
+			Data:           updated,
+		})
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return updated, nil
+}
+
+// MergeUserProfiles stores the result of a merge as the surviving profile and removes the merged profile
+// in a single transaction. The PIN, password, communications settings and experiment participation of the
+// merged profile are moved to the survivor unless it has its own
+func (fr *Repository) MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error {
+	ctx, span := tracer.Start(ctx, "MergeUserProfiles")
+	defer span.End()
+
+	events, err := utils.ProfileEvents(merge.Survivor, merge.Result)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	event, err := utils.UserMergedEvent(merge)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	events = append(events, event)
+	reserve, _ := utils.IdentifierReservationChanges(merge.Survivor, merge.Result)
+
+	err = fr.FirestoreClient.RunTransaction(ctx, func(ctx context.Context, tx FirestoreTransaction) error {
+		// every read has to be done before the first write
+		survivorDoc, err := fr.getProfileDocumentForMerge(tx, merge.Survivor)
+		if err != nil {
+			return err
+		}
+		mergedDoc, err := fr.getProfileDocumentForMerge(tx, merge.MergedProfile)
+		if err != nil {
+			return err
+		}
+
+		reads := map[string]*GetAllQuery{}
+		for _, profileID := range []string{merge.SurvivorID, merge.MergedProfileID} {
+			reads[fr.GetPINsCollectionName()+profileID] = &GetAllQuery{
+				CollectionName: fr.GetPINsCollectionName(),
+				FieldName:      "profileID",
+				Value:          profileID,
+				Operator:       "==",
+			}
+			reads[fr.GetCommunicationsSettingsCollectionName()+profileID] = &GetAllQuery{
+				CollectionName: fr.GetCommunicationsSettingsCollectionName(),
+				FieldName:      "profileID",
+				Value:          profileID,
+				Operator:       "==",
+			}
+			reads[fr.GetExperimentParticipantCollectionName()+profileID] = &GetAllQuery{
+				CollectionName: fr.GetExperimentParticipantCollectionName(),
+				FieldName:      "id",
+				Value:          profileID,
+				Operator:       "==",
+			}
+		}
+		reads[fr.GetIdentifierReservationsCollectionName()] = &GetAllQuery{
+			CollectionName: fr.GetIdentifierReservationsCollectionName(),
+			FieldName:      "profileID",
+			Value:          merge.MergedProfileID,
+			Operator:       "==",
+		}
+		reads[fr.GetProfileTombstonesCollectionName()] = &GetAllQuery{
+			CollectionName: fr.GetProfileTombstonesCollectionName(),
+			FieldName:      "mergedInto",
+			Value:          merge.MergedProfileID,
+			Operator:       "==",
+		}
+		docs := map[string][]*firestore.DocumentSnapshot{}
+		for key, query := range reads {
+			found, err := tx.GetAll(query)
+			if err != nil {
+				return err
+			}
+			docs[key] = found
+		}
+
+		passwords := map[string]*firestore.DocumentSnapshot{}
+		for _, profileID := range []string{merge.SurvivorID, merge.MergedProfileID} {
+			dsnap, err := tx.Get(&GetSingleQuery{
+				CollectionName: fr.GetPasswordsCollectionName(),
+				Value:          profileID,
+			})
+			if err != nil {
+				return err
+			}
+			passwords[profileID] = dsnap
+		}
+
+		// the identifiers of the merged profile are handed over to the survivor. Any other
+		// identifier that the survivor gains must be free
+		handedOver := map[string]bool{}
+		for _, reservation := range reserve {
+			handedOver[reservation.ID] = true
+			dsnap, err := tx.Get(&GetSingleQuery{
+				CollectionName: fr.GetIdentifierReservationsCollectionName(),
+				Value:          reservation.ID,
+			})
+			if err != nil {
+				return err
+			}
+			if dsnap == nil {
+				continue
+			}
+			existing := &domain.IdentifierReservation{}
+			if err := dsnap.DataTo(existing); err != nil {
+				return exceptions.InternalServerError(
+					fmt.Errorf("unable to read identifier reservation: %w", err),
+				)
+			}
+			if existing.ProfileID != merge.SurvivorID && existing.ProfileID != merge.MergedProfileID {
+				return utils.IdentifierInUseError(reservation.Kind)
+			}
+		}
+
+		err = tx.Update(&UpdateCommand{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			ID:             survivorDoc.Ref.ID,
+			Data:           merge.Result,
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Delete(&DeleteCommand{
+			CollectionName: fr.GetUserProfileCollectionName(),
+			ID:             mergedDoc.Ref.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range docs[fr.GetIdentifierReservationsCollectionName()] {
+			if handedOver[doc.Ref.ID] {
+				continue
+			}
+			err := tx.Delete(&DeleteCommand{
+				CollectionName: fr.GetIdentifierReservationsCollectionName(),
+				ID:             doc.Ref.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, reservation := range reserve {
+			err := tx.Update(&UpdateCommand{
+				CollectionName: fr.GetIdentifierReservationsCollectionName(),
+				ID:             reservation.ID,
+				Data:           reservation,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, collection := range []string{fr.GetPINsCollectionName(), fr.GetCommunicationsSettingsCollectionName()} {
+			keep := len(docs[collection+merge.SurvivorID]) == 0
+			for _, doc := range docs[collection+merge.MergedProfileID] {
+				if !keep {
+					err := tx.Delete(&DeleteCommand{CollectionName: collection, ID: doc.Ref.ID})
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				data := doc.Data()
+				data["profileID"] = merge.SurvivorID
+				err := tx.Update(&UpdateCommand{CollectionName: collection, ID: doc.Ref.ID, Data: data})
+				if err != nil {
+					return err
+				}
+				keep = false
+			}
+		}
+
+		participants := fr.GetExperimentParticipantCollectionName()
+		keep := len(docs[participants+merge.SurvivorID]) == 0
+		for _, doc := range docs[participants+merge.MergedProfileID] {
+			if !keep {
+				if err := tx.Delete(&DeleteCommand{CollectionName: participants, ID: doc.Ref.ID}); err != nil {
+					return err
+				}
+				continue
+			}
+			err := tx.Update(&UpdateCommand{CollectionName: participants, ID: doc.Ref.ID, Data: merge.Result})
+			if err != nil {
+				return err
+			}
+			keep = false
+		}
+
+		if merged := passwords[merge.MergedProfileID]; merged != nil {
+			err := tx.Delete(&DeleteCommand{
+				CollectionName: fr.GetPasswordsCollectionName(),
+				ID:             merge.MergedProfileID,
+			})
+			if err != nil {
+				return err
+			}
+			if passwords[merge.SurvivorID] == nil {
+				data := merged.Data()
+				data["profileID"] = merge.SurvivorID
+				err := tx.Update(&UpdateCommand{
+					CollectionName: fr.GetPasswordsCollectionName(),
+					ID:             merge.SurvivorID,
+					Data:           data,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// profiles that were merged into the merged profile now live on in the survivor
+		for _, doc := range docs[fr.GetProfileTombstonesCollectionName()] {
+			data := doc.Data()
+			data["mergedInto"] = merge.SurvivorID
+			err := tx.Update(&UpdateCommand{
+				CollectionName: fr.GetProfileTombstonesCollectionName(),
+				ID:             doc.Ref.ID,
+				Data:           data,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Update(&UpdateCommand{
+			CollectionName: fr.GetProfileTombstonesCollectionName(),
+			ID:             merge.MergedProfileID,
+			Data:           utils.NewProfileTombstone(merge),
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Update(&UpdateCommand{
+			CollectionName: fr.GetProfileMergesCollectionName(),
+			ID:             merge.ID,
+			Data:           merge,
+		})
+		if err != nil {
+			return err
+		}
+		return fr.addOutboxEvents(tx, events...)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		if isWrappedError(err) {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to merge user profiles: %v", err),
+		)
+	}
+	return nil
+}
+
+// getProfileDocumentForMerge reads the document of a profile that is being merged in a transaction.
+// It returns a conflict error when the profile is no longer in the state that the merge is based on
+func (fr *Repository) getProfileDocumentForMerge(
+	tx FirestoreTransaction,
+	profile *profileutils.UserProfile,
+) (*firestore.DocumentSnapshot, error) {
+	docs, err := tx.GetAll(&GetAllQuery{
+		CollectionName: fr.GetUserProfileCollectionName(),
+		FieldName:      "id",
+		Value:          profile.ID,
+		Operator:       "==",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+	stored := &profileutils.UserProfile{}
+	if err := docs[0].DataTo(stored); err != nil {
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read user profile: %w", err),
+		)
+	}
+	if err := utils.CheckProfileUnchanged(stored, profile); err != nil {
+		return nil, err
+	}
+	return docs[0], nil
+}
+
+// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one.
+// It returns nil if the profile has not been merged
+func (fr *Repository) GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	ctx, span := tracer.Start(ctx, "GetProfileTombstone")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetProfileTombstonesCollectionName(),
+		FieldName:      "profileID",
+		Value:          profileID,
+		Operator:       "==",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	tombstone := &domain.ProfileTombstone{}
+	if err := docs[0].DataTo(tombstone); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to read profile tombstone: %w", err),
+		)
+	}
+	return tombstone, nil
+}
+
+// SaveSigningKey stores a new signing key
+func (fr *Repository) SaveSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	ctx, span := tracer.Start(ctx, "SaveSigningKey")
+	defer span.End()
+
+	command := &UpdateCommand{
+		CollectionName: fr.GetSigningKeysCollectionName(),
+		ID:             key.ID,
+		Data:           key,
+	}
+	if err := fr.FirestoreClient.Update(ctx, command); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListSigningKeys reads the signing keys that are still published at the provided time, newest first
+func (fr *Repository) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	ctx, span := tracer.Start(ctx, "ListSigningKeys")
+	defer span.End()
+
+	query := &GetAllQuery{
+		CollectionName: fr.GetSigningKeysCollectionName(),
+		FieldName:      "notAfter",
+		Value:          now,
+		Operator:       ">",
+	}
+	docs, err := fr.FirestoreClient.GetAll(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	keys := []*domain.SigningKey{}
+	for _, doc := range docs {
+		key := &domain.SigningKey{}
+		if err := doc.DataTo(key); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read signing key: %w", err),
+			)
+		}
+		keys = append(keys, key)
+	}
+	utils.SortSigningKeys(keys)
+	return keys, nil
+}
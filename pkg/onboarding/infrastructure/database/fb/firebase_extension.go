@@ -3,6 +3,7 @@ package fb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 
@@ -13,11 +14,23 @@ import (
 // FirestoreClientExtension represents the methods we need from firebase `firestore.Client`
 type FirestoreClientExtension interface {
 	GetAll(ctx context.Context, query *GetAllQuery) ([]*firestore.DocumentSnapshot, error)
+	Query(ctx context.Context, query *PageQuery) ([]*firestore.DocumentSnapshot, error)
 	Create(ctx context.Context, command *CreateCommand) (*firestore.DocumentRef, error)
 	Update(ctx context.Context, command *UpdateCommand) error
 	Delete(ctx context.Context, command *DeleteCommand) error
 	Get(ctx context.Context, query *GetSingleQuery) (*firestore.DocumentSnapshot, error)
 	RawClient(ctx context.Context) *firestore.Client
+	RunTransaction(ctx context.Context, fn func(ctx context.Context, tx FirestoreTransaction) error) error
+}
+
+// FirestoreTransaction represents the operations that can be performed as a single unit of work.
+// All the reads in a transaction must be done before any of the writes
+type FirestoreTransaction interface {
+	Get(query *GetSingleQuery) (*firestore.DocumentSnapshot, error)
+	GetAll(query *GetAllQuery) ([]*firestore.DocumentSnapshot, error)
+	Create(command *CreateCommand) (*firestore.DocumentRef, error)
+	Update(command *UpdateCommand) error
+	Delete(command *DeleteCommand) error
 }
 
 // FirestoreClientExtensionImpl ...
@@ -38,6 +51,25 @@ type GetAllQuery struct {
 	Operator       string
 }
 
+// QueryFilter is a condition that the documents read by a PageQuery should meet
+type QueryFilter struct {
+	FieldName string
+	Operator  string
+	Value     interface{}
+}
+
+// PageQuery represent payload required to read a page of documents from the database.
+// The documents are ordered by the OrderBy fields and read starting after the StartAfter
+// values, which are given in the same order as the OrderBy fields
+type PageQuery struct {
+	CollectionName string
+	Filters        []QueryFilter
+	OrderBy        []string
+	Descending     bool
+	StartAfter     []interface{}
+	Limit          int
+}
+
 // GetSingleQuery represent payload required to get a single item from the database
 type GetSingleQuery struct {
 	CollectionName string
@@ -55,6 +87,10 @@ type UpdateCommand struct {
 	CollectionName string
 	Data           interface{}
 	ID             string
+
+	// LastUpdateTime, when set, is the update time of the document when it was read.
+	// The update is rejected with a conflict error if the document has changed since then
+	LastUpdateTime time.Time
 }
 
 // DeleteCommand represent payload required to perform a delete operation in the database
@@ -90,6 +126,34 @@ func (f *FirestoreClientExtensionImpl) GetAll(ctx context.Context, getQuery *Get
 	return documents, nil
 }
 
+// Query reads a page of documents from a firestore collection
+func (f *FirestoreClientExtensionImpl) Query(ctx context.Context, pageQuery *PageQuery) ([]*firestore.DocumentSnapshot, error) {
+	query := f.client.Collection(pageQuery.CollectionName).Query
+	for _, filter := range pageQuery.Filters {
+		query = query.Where(filter.FieldName, filter.Operator, filter.Value)
+	}
+
+	direction := firestore.Asc
+	if pageQuery.Descending {
+		direction = firestore.Desc
+	}
+	for _, field := range pageQuery.OrderBy {
+		query = query.OrderBy(field, direction)
+	}
+	if len(pageQuery.StartAfter) > 0 {
+		query = query.StartAfter(pageQuery.StartAfter...)
+	}
+	if pageQuery.Limit > 0 {
+		query = query.Limit(pageQuery.Limit)
+	}
+
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	return docs, nil
+}
+
 // Create persists data to a firestore collection
 func (f *FirestoreClientExtensionImpl) Create(ctx context.Context, command *CreateCommand) (*firestore.DocumentRef, error) {
 	docRef, _, err := f.client.Collection(command.CollectionName).Add(ctx, command.Data)
@@ -101,11 +165,27 @@ func (f *FirestoreClientExtensionImpl) Create(ctx context.Context, command *Crea
 
 // Update updates data to a firestore collection
 func (f *FirestoreClientExtensionImpl) Update(ctx context.Context, command *UpdateCommand) error {
-	_, err := f.client.Collection(command.CollectionName).Doc(command.ID).Set(ctx, command.Data)
-	if err != nil {
-		return err
+	docRef := f.client.Collection(command.CollectionName).Doc(command.ID)
+	if command.LastUpdateTime.IsZero() {
+		_, err := docRef.Set(ctx, command.Data)
+		if err != nil {
+			return err
+		}
+		return nil
 	}
-	return nil
+
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		dsnap, err := tx.Get(docRef)
+		if err != nil {
+			return err
+		}
+		if !dsnap.UpdateTime.Equal(command.LastUpdateTime) {
+			return exceptions.ProfileUpdateConflictError(
+				fmt.Errorf("document %s has been updated since it was read", command.ID),
+			)
+		}
+		return tx.Set(docRef, command.Data)
+	})
 }
 
 // Delete deletes data to a firestore collection
@@ -131,12 +211,83 @@ func (f *FirestoreClientExtensionImpl) RawClient(ctx context.Context) *firestore
 	return f.client
 }
 
+// RunTransaction runs the provided function in a firestore transaction. The writes made through
+// the transaction are committed when the function returns without an error and discarded otherwise
+func (f *FirestoreClientExtensionImpl) RunTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context, tx FirestoreTransaction) error,
+) error {
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return fn(ctx, &FirestoreTransactionImpl{client: f.client, tx: tx})
+	})
+}
+
+// FirestoreTransactionImpl ...
+type FirestoreTransactionImpl struct {
+	client *firestore.Client
+	tx     *firestore.Transaction
+}
+
+// Get retrieves a single document as part of the transaction. It returns nil when the document does not exist
+func (t *FirestoreTransactionImpl) Get(query *GetSingleQuery) (*firestore.DocumentSnapshot, error) {
+	docRef := t.client.Collection(query.CollectionName).Doc(query.Value)
+	docs, err := t.tx.GetAll([]*firestore.DocumentRef{docRef})
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	if !docs[0].Exists() {
+		return nil, nil
+	}
+	return docs[0], nil
+}
+
+// GetAll retrieves the documents matching the query as part of the transaction
+func (t *FirestoreTransactionImpl) GetAll(getQuery *GetAllQuery) ([]*firestore.DocumentSnapshot, error) {
+	collection := t.client.Collection(getQuery.CollectionName)
+
+	var docs []*firestore.DocumentSnapshot
+	var err error
+	if getQuery.FieldName == "" && getQuery.Operator == "" && getQuery.Value == nil {
+		docs, err = t.tx.Documents(collection).GetAll()
+	} else {
+		query := collection.Where(getQuery.FieldName, getQuery.Operator, getQuery.Value)
+		docs, err = t.tx.Documents(query).GetAll()
+	}
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	return docs, nil
+}
+
+// Create adds a new document to a firestore collection when the transaction is committed
+func (t *FirestoreTransactionImpl) Create(command *CreateCommand) (*firestore.DocumentRef, error) {
+	docRef := t.client.Collection(command.CollectionName).NewDoc()
+	if err := t.tx.Create(docRef, command.Data); err != nil {
+		return nil, exceptions.InternalServerError(fmt.Errorf("unable to create new document: %w", err))
+	}
+	return docRef, nil
+}
+
+// Update replaces a document in a firestore collection when the transaction is committed
+func (t *FirestoreTransactionImpl) Update(command *UpdateCommand) error {
+	return t.tx.Set(t.client.Collection(command.CollectionName).Doc(command.ID), command.Data)
+}
+
+// Delete removes a document from a firestore collection when the transaction is committed
+func (t *FirestoreTransactionImpl) Delete(command *DeleteCommand) error {
+	return t.tx.Delete(t.client.Collection(command.CollectionName).Doc(command.ID))
+}
+
 // FirebaseClientExtension represents the methods we need from firebase `auth.Client`
 type FirebaseClientExtension interface {
+	GetUser(ctx context.Context, uid string) (*auth.UserRecord, error)
 	GetUserByPhoneNumber(ctx context.Context, phone string) (*auth.UserRecord, error)
+	GetUserByEmail(ctx context.Context, email string) (*auth.UserRecord, error)
 	CreateUser(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error)
+	UpdateUser(ctx context.Context, uid string, user *auth.UserToUpdate) (*auth.UserRecord, error)
 	DeleteUser(ctx context.Context, uid string) error
 	VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error)
+	RevokeRefreshTokens(ctx context.Context, uid string) error
 }
 
 // FirebaseClientExtensionImpl ...
@@ -147,18 +298,40 @@ func NewFirebaseClientExtensionImpl() FirebaseClientExtension {
 	return &FirebaseClientExtensionImpl{}
 }
 
+// GetUser ...
+func (f *FirebaseClientExtensionImpl) GetUser(ctx context.Context, uid string) (*auth.UserRecord, error) {
+	var client *auth.Client
+	return client.GetUser(ctx, uid)
+}
+
 // GetUserByPhoneNumber ...
 func (f *FirebaseClientExtensionImpl) GetUserByPhoneNumber(ctx context.Context, phone string) (*auth.UserRecord, error) {
 	var client *auth.Client
 	return client.GetUserByPhoneNumber(ctx, phone)
 }
 
+// GetUserByEmail ...
+func (f *FirebaseClientExtensionImpl) GetUserByEmail(ctx context.Context, email string) (*auth.UserRecord, error) {
+	var client *auth.Client
+	return client.GetUserByEmail(ctx, email)
+}
+
 // CreateUser ...
 func (f *FirebaseClientExtensionImpl) CreateUser(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
 	var client *auth.Client
 	return client.CreateUser(ctx, user)
 }
 
+// UpdateUser ...
+func (f *FirebaseClientExtensionImpl) UpdateUser(
+	ctx context.Context,
+	uid string,
+	user *auth.UserToUpdate,
+) (*auth.UserRecord, error) {
+	var client *auth.Client
+	return client.UpdateUser(ctx, uid, user)
+}
+
 // DeleteUser ...
 func (f *FirebaseClientExtensionImpl) DeleteUser(ctx context.Context, uid string) error {
 	var client *auth.Client
@@ -174,3 +347,9 @@ func (f *FirebaseClientExtensionImpl) VerifyIDToken(ctx context.Context, idToken
 	}
 	return authToken, nil
 }
+
+// RevokeRefreshTokens revokes all the refresh tokens that were issued to a user
+func (f *FirebaseClientExtensionImpl) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	var client *auth.Client
+	return client.RevokeRefreshTokens(ctx, uid)
+}
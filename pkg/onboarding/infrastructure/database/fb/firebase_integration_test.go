@@ -21,6 +21,7 @@ import (
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/fb"
 	"github.com/savannahghi/profileutils"
@@ -275,7 +276,7 @@ func TestPurgeUserByPhoneNumber(t *testing.T) {
 	assert.Nil(t, pin)
 
 	// now set a  pin. this should not fail
-	userpin := "1234"
+	userpin := "2791"
 	pset, err := s.SetUserPIN(ctx, userpin, invalidpr1.ID)
 	assert.Nil(t, err)
 	assert.NotNil(t, pset)
@@ -1321,7 +1322,7 @@ func TestRepository_UpdateVerifiedUIDS(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := fr.UpdateVerifiedUIDS(tt.args.ctx, tt.args.id, tt.args.uids); (err != nil) != tt.wantErr {
+			if err := fr.UpdateVerifiedUIDS(storedVersion(tt.args.ctx, fr, tt.args.id), tt.args.id, tt.args.uids); (err != nil) != tt.wantErr {
 				t.Errorf("Repository.UpdateVerifiedUIDS() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1424,7 +1425,7 @@ func TestRepository_UpdateVerifiedIdentifiers(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := fr.UpdateVerifiedIdentifiers(tt.args.ctx, tt.args.id, tt.args.identifiers); (err != nil) != tt.wantErr {
+			if err := fr.UpdateVerifiedIdentifiers(storedVersion(tt.args.ctx, fr, tt.args.id), tt.args.id, tt.args.identifiers); (err != nil) != tt.wantErr {
 				t.Errorf(
 					"Repository.UpdateVerifiedIdentifiers() error = %v, wantErr %v",
 					err,
@@ -1491,7 +1492,7 @@ func TestRepository_UpdateSecondaryEmailAddresses(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := fr.UpdateSecondaryEmailAddresses(tt.args.ctx, tt.args.id, tt.args.emailAddresses)
+			err := fr.UpdateSecondaryEmailAddresses(storedVersion(tt.args.ctx, fr, tt.args.id), tt.args.id, tt.args.emailAddresses)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"Repository.UpdateSecondaryEmailAddresses() error = %v, wantErr %v",
@@ -1712,7 +1713,7 @@ func TestRepository_UpdateSecondaryPhoneNumbers(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := fr.UpdateSecondaryPhoneNumbers(tt.args.ctx, tt.args.id, tt.args.phoneNumbers); (err != nil) != tt.wantErr {
+			if err := fr.UpdateSecondaryPhoneNumbers(storedVersion(tt.args.ctx, fr, tt.args.id), tt.args.id, tt.args.phoneNumbers); (err != nil) != tt.wantErr {
 				t.Errorf(
 					"Repository.UpdateSecondaryPhoneNumbers() error = %v, wantErr %v",
 					err,
@@ -2103,7 +2104,7 @@ func TestRepositoryFetchAdminUsers(t *testing.T) {
 
 	permissions := profileutils.DefaultAdminPermissions
 
-	err = fr.UpdatePermissions(ctx, userProfile.ID, permissions)
+	err = fr.UpdatePermissions(storedVersion(ctx, fr, userProfile.ID), userProfile.ID, permissions)
 	if err != nil {
 		t.Errorf("failed to update user permissions: %v", err)
 		return
@@ -2473,7 +2474,7 @@ func TestRepository_UpdateUserRoleIDs_Integration(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := fr.UpdateUserRoleIDs(tt.args.ctx, tt.args.id, tt.args.roleIDs); (err != nil) != tt.wantErr {
+			if err := fr.UpdateUserRoleIDs(storedVersion(tt.args.ctx, fr, tt.args.id), tt.args.id, tt.args.roleIDs); (err != nil) != tt.wantErr {
 				t.Errorf("Repository.UpdateUserRoleIDs() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -2780,3 +2781,17 @@ func TestRepository_GetRoleByID_Integration(t *testing.T) {
 // 		})
 // 	}
 // }
+
+// storedVersion returns a context that expects the profile that matches the id to be as it is
+// stored now. Profiles that can't be read are left for the write to fail on
+func storedVersion(ctx context.Context, fr *fb.Repository, id string) context.Context {
+	profile, err := fr.GetUserProfileByID(ctx, id, false)
+	if err != nil {
+		return ctx
+	}
+	versionedCtx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		return ctx
+	}
+	return versionedCtx
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/fb"
 	extMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/fb/mock"
 	"github.com/savannahghi/profileutils"
@@ -828,8 +829,18 @@ func TestRepository_CreateDetailedUserProfile(t *testing.T) {
 					}, nil
 				}
 
-				fakeFireStoreClientExt.CreateFn = func(ctx context.Context, command *fb.CreateCommand) (*firestore.DocumentRef, error) {
-					return &firestore.DocumentRef{ID: "c9d62c7e-93e5-44a6-b503-6fc159c1782f"}, nil
+				fakeFireStoreClientExt.RunTransactionFn = func(ctx context.Context, fn func(ctx context.Context, tx fb.FirestoreTransaction) error) error {
+					return fn(ctx, &extMock.FirestoreTransaction{
+						GetFn: func(query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error) {
+							return nil, nil
+						},
+						UpdateFn: func(command *fb.UpdateCommand) error {
+							return nil
+						},
+						CreateFn: func(command *fb.CreateCommand) (*firestore.DocumentRef, error) {
+							return &firestore.DocumentRef{ID: "c9d62c7e-93e5-44a6-b503-6fc159c1782f"}, nil
+						},
+					})
 				}
 			}
 
@@ -911,8 +922,18 @@ func TestRepository_CreateDetailedUserProfile(t *testing.T) {
 					}, nil
 				}
 
-				fakeFireStoreClientExt.CreateFn = func(ctx context.Context, command *fb.CreateCommand) (*firestore.DocumentRef, error) {
-					return nil, fmt.Errorf("cannot create user on firestore")
+				fakeFireStoreClientExt.RunTransactionFn = func(ctx context.Context, fn func(ctx context.Context, tx fb.FirestoreTransaction) error) error {
+					return fn(ctx, &extMock.FirestoreTransaction{
+						GetFn: func(query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error) {
+							return nil, nil
+						},
+						UpdateFn: func(command *fb.UpdateCommand) error {
+							return nil
+						},
+						CreateFn: func(command *fb.CreateCommand) (*firestore.DocumentRef, error) {
+							return nil, fmt.Errorf("cannot create user on firestore")
+						},
+					})
 				}
 			}
 
@@ -936,6 +957,141 @@ func TestRepository_CreateDetailedUserProfile(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateUserAccount(t *testing.T) {
+	ctx := context.Background()
+	var fireStoreClientExt fb.FirestoreClientExtension = &fakeFireStoreClientExt
+	repo := fb.NewFirebaseRepository(fireStoreClientExt, fireBaseClientExt)
+
+	type args struct {
+		ctx         context.Context
+		phoneNumber string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantErr         bool
+		wantDeletedUser bool
+	}{
+		{
+			name: "valid:create_user_account",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid:phone_number_exists_new_firebase_user_is_removed",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+			},
+			wantErr:         true,
+			wantDeletedUser: true,
+		},
+		{
+			name: "invalid:transaction_fails_existing_firebase_user_is_kept",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+			},
+			wantErr:         true,
+			wantDeletedUser: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := []string{}
+			reserved := []string{}
+			events := []string{}
+			deletedUser := false
+			tx := &extMock.FirestoreTransaction{
+				GetFn: func(query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error) {
+					return nil, nil
+				},
+				GetAllFn: func(query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error) {
+					return []*firestore.DocumentSnapshot{}, nil
+				},
+				CreateFn: func(command *fb.CreateCommand) (*firestore.DocumentRef, error) {
+					created = append(created, command.CollectionName)
+					return &firestore.DocumentRef{ID: uuid.New().String()}, nil
+				},
+				UpdateFn: func(command *fb.UpdateCommand) error {
+					if command.CollectionName == repo.GetOutboxEventsCollectionName() {
+						events = append(events, command.ID)
+						return nil
+					}
+					reserved = append(reserved, command.ID)
+					return nil
+				},
+			}
+
+			fakeFireStoreClientExt.GetAllFn = func(ctx context.Context, query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error) {
+				return []*firestore.DocumentSnapshot{}, nil
+			}
+			fakeFireStoreClientExt.RunTransactionFn = func(ctx context.Context, fn func(ctx context.Context, tx fb.FirestoreTransaction) error) error {
+				return fn(ctx, tx)
+			}
+			fakeFireBaseClientExt.DeleteUserFn = func(ctx context.Context, uid string) error {
+				deletedUser = true
+				return nil
+			}
+
+			if tt.name == "valid:create_user_account" {
+				fakeFireBaseClientExt.GetUserByPhoneNumberFn = func(ctx context.Context, phone string) (*auth.UserRecord, error) {
+					return nil, fmt.Errorf("user not found")
+				}
+				fakeFireBaseClientExt.CreateUserFn = func(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
+					return &auth.UserRecord{UserInfo: &auth.UserInfo{UID: uuid.New().String()}}, nil
+				}
+			}
+
+			if tt.name == "invalid:phone_number_exists_new_firebase_user_is_removed" {
+				fakeFireBaseClientExt.GetUserByPhoneNumberFn = func(ctx context.Context, phone string) (*auth.UserRecord, error) {
+					return nil, fmt.Errorf("user not found")
+				}
+				fakeFireBaseClientExt.CreateUserFn = func(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
+					return &auth.UserRecord{UserInfo: &auth.UserInfo{UID: uuid.New().String()}}, nil
+				}
+				tx.GetAllFn = func(query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error) {
+					return []*firestore.DocumentSnapshot{{Ref: &firestore.DocumentRef{ID: uuid.New().String()}}}, nil
+				}
+			}
+
+			if tt.name == "invalid:transaction_fails_existing_firebase_user_is_kept" {
+				fakeFireBaseClientExt.GetUserByPhoneNumberFn = func(ctx context.Context, phone string) (*auth.UserRecord, error) {
+					return &auth.UserRecord{UserInfo: &auth.UserInfo{UID: uuid.New().String()}}, nil
+				}
+				tx.CreateFn = func(command *fb.CreateCommand) (*firestore.DocumentRef, error) {
+					return nil, fmt.Errorf("unable to create document")
+				}
+			}
+
+			account, err := repo.CreateUserAccount(tt.args.ctx, tt.args.phoneNumber, &domain.UserAccount{
+				PIN:                    &domain.PIN{ID: uuid.New().String()},
+				CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowPush: true},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Repository.CreateUserAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if deletedUser != tt.wantDeletedUser {
+				t.Errorf("Repository.CreateUserAccount() deleted firebase user = %v, want %v", deletedUser, tt.wantDeletedUser)
+			}
+			if !tt.wantErr {
+				assert.Len(t, created, 3)
+				// the phone number and the username of the new profile
+				assert.Len(t, reserved, 2)
+				// the user.created and pin.changed events
+				assert.Len(t, events, 2)
+				assert.Equal(t, account.Profile.ID, account.PIN.ProfileID)
+				assert.Equal(t, account.Profile.ID, account.CommunicationsSettings.ProfileID)
+				assert.True(t, account.NewAuthUser)
+			}
+		})
+	}
+}
+
 func TestRepository_ListAgentUserProfiles(t *testing.T) {
 	ctx := context.Background()
 	var fireStoreClientExt fb.FirestoreClientExtension = &fakeFireStoreClientExt
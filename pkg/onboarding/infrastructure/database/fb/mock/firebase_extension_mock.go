@@ -14,11 +14,14 @@ import (
 type FirestoreClientExtension struct {
 	CollectionFn func(path string) *firestore.CollectionRef
 	GetAllFn     func(ctx context.Context, query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error)
+	QueryFn      func(ctx context.Context, query *fb.PageQuery) ([]*firestore.DocumentSnapshot, error)
 	CreateFn     func(ctx context.Context, command *fb.CreateCommand) (*firestore.DocumentRef, error)
 	UpdateFn     func(ctx context.Context, command *fb.UpdateCommand) error
 	DeleteFn     func(ctx context.Context, command *fb.DeleteCommand) error
 	GetFn        func(ctx context.Context, query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error)
 	RawClientFn  func(ctx context.Context) *firestore.Client
+
+	RunTransactionFn func(ctx context.Context, fn func(ctx context.Context, tx fb.FirestoreTransaction) error) error
 }
 
 // Collection ...
@@ -31,6 +34,11 @@ func (f *FirestoreClientExtension) GetAll(ctx context.Context, getQuery *fb.GetA
 	return f.GetAllFn(ctx, getQuery)
 }
 
+// Query reads a page of documents from a firestore collection
+func (f *FirestoreClientExtension) Query(ctx context.Context, query *fb.PageQuery) ([]*firestore.DocumentSnapshot, error) {
+	return f.QueryFn(ctx, query)
+}
+
 // Create persists data to a firestore collection
 func (f *FirestoreClientExtension) Create(ctx context.Context, command *fb.CreateCommand) (*firestore.DocumentRef, error) {
 	return f.CreateFn(ctx, command)
@@ -56,13 +64,64 @@ func (f *FirestoreClientExtension) RawClient(ctx context.Context) *firestore.Cli
 	return f.RawClientFn(ctx)
 }
 
+// RunTransaction ...
+func (f *FirestoreClientExtension) RunTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context, tx fb.FirestoreTransaction) error,
+) error {
+	return f.RunTransactionFn(ctx, fn)
+}
+
+// FirestoreTransaction represents a `firestore.Transaction` fake
+type FirestoreTransaction struct {
+	GetFn    func(query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error)
+	GetAllFn func(query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error)
+	CreateFn func(command *fb.CreateCommand) (*firestore.DocumentRef, error)
+	UpdateFn func(command *fb.UpdateCommand) error
+	DeleteFn func(command *fb.DeleteCommand) error
+}
+
+// Get ...
+func (f *FirestoreTransaction) Get(query *fb.GetSingleQuery) (*firestore.DocumentSnapshot, error) {
+	return f.GetFn(query)
+}
+
+// GetAll ...
+func (f *FirestoreTransaction) GetAll(query *fb.GetAllQuery) ([]*firestore.DocumentSnapshot, error) {
+	return f.GetAllFn(query)
+}
+
+// Create ...
+func (f *FirestoreTransaction) Create(command *fb.CreateCommand) (*firestore.DocumentRef, error) {
+	return f.CreateFn(command)
+}
+
+// Update ...
+func (f *FirestoreTransaction) Update(command *fb.UpdateCommand) error {
+	return f.UpdateFn(command)
+}
+
+// Delete ...
+func (f *FirestoreTransaction) Delete(command *fb.DeleteCommand) error {
+	return f.DeleteFn(command)
+}
+
 // FirebaseClientExtension represents `auth.Client` fake
 type FirebaseClientExtension struct {
+	GetUserFn              func(ctx context.Context, uid string) (*auth.UserRecord, error)
 	GetUserByPhoneNumberFn func(ctx context.Context, phone string) (*auth.UserRecord, error)
+	GetUserByEmailFn       func(ctx context.Context, email string) (*auth.UserRecord, error)
 	CreateUserFn           func(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error)
+	UpdateUserFn           func(ctx context.Context, uid string, user *auth.UserToUpdate) (*auth.UserRecord, error)
 	DeleteUserFn           func(ctx context.Context, uid string) error
 	GetUserProfileByIDFn   func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error)
 	VerifyIDTokenFn        func(ctx context.Context, idToken string) (*auth.Token, error)
+	RevokeRefreshTokensFn  func(ctx context.Context, uid string) error
+}
+
+// GetUser ...
+func (f *FirebaseClientExtension) GetUser(ctx context.Context, uid string) (*auth.UserRecord, error) {
+	return f.GetUserFn(ctx, uid)
 }
 
 // GetUserByPhoneNumber ...
@@ -70,11 +129,21 @@ func (f *FirebaseClientExtension) GetUserByPhoneNumber(ctx context.Context, phon
 	return f.GetUserByPhoneNumberFn(ctx, phone)
 }
 
+// GetUserByEmail ...
+func (f *FirebaseClientExtension) GetUserByEmail(ctx context.Context, email string) (*auth.UserRecord, error) {
+	return f.GetUserByEmailFn(ctx, email)
+}
+
 // CreateUser ...
 func (f *FirebaseClientExtension) CreateUser(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
 	return f.CreateUserFn(ctx, user)
 }
 
+// UpdateUser ...
+func (f *FirebaseClientExtension) UpdateUser(ctx context.Context, uid string, user *auth.UserToUpdate) (*auth.UserRecord, error) {
+	return f.UpdateUserFn(ctx, uid, user)
+}
+
 // DeleteUser ...
 func (f *FirebaseClientExtension) DeleteUser(ctx context.Context, uid string) error {
 	return f.DeleteUserFn(ctx, uid)
@@ -89,3 +158,8 @@ func (f *FirebaseClientExtension) GetUserProfileByID(ctx context.Context, id str
 func (f *FirebaseClientExtension) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
 	return f.VerifyIDTokenFn(ctx, idToken)
 }
+
+// RevokeRefreshTokens ...
+func (f *FirebaseClientExtension) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	return f.RevokeRefreshTokensFn(ctx, uid)
+}
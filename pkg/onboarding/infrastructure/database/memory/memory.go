@@ -0,0 +1,3894 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+	"go.opentelemetry.io/otel"
+)
+
+// Package that generates trace information
+var tracer = otel.Tracer(
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory",
+)
+
+const (
+	// SnapshotPathEnvVarName is the env var that holds the path of the JSON file the in memory
+	// repository is loaded from and saved to. When it is not set, nothing is persisted
+	SnapshotPathEnvVarName = "MEMORY_REPOSITORY_SNAPSHOT"
+
+	// tokenExpirySeconds is how long the locally issued ID tokens are valid for
+	tokenExpirySeconds = "3600"
+)
+
+// AuthUser is a user account that is managed by the in memory repository in place of Firebase Authentication
+type AuthUser struct {
+	UID          string `json:"uid"`
+	PhoneNumber  string `json:"phoneNumber"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// Snapshot is the complete state of the in memory repository.
+// It is what is written to and read from the snapshot file
+type Snapshot struct {
+	UserProfiles           []*profileutils.UserProfile                        `json:"userProfiles"`
+	PINs                   []*domain.PIN                                      `json:"pins"`
+	PostVisitSurveys       []*domain.PostVisitSurvey                          `json:"postVisitSurveys"`
+	ProfileNudges          []*feedlib.Nudge                                   `json:"profileNudges"`
+	ExperimentParticipants map[string]*profileutils.UserProfile               `json:"experimentParticipants"`
+	CommunicationsSettings map[string]*profileutils.UserCommunicationsSetting `json:"communicationsSettings"`
+	Roles                  []*profileutils.Role                               `json:"roles"`
+	RoleRevocations        []*domain.RoleRevocationLog                        `json:"roleRevocations"`
+	AuthUsers              []*AuthUser                                        `json:"authUsers"`
+	IdentifierReservations map[string]*domain.IdentifierReservation           `json:"identifierReservations"`
+	OutboxEvents           []*domain.OutboxEvent                              `json:"outboxEvents"`
+	PubSubMessages         map[string]*domain.PubSubMessage                   `json:"pubSubMessages"`
+	WebhookEndpoints       []*domain.WebhookEndpoint                          `json:"webhookEndpoints"`
+	WebhookDeliveries      []*domain.WebhookDelivery                          `json:"webhookDeliveries"`
+	Sessions               []*domain.Session                                  `json:"sessions"`
+	LoginEvents            []*domain.LoginEvent                               `json:"loginEvents"`
+	LoginAlerts            []*domain.LoginAlert                               `json:"loginAlerts"`
+	TOTPEnrolments         map[string]*domain.TOTPEnrolment                   `json:"totpEnrolments"`
+	TwoFactorPolicy        *domain.TwoFactorPolicy                            `json:"twoFactorPolicy"`
+	Passwords              map[string]*domain.Password                        `json:"passwords"`
+	ProfileMerges          []*domain.ProfileMerge                             `json:"profileMerges"`
+	ProfileTombstones      map[string]*domain.ProfileTombstone                `json:"profileTombstones"`
+
+	// RefreshTokens maps the locally issued refresh tokens to the UID they were issued to
+	RefreshTokens map[string]string `json:"refreshTokens"`
+}
+
+// Repository keeps every record in memory. It has the same semantics as the
+// Firestore repository and is meant for local development and tests.
+type Repository struct {
+	mu    sync.RWMutex
+	store *Snapshot
+
+	// signingKeys are kept out of the snapshot so that private keys are never written to disk.
+	// A repository that is loaded from a snapshot signs with a new key
+	signingKeys []*domain.SigningKey
+
+	// SnapshotPath is the file the repository is saved to after every write. It is optional
+	SnapshotPath string
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{
+		ExperimentParticipants: map[string]*profileutils.UserProfile{},
+		CommunicationsSettings: map[string]*profileutils.UserCommunicationsSetting{},
+		RefreshTokens:          map[string]string{},
+		IdentifierReservations: map[string]*domain.IdentifierReservation{},
+		PubSubMessages:         map[string]*domain.PubSubMessage{},
+		TOTPEnrolments:         map[string]*domain.TOTPEnrolment{},
+		Passwords:              map[string]*domain.Password{},
+		ProfileTombstones:      map[string]*domain.ProfileTombstone{},
+	}
+}
+
+// NewMemoryRepository initializes an empty in memory repository
+func NewMemoryRepository() *Repository {
+	return &Repository{
+		store: newSnapshot(),
+	}
+}
+
+// NewMemoryRepositoryFromSnapshot initializes an in memory repository from the snapshot at the
+// provided path. A missing file results in an empty repository. The repository is saved back
+// to the same path after every write
+func NewMemoryRepositoryFromSnapshot(path string) (*Repository, error) {
+	r := NewMemoryRepository()
+	if err := r.LoadSnapshot(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	r.SnapshotPath = path
+	return r, nil
+}
+
+// LoadSnapshot replaces the contents of the repository with the snapshot at the provided path
+func (r *Repository) LoadSnapshot(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read snapshot: %w", err)
+	}
+
+	store := newSnapshot()
+	if err := json.Unmarshal(data, store); err != nil {
+		return fmt.Errorf("unable to parse snapshot: %w", err)
+	}
+	if store.ExperimentParticipants == nil {
+		store.ExperimentParticipants = map[string]*profileutils.UserProfile{}
+	}
+	if store.CommunicationsSettings == nil {
+		store.CommunicationsSettings = map[string]*profileutils.UserCommunicationsSetting{}
+	}
+	if store.RefreshTokens == nil {
+		store.RefreshTokens = map[string]string{}
+	}
+	if store.IdentifierReservations == nil {
+		store.IdentifierReservations = map[string]*domain.IdentifierReservation{}
+	}
+	if store.PubSubMessages == nil {
+		store.PubSubMessages = map[string]*domain.PubSubMessage{}
+	}
+	if store.TOTPEnrolments == nil {
+		store.TOTPEnrolments = map[string]*domain.TOTPEnrolment{}
+	}
+	if store.Passwords == nil {
+		store.Passwords = map[string]*domain.Password{}
+	}
+	if store.ProfileTombstones == nil {
+		store.ProfileTombstones = map[string]*domain.ProfileTombstone{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store = store
+	return nil
+}
+
+// SaveSnapshot writes the contents of the repository to the provided path
+func (r *Repository) SaveSnapshot(path string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.saveSnapshot(path)
+}
+
+// saveSnapshot writes the store to a temporary file that then replaces the snapshot so
+// that a failed write never leaves a partial snapshot behind. The caller must hold the lock
+func (r *Repository) saveSnapshot(path string) error {
+	data, err := json.MarshalIndent(r.store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal snapshot: %w", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("unable to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// persist saves the repository if a snapshot path has been configured. The caller must hold the write lock
+func (r *Repository) persist() error {
+	if r.SnapshotPath == "" {
+		return nil
+	}
+	return r.saveSnapshot(r.SnapshotPath)
+}
+
+// clone makes a deep copy of a record so that changes made by callers are only
+// stored when they go through the repository
+func clone(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func cloneProfile(profile *profileutils.UserProfile) (*profileutils.UserProfile, error) {
+	copied := &profileutils.UserProfile{}
+	if err := clone(profile, copied); err != nil {
+		return nil, fmt.Errorf("unable to read user profile: %w", err)
+	}
+	return copied, nil
+}
+
+func cloneProfiles(profiles []*profileutils.UserProfile) ([]*profileutils.UserProfile, error) {
+	copies := []*profileutils.UserProfile{}
+	for _, profile := range profiles {
+		copied, err := cloneProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, copied)
+	}
+	return copies, nil
+}
+
+// filterProfiles returns the stored profiles that match the predicate. The caller must hold the lock
+func (r *Repository) filterProfiles(match func(profile *profileutils.UserProfile) bool) []*profileutils.UserProfile {
+	profiles := []*profileutils.UserProfile{}
+	for _, profile := range r.store.UserProfiles {
+		if match(profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// profileByID returns the stored profile with the provided id. The caller must hold the lock
+func (r *Repository) profileByID(id string) *profileutils.UserProfile {
+	for _, profile := range r.store.UserProfiles {
+		if profile.ID == id {
+			return profile
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	_, exists := utils.FindItem(values, value)
+	return exists
+}
+
+func hasPrimaryPhone(phone string) func(profile *profileutils.UserProfile) bool {
+	return func(profile *profileutils.UserProfile) bool {
+		return profile.PrimaryPhone != nil && *profile.PrimaryPhone == phone
+	}
+}
+
+func hasSecondaryPhone(phone string) func(profile *profileutils.UserProfile) bool {
+	return func(profile *profileutils.UserProfile) bool {
+		return contains(profile.SecondaryPhoneNumbers, phone)
+	}
+}
+
+func hasPrimaryEmail(email string) func(profile *profileutils.UserProfile) bool {
+	return func(profile *profileutils.UserProfile) bool {
+		return profile.PrimaryEmailAddress != nil && *profile.PrimaryEmailAddress == email
+	}
+}
+
+func hasSecondaryEmail(email string) func(profile *profileutils.UserProfile) bool {
+	return func(profile *profileutils.UserProfile) bool {
+		return contains(profile.SecondaryEmailAddresses, email)
+	}
+}
+
+// phoneNumberExists checks the primary and secondary phone numbers. The caller must hold the lock
+func (r *Repository) phoneNumberExists(phone string) bool {
+	return len(r.filterProfiles(hasPrimaryPhone(phone))) > 0 ||
+		len(r.filterProfiles(hasSecondaryPhone(phone))) > 0
+}
+
+// emailAddressExists checks the primary and secondary email addresses. The caller must hold the lock
+func (r *Repository) emailAddressExists(email string) bool {
+	return len(r.filterProfiles(hasPrimaryEmail(email))) > 0 ||
+		len(r.filterProfiles(hasSecondaryEmail(email))) > 0
+}
+
+// usernameExists checks if the username has been taken. The caller must hold the lock
+func (r *Repository) usernameExists(userName string) bool {
+	return len(r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		return profile.UserName != nil && *profile.UserName == userName
+	})) > 0
+}
+
+// randomUserName picks a username that is not in use. The caller must hold the lock
+func (r *Repository) randomUserName() *string {
+	n := utils.GetRandomName()
+	if r.usernameExists(*n) {
+		return r.randomUserName()
+	}
+	return n
+}
+
+// reserveIdentifiers applies the identifier reservation changes of a profile write. Nothing is
+// changed when one of the identifiers is reserved by another profile. The caller must hold the write lock
+func (r *Repository) reserveIdentifiers(before, after *profileutils.UserProfile) error {
+	reserve, release := utils.IdentifierReservationChanges(before, after)
+	for _, reservation := range reserve {
+		existing, reserved := r.store.IdentifierReservations[reservation.ID]
+		if reserved && existing.ProfileID != reservation.ProfileID {
+			return utils.IdentifierInUseError(reservation.Kind)
+		}
+	}
+	for _, reservation := range release {
+		existing, reserved := r.store.IdentifierReservations[reservation.ID]
+		if reserved && existing.ProfileID == reservation.ProfileID {
+			delete(r.store.IdentifierReservations, reservation.ID)
+		}
+	}
+	for _, reservation := range reserve {
+		r.store.IdentifierReservations[reservation.ID] = reservation
+	}
+	return nil
+}
+
+// releaseIdentifiers frees every identifier reserved by a profile. The caller must hold the write lock
+func (r *Repository) releaseIdentifiers(profileID string) {
+	for id, reservation := range r.store.IdentifierReservations {
+		if reservation.ProfileID == profileID {
+			delete(r.store.IdentifierReservations, id)
+		}
+	}
+}
+
+// recordPINEvent adds the `pin.changed` event of a PIN write to the outbox. The caller must hold the write lock
+func (r *Repository) recordPINEvent(pin *domain.PIN) error {
+	event, err := utils.PINChangedEvent(pin)
+	if err != nil {
+		return err
+	}
+	r.store.OutboxEvents = append(r.store.OutboxEvents, event)
+	return nil
+}
+
+// insertProfile stores a new profile enforcing the uniqueness of its phone numbers, email addresses
+// and username. The caller must hold the write lock
+func (r *Repository) insertProfile(profile *profileutils.UserProfile) error {
+	if profile.PrimaryPhone != nil && r.phoneNumberExists(*profile.PrimaryPhone) {
+		return exceptions.CheckPhoneNumberExistError()
+	}
+	if profile.UserName != nil && r.usernameExists(*profile.UserName) {
+		return exceptions.InternalServerError(fmt.Errorf("%v", exceptions.UsernameInUseErrMsg))
+	}
+
+	stored, err := cloneProfile(profile)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	events, err := utils.ProfileEvents(nil, stored)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	if err := r.reserveIdentifiers(nil, stored); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	r.store.UserProfiles = append(r.store.UserProfiles, stored)
+	r.store.OutboxEvents = append(r.store.OutboxEvents, events...)
+	if err := r.persist(); err != nil {
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to create new user profile: %w", err),
+		)
+	}
+	return nil
+}
+
+// replaceProfile overwrites the stored profile that has the same id. The identifiers that the profile
+// no longer has are released and the new ones reserved. The caller must hold the write lock
+func (r *Repository) replaceProfile(profile *profileutils.UserProfile) error {
+	for i, existing := range r.store.UserProfiles {
+		if existing.ID != profile.ID {
+			continue
+		}
+		stored, err := cloneProfile(profile)
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		events, err := utils.ProfileEvents(existing, stored)
+		if err != nil {
+			return exceptions.InternalServerError(err)
+		}
+		if err := r.reserveIdentifiers(existing, stored); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		r.store.UserProfiles[i] = stored
+		r.store.OutboxEvents = append(r.store.OutboxEvents, events...)
+		if err := r.persist(); err != nil {
+			return exceptions.InternalServerError(
+				fmt.Errorf("unable to update user profile: %w", err),
+			)
+		}
+		return nil
+	}
+	return exceptions.InternalServerError(fmt.Errorf("user profile not found"))
+}
+
+// updateProfileByID applies the provided changes to the profile that matches the id.
+// Suspended profiles are only updated when `suspended` is true. The changes are rejected with a
+// conflict error when the profile is no longer in the state the caller read it in
+func (r *Repository) updateProfileByID(
+	ctx context.Context,
+	id string,
+	suspended bool,
+	update func(profile *profileutils.UserProfile) error,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.profileByID(id)
+	if stored == nil {
+		return exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+	if !suspended && stored.Suspended {
+		return exceptions.ProfileSuspendFoundError()
+	}
+	if err := utils.CheckProfileVersion(ctx, stored); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	profile, err := cloneProfile(stored)
+	if err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	if err := update(profile); err != nil {
+		return err
+	}
+	return r.replaceProfile(profile)
+}
+
+// updateVersionedProfileByID is updateProfileByID for the changes that are derived from an earlier
+// read of the profile. They are refused when the context has no expected version of the profile
+func (r *Repository) updateVersionedProfileByID(
+	ctx context.Context,
+	id string,
+	suspended bool,
+	update func(profile *profileutils.UserProfile) error,
+) error {
+	return r.updateProfileByID(ctx, id, suspended, func(profile *profileutils.UserProfile) error {
+		if err := utils.RequireProfileVersion(ctx, profile); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		return update(profile)
+	})
+}
+
+// GetUserProfileByUID retrieves the user profile by UID
+func (r *Repository) GetUserProfileByUID(
+	ctx context.Context,
+	uid string,
+	suspended bool,
+) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfileByUID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		return contains(profile.VerifiedUIDS, uid)
+	})
+	if len(profiles) == 0 {
+		err := exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	if !suspended {
+		// never return a suspended user profile
+		if profiles[0].Suspended {
+			return nil, exceptions.ProfileSuspendFoundError()
+		}
+	}
+
+	profile, err := cloneProfile(profiles[0])
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profile, nil
+}
+
+// GetUserProfileByPhoneOrEmail retrieves user profile by phone number or email address
+func (r *Repository) GetUserProfileByPhoneOrEmail(ctx context.Context, payload *dto.RetrieveUserProfileInput) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfileByPhoneOrEmail")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var profiles []*profileutils.UserProfile
+	if payload.PhoneNumber == nil {
+		email := ""
+		if payload.Email != nil {
+			email = *payload.Email
+		}
+		profiles = r.filterProfiles(hasPrimaryEmail(email))
+		if len(profiles) == 0 {
+			profiles = r.filterProfiles(hasSecondaryEmail(email))
+		}
+	} else {
+		profiles = r.filterProfiles(hasPrimaryPhone(*payload.PhoneNumber))
+		if len(profiles) == 0 {
+			profiles = r.filterProfiles(hasSecondaryPhone(*payload.PhoneNumber))
+		}
+	}
+
+	if len(profiles) == 0 {
+		err := exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	profile, err := cloneProfile(profiles[0])
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profile, nil
+}
+
+// UpdateUserProfileEmail updates user profile's email
+func (r *Repository) UpdateUserProfileEmail(
+	ctx context.Context,
+	phone string,
+	email string,
+) error {
+	ctx, span := tracer.Start(ctx, "UpdateUserProfileEmail")
+	defer span.End()
+
+	profile, err := r.GetUserProfileByPhoneOrEmail(ctx, &dto.RetrieveUserProfileInput{PhoneNumber: &phone})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	err = r.updateProfileByID(ctx, profile.ID, true, func(profile *profileutils.UserProfile) error {
+		profile.PrimaryEmailAddress = &email
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// GetUserProfileByID retrieves a user profile by ID
+func (r *Repository) GetUserProfileByID(
+	ctx context.Context,
+	id string,
+	suspended bool,
+) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfileByID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.profileByID(id)
+	if stored == nil {
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+
+	if !suspended {
+		// never return a suspended user profile
+		if stored.Suspended {
+			return nil, exceptions.ProfileSuspendFoundError()
+		}
+	}
+
+	profile, err := cloneProfile(stored)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profile, nil
+}
+
+// CreateUserProfile creates a user profile of using the provided phone number and uid
+func (r *Repository) CreateUserProfile(
+	ctx context.Context,
+	phoneNumber, uid string,
+) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "CreateUserProfile")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.phoneNumberExists(phoneNumber) {
+		// this phone is number is associated with another user profile, hence can not create an profile with the same phone number
+		return nil, exceptions.CheckPhoneNumberExistError()
+	}
+
+	created := time.Now().In(pubsubtools.TimeLocation)
+	profile := &profileutils.UserProfile{
+		ID:           uuid.New().String(),
+		UserName:     r.randomUserName(),
+		PrimaryPhone: &phoneNumber,
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{{
+			UID:           uid,
+			LoginProvider: profileutils.LoginProviderTypePhone,
+			Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+		}},
+		VerifiedUIDS:  []string{uid},
+		TermsAccepted: true,
+		Suspended:     false,
+		Created:       &created,
+	}
+
+	if err := r.insertProfile(profile); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	return profile, nil
+}
+
+// CreateDetailedUserProfile creates a new user profile that is pre-filled using the provided phone number
+func (r *Repository) CreateDetailedUserProfile(
+	ctx context.Context,
+	phoneNumber string,
+	profile profileutils.UserProfile,
+) (*profileutils.UserProfile, error) {
+	ctx, span := tracer.Start(ctx, "CreateDetailedUserProfile")
+	defer span.End()
+
+	exists, err := r.CheckIfPhoneNumberExists(ctx, phoneNumber)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("failed to check the phone number: %v", err),
+		)
+	}
+	if exists {
+		// this phone is number is associated with another user profile, hence can not create an profile with the same phone number
+		err = exceptions.CheckPhoneNumberExistError()
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	user, err := r.GetOrCreatePhoneNumberUser(ctx, phoneNumber)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, profileutils.VerifiedIdentifier{
+		UID:           user.UID,
+		LoginProvider: profileutils.LoginProviderTypePhone,
+		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+	})
+	profile.VerifiedUIDS = append(profile.VerifiedUIDS, user.UID)
+	profile.ID = uuid.New().String()
+	profile.PrimaryPhone = &phoneNumber
+	profile.UserName = r.randomUserName()
+	profile.TermsAccepted = true
+	profile.Suspended = false
+	created := time.Now().In(pubsubtools.TimeLocation)
+	profile.Created = &created
+
+	if err := r.insertProfile(&profile); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// CreateUserAccount creates the auth user, profile, PIN and communications settings of a new user.
+// All the records are checked before any of them is stored
+func (r *Repository) CreateUserAccount(
+	ctx context.Context,
+	phoneNumber string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	ctx, span := tracer.Start(ctx, "CreateUserAccount")
+	defer span.End()
+
+	return r.createUserAccount(ctx, domain.IdentifierKindPhone, phoneNumber, account)
+}
+
+// CreateEmailUserAccount creates the auth user, profile, password and communications settings of
+// a user who signs up with an email address. All the records are checked before any of them is stored
+func (r *Repository) CreateEmailUserAccount(
+	ctx context.Context,
+	emailAddress string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	ctx, span := tracer.Start(ctx, "CreateEmailUserAccount")
+	defer span.End()
+
+	return r.createUserAccount(ctx, domain.IdentifierKindEmail, emailAddress, account)
+}
+
+// createUserAccount creates an account whose auth user and profile are identified by a phone
+// number or an email address
+func (r *Repository) createUserAccount(
+	ctx context.Context,
+	kind domain.IdentifierKind,
+	identifier string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	_, span := tracer.Start(ctx, "createUserAccount")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if kind == domain.IdentifierKindEmail && r.emailAddressExists(identifier) {
+		err := exceptions.CheckEmailExistError()
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if kind == domain.IdentifierKindPhone && r.phoneNumberExists(identifier) {
+		// this phone is number is associated with another user profile, hence can not create an profile with the same phone number
+		err := exceptions.CheckPhoneNumberExistError()
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	previous := *r.store
+
+	user := r.authUserByPhone(identifier)
+	loginProvider := profileutils.LoginProviderTypePhone
+	if kind == domain.IdentifierKindEmail {
+		user, loginProvider = r.authUserByEmail(identifier), domain.LoginProviderTypeEmail
+	}
+	if account.AnonymousUID != "" {
+		upgraded, err := r.upgradeAnonymousUser(kind, identifier, account.AnonymousUID, user)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, err
+		}
+		user = upgraded
+	}
+	account.NewAuthUser = user == nil
+	if user == nil {
+		user = &AuthUser{UID: uuid.New().String()}
+		if kind == domain.IdentifierKindEmail {
+			user.EmailAddress = identifier
+		} else {
+			user.PhoneNumber = identifier
+		}
+		r.store.AuthUsers = append(r.store.AuthUsers, user)
+	}
+	account.UID = user.UID
+
+	if account.Profile == nil {
+		account.Profile = &profileutils.UserProfile{}
+	}
+	profile := account.Profile
+	profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, profileutils.VerifiedIdentifier{
+		UID:           user.UID,
+		LoginProvider: loginProvider,
+		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+	})
+	profile.VerifiedUIDS = append(profile.VerifiedUIDS, user.UID)
+	profile.ID = uuid.New().String()
+	if kind == domain.IdentifierKindEmail {
+		profile.PrimaryEmailAddress = &identifier
+	} else {
+		profile.PrimaryPhone = &identifier
+	}
+	profile.UserName = r.randomUserName()
+	profile.TermsAccepted = true
+	profile.Suspended = false
+	created := time.Now().In(pubsubtools.TimeLocation)
+	profile.Created = &created
+
+	storedProfile, err := cloneProfile(profile)
+	if err != nil {
+		r.store.AuthUsers = previous.AuthUsers
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	events, err := utils.ProfileEvents(nil, storedProfile)
+	if err != nil {
+		r.store.AuthUsers = previous.AuthUsers
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	if account.PIN != nil {
+		account.PIN.ProfileID = profile.ID
+		event, err := utils.PINChangedEvent(account.PIN)
+		if err != nil {
+			r.store.AuthUsers = previous.AuthUsers
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		events = append(events, event)
+	}
+	if account.AnonymousUID != "" {
+		event, err := utils.UserUpgradedEvent(account.AnonymousUID, storedProfile)
+		if err != nil {
+			r.store.AuthUsers = previous.AuthUsers
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		events = append(events, event)
+	}
+	if err := r.reserveIdentifiers(nil, storedProfile); err != nil {
+		r.store.AuthUsers = previous.AuthUsers
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	r.store.UserProfiles = append(r.store.UserProfiles, storedProfile)
+	r.store.OutboxEvents = append(r.store.OutboxEvents, events...)
+
+	if account.PIN != nil {
+		pin := *account.PIN
+		r.store.PINs = append(r.store.PINs, &pin)
+	}
+	if account.Password != nil {
+		account.Password.ProfileID = profile.ID
+		password := *account.Password
+		r.store.Passwords[profile.ID] = &password
+	}
+	if account.CommunicationsSettings != nil {
+		account.CommunicationsSettings.ID = uuid.New().String()
+		account.CommunicationsSettings.ProfileID = profile.ID
+		comms := *account.CommunicationsSettings
+		r.store.CommunicationsSettings[profile.ID] = &comms
+	}
+
+	if err := r.persist(); err != nil {
+		// undo the changes so that the repository matches the last snapshot
+		r.store.AuthUsers = previous.AuthUsers
+		r.store.UserProfiles = previous.UserProfiles
+		r.store.PINs = previous.PINs
+		r.store.OutboxEvents = previous.OutboxEvents
+		delete(r.store.Passwords, profile.ID)
+		delete(r.store.CommunicationsSettings, profile.ID)
+		r.releaseIdentifiers(profile.ID)
+
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("unable to create new user account: %w", err),
+		)
+	}
+
+	return account, nil
+}
+
+// DeleteUserAccount removes the profile, PIN, password and communications settings of an account
+// created by CreateUserAccount or CreateEmailUserAccount. The auth user is removed when it was created with the account
+func (r *Repository) DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error {
+	_, span := tracer.Start(ctx, "DeleteUserAccount")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if account.Profile != nil {
+		profiles := []*profileutils.UserProfile{}
+		for _, profile := range r.store.UserProfiles {
+			if profile.ID != account.Profile.ID {
+				profiles = append(profiles, profile)
+			}
+		}
+		r.store.UserProfiles = profiles
+
+		pins := []*domain.PIN{}
+		for _, pin := range r.store.PINs {
+			if pin.ProfileID != account.Profile.ID {
+				pins = append(pins, pin)
+			}
+		}
+		r.store.PINs = pins
+
+		delete(r.store.Passwords, account.Profile.ID)
+		delete(r.store.CommunicationsSettings, account.Profile.ID)
+		r.releaseIdentifiers(account.Profile.ID)
+
+		// the events of the account are only dropped if they have not been published yet
+		events := []*domain.OutboxEvent{}
+		for _, event := range r.store.OutboxEvents {
+			if event.ProfileID != account.Profile.ID || event.Published != nil {
+				events = append(events, event)
+			}
+		}
+		r.store.OutboxEvents = events
+	}
+
+	if account.NewAuthUser {
+		users := []*AuthUser{}
+		for _, user := range r.store.AuthUsers {
+			if user.UID != account.UID {
+				users = append(users, user)
+			}
+		}
+		r.store.AuthUsers = users
+	}
+
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetUserProfileByPrimaryPhoneNumber fetches a user profile by primary phone number
+func (r *Repository) GetUserProfileByPrimaryPhoneNumber(
+	ctx context.Context,
+	phoneNumber string,
+	suspended bool,
+) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfileByPrimaryPhoneNumber")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := r.filterProfiles(hasPrimaryPhone(phoneNumber))
+	if len(profiles) == 0 {
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+
+	if !suspended {
+		// never return a suspended user profile
+		if profiles[0].Suspended {
+			return nil, exceptions.ProfileSuspendFoundError()
+		}
+	}
+
+	profile, err := cloneProfile(profiles[0])
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profile, nil
+}
+
+// GetUserProfileByPhoneNumber fetches a user profile by phone number. This method traverses both PRIMARY PHONE numbers
+// and SECONDARY PHONE numbers.
+func (r *Repository) GetUserProfileByPhoneNumber(
+	ctx context.Context,
+	phoneNumber string,
+	suspended bool,
+) (*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfileByPhoneNumber")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// check first primary phone numbers
+	profiles := r.filterProfiles(hasPrimaryPhone(phoneNumber))
+	if len(profiles) == 1 {
+		profile, err := cloneProfile(profiles[0])
+		if err != nil {
+			return nil, exceptions.InternalServerError(err)
+		}
+		return profile, nil
+	}
+
+	// then check in secondary phone numbers
+	profiles = r.filterProfiles(hasSecondaryPhone(phoneNumber))
+	if len(profiles) == 1 {
+		if !suspended {
+			// never return a suspended user profile
+			if profiles[0].Suspended {
+				return nil, exceptions.ProfileSuspendFoundError()
+			}
+		}
+		profile, err := cloneProfile(profiles[0])
+		if err != nil {
+			return nil, exceptions.InternalServerError(err)
+		}
+		return profile, nil
+	}
+
+	return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+}
+
+// CheckIfPhoneNumberExists checks both PRIMARY PHONE NUMBERs and SECONDARY PHONE NUMBERs for the
+// existence of the argument phoneNumber.
+func (r *Repository) CheckIfPhoneNumberExists(ctx context.Context, phoneNumber string) (bool, error) {
+	_, span := tracer.Start(ctx, "CheckIfPhoneNumberExists")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.phoneNumberExists(phoneNumber), nil
+}
+
+// CheckIfEmailExists checks in both PRIMARY EMAIL and SECONDARY EMAIL for the
+// existence of the argument email
+func (r *Repository) CheckIfEmailExists(ctx context.Context, email string) (bool, error) {
+	_, span := tracer.Start(ctx, "CheckIfEmailExists")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exists := len(r.filterProfiles(hasPrimaryEmail(email))) > 0 ||
+		len(r.filterProfiles(hasSecondaryEmail(email))) > 0
+	return exists, nil
+}
+
+// CheckIfUsernameExists checks if the provided username exists. If true, it means its has already been associated with
+// another user
+func (r *Repository) CheckIfUsernameExists(ctx context.Context, userName string) (bool, error) {
+	_, span := tracer.Start(ctx, "CheckIfUsernameExists")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.usernameExists(userName), nil
+}
+
+// GetPINByProfileID gets a user's PIN by their profile ID
+func (r *Repository) GetPINByProfileID(
+	ctx context.Context,
+	profileID string,
+) (*domain.PIN, error) {
+	_, span := tracer.Start(ctx, "GetPINByProfileID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.store.PINs {
+		if stored.ProfileID == profileID {
+			pin := &domain.PIN{}
+			if err := clone(stored, pin); err != nil {
+				utils.RecordSpanError(span, err)
+				return nil, err
+			}
+			return pin, nil
+		}
+	}
+
+	return nil, exceptions.PinNotFoundError(fmt.Errorf("failed to get a user pin"))
+}
+
+// issueCredentials mints local tokens for the provided UID. The caller must hold the write lock
+func (r *Repository) issueCredentials(uid string) (*profileutils.AuthCredentialResponse, error) {
+	customToken := uuid.New().String()
+	idToken := uuid.New().String()
+	refreshToken := uuid.New().String()
+
+	r.store.RefreshTokens[refreshToken] = uid
+	if err := r.persist(); err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	return &profileutils.AuthCredentialResponse{
+		CustomToken:  &customToken,
+		IDToken:      &idToken,
+		ExpiresIn:    tokenExpirySeconds,
+		RefreshToken: refreshToken,
+		UID:          uid,
+	}, nil
+}
+
+// GenerateAuthCredentialsForAnonymousUser generates auth credentials for a new anonymous user.
+// Every anonymous login gets its own local user so that it can be upgraded into an account
+func (r *Repository) GenerateAuthCredentialsForAnonymousUser(
+	ctx context.Context,
+) (*profileutils.AuthCredentialResponse, error) {
+	_, span := tracer.Start(ctx, "GenerateAuthCredentialsForAnonymousUser")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user := &AuthUser{UID: utils.AnonymousUID()}
+	r.store.AuthUsers = append(r.store.AuthUsers, user)
+	creds, err := r.issueCredentials(user.UID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	creds.IsAnonymous = true
+	return creds, nil
+}
+
+// GenerateAuthCredentials gets a local user by phone, email address or social login UID and creates
+// their tokens
+func (r *Repository) GenerateAuthCredentials(
+	ctx context.Context,
+	phone string,
+	profile *profileutils.UserProfile,
+) (*profileutils.AuthCredentialResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenerateAuthCredentials")
+	defer span.End()
+
+	getOrCreateUser := r.GetOrCreatePhoneNumberUser
+	loginProvider := profileutils.LoginProviderTypePhone
+	if utils.IsEmailAddress(phone) {
+		getOrCreateUser, loginProvider = r.getOrCreateEmailUser, domain.LoginProviderTypeEmail
+	} else if provider, ok := utils.SocialLoginProvider(phone); ok {
+		getOrCreateUser, loginProvider = r.getOrCreateSocialUser, provider
+	}
+	resp, err := getOrCreateUser(ctx, phone)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	if err := r.addLoginUID(ctx, profile.ID, profileutils.VerifiedIdentifier{
+		UID:           resp.UID,
+		LoginProvider: loginProvider,
+		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+	}); err != nil {
+		return nil, exceptions.UpdateProfileError(err)
+	}
+
+	canExperiment, err := r.CheckIfExperimentParticipant(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	creds, err := r.issueCredentials(resp.UID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	creds.IsAdmin = r.CheckIfAdmin(profile)
+	creds.CanExperiment = canExperiment
+	return creds, nil
+}
+
+// CheckIfAdmin checks if a user has admin permissions
+func (r *Repository) CheckIfAdmin(profile *profileutils.UserProfile) bool {
+	for _, p := range profile.Permissions {
+		if p == profileutils.PermissionTypeSuperAdmin || p == profileutils.PermissionTypeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateUserName updates the username of a profile that matches the id
+// this method should be called after asserting the username is unique and not associated with another userProfile
+func (r *Repository) UpdateUserName(ctx context.Context, id string, userName string) error {
+	ctx, span := tracer.Start(ctx, "UpdateUserName")
+	defer span.End()
+
+	v, err := r.CheckIfUsernameExists(ctx, userName)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	if v {
+		return exceptions.InternalServerError(fmt.Errorf("%v", exceptions.UsernameInUseErrMsg))
+	}
+
+	err = r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.UserName = &userName
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePrimaryPhoneNumber append a new primary phone number to the user profile
+// this method should be called after asserting the phone number is unique and not associated with another userProfile
+func (r *Repository) UpdatePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error {
+	_, span := tracer.Start(ctx, "UpdatePrimaryPhoneNumber")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.PrimaryPhone = &phoneNumber
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateUserRoleIDs updates the roles for a user
+func (r *Repository) UpdateUserRoleIDs(ctx context.Context, id string, roleIDs []string) error {
+	_, span := tracer.Start(ctx, "UpdateUserRoleIDs")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.Roles = roleIDs
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePrimaryEmailAddress the primary email addresses of the profile that matches the id
+// this method should be called after asserting the emailAddress is unique and not associated with another userProfile
+func (r *Repository) UpdatePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error {
+	_, span := tracer.Start(ctx, "UpdatePrimaryEmailAddress")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.PrimaryEmailAddress = &emailAddress
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ReplacePrimaryPhoneNumber sets the primary phone number of the profile that matches the id and keeps
+// the previous one as a secondary phone number, in a single write. The write is refused unless the
+// context carries the version of the profile that was read
+func (r *Repository) ReplacePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error {
+	_, span := tracer.Start(ctx, "ReplacePrimaryPhoneNumber")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		utils.ReplacePrimaryPhoneNumber(profile, phoneNumber)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ReplacePrimaryEmailAddress sets the primary email address of the profile that matches the id and keeps
+// the previous one as a secondary email address, in a single write. The write is refused unless the
+// context carries the version of the profile that was read
+func (r *Repository) ReplacePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error {
+	_, span := tracer.Start(ctx, "ReplacePrimaryEmailAddress")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		utils.ReplacePrimaryEmailAddress(profile, emailAddress)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// removeItems returns the items in the slice that are not in the values to remove
+func removeItems(slice []string, values ...string) []string {
+	result := []string{}
+	for _, item := range slice {
+		if !contains(values, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
+// this method should be called after asserting the phone numbers are unique and not associated with another userProfile
+func (r *Repository) UpdateSecondaryPhoneNumbers(ctx context.Context, id string, phoneNumbers []string) error {
+	_, span := tracer.Start(ctx, "UpdateSecondaryPhoneNumbers")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		secondaryPhones := profile.SecondaryPhoneNumbers
+		// the former primary phone should not remain in the secondary phone numbers
+		if profile.PrimaryPhone != nil {
+			secondaryPhones = removeItems(secondaryPhones, *profile.PrimaryPhone)
+		}
+		secondaryPhones = removeItems(secondaryPhones, phoneNumbers...)
+		profile.SecondaryPhoneNumbers = append(secondaryPhones, phoneNumbers...)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateSecondaryEmailAddresses the secondary email addresses of the profile that matches the id
+// this method should be called after asserting the emailAddresses  as unique and not associated with another userProfile
+func (r *Repository) UpdateSecondaryEmailAddresses(ctx context.Context, id string, emailAddresses []string) error {
+	_, span := tracer.Start(ctx, "UpdateSecondaryEmailAddresses")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		secondaryEmails := profile.SecondaryEmailAddresses
+		// the former primary email should not remain in the secondary emails
+		if profile.PrimaryEmailAddress != nil {
+			secondaryEmails = removeItems(secondaryEmails, *profile.PrimaryEmailAddress)
+		}
+		secondaryEmails = removeItems(secondaryEmails, emailAddresses...)
+		profile.SecondaryEmailAddresses = append(secondaryEmails, emailAddresses...)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateSuspended updates the suspend attribute of the profile that matches the id
+func (r *Repository) UpdateSuspended(ctx context.Context, id string, status bool) error {
+	_, span := tracer.Start(ctx, "UpdateSuspended")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, true, func(profile *profileutils.UserProfile) error {
+		profile.Suspended = status
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePhotoUploadID updates the photoUploadID attribute of the profile that matches the id
+func (r *Repository) UpdatePhotoUploadID(ctx context.Context, id string, uploadID string) error {
+	_, span := tracer.Start(ctx, "UpdatePhotoUploadID")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.PhotoUploadID = uploadID
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePushTokens updates the pushTokens attribute of the profile that matches the id. This function does a hard reset instead of prior
+// matching
+func (r *Repository) UpdatePushTokens(ctx context.Context, id string, pushTokens []string) error {
+	_, span := tracer.Start(ctx, "UpdatePushTokens")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.PushTokens = pushTokens
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdatePermissions update the permissions of the user profile
+func (r *Repository) UpdatePermissions(ctx context.Context, id string, perms []profileutils.PermissionType) error {
+	_, span := tracer.Start(ctx, "UpdatePermissions")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		// Removes duplicate permissions from array
+		profile.Permissions = utils.UniquePermissionsArray(profile.Permissions)
+
+		newPerms := []profileutils.PermissionType{}
+		newPerms = append(newPerms, profile.Permissions...)
+		for _, perm := range perms {
+			// add permission if it doesn't exist
+			if !profile.HasPermission(perm) {
+				newPerms = append(newPerms, perm)
+			}
+		}
+		profile.Permissions = newPerms
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateRole update the permissions of the user profile
+func (r *Repository) UpdateRole(ctx context.Context, id string, role profileutils.RoleType) error {
+	_, span := tracer.Start(ctx, "UpdateRole")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.Role = role
+		profile.Permissions = role.Permissions()
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateFavNavActions update the permissions of the user profile
+func (r *Repository) UpdateFavNavActions(ctx context.Context, id string, favActions []string) error {
+	_, span := tracer.Start(ctx, "UpdateFavNavActions")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		profile.FavNavActions = favActions
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateBioData updates the biodate of the profile that matches the id
+func (r *Repository) UpdateBioData(ctx context.Context, id string, data profileutils.BioData) error {
+	_, span := tracer.Start(ctx, "UpdateBioData")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		if data.FirstName != nil {
+			profile.UserBioData.FirstName = data.FirstName
+		}
+		if data.LastName != nil {
+			profile.UserBioData.LastName = data.LastName
+		}
+		if data.Gender.String() != "" {
+			profile.UserBioData.Gender = data.Gender
+		}
+		if data.DateOfBirth != nil {
+			profile.UserBioData.DateOfBirth = data.DateOfBirth
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateVerifiedIdentifiers adds a UID to a user profile during login if it does not exist
+func (r *Repository) UpdateVerifiedIdentifiers(
+	ctx context.Context,
+	id string,
+	identifiers []profileutils.VerifiedIdentifier,
+) error {
+	_, span := tracer.Start(ctx, "UpdateVerifiedIdentifiers")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		for _, identifier := range identifiers {
+			if !utils.CheckIdentifierExists(profile, identifier.UID) {
+				profile.VerifiedIdentifiers = append(profile.VerifiedIdentifiers, identifier)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateVerifiedUIDS adds a UID to a user profile during login if it does not exist
+func (r *Repository) UpdateVerifiedUIDS(ctx context.Context, id string, uids []string) error {
+	_, span := tracer.Start(ctx, "UpdateVerifiedUIDS")
+	defer span.End()
+
+	err := r.updateVersionedProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		for _, uid := range uids {
+			if !contains(profile.VerifiedUIDS, uid) {
+				profile.VerifiedUIDS = append(profile.VerifiedUIDS, uid)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// addLoginUID adds a login UID to the verified identifiers and the verified UIDs of the profile that
+// matches the id in one write. The UID is added to the profile as it is stored, not as the caller
+// read it
+func (r *Repository) addLoginUID(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	return r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		utils.AddLoginUID(profile, identifier)
+		return nil
+	})
+}
+
+// LinkSocialAccount adds the verified identifier and the verified UID of an account with a social
+// login provider to the profile that matches the id in one write. The UID is reserved for the profile
+// in the same write so that the account can not be linked to two profiles
+func (r *Repository) LinkSocialAccount(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	_, span := tracer.Start(ctx, "LinkSocialAccount")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		_, err := utils.LinkSocialAccount(profile, identifier)
+		return err
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RemoveVerifiedIdentifier removes the verified identifier and the verified UID of a login UID from
+// the profile that matches the id. The user can no longer log in with that UID
+func (r *Repository) RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error {
+	_, span := tracer.Start(ctx, "RemoveVerifiedIdentifier")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		utils.RemoveLoginUID(profile, uid)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateAddresses persists a user's home or work address information to the database
+func (r *Repository) UpdateAddresses(
+	ctx context.Context,
+	id string,
+	address profileutils.Address,
+	addressType enumutils.AddressType,
+) error {
+	_, span := tracer.Start(ctx, "UpdateAddresses")
+	defer span.End()
+
+	err := r.updateProfileByID(ctx, id, false, func(profile *profileutils.UserProfile) error {
+		switch addressType {
+		case enumutils.AddressTypeHome:
+			profile.HomeAddress = &address
+		case enumutils.AddressTypeWork:
+			profile.WorkAddress = &address
+		default:
+			return exceptions.WrongEnumTypeError(addressType.String())
+		}
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// HardResetSecondaryPhoneNumbers does a hard reset of user secondary phone numbers.
+// This should be called when retiring specific secondary phone number and passing in
+// the new secondary phone numbers as an argument.
+func (r *Repository) HardResetSecondaryPhoneNumbers(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	newSecondaryPhoneNumbers []string,
+) error {
+	ctx, span := tracer.Start(ctx, "HardResetSecondaryPhoneNumbers")
+	defer span.End()
+
+	// the profile is only written back if it is still in the state the caller read it in
+	ctx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	err = r.updateProfileByID(ctx, profile.ID, true, func(stored *profileutils.UserProfile) error {
+		stored.SecondaryPhoneNumbers = newSecondaryPhoneNumbers
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	profile.SecondaryPhoneNumbers = newSecondaryPhoneNumbers
+	return nil
+}
+
+// HardResetSecondaryEmailAddress does a hard reset of user secondary email addresses. This should be called when retiring specific
+// secondary email addresses and passing in the new secondary email address as an argument.
+func (r *Repository) HardResetSecondaryEmailAddress(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	newSecondaryEmails []string,
+) error {
+	ctx, span := tracer.Start(ctx, "HardResetSecondaryEmailAddress")
+	defer span.End()
+
+	// the profile is only written back if it is still in the state the caller read it in
+	ctx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	err = r.updateProfileByID(ctx, profile.ID, true, func(stored *profileutils.UserProfile) error {
+		stored.SecondaryEmailAddresses = newSecondaryEmails
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	profile.SecondaryEmailAddresses = newSecondaryEmails
+	return nil
+}
+
+// RecordPostVisitSurvey records an end of visit survey
+func (r *Repository) RecordPostVisitSurvey(
+	ctx context.Context,
+	input dto.PostVisitSurveyInput,
+	UID string,
+) error {
+	_, span := tracer.Start(ctx, "RecordPostVisitSurvey")
+	defer span.End()
+
+	if input.LikelyToRecommend < 0 || input.LikelyToRecommend > 10 {
+		return exceptions.LikelyToRecommendError(
+			fmt.Errorf("the likelihood of recommending should be an int between 0 and 10"),
+		)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store.PostVisitSurveys = append(r.store.PostVisitSurveys, &domain.PostVisitSurvey{
+		LikelyToRecommend: input.LikelyToRecommend,
+		Criticism:         input.Criticism,
+		Suggestions:       input.Suggestions,
+		UID:               UID,
+		Timestamp:         time.Now(),
+	})
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+	return nil
+}
+
+// SavePIN  persist the data of the newly created PIN to a datastore
+func (r *Repository) SavePIN(ctx context.Context, pin *domain.PIN) (bool, error) {
+	_, span := tracer.Start(ctx, "SavePin")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := &domain.PIN{}
+	if err := clone(pin, stored); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.AddRecordError(err)
+	}
+	if err := r.recordPINEvent(stored); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.AddRecordError(err)
+	}
+	r.store.PINs = append(r.store.PINs, stored)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.AddRecordError(err)
+	}
+	return true, nil
+}
+
+// UpdatePIN  persist the data of the updated PIN to a datastore
+func (r *Repository) UpdatePIN(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
+	_, span := tracer.Start(ctx, "UpdatePIN")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.store.PINs {
+		if stored.ProfileID != id {
+			continue
+		}
+
+		// Check if PIN being updated is a Temporary PIN
+		if stored.IsOTP {
+			// Set New PIN flag as false
+			pin.IsOTP = false
+		}
+
+		updated := &domain.PIN{}
+		if err := clone(pin, updated); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, exceptions.UpdateProfileError(err)
+		}
+		if err := r.recordPINEvent(updated); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, exceptions.UpdateProfileError(err)
+		}
+		r.store.PINs[i] = updated
+		if err := r.persist(); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, exceptions.UpdateProfileError(err)
+		}
+		return true, nil
+	}
+
+	err := exceptions.PinNotFoundError(fmt.Errorf("failed to get a user pin"))
+	utils.RecordSpanError(span, err)
+	return false, exceptions.PinNotFoundError(err)
+}
+
+// ReservePINAttempt counts an attempt to use the PIN of a profile before the PIN is compared. It
+// refuses the attempt when the PIN is locked, must be reset or was attempted too recently
+func (r *Repository) ReservePINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	_, span := tracer.Start(ctx, "ReservePINAttempt")
+	defer span.End()
+
+	pin, err := r.updateStoredPIN(profileID, func(pin *domain.PIN) error {
+		return utils.ReservePINAttempt(pin, attemptedAt)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return pin, nil
+}
+
+// RecordFailedPINAttempt counts a failed attempt to use the PIN of a profile, locking the PIN
+// after too many failed attempts
+func (r *Repository) RecordFailedPINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	_, span := tracer.Start(ctx, "RecordFailedPINAttempt")
+	defer span.End()
+
+	pin, err := r.updateStoredPIN(profileID, func(pin *domain.PIN) error {
+		utils.RecordFailedPINAttempt(pin, attemptedAt)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return pin, nil
+}
+
+// ClearPINAttempts forgets the failed attempts to use the PIN of a profile, unlocking the PIN
+func (r *Repository) ClearPINAttempts(ctx context.Context, profileID string) error {
+	_, span := tracer.Start(ctx, "ClearPINAttempts")
+	defer span.End()
+
+	if _, err := r.updateStoredPIN(profileID, func(pin *domain.PIN) error {
+		utils.ClearPINAttempts(pin)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RequirePINReset stops the PIN of a profile from being used until it is reset with an OTP
+func (r *Repository) RequirePINReset(ctx context.Context, profileID string) error {
+	_, span := tracer.Start(ctx, "RequirePINReset")
+	defer span.End()
+
+	if _, err := r.updateStoredPIN(profileID, func(pin *domain.PIN) error {
+		utils.RequirePINReset(pin)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// UpdatePINHash replaces the hash, salt and hashing scheme of the PIN of a profile with the ones
+// of a rehashed PIN. The rest of the PIN, including whether it is temporary, is kept
+func (r *Repository) UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+	_, span := tracer.Start(ctx, "UpdatePINHash")
+	defer span.End()
+
+	_, err := r.updateStoredPIN(pin.ProfileID, func(stored *domain.PIN) error {
+		utils.ReplacePINHash(stored, previousPINNumber, pin)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// updateStoredPIN applies a change to the stored PIN of a profile and records the lockout
+// events of the change. It returns a copy of the updated PIN. The PIN is left unchanged when the
+// change returns an error
+func (r *Repository) updateStoredPIN(profileID string, update func(pin *domain.PIN) error) (*domain.PIN, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.store.PINs {
+		if stored.ProfileID != profileID {
+			continue
+		}
+
+		updated := &domain.PIN{}
+		if err := clone(stored, updated); err != nil {
+			return nil, exceptions.InternalServerError(err)
+		}
+		if err := update(updated); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return nil, err
+		}
+		events, err := utils.PINAttemptEvents(stored, updated)
+		if err != nil {
+			return nil, exceptions.InternalServerError(err)
+		}
+
+		previousEvents := r.store.OutboxEvents
+		r.store.PINs[i] = updated
+		r.store.OutboxEvents = append(r.store.OutboxEvents, events...)
+		if err := r.persist(); err != nil {
+			r.store.PINs[i] = stored
+			r.store.OutboxEvents = previousEvents
+			return nil, exceptions.InternalServerError(err)
+		}
+
+		pin := &domain.PIN{}
+		if err := clone(updated, pin); err != nil {
+			return nil, exceptions.InternalServerError(err)
+		}
+		return pin, nil
+	}
+
+	return nil, exceptions.PinNotFoundError(fmt.Errorf("failed to get a user pin"))
+}
+
+// SaveTempPIN replaces the PIN of a profile with a temporary PIN, saving it when the profile has
+// no PIN
+func (r *Repository) SaveTempPIN(ctx context.Context, pin *domain.PIN) error {
+	_, span := tracer.Start(ctx, "SaveTempPIN")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := &domain.PIN{}
+	if err := clone(pin, stored); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+	event, err := utils.PINChangedEvent(stored)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+
+	previousPINs := r.store.PINs
+	previousEvents := r.store.OutboxEvents
+	pins := []*domain.PIN{}
+	for _, existing := range r.store.PINs {
+		if existing.ProfileID != pin.ProfileID {
+			pins = append(pins, existing)
+		}
+	}
+	r.store.PINs = append(pins, stored)
+	r.store.OutboxEvents = append(r.store.OutboxEvents, event)
+	if err := r.persist(); err != nil {
+		r.store.PINs = previousPINs
+		r.store.OutboxEvents = previousEvents
+		utils.RecordSpanError(span, err)
+		return exceptions.AddRecordError(err)
+	}
+	return nil
+}
+
+// ExchangeRefreshTokenForIDToken exchanges a refresh token issued by the repository for new
+// auth credentials. The refresh token is rotated on every exchange
+func (r *Repository) ExchangeRefreshTokenForIDToken(
+	ctx context.Context,
+	refreshToken string,
+) (*profileutils.AuthCredentialResponse, error) {
+	ctx, span := tracer.Start(ctx, "ExchangeRefreshTokenForIDToken")
+	defer span.End()
+
+	r.mu.RLock()
+	uid, ok := r.store.RefreshTokens[refreshToken]
+	r.mu.RUnlock()
+	if !ok {
+		err := exceptions.InternalServerError(fmt.Errorf("invalid refresh token"))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	profile, err := r.GetUserProfileByUID(ctx, uid, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(fmt.Errorf(
+			"failed to retrieve user profile: %s", err,
+		))
+	}
+
+	canExperiment, err := r.CheckIfExperimentParticipant(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(fmt.Errorf(
+			"failed to check if the logged in user is an experimental participant: %s", err,
+		))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.store.RefreshTokens, refreshToken)
+	creds, err := r.issueCredentials(uid)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	// like the firebase token exchange, no custom token is returned
+	creds.CustomToken = nil
+	creds.IsAdmin = r.CheckIfAdmin(profile)
+	creds.CanExperiment = canExperiment
+	return creds, nil
+}
+
+// StageProfileNudge stages nudges published from this service.
+func (r *Repository) StageProfileNudge(ctx context.Context, nudge *feedlib.Nudge) error {
+	_, span := tracer.Start(ctx, "StageProfileNudge")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := &feedlib.Nudge{}
+	if err := clone(nudge, stored); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	r.store.ProfileNudges = append(r.store.ProfileNudges, stored)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// FetchAdminUsers fetches all admins
+func (r *Repository) FetchAdminUsers(ctx context.Context) ([]*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "FetchAdminUsers")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	admins := r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		for _, perm := range profileutils.DefaultAdminPermissions {
+			if profile.HasPermission(perm) {
+				return true
+			}
+		}
+		return false
+	})
+
+	profiles, err := cloneProfiles(admins)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profiles, nil
+}
+
+// FetchAllUsers is a no-op. The in memory repository has no users to hand over to other services
+func (r *Repository) FetchAllUsers(ctx context.Context, callbackURL string) {}
+
+// PurgeUserByPhoneNumber removes the record of a user given a phone number.
+func (r *Repository) PurgeUserByPhoneNumber(ctx context.Context, phone string) error {
+	ctx, span := tracer.Start(ctx, "PurgeUserByPhoneNumber")
+	defer span.End()
+
+	profile, err := r.GetUserProfileByPhoneNumber(ctx, phone, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pins := []*domain.PIN{}
+	for _, pin := range r.store.PINs {
+		if pin.ProfileID != profile.ID {
+			pins = append(pins, pin)
+		}
+	}
+	r.store.PINs = pins
+
+	profiles := []*profileutils.UserProfile{}
+	for _, stored := range r.store.UserProfiles {
+		if stored.ID != profile.ID {
+			profiles = append(profiles, stored)
+		}
+	}
+	r.store.UserProfiles = profiles
+	r.releaseIdentifiers(profile.ID)
+
+	users := []*AuthUser{}
+	for _, user := range r.store.AuthUsers {
+		if user.PhoneNumber != phone {
+			users = append(users, user)
+		}
+	}
+	r.store.AuthUsers = users
+
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// upgradeAnonymousUser adds the phone number of an account to the local user of the anonymous user
+// that the account is created for. The local user of the phone number, if any, must be the
+// anonymous user. The updated user replaces the stored one in a copy of the users so that the
+// caller can undo the change. The caller must hold the lock
+func (r *Repository) upgradeAnonymousUser(
+	kind domain.IdentifierKind,
+	phone string,
+	uid string,
+	existing *AuthUser,
+) (*AuthUser, error) {
+	if kind != domain.IdentifierKindPhone || !utils.IsAnonymousUID(uid) {
+		return nil, exceptions.NotAnonymousUserError()
+	}
+	upgraded := r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		return contains(profile.VerifiedUIDS, uid)
+	})
+	if len(upgraded) > 0 {
+		// an anonymous user is only upgraded once
+		return nil, exceptions.NotAnonymousUserError()
+	}
+	if existing != nil && existing.UID != uid {
+		// the phone number belongs to another local user
+		return nil, exceptions.CheckPhoneNumberExistError()
+	}
+
+	users := append([]*AuthUser{}, r.store.AuthUsers...)
+	for i, user := range users {
+		if user.UID == uid {
+			updated := *user
+			updated.PhoneNumber = phone
+			users[i] = &updated
+			r.store.AuthUsers = users
+			return &updated, nil
+		}
+	}
+	return nil, exceptions.NotAnonymousUserError()
+}
+
+// authUserByPhone returns the auth user with the provided phone number. The caller must hold the lock
+func (r *Repository) authUserByPhone(phone string) *AuthUser {
+	for _, user := range r.store.AuthUsers {
+		if user.PhoneNumber == phone {
+			return user
+		}
+	}
+	return nil
+}
+
+// authUserByEmail returns the auth user with the provided email address. The caller must hold the lock
+func (r *Repository) authUserByEmail(email string) *AuthUser {
+	for _, user := range r.store.AuthUsers {
+		if user.EmailAddress != "" && user.EmailAddress == email {
+			return user
+		}
+	}
+	return nil
+}
+
+// GetOrCreatePhoneNumberUser retrieves or creates a local phone number user account
+func (r *Repository) GetOrCreatePhoneNumberUser(
+	ctx context.Context,
+	phone string,
+) (*dto.CreatedUserResponse, error) {
+	_, span := tracer.Start(ctx, "GetOrCreatePhoneNumberUser")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user := r.authUserByPhone(phone); user != nil {
+		return &dto.CreatedUserResponse{
+			UID:         user.UID,
+			PhoneNumber: user.PhoneNumber,
+		}, nil
+	}
+
+	user := &AuthUser{
+		UID:         uuid.New().String(),
+		PhoneNumber: phone,
+	}
+	r.store.AuthUsers = append(r.store.AuthUsers, user)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	return &dto.CreatedUserResponse{
+		UID:         user.UID,
+		PhoneNumber: user.PhoneNumber,
+	}, nil
+}
+
+// getOrCreateEmailUser retrieves or creates a local email address user account
+func (r *Repository) getOrCreateEmailUser(
+	ctx context.Context,
+	email string,
+) (*dto.CreatedUserResponse, error) {
+	_, span := tracer.Start(ctx, "getOrCreateEmailUser")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user := r.authUserByEmail(email); user != nil {
+		return &dto.CreatedUserResponse{
+			UID:   user.UID,
+			Email: user.EmailAddress,
+		}, nil
+	}
+
+	user := &AuthUser{
+		UID:          uuid.New().String(),
+		EmailAddress: email,
+	}
+	r.store.AuthUsers = append(r.store.AuthUsers, user)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	return &dto.CreatedUserResponse{
+		UID:   user.UID,
+		Email: user.EmailAddress,
+	}, nil
+}
+
+// getOrCreateSocialUser retrieves or creates the local user of an account with a social login
+// provider. Its UID is derived from the account, which should have been verified before
+func (r *Repository) getOrCreateSocialUser(
+	ctx context.Context,
+	uid string,
+) (*dto.CreatedUserResponse, error) {
+	_, span := tracer.Start(ctx, "getOrCreateSocialUser")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.store.AuthUsers {
+		if user.UID == uid {
+			return &dto.CreatedUserResponse{UID: user.UID}, nil
+		}
+	}
+
+	r.store.AuthUsers = append(r.store.AuthUsers, &AuthUser{UID: uid})
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return &dto.CreatedUserResponse{UID: uid}, nil
+}
+
+// CheckIfExperimentParticipant check if a user has subscribed to be an experiment participant
+func (r *Repository) CheckIfExperimentParticipant(ctx context.Context, profileID string) (bool, error) {
+	_, span := tracer.Start(ctx, "CheckIfExperimentParticipant")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.store.ExperimentParticipants[profileID]
+	return exists, nil
+}
+
+// AddUserAsExperimentParticipant adds the provided user profile as an experiment participant if does not already exist.
+// this method is idempotent.
+func (r *Repository) AddUserAsExperimentParticipant(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+) (bool, error) {
+	_, span := tracer.Start(ctx, "AddUserAsExperimentParticipant")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.store.ExperimentParticipants[profile.ID]; exists {
+		// the user already exists as an experiment participant
+		return true, nil
+	}
+
+	participant, err := cloneProfile(profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.InternalServerError(err)
+	}
+	r.store.ExperimentParticipants[profile.ID] = participant
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.InternalServerError(
+			fmt.Errorf(
+				"unable to add user profile of ID %v in experiment_participant: %v",
+				profile.ID,
+				err,
+			),
+		)
+	}
+	return true, nil
+}
+
+// RemoveUserAsExperimentParticipant removes the provide user profile as an experiment participant. This methold does not check
+// for existence before deletion since non-existence is relatively equivalent to a removal
+func (r *Repository) RemoveUserAsExperimentParticipant(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+) (bool, error) {
+	_, span := tracer.Start(ctx, "RemoveUserAsExperimentParticipant")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.store.ExperimentParticipants, profile.ID)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.InternalServerError(
+			fmt.Errorf(
+				"unable to remove user profile of ID %v from experiment_participant: %v",
+				profile.ID,
+				err,
+			),
+		)
+	}
+	return true, nil
+}
+
+// GetUserCommunicationsSettings fetches the communication settings of a specific user.
+func (r *Repository) GetUserCommunicationsSettings(
+	ctx context.Context,
+	profileID string,
+) (*profileutils.UserCommunicationsSetting, error) {
+	_, span := tracer.Start(ctx, "GetUserCommunicationsSettings")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.store.CommunicationsSettings[profileID]
+	if !ok {
+		return &profileutils.UserCommunicationsSetting{ProfileID: profileID}, nil
+	}
+	comms := *stored
+	return &comms, nil
+}
+
+// SetUserCommunicationsSettings sets communication settings for a specific user
+func (r *Repository) SetUserCommunicationsSettings(
+	ctx context.Context,
+	profileID string,
+	allowWhatsApp *bool,
+	allowTextSms *bool,
+	allowPush *bool,
+	allowEmail *bool,
+) (*profileutils.UserCommunicationsSetting, error) {
+	ctx, span := tracer.Start(ctx, "SetUserCommunicationsSettings")
+	defer span.End()
+
+	// get the previous communications_settings
+	comms, err := r.GetUserCommunicationsSettings(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.store.CommunicationsSettings[profileID] = &profileutils.UserCommunicationsSetting{
+		ID:            uuid.New().String(),
+		ProfileID:     profileID,
+		AllowWhatsApp: utils.MatchAndReturn(comms.AllowWhatsApp, *allowWhatsApp),
+		AllowTextSMS:  utils.MatchAndReturn(comms.AllowTextSMS, *allowTextSms),
+		AllowPush:     utils.MatchAndReturn(comms.AllowPush, *allowPush),
+		AllowEmail:    utils.MatchAndReturn(comms.AllowEmail, *allowEmail),
+	}
+	err = r.persist()
+	r.mu.Unlock()
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	// fetch the now set communications_settings and return it
+	return r.GetUserCommunicationsSettings(ctx, profileID)
+}
+
+// ListUserProfiles fetches all users with the specified role from the database
+func (r *Repository) ListUserProfiles(
+	ctx context.Context,
+	role profileutils.RoleType,
+) ([]*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "ListUserProfiles")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles, err := cloneProfiles(r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		return profile.Role == role
+	}))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profiles, nil
+}
+
+// ListUserProfilesPage reads the user profiles of a page of a listing
+func (r *Repository) ListUserProfilesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "ListUserProfilesPage")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	position := func(profile *profileutils.UserProfile) interface{} {
+		return utils.UserProfileListingValue(profile, query.SortField)
+	}
+	matches := r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		if !utils.MatchesUserProfileFilters(profile, query.Filters) {
+			return false
+		}
+		return query.Cursor == nil || utils.CompareListingPositions(
+			*query,
+			position(profile),
+			profile.ID,
+			query.Cursor.Value,
+			query.Cursor.ID,
+		) > 0
+	})
+	sort.Slice(matches, func(i, j int) bool {
+		return utils.CompareListingPositions(
+			*query,
+			position(matches[i]),
+			matches[i].ID,
+			position(matches[j]),
+			matches[j].ID,
+		) < 0
+	})
+	if len(matches) > query.Limit+1 {
+		matches = matches[:query.Limit+1]
+	}
+
+	profiles, err := cloneProfiles(matches)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return profiles, nil
+}
+
+// roleByID returns the stored role with the provided id. The caller must hold the lock
+func (r *Repository) roleByID(id string) *profileutils.Role {
+	for _, role := range r.store.Roles {
+		if role.ID == id {
+			return role
+		}
+	}
+	return nil
+}
+
+// roleByName returns the stored role with the provided name. The caller must hold the lock
+func (r *Repository) roleByName(name string) *profileutils.Role {
+	for _, role := range r.store.Roles {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+func cloneRole(role *profileutils.Role) (*profileutils.Role, error) {
+	copied := &profileutils.Role{}
+	if err := clone(role, copied); err != nil {
+		return nil, fmt.Errorf("unable to read role")
+	}
+	return copied, nil
+}
+
+// CreateRole creates a new role and persists it to the database
+func (r *Repository) CreateRole(
+	ctx context.Context,
+	profileID string,
+	input dto.RoleInput,
+) (*profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "CreateRole")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.roleByName(input.Name) != nil {
+		err := fmt.Errorf("role with similar name exists:%v", input.Name)
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	role := profileutils.Role{
+		ID:          uuid.New().String(),
+		Name:        input.Name,
+		Description: input.Description,
+		CreatedBy:   profileID,
+		Created:     time.Now().In(pubsubtools.TimeLocation),
+		Active:      true,
+		Scopes:      input.Scopes,
+	}
+
+	stored, err := cloneRole(&role)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	r.store.Roles = append(r.store.Roles, stored)
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return &role, nil
+}
+
+// GetAllRoles returns a list of all created roles
+func (r *Repository) GetAllRoles(ctx context.Context) (*[]profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "GetAllRoles")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	roles := []profileutils.Role{}
+	for _, stored := range r.store.Roles {
+		role, err := cloneRole(stored)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		roles = append(roles, *role)
+	}
+	return &roles, nil
+}
+
+// ListRolesPage reads the roles of a page of a listing
+func (r *Repository) ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "ListRolesPage")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []*profileutils.Role{}
+	for _, role := range r.store.Roles {
+		if !utils.MatchesRoleFilters(role, query.Filters) {
+			continue
+		}
+		if query.Cursor != nil && utils.CompareListingPositions(
+			*query,
+			utils.RoleListingValue(role, query.SortField),
+			role.ID,
+			query.Cursor.Value,
+			query.Cursor.ID,
+		) <= 0 {
+			continue
+		}
+		matches = append(matches, role)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return utils.CompareListingPositions(
+			*query,
+			utils.RoleListingValue(matches[i], query.SortField),
+			matches[i].ID,
+			utils.RoleListingValue(matches[j], query.SortField),
+			matches[j].ID,
+		) < 0
+	})
+	if len(matches) > query.Limit+1 {
+		matches = matches[:query.Limit+1]
+	}
+
+	roles := []*profileutils.Role{}
+	for _, stored := range matches {
+		role, err := cloneRole(stored)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// UpdateRoleDetails  updates the details of a role
+func (r *Repository) UpdateRoleDetails(
+	ctx context.Context,
+	profileID string,
+	role profileutils.Role,
+) (*profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "UpdateRoleDetails")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updatedRole := profileutils.Role{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Active:      role.Active,
+		Scopes:      role.Scopes,
+		CreatedBy:   role.CreatedBy,
+		Created:     role.Created,
+		UpdatedBy:   profileID,
+		Updated:     time.Now().In(pubsubtools.TimeLocation),
+	}
+
+	for i, stored := range r.store.Roles {
+		if stored.ID != role.ID {
+			continue
+		}
+		updated, err := cloneRole(&updatedRole)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		r.store.Roles[i] = updated
+		if err := r.persist(); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		return &updatedRole, nil
+	}
+
+	err := fmt.Errorf("role not found: %v", role.ID)
+	utils.RecordSpanError(span, err)
+	return nil, err
+}
+
+// GetRoleByID gets role with matching id
+func (r *Repository) GetRoleByID(ctx context.Context, roleID string) (*profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "GetRoleByID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.roleByID(roleID)
+	if stored == nil {
+		err := fmt.Errorf("role not found: %v", roleID)
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	role, err := cloneRole(stored)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return role, nil
+}
+
+// GetRolesByIDs gets all roles matching provided roleIDs if specified otherwise all roles
+func (r *Repository) GetRolesByIDs(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+	ctx, span := tracer.Start(ctx, "GetRolesByIDs")
+	defer span.End()
+
+	roles := []profileutils.Role{}
+	for _, id := range roleIDs {
+		role, err := r.GetRoleByID(ctx, id)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, err
+		}
+		roles = append(roles, *role)
+	}
+	return &roles, nil
+}
+
+// DeleteRole removes a role permanently from the database
+func (r *Repository) DeleteRole(ctx context.Context, roleID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "DeleteRole")
+	defer span.End()
+
+	// remove this role for all users who has it assigned
+	users, err := r.GetUserProfilesByRoleID(ctx, roleID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	for _, user := range users {
+		// the roles are derived from this read of the profile
+		userCtx, err := utils.WithExpectedProfileVersion(ctx, user)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return false, err
+		}
+		if err := r.UpdateUserRoleIDs(userCtx, user.ID, removeItems(user.Roles, roleID)); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, exceptions.InternalServerError(err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.store.Roles {
+		if stored.ID != roleID {
+			continue
+		}
+		r.store.Roles = append(r.store.Roles[:i], r.store.Roles[i+1:]...)
+		if err := r.persist(); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, fmt.Errorf(
+				"unable to remove role of ID %v, error: %v",
+				roleID,
+				err,
+			)
+		}
+		return true, nil
+	}
+
+	// means the role was removed or does not exist
+	return false, fmt.Errorf("error role does not exist")
+}
+
+// CheckIfRoleNameExists checks if a role with a similar name exists
+// Ensures unique name for each role during creation
+func (r *Repository) CheckIfRoleNameExists(ctx context.Context, name string) (bool, error) {
+	_, span := tracer.Start(ctx, "CheckIfRoleNameExists")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.roleByName(name) != nil, nil
+}
+
+// GetUserProfilesByRoleID returns a list of user profiles with the role ID
+// i.e users assigned a particular role
+func (r *Repository) GetUserProfilesByRoleID(ctx context.Context, roleID string) ([]*profileutils.UserProfile, error) {
+	_, span := tracer.Start(ctx, "GetUserProfilesByRoleID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users, err := cloneProfiles(r.filterProfiles(func(profile *profileutils.UserProfile) bool {
+		return contains(profile.Roles, roleID)
+	}))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, fmt.Errorf("unable to parse userprofile")
+	}
+	return users, nil
+}
+
+// GetRoleByName retrieves a role using it's name
+func (r *Repository) GetRoleByName(ctx context.Context, roleName string) (*profileutils.Role, error) {
+	_, span := tracer.Start(ctx, "GetRoleByName")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.roleByName(roleName)
+	if stored == nil {
+		err := fmt.Errorf("role with name %v not found", roleName)
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	role, err := cloneRole(stored)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return role, nil
+}
+
+// SaveRoleRevocation records a log for a role revocation
+//
+// userId is the ID of the user removing a role from a user
+func (r *Repository) SaveRoleRevocation(ctx context.Context, userID string, revocation dto.RoleRevocationInput) error {
+	_, span := tracer.Start(ctx, "SaveRoleRevocation")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store.RoleRevocations = append(r.store.RoleRevocations, &domain.RoleRevocationLog{
+		ID:        uuid.New().String(),
+		ProfileID: revocation.ProfileID,
+		RoleID:    revocation.RoleID,
+		Reason:    revocation.Reason,
+		CreatedBy: userID,
+		Created:   time.Now().In(pubsubtools.TimeLocation),
+	})
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// CheckIfUserHasPermission checks if a user has the required permission
+func (r *Repository) CheckIfUserHasPermission(
+	ctx context.Context,
+	UID string,
+	requiredPermission profileutils.Permission,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "CheckIfUserHasPermission")
+	defer span.End()
+
+	userprofile, err := r.GetUserProfileByUID(ctx, UID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	roles, err := r.GetRolesByIDs(ctx, userprofile.Roles)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	for _, role := range *roles {
+		if role.Active && role.HasPermission(ctx, requiredPermission.Scope) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ListPendingOutboxEvents reads the oldest events that have not been published yet
+func (r *Repository) ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	_, span := tracer.Start(ctx, "ListPendingOutboxEvents")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []*domain.OutboxEvent{}
+	for _, event := range r.store.OutboxEvents {
+		if event.Published != nil {
+			continue
+		}
+		if limit > 0 && len(events) == limit {
+			break
+		}
+		copied := *event
+		events = append(events, &copied)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventPublished records that an event has been published
+func (r *Repository) MarkOutboxEventPublished(ctx context.Context, id string) error {
+	_, span := tracer.Start(ctx, "MarkOutboxEventPublished")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := r.outboxEventByID(id)
+	if event == nil {
+		err := exceptions.InternalServerError(fmt.Errorf("outbox event %s not found", id))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	published := time.Now().In(pubsubtools.TimeLocation)
+	event.Published = &published
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// RecordOutboxEventFailure records a failed attempt to publish an event
+func (r *Repository) RecordOutboxEventFailure(ctx context.Context, id string, reason string) error {
+	_, span := tracer.Start(ctx, "RecordOutboxEventFailure")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := r.outboxEventByID(id)
+	if event == nil {
+		err := exceptions.InternalServerError(fmt.Errorf("outbox event %s not found", id))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	event.Attempts++
+	event.LastError = reason
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// outboxEventByID returns the stored outbox event with the provided id. The caller must hold the lock
+func (r *Repository) outboxEventByID(id string) *domain.OutboxEvent {
+	for _, event := range r.store.OutboxEvents {
+		if event.ID == id {
+			return event
+		}
+	}
+	return nil
+}
+
+// GetPubSubMessage reads the record of a received message. It returns nil if the message
+// has not been received before
+func (r *Repository) GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error) {
+	_, span := tracer.Start(ctx, "GetPubSubMessage")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	message, ok := r.store.PubSubMessages[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *message
+	return &copied, nil
+}
+
+// SavePubSubMessage creates or replaces the record of a received message
+func (r *Repository) SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error {
+	_, span := tracer.Start(ctx, "SavePubSubMessage")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *message
+	r.store.PubSubMessages[message.ID] = &copied
+	if err := r.persist(); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListFailedPubSubMessages reads the messages that failed or were dead lettered, oldest first
+func (r *Repository) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	_, span := tracer.Start(ctx, "ListFailedPubSubMessages")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	messages := []*domain.PubSubMessage{}
+	for _, message := range r.store.PubSubMessages {
+		if message.Status == domain.PubSubMessageStatusProcessed {
+			continue
+		}
+		if topicID != nil && message.TopicID != *topicID {
+			continue
+		}
+		copied := *message
+		messages = append(messages, &copied)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].Created.Equal(messages[j].Created) {
+			return messages[i].ID < messages[j].ID
+		}
+		return messages[i].Created.Before(messages[j].Created)
+	})
+	return messages, nil
+}
+
+// CreateWebhookEndpoint stores a new webhook endpoint
+func (r *Repository) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	_, span := tracer.Start(ctx, "CreateWebhookEndpoint")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *endpoint
+	r.store.WebhookEndpoints = append(r.store.WebhookEndpoints, &copied)
+	if err := r.persist(); err != nil {
+		r.store.WebhookEndpoints = r.store.WebhookEndpoints[:len(r.store.WebhookEndpoints)-1]
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetWebhookEndpoint reads a webhook endpoint
+func (r *Repository) GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	_, span := tracer.Start(ctx, "GetWebhookEndpoint")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, endpoint := range r.store.WebhookEndpoints {
+		if endpoint.ID == id {
+			copied := *endpoint
+			return &copied, nil
+		}
+	}
+	err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook endpoint %s not found", id))
+	utils.RecordSpanError(span, err)
+	return nil, err
+}
+
+// ListWebhookEndpoints reads all the webhook endpoints in the order they were registered
+func (r *Repository) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	_, span := tracer.Start(ctx, "ListWebhookEndpoints")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoints := []*domain.WebhookEndpoint{}
+	for _, endpoint := range r.store.WebhookEndpoints {
+		copied := *endpoint
+		endpoints = append(endpoints, &copied)
+	}
+	return endpoints, nil
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint together with its deliveries
+func (r *Repository) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	_, span := tracer.Start(ctx, "DeleteWebhookEndpoint")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := *r.store
+	endpoints := []*domain.WebhookEndpoint{}
+	for _, endpoint := range r.store.WebhookEndpoints {
+		if endpoint.ID != id {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == len(r.store.WebhookEndpoints) {
+		err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook endpoint %s not found", id))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	deliveries := []*domain.WebhookDelivery{}
+	for _, delivery := range r.store.WebhookDeliveries {
+		if delivery.EndpointID != id {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	r.store.WebhookEndpoints = endpoints
+	r.store.WebhookDeliveries = deliveries
+
+	if err := r.persist(); err != nil {
+		r.store.WebhookEndpoints = previous.WebhookEndpoints
+		r.store.WebhookDeliveries = previous.WebhookDeliveries
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints
+// that subscribe to it
+func (r *Repository) EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error {
+	_, span := tracer.Start(ctx, "EnqueueWebhookDeliveries")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.store.WebhookDeliveries
+	now := time.Now().In(pubsubtools.TimeLocation)
+	for _, endpoint := range r.store.WebhookEndpoints {
+		if !endpoint.Active || !endpoint.Subscribes(event.Type) {
+			continue
+		}
+		delivery := utils.NewWebhookDelivery(endpoint, event, now)
+		if r.webhookDeliveryByID(delivery.ID) != nil {
+			continue
+		}
+		r.store.WebhookDeliveries = append(r.store.WebhookDeliveries, delivery)
+	}
+
+	if err := r.persist(); err != nil {
+		r.store.WebhookDeliveries = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListDueWebhookDeliveries reads the oldest pending deliveries that are due to be attempted
+func (r *Repository) ListDueWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*domain.WebhookDelivery, error) {
+	_, span := tracer.Start(ctx, "ListDueWebhookDeliveries")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for _, delivery := range r.store.WebhookDeliveries {
+		if delivery.Status != domain.WebhookDeliveryStatusPending || delivery.NextAttempt.After(now) {
+			continue
+		}
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttempt.Before(deliveries[j].NextAttempt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDelivery reads a webhook delivery
+func (r *Repository) GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	_, span := tracer.Start(ctx, "GetWebhookDelivery")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery := r.webhookDeliveryByID(id)
+	if delivery == nil {
+		err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook delivery %s not found", id))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+func (r *Repository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, span := tracer.Start(ctx, "UpdateWebhookDelivery")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.webhookDeliveryByID(delivery.ID)
+	if stored == nil {
+		err := exceptions.RecordDoesNotExistError(fmt.Errorf("webhook delivery %s not found", delivery.ID))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	previous := *stored
+	*stored = *delivery
+	if err := r.persist(); err != nil {
+		*stored = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first
+func (r *Repository) ListWebhookDeliveries(
+	ctx context.Context,
+	endpointID string,
+	status *domain.WebhookDeliveryStatus,
+) ([]*domain.WebhookDelivery, error) {
+	_, span := tracer.Start(ctx, "ListWebhookDeliveries")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for i := len(r.store.WebhookDeliveries) - 1; i >= 0; i-- {
+		delivery := r.store.WebhookDeliveries[i]
+		if delivery.EndpointID != endpointID || (status != nil && delivery.Status != *status) {
+			continue
+		}
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+	return deliveries, nil
+}
+
+// webhookDeliveryByID returns the stored webhook delivery with the provided id. The caller must hold the lock
+func (r *Repository) webhookDeliveryByID(id string) *domain.WebhookDelivery {
+	for _, delivery := range r.store.WebhookDeliveries {
+		if delivery.ID == id {
+			return delivery
+		}
+	}
+	return nil
+}
+
+// SaveSession creates or replaces a session
+func (r *Repository) SaveSession(ctx context.Context, session *domain.Session) error {
+	_, span := tracer.Start(ctx, "SaveSession")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.store.Sessions
+	sessions := []*domain.Session{}
+	for _, stored := range r.store.Sessions {
+		if stored.ID != session.ID {
+			sessions = append(sessions, stored)
+		}
+	}
+	copied := *session
+	r.store.Sessions = append(sessions, &copied)
+	if err := r.persist(); err != nil {
+		r.store.Sessions = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetSessionByRefreshTokenHash reads the session that holds a refresh token. It returns nil if
+// no session holds the token
+func (r *Repository) GetSessionByRefreshTokenHash(
+	ctx context.Context,
+	refreshTokenHash string,
+) (*domain.Session, error) {
+	_, span := tracer.Start(ctx, "GetSessionByRefreshTokenHash")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, session := range r.store.Sessions {
+		if session.RefreshTokenHash == refreshTokenHash {
+			copied := *session
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListSessions reads the sessions of a profile, most recently seen first
+func (r *Repository) ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	_, span := tracer.Start(ctx, "ListSessions")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sessions := []*domain.Session{}
+	for _, session := range r.store.Sessions {
+		if session.ProfileID == profileID {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	utils.SortSessions(sessions)
+	return sessions, nil
+}
+
+// RevokeSessions signs out the listed sessions of a profile, or all of its sessions when no
+// session is listed
+func (r *Repository) RevokeSessions(
+	ctx context.Context,
+	profileID string,
+	sessionIDs []string,
+	revokedAt time.Time,
+) error {
+	_, span := tracer.Start(ctx, "RevokeSessions")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.store.Sessions
+	sessions := []*domain.Session{}
+	owned := []*domain.Session{}
+	for _, session := range r.store.Sessions {
+		copied := *session
+		sessions = append(sessions, &copied)
+		if copied.ProfileID == profileID {
+			owned = append(owned, &copied)
+		}
+	}
+	utils.RevokeSessions(owned, sessionIDs, revokedAt)
+	r.store.Sessions = sessions
+
+	if err := r.persist(); err != nil {
+		r.store.Sessions = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// RevokeRefreshTokens revokes all the refresh tokens that were issued to a user
+func (r *Repository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	_, span := tracer.Start(ctx, "RevokeRefreshTokens")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := map[string]string{}
+	for token, owner := range r.store.RefreshTokens {
+		previous[token] = owner
+		if owner == uid {
+			delete(r.store.RefreshTokens, token)
+		}
+	}
+	if err := r.persist(); err != nil {
+		r.store.RefreshTokens = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// RecordLoginEvent appends an attempt to log in to the audit trail
+func (r *Repository) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error {
+	_, span := tracer.Start(ctx, "RecordLoginEvent")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *event
+	r.store.LoginEvents = append(r.store.LoginEvents, &copied)
+	if err := r.persist(); err != nil {
+		r.store.LoginEvents = r.store.LoginEvents[:len(r.store.LoginEvents)-1]
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// ListLoginEvents reads the login events that match a filter, newest first
+func (r *Repository) ListLoginEvents(
+	ctx context.Context,
+	filter *domain.LoginEventFilter,
+) ([]*domain.LoginEvent, error) {
+	_, span := tracer.Start(ctx, "ListLoginEvents")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []*domain.LoginEvent{}
+	for _, event := range r.store.LoginEvents {
+		if filter.Matches(event) {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+// SaveLoginAlert creates or replaces a login alert
+func (r *Repository) SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error {
+	_, span := tracer.Start(ctx, "SaveLoginAlert")
+	defer span.End()
+
+	copied := &domain.LoginAlert{}
+	if err := clone(alert, copied); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.store.LoginAlerts
+	alerts := []*domain.LoginAlert{}
+	for _, stored := range r.store.LoginAlerts {
+		if stored.ID != alert.ID {
+			alerts = append(alerts, stored)
+		}
+	}
+	r.store.LoginAlerts = append(alerts, copied)
+	if err := r.persist(); err != nil {
+		r.store.LoginAlerts = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetLoginAlertByTokenHash reads the login alert whose "this wasn't me" token has the provided
+// hash. It returns nil if there is no such alert
+func (r *Repository) GetLoginAlertByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*domain.LoginAlert, error) {
+	_, span := tracer.Start(ctx, "GetLoginAlertByTokenHash")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.store.LoginAlerts {
+		if stored.TokenHash != tokenHash {
+			continue
+		}
+		alert := &domain.LoginAlert{}
+		if err := clone(stored, alert); err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, exceptions.InternalServerError(err)
+		}
+		return alert, nil
+	}
+	return nil, nil
+}
+
+// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile. A profile has at most one
+func (r *Repository) SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+	_, span := tracer.Start(ctx, "SaveTOTPEnrolment")
+	defer span.End()
+
+	copied := &domain.TOTPEnrolment{}
+	if err := clone(enrolment, copied); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.store.TOTPEnrolments[enrolment.ProfileID]
+	r.store.TOTPEnrolments[enrolment.ProfileID] = copied
+	if err := r.persist(); err != nil {
+		if existed {
+			r.store.TOTPEnrolments[enrolment.ProfileID] = previous
+		} else {
+			delete(r.store.TOTPEnrolments, enrolment.ProfileID)
+		}
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetTOTPEnrolment reads the TOTP enrolment of a profile. It returns nil if the profile has none
+func (r *Repository) GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+	_, span := tracer.Start(ctx, "GetTOTPEnrolment")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.store.TOTPEnrolments[profileID]
+	if !ok {
+		return nil, nil
+	}
+	enrolment := &domain.TOTPEnrolment{}
+	if err := clone(stored, enrolment); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return enrolment, nil
+}
+
+// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+func (r *Repository) DeleteTOTPEnrolment(ctx context.Context, profileID string) error {
+	_, span := tracer.Start(ctx, "DeleteTOTPEnrolment")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.store.TOTPEnrolments[profileID]
+	if !existed {
+		return nil
+	}
+	delete(r.store.TOTPEnrolments, profileID)
+	if err := r.persist(); err != nil {
+		r.store.TOTPEnrolments[profileID] = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetTwoFactorPolicy reads the two factor policy. It returns an empty policy if none has been saved
+func (r *Repository) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	_, span := tracer.Start(ctx, "GetTwoFactorPolicy")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.store.TwoFactorPolicy == nil {
+		return &domain.TwoFactorPolicy{ID: domain.TwoFactorPolicyID, RequiredScopes: []string{}}, nil
+	}
+	policy := &domain.TwoFactorPolicy{}
+	if err := clone(r.store.TwoFactorPolicy, policy); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return policy, nil
+}
+
+// SaveTwoFactorPolicy replaces the two factor policy
+func (r *Repository) SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	_, span := tracer.Start(ctx, "SaveTwoFactorPolicy")
+	defer span.End()
+
+	policy.ID = domain.TwoFactorPolicyID
+	copied := &domain.TwoFactorPolicy{}
+	if err := clone(policy, copied); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.store.TwoFactorPolicy
+	r.store.TwoFactorPolicy = copied
+	if err := r.persist(); err != nil {
+		r.store.TwoFactorPolicy = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// SavePassword creates or replaces the password of the email login of a profile. A profile has at most one
+func (r *Repository) SavePassword(ctx context.Context, password *domain.Password) error {
+	_, span := tracer.Start(ctx, "SavePassword")
+	defer span.End()
+
+	copied := &domain.Password{}
+	if err := clone(password, copied); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.store.Passwords[password.ProfileID]
+	r.store.Passwords[password.ProfileID] = copied
+	if err := r.persist(); err != nil {
+		if existed {
+			r.store.Passwords[password.ProfileID] = previous
+		} else {
+			delete(r.store.Passwords, password.ProfileID)
+		}
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	return nil
+}
+
+// GetPasswordByEmailAddress reads the password of the email login of a normalized email address.
+// It returns nil if the email address has no email login
+func (r *Repository) GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error) {
+	_, span := tracer.Start(ctx, "GetPasswordByEmailAddress")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.store.Passwords {
+		if stored.EmailAddress == emailAddress {
+			password := &domain.Password{}
+			if err := clone(stored, password); err != nil {
+				utils.RecordSpanError(span, err)
+				return nil, exceptions.InternalServerError(err)
+			}
+			return password, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetPasswordByProfileID reads the password of the email login of a profile. It returns nil if
+// the profile has none
+func (r *Repository) GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error) {
+	_, span := tracer.Start(ctx, "GetPasswordByProfileID")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.store.Passwords[profileID]
+	if !ok {
+		return nil, nil
+	}
+	password := &domain.Password{}
+	if err := clone(stored, password); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return password, nil
+}
+
+// ReservePasswordAttempt counts an attempt to use the password of the email login of a profile
+// before the password is compared, locking the password after too many failed attempts. It refuses
+// the attempt when the password is locked or was attempted too recently
+func (r *Repository) ReservePasswordAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.Password, error) {
+	_, span := tracer.Start(ctx, "ReservePasswordAttempt")
+	defer span.End()
+
+	password, err := r.updateStoredPassword(profileID, func(password *domain.Password) error {
+		return utils.ReservePasswordAttempt(password, attemptedAt)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return password, nil
+}
+
+// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of a
+// profile, unlocking the password
+func (r *Repository) ClearPasswordAttempts(ctx context.Context, profileID string) error {
+	_, span := tracer.Start(ctx, "ClearPasswordAttempts")
+	defer span.End()
+
+	if _, err := r.updateStoredPassword(profileID, func(password *domain.Password) error {
+		utils.ClearPasswordAttempts(password)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// RequirePasswordReset stops the password of the email login of a profile from being used until it
+// is reset with an OTP
+func (r *Repository) RequirePasswordReset(ctx context.Context, profileID string) error {
+	_, span := tracer.Start(ctx, "RequirePasswordReset")
+	defer span.End()
+
+	if _, err := r.updateStoredPassword(profileID, func(password *domain.Password) error {
+		utils.RequirePasswordReset(password)
+		return nil
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// UpdatePasswordHash replaces the hash, salt and hashing scheme of the password of a profile with
+// the ones of a rehashed password. The rest of the password, including its failed attempts, is kept
+func (r *Repository) UpdatePasswordHash(
+	ctx context.Context,
+	password *domain.Password,
+	previousPasswordHash string,
+) error {
+	_, span := tracer.Start(ctx, "UpdatePasswordHash")
+	defer span.End()
+
+	_, err := r.updateStoredPassword(password.ProfileID, func(stored *domain.Password) error {
+		utils.ReplacePasswordHash(stored, previousPasswordHash, password)
+		return nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// updateStoredPassword applies a change to the stored password of a profile. It returns a copy of
+// the updated password
+func (r *Repository) updateStoredPassword(
+	profileID string,
+	update func(password *domain.Password) error,
+) (*domain.Password, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.store.Passwords[profileID]
+	if !ok {
+		return nil, exceptions.InternalServerError(fmt.Errorf("profile %s has no password", profileID))
+	}
+	updated := &domain.Password{}
+	if err := clone(stored, updated); err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	if err := update(updated); err != nil {
+		return nil, err
+	}
+
+	r.store.Passwords[profileID] = updated
+	if err := r.persist(); err != nil {
+		r.store.Passwords[profileID] = stored
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	password := &domain.Password{}
+	if err := clone(updated, password); err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	return password, nil
+}
+
+// MergeUserProfiles stores the result of a merge as the surviving profile and removes the merged profile.
+// The PIN, password, communications settings and experiment participation of the merged profile are
+// moved to the survivor unless it has its own
+func (r *Repository) MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error {
+	_, span := tracer.Start(ctx, "MergeUserProfiles")
+	defer span.End()
+
+	result, err := cloneProfile(merge.Result)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	entry := &domain.ProfileMerge{}
+	if err := clone(merge, entry); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	events, err := utils.ProfileEvents(merge.Survivor, result)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	event, err := utils.UserMergedEvent(merge)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	events = append(events, event)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	survivor := r.profileByID(merge.SurvivorID)
+	merged := r.profileByID(merge.MergedProfileID)
+	if survivor == nil || merged == nil {
+		err := exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if err := utils.CheckProfileUnchanged(survivor, merge.Survivor); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if err := utils.CheckProfileUnchanged(merged, merge.MergedProfile); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
+	// the merge touches most of the store so a copy of it is restored when the merge fails
+	previous := newSnapshot()
+	if err := clone(r.store, previous); err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+
+	// the identifiers of the merged profile are handed over to the survivor
+	r.releaseIdentifiers(merged.ID)
+	if err := r.reserveIdentifiers(survivor, result); err != nil {
+		r.store = previous
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
+	profiles := []*profileutils.UserProfile{}
+	for _, profile := range r.store.UserProfiles {
+		switch profile.ID {
+		case survivor.ID:
+			profiles = append(profiles, result)
+		case merged.ID:
+		default:
+			profiles = append(profiles, profile)
+		}
+	}
+	r.store.UserProfiles = profiles
+
+	survivorHasPIN := false
+	for _, pin := range r.store.PINs {
+		if pin.ProfileID == survivor.ID {
+			survivorHasPIN = true
+		}
+	}
+	pins := []*domain.PIN{}
+	for _, pin := range r.store.PINs {
+		if pin.ProfileID == merged.ID {
+			if survivorHasPIN {
+				continue
+			}
+			moved := *pin
+			moved.ProfileID = survivor.ID
+			pin = &moved
+		}
+		pins = append(pins, pin)
+	}
+	r.store.PINs = pins
+
+	if password, ok := r.store.Passwords[merged.ID]; ok {
+		delete(r.store.Passwords, merged.ID)
+		if _, exists := r.store.Passwords[survivor.ID]; !exists {
+			moved := *password
+			moved.ProfileID = survivor.ID
+			r.store.Passwords[survivor.ID] = &moved
+		}
+	}
+	if comms, ok := r.store.CommunicationsSettings[merged.ID]; ok {
+		delete(r.store.CommunicationsSettings, merged.ID)
+		if _, exists := r.store.CommunicationsSettings[survivor.ID]; !exists {
+			moved := *comms
+			moved.ProfileID = survivor.ID
+			r.store.CommunicationsSettings[survivor.ID] = &moved
+		}
+	}
+	if _, ok := r.store.ExperimentParticipants[merged.ID]; ok {
+		delete(r.store.ExperimentParticipants, merged.ID)
+		if _, exists := r.store.ExperimentParticipants[survivor.ID]; !exists {
+			participant, err := cloneProfile(result)
+			if err != nil {
+				r.store = previous
+				utils.RecordSpanError(span, err)
+				return exceptions.InternalServerError(err)
+			}
+			r.store.ExperimentParticipants[survivor.ID] = participant
+		}
+	}
+
+	// profiles that were merged into the merged profile now live on in the survivor
+	for _, tombstone := range r.store.ProfileTombstones {
+		if tombstone.MergedInto == merged.ID {
+			tombstone.MergedInto = survivor.ID
+		}
+	}
+	r.store.ProfileTombstones[merged.ID] = utils.NewProfileTombstone(entry)
+	r.store.ProfileMerges = append(r.store.ProfileMerges, entry)
+	r.store.OutboxEvents = append(r.store.OutboxEvents, events...)
+
+	if err := r.persist(); err != nil {
+		r.store = previous
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(
+			fmt.Errorf("unable to merge user profiles: %w", err),
+		)
+	}
+	return nil
+}
+
+// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one.
+// It returns nil if the profile has not been merged
+func (r *Repository) GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	_, span := tracer.Start(ctx, "GetProfileTombstone")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.store.ProfileTombstones[profileID]
+	if !ok {
+		return nil, nil
+	}
+	tombstone := *stored
+	return &tombstone, nil
+}
+
+// SaveSigningKey stores a new signing key. It is only kept in memory, never in the snapshot
+func (r *Repository) SaveSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	_, span := tracer.Start(ctx, "SaveSigningKey")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.signingKeys {
+		if stored.ID == key.ID {
+			err := fmt.Errorf("signing key %s already exists", key.ID)
+			utils.RecordSpanError(span, err)
+			return exceptions.InternalServerError(err)
+		}
+	}
+
+	copied := *key
+	r.signingKeys = append(r.signingKeys, &copied)
+	return nil
+}
+
+// ListSigningKeys reads the signing keys that are still published at the provided time, newest first
+func (r *Repository) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	_, span := tracer.Start(ctx, "ListSigningKeys")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []*domain.SigningKey{}
+	for _, key := range r.signingKeys {
+		if key.NotAfter.After(now) {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+	utils.SortSigningKeys(keys)
+	return keys, nil
+}
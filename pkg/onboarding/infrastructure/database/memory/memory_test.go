@@ -0,0 +1,1633 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPhone       = "+254711223344"
+	testSecondPhone = "+254722334455"
+)
+
+func TestRepository_CreateUserProfile(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, testPhone, *profile.PrimaryPhone)
+	assert.NotNil(t, profile.UserName)
+	assert.Equal(t, []string{"uid-1"}, profile.VerifiedUIDS)
+
+	if _, err := repo.CreateUserProfile(ctx, testPhone, "uid-2"); err == nil {
+		t.Errorf("expected an error when the phone number is in use")
+	}
+
+	if err := repo.UpdateSecondaryPhoneNumbers(currentVersion(t, repo, profile.ID), profile.ID, []string{testSecondPhone}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.CreateUserProfile(ctx, testSecondPhone, "uid-3"); err == nil {
+		t.Errorf("expected an error when the phone number is a secondary phone number")
+	}
+
+	exists, err := repo.CheckIfUsernameExists(ctx, *profile.UserName)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, exists)
+
+	other, err := repo.CreateUserProfile(ctx, "+254733445566", "uid-4")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateUserName(currentVersion(t, repo, other.ID), other.ID, *profile.UserName); err == nil {
+		t.Errorf("expected an error when the username is in use")
+	}
+}
+
+func TestRepository_SuspendedProfiles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSuspended(ctx, profile.ID, true); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		get       func(suspended bool) (*profileutils.UserProfile, error)
+		suspended bool
+		wantErr   bool
+	}{
+		{
+			name: "Sad case:suspended profile by uid",
+			get: func(suspended bool) (*profileutils.UserProfile, error) {
+				return repo.GetUserProfileByUID(ctx, "uid-1", suspended)
+			},
+			suspended: false,
+			wantErr:   true,
+		},
+		{
+			name: "Happy case:suspended profile by uid",
+			get: func(suspended bool) (*profileutils.UserProfile, error) {
+				return repo.GetUserProfileByUID(ctx, "uid-1", suspended)
+			},
+			suspended: true,
+			wantErr:   false,
+		},
+		{
+			name: "Sad case:suspended profile by id",
+			get: func(suspended bool) (*profileutils.UserProfile, error) {
+				return repo.GetUserProfileByID(ctx, profile.ID, suspended)
+			},
+			suspended: false,
+			wantErr:   true,
+		},
+		{
+			name: "Sad case:suspended profile by primary phone number",
+			get: func(suspended bool) (*profileutils.UserProfile, error) {
+				return repo.GetUserProfileByPrimaryPhoneNumber(ctx, testPhone, suspended)
+			},
+			suspended: false,
+			wantErr:   true,
+		},
+		{
+			name: "Sad case:unknown uid",
+			get: func(suspended bool) (*profileutils.UserProfile, error) {
+				return repo.GetUserProfileByUID(ctx, "unknown", suspended)
+			},
+			suspended: true,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get(tt.suspended)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a user profile")
+			}
+		})
+	}
+
+	if err := repo.UpdateSuspended(ctx, profile.ID, false); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.GetUserProfileByUID(ctx, "uid-1", false); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+}
+
+func TestRepository_Roles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	input := dto.RoleInput{
+		Name:   "Employee",
+		Scopes: []string{"role.create"},
+	}
+	role, err := repo.CreateRole(ctx, profile.ID, input)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.CreateRole(ctx, profile.ID, input); err == nil {
+		t.Errorf("expected an error when the role name exists")
+	}
+
+	byName, err := repo.GetRoleByName(ctx, "Employee")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, role.ID, byName.ID)
+
+	if _, err := repo.GetRoleByID(ctx, "unknown"); err == nil {
+		t.Errorf("expected an error for an unknown role")
+	}
+
+	if err := repo.UpdateUserRoleIDs(currentVersion(t, repo, profile.ID), profile.ID, []string{role.ID}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	allowed, err := repo.CheckIfUserHasPermission(ctx, "uid-1", profileutils.Permission{Scope: "role.create"})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, allowed)
+
+	deleted, err := repo.DeleteRole(ctx, role.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, deleted)
+
+	updated, err := repo.GetUserProfileByID(ctx, profile.ID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, updated.Roles)
+
+	if _, err := repo.DeleteRole(ctx, role.ID); err == nil {
+		t.Errorf("expected an error when the role does not exist")
+	}
+}
+
+func TestRepository_UpdatePIN(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if _, err := repo.UpdatePIN(ctx, "profile-1", &domain.PIN{ProfileID: "profile-1"}); err == nil {
+		t.Errorf("expected an error when the PIN does not exist")
+	}
+
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1", IsOTP: true}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	newPIN := &domain.PIN{ID: "pin-1", ProfileID: "profile-1", PINNumber: "hash", IsOTP: true}
+	if _, err := repo.UpdatePIN(ctx, "profile-1", newPIN); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	pin, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.False(t, pin.IsOTP)
+	assert.Equal(t, "hash", pin.PINNumber)
+}
+
+func TestRepository_PINAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if _, err := repo.RecordFailedPINAttempt(ctx, "profile-1", time.Now()); err == nil {
+		t.Errorf("expected an error when the PIN does not exist")
+	}
+
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	now := time.Now()
+	var pin *domain.PIN
+	for i := 0; i < utils.MaxPINAttempts; i++ {
+		var err error
+		pin, err = repo.RecordFailedPINAttempt(ctx, "profile-1", now)
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	assert.Equal(t, utils.MaxPINAttempts, pin.FailedAttempts)
+	assert.True(t, pin.IsLocked(now))
+
+	stored, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, stored.IsLocked(now))
+
+	if err := repo.ClearPINAttempts(ctx, "profile-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	stored, err = repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 0, stored.FailedAttempts)
+	assert.False(t, stored.IsLocked(now))
+
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	types := []domain.EventType{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []domain.EventType{
+		domain.EventTypePINChanged,
+		domain.EventTypePINLocked,
+		domain.EventTypePINUnlocked,
+	}, types)
+}
+
+func TestRepository_ReservePINAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if _, err := repo.ReservePINAttempt(ctx, "profile-1", time.Now()); err == nil {
+		t.Errorf("expected an error when the PIN does not exist")
+	}
+
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// a burst of concurrent attempts only gets the attempts that are not throttled: the first
+	// attempt and the one after it, which is not delayed either
+	now := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.ReservePINAttempt(ctx, "profile-1", now); err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, reserved)
+
+	stored, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 2, stored.FailedAttempts)
+
+	// the attempts that are left are reserved once they are no longer throttled, and the PIN is
+	// locked by the last of them
+	attemptedAt := now
+	for i := stored.FailedAttempts; i < utils.MaxPINAttempts; i++ {
+		attemptedAt = attemptedAt.Add(time.Minute)
+		if _, err := repo.ReservePINAttempt(ctx, "profile-1", attemptedAt); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	_, err = repo.ReservePINAttempt(ctx, "profile-1", attemptedAt.Add(time.Minute))
+	assert.NotNil(t, err)
+
+	stored, err = repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, utils.MaxPINAttempts, stored.FailedAttempts)
+	assert.True(t, stored.IsLocked(attemptedAt))
+}
+
+func TestRepository_SaveTempPIN(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	// a profile without a PIN gets one
+	if err := repo.SaveTempPIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1", IsOTP: true}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	pin, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "pin-1", pin.ID)
+
+	// a temporary PIN replaces a temporary PIN without losing its flag
+	if err := repo.SaveTempPIN(ctx, &domain.PIN{ID: "pin-2", ProfileID: "profile-1", IsOTP: true}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	pin, err = repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "pin-2", pin.ID)
+	assert.True(t, pin.IsOTP)
+
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, events, 2)
+}
+
+func TestRepository_UpdatePINHash(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if err := repo.SaveTempPIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1", PINNumber: "legacy", IsOTP: true}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	scheme := &domain.PINHashScheme{Algorithm: "argon2id", Iterations: 2}
+	rehashed := &domain.PIN{ProfileID: "profile-1", PINNumber: "rehashed", Salt: "salt", Scheme: scheme}
+	if err := repo.UpdatePINHash(ctx, rehashed, "legacy"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	pin, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "pin-1", pin.ID)
+	assert.Equal(t, "rehashed", pin.PINNumber)
+	assert.Equal(t, scheme, pin.Scheme)
+	assert.True(t, pin.IsOTP)
+
+	// a PIN that changed after it was rehashed is not overwritten
+	stale := &domain.PIN{ProfileID: "profile-1", PINNumber: "stale"}
+	if err := repo.UpdatePINHash(ctx, stale, "legacy"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	pin, err = repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "rehashed", pin.PINNumber)
+
+	assert.NotNil(t, repo.UpdatePINHash(ctx, &domain.PIN{ProfileID: "profile-2"}, "legacy"))
+}
+
+func TestRepository_CommunicationsSettings(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	comms, err := repo.GetUserCommunicationsSettings(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "profile-1", comms.ProfileID)
+	assert.False(t, comms.AllowEmail)
+
+	yes, no := true, false
+	comms, err = repo.SetUserCommunicationsSettings(ctx, "profile-1", &no, &yes, &no, &yes)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, comms.AllowEmail)
+	assert.True(t, comms.AllowTextSMS)
+	assert.False(t, comms.AllowPush)
+}
+
+func TestRepository_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	repo, err := memory.NewMemoryRepositoryFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	creds, err := repo.GenerateAuthCredentials(ctx, testPhone, profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	loaded, err := memory.NewMemoryRepositoryFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	got, err := loaded.GetUserProfileByPhoneNumber(ctx, testPhone, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, profile.ID, got.ID)
+
+	refreshed, err := loaded.ExchangeRefreshTokenForIDToken(ctx, creds.RefreshToken)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, creds.UID, refreshed.UID)
+
+	if _, err := loaded.ExchangeRefreshTokenForIDToken(ctx, creds.RefreshToken); err == nil {
+		t.Errorf("expected an error when the refresh token has been used")
+	}
+
+	if err := loaded.LoadSnapshot(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error when the snapshot does not exist")
+	}
+}
+
+func TestRepository_UserAccount(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	account, err := repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{
+		PIN:                    &domain.PIN{ID: "pin-1", PINNumber: "hash"},
+		CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowPush: true},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, account.NewAuthUser)
+	assert.Equal(t, []string{account.UID}, account.Profile.VerifiedUIDS)
+
+	pin, err := repo.GetPINByProfileID(ctx, account.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "hash", pin.PINNumber)
+
+	comms, err := repo.GetUserCommunicationsSettings(ctx, account.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, comms.AllowPush)
+
+	if _, err := repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{}); err == nil {
+		t.Errorf("expected an error when the phone number is in use")
+	}
+
+	if err := repo.DeleteUserAccount(ctx, account); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.GetUserProfileByID(ctx, account.Profile.ID, false); err == nil {
+		t.Errorf("expected an error when the profile has been removed")
+	}
+	if _, err := repo.GetPINByProfileID(ctx, account.Profile.ID); err == nil {
+		t.Errorf("expected an error when the PIN has been removed")
+	}
+
+	recreated, err := repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, recreated.NewAuthUser)
+}
+
+func TestRepository_UpdateConflicts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	versionedCtx, err := utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// another request changes the profile after it was read
+	if err := repo.UpdateUserRoleIDs(currentVersion(t, repo, profile.ID), profile.ID, []string{"role-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	err = repo.UpdateSecondaryPhoneNumbers(versionedCtx, profile.ID, []string{testSecondPhone})
+	assert.True(t, exceptions.IsConflictError(err))
+
+	err = repo.HardResetSecondaryPhoneNumbers(ctx, profile, []string{testSecondPhone})
+	assert.True(t, exceptions.IsConflictError(err))
+
+	// a write that is derived from a read of the profile can't skip the check
+	err = repo.UpdateSecondaryPhoneNumbers(ctx, profile.ID, []string{testSecondPhone})
+	assert.NotNil(t, err)
+
+	latest, err := repo.GetUserProfileByID(ctx, profile.ID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, latest.SecondaryPhoneNumbers)
+	assert.Equal(t, []string{"role-1"}, latest.Roles)
+
+	// retrying with the latest version succeeds
+	versionedCtx, err = utils.WithExpectedProfileVersion(ctx, latest)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSecondaryPhoneNumbers(versionedCtx, profile.ID, []string{testSecondPhone}); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+}
+
+func TestRepository_ReplacePrimaryContacts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSecondaryPhoneNumbers(currentVersion(t, repo, profile.ID), profile.ID, []string{testSecondPhone}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// a write that is derived from a read of the profile can't skip the check
+	err = repo.ReplacePrimaryPhoneNumber(ctx, profile.ID, testSecondPhone)
+	assert.NotNil(t, err)
+
+	versionedCtx := currentVersion(t, repo, profile.ID)
+	if err := repo.ReplacePrimaryPhoneNumber(versionedCtx, profile.ID, testSecondPhone); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	latest, err := repo.GetUserProfileByID(ctx, profile.ID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, testSecondPhone, *latest.PrimaryPhone)
+	assert.Equal(t, []string{testPhone}, latest.SecondaryPhoneNumbers)
+
+	// the profile has changed since it was read
+	err = repo.ReplacePrimaryPhoneNumber(versionedCtx, profile.ID, testPhone)
+	assert.True(t, exceptions.IsConflictError(err))
+
+	if err := repo.ReplacePrimaryEmailAddress(currentVersion(t, repo, profile.ID), profile.ID, "jane@example.com"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.ReplacePrimaryEmailAddress(currentVersion(t, repo, profile.ID), profile.ID, "john@example.com"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	latest, err = repo.GetUserProfileByID(ctx, profile.ID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "john@example.com", *latest.PrimaryEmailAddress)
+	assert.Equal(t, []string{"jane@example.com"}, latest.SecondaryEmailAddresses)
+}
+
+func TestRepository_IdentifierReservations(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	first, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	second, err := repo.CreateUserProfile(ctx, "+254733445566", "uid-2")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSecondaryPhoneNumbers(currentVersion(t, repo, first.ID), first.ID, []string{testSecondPhone}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdatePrimaryEmailAddress(ctx, first.ID, "Jane@Example.com"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// identifiers are reserved in their normalized form
+	err = repo.UpdateSecondaryPhoneNumbers(currentVersion(t, repo, second.ID), second.ID, []string{"0722334455"})
+	assert.Equal(t, exceptions.CheckPhoneNumberExistError().Error(), err.Error())
+	err = repo.UpdatePrimaryEmailAddress(ctx, second.ID, "jane@example.com")
+	assert.Equal(t, exceptions.CheckEmailExistError().Error(), err.Error())
+	err = repo.UpdateUserName(currentVersion(t, repo, second.ID), second.ID, "  "+*first.UserName)
+	assert.Equal(t, exceptions.UsernameInUseError().Error(), err.Error())
+
+	// a retired phone number can be claimed by another profile
+	latest, err := repo.GetUserProfileByID(ctx, first.ID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.HardResetSecondaryPhoneNumbers(ctx, latest, []string{}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSecondaryPhoneNumbers(currentVersion(t, repo, second.ID), second.ID, []string{"0722334455"}); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+
+	// purging a user frees all of their identifiers
+	if err := repo.PurgeUserByPhoneNumber(ctx, testPhone); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdatePrimaryEmailAddress(ctx, second.ID, "jane@example.com"); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+	if err := repo.UpdateUserName(currentVersion(t, repo, second.ID), second.ID, *first.UserName); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+}
+
+func TestRepository_LinkSocialAccount(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profileIDs := []string{}
+	for i := 0; i < 2; i++ {
+		profile, err := repo.CreateUserProfile(ctx, fmt.Sprintf("+25471122334%d", i), fmt.Sprintf("uid-%d", i))
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		profileIDs = append(profileIDs, profile.ID)
+	}
+	identifier := profileutils.VerifiedIdentifier{
+		UID:           utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, "110248495921238986420"),
+		LoginProvider: profileutils.LoginProviderTypeSocialGoogle,
+	}
+
+	// the account is linked to only one of the profiles that link it at the same time
+	var wg sync.WaitGroup
+	errs := make([]error, len(profileIDs))
+	for i, id := range profileIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			errs[i] = repo.LinkSocialAccount(ctx, id, identifier)
+		}(i, id)
+	}
+	wg.Wait()
+
+	linked := 0
+	for i, err := range errs {
+		if err != nil {
+			assert.Equal(t, exceptions.SocialAccountInUseError().Error(), err.Error())
+			continue
+		}
+		linked++
+		profile, err := repo.GetUserProfileByUID(ctx, identifier.UID, false)
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		assert.Equal(t, profileIDs[i], profile.ID)
+		assert.Contains(t, profile.VerifiedUIDS, identifier.UID)
+	}
+	assert.Equal(t, 1, linked)
+}
+
+func TestRepository_Listings(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profileIDs := []string{}
+	for i := 0; i < 5; i++ {
+		profile, err := repo.CreateUserProfile(ctx, fmt.Sprintf("+25471122334%d", i), fmt.Sprintf("uid-%d", i))
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		profileIDs = append(profileIDs, profile.ID)
+	}
+	role, err := repo.CreateRole(ctx, profileIDs[0], dto.RoleInput{Name: "Employee"})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	withRole := profileIDs[:3]
+	for _, id := range withRole {
+		if err := repo.UpdateUserRoleIDs(currentVersion(t, repo, id), id, []string{role.ID}); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(withRole)))
+
+	roleFilter := &firebasetools.FilterInput{
+		FilterBy: []*firebasetools.FilterParam{
+			{
+				FieldName:           utils.ListingFieldRole,
+				FieldType:           enumutils.FieldTypeString,
+				ComparisonOperation: enumutils.OperationEqual,
+				FieldValue:          role.ID,
+			},
+		},
+	}
+	descending := &firebasetools.SortInput{
+		SortBy: []*firebasetools.SortParam{
+			{FieldName: utils.ListingFieldID, SortOrder: enumutils.SortOrderDesc},
+		},
+	}
+	listPage := func(pagination *firebasetools.PaginationInput) *dto.UserProfileConnection {
+		query, err := utils.NewUserProfileListingQuery(pagination, roleFilter, descending)
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		profiles, err := repo.ListUserProfilesPage(ctx, query)
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		return utils.NewUserProfileConnection(query, profiles)
+	}
+
+	// walk through the profiles with the role, two at a time
+	listed := []string{}
+	pagination := &firebasetools.PaginationInput{First: 2}
+	var page *dto.UserProfileConnection
+	for {
+		page = listPage(pagination)
+		for _, edge := range page.Edges {
+			listed = append(listed, edge.Node.ID)
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		pagination = &firebasetools.PaginationInput{First: 2, After: *page.PageInfo.EndCursor}
+	}
+	assert.Equal(t, withRole, listed)
+
+	// and back from the last one
+	page = listPage(&firebasetools.PaginationInput{Last: 2, Before: *page.PageInfo.EndCursor})
+	assert.Len(t, page.Edges, 2)
+	assert.Equal(t, withRole[0], page.Edges[0].Node.ID)
+	assert.Equal(t, withRole[1], page.Edges[1].Node.ID)
+	assert.False(t, page.PageInfo.HasPreviousPage)
+	assert.True(t, page.PageInfo.HasNextPage)
+
+	roleQuery, err := utils.NewRoleListingQuery(nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	roles, err := repo.ListRolesPage(ctx, roleQuery)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, roles, 1)
+	assert.Equal(t, role.ID, roles[0].ID)
+}
+
+func TestRepository_OutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateUserRoleIDs(currentVersion(t, repo, profile.ID), profile.ID, []string{"role-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdateSuspended(ctx, profile.ID, true); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: profile.ID}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// writes that are rejected do not add events
+	if _, err := repo.CreateUserProfile(ctx, testPhone, "uid-2"); err == nil {
+		t.Errorf("expected an error when the phone number is in use")
+	}
+
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	types := []domain.EventType{}
+	for _, event := range events {
+		assert.Equal(t, profile.ID, event.ProfileID)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []domain.EventType{
+		domain.EventTypeUserCreated,
+		domain.EventTypeRoleAssigned,
+		domain.EventTypeUserSuspended,
+		domain.EventTypePINChanged,
+	}, types)
+
+	// failed events stay pending while published ones are no longer listed
+	if err := repo.RecordOutboxEventFailure(ctx, events[0].ID, "topic not found"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.MarkOutboxEventPublished(ctx, events[1].ID); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	pending, err := repo.ListPendingOutboxEvents(ctx, 2)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, pending, 2)
+	assert.Equal(t, events[0].ID, pending[0].ID)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "topic not found", pending[0].LastError)
+	assert.Equal(t, events[2].ID, pending[1].ID)
+
+	assert.NotNil(t, repo.MarkOutboxEventPublished(ctx, "unknown"))
+}
+
+func TestRepository_PubSubMessages(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	message, err := repo.GetPubSubMessage(ctx, "message-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Nil(t, message)
+
+	now := time.Now()
+	messages := []*domain.PubSubMessage{
+		{ID: "message-1", TopicID: "topic-1", Status: domain.PubSubMessageStatusProcessed, Created: now},
+		{ID: "message-2", TopicID: "topic-2", Status: domain.PubSubMessageStatusDeadLettered, Created: now.Add(time.Second)},
+		{ID: "message-3", TopicID: "topic-1", Status: domain.PubSubMessageStatusFailed, Created: now.Add(2 * time.Second)},
+	}
+	for _, message := range messages {
+		if err := repo.SavePubSubMessage(ctx, message); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+
+	message, err = repo.GetPubSubMessage(ctx, "message-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.PubSubMessageStatusProcessed, message.Status)
+
+	failed, err := repo.ListFailedPubSubMessages(ctx, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, failed, 2)
+	assert.Equal(t, "message-2", failed[0].ID)
+
+	topicID := "topic-1"
+	failed, err = repo.ListFailedPubSubMessages(ctx, &topicID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "message-3", failed[0].ID)
+
+	// a retried message replaces its record
+	messages[2].Status = domain.PubSubMessageStatusProcessed
+	if err := repo.SavePubSubMessage(ctx, messages[2]); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	failed, err = repo.ListFailedPubSubMessages(ctx, &topicID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, failed)
+}
+
+func TestRepository_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	now := time.Now()
+	endpoints := []*domain.WebhookEndpoint{
+		{
+			ID:         "endpoint-1",
+			URL:        "https://partner.example.com/hooks",
+			Secret:     "a-very-long-secret",
+			EventTypes: []domain.EventType{domain.EventTypeUserCreated, domain.EventTypeRoleAssigned},
+			Active:     true,
+			Created:    now,
+		},
+		{
+			ID:         "endpoint-2",
+			URL:        "https://other.example.com/hooks",
+			Secret:     "another-long-secret",
+			EventTypes: []domain.EventType{domain.EventTypeUserCreated},
+			Active:     false,
+			Created:    now.Add(time.Second),
+		},
+	}
+	for _, endpoint := range endpoints {
+		if err := repo.CreateWebhookEndpoint(ctx, endpoint); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+
+	listed, err := repo.ListWebhookEndpoints(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, listed, 2)
+
+	_, err = repo.GetWebhookEndpoint(ctx, "missing")
+	assert.NotNil(t, err)
+
+	// only the active endpoints that subscribe to an event get a delivery, once
+	event := &domain.OutboxEvent{ID: "event-1", Type: domain.EventTypeUserCreated, Payload: `{"id":"event-1"}`}
+	for i := 0; i < 2; i++ {
+		if err := repo.EnqueueWebhookDeliveries(ctx, event); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	other := &domain.OutboxEvent{ID: "event-2", Type: domain.EventTypePINChanged, Payload: `{"id":"event-2"}`}
+	if err := repo.EnqueueWebhookDeliveries(ctx, other); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	due, err := repo.ListDueWebhookDeliveries(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, due, 1)
+	assert.Equal(t, "endpoint-1-event-1", due[0].ID)
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, due[0].Status)
+
+	delivery := due[0]
+	delivery.Attempts = 1
+	delivery.NextAttempt = time.Now().Add(time.Hour)
+	if err := repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	due, err = repo.ListDueWebhookDeliveries(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, due)
+
+	stored, err := repo.GetWebhookDelivery(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, stored.Attempts)
+
+	failed := domain.WebhookDeliveryStatusFailed
+	deliveries, err := repo.ListWebhookDeliveries(ctx, "endpoint-1", &failed)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, deliveries)
+	deliveries, err = repo.ListWebhookDeliveries(ctx, "endpoint-1", nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, deliveries, 1)
+
+	// the delivery log goes with the endpoint
+	if err := repo.DeleteWebhookEndpoint(ctx, "endpoint-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	_, err = repo.GetWebhookDelivery(ctx, delivery.ID)
+	assert.NotNil(t, err)
+	assert.NotNil(t, repo.DeleteWebhookEndpoint(ctx, "endpoint-1"))
+}
+
+func TestRepository_Sessions(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	profile, err := repo.CreateUserProfile(ctx, testPhone, "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	creds, err := repo.GenerateAuthCredentials(ctx, testPhone, profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	now := time.Now()
+	first := &domain.Session{ID: "session-1", ProfileID: profile.ID, UID: creds.UID, RefreshTokenHash: "hash-1", LastSeen: now.Add(-time.Hour)}
+	second := &domain.Session{ID: "session-2", ProfileID: profile.ID, UID: creds.UID, RefreshTokenHash: "hash-2", LastSeen: now}
+	for _, session := range []*domain.Session{first, second} {
+		if err := repo.SaveSession(ctx, session); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+
+	// saving a session again replaces it
+	first.RefreshTokenHash = "hash-3"
+	if err := repo.SaveSession(ctx, first); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	found, err := repo.GetSessionByRefreshTokenHash(ctx, "hash-3")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "session-1", found.ID)
+	missing, err := repo.GetSessionByRefreshTokenHash(ctx, "hash-1")
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+
+	sessions, err := repo.ListSessions(ctx, profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "session-2", sessions[0].ID)
+
+	if err := repo.RevokeSessions(ctx, profile.ID, []string{"session-2"}, now); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	later := now.Add(time.Minute)
+	if err := repo.RevokeSessions(ctx, profile.ID, nil, later); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	sessions, err = repo.ListSessions(ctx, profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, later, *sessions[1].Revoked)
+	assert.Equal(t, now, *sessions[0].Revoked)
+
+	if err := repo.RevokeRefreshTokens(ctx, creds.UID); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.ExchangeRefreshTokenForIDToken(ctx, creds.RefreshToken); err == nil {
+		t.Errorf("expected an error when the refresh token has been revoked")
+	}
+}
+
+func TestRepository_LoginEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	now := time.Now()
+	events := []*domain.LoginEvent{
+		{ID: "event-1", ProfileID: "profile-1", Outcome: domain.LoginOutcomeSucceeded, Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "event-2", ProfileID: "profile-1", Outcome: domain.LoginOutcomeFailed, Reason: domain.LoginFailureReasonPINMismatch, Timestamp: now.Add(-time.Hour)},
+		{ID: "event-3", ProfileID: "profile-2", Outcome: domain.LoginOutcomeFailed, Reason: domain.LoginFailureReasonSuspended, Timestamp: now},
+	}
+	for _, event := range events {
+		if err := repo.RecordLoginEvent(ctx, event); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+
+	listed, err := repo.ListLoginEvents(ctx, &domain.LoginEventFilter{})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, listed, 3)
+	assert.Equal(t, "event-3", listed[0].ID)
+
+	failed := domain.LoginOutcomeFailed
+	from := now.Add(-90 * time.Minute)
+	listed, err = repo.ListLoginEvents(ctx, &domain.LoginEventFilter{From: &from, To: &now, Outcome: &failed})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, listed, 1)
+	assert.Equal(t, "event-2", listed[0].ID)
+
+	profileID := "profile-1"
+	listed, err = repo.ListLoginEvents(ctx, &domain.LoginEventFilter{ProfileID: &profileID, Limit: 1})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, listed, 1)
+	assert.Equal(t, "event-2", listed[0].ID)
+}
+
+func TestRepository_LoginAlerts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	now := time.Now()
+	alert := &domain.LoginAlert{
+		ID:        "alert-1",
+		ProfileID: "profile-1",
+		SessionID: "session-1",
+		TokenHash: "token-hash-1",
+		Reasons:   []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice},
+		Created:   now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	if err := repo.SaveLoginAlert(ctx, alert); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// the saved alert is a copy
+	alert.Reasons[0] = domain.LoginAlertReasonNewIPRange
+	found, err := repo.GetLoginAlertByTokenHash(ctx, "token-hash-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "alert-1", found.ID)
+	assert.Equal(t, []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice}, found.Reasons)
+	assert.Nil(t, found.Reported)
+
+	found.Reported = &now
+	if err := repo.SaveLoginAlert(ctx, found); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	found, err = repo.GetLoginAlertByTokenHash(ctx, "token-hash-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.NotNil(t, found.Reported)
+
+	found, err = repo.GetLoginAlertByTokenHash(ctx, "unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+}
+
+func TestRepository_RequirePINReset(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if err := repo.RequirePINReset(ctx, "profile-1"); err == nil {
+		t.Errorf("expected an error when the PIN does not exist")
+	}
+
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.RequirePINReset(ctx, "profile-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	stored, err := repo.GetPINByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, stored.ResetRequired)
+}
+
+func TestRepository_TwoFactor(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	found, err := repo.GetTOTPEnrolment(ctx, "profile-1")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	enrolment := &domain.TOTPEnrolment{
+		ID:                 "enrolment-1",
+		ProfileID:          "profile-1",
+		EncryptedSecret:    "encrypted-secret",
+		EncryptedDataKey:   "encrypted-data-key",
+		RecoveryCodeHashes: []string{"hash-1", "hash-2"},
+		Created:            time.Now(),
+	}
+	if err := repo.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// the saved enrolment is a copy and is replaced by a new enrolment of the same profile
+	enrolment.RecoveryCodeHashes[0] = "changed"
+	found, err = repo.GetTOTPEnrolment(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, []string{"hash-1", "hash-2"}, found.RecoveryCodeHashes)
+	assert.Equal(t, "encrypted-secret", found.EncryptedSecret)
+	assert.Equal(t, "encrypted-data-key", found.EncryptedDataKey)
+
+	found.ID = "enrolment-2"
+	if err := repo.SaveTOTPEnrolment(ctx, found); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	found, err = repo.GetTOTPEnrolment(ctx, "profile-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "enrolment-2", found.ID)
+
+	assert.Nil(t, repo.DeleteTOTPEnrolment(ctx, "profile-1"))
+	found, err = repo.GetTOTPEnrolment(ctx, "profile-1")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	policy, err := repo.GetTwoFactorPolicy(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, policy.RequiredScopes)
+
+	err = repo.SaveTwoFactorPolicy(ctx, &domain.TwoFactorPolicy{RequiredScopes: []string{"role.assign"}, UpdatedBy: "admin"})
+	assert.Nil(t, err)
+	policy, err = repo.GetTwoFactorPolicy(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TwoFactorPolicyID, policy.ID)
+	assert.Equal(t, []string{"role.assign"}, policy.RequiredScopes)
+}
+
+func TestRepository_EmailUserAccount(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	email := "wanjiku@example.com"
+
+	account, err := repo.CreateEmailUserAccount(ctx, email, &domain.UserAccount{
+		Password:               &domain.Password{ID: "password-1", EmailAddress: email, PasswordHash: "hash"},
+		CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowEmail: true},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, account.NewAuthUser)
+	assert.Equal(t, email, *account.Profile.PrimaryEmailAddress)
+	assert.Nil(t, account.Profile.PrimaryPhone)
+	assert.Equal(t, domain.LoginProviderTypeEmail, account.Profile.VerifiedIdentifiers[0].LoginProvider)
+
+	password, err := repo.GetPasswordByEmailAddress(ctx, email)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, account.Profile.ID, password.ProfileID)
+
+	if _, err := repo.CreateEmailUserAccount(ctx, email, &domain.UserAccount{}); err == nil {
+		t.Errorf("expected an error when the email address is in use")
+	}
+
+	// the credentials of an email login are issued to the auth user of the email address
+	creds, err := repo.GenerateAuthCredentials(ctx, email, account.Profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, account.UID, creds.UID)
+
+	password.FailedAttempts = 2
+	if err := repo.SavePassword(ctx, password); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	password, err = repo.GetPasswordByProfileID(ctx, account.Profile.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, password.FailedAttempts)
+
+	if err := repo.DeleteUserAccount(ctx, account); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	password, err = repo.GetPasswordByEmailAddress(ctx, email)
+	assert.Nil(t, err)
+	assert.Nil(t, password)
+}
+
+func TestRepository_PasswordAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	if _, err := repo.ReservePasswordAttempt(ctx, "profile-1", time.Now()); err == nil {
+		t.Errorf("expected an error when the password does not exist")
+	}
+
+	password := &domain.Password{ID: "password-1", ProfileID: "profile-1", PasswordHash: "hash"}
+	if err := repo.SavePassword(ctx, password); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// a burst of concurrent attempts only gets the attempts that are not throttled: the first
+	// attempt and the one after it, which is not delayed either
+	now := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.ReservePasswordAttempt(ctx, "profile-1", now); err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, reserved)
+
+	// the attempts that are left are reserved once they are no longer throttled, and the password
+	// is locked by the last of them
+	attemptedAt := now
+	for i := reserved; i < utils.MaxPINAttempts; i++ {
+		attemptedAt = attemptedAt.Add(time.Minute)
+		if _, err := repo.ReservePasswordAttempt(ctx, "profile-1", attemptedAt); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	_, err := repo.ReservePasswordAttempt(ctx, "profile-1", attemptedAt.Add(time.Minute))
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+
+	stored, err := repo.GetPasswordByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, utils.MaxPINAttempts, stored.FailedAttempts)
+	assert.True(t, stored.IsLocked(attemptedAt))
+
+	// a rehash keeps the failed attempts, and is dropped when the password has changed since
+	rehashed := &domain.Password{ProfileID: "profile-1", PasswordHash: "rehashed", Salt: "salt"}
+	if err := repo.UpdatePasswordHash(ctx, rehashed, "hash"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.UpdatePasswordHash(ctx, &domain.Password{ProfileID: "profile-1", PasswordHash: "stale"}, "hash"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	stored, err = repo.GetPasswordByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "rehashed", stored.PasswordHash)
+	assert.Equal(t, utils.MaxPINAttempts, stored.FailedAttempts)
+
+	if err := repo.ClearPasswordAttempts(ctx, "profile-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	stored, err = repo.GetPasswordByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 0, stored.FailedAttempts)
+	assert.False(t, stored.IsLocked(attemptedAt))
+
+	if err := repo.RequirePasswordReset(ctx, "profile-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	stored, err = repo.GetPasswordByProfileID(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, stored.ResetRequired)
+	assert.NotNil(t, repo.RequirePasswordReset(ctx, "profile-2"))
+}
+
+func TestRepository_SocialLogin(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	email := "wanjiku@example.com"
+
+	account, err := repo.CreateEmailUserAccount(ctx, email, &domain.UserAccount{
+		CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowEmail: true},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// the credentials of a social login are issued to the auth user of its UID, which links it
+	uid := utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, "110248495921238986420")
+	creds, err := repo.GenerateAuthCredentials(ctx, uid, account.Profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, uid, creds.UID)
+
+	profile, err := repo.GetUserProfileByUID(ctx, uid, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, account.Profile.ID, profile.ID)
+	assert.True(t, utils.CheckIdentifierExists(profile, uid))
+	for _, identifier := range profile.VerifiedIdentifiers {
+		if identifier.UID == uid {
+			assert.Equal(t, profileutils.LoginProviderTypeSocialGoogle, identifier.LoginProvider)
+		}
+	}
+
+	// the UID can no longer be used to log in once it is removed
+	if err := repo.RemoveVerifiedIdentifier(ctx, profile.ID, uid); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	_, err = repo.GetUserProfileByUID(ctx, uid, false)
+	assert.True(t, exceptions.IsProfileNotFoundError(err))
+
+	profile, err = repo.GetUserProfileByUID(ctx, account.UID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.False(t, utils.CheckIdentifierExists(profile, uid))
+	assert.True(t, utils.CheckIdentifierExists(profile, account.UID))
+}
+
+func TestRepository_UpgradeAnonymousUser(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	// every anonymous login gets its own auth user
+	anonymous, err := repo.GenerateAuthCredentialsForAnonymousUser(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	other, err := repo.GenerateAuthCredentialsForAnonymousUser(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, anonymous.IsAnonymous)
+	assert.True(t, utils.IsAnonymousUID(anonymous.UID))
+	assert.NotEqual(t, anonymous.UID, other.UID)
+
+	// the phone number of an account is added to the anonymous user it is created for
+	account, err := repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{
+		AnonymousUID: anonymous.UID,
+		PIN:          &domain.PIN{ID: "pin-1"},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, anonymous.UID, account.UID)
+	assert.False(t, account.NewAuthUser)
+
+	creds, err := repo.GenerateAuthCredentials(ctx, testPhone, account.Profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, anonymous.UID, creds.UID)
+	profile, err := repo.GetUserProfileByUID(ctx, anonymous.UID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, account.Profile.ID, profile.ID)
+
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	types := []domain.EventType{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []domain.EventType{
+		domain.EventTypeUserCreated,
+		domain.EventTypePINChanged,
+		domain.EventTypeUserUpgraded,
+	}, types)
+
+	// an anonymous user is only upgraded once, and not with a phone number of another user
+	_, err = repo.CreateUserAccount(ctx, "+254722000111", &domain.UserAccount{AnonymousUID: anonymous.UID})
+	assert.NotNil(t, err)
+	_, err = repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{AnonymousUID: other.UID})
+	assert.NotNil(t, err)
+	_, err = repo.CreateUserAccount(ctx, "+254722000111", &domain.UserAccount{AnonymousUID: "uid-1"})
+	assert.NotNil(t, err)
+}
+
+func TestRepository_MergeUserProfiles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	survivor, err := repo.CreateUserAccount(ctx, testPhone, &domain.UserAccount{
+		PIN: &domain.PIN{ID: "pin-1", PINNumber: "survivor"},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	duplicate, err := repo.CreateUserAccount(ctx, testSecondPhone, &domain.UserAccount{
+		PIN:                    &domain.PIN{ID: "pin-2", PINNumber: "duplicate"},
+		CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowPush: true},
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.AddUserAsExperimentParticipant(ctx, duplicate.Profile); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	result, err := utils.MergeUserProfiles(survivor.Profile, duplicate.Profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	merge := &domain.ProfileMerge{
+		ID:              "merge-1",
+		SurvivorID:      survivor.Profile.ID,
+		MergedProfileID: duplicate.Profile.ID,
+		Survivor:        survivor.Profile,
+		MergedProfile:   duplicate.Profile,
+		Result:          result,
+		MergedBy:        "admin",
+		Created:         time.Now(),
+	}
+
+	// a merge that is based on an outdated profile is rejected
+	stale := *merge
+	stale.MergedProfile = &profileutils.UserProfile{ID: duplicate.Profile.ID}
+	err = repo.MergeUserProfiles(ctx, &stale)
+	assert.True(t, exceptions.IsConflictError(err))
+
+	if err := repo.MergeUserProfiles(ctx, merge); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// the duplicate is removed and its logins and phone number belong to the survivor
+	_, err = repo.GetUserProfileByID(ctx, duplicate.Profile.ID, false)
+	assert.True(t, exceptions.IsProfileNotFoundError(err))
+	profile, err := repo.GetUserProfileByUID(ctx, duplicate.UID, false)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, survivor.Profile.ID, profile.ID)
+	assert.Equal(t, []string{testSecondPhone}, profile.SecondaryPhoneNumbers)
+	_, err = repo.CreateUserProfile(ctx, testSecondPhone, "uid-3")
+	assert.NotNil(t, err)
+
+	// the survivor keeps its own PIN and takes over the rest
+	pin, err := repo.GetPINByProfileID(ctx, survivor.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "survivor", pin.PINNumber)
+	_, err = repo.GetPINByProfileID(ctx, duplicate.Profile.ID)
+	assert.NotNil(t, err)
+	comms, err := repo.GetUserCommunicationsSettings(ctx, survivor.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, comms.AllowPush)
+	participant, err := repo.CheckIfExperimentParticipant(ctx, survivor.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, participant)
+
+	tombstone, err := repo.GetProfileTombstone(ctx, duplicate.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, survivor.Profile.ID, tombstone.MergedInto)
+	assert.Equal(t, "merge-1", tombstone.MergeID)
+	tombstone, err = repo.GetProfileTombstone(ctx, survivor.Profile.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Nil(t, tombstone)
+
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	last := events[len(events)-1]
+	assert.Equal(t, domain.EventTypeUserMerged, last.Type)
+	assert.Equal(t, survivor.Profile.ID, last.ProfileID)
+
+	// a profile can only be merged once
+	err = repo.MergeUserProfiles(ctx, merge)
+	assert.True(t, exceptions.IsProfileNotFoundError(err))
+}
+
+func TestRepository_SigningKeys(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	repo, err := memory.NewMemoryRepositoryFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	now := time.Now()
+
+	for _, key := range []*domain.SigningKey{
+		{ID: "expired", Created: now.Add(-48 * time.Hour), NotAfter: now.Add(-time.Hour)},
+		{ID: "old", Created: now.Add(-24 * time.Hour), NotAfter: now.Add(time.Hour)},
+		{ID: "new", Created: now, NotAfter: now.Add(24 * time.Hour)},
+	} {
+		if err := repo.SaveSigningKey(ctx, key); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	assert.NotNil(t, repo.SaveSigningKey(ctx, &domain.SigningKey{ID: "new"}))
+
+	keys, err := repo.ListSigningKeys(ctx, now)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "new", keys[0].ID)
+	assert.Equal(t, "old", keys[1].ID)
+
+	// the signing keys are never written to the snapshot
+	if err := repo.SaveSnapshot(path); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	loaded, err := memory.NewMemoryRepositoryFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	keys, err = loaded.ListSigningKeys(ctx, now)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, keys)
+}
+
+// currentVersion returns a context that expects the profile that matches the id to be as it is
+// stored now
+func currentVersion(t *testing.T, repo *memory.Repository, id string) context.Context {
+	profile, err := repo.GetUserProfileByID(context.Background(), id, true)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	ctx, err := utils.WithExpectedProfileVersion(context.Background(), profile)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	return ctx
+}
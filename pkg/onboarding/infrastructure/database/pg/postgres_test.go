@@ -0,0 +1,1417 @@
+package pg_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	extMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/fb/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/pg"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var fakeFireBaseClientExt extMock.FirebaseClientExtension
+
+func newTestRepository(t *testing.T) (*pg.Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return pg.NewPostgresRepository(db, &fakeFireBaseClientExt), mock
+}
+
+func profileRows(t *testing.T, profiles ...profileutils.UserProfile) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"data"})
+	for _, profile := range profiles {
+		data, err := json.Marshal(profile)
+		if err != nil {
+			t.Fatalf("unable to marshal profile: %v", err)
+		}
+		rows.AddRow(data)
+	}
+	return rows
+}
+
+func versionedProfileRows(t *testing.T, version int64, profile profileutils.UserProfile) *sqlmock.Rows {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatalf("unable to marshal profile: %v", err)
+	}
+	return sqlmock.NewRows([]string{"data", "version"}).AddRow(data, version)
+}
+
+func TestRepository_Migrate(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS user_profiles").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.Migrate(ctx); err != nil {
+		t.Errorf("error not expected got %v", err)
+	}
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS user_profiles").WillReturnError(fmt.Errorf("permission denied"))
+	if err := repo.Migrate(ctx); err == nil {
+		t.Errorf("error expected got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_GetUserProfileByUID(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT data FROM user_profiles WHERE $1 = ANY(verified_uids)")
+
+	type args struct {
+		ctx       context.Context
+		uid       string
+		suspended bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid:profile_found",
+			args: args{
+				ctx: ctx,
+				uid: "uid-1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid:profile_not_found",
+			args: args{
+				ctx: ctx,
+				uid: "uid-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:suspended_profile",
+			args: args{
+				ctx: ctx,
+				uid: "uid-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid:suspended_profile_allowed",
+			args: args{
+				ctx:       ctx,
+				uid:       "uid-1",
+				suspended: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid:database_error",
+			args: args{
+				ctx: ctx,
+				uid: "uid-1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "valid:profile_found" {
+				mock.ExpectQuery(query).WithArgs(tt.args.uid).WillReturnRows(
+					profileRows(t, profileutils.UserProfile{ID: "1", VerifiedUIDS: []string{"uid-1"}}),
+				)
+			}
+			if tt.name == "invalid:profile_not_found" {
+				mock.ExpectQuery(query).WithArgs(tt.args.uid).WillReturnRows(profileRows(t))
+			}
+			if tt.name == "invalid:suspended_profile" || tt.name == "valid:suspended_profile_allowed" {
+				mock.ExpectQuery(query).WithArgs(tt.args.uid).WillReturnRows(
+					profileRows(t, profileutils.UserProfile{ID: "1", Suspended: true}),
+				)
+			}
+			if tt.name == "invalid:database_error" {
+				mock.ExpectQuery(query).WithArgs(tt.args.uid).WillReturnError(fmt.Errorf("connection refused"))
+			}
+
+			profile, err := repo.GetUserProfileByUID(tt.args.ctx, tt.args.uid, tt.args.suspended)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("error expected got %v", err)
+					return
+				}
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("error not expected got %v", err)
+					return
+				}
+				assert.Equal(t, "1", profile.ID)
+			}
+		})
+	}
+}
+
+func TestRepository_CheckIfPhoneNumberExists(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE primary_phone = $1 OR $1 = ANY(secondary_phone_numbers)")
+
+	mock.ExpectQuery(query).WithArgs("+254711223344").WillReturnRows(
+		sqlmock.NewRows([]string{"exists"}).AddRow(true),
+	)
+	exists, err := repo.CheckIfPhoneNumberExists(ctx, "+254711223344")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	mock.ExpectQuery(query).WithArgs("+254711223355").WillReturnRows(
+		sqlmock.NewRows([]string{"exists"}).AddRow(false),
+	)
+	exists, err = repo.CheckIfPhoneNumberExists(ctx, "+254711223355")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	mock.ExpectQuery(query).WillReturnError(fmt.Errorf("connection refused"))
+	_, err = repo.CheckIfPhoneNumberExists(ctx, "+254711223355")
+	assert.NotNil(t, err)
+}
+
+func TestRepository_CreateUserProfile(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	phoneQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE primary_phone = $1")
+	usernameQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE user_name = $1")
+
+	// phone number already in use
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	_, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	assert.NotNil(t, err)
+	assert.Equal(t, exceptions.CheckPhoneNumberExistError().Error(), err.Error())
+
+	// successful creation
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO user_profiles").WillReturnResult(sqlmock.NewResult(1, 1))
+	// the phone number and the username are reserved with the profile
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "user.created", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT data FROM user_profiles WHERE id = $1")).WillReturnRows(
+		profileRows(t, profileutils.UserProfile{ID: "1", VerifiedUIDS: []string{"uid-1"}}),
+	)
+	profile, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	assert.Nil(t, err)
+	assert.NotNil(t, profile)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_CreateUserAccount(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	usernameQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE user_name = $1")
+	phoneQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE primary_phone = $1 OR $1 = ANY(secondary_phone_numbers)")
+
+	deleted := []string{}
+	fakeFireBaseClientExt.GetUserByPhoneNumberFn = func(ctx context.Context, phone string) (*auth.UserRecord, error) {
+		return nil, fmt.Errorf("user not found")
+	}
+	fakeFireBaseClientExt.CreateUserFn = func(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
+		return &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "uid-1"}}, nil
+	}
+	fakeFireBaseClientExt.DeleteUserFn = func(ctx context.Context, uid string) error {
+		deleted = append(deleted, uid)
+		return nil
+	}
+	newAccount := func() *domain.UserAccount {
+		return &domain.UserAccount{
+			PIN:                    &domain.PIN{ID: "pin-1"},
+			CommunicationsSettings: &profileutils.UserCommunicationsSetting{AllowPush: true},
+		}
+	}
+
+	// phone number already in use, the transaction is rolled back and the new firebase user removed
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+	_, err := repo.CreateUserAccount(ctx, "+254711223344", newAccount())
+	assert.NotNil(t, err)
+	assert.Equal(t, exceptions.CheckPhoneNumberExistError().Error(), err.Error())
+	assert.Equal(t, []string{"uid-1"}, deleted)
+
+	// failing to save the PIN rolls back the profile
+	deleted = []string{}
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO user_profiles").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO pins").WillReturnError(fmt.Errorf("connection reset"))
+	mock.ExpectRollback()
+	_, err = repo.CreateUserAccount(ctx, "+254711223344", newAccount())
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"uid-1"}, deleted)
+
+	// successful creation
+	deleted = []string{}
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO user_profiles").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	// the events of the new account are written in the same transaction
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "user.created", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO pins").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "pin.changed", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO communications_settings").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	account, err := repo.CreateUserAccount(ctx, "+254711223344", newAccount())
+	assert.Nil(t, err)
+	assert.Empty(t, deleted)
+	assert.True(t, account.NewAuthUser)
+	assert.Equal(t, account.Profile.ID, account.PIN.ProfileID)
+	assert.Equal(t, account.Profile.ID, account.CommunicationsSettings.ProfileID)
+
+	// removing the account removes the new firebase user and the events that were not published
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_profiles WHERE id = $1")).
+		WithArgs(account.Profile.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM outbox_events WHERE profile_id = $1 AND published_at IS NULL")).
+		WithArgs(account.Profile.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	err = repo.DeleteUserAccount(ctx, account)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"uid-1"}, deleted)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_CreateUserAccount_AnonymousUser(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	usernameQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE user_name = $1")
+	phoneQuery := regexp.QuoteMeta("SELECT 1 FROM user_profiles WHERE primary_phone = $1 OR $1 = ANY(secondary_phone_numbers)")
+	upgradedQuery := regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM user_profiles WHERE $1 = ANY(verified_uids))")
+	anonymousUID := utils.AnonymousUID()
+
+	updated := []*auth.UserToUpdate{}
+	fakeFireBaseClientExt.GetUserByPhoneNumberFn = func(ctx context.Context, phone string) (*auth.UserRecord, error) {
+		return nil, fmt.Errorf("user not found")
+	}
+	fakeFireBaseClientExt.UpdateUserFn = func(
+		ctx context.Context,
+		uid string,
+		user *auth.UserToUpdate,
+	) (*auth.UserRecord, error) {
+		updated = append(updated, user)
+		return &auth.UserRecord{UserInfo: &auth.UserInfo{UID: uid}}, nil
+	}
+	fakeFireBaseClientExt.CreateUserFn = func(ctx context.Context, user *auth.UserToCreate) (*auth.UserRecord, error) {
+		t.Errorf("a firebase user is not expected to be created")
+		return nil, fmt.Errorf("unexpected call")
+	}
+
+	// an anonymous user that was already upgraded is refused, and its phone number removed again
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(upgradedQuery).
+		WithArgs(anonymousUID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+	_, err := repo.CreateUserAccount(ctx, "+254711223344", &domain.UserAccount{AnonymousUID: anonymousUID})
+	assert.Equal(t, exceptions.NotAnonymousUserError().Error(), err.Error())
+	assert.Len(t, updated, 2)
+
+	// the account keeps the UID of the anonymous user and publishes its upgrade
+	updated = []*auth.UserToUpdate{}
+	mock.ExpectQuery(usernameQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(phoneQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(upgradedQuery).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO user_profiles").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "user.created", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "user.upgraded", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	account, err := repo.CreateUserAccount(ctx, "+254711223344", &domain.UserAccount{AnonymousUID: anonymousUID})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, anonymousUID, account.UID)
+	assert.False(t, account.NewAuthUser)
+	assert.Equal(t, []string{anonymousUID}, account.Profile.VerifiedUIDS)
+	assert.Len(t, updated, 1)
+
+	// only anonymous users are upgraded
+	_, err = repo.CreateUserAccount(ctx, "+254711223344", &domain.UserAccount{AnonymousUID: "uid-1"})
+	assert.Equal(t, exceptions.NotAnonymousUserError().Error(), err.Error())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_UpdateSecondaryPhoneNumbers(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT data, version FROM user_profiles WHERE id = $1")
+	update := regexp.QuoteMeta("WHERE id = $1 AND version = $13")
+	phone := "+254711223344"
+	profile := profileutils.UserProfile{ID: "1", PrimaryPhone: &phone}
+
+	// the caller read the profile before it was changed
+	versionedCtx, err := utils.WithExpectedProfileVersion(ctx, &profile)
+	assert.Nil(t, err)
+
+	// the write is made against the version that was read and reserves the new phone number
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, profile))
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs("1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").
+		WithArgs(utils.IdentifierReservationID(domain.IdentifierKindPhone, "+254722334455"), "PHONE", "+254722334455", "1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "contact.changed", "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err = repo.UpdateSecondaryPhoneNumbers(versionedCtx, "1", []string{"+254722334455"})
+	assert.Nil(t, err)
+
+	// the profile was written to by another request after it was read
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, profile))
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = repo.UpdateSecondaryPhoneNumbers(versionedCtx, "1", []string{"+254722334455"})
+	assert.NotNil(t, err)
+	assert.True(t, exceptions.IsConflictError(err))
+
+	// the phone number has been reserved by another profile in the meantime
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, profile))
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO identifier_reservations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = repo.UpdateSecondaryPhoneNumbers(versionedCtx, "1", []string{"+254722334455"})
+	assert.NotNil(t, err)
+	assert.Equal(t, exceptions.CheckPhoneNumberExistError().Error(), err.Error())
+
+	// the profile was changed after the caller read it
+	changed := profile
+	changed.SecondaryPhoneNumbers = []string{"+254733445566"}
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 4, changed))
+	err = repo.UpdateSecondaryPhoneNumbers(versionedCtx, "1", []string{"+254722334455"})
+	assert.NotNil(t, err)
+	assert.True(t, exceptions.IsConflictError(err))
+
+	// the caller did not say which version of the profile the write is based on
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, profile))
+	err = repo.UpdateSecondaryPhoneNumbers(ctx, "1", []string{"+254722334455"})
+	assert.NotNil(t, err)
+	assert.False(t, exceptions.IsConflictError(err))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_HardResetSecondaryPhoneNumbers(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	phone := "+254711223344"
+	profile := profileutils.UserProfile{ID: "1", PrimaryPhone: &phone, SecondaryPhoneNumbers: []string{"0722334455"}}
+
+	// retiring a secondary phone number releases its reservation
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT data, version FROM user_profiles WHERE id = $1")).
+		WithArgs("1").
+		WillReturnRows(versionedProfileRows(t, 2, profile))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE user_profiles SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM identifier_reservations WHERE id = $1 AND profile_id = $2")).
+		WithArgs(utils.IdentifierReservationID(domain.IdentifierKindPhone, "+254722334455"), "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err := repo.HardResetSecondaryPhoneNumbers(ctx, &profile, []string{})
+	assert.Nil(t, err)
+	assert.Empty(t, profile.SecondaryPhoneNumbers)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_UpdatePIN(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT data FROM pins WHERE profile_id = $1")
+
+	// no pin for the profile
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"data"}))
+	_, err := repo.UpdatePIN(ctx, "123", &domain.PIN{ProfileID: "123"})
+	assert.NotNil(t, err)
+
+	// updating a temporary PIN clears the OTP flag
+	data, _ := json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123", IsOTP: true})
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "pin.changed", "123", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	pin := &domain.PIN{ID: "pin-1", ProfileID: "123", IsOTP: true}
+	ok, err := repo.UpdatePIN(ctx, "123", pin)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.False(t, pin.IsOTP)
+
+	// the PIN is not changed when its event can not be written
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE pins SET data").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnError(fmt.Errorf("connection reset"))
+	mock.ExpectRollback()
+	_, err = repo.UpdatePIN(ctx, "123", &domain.PIN{ID: "pin-1", ProfileID: "123"})
+	assert.NotNil(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_SaveTempPIN(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM pins WHERE profile_id = $1")).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pins").WithArgs("pin-2", "123", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "pin.changed", "123", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.SaveTempPIN(ctx, &domain.PIN{ID: "pin-2", ProfileID: "123", IsOTP: true}))
+
+	// the previous PIN is kept when the temporary PIN can not be saved
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM pins").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pins").WillReturnError(fmt.Errorf("connection reset"))
+	mock.ExpectRollback()
+	assert.NotNil(t, repo.SaveTempPIN(ctx, &domain.PIN{ID: "pin-3", ProfileID: "123", IsOTP: true}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_RecordFailedPINAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT id, data FROM pins WHERE profile_id = $1 FOR UPDATE")
+
+	// no pin for the profile
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}))
+	mock.ExpectRollback()
+	_, err := repo.RecordFailedPINAttempt(ctx, "123", time.Now())
+	assert.NotNil(t, err)
+
+	// a failed attempt that does not lock the PIN adds no events
+	data, _ := json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123"})
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("pin-1", data))
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	pin, err := repo.RecordFailedPINAttempt(ctx, "123", time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pin.FailedAttempts)
+
+	// the last allowed failed attempt locks the PIN
+	data, _ = json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123", FailedAttempts: utils.MaxPINAttempts - 1})
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("pin-1", data))
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "pin.locked", "123", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	pin, err = repo.RecordFailedPINAttempt(ctx, "123", time.Now())
+	assert.Nil(t, err)
+	assert.True(t, pin.IsLocked(time.Now()))
+
+	// clearing the attempts of a locked PIN unlocks it
+	lockedUntil := time.Now().Add(utils.PINLockoutDuration)
+	data, _ = json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123", FailedAttempts: utils.MaxPINAttempts, LockedUntil: &lockedUntil})
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("pin-1", data))
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "pin.unlocked", "123", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.ClearPINAttempts(ctx, "123"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_RequirePINReset(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT id, data FROM pins WHERE profile_id = $1 FOR UPDATE")
+
+	data, _ := json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123"})
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("pin-1", data))
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.RequirePINReset(ctx, "123"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_UpdatePINHash(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT id, data FROM pins WHERE profile_id = $1 FOR UPDATE")
+
+	// rehashing a PIN changes no lockout state so no events are added
+	data, _ := json.Marshal(domain.PIN{ID: "pin-1", ProfileID: "123", PINNumber: "legacy"})
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("pin-1", data))
+	mock.ExpectExec("UPDATE pins SET data").WithArgs("pin-1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	rehashed := &domain.PIN{ProfileID: "123", PINNumber: "rehashed", Scheme: &domain.PINHashScheme{Algorithm: "bcrypt", Cost: 10}}
+	assert.Nil(t, repo.UpdatePINHash(ctx, rehashed, "legacy"))
+
+	// no pin for the profile
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}))
+	mock.ExpectRollback()
+	assert.NotNil(t, repo.UpdatePINHash(ctx, rehashed, "legacy"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_CreateRole(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM user_roles WHERE name = $1)")
+	input := dto.RoleInput{Name: "Employee", Scopes: []string{"role.create"}}
+
+	mock.ExpectQuery(query).WithArgs(input.Name).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	_, err := repo.CreateRole(ctx, "123", input)
+	assert.NotNil(t, err)
+
+	mock.ExpectQuery(query).WithArgs(input.Name).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("INSERT INTO user_roles").WillReturnResult(sqlmock.NewResult(1, 1))
+	role, err := repo.CreateRole(ctx, "123", input)
+	assert.Nil(t, err)
+	assert.Equal(t, input.Name, role.Name)
+	assert.True(t, role.Active)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_GetRoleByID(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT data FROM user_roles WHERE id = $1")
+
+	mock.ExpectQuery(query).WithArgs("role-1").WillReturnRows(sqlmock.NewRows([]string{"data"}))
+	_, err := repo.GetRoleByID(ctx, "role-1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "role not found")
+
+	data, _ := json.Marshal(profileutils.Role{ID: "role-1", Name: "Employee"})
+	mock.ExpectQuery(query).WithArgs("role-1").WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	role, err := repo.GetRoleByID(ctx, "role-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Employee", role.Name)
+}
+
+func TestRepository_ListUserProfilesPage(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := &dto.ListingQuery{
+		Filters: []*dto.ListingFilter{
+			{FieldName: utils.ListingFieldRole, Operation: enumutils.OperationEqual, Value: "role-1"},
+			{FieldName: utils.ListingFieldSuspended, Operation: enumutils.OperationEqual, Value: false},
+		},
+		SortField:  utils.ListingFieldUserName,
+		Descending: true,
+		Cursor:     &dto.ListingCursor{ID: "profile-3", Value: "jane"},
+		Limit:      2,
+	}
+	statement := regexp.QuoteMeta(
+		"SELECT data FROM user_profiles WHERE $1 = ANY(roles) AND suspended = $2 " +
+			"AND (COALESCE(user_name, ''), id) < ($3, $4) " +
+			"ORDER BY COALESCE(user_name, '') DESC, id DESC LIMIT $5",
+	)
+
+	mock.ExpectQuery(statement).
+		WithArgs("role-1", false, "jane", "profile-3", 3).
+		WillReturnRows(profileRows(t, profileutils.UserProfile{ID: "profile-2"}, profileutils.UserProfile{ID: "profile-1"}))
+	profiles, err := repo.ListUserProfilesPage(ctx, query)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, "profile-2", profiles[0].ID)
+
+	mock.ExpectQuery(statement).WillReturnError(fmt.Errorf("connection reset"))
+	_, err = repo.ListUserProfilesPage(ctx, query)
+	assert.NotNil(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_GetUserCommunicationsSettings(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := "SELECT id, profile_id, allow_whatsapp"
+	columns := []string{"id", "profile_id", "allow_whatsapp", "allow_text_sms", "allow_push", "allow_email"}
+
+	// default settings are returned when none have been set
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(sqlmock.NewRows(columns))
+	comms, err := repo.GetUserCommunicationsSettings(ctx, "123")
+	assert.Nil(t, err)
+	assert.Equal(t, "123", comms.ProfileID)
+	assert.False(t, comms.AllowEmail)
+
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(
+		sqlmock.NewRows(columns).AddRow("c-1", "123", true, true, false, true),
+	)
+	comms, err = repo.GetUserCommunicationsSettings(ctx, "123")
+	assert.Nil(t, err)
+	assert.True(t, comms.AllowWhatsApp)
+	assert.False(t, comms.AllowPush)
+	assert.True(t, comms.AllowEmail)
+}
+
+func TestRepository_DeleteRole(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	usersQuery := regexp.QuoteMeta("SELECT data FROM user_profiles WHERE $1 = ANY(roles)")
+
+	// the role is removed from the users that have it before it is deleted
+	mock.ExpectQuery(usersQuery).WithArgs("role-1").WillReturnRows(
+		profileRows(t, profileutils.UserProfile{ID: "1", Roles: []string{"role-1", "role-2"}}),
+	)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT data, version FROM user_profiles WHERE id = $1")).WithArgs("1").WillReturnRows(
+		versionedProfileRows(t, 1, profileutils.UserProfile{ID: "1", Roles: []string{"role-1", "role-2"}}),
+	)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE user_profiles SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "role.revoked", "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_roles WHERE id = $1")).WithArgs("role-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	ok, err := repo.DeleteRole(ctx, "role-1")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// the role does not exist
+	mock.ExpectQuery(usersQuery).WithArgs("role-3").WillReturnRows(profileRows(t))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_roles WHERE id = $1")).WithArgs("role-3").WillReturnResult(sqlmock.NewResult(0, 0))
+	ok, err = repo.DeleteRole(ctx, "role-3")
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_ListPendingOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "event_type", "profile_id", "payload", "created_at", "attempts", "last_error"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM outbox_events WHERE published_at IS NULL ORDER BY created_at, id LIMIT $1",
+	)).WithArgs(10).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("event-1", "user.created", "1", []byte(`{"id":"event-1"}`), created, 0, "").
+			AddRow("event-2", "pin.changed", "1", []byte(`{"id":"event-2"}`), created, 2, "deadline exceeded"),
+	)
+	events, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, events, 2)
+	assert.Equal(t, domain.EventTypeUserCreated, events[0].Type)
+	assert.Equal(t, `{"id":"event-1"}`, events[0].Payload)
+	assert.Equal(t, 2, events[1].Attempts)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_events SET published_at = NOW() WHERE id = $1")).
+		WithArgs("event-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.MarkOutboxEventPublished(ctx, "event-1"))
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_events SET attempts = attempts + 1, last_error = $2 WHERE id = $1")).
+		WithArgs("event-2", "topic not found").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.RecordOutboxEventFailure(ctx, "event-2", "topic not found"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_PubSubMessages(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "topic_id", "data", "status", "attempts", "last_error", "created_at", "updated_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM pubsub_messages WHERE id = $1")).
+		WithArgs("message-1").
+		WillReturnRows(sqlmock.NewRows(columns))
+	message, err := repo.GetPubSubMessage(ctx, "message-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Nil(t, message)
+
+	message = &domain.PubSubMessage{
+		ID:        "message-1",
+		TopicID:   "topic-1",
+		Data:      `{"uid":"uid-1"}`,
+		Status:    domain.PubSubMessageStatusFailed,
+		Attempts:  1,
+		LastError: "deadline exceeded",
+		Created:   created,
+		Updated:   created,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO pubsub_messages")).
+		WithArgs("message-1", "topic-1", `{"uid":"uid-1"}`, "FAILED", 1, "deadline exceeded", created, created).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SavePubSubMessage(ctx, message))
+
+	topicID := "topic-1"
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM pubsub_messages WHERE status <> $1 AND topic_id = $2 ORDER BY created_at, id",
+	)).WithArgs("PROCESSED", "topic-1").WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("message-1", "topic-1", `{"uid":"uid-1"}`, "FAILED", 1, "deadline exceeded", created, created),
+	)
+	messages, err := repo.ListFailedPubSubMessages(ctx, &topicID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, messages, 1)
+	assert.Equal(t, domain.PubSubMessageStatusFailed, messages[0].Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	endpoint := &domain.WebhookEndpoint{
+		ID:         "endpoint-1",
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "a-very-long-secret",
+		EventTypes: []domain.EventType{domain.EventTypeUserCreated, domain.EventTypeRoleAssigned},
+		Active:     true,
+		Created:    created,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_endpoints")).
+		WithArgs("endpoint-1", endpoint.URL, endpoint.Secret, sqlmock.AnyArg(), true, created).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.CreateWebhookEndpoint(ctx, endpoint))
+
+	endpointColumns := []string{"id", "url", "secret", "event_types", "active", "created_at"}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhook_endpoints WHERE id = $1")).
+		WithArgs("endpoint-1").
+		WillReturnRows(sqlmock.NewRows(endpointColumns).AddRow(
+			"endpoint-1", endpoint.URL, endpoint.Secret, "{user.created,role.assigned}", true, created,
+		))
+	stored, err := repo.GetWebhookEndpoint(ctx, "endpoint-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, endpoint.EventTypes, stored.EventTypes)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhook_endpoints WHERE id = $1")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(endpointColumns))
+	_, err = repo.GetWebhookEndpoint(ctx, "missing")
+	assert.NotNil(t, err)
+
+	event := &domain.OutboxEvent{ID: "event-1", Type: domain.EventTypeUserCreated, Payload: `{"id":"event-1"}`}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WithArgs("event-1", "user.created", `{"id":"event-1"}`, "PENDING", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.EnqueueWebhookDeliveries(ctx, event))
+
+	deliveryColumns := []string{
+		"id", "endpoint_id", "event_id", "event_type", "payload", "status", "attempts",
+		"response_status", "last_error", "next_attempt_at", "delivered_at", "created_at",
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at, id LIMIT $3",
+	)).WithArgs("PENDING", created, 10).WillReturnRows(
+		sqlmock.NewRows(deliveryColumns).AddRow(
+			"endpoint-1-event-1", "endpoint-1", "event-1", "user.created", []byte(`{"id":"event-1"}`),
+			"PENDING", 0, 0, "", created, nil, created,
+		),
+	)
+	due, err := repo.ListDueWebhookDeliveries(ctx, created, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, due, 1)
+	assert.Nil(t, due[0].Delivered)
+
+	delivery := due[0]
+	delivery.Status = domain.WebhookDeliveryStatusSucceeded
+	delivery.Attempts = 1
+	delivery.ResponseStatus = 200
+	delivery.Delivered = &created
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook_deliveries SET status = $2")).
+		WithArgs(delivery.ID, "SUCCEEDED", 1, 200, "", created, &created).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.UpdateWebhookDelivery(ctx, delivery)
+	assert.NotNil(t, err, "a delivery that does not exist can't be updated")
+
+	status := domain.WebhookDeliveryStatusFailed
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"WHERE endpoint_id = $1 AND status = $2 ORDER BY created_at DESC, id DESC",
+	)).WithArgs("endpoint-1", "FAILED").WillReturnRows(sqlmock.NewRows(deliveryColumns))
+	deliveries, err := repo.ListWebhookDeliveries(ctx, "endpoint-1", &status)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, deliveries)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM webhook_endpoints WHERE id = $1")).
+		WithArgs("endpoint-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.DeleteWebhookEndpoint(ctx, "endpoint-1"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_Sessions(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	session := &domain.Session{
+		ID:               "session-1",
+		ProfileID:        "123",
+		UID:              "uid-1",
+		RefreshTokenHash: "hash-1",
+		DeviceID:         "device-1",
+		Platform:         "android",
+		Created:          now,
+		LastSeen:         now,
+		Flavour:          feedlib.FlavourConsumer,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sessions")).
+		WithArgs("session-1", "123", "uid-1", "hash-1", "device-1", "android", "", "", "", now, now, nil, "CONSUMER").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SaveSession(ctx, session))
+
+	sessionColumns := []string{
+		"id", "profile_id", "uid", "refresh_token_hash", "device_id", "platform", "app_version",
+		"ip_address", "user_agent", "created_at", "last_seen_at", "revoked_at", "flavour",
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sessions WHERE refresh_token_hash = $1")).
+		WithArgs("hash-1").
+		WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(
+			"session-1", "123", "uid-1", "hash-1", "device-1", "android", "", "", "", now, now, now, "CONSUMER",
+		))
+	found, err := repo.GetSessionByRefreshTokenHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, found.IsRevoked())
+	assert.Equal(t, feedlib.FlavourConsumer, found.Flavour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sessions WHERE refresh_token_hash = $1")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(sessionColumns))
+	found, err = repo.GetSessionByRefreshTokenHash(ctx, "missing")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	mock.ExpectExec(regexp.QuoteMeta("AND id = ANY($2) AND revoked_at IS NULL")).
+		WithArgs("123", sqlmock.AnyArg(), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.RevokeSessions(ctx, "123", []string{"session-1"}, now))
+
+	mock.ExpectExec(regexp.QuoteMeta("WHERE profile_id = $1 AND revoked_at IS NULL")).
+		WithArgs("123", now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.Nil(t, repo.RevokeSessions(ctx, "123", nil, now))
+
+	fakeFireBaseClientExt.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+		return fmt.Errorf("unable to revoke the refresh tokens")
+	}
+	assert.NotNil(t, repo.RevokeRefreshTokens(ctx, "uid-1"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_LoginEvents(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	event := &domain.LoginEvent{
+		ID:          "event-1",
+		Method:      domain.LoginMethodPhone,
+		Outcome:     domain.LoginOutcomeFailed,
+		Reason:      domain.LoginFailureReasonPINMismatch,
+		ProfileID:   "123",
+		MaskedPhone: "+254711***344",
+		Flavour:     feedlib.FlavourConsumer,
+		IPAddress:   "196.201.214.1",
+		Timestamp:   now,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_events")).
+		WithArgs("event-1", "PHONE", "FAILED", "PIN_MISMATCH", "123", "", "+254711***344", "CONSUMER", "", "196.201.214.1", "", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.RecordLoginEvent(ctx, event))
+
+	eventColumns := []string{
+		"id", "method", "outcome", "reason", "profile_id", "uid", "masked_phone", "flavour", "device_id",
+		"ip_address", "user_agent", "created_at",
+	}
+	from := now.Add(-time.Hour)
+	failed := domain.LoginOutcomeFailed
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"FROM login_events WHERE created_at >= $1 AND outcome = $2 ORDER BY created_at DESC, id LIMIT $3",
+	)).WithArgs(from, "FAILED", 10).WillReturnRows(
+		sqlmock.NewRows(eventColumns).AddRow(
+			"event-1", "PHONE", "FAILED", "PIN_MISMATCH", "123", "", "+254711***344", "CONSUMER", "",
+			"196.201.214.1", "", now,
+		),
+	)
+	events, err := repo.ListLoginEvents(ctx, &domain.LoginEventFilter{From: &from, Outcome: &failed, Limit: 10})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.LoginFailureReasonPINMismatch, events[0].Reason)
+	assert.Equal(t, feedlib.FlavourConsumer, events[0].Flavour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM login_events ORDER BY created_at DESC, id")).
+		WillReturnRows(sqlmock.NewRows(eventColumns))
+	events, err = repo.ListLoginEvents(ctx, &domain.LoginEventFilter{})
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_LoginAlerts(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	alert := &domain.LoginAlert{
+		ID:        "alert-1",
+		ProfileID: "123",
+		SessionID: "session-1",
+		TokenHash: "token-hash-1",
+		Reasons:   []domain.LoginAlertReason{domain.LoginAlertReasonNewDevice, domain.LoginAlertReasonNewIPRange},
+		DeviceID:  "device-1",
+		Platform:  "android",
+		IPAddress: "196.201.214.1",
+		Created:   now,
+		ExpiresAt: expiresAt,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_alerts")).
+		WithArgs(
+			"alert-1", "123", "session-1", "token-hash-1", sqlmock.AnyArg(), "device-1", "android",
+			"196.201.214.1", "", now, expiresAt, nil,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SaveLoginAlert(ctx, alert))
+
+	alertColumns := []string{
+		"id", "profile_id", "session_id", "token_hash", "reasons", "device_id", "platform", "ip_address",
+		"user_agent", "created_at", "expires_at", "reported_at",
+	}
+	query := regexp.QuoteMeta("FROM login_alerts WHERE token_hash = $1")
+	mock.ExpectQuery(query).WithArgs("token-hash-1").WillReturnRows(
+		sqlmock.NewRows(alertColumns).AddRow(
+			"alert-1", "123", "session-1", "token-hash-1", "{NEW_DEVICE,NEW_IP_RANGE}", "device-1", "android",
+			"196.201.214.1", "", now, expiresAt, nil,
+		),
+	)
+	found, err := repo.GetLoginAlertByTokenHash(ctx, "token-hash-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, alert, found)
+
+	mock.ExpectQuery(query).WithArgs("unknown").WillReturnRows(sqlmock.NewRows(alertColumns))
+	found, err = repo.GetLoginAlertByTokenHash(ctx, "unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_TwoFactor(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	enrolment := &domain.TOTPEnrolment{
+		ID:                 "enrolment-1",
+		ProfileID:          "123",
+		EncryptedSecret:    "encrypted-secret",
+		EncryptedDataKey:   "encrypted-data-key",
+		RecoveryCodeHashes: []string{"hash-1", "hash-2"},
+		LastUsedStep:       54000000,
+		Created:            now,
+		Confirmed:          &now,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO totp_enrolments")).
+		WithArgs(
+			"enrolment-1", "123", "encrypted-secret", "encrypted-data-key",
+			sqlmock.AnyArg(), int64(54000000), now, &now,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SaveTOTPEnrolment(ctx, enrolment))
+
+	enrolmentColumns := []string{
+		"id", "profile_id", "encrypted_secret", "encrypted_data_key", "recovery_code_hashes",
+		"last_used_step", "created_at", "confirmed_at",
+	}
+	query := regexp.QuoteMeta("FROM totp_enrolments WHERE profile_id = $1")
+	mock.ExpectQuery(query).WithArgs("123").WillReturnRows(
+		sqlmock.NewRows(enrolmentColumns).AddRow(
+			"enrolment-1", "123", "encrypted-secret", "encrypted-data-key", "{hash-1,hash-2}",
+			54000000, now, now,
+		),
+	)
+	found, err := repo.GetTOTPEnrolment(ctx, "123")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, enrolment, found)
+
+	mock.ExpectQuery(query).WithArgs("456").WillReturnRows(sqlmock.NewRows(enrolmentColumns))
+	found, err = repo.GetTOTPEnrolment(ctx, "456")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM totp_enrolments WHERE profile_id = $1")).
+		WithArgs("123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.DeleteTOTPEnrolment(ctx, "123"))
+
+	policyColumns := []string{"id", "required_scopes", "updated_at", "updated_by"}
+	policyQuery := regexp.QuoteMeta("FROM two_factor_policies WHERE id = $1")
+	mock.ExpectQuery(policyQuery).WithArgs(domain.TwoFactorPolicyID).WillReturnRows(sqlmock.NewRows(policyColumns))
+	policy, err := repo.GetTwoFactorPolicy(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, policy.RequiredScopes)
+
+	policy = &domain.TwoFactorPolicy{RequiredScopes: []string{"role.assign"}, Updated: now, UpdatedBy: "admin"}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO two_factor_policies")).
+		WithArgs(domain.TwoFactorPolicyID, sqlmock.AnyArg(), now, "admin").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SaveTwoFactorPolicy(ctx, policy))
+
+	mock.ExpectQuery(policyQuery).WithArgs(domain.TwoFactorPolicyID).WillReturnRows(
+		sqlmock.NewRows(policyColumns).AddRow(domain.TwoFactorPolicyID, "{role.assign}", now, "admin"),
+	)
+	saved, err := repo.GetTwoFactorPolicy(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, policy, saved)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_Passwords(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	password := &domain.Password{
+		ID:           "password-1",
+		ProfileID:    "123",
+		EmailAddress: "wanjiku@example.com",
+		PasswordHash: "hash",
+		Salt:         "salt",
+		Created:      now,
+		Updated:      now,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO passwords")).
+		WithArgs("123", "wanjiku@example.com", now, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SavePassword(ctx, password))
+
+	data, err := json.Marshal(password)
+	if err != nil {
+		t.Fatalf("unable to marshal the password: %v", err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT data FROM passwords WHERE email_address = $1")).
+		WithArgs("wanjiku@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	found, err := repo.GetPasswordByEmailAddress(ctx, "wanjiku@example.com")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, password, found)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT data FROM passwords WHERE profile_id = $1")).
+		WithArgs("456").
+		WillReturnRows(sqlmock.NewRows([]string{"data"}))
+	found, err = repo.GetPasswordByProfileID(ctx, "456")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_RemoveVerifiedIdentifier(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	query := regexp.QuoteMeta("SELECT data, version FROM user_profiles WHERE id = $1")
+	update := regexp.QuoteMeta("WHERE id = $1 AND version = $13")
+	socialUID := utils.SocialUID(profileutils.LoginProviderTypeAppleFacebook, "001234.abcd")
+	profile := profileutils.UserProfile{
+		ID:           "1",
+		VerifiedUIDS: []string{"uid-1", socialUID},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{
+			{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone},
+			{UID: socialUID, LoginProvider: profileutils.LoginProviderTypeAppleFacebook},
+		},
+	}
+
+	// the UID is removed from the indexed verified UIDs as well as the identifiers, and the
+	// reservation of the social account is released
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(versionedProfileRows(t, 2, profile))
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs("1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), `{"uid-1"}`, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM identifier_reservations WHERE id = $1 AND profile_id = $2")).
+		WithArgs(utils.IdentifierReservationID(domain.IdentifierKindSocial, socialUID), "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err := repo.RemoveVerifiedIdentifier(ctx, "1", socialUID)
+	assert.Nil(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_MergeUserProfiles(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	lock := regexp.QuoteMeta("SELECT data, version FROM user_profiles WHERE id = $1 FOR UPDATE")
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	survivorPhone := "+254711223344"
+	mergedPhone := "+254722334455"
+	survivor := profileutils.UserProfile{ID: "1", PrimaryPhone: &survivorPhone, VerifiedUIDS: []string{"uid-1"}}
+	merged := profileutils.UserProfile{ID: "2", PrimaryPhone: &mergedPhone, VerifiedUIDS: []string{"uid-2"}}
+	result, err := utils.MergeUserProfiles(&survivor, &merged)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	merge := &domain.ProfileMerge{
+		ID:              "merge-1",
+		SurvivorID:      "1",
+		MergedProfileID: "2",
+		Survivor:        &survivor,
+		MergedProfile:   &merged,
+		Result:          result,
+		MergedBy:        "admin",
+		Created:         now,
+	}
+
+	// the merged profile's phone number is handed over and its records are moved before it is removed
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, survivor))
+	mock.ExpectQuery(lock).WithArgs("2").WillReturnRows(versionedProfileRows(t, 1, merged))
+	mock.ExpectExec(regexp.QuoteMeta("WHERE id = $1 AND version = $13")).
+		WithArgs("1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			`{"+254722334455"}`, sqlmock.AnyArg(), `{"uid-1","uid-2"}`, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM identifier_reservations WHERE profile_id = $1")).
+		WithArgs("2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO identifier_reservations")).
+		WithArgs(sqlmock.AnyArg(), "PHONE", mergedPhone, "1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"pins", "passwords", "communications_settings"} {
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE "+table+" SET profile_id = $1")).
+			WithArgs("1", "2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE experiment_participants SET profile_id = $1")).
+		WithArgs("1", "2", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE profile_tombstones SET merged_into = $1 WHERE merged_into = $2")).
+		WithArgs("1", "2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_profiles WHERE id = $1")).
+		WithArgs("2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO profile_merges")).
+		WithArgs("merge-1", "1", "2", "admin", now, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO profile_tombstones")).
+		WithArgs("2", "1", "merge-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs(sqlmock.AnyArg(), string(domain.EventTypeContactChanged), "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs(sqlmock.AnyArg(), string(domain.EventTypeUserMerged), "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.Nil(t, repo.MergeUserProfiles(ctx, merge))
+
+	// a merge that is based on an outdated profile is rejected
+	changed := merged
+	changed.PushTokens = []string{"token-1"}
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs("1").WillReturnRows(versionedProfileRows(t, 3, survivor))
+	mock.ExpectQuery(lock).WithArgs("2").WillReturnRows(versionedProfileRows(t, 2, changed))
+	mock.ExpectRollback()
+	err = repo.MergeUserProfiles(ctx, merge)
+	assert.True(t, exceptions.IsConflictError(err))
+
+	tombstones := regexp.QuoteMeta("FROM profile_tombstones WHERE profile_id = $1")
+	mock.ExpectQuery(tombstones).
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"profile_id", "merged_into", "merge_id", "created_at"}).
+			AddRow("2", "1", "merge-1", now))
+	tombstone, err := repo.GetProfileTombstone(ctx, "2")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, &domain.ProfileTombstone{ProfileID: "2", MergedInto: "1", MergeID: "merge-1", Created: now}, tombstone)
+
+	mock.ExpectQuery(tombstones).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"profile_id", "merged_into", "merge_id", "created_at"}))
+	tombstone, err = repo.GetProfileTombstone(ctx, "1")
+	assert.Nil(t, err)
+	assert.Nil(t, tombstone)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepository_SigningKeys(t *testing.T) {
+	ctx := context.Background()
+	repo, mock := newTestRepository(t)
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	notAfter := now.Add(24 * time.Hour)
+
+	key := &domain.SigningKey{
+		ID:                  "key-1",
+		Algorithm:           "RS256",
+		EncryptedPrivateKey: "encrypted-private-key",
+		EncryptedDataKey:    "encrypted-data-key",
+		Created:             now,
+		NotAfter:            notAfter,
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO signing_keys")).
+		WithArgs("key-1", "RS256", "encrypted-private-key", "encrypted-data-key", now, notAfter).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, repo.SaveSigningKey(ctx, key))
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM signing_keys WHERE not_after > $1")).
+		WithArgs(now).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "algorithm", "encrypted_private_key", "encrypted_data_key", "created_at", "not_after"}).
+				AddRow("key-1", "RS256", "encrypted-private-key", "encrypted-data-key", now, notAfter),
+		)
+	keys, err := repo.ListSigningKeys(ctx, now)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, []*domain.SigningKey{key}, keys)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
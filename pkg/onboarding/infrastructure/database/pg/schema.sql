@@ -0,0 +1,265 @@
+-- Schema for the postgres implementation of the onboarding repository.
+--
+-- Each table keeps the fields that are queried, constrained or reported on as
+-- proper columns. The complete record is kept in the `data` column as JSONB so
+-- that it can be read back into the same structs used by the other repositories.
+-- The statements are idempotent and are run every time the service starts.
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+    id TEXT PRIMARY KEY,
+    primary_phone TEXT UNIQUE,
+    primary_email_address TEXT,
+    user_name TEXT UNIQUE,
+    role TEXT,
+    suspended BOOLEAN NOT NULL DEFAULT FALSE,
+    secondary_phone_numbers TEXT[] NOT NULL DEFAULT '{}',
+    secondary_email_addresses TEXT[] NOT NULL DEFAULT '{}',
+    verified_uids TEXT[] NOT NULL DEFAULT '{}',
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1,
+    data JSONB NOT NULL
+);
+
+-- the version is incremented on every write and used to reject writes based on a stale read
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS user_profiles_primary_email_address_idx ON user_profiles (primary_email_address);
+CREATE INDEX IF NOT EXISTS user_profiles_role_idx ON user_profiles (role);
+CREATE INDEX IF NOT EXISTS user_profiles_secondary_phone_numbers_idx ON user_profiles USING GIN (secondary_phone_numbers);
+CREATE INDEX IF NOT EXISTS user_profiles_secondary_email_addresses_idx ON user_profiles USING GIN (secondary_email_addresses);
+CREATE INDEX IF NOT EXISTS user_profiles_verified_uids_idx ON user_profiles USING GIN (verified_uids);
+CREATE INDEX IF NOT EXISTS user_profiles_roles_idx ON user_profiles USING GIN (roles);
+CREATE INDEX IF NOT EXISTS user_profiles_permissions_idx ON user_profiles USING GIN (permissions);
+CREATE INDEX IF NOT EXISTS user_profiles_created_at_idx ON user_profiles (created_at, id);
+
+-- every phone number, email address and username in use is reserved for a single profile.
+-- The id is derived from the kind and the normalized identifier so that a second reservation fails
+CREATE TABLE IF NOT EXISTS identifier_reservations (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    identifier TEXT NOT NULL,
+    profile_id TEXT NOT NULL REFERENCES user_profiles (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS identifier_reservations_profile_id_idx ON identifier_reservations (profile_id);
+
+CREATE TABLE IF NOT EXISTS pins (
+    id TEXT PRIMARY KEY,
+    profile_id TEXT NOT NULL UNIQUE REFERENCES user_profiles (id) ON DELETE CASCADE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS post_visit_surveys (
+    id BIGSERIAL PRIMARY KEY,
+    uid TEXT NOT NULL,
+    likely_to_recommend INTEGER NOT NULL CHECK (likely_to_recommend BETWEEN 0 AND 10),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS profile_nudges (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS experiment_participants (
+    profile_id TEXT PRIMARY KEY REFERENCES user_profiles (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS communications_settings (
+    id TEXT PRIMARY KEY,
+    profile_id TEXT NOT NULL UNIQUE REFERENCES user_profiles (id) ON DELETE CASCADE,
+    allow_whatsapp BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_text_sms BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_push BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_email BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_created_at_idx ON user_roles (created_at, id);
+
+CREATE TABLE IF NOT EXISTS role_revocations (
+    id TEXT PRIMARY KEY,
+    profile_id TEXT NOT NULL,
+    role_id TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS role_revocations_profile_id_idx ON role_revocations (profile_id);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    profile_id TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (created_at, id) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS pubsub_messages (
+    id TEXT PRIMARY KEY,
+    topic_id TEXT NOT NULL,
+    data TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS pubsub_messages_failed_idx ON pubsub_messages (created_at, id) WHERE status <> 'PROCESSED';
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at, id) WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_endpoint_idx ON webhook_deliveries (endpoint_id, created_at);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id TEXT PRIMARY KEY,
+    profile_id TEXT NOT NULL,
+    uid TEXT NOT NULL,
+    refresh_token_hash TEXT NOT NULL UNIQUE,
+    device_id TEXT NOT NULL DEFAULT '',
+    platform TEXT NOT NULL DEFAULT '',
+    app_version TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS flavour TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS sessions_profile_idx ON sessions (profile_id, last_seen_at);
+
+CREATE TABLE IF NOT EXISTS login_events (
+    id TEXT PRIMARY KEY,
+    method TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    profile_id TEXT NOT NULL DEFAULT '',
+    uid TEXT NOT NULL DEFAULT '',
+    masked_phone TEXT NOT NULL DEFAULT '',
+    flavour TEXT NOT NULL DEFAULT '',
+    device_id TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS login_events_created_idx ON login_events (created_at DESC, id);
+
+CREATE INDEX IF NOT EXISTS login_events_profile_idx ON login_events (profile_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS login_alerts (
+    id TEXT PRIMARY KEY,
+    profile_id TEXT NOT NULL,
+    session_id TEXT NOT NULL DEFAULT '',
+    token_hash TEXT NOT NULL UNIQUE,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    device_id TEXT NOT NULL DEFAULT '',
+    platform TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    reported_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS totp_enrolments (
+    id TEXT NOT NULL,
+    profile_id TEXT PRIMARY KEY,
+    encrypted_secret TEXT NOT NULL,
+    encrypted_data_key TEXT NOT NULL,
+    recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}',
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_policies (
+    id TEXT PRIMARY KEY,
+    required_scopes TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS passwords (
+    profile_id TEXT PRIMARY KEY REFERENCES user_profiles (id) ON DELETE CASCADE,
+    email_address TEXT NOT NULL UNIQUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS profile_merges (
+    id TEXT PRIMARY KEY,
+    survivor_id TEXT NOT NULL,
+    merged_profile_id TEXT NOT NULL,
+    merged_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS profile_tombstones (
+    profile_id TEXT PRIMARY KEY,
+    merged_into TEXT NOT NULL,
+    merge_id TEXT NOT NULL REFERENCES profile_merges (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS profile_tombstones_merged_into_idx ON profile_tombstones (merged_into);
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id TEXT PRIMARY KEY,
+    algorithm TEXT NOT NULL,
+    encrypted_private_key TEXT NOT NULL,
+    encrypted_data_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    not_after TIMESTAMPTZ NOT NULL
+);
@@ -2,21 +2,49 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/fb"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/pg"
 	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/serverutils"
 )
 
 // Repository interface that provide access to all persistent storage operations
 type Repository interface {
 	UserProfileRepository
 
+	OutboxRepository
+
+	PubSubMessageRepository
+
+	WebhookRepository
+
+	SessionRepository
+
+	LoginEventRepository
+
+	LoginAlertRepository
+
+	TwoFactorRepository
+
+	PasswordRepository
+
+	ProfileMergeRepository
+
+	SigningKeyRepository
+
 	SupplierRepository
 
 	CustomerRepository
@@ -36,6 +64,18 @@ type Repository interface {
 		profile profileutils.UserProfile,
 	) (*profileutils.UserProfile, error)
 
+	// creates the auth user, profile, PIN and communications settings of a new user as a single unit.
+	// Either all of them are stored or none is
+	CreateUserAccount(
+		ctx context.Context,
+		phoneNumber string,
+		account *domain.UserAccount,
+	) (*domain.UserAccount, error)
+
+	// removes the records of an account created by CreateUserAccount. It is used to roll back
+	// a signup that fails after the account has been stored
+	DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error
+
 	// fetches a user profile by uid
 	GetUserProfileByUID(
 		ctx context.Context,
@@ -119,6 +159,28 @@ type Repository interface {
 	SavePIN(ctx context.Context, pin *domain.PIN) (bool, error)
 	UpdatePIN(ctx context.Context, id string, pin *domain.PIN) (bool, error)
 
+	// counts an attempt to use the PIN of a profile before the PIN is compared, refusing it when
+	// the PIN can't be attempted. It returns the updated PIN
+	ReservePINAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// counts a failed attempt to use the PIN of a profile, locking the PIN after too many
+	// failed attempts. It returns the updated PIN
+	RecordFailedPINAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// forgets the failed attempts to use the PIN of a profile, unlocking the PIN
+	ClearPINAttempts(ctx context.Context, profileID string) error
+
+	// stops the PIN of a profile from being used until it is reset with an OTP
+	RequirePINReset(ctx context.Context, profileID string) error
+
+	// replaces the PIN of a profile with a temporary PIN, saving it when the profile has no PIN.
+	// The temporary flag of the PIN is kept
+	SaveTempPIN(ctx context.Context, pin *domain.PIN) error
+
+	// replaces the hash, salt and hashing scheme of the PIN of a profile with the ones of `pin`,
+	// unless the stored hash is no longer `previousPINNumber`
+	UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error
+
 	ExchangeRefreshTokenForIDToken(
 		ctx context.Context,
 		token string,
@@ -162,11 +224,17 @@ type CustomerRepository interface {
 	UpdateUserProfileEmail(ctx context.Context, phone string, email string) error
 }
 
-// UserProfileRepository interface that provide access to all persistent storage operations for user profile
+// UserProfileRepository interface that provide access to all persistent storage operations for user profile.
+// The username, secondary phone numbers, secondary email addresses, verified identifiers, verified
+// UIDs, push tokens, permissions, role, role IDs and favourite navigation actions are derived from a
+// read of the profile. Their updates are refused unless the context carries the version of the
+// profile that was read, see utils.WithExpectedProfileVersion
 type UserProfileRepository interface {
 	UpdateUserName(ctx context.Context, id string, userName string) error
 	UpdatePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error
 	UpdatePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error
+	ReplacePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error
+	ReplacePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error
 	UpdateSecondaryPhoneNumbers(ctx context.Context, id string, phoneNumbers []string) error
 	UpdateSecondaryEmailAddresses(ctx context.Context, id string, emailAddresses []string) error
 	UpdateVerifiedIdentifiers(
@@ -175,6 +243,8 @@ type UserProfileRepository interface {
 		identifiers []profileutils.VerifiedIdentifier,
 	) error
 	UpdateVerifiedUIDS(ctx context.Context, id string, uids []string) error
+	RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error
+	LinkSocialAccount(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error
 	UpdateSuspended(ctx context.Context, id string, status bool) error
 	UpdatePhotoUploadID(ctx context.Context, id string, uploadID string) error
 	UpdatePushTokens(ctx context.Context, id string, pushToken []string) error
@@ -193,6 +263,10 @@ type UserProfileRepository interface {
 		ctx context.Context,
 		role profileutils.RoleType,
 	) ([]*profileutils.UserProfile, error)
+
+	// ListUserProfilesPage reads the user profiles of a page of a listing, in the order of the
+	// listing query. At most one profile more than the page size is read
+	ListUserProfilesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.UserProfile, error)
 }
 
 //RolesRepository interface that provide access to all persistent storage operations for roles
@@ -205,6 +279,10 @@ type RolesRepository interface {
 
 	GetAllRoles(ctx context.Context) (*[]profileutils.Role, error)
 
+	// ListRolesPage reads the roles of a page of a listing, in the order of the listing query.
+	// At most one role more than the page size is read
+	ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error)
+
 	GetRoleByID(ctx context.Context, roleID string) (*profileutils.Role, error)
 
 	GetRoleByName(ctx context.Context, roleName string) (*profileutils.Role, error)
@@ -231,14 +309,35 @@ type RolesRepository interface {
 
 // DbService is an implementation of the database repository
 // It is implementation agnostic i.e logic should be handled using
-// the preferred database
+// the preferred database. The TOTP secrets are encrypted with the key encryption key before they
+// are handed to the repository
 type DbService struct {
-	firestore *fb.Repository
+	repository       Repository
+	keyEncryptionKey []byte
 }
 
-// NewDbService creates a new database service
+// NewDbService creates a new database service. The backing database is picked
+// using the `REPOSITORY` environment variable
 func NewDbService() *DbService {
 	ctx := context.Background()
+
+	repo := serverutils.MustGetEnvVar(domain.Repo)
+	keyEncryptionKey, err := utils.KeyEncryptionKey()
+	if err != nil {
+		// the service runs without TOTP enrolments until it has a key to encrypt them with
+		log.Printf("unable to load the key that the TOTP secrets are encrypted with: %s", err)
+	}
+	if repo == domain.MemoryRepository {
+		// the in memory repository does not need a GCP project
+		memoryRepository, err := memory.NewMemoryRepositoryFromSnapshot(
+			os.Getenv(memory.SnapshotPathEnvVarName),
+		)
+		if err != nil {
+			log.Fatalf("unable to initialize the in memory repository: %s", err)
+		}
+		return NewDbServiceWithRepository(memoryRepository, keyEncryptionKey)
+	}
+
 	fc := &firebasetools.FirebaseClient{}
 	firebaseApp, err := fc.InitFirebase()
 	if err != nil {
@@ -248,63 +347,108 @@ func NewDbService() *DbService {
 	if err != nil {
 		log.Panicf("can't initialize Firebase auth when setting up profile service: %s", err)
 	}
-	fsc, err := firebaseApp.Firestore(ctx)
-	if err != nil {
-		log.Fatalf("unable to initialize Firestore: %s", err)
+
+	switch repo {
+	case domain.FirebaseRepository:
+		fsc, err := firebaseApp.Firestore(ctx)
+		if err != nil {
+			log.Fatalf("unable to initialize Firestore: %s", err)
+		}
+		firestoreExtension := fb.NewFirestoreClientExtension(fsc)
+
+		firestore := fb.NewFirebaseRepository(firestoreExtension, fbc)
+		return NewDbServiceWithRepository(firestore, keyEncryptionKey)
+
+	case domain.PostgresRepository:
+		db, err := pg.OpenDatabase(ctx, serverutils.MustGetEnvVar(pg.DatabaseURLEnvVarName))
+		if err != nil {
+			log.Fatalf("unable to initialize postgres: %s", err)
+		}
+		postgres := pg.NewPostgresRepository(db, fbc)
+		if err := postgres.Migrate(ctx); err != nil {
+			log.Fatalf("unable to migrate postgres: %s", err)
+		}
+		return NewDbServiceWithRepository(postgres, keyEncryptionKey)
+
+	default:
+		log.Fatalf("unknown repository %q, expected one of %q, %q or %q",
+			repo,
+			domain.FirebaseRepository,
+			domain.PostgresRepository,
+			domain.MemoryRepository,
+		)
 	}
-	firestoreExtension := fb.NewFirestoreClientExtension(fsc)
+	return nil
+}
 
-	firestore := fb.NewFirebaseRepository(firestoreExtension, fbc)
+// NewDbServiceWithRepository creates a new database service backed by the provided repository.
+// The TOTP secrets are encrypted with the provided 256 bit key encryption key
+func NewDbServiceWithRepository(repository Repository, keyEncryptionKey []byte) *DbService {
 	return &DbService{
-		firestore: firestore,
+		repository:       repository,
+		keyEncryptionKey: keyEncryptionKey,
 	}
 }
 
 // CheckPreconditions ensures correct initialization
 func (d DbService) CheckPreconditions() {
-	if d.firestore == nil {
-		log.Panicf("nil firestore service in database service")
+	if d.repository == nil {
+		log.Panicf("nil repository in database service")
 	}
 }
 
 // StageProfileNudge stages nudges published from this service.
 func (d DbService) StageProfileNudge(ctx context.Context, nudge *feedlib.Nudge) error {
-	return d.firestore.StageProfileNudge(ctx, nudge)
+	return d.repository.StageProfileNudge(ctx, nudge)
 }
 
 // CheckIfAdmin checks if a user has admin permissions
 func (d DbService) CheckIfAdmin(profile *profileutils.UserProfile) bool {
-	return d.firestore.CheckIfAdmin(profile)
+	return d.repository.CheckIfAdmin(profile)
 }
 
 // UpdateUserName updates the username of a profile that matches the id
 // this method should be called after asserting the username is unique and not associated with another userProfile
 func (d DbService) UpdateUserName(ctx context.Context, id string, userName string) error {
-	return d.firestore.UpdateUserName(ctx, id, userName)
+	return d.repository.UpdateUserName(ctx, id, userName)
 }
 
 // UpdatePrimaryPhoneNumber append a new primary phone number to the user profile
 // this method should be called after asserting the phone number is unique and not associated with another userProfile
 func (d DbService) UpdatePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error {
-	return d.firestore.UpdatePrimaryPhoneNumber(ctx, id, phoneNumber)
+	return d.repository.UpdatePrimaryPhoneNumber(ctx, id, phoneNumber)
 }
 
 // UpdatePrimaryEmailAddress the primary email addresses of the profile that matches the id
 // this method should be called after asserting the emailAddress is unique and not associated with another userProfile
 func (d DbService) UpdatePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error {
-	return d.firestore.UpdatePrimaryEmailAddress(ctx, id, emailAddress)
+	return d.repository.UpdatePrimaryEmailAddress(ctx, id, emailAddress)
+}
+
+// ReplacePrimaryPhoneNumber sets the primary phone number of the profile that matches the id and keeps
+// the previous one as a secondary phone number, in a single write
+// this method should be called after asserting the phone number is unique and not associated with another userProfile
+func (d DbService) ReplacePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error {
+	return d.repository.ReplacePrimaryPhoneNumber(ctx, id, phoneNumber)
+}
+
+// ReplacePrimaryEmailAddress sets the primary email address of the profile that matches the id and keeps
+// the previous one as a secondary email address, in a single write
+// this method should be called after asserting the emailAddress is unique and not associated with another userProfile
+func (d DbService) ReplacePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error {
+	return d.repository.ReplacePrimaryEmailAddress(ctx, id, emailAddress)
 }
 
 // UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
 // this method should be called after asserting the phone numbers are unique and not associated with another userProfile
 func (d DbService) UpdateSecondaryPhoneNumbers(ctx context.Context, id string, phoneNumbers []string) error {
-	return d.firestore.UpdateSecondaryPhoneNumbers(ctx, id, phoneNumbers)
+	return d.repository.UpdateSecondaryPhoneNumbers(ctx, id, phoneNumbers)
 }
 
 // UpdateSecondaryEmailAddresses the secondary email addresses of the profile that matches the id
 // this method should be called after asserting the emailAddresses  as unique and not associated with another userProfile
 func (d DbService) UpdateSecondaryEmailAddresses(ctx context.Context, id string, emailAddresses []string) error {
-	return d.firestore.UpdateSecondaryEmailAddresses(ctx, id, emailAddresses)
+	return d.repository.UpdateSecondaryEmailAddresses(ctx, id, emailAddresses)
 }
 
 // UpdateVerifiedIdentifiers adds a UID to a user profile during login if it does not exist
@@ -313,48 +457,64 @@ func (d DbService) UpdateVerifiedIdentifiers(
 	id string,
 	identifiers []profileutils.VerifiedIdentifier,
 ) error {
-	return d.firestore.UpdateVerifiedIdentifiers(ctx, id, identifiers)
+	return d.repository.UpdateVerifiedIdentifiers(ctx, id, identifiers)
 }
 
 // UpdateVerifiedUIDS adds a UID to a user profile during login if it does not exist
 func (d DbService) UpdateVerifiedUIDS(ctx context.Context, id string, uids []string) error {
-	return d.firestore.UpdateVerifiedUIDS(ctx, id, uids)
+	return d.repository.UpdateVerifiedUIDS(ctx, id, uids)
+}
+
+// RemoveVerifiedIdentifier removes the verified identifier and the verified UID of a login UID from
+// the profile that matches the id. The user can no longer log in with that UID
+func (d DbService) RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error {
+	return d.repository.RemoveVerifiedIdentifier(ctx, id, uid)
+}
+
+// LinkSocialAccount adds the verified identifier and the verified UID of an account with a social
+// login provider to the profile that matches the id and reserves the UID for that profile
+func (d DbService) LinkSocialAccount(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	return d.repository.LinkSocialAccount(ctx, id, identifier)
 }
 
 // UpdateSuspended updates the suspend attribute of the profile that matches the id
 func (d DbService) UpdateSuspended(ctx context.Context, id string, status bool) error {
-	return d.firestore.UpdateSuspended(ctx, id, status)
+	return d.repository.UpdateSuspended(ctx, id, status)
 }
 
 // UpdatePhotoUploadID updates the photoUploadID attribute of the profile that matches the id
 func (d DbService) UpdatePhotoUploadID(ctx context.Context, id string, uploadID string) error {
-	return d.firestore.UpdatePhotoUploadID(ctx, id, uploadID)
+	return d.repository.UpdatePhotoUploadID(ctx, id, uploadID)
 }
 
 // UpdatePushTokens updates the pushTokens attribute of the profile that matches the id. This function does a hard reset instead of prior
 // matching
 func (d DbService) UpdatePushTokens(ctx context.Context, id string, pushToken []string) error {
-	return d.firestore.UpdatePushTokens(ctx, id, pushToken)
+	return d.repository.UpdatePushTokens(ctx, id, pushToken)
 }
 
 // UpdatePermissions update the permissions of the user profile
 func (d DbService) UpdatePermissions(ctx context.Context, id string, perms []profileutils.PermissionType) error {
-	return d.firestore.UpdatePermissions(ctx, id, perms)
+	return d.repository.UpdatePermissions(ctx, id, perms)
 }
 
 // UpdateRole update the permissions of the user profile
 func (d DbService) UpdateRole(ctx context.Context, id string, role profileutils.RoleType) error {
-	return d.firestore.UpdateRole(ctx, id, role)
+	return d.repository.UpdateRole(ctx, id, role)
 }
 
 // UpdateUserRoleIDs updates the roles for a user
 func (d DbService) UpdateUserRoleIDs(ctx context.Context, id string, roleIDs []string) error {
-	return d.firestore.UpdateUserRoleIDs(ctx, id, roleIDs)
+	return d.repository.UpdateUserRoleIDs(ctx, id, roleIDs)
 }
 
 // UpdateBioData updates the biodate of the profile that matches the id
 func (d DbService) UpdateBioData(ctx context.Context, id string, data profileutils.BioData) error {
-	return d.firestore.UpdateBioData(ctx, id, data)
+	return d.repository.UpdateBioData(ctx, id, data)
 }
 
 // UpdateAddresses persists a user's home or work address information to the database
@@ -364,12 +524,12 @@ func (d DbService) UpdateAddresses(
 	address profileutils.Address,
 	addressType enumutils.AddressType,
 ) error {
-	return d.firestore.UpdateAddresses(ctx, id, address, addressType)
+	return d.repository.UpdateAddresses(ctx, id, address, addressType)
 }
 
 // UpdateFavNavActions update the permissions of the user profile
 func (d DbService) UpdateFavNavActions(ctx context.Context, id string, favActions []string) error {
-	return d.firestore.UpdateFavNavActions(ctx, id, favActions)
+	return d.repository.UpdateFavNavActions(ctx, id, favActions)
 }
 
 // ListUserProfiles fetches all users with the specified role from the database
@@ -377,7 +537,15 @@ func (d DbService) ListUserProfiles(
 	ctx context.Context,
 	role profileutils.RoleType,
 ) ([]*profileutils.UserProfile, error) {
-	return d.firestore.ListUserProfiles(ctx, role)
+	return d.repository.ListUserProfiles(ctx, role)
+}
+
+// ListUserProfilesPage reads the user profiles of a page of a listing
+func (d DbService) ListUserProfilesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.UserProfile, error) {
+	return d.repository.ListUserProfilesPage(ctx, query)
 }
 
 // CreateRole creates a new role and persists it to the database
@@ -386,43 +554,48 @@ func (d DbService) CreateRole(
 	profileID string,
 	input dto.RoleInput,
 ) (*profileutils.Role, error) {
-	return d.firestore.CreateRole(ctx, profileID, input)
+	return d.repository.CreateRole(ctx, profileID, input)
 }
 
 // GetAllRoles returns a list of all created roles
 func (d DbService) GetAllRoles(ctx context.Context) (*[]profileutils.Role, error) {
-	return d.firestore.GetAllRoles(ctx)
+	return d.repository.GetAllRoles(ctx)
+}
+
+// ListRolesPage reads the roles of a page of a listing
+func (d DbService) ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error) {
+	return d.repository.ListRolesPage(ctx, query)
 }
 
 // GetRoleByID gets role with matching id
 func (d DbService) GetRoleByID(ctx context.Context, roleID string) (*profileutils.Role, error) {
-	return d.firestore.GetRoleByID(ctx, roleID)
+	return d.repository.GetRoleByID(ctx, roleID)
 }
 
 // GetRoleByName retrieves a role using it's name
 func (d DbService) GetRoleByName(ctx context.Context, roleName string) (*profileutils.Role, error) {
-	return d.firestore.GetRoleByName(ctx, roleName)
+	return d.repository.GetRoleByName(ctx, roleName)
 }
 
 // GetRolesByIDs gets all roles matching provided roleIDs if specified otherwise all roles
 func (d DbService) GetRolesByIDs(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
-	return d.firestore.GetRolesByIDs(ctx, roleIDs)
+	return d.repository.GetRolesByIDs(ctx, roleIDs)
 }
 
 // CheckIfRoleNameExists checks if a role with a similar name exists
 // Ensures unique name for each role during creation
 func (d DbService) CheckIfRoleNameExists(ctx context.Context, name string) (bool, error) {
-	return d.firestore.CheckIfRoleNameExists(ctx, name)
+	return d.repository.CheckIfRoleNameExists(ctx, name)
 }
 
 // UpdateRoleDetails  updates the details of a role
 func (d DbService) UpdateRoleDetails(ctx context.Context, profileID string, role profileutils.Role) (*profileutils.Role, error) {
-	return d.firestore.UpdateRoleDetails(ctx, profileID, role)
+	return d.repository.UpdateRoleDetails(ctx, profileID, role)
 }
 
 // DeleteRole removes a role permanently from the database
 func (d DbService) DeleteRole(ctx context.Context, roleID string) (bool, error) {
-	return d.firestore.DeleteRole(ctx, roleID)
+	return d.repository.DeleteRole(ctx, roleID)
 }
 
 //CheckIfUserHasPermission checks if a user has the required permission
@@ -431,13 +604,13 @@ func (d DbService) CheckIfUserHasPermission(
 	UID string,
 	requiredPermission profileutils.Permission,
 ) (bool, error) {
-	return d.firestore.CheckIfUserHasPermission(ctx, UID, requiredPermission)
+	return d.repository.CheckIfUserHasPermission(ctx, UID, requiredPermission)
 }
 
 // GetUserProfilesByRoleID returns a list of user profiles with the role ID
 // i.e users assigned a particular role
 func (d DbService) GetUserProfilesByRoleID(ctx context.Context, role string) ([]*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfilesByRoleID(ctx, role)
+	return d.repository.GetUserProfilesByRoleID(ctx, role)
 }
 
 // CreateUserProfile creates a user profile of using the provided phone number and uid
@@ -445,7 +618,7 @@ func (d DbService) CreateUserProfile(
 	ctx context.Context,
 	phoneNumber, uid string,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.CreateUserProfile(ctx, phoneNumber, uid)
+	return d.repository.CreateUserProfile(ctx, phoneNumber, uid)
 }
 
 // CreateDetailedUserProfile creates a new user profile that is pre-filled using the provided phone number
@@ -454,7 +627,21 @@ func (d DbService) CreateDetailedUserProfile(
 	phoneNumber string,
 	profile profileutils.UserProfile,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.CreateDetailedUserProfile(ctx, phoneNumber, profile)
+	return d.repository.CreateDetailedUserProfile(ctx, phoneNumber, profile)
+}
+
+// CreateUserAccount creates the auth user, profile, PIN and communications settings of a new user as a single unit
+func (d DbService) CreateUserAccount(
+	ctx context.Context,
+	phoneNumber string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	return d.repository.CreateUserAccount(ctx, phoneNumber, account)
+}
+
+// DeleteUserAccount removes the records of an account created by CreateUserAccount
+func (d DbService) DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error {
+	return d.repository.DeleteUserAccount(ctx, account)
 }
 
 // GetUserProfileByUID fetches a user profile by uid
@@ -463,7 +650,7 @@ func (d DbService) GetUserProfileByUID(
 	uid string,
 	suspended bool,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfileByUID(ctx, uid, suspended)
+	return d.repository.GetUserProfileByUID(ctx, uid, suspended)
 }
 
 // GetUserProfileByID fetches a user profile by id. returns the unsuspend profile
@@ -472,7 +659,7 @@ func (d DbService) GetUserProfileByID(
 	id string,
 	suspended bool,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfileByID(ctx, id, suspended)
+	return d.repository.GetUserProfileByID(ctx, id, suspended)
 }
 
 // GetUserProfileByPhoneNumber fetches a user profile by phone number
@@ -481,7 +668,7 @@ func (d DbService) GetUserProfileByPhoneNumber(
 	phoneNumber string,
 	suspended bool,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfileByPhoneNumber(ctx, phoneNumber, suspended)
+	return d.repository.GetUserProfileByPhoneNumber(ctx, phoneNumber, suspended)
 }
 
 // GetUserProfileByPrimaryPhoneNumber fetches a user profile by primary phone number
@@ -490,30 +677,30 @@ func (d DbService) GetUserProfileByPrimaryPhoneNumber(
 	phoneNumber string,
 	suspend bool,
 ) (*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfileByPrimaryPhoneNumber(ctx, phoneNumber, suspend)
+	return d.repository.GetUserProfileByPrimaryPhoneNumber(ctx, phoneNumber, suspend)
 }
 
 // CheckIfPhoneNumberExists checks if a specific phone number has already been registered to another user
 func (d DbService) CheckIfPhoneNumberExists(ctx context.Context, phone string) (bool, error) {
-	return d.firestore.CheckIfPhoneNumberExists(ctx, phone)
+	return d.repository.CheckIfPhoneNumberExists(ctx, phone)
 }
 
 // CheckIfEmailExists checks if a specific email has already been registered to another user
 func (d DbService) CheckIfEmailExists(ctx context.Context, email string) (bool, error) {
-	return d.firestore.CheckIfEmailExists(ctx, email)
+	return d.repository.CheckIfEmailExists(ctx, email)
 }
 
 // CheckIfUsernameExists checks if a specific username has already been registered to another user
 func (d DbService) CheckIfUsernameExists(ctx context.Context, phone string) (bool, error) {
 	username := phone
-	return d.firestore.CheckIfUsernameExists(ctx, username)
+	return d.repository.CheckIfUsernameExists(ctx, username)
 }
 
 // GenerateAuthCredentialsForAnonymousUser ...
 func (d DbService) GenerateAuthCredentialsForAnonymousUser(
 	ctx context.Context,
 ) (*profileutils.AuthCredentialResponse, error) {
-	return d.firestore.GenerateAuthCredentialsForAnonymousUser(ctx)
+	return d.repository.GenerateAuthCredentialsForAnonymousUser(ctx)
 }
 
 // GenerateAuthCredentials ...
@@ -522,21 +709,21 @@ func (d DbService) GenerateAuthCredentials(
 	phone string,
 	profile *profileutils.UserProfile,
 ) (*profileutils.AuthCredentialResponse, error) {
-	return d.firestore.GenerateAuthCredentials(ctx, phone, profile)
+	return d.repository.GenerateAuthCredentials(ctx, phone, profile)
 }
 
 // FetchAdminUsers ...
 func (d DbService) FetchAdminUsers(ctx context.Context) ([]*profileutils.UserProfile, error) {
-	return d.firestore.FetchAdminUsers(ctx)
+	return d.repository.FetchAdminUsers(ctx)
 }
 
 func (d DbService) FetchAllUsers(ctx context.Context, callbackURL string) {
-	d.firestore.FetchAllUsers(ctx, callbackURL)
+	d.repository.FetchAllUsers(ctx, callbackURL)
 }
 
 // PurgeUserByPhoneNumber removes user completely. This should be used only under testing environment
 func (d DbService) PurgeUserByPhoneNumber(ctx context.Context, phone string) error {
-	return d.firestore.PurgeUserByPhoneNumber(ctx, phone)
+	return d.repository.PurgeUserByPhoneNumber(ctx, phone)
 }
 
 // HardResetSecondaryPhoneNumbers ...
@@ -545,7 +732,7 @@ func (d DbService) HardResetSecondaryPhoneNumbers(
 	profile *profileutils.UserProfile,
 	newSecondaryPhones []string,
 ) error {
-	return d.firestore.HardResetSecondaryPhoneNumbers(ctx, profile, newSecondaryPhones)
+	return d.repository.HardResetSecondaryPhoneNumbers(ctx, profile, newSecondaryPhones)
 }
 
 // HardResetSecondaryEmailAddress ...
@@ -554,7 +741,7 @@ func (d DbService) HardResetSecondaryEmailAddress(
 	profile *profileutils.UserProfile,
 	newSecondaryEmails []string,
 ) error {
-	return d.firestore.HardResetSecondaryEmailAddress(ctx, profile, newSecondaryEmails)
+	return d.repository.HardResetSecondaryEmailAddress(ctx, profile, newSecondaryEmails)
 }
 
 // GetPINByProfileID ...
@@ -562,7 +749,7 @@ func (d DbService) GetPINByProfileID(
 	ctx context.Context,
 	ProfileID string,
 ) (*domain.PIN, error) {
-	return d.firestore.GetPINByProfileID(ctx, ProfileID)
+	return d.repository.GetPINByProfileID(ctx, ProfileID)
 }
 
 // RecordPostVisitSurvey records the  post visit survey
@@ -571,17 +758,55 @@ func (d DbService) RecordPostVisitSurvey(
 	input dto.PostVisitSurveyInput,
 	UID string,
 ) error {
-	return d.firestore.RecordPostVisitSurvey(ctx, input, UID)
+	return d.repository.RecordPostVisitSurvey(ctx, input, UID)
 }
 
 // SavePIN  User Pin methods
 func (d DbService) SavePIN(ctx context.Context, pin *domain.PIN) (bool, error) {
-	return d.firestore.SavePIN(ctx, pin)
+	return d.repository.SavePIN(ctx, pin)
 }
 
 // UpdatePIN ...
 func (d DbService) UpdatePIN(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
-	return d.firestore.UpdatePIN(ctx, id, pin)
+	return d.repository.UpdatePIN(ctx, id, pin)
+}
+
+// ReservePINAttempt ...
+func (d DbService) ReservePINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return d.repository.ReservePINAttempt(ctx, profileID, attemptedAt)
+}
+
+// RecordFailedPINAttempt ...
+func (d DbService) RecordFailedPINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return d.repository.RecordFailedPINAttempt(ctx, profileID, attemptedAt)
+}
+
+// ClearPINAttempts ...
+func (d DbService) ClearPINAttempts(ctx context.Context, profileID string) error {
+	return d.repository.ClearPINAttempts(ctx, profileID)
+}
+
+// RequirePINReset ...
+func (d DbService) RequirePINReset(ctx context.Context, profileID string) error {
+	return d.repository.RequirePINReset(ctx, profileID)
+}
+
+// SaveTempPIN ...
+func (d DbService) SaveTempPIN(ctx context.Context, pin *domain.PIN) error {
+	return d.repository.SaveTempPIN(ctx, pin)
+}
+
+// UpdatePINHash ...
+func (d DbService) UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+	return d.repository.UpdatePINHash(ctx, pin, previousPINNumber)
 }
 
 // ExchangeRefreshTokenForIDToken ...
@@ -589,12 +814,12 @@ func (d DbService) ExchangeRefreshTokenForIDToken(
 	ctx context.Context,
 	token string,
 ) (*profileutils.AuthCredentialResponse, error) {
-	return d.firestore.ExchangeRefreshTokenForIDToken(ctx, token)
+	return d.repository.ExchangeRefreshTokenForIDToken(ctx, token)
 }
 
 // GetOrCreatePhoneNumberUser ...
 func (d DbService) GetOrCreatePhoneNumberUser(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-	return d.firestore.GetOrCreatePhoneNumberUser(ctx, phone)
+	return d.repository.GetOrCreatePhoneNumberUser(ctx, phone)
 }
 
 // AddUserAsExperimentParticipant ...
@@ -602,7 +827,7 @@ func (d DbService) AddUserAsExperimentParticipant(
 	ctx context.Context,
 	profile *profileutils.UserProfile,
 ) (bool, error) {
-	return d.firestore.AddUserAsExperimentParticipant(ctx, profile)
+	return d.repository.AddUserAsExperimentParticipant(ctx, profile)
 }
 
 // RemoveUserAsExperimentParticipant ...
@@ -610,12 +835,12 @@ func (d DbService) RemoveUserAsExperimentParticipant(
 	ctx context.Context,
 	profile *profileutils.UserProfile,
 ) (bool, error) {
-	return d.firestore.RemoveUserAsExperimentParticipant(ctx, profile)
+	return d.repository.RemoveUserAsExperimentParticipant(ctx, profile)
 }
 
 // CheckIfExperimentParticipant ...
 func (d DbService) CheckIfExperimentParticipant(ctx context.Context, profileID string) (bool, error) {
-	return d.firestore.CheckIfExperimentParticipant(ctx, profileID)
+	return d.repository.CheckIfExperimentParticipant(ctx, profileID)
 }
 
 // GetUserCommunicationsSettings ...
@@ -623,28 +848,439 @@ func (d DbService) GetUserCommunicationsSettings(
 	ctx context.Context,
 	profileID string,
 ) (*profileutils.UserCommunicationsSetting, error) {
-	return d.firestore.GetUserCommunicationsSettings(ctx, profileID)
+	return d.repository.GetUserCommunicationsSettings(ctx, profileID)
 }
 
 // SetUserCommunicationsSettings ...
 func (d DbService) SetUserCommunicationsSettings(ctx context.Context, profileID string,
 	allowWhatsApp *bool, allowTextSms *bool, allowPush *bool, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-	return d.firestore.SetUserCommunicationsSettings(ctx, profileID, allowWhatsApp, allowTextSms, allowPush, allowEmail)
+	return d.repository.SetUserCommunicationsSettings(ctx, profileID, allowWhatsApp, allowTextSms, allowPush, allowEmail)
 }
 
 // SaveRoleRevocation records a log for a role revocation
 //
 // userId is the ID of the user removing a role from a user
 func (d DbService) SaveRoleRevocation(ctx context.Context, userID string, revocation dto.RoleRevocationInput) error {
-	return d.firestore.SaveRoleRevocation(ctx, userID, revocation)
+	return d.repository.SaveRoleRevocation(ctx, userID, revocation)
 }
 
 // GetUserProfileByPhoneOrEmail gets usser profile by phone or email
 func (d DbService) GetUserProfileByPhoneOrEmail(ctx context.Context, payload *dto.RetrieveUserProfileInput) (*profileutils.UserProfile, error) {
-	return d.firestore.GetUserProfileByPhoneOrEmail(ctx, payload)
+	return d.repository.GetUserProfileByPhoneOrEmail(ctx, payload)
 }
 
 // UpdateUserProfileEmail updates user profile's email
 func (d DbService) UpdateUserProfileEmail(ctx context.Context, phone string, email string) error {
-	return d.firestore.UpdateUserProfileEmail(ctx, phone, email)
+	return d.repository.UpdateUserProfileEmail(ctx, phone, email)
+}
+
+// OutboxRepository defines signatures that relate to the outbox of domain events. The events are
+// written together with the state change they describe and read by the outbox relay
+type OutboxRepository interface {
+	// ListPendingOutboxEvents reads the oldest events that have not been published yet
+	ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+
+	// MarkOutboxEventPublished records that an event has been published
+	MarkOutboxEventPublished(ctx context.Context, id string) error
+
+	// RecordOutboxEventFailure records a failed attempt to publish an event. The event stays pending
+	RecordOutboxEventFailure(ctx context.Context, id string, reason string) error
+}
+
+// PubSubMessageRepository defines signatures that relate to the messages received from pubsub.
+// A message is recorded by its ID so that it is handled once even when it is delivered again
+type PubSubMessageRepository interface {
+	// GetPubSubMessage reads the record of a received message. It returns nil if the message
+	// has not been received before
+	GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error)
+
+	// SavePubSubMessage creates or replaces the record of a received message
+	SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error
+
+	// ListFailedPubSubMessages reads the messages that failed or were dead lettered, optionally of a single topic
+	ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error)
+}
+
+// WebhookRepository defines signatures that relate to the webhook endpoints of partner systems
+// and the deliveries of the domain events to them
+type WebhookRepository interface {
+	// CreateWebhookEndpoint stores a new webhook endpoint
+	CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+
+	// GetWebhookEndpoint reads a webhook endpoint
+	GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+
+	// ListWebhookEndpoints reads all the webhook endpoints
+	ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+
+	// DeleteWebhookEndpoint removes a webhook endpoint together with its deliveries
+	DeleteWebhookEndpoint(ctx context.Context, id string) error
+
+	// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints
+	// that subscribe to it. An event that has already been enqueued is not enqueued again
+	EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error
+
+	// ListDueWebhookDeliveries reads the oldest pending deliveries that are due to be attempted
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+
+	// GetWebhookDelivery reads a webhook delivery
+	GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+
+	// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first, optionally
+	// with a single status
+	ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error)
+}
+
+// SessionRepository defines signatures that relate to the devices that users are signed in on
+type SessionRepository interface {
+	// SaveSession creates or replaces a session
+	SaveSession(ctx context.Context, session *domain.Session) error
+
+	// GetSessionByRefreshTokenHash reads the session that holds a refresh token. It returns nil
+	// if no session holds the token
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Session, error)
+
+	// ListSessions reads the sessions of a profile, most recently seen first
+	ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error)
+
+	// RevokeSessions signs out the listed sessions of a profile, or all of its sessions when no
+	// session is listed. Sessions that are already revoked keep the time they were revoked at
+	RevokeSessions(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error
+
+	// RevokeRefreshTokens revokes all the refresh tokens that were issued to an auth user
+	RevokeRefreshTokens(ctx context.Context, uid string) error
+}
+
+// LoginEventRepository defines signatures that relate to the audit trail of attempts to log in.
+// Login events can only be appended
+type LoginEventRepository interface {
+	// RecordLoginEvent appends an attempt to log in to the audit trail
+	RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error
+
+	// ListLoginEvents reads the login events that match a filter, newest first
+	ListLoginEvents(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error)
+}
+
+// LoginAlertRepository defines signatures that relate to the alerts that users are sent when
+// they log in from a new device or network
+type LoginAlertRepository interface {
+	// SaveLoginAlert creates or replaces a login alert
+	SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error
+
+	// GetLoginAlertByTokenHash finds the login alert whose "this wasn't me" token has the provided hash.
+	// It returns nil when there is no such alert
+	GetLoginAlertByTokenHash(ctx context.Context, tokenHash string) (*domain.LoginAlert, error)
+}
+
+// TwoFactorRepository defines signatures that relate to second factor authentication
+type TwoFactorRepository interface {
+	// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile
+	SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error
+
+	// GetTOTPEnrolment reads the TOTP enrolment of a profile. It returns nil when the profile has none
+	GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error)
+
+	// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+	DeleteTOTPEnrolment(ctx context.Context, profileID string) error
+
+	// GetTwoFactorPolicy reads the two factor policy. It returns an empty policy when none has been saved
+	GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error)
+
+	// SaveTwoFactorPolicy replaces the two factor policy
+	SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error
+}
+
+// PasswordRepository defines signatures that relate to email and password logins
+type PasswordRepository interface {
+	// CreateEmailUserAccount creates the auth user, profile, password and communications settings of a user
+	// who signs up with an email address as a single unit. Either all of them are stored or none is.
+	// The account is removed with DeleteUserAccount
+	CreateEmailUserAccount(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error)
+
+	// SavePassword creates or replaces the password of the email login of a profile
+	SavePassword(ctx context.Context, password *domain.Password) error
+
+	// ReservePasswordAttempt counts an attempt to use the password of the email login of a
+	// profile before the password is compared, locking the password after too many failed
+	// attempts. It refuses the attempt when the password is locked or was attempted too recently.
+	// It returns the updated password
+	ReservePasswordAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.Password, error)
+
+	// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of
+	// a profile, unlocking the password
+	ClearPasswordAttempts(ctx context.Context, profileID string) error
+
+	// RequirePasswordReset stops the password of the email login of a profile from being used
+	// until it is reset with an OTP
+	RequirePasswordReset(ctx context.Context, profileID string) error
+
+	// UpdatePasswordHash replaces the hash, salt and hashing scheme of the password of a profile
+	// with the ones of a rehashed password, unless the password was changed since it was read
+	UpdatePasswordHash(ctx context.Context, password *domain.Password, previousPasswordHash string) error
+
+	// GetPasswordByEmailAddress reads the password of the email login of a normalized email address.
+	// It returns nil when the email address has no email login
+	GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error)
+
+	// GetPasswordByProfileID reads the password of the email login of a profile. It returns nil when
+	// the profile has no email login
+	GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error)
+}
+
+// ProfileMergeRepository defines signatures that relate to merging duplicate user profiles
+type ProfileMergeRepository interface {
+	// MergeUserProfiles stores the result of a merge as the surviving profile and removes the merged
+	// profile as a single unit. The PIN, password, communications settings and experiment participation
+	// of the merged profile are moved to the survivor unless it has its own. The write is rejected with
+	// a conflict error when either profile has changed since the merge was prepared
+	MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error
+
+	// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one.
+	// It returns nil when the profile has not been merged
+	GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error)
+}
+
+// SigningKeyRepository defines signatures that relate to the keys that the service signs its access
+// tokens with
+type SigningKeyRepository interface {
+	// SaveSigningKey stores a new signing key
+	SaveSigningKey(ctx context.Context, key *domain.SigningKey) error
+
+	// ListSigningKeys reads the signing keys that are still published at the provided time, newest first
+	ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error)
+}
+
+// ListPendingOutboxEvents reads the oldest events that have not been published yet
+func (d DbService) ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	return d.repository.ListPendingOutboxEvents(ctx, limit)
+}
+
+// MarkOutboxEventPublished records that an event has been published
+func (d DbService) MarkOutboxEventPublished(ctx context.Context, id string) error {
+	return d.repository.MarkOutboxEventPublished(ctx, id)
+}
+
+// RecordOutboxEventFailure records a failed attempt to publish an event
+func (d DbService) RecordOutboxEventFailure(ctx context.Context, id string, reason string) error {
+	return d.repository.RecordOutboxEventFailure(ctx, id, reason)
+}
+
+// GetPubSubMessage reads the record of a received message
+func (d DbService) GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error) {
+	return d.repository.GetPubSubMessage(ctx, id)
+}
+
+// SavePubSubMessage creates or replaces the record of a received message
+func (d DbService) SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error {
+	return d.repository.SavePubSubMessage(ctx, message)
+}
+
+// ListFailedPubSubMessages reads the messages that failed or were dead lettered
+func (d DbService) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	return d.repository.ListFailedPubSubMessages(ctx, topicID)
+}
+
+// CreateWebhookEndpoint stores a new webhook endpoint
+func (d DbService) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return d.repository.CreateWebhookEndpoint(ctx, endpoint)
+}
+
+// GetWebhookEndpoint reads a webhook endpoint
+func (d DbService) GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	return d.repository.GetWebhookEndpoint(ctx, id)
+}
+
+// ListWebhookEndpoints reads all the webhook endpoints
+func (d DbService) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return d.repository.ListWebhookEndpoints(ctx)
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint and its deliveries
+func (d DbService) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	return d.repository.DeleteWebhookEndpoint(ctx, id)
+}
+
+// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints that subscribe to it
+func (d DbService) EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error {
+	return d.repository.EnqueueWebhookDeliveries(ctx, event)
+}
+
+// ListDueWebhookDeliveries reads the pending deliveries that are due to be attempted
+func (d DbService) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return d.repository.ListDueWebhookDeliveries(ctx, now, limit)
+}
+
+// GetWebhookDelivery reads a webhook delivery
+func (d DbService) GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	return d.repository.GetWebhookDelivery(ctx, id)
+}
+
+// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+func (d DbService) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return d.repository.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first
+func (d DbService) ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	return d.repository.ListWebhookDeliveries(ctx, endpointID, status)
+}
+
+// SaveSession creates or replaces a session
+func (d DbService) SaveSession(ctx context.Context, session *domain.Session) error {
+	return d.repository.SaveSession(ctx, session)
+}
+
+// GetSessionByRefreshTokenHash reads the session that holds a refresh token
+func (d DbService) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+	return d.repository.GetSessionByRefreshTokenHash(ctx, refreshTokenHash)
+}
+
+// ListSessions reads the sessions of a profile, most recently seen first
+func (d DbService) ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	return d.repository.ListSessions(ctx, profileID)
+}
+
+// RevokeSessions signs out the listed sessions of a profile, or all of them
+func (d DbService) RevokeSessions(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+	return d.repository.RevokeSessions(ctx, profileID, sessionIDs, revokedAt)
+}
+
+// RevokeRefreshTokens revokes all the refresh tokens that were issued to an auth user
+func (d DbService) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	return d.repository.RevokeRefreshTokens(ctx, uid)
+}
+
+// RecordLoginEvent appends an attempt to log in to the audit trail
+func (d DbService) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error {
+	return d.repository.RecordLoginEvent(ctx, event)
+}
+
+// ListLoginEvents reads the login events that match a filter, newest first
+func (d DbService) ListLoginEvents(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error) {
+	return d.repository.ListLoginEvents(ctx, filter)
+}
+
+// SaveLoginAlert creates or replaces a login alert
+func (d DbService) SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error {
+	return d.repository.SaveLoginAlert(ctx, alert)
+}
+
+// GetLoginAlertByTokenHash finds the login alert whose "this wasn't me" token has the provided hash
+func (d DbService) GetLoginAlertByTokenHash(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+	return d.repository.GetLoginAlertByTokenHash(ctx, tokenHash)
+}
+
+// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile. Only the encrypted
+// secret is stored
+func (d DbService) SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+	if d.keyEncryptionKey == nil {
+		return exceptions.InternalServerError(
+			fmt.Errorf("%s is required to store TOTP enrolments", utils.KeyEncryptionKeyEnvVarName),
+		)
+	}
+	sealed := *enrolment
+	if err := utils.SealTOTPSecret(d.keyEncryptionKey, &sealed); err != nil {
+		return exceptions.InternalServerError(err)
+	}
+	sealed.Secret = ""
+	return d.repository.SaveTOTPEnrolment(ctx, &sealed)
+}
+
+// GetTOTPEnrolment reads the TOTP enrolment of a profile and decrypts its secret
+func (d DbService) GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+	enrolment, err := d.repository.GetTOTPEnrolment(ctx, profileID)
+	if err != nil || enrolment == nil {
+		return enrolment, err
+	}
+	if d.keyEncryptionKey == nil {
+		return nil, exceptions.InternalServerError(
+			fmt.Errorf("%s is required to read TOTP enrolments", utils.KeyEncryptionKeyEnvVarName),
+		)
+	}
+	if err := utils.OpenTOTPSecret(d.keyEncryptionKey, enrolment); err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	return enrolment, nil
+}
+
+// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+func (d DbService) DeleteTOTPEnrolment(ctx context.Context, profileID string) error {
+	return d.repository.DeleteTOTPEnrolment(ctx, profileID)
+}
+
+// GetTwoFactorPolicy reads the two factor policy
+func (d DbService) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	return d.repository.GetTwoFactorPolicy(ctx)
+}
+
+// SaveTwoFactorPolicy replaces the two factor policy
+func (d DbService) SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	return d.repository.SaveTwoFactorPolicy(ctx, policy)
+}
+
+// CreateEmailUserAccount creates the account of a user who signs up with an email address
+func (d DbService) CreateEmailUserAccount(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error) {
+	return d.repository.CreateEmailUserAccount(ctx, emailAddress, account)
+}
+
+// SavePassword creates or replaces the password of the email login of a profile
+func (d DbService) SavePassword(ctx context.Context, password *domain.Password) error {
+	return d.repository.SavePassword(ctx, password)
+}
+
+// ReservePasswordAttempt counts an attempt to use the password of the email login of a profile before it is compared
+func (d DbService) ReservePasswordAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.Password, error) {
+	return d.repository.ReservePasswordAttempt(ctx, profileID, attemptedAt)
+}
+
+// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of a profile
+func (d DbService) ClearPasswordAttempts(ctx context.Context, profileID string) error {
+	return d.repository.ClearPasswordAttempts(ctx, profileID)
+}
+
+// RequirePasswordReset stops the password of the email login of a profile from being used until it is reset
+func (d DbService) RequirePasswordReset(ctx context.Context, profileID string) error {
+	return d.repository.RequirePasswordReset(ctx, profileID)
+}
+
+// UpdatePasswordHash replaces the hash of the password of a profile with the one of a rehashed password
+func (d DbService) UpdatePasswordHash(ctx context.Context, password *domain.Password, previousPasswordHash string) error {
+	return d.repository.UpdatePasswordHash(ctx, password, previousPasswordHash)
+}
+
+// GetPasswordByEmailAddress reads the password of the email login of an email address
+func (d DbService) GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error) {
+	return d.repository.GetPasswordByEmailAddress(ctx, emailAddress)
+}
+
+// GetPasswordByProfileID reads the password of the email login of a profile
+func (d DbService) GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error) {
+	return d.repository.GetPasswordByProfileID(ctx, profileID)
+}
+
+// MergeUserProfiles stores the result of a merge and removes the merged profile
+func (d DbService) MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error {
+	return d.repository.MergeUserProfiles(ctx, merge)
+}
+
+// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one
+func (d DbService) GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	return d.repository.GetProfileTombstone(ctx, profileID)
+}
+
+// SaveSigningKey stores a new signing key
+func (d DbService) SaveSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	return d.repository.SaveSigningKey(ctx, key)
+}
+
+// ListSigningKeys reads the signing keys that are still published, newest first
+func (d DbService) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	return d.repository.ListSigningKeys(ctx, now)
 }
@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbService_TOTPEnrolment(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	kek, err := utils.NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	db := database.NewDbServiceWithRepository(repo, kek)
+
+	enrolment, _, err := utils.NewTOTPEnrolment("profile-1", time.Now())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := db.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, enrolment.EncryptedSecret, "the enrolment of the caller should not change")
+
+	// the repository only has the encrypted secret
+	stored, err := repo.GetTOTPEnrolment(ctx, "profile-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, stored.Secret)
+	assert.NotEmpty(t, stored.EncryptedSecret)
+	assert.NotContains(t, stored.EncryptedSecret, enrolment.Secret)
+
+	found, err := db.GetTOTPEnrolment(ctx, "profile-1")
+	assert.Nil(t, err)
+	assert.Equal(t, enrolment.Secret, found.Secret)
+
+	found, err = db.GetTOTPEnrolment(ctx, "profile-2")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	// the secrets can't be read or stored without the key they are encrypted with
+	otherKEK, _ := utils.NewKeyEncryptionKey()
+	_, err = database.NewDbServiceWithRepository(repo, otherKEK).GetTOTPEnrolment(ctx, "profile-1")
+	assert.NotNil(t, err)
+	_, err = database.NewDbServiceWithRepository(repo, nil).GetTOTPEnrolment(ctx, "profile-1")
+	assert.NotNil(t, err)
+	assert.NotNil(t, database.NewDbServiceWithRepository(repo, nil).SaveTOTPEnrolment(ctx, enrolment))
+}
@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
 
 	"cloud.google.com/go/pubsub"
 	"github.com/savannahghi/firebasetools"
@@ -11,6 +13,9 @@ import (
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/engagement"
 	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/social"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks"
 	"github.com/savannahghi/serverutils"
 )
 
@@ -28,6 +33,9 @@ type Infrastructure struct {
 	Database   database.Repository
 	Engagement engagement.ServiceEngagement
 	Pubsub     pubsubmessaging.ServicePubSub
+	Webhooks   webhooks.ServiceWebhooks
+	Social     social.ServiceSocial
+	Tokens     tokens.ServiceTokens
 }
 
 // NewInfrastructureInteractor initializes a new infrastructure interactor
@@ -38,26 +46,66 @@ func NewInfrastructureInteractor() Infrastructure {
 
 	baseExtension := extension.NewBaseExtensionImpl(&firebasetools.FirebaseClient{})
 
-	projectID, err := serverutils.GetEnvVar(serverutils.GoogleCloudProjectIDEnvVarName)
+	pubsub, err := newServicePubSub(ctx, baseExtension, db)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	pubSubClient, err := pubsub.NewClient(ctx, projectID)
-	if err != nil {
-		log.Fatal(err)
-	}
-	pubsub, err := pubsubmessaging.NewServicePubSubMessaging(pubSubClient, baseExtension, db)
+	engagementClient := utils.NewInterServiceClient("engagement", baseExtension)
+	engagement := engagement.NewServiceEngagementImpl(engagementClient, baseExtension)
+
+	webhooks := webhooks.NewServiceWebhooksImpl(db)
+
+	social := social.NewServiceSocial()
+
+	tokens, err := tokens.NewServiceTokens(db)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	engagementClient := utils.NewInterServiceClient("engagement", baseExtension)
-	engagement := engagement.NewServiceEngagementImpl(engagementClient, baseExtension)
-
 	return Infrastructure{
 		db,
 		engagement,
 		pubsub,
+		webhooks,
+		social,
+		tokens,
+	}
+}
+
+// newServicePubSub initializes the pubsub service with the broker that is selected by the
+// `PUBSUB_BROKER` env var. Google Cloud Pub/Sub is used when it is not set
+func newServicePubSub(
+	ctx context.Context,
+	baseExtension extension.BaseExtension,
+	db database.Repository,
+) (*pubsubmessaging.ServicePubSubMessaging, error) {
+	broker := os.Getenv(pubsubmessaging.BrokerEnvVarName)
+	switch broker {
+	case pubsubmessaging.LocalPubSubBroker:
+		// the in-process broker does not need a GCP project
+		return pubsubmessaging.NewLocalServicePubSubMessaging(
+			baseExtension,
+			db,
+			pubsubmessaging.NewLocalBroker(),
+		)
+
+	case "", pubsubmessaging.GooglePubSubBroker:
+		projectID, err := serverutils.GetEnvVar(serverutils.GoogleCloudProjectIDEnvVarName)
+		if err != nil {
+			return nil, err
+		}
+		pubSubClient, err := pubsub.NewClient(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return pubsubmessaging.NewServicePubSubMessaging(pubSubClient, baseExtension, db)
+
+	default:
+		return nil, fmt.Errorf("unknown pubsub broker %q, expected one of %q or %q",
+			broker,
+			pubsubmessaging.GooglePubSubBroker,
+			pubsubmessaging.LocalPubSubBroker,
+		)
 	}
 }
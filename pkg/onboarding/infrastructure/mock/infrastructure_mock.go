@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
@@ -25,6 +26,9 @@ type FakeInfrastructure struct {
 	// GetAllRoles returns a list of all created roles
 	GetAllRolesFn func(ctx context.Context) (*[]profileutils.Role, error)
 
+	// ListRolesPage reads the roles of a page of a listing
+	ListRolesPageFn func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error)
+
 	// GetRoleByID gets role with matching id
 	GetRoleByIDFn func(ctx context.Context, roleID string) (*profileutils.Role, error)
 
@@ -74,6 +78,14 @@ type FakeInfrastructure struct {
 	// this method should be called after asserting the emailAddress is unique and not associated with another userProfile
 	UpdatePrimaryEmailAddressFn func(ctx context.Context, id string, emailAddress string) error
 
+	// ReplacePrimaryPhoneNumber sets the primary phone number of the profile that matches the id and keeps
+	// the previous one as a secondary phone number, in a single write
+	ReplacePrimaryPhoneNumberFn func(ctx context.Context, id string, phoneNumber string) error
+
+	// ReplacePrimaryEmailAddress sets the primary email address of the profile that matches the id and keeps
+	// the previous one as a secondary email address, in a single write
+	ReplacePrimaryEmailAddressFn func(ctx context.Context, id string, emailAddress string) error
+
 	// UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
 	// this method should be called after asserting the phone numbers are unique and not associated with another userProfile
 	UpdateSecondaryPhoneNumbersFn func(ctx context.Context, id string, phoneNumbers []string) error
@@ -92,6 +104,14 @@ type FakeInfrastructure struct {
 	// UpdateVerifiedUIDS adds a UID to a user profile during login if it does not exist
 	UpdateVerifiedUIDSFn func(ctx context.Context, id string, uids []string) error
 
+	// RemoveVerifiedIdentifier removes the verified identifier and the verified UID of a login UID from
+	// the profile that matches the id. The user can no longer log in with that UID
+	RemoveVerifiedIdentifierFn func(ctx context.Context, id string, uid string) error
+
+	// LinkSocialAccount adds the verified identifier and the verified UID of an account with a social
+	// login provider to the profile that matches the id and reserves the UID for that profile
+	LinkSocialAccountFn func(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error
+
 	// UpdateSuspended updates the suspend attribute of the profile that matches the id
 	UpdateSuspendedFn func(ctx context.Context, id string, status bool) error
 
@@ -131,6 +151,132 @@ type FakeInfrastructure struct {
 		role profileutils.RoleType,
 	) ([]*profileutils.UserProfile, error)
 
+	// ListPendingOutboxEvents reads the oldest events that have not been published yet
+	ListPendingOutboxEventsFn func(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+
+	// MarkOutboxEventPublished records that an event has been published
+	MarkOutboxEventPublishedFn func(ctx context.Context, id string) error
+
+	// RecordOutboxEventFailure records a failed attempt to publish an event
+	RecordOutboxEventFailureFn func(ctx context.Context, id string, reason string) error
+
+	// GetPubSubMessage reads the record of a received message
+	GetPubSubMessageFn func(ctx context.Context, id string) (*domain.PubSubMessage, error)
+
+	// SavePubSubMessage creates or replaces the record of a received message
+	SavePubSubMessageFn func(ctx context.Context, message *domain.PubSubMessage) error
+
+	// ListFailedPubSubMessages reads the messages that failed or were dead lettered
+	ListFailedPubSubMessagesFn func(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error)
+
+	// CreateWebhookEndpoint stores a new webhook endpoint
+	CreateWebhookEndpointFn func(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+
+	// GetWebhookEndpoint reads a webhook endpoint
+	GetWebhookEndpointFn func(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+
+	// ListWebhookEndpoints reads all the webhook endpoints
+	ListWebhookEndpointsFn func(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+
+	// DeleteWebhookEndpoint removes a webhook endpoint and its deliveries
+	DeleteWebhookEndpointFn func(ctx context.Context, id string) error
+
+	// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints that subscribe to it
+	EnqueueWebhookDeliveriesFn func(ctx context.Context, event *domain.OutboxEvent) error
+
+	// ListDueWebhookDeliveries reads the pending deliveries that are due to be attempted
+	ListDueWebhookDeliveriesFn func(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+
+	// GetWebhookDelivery reads a webhook delivery
+	GetWebhookDeliveryFn func(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+
+	// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+	UpdateWebhookDeliveryFn func(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first
+	ListWebhookDeliveriesFn func(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error)
+
+	// SaveSession creates or replaces a session
+	SaveSessionFn func(ctx context.Context, session *domain.Session) error
+
+	// GetSessionByRefreshTokenHash reads the session that holds a refresh token
+	GetSessionByRefreshTokenHashFn func(ctx context.Context, refreshTokenHash string) (*domain.Session, error)
+
+	// ListSessions reads the sessions of a profile, most recently seen first
+	ListSessionsFn func(ctx context.Context, profileID string) ([]*domain.Session, error)
+
+	// RevokeSessions signs out the listed sessions of a profile, or all of them
+	RevokeSessionsFn func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error
+
+	// RevokeRefreshTokens revokes all the refresh tokens that were issued to an auth user
+	RevokeRefreshTokensFn func(ctx context.Context, uid string) error
+
+	// RecordLoginEvent appends an attempt to log in to the audit trail
+	RecordLoginEventFn func(ctx context.Context, event *domain.LoginEvent) error
+
+	// ListLoginEvents reads the login events that match a filter, newest first
+	ListLoginEventsFn func(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error)
+
+	// SaveLoginAlert creates or replaces a login alert
+	SaveLoginAlertFn func(ctx context.Context, alert *domain.LoginAlert) error
+
+	// GetLoginAlertByTokenHash finds the login alert whose "this wasn't me" token has the provided hash
+	GetLoginAlertByTokenHashFn func(ctx context.Context, tokenHash string) (*domain.LoginAlert, error)
+
+	// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile
+	SaveTOTPEnrolmentFn func(ctx context.Context, enrolment *domain.TOTPEnrolment) error
+
+	// GetTOTPEnrolment reads the TOTP enrolment of a profile
+	GetTOTPEnrolmentFn func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error)
+
+	// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+	DeleteTOTPEnrolmentFn func(ctx context.Context, profileID string) error
+
+	// GetTwoFactorPolicy reads the two factor policy
+	GetTwoFactorPolicyFn func(ctx context.Context) (*domain.TwoFactorPolicy, error)
+
+	// SaveTwoFactorPolicy replaces the two factor policy
+	SaveTwoFactorPolicyFn func(ctx context.Context, policy *domain.TwoFactorPolicy) error
+
+	// CreateEmailUserAccount creates the account of a user who signs up with an email address
+	CreateEmailUserAccountFn func(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error)
+
+	// SavePassword creates or replaces the password of the email login of a profile
+	SavePasswordFn func(ctx context.Context, password *domain.Password) error
+
+	// ReservePasswordAttempt counts an attempt to use the password of the email login of a profile before it is compared
+	ReservePasswordAttemptFn func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.Password, error)
+
+	// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of a profile
+	ClearPasswordAttemptsFn func(ctx context.Context, profileID string) error
+
+	// RequirePasswordReset stops the password of the email login of a profile from being used until it is reset
+	RequirePasswordResetFn func(ctx context.Context, profileID string) error
+
+	// UpdatePasswordHash replaces the hash of the password of a profile with the one of a rehashed password
+	UpdatePasswordHashFn func(ctx context.Context, password *domain.Password, previousPasswordHash string) error
+
+	// GetPasswordByEmailAddress reads the password of the email login of an email address
+	GetPasswordByEmailAddressFn func(ctx context.Context, emailAddress string) (*domain.Password, error)
+
+	// GetPasswordByProfileID reads the password of the email login of a profile
+	GetPasswordByProfileIDFn func(ctx context.Context, profileID string) (*domain.Password, error)
+
+	// MergeUserProfiles stores the result of a merge and removes the merged profile
+	MergeUserProfilesFn func(ctx context.Context, merge *domain.ProfileMerge) error
+
+	// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one
+	GetProfileTombstoneFn func(ctx context.Context, profileID string) (*domain.ProfileTombstone, error)
+
+	// SaveSigningKey stores a new signing key
+	SaveSigningKeyFn func(ctx context.Context, key *domain.SigningKey) error
+
+	// ListSigningKeys reads the signing keys that are still published, newest first
+	ListSigningKeysFn func(ctx context.Context, now time.Time) ([]*domain.SigningKey, error)
+
+	// ListUserProfilesPage reads the user profiles of a page of a listing
+	ListUserProfilesPageFn func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.UserProfile, error)
+
 	// GetUserProfileByPhoneOrEmail gets usser profile by phone or email
 	GetUserProfileByPhoneOrEmailFn func(ctx context.Context, payload *dto.RetrieveUserProfileInput) (*profileutils.UserProfile, error)
 
@@ -150,6 +296,16 @@ type FakeInfrastructure struct {
 		profile profileutils.UserProfile,
 	) (*profileutils.UserProfile, error)
 
+	// CreateUserAccount creates the auth user, profile, PIN and communications settings of a new user as a single unit
+	CreateUserAccountFn func(
+		ctx context.Context,
+		phoneNumber string,
+		account *domain.UserAccount,
+	) (*domain.UserAccount, error)
+
+	// DeleteUserAccount removes the records of an account created by CreateUserAccount
+	DeleteUserAccountFn func(ctx context.Context, account *domain.UserAccount) error
+
 	// GetUserProfileByUID fetches a user profile by uid
 	GetUserProfileByUIDFn func(
 		ctx context.Context,
@@ -231,6 +387,24 @@ type FakeInfrastructure struct {
 	// UpdatePIN ...
 	UpdatePINFn func(ctx context.Context, id string, pin *domain.PIN) (bool, error)
 
+	// ReservePINAttempt ...
+	ReservePINAttemptFn func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// RecordFailedPINAttempt ...
+	RecordFailedPINAttemptFn func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// ClearPINAttempts ...
+	ClearPINAttemptsFn func(ctx context.Context, profileID string) error
+
+	// RequirePINReset ...
+	RequirePINResetFn func(ctx context.Context, profileID string) error
+
+	// SaveTempPIN ...
+	SaveTempPINFn func(ctx context.Context, pin *domain.PIN) error
+
+	// UpdatePINHash ...
+	UpdatePINHashFn func(ctx context.Context, pin *domain.PIN, previousPINNumber string) error
+
 	// ExchangeRefreshTokenForIDToken ...
 	ExchangeRefreshTokenForIDTokenFn func(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error)
 
@@ -322,6 +496,11 @@ func (f FakeInfrastructure) GetAllRoles(ctx context.Context) (*[]profileutils.Ro
 	return f.GetAllRolesFn(ctx)
 }
 
+// ListRolesPage reads the roles of a page of a listing
+func (f FakeInfrastructure) ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error) {
+	return f.ListRolesPageFn(ctx, query)
+}
+
 // GetRoleByID gets role with matching id
 func (f FakeInfrastructure) GetRoleByID(ctx context.Context, roleID string) (*profileutils.Role, error) {
 	return f.GetRoleByIDFn(ctx, roleID)
@@ -398,6 +577,18 @@ func (f FakeInfrastructure) UpdatePrimaryEmailAddress(ctx context.Context, id st
 	return f.UpdatePrimaryEmailAddressFn(ctx, id, emailAddress)
 }
 
+// ReplacePrimaryPhoneNumber sets the primary phone number of the profile that matches the id and keeps
+// the previous one as a secondary phone number, in a single write
+func (f FakeInfrastructure) ReplacePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error {
+	return f.ReplacePrimaryPhoneNumberFn(ctx, id, phoneNumber)
+}
+
+// ReplacePrimaryEmailAddress sets the primary email address of the profile that matches the id and keeps
+// the previous one as a secondary email address, in a single write
+func (f FakeInfrastructure) ReplacePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error {
+	return f.ReplacePrimaryEmailAddressFn(ctx, id, emailAddress)
+}
+
 // UpdateSecondaryPhoneNumbers updates the secondary phone numbers of the profile that matches the id
 // this method should be called after asserting the phone numbers are unique and not associated with another userProfile
 func (f FakeInfrastructure) UpdateSecondaryPhoneNumbers(ctx context.Context, id string, phoneNumbers []string) error {
@@ -424,6 +615,22 @@ func (f FakeInfrastructure) UpdateVerifiedUIDS(ctx context.Context, id string, u
 	return f.UpdateVerifiedUIDSFn(ctx, id, uids)
 }
 
+// RemoveVerifiedIdentifier removes the verified identifier and the verified UID of a login UID from
+// the profile that matches the id. The user can no longer log in with that UID
+func (f FakeInfrastructure) RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error {
+	return f.RemoveVerifiedIdentifierFn(ctx, id, uid)
+}
+
+// LinkSocialAccount adds the verified identifier and the verified UID of an account with a social
+// login provider to the profile that matches the id and reserves the UID for that profile
+func (f FakeInfrastructure) LinkSocialAccount(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	return f.LinkSocialAccountFn(ctx, id, identifier)
+}
+
 // UpdateSuspended updates the suspend attribute of the profile that matches the id
 func (f FakeInfrastructure) UpdateSuspended(ctx context.Context, id string, status bool) error {
 	return f.UpdateSuspendedFn(ctx, id, status)
@@ -483,6 +690,14 @@ func (f FakeInfrastructure) ListUserProfiles(
 	return f.ListUserProfilesFn(ctx, role)
 }
 
+// ListUserProfilesPage reads the user profiles of a page of a listing
+func (f FakeInfrastructure) ListUserProfilesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.UserProfile, error) {
+	return f.ListUserProfilesPageFn(ctx, query)
+}
+
 // GetUserProfileByPhoneOrEmail gets usser profile by phone or email
 func (f FakeInfrastructure) GetUserProfileByPhoneOrEmail(ctx context.Context, payload *dto.RetrieveUserProfileInput) (*profileutils.UserProfile, error) {
 	return f.GetUserProfileByPhoneOrEmailFn(ctx, payload)
@@ -510,6 +725,20 @@ func (f FakeInfrastructure) CreateDetailedUserProfile(
 	return f.CreateDetailedUserProfileFn(ctx, phoneNumber, profile)
 }
 
+// CreateUserAccount creates the auth user, profile, PIN and communications settings of a new user as a single unit
+func (f FakeInfrastructure) CreateUserAccount(
+	ctx context.Context,
+	phoneNumber string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	return f.CreateUserAccountFn(ctx, phoneNumber, account)
+}
+
+// DeleteUserAccount removes the records of an account created by CreateUserAccount
+func (f FakeInfrastructure) DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error {
+	return f.DeleteUserAccountFn(ctx, account)
+}
+
 // GetUserProfileByUID fetches a user profile by uid
 func (f FakeInfrastructure) GetUserProfileByUID(
 	ctx context.Context,
@@ -632,6 +861,44 @@ func (f FakeInfrastructure) UpdatePIN(ctx context.Context, id string, pin *domai
 	return f.UpdatePINFn(ctx, id, pin)
 }
 
+// ReservePINAttempt ...
+func (f FakeInfrastructure) ReservePINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return f.ReservePINAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// RecordFailedPINAttempt ...
+func (f FakeInfrastructure) RecordFailedPINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return f.RecordFailedPINAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// ClearPINAttempts ...
+func (f FakeInfrastructure) ClearPINAttempts(ctx context.Context, profileID string) error {
+	return f.ClearPINAttemptsFn(ctx, profileID)
+}
+
+// RequirePINReset ...
+func (f FakeInfrastructure) RequirePINReset(ctx context.Context, profileID string) error {
+	return f.RequirePINResetFn(ctx, profileID)
+}
+
+// SaveTempPIN ...
+func (f FakeInfrastructure) SaveTempPIN(ctx context.Context, pin *domain.PIN) error {
+	return f.SaveTempPINFn(ctx, pin)
+}
+
+// UpdatePINHash ...
+func (f FakeInfrastructure) UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+	return f.UpdatePINHashFn(ctx, pin, previousPINNumber)
+}
+
 // ExchangeRefreshTokenForIDToken ...
 func (f FakeInfrastructure) ExchangeRefreshTokenForIDToken(
 	ctx context.Context,
@@ -770,3 +1037,216 @@ func (f FakeInfrastructure) SubscriptionIDs() map[string]string {
 func (f FakeInfrastructure) FetchAllUsers(ctx context.Context, callbackURL string) {
 	f.FetchAllUsersFn(ctx, callbackURL)
 }
+
+// ListPendingOutboxEvents reads the oldest events that have not been published yet
+func (f FakeInfrastructure) ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	return f.ListPendingOutboxEventsFn(ctx, limit)
+}
+
+// MarkOutboxEventPublished records that an event has been published
+func (f FakeInfrastructure) MarkOutboxEventPublished(ctx context.Context, id string) error {
+	return f.MarkOutboxEventPublishedFn(ctx, id)
+}
+
+// RecordOutboxEventFailure records a failed attempt to publish an event
+func (f FakeInfrastructure) RecordOutboxEventFailure(ctx context.Context, id string, reason string) error {
+	return f.RecordOutboxEventFailureFn(ctx, id, reason)
+}
+
+// GetPubSubMessage reads the record of a received message
+func (f FakeInfrastructure) GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error) {
+	return f.GetPubSubMessageFn(ctx, id)
+}
+
+// SavePubSubMessage creates or replaces the record of a received message
+func (f FakeInfrastructure) SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error {
+	return f.SavePubSubMessageFn(ctx, message)
+}
+
+// ListFailedPubSubMessages reads the messages that failed or were dead lettered
+func (f FakeInfrastructure) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	return f.ListFailedPubSubMessagesFn(ctx, topicID)
+}
+
+// CreateWebhookEndpoint stores a new webhook endpoint
+func (f FakeInfrastructure) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return f.CreateWebhookEndpointFn(ctx, endpoint)
+}
+
+// GetWebhookEndpoint reads a webhook endpoint
+func (f FakeInfrastructure) GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	return f.GetWebhookEndpointFn(ctx, id)
+}
+
+// ListWebhookEndpoints reads all the webhook endpoints
+func (f FakeInfrastructure) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return f.ListWebhookEndpointsFn(ctx)
+}
+
+// DeleteWebhookEndpoint removes a webhook endpoint and its deliveries
+func (f FakeInfrastructure) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	return f.DeleteWebhookEndpointFn(ctx, id)
+}
+
+// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints that subscribe to it
+func (f FakeInfrastructure) EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error {
+	return f.EnqueueWebhookDeliveriesFn(ctx, event)
+}
+
+// ListDueWebhookDeliveries reads the pending deliveries that are due to be attempted
+func (f FakeInfrastructure) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return f.ListDueWebhookDeliveriesFn(ctx, now, limit)
+}
+
+// GetWebhookDelivery reads a webhook delivery
+func (f FakeInfrastructure) GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	return f.GetWebhookDeliveryFn(ctx, id)
+}
+
+// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+func (f FakeInfrastructure) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return f.UpdateWebhookDeliveryFn(ctx, delivery)
+}
+
+// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first
+func (f FakeInfrastructure) ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	return f.ListWebhookDeliveriesFn(ctx, endpointID, status)
+}
+
+// SaveSession creates or replaces a session
+func (f FakeInfrastructure) SaveSession(ctx context.Context, session *domain.Session) error {
+	return f.SaveSessionFn(ctx, session)
+}
+
+// GetSessionByRefreshTokenHash reads the session that holds a refresh token
+func (f FakeInfrastructure) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+	return f.GetSessionByRefreshTokenHashFn(ctx, refreshTokenHash)
+}
+
+// ListSessions reads the sessions of a profile, most recently seen first
+func (f FakeInfrastructure) ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	return f.ListSessionsFn(ctx, profileID)
+}
+
+// RevokeSessions signs out the listed sessions of a profile, or all of them
+func (f FakeInfrastructure) RevokeSessions(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+	return f.RevokeSessionsFn(ctx, profileID, sessionIDs, revokedAt)
+}
+
+// RevokeRefreshTokens revokes all the refresh tokens that were issued to an auth user
+func (f FakeInfrastructure) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	return f.RevokeRefreshTokensFn(ctx, uid)
+}
+
+// RecordLoginEvent appends an attempt to log in to the audit trail
+func (f FakeInfrastructure) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error {
+	return f.RecordLoginEventFn(ctx, event)
+}
+
+// ListLoginEvents reads the login events that match a filter, newest first
+func (f FakeInfrastructure) ListLoginEvents(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error) {
+	return f.ListLoginEventsFn(ctx, filter)
+}
+
+// SaveLoginAlert creates or replaces a login alert
+func (f FakeInfrastructure) SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error {
+	return f.SaveLoginAlertFn(ctx, alert)
+}
+
+// GetLoginAlertByTokenHash finds the login alert whose "this wasn't me" token has the provided hash
+func (f FakeInfrastructure) GetLoginAlertByTokenHash(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+	return f.GetLoginAlertByTokenHashFn(ctx, tokenHash)
+}
+
+// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile
+func (f FakeInfrastructure) SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+	return f.SaveTOTPEnrolmentFn(ctx, enrolment)
+}
+
+// GetTOTPEnrolment reads the TOTP enrolment of a profile
+func (f FakeInfrastructure) GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+	return f.GetTOTPEnrolmentFn(ctx, profileID)
+}
+
+// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+func (f FakeInfrastructure) DeleteTOTPEnrolment(ctx context.Context, profileID string) error {
+	return f.DeleteTOTPEnrolmentFn(ctx, profileID)
+}
+
+// GetTwoFactorPolicy reads the two factor policy
+func (f FakeInfrastructure) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	return f.GetTwoFactorPolicyFn(ctx)
+}
+
+// SaveTwoFactorPolicy replaces the two factor policy
+func (f FakeInfrastructure) SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	return f.SaveTwoFactorPolicyFn(ctx, policy)
+}
+
+// CreateEmailUserAccount creates the account of a user who signs up with an email address
+func (f FakeInfrastructure) CreateEmailUserAccount(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error) {
+	return f.CreateEmailUserAccountFn(ctx, emailAddress, account)
+}
+
+// SavePassword creates or replaces the password of the email login of a profile
+func (f FakeInfrastructure) SavePassword(ctx context.Context, password *domain.Password) error {
+	return f.SavePasswordFn(ctx, password)
+}
+
+// ReservePasswordAttempt counts an attempt to use the password of the email login of a profile before it is compared
+func (f FakeInfrastructure) ReservePasswordAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.Password, error) {
+	return f.ReservePasswordAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of a profile
+func (f FakeInfrastructure) ClearPasswordAttempts(ctx context.Context, profileID string) error {
+	return f.ClearPasswordAttemptsFn(ctx, profileID)
+}
+
+// RequirePasswordReset stops the password of the email login of a profile from being used until it is reset
+func (f FakeInfrastructure) RequirePasswordReset(ctx context.Context, profileID string) error {
+	return f.RequirePasswordResetFn(ctx, profileID)
+}
+
+// UpdatePasswordHash replaces the hash of the password of a profile with the one of a rehashed password
+func (f FakeInfrastructure) UpdatePasswordHash(
+	ctx context.Context,
+	password *domain.Password,
+	previousPasswordHash string,
+) error {
+	return f.UpdatePasswordHashFn(ctx, password, previousPasswordHash)
+}
+
+// GetPasswordByEmailAddress reads the password of the email login of an email address
+func (f FakeInfrastructure) GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error) {
+	return f.GetPasswordByEmailAddressFn(ctx, emailAddress)
+}
+
+// GetPasswordByProfileID reads the password of the email login of a profile
+func (f FakeInfrastructure) GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error) {
+	return f.GetPasswordByProfileIDFn(ctx, profileID)
+}
+
+// MergeUserProfiles stores the result of a merge and removes the merged profile
+func (f FakeInfrastructure) MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error {
+	return f.MergeUserProfilesFn(ctx, merge)
+}
+
+// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one
+func (f FakeInfrastructure) GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	return f.GetProfileTombstoneFn(ctx, profileID)
+}
+
+// SaveSigningKey stores a new signing key
+func (f FakeInfrastructure) SaveSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	return f.SaveSigningKeyFn(ctx, key)
+}
+
+// ListSigningKeys reads the signing keys that are still published, newest first
+func (f FakeInfrastructure) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	return f.ListSigningKeysFn(ctx, now)
+}
@@ -29,6 +29,14 @@ type FakeServiceEngagement struct {
 	VerifyEmailOTPFn func(ctx context.Context, email, OTP string) (bool, error)
 
 	SendSMSFn func(ctx context.Context, phoneNumbers []string, message string) error
+
+	SendPushNotificationFn func(
+		ctx context.Context,
+		pushTokens []string,
+		title string,
+		body string,
+		data map[string]string,
+	) error
 }
 
 // ResolveDefaultNudgeByTitle ...
@@ -89,3 +97,14 @@ func (f *FakeServiceEngagement) VerifyEmailOTP(ctx context.Context, email, OTP s
 func (f *FakeServiceEngagement) SendSMS(ctx context.Context, phoneNumbers []string, message string) error {
 	return f.SendSMSFn(ctx, phoneNumbers, message)
 }
+
+// SendPushNotification ...
+func (f *FakeServiceEngagement) SendPushNotification(
+	ctx context.Context,
+	pushTokens []string,
+	title string,
+	body string,
+	data map[string]string,
+) error {
+	return f.SendPushNotificationFn(ctx, pushTokens, title, body, data)
+}
@@ -34,6 +34,8 @@ const (
 	VerifyOTPEndPoint = "internal/verify_otp/"
 
 	sendSMS = "internal/send_sms"
+
+	sendPushNotification = "internal/send_notification"
 )
 
 // ServiceEngagement represents engagement usecases
@@ -70,6 +72,14 @@ type ServiceEngagement interface {
 	VerifyEmailOTP(ctx context.Context, email, OTP string) (bool, error)
 
 	SendSMS(ctx context.Context, phoneNumbers []string, message string) error
+
+	SendPushNotification(
+		ctx context.Context,
+		pushTokens []string,
+		title string,
+		body string,
+		data map[string]string,
+	) error
 }
 
 // ServiceEngagementImpl represents engagement usecases
@@ -351,3 +361,36 @@ func (en *ServiceEngagementImpl) SendSMS(ctx context.Context, phoneNumbers []str
 
 	return nil
 }
+
+// SendPushNotification sends a push notification to the devices with the provided FCM push tokens.
+// The data is delivered to the app together with the notification
+func (en *ServiceEngagementImpl) SendPushNotification(
+	ctx context.Context,
+	pushTokens []string,
+	title string,
+	body string,
+	data map[string]string,
+) error {
+	if len(pushTokens) == 0 {
+		return fmt.Errorf("no push tokens to send the notification to")
+	}
+
+	payload := map[string]interface{}{
+		"registrationTokens": pushTokens,
+		"notification": map[string]string{
+			"title": title,
+			"body":  body,
+		},
+		"data": data,
+	}
+
+	resp, err := en.Engage.MakeRequest(ctx, http.MethodPost, sendPushNotification, payload)
+	if err != nil {
+		return fmt.Errorf("unable to send push notification: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to send push notification, with status code %v", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package pubsubmessaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/pubsubtools"
+)
+
+const (
+	// BrokerEnvVarName is the env var that selects the pubsub broker the service uses
+	BrokerEnvVarName = "PUBSUB_BROKER"
+
+	// GooglePubSubBroker is the value of the env when using Google Cloud Pub/Sub. It is the default
+	GooglePubSubBroker = "gcp"
+
+	// LocalPubSubBroker is the value of the env when using the in-process broker
+	LocalPubSubBroker = "local"
+
+	// localMaxDeliveryAttempts is the number of times the local broker pushes a message to a
+	// subscription before it gives up. Messages that were not delivered can be replayed
+	localMaxDeliveryAttempts = 10
+
+	// localRetryBackoff is the delay before the first redelivery, it doubles after every attempt
+	localRetryBackoff = 100 * time.Millisecond
+
+	// localMessageRetention is how long the local broker keeps the messages of a topic for replays
+	localMessageRetention = 24 * time.Hour
+
+	// localMaxRetainedMessages is the number of the latest messages of a topic that the local
+	// broker keeps for replays
+	localMaxRetainedMessages = 1000
+
+	// LocalBrokerSecretHeader is the header of the messages pushed by the local broker that carries
+	// its secret. Pushed messages without the secret are refused, since they are not signed
+	LocalBrokerSecretHeader = "X-Local-Broker-Secret"
+)
+
+// localMessage is a message published to the local broker. Messages are retained so that
+// they can be replayed
+type localMessage struct {
+	message   pubsubtools.PubSubMessage
+	published time.Time
+}
+
+// localSubscription pushes the messages of a topic to an endpoint
+type localSubscription struct {
+	topicID      string
+	pushEndpoint string
+}
+
+// LocalBroker is an in-process stand in for Google Cloud Pub/Sub. Topics and push subscriptions
+// only live as long as the process. Published messages are pushed to the subscriptions of their
+// topic in the background with the same payload that Pub/Sub pushes, and are retried with a
+// backoff while the endpoint does not acknowledge them. Every push carries a secret that is
+// generated when the broker is created, in place of the JWT that Pub/Sub signs its pushes with.
+// The messages of a topic are kept for replays for `localMessageRetention`, up to
+// `localMaxRetainedMessages` of them. It is meant for local development
+type LocalBroker struct {
+	mu            sync.Mutex
+	topics        map[string][]*localMessage
+	subscriptions map[string]*localSubscription
+	secret        string
+
+	client     *http.Client
+	deliveries sync.WaitGroup
+}
+
+// NewLocalBroker initializes an empty in-process broker
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{
+		topics:        map[string][]*localMessage{},
+		subscriptions: map[string]*localSubscription{},
+		secret:        uuid.NewString(),
+		client:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// IsPushedByBroker checks whether a request carries the secret of the broker, which means that
+// it was pushed by the broker
+func (b *LocalBroker) IsPushedByBroker(r *http.Request) bool {
+	secret := r.Header.Get(LocalBrokerSecretHeader)
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(b.secret)) == 1
+}
+
+// CreateTopic creates a topic if it does not already exist
+func (b *LocalBroker) CreateTopic(topicID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.topics[topicID]; !ok {
+		b.topics[topicID] = []*localMessage{}
+	}
+}
+
+// CreateSubscription creates or updates a push subscription of an existing topic
+func (b *LocalBroker) CreateSubscription(subscriptionID string, topicID string, pushEndpoint string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.topics[topicID]; !ok {
+		return fmt.Errorf("topic %s does not exist, can't subscribe to it", topicID)
+	}
+	b.subscriptions[subscriptionID] = &localSubscription{
+		topicID:      topicID,
+		pushEndpoint: pushEndpoint,
+	}
+	return nil
+}
+
+// Publish adds a message to a topic and pushes it to the subscriptions of the topic.
+// It returns the ID of the message
+func (b *LocalBroker) Publish(topicID string, data []byte) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages, ok := b.topics[topicID]
+	if !ok {
+		return "", fmt.Errorf("topic %s is not registered, can't publish to it", topicID)
+	}
+
+	now := time.Now()
+	message := &localMessage{
+		message: pubsubtools.PubSubMessage{
+			// the ID is unique across restarts, since the receiver deduplicates messages by their ID
+			MessageID:  uuid.New().String(),
+			Data:       data,
+			Attributes: map[string]string{"topicID": topicID},
+		},
+		published: now,
+	}
+	b.topics[topicID] = retainedMessages(append(messages, message), now)
+
+	for subscriptionID, subscription := range b.subscriptions {
+		if subscription.topicID == topicID {
+			b.deliver(subscriptionID, subscription.pushEndpoint, message.message)
+		}
+	}
+	return message.message.MessageID, nil
+}
+
+// Replay pushes the messages of the topic of a subscription that were published at or after
+// `since` to the subscription again. It returns the number of messages that are replayed.
+// Messages that have already been handled are ignored by the receiver
+func (b *LocalBroker) Replay(subscriptionID string, since time.Time) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription, ok := b.subscriptions[subscriptionID]
+	if !ok {
+		return 0, fmt.Errorf("subscription %s does not exist", subscriptionID)
+	}
+
+	replayed := 0
+	for _, message := range b.topics[subscription.topicID] {
+		if message.published.Before(since) {
+			continue
+		}
+		b.deliver(subscriptionID, subscription.pushEndpoint, message.message)
+		replayed++
+	}
+	return replayed, nil
+}
+
+// retainedMessages drops the messages of a topic that are older than `localMessageRetention`, and
+// the oldest ones beyond `localMaxRetainedMessages`. Messages are kept in the order they were
+// published
+func retainedMessages(messages []*localMessage, now time.Time) []*localMessage {
+	first := 0
+	if len(messages) > localMaxRetainedMessages {
+		first = len(messages) - localMaxRetainedMessages
+	}
+	cutoff := now.Add(-localMessageRetention)
+	for first < len(messages) && messages[first].published.Before(cutoff) {
+		first++
+	}
+	if first == 0 {
+		return messages
+	}
+	// copy the messages so that the dropped ones can be garbage collected
+	return append([]*localMessage{}, messages[first:]...)
+}
+
+// Wait blocks until the messages that are being pushed have been delivered or given up on
+func (b *LocalBroker) Wait() {
+	b.deliveries.Wait()
+}
+
+// deliver pushes a message to a subscription in the background. The caller must hold the lock
+func (b *LocalBroker) deliver(subscriptionID string, pushEndpoint string, message pubsubtools.PubSubMessage) {
+	b.deliveries.Add(1)
+	go func() {
+		defer b.deliveries.Done()
+
+		payload := pubsubtools.PubSubPayload{
+			Message:      message,
+			Subscription: subscriptionID,
+		}
+		backoff := localRetryBackoff
+		for attempt := 1; attempt <= localMaxDeliveryAttempts; attempt++ {
+			err := b.push(pushEndpoint, payload)
+			if err == nil {
+				return
+			}
+			if attempt == localMaxDeliveryAttempts {
+				log.Printf(
+					"giving up on pushing message %s to %s after %d attempts: %v",
+					message.MessageID, subscriptionID, attempt, err,
+				)
+				return
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}()
+}
+
+// push sends a message to a push endpoint. Any 2xx response acknowledges the message
+func (b *LocalBroker) push(pushEndpoint string, payload pubsubtools.PubSubPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, pushEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(LocalBrokerSecretHeader, b.secret)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("push endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package pubsubmessaging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	extMock "github.com/savannahghi/onboarding/pkg/onboarding/application/extension/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/savannahghi/pubsubtools"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLocalTestPubSub starts a server that receives the messages pushed by a local broker
+func newLocalTestPubSub(
+	t *testing.T,
+	ext *extMock.FakeBaseExtensionImpl,
+	repo *memory.Repository,
+	broker *pubsubmessaging.LocalBroker,
+) *pubsubmessaging.ServicePubSubMessaging {
+	var ps *pubsubmessaging.ServicePubSubMessaging
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pubsub", func(w http.ResponseWriter, r *http.Request) {
+		ps.ReceivePubSubPushMessages(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	setupFakeExtension(ext, server.URL)
+	ext.SubscriptionIDsFn = func(topicIDs []string) map[string]string {
+		subscriptions := map[string]string{}
+		for _, topicID := range topicIDs {
+			subscriptions[topicID] = topicID + "-default-subscription"
+		}
+		return subscriptions
+	}
+	ext.GetPubSubTopicFn = func(m *pubsubtools.PubSubPayload) (string, error) {
+		return m.Message.Attributes["topicID"], nil
+	}
+	ext.ErrorMapFn = func(err error) map[string]string {
+		return map[string]string{"error": err.Error()}
+	}
+	ext.WriteJSONResponseFn = func(w http.ResponseWriter, source interface{}, status int) {
+		w.WriteHeader(status)
+	}
+
+	var err error
+	ps, err = pubsubmessaging.NewLocalServicePubSubMessaging(ext, repo, broker)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	return ps
+}
+
+func TestLocalServicePubSubMessaging_PublishAndReceive(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	broker := pubsubmessaging.NewLocalBroker()
+	ps := newLocalTestPubSub(t, &extMock.FakeBaseExtensionImpl{}, repo, broker)
+
+	profile, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	data, err := json.Marshal(domain.AccountDeletionRequestedEvent{UID: "uid-1"})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	messageID, err := broker.Publish(accountDeletionTopic, data)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	broker.Wait()
+
+	_, err = repo.GetUserProfileByID(ctx, profile.ID, true)
+	assert.NotNil(t, err)
+
+	record, err := repo.GetPubSubMessage(ctx, messageID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.PubSubMessageStatusProcessed, record.Status)
+
+	// replayed messages that have been handled are not handled again
+	replayed, err := ps.ReplayMessages(ctx, accountDeletionTopic+"-default-subscription", time.Time{})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, replayed)
+	broker.Wait()
+	record, err = repo.GetPubSubMessage(ctx, messageID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, record.Attempts)
+
+	_, err = ps.ReplayMessages(ctx, "unknown-subscription", time.Time{})
+	assert.NotNil(t, err)
+
+	// a broker that is started again, e.g. after a restart, does not reuse the IDs of the messages
+	// that were handled, which would make the receiver drop its messages as redeliveries
+	restarted := pubsubmessaging.NewLocalBroker()
+	restarted.CreateTopic(accountDeletionTopic)
+	restartedID, err := restarted.Publish(accountDeletionTopic, data)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.NotEqual(t, messageID, restartedID)
+
+	err = ps.PublishToPubsub(ctx, "engagement-unknown-testing-v1", data)
+	assert.NotNil(t, err)
+}
+
+func TestLocalServicePubSubMessaging_Redelivery(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	broker := pubsubmessaging.NewLocalBroker()
+	ps := newLocalTestPubSub(t, &extMock.FakeBaseExtensionImpl{}, repo, broker)
+
+	// a handler registered after the service started needs a subscription
+	topicID := "engagement-nudge.resolved-testing-v1"
+	handled := 0
+	messageID := ""
+	ps.RegisterHandler(topicID, func(ctx context.Context, message *pubsubtools.PubSubMessage) error {
+		handled++
+		messageID = message.MessageID
+		if handled == 1 {
+			return fmt.Errorf("nudge service unavailable")
+		}
+		return nil
+	})
+	if err := ps.EnsureTopicsExist(ctx, []string{topicID}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := ps.EnsureSubscriptionsExist(ctx); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	if err := ps.PublishToPubsub(ctx, topicID, []byte(`{}`)); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	broker.Wait()
+	assert.Equal(t, 2, handled)
+
+	record, err := repo.GetPubSubMessage(ctx, messageID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.PubSubMessageStatusProcessed, record.Status)
+	assert.Equal(t, 2, record.Attempts)
+
+	// the domain events are relayed through the local broker too
+	if _, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	count, err := ps.RelayOutboxEvents(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestLocalServicePubSubMessaging_UnsignedPush(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	broker := pubsubmessaging.NewLocalBroker()
+	ps := newLocalTestPubSub(t, &extMock.FakeBaseExtensionImpl{}, repo, broker)
+
+	profile, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	data, err := json.Marshal(domain.AccountDeletionRequestedEvent{UID: "uid-1"})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	body, err := json.Marshal(pubsubtools.PubSubPayload{
+		Message: pubsubtools.PubSubMessage{
+			MessageID:  "forged-1",
+			Data:       data,
+			Attributes: map[string]string{"topicID": accountDeletionTopic},
+		},
+		Subscription: accountDeletionTopic + "-default-subscription",
+	})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	for _, secret := range []string{"", "not-the-secret"} {
+		req := httptest.NewRequest(http.MethodPost, "/pubsub", bytes.NewReader(body))
+		if secret != "" {
+			req.Header.Set(pubsubmessaging.LocalBrokerSecretHeader, secret)
+		}
+		response := httptest.NewRecorder()
+		ps.ReceivePubSubPushMessages(response, req)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	}
+
+	// the account was not deleted
+	_, err = repo.GetUserProfileByID(ctx, profile.ID, true)
+	assert.Nil(t, err)
+	record, err := repo.GetPubSubMessage(ctx, "forged-1")
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+}
+
+func TestLocalBroker_Retention(t *testing.T) {
+	pushed := make(chan struct{}, 2000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushed <- struct{}{}
+	}))
+	t.Cleanup(server.Close)
+
+	broker := pubsubmessaging.NewLocalBroker()
+	broker.CreateTopic("topic-1")
+	for i := 0; i < 1005; i++ {
+		if _, err := broker.Publish("topic-1", []byte(`{}`)); err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+	}
+	if err := broker.CreateSubscription("subscription-1", "topic-1", server.URL); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// only the latest messages are kept for replays
+	replayed, err := broker.Replay("subscription-1", time.Time{})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1000, replayed)
+	broker.Wait()
+	assert.Len(t, pushed, 1000)
+}
@@ -0,0 +1,142 @@
+package pubsubmessaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/pubsubtools"
+)
+
+const (
+	// MaxPubSubMessageAttempts is the number of times a received message is handled before
+	// it is dead lettered
+	MaxPubSubMessageAttempts = 5
+
+	// AccountDeletionRequestedTopic is published by the engagement service when a user asks
+	// for their account to be deleted
+	AccountDeletionRequestedTopic = "account.deletion.requested"
+
+	// deadLetterTopicName is the topic that the messages which could not be handled are
+	// published to. The service does not subscribe to it
+	deadLetterTopicName = "dead-letter"
+)
+
+// ErrUnprocessableMessage is returned by a handler for a message that can never be handled
+// e.g. one whose data can not be decoded. Such a message is dead lettered without being retried
+var ErrUnprocessableMessage = errors.New("unprocessable pubsub message")
+
+// PubSubHandler handles the messages of a topic that the service subscribes to. A message
+// whose handler returns an error is delivered again by pubsub, so handlers should be safe
+// to run more than once for the same message
+type PubSubHandler func(ctx context.Context, message *pubsubtools.PubSubMessage) error
+
+// DeadLetter is the payload published to the dead letter topic
+type DeadLetter struct {
+	MessageID  string            `json:"messageID"`
+	TopicID    string            `json:"topicID"`
+	Data       []byte            `json:"data"`
+	Attributes map[string]string `json:"attributes"`
+	Attempts   int               `json:"attempts"`
+	LastError  string            `json:"lastError"`
+}
+
+// DecodeMessageData unmarshals the JSON data of a message into the typed payload of a handler
+func DecodeMessageData(message *pubsubtools.PubSubMessage, payload interface{}) error {
+	if err := json.Unmarshal(message.Data, payload); err != nil {
+		return fmt.Errorf("%w: unable to decode message %s: %v", ErrUnprocessableMessage, message.MessageID, err)
+	}
+	return nil
+}
+
+// RegisterHandler sets the handler of the messages of a topic and subscribes the service
+// to the topic. Handlers are registered when the service starts, before any message is received
+func (ps ServicePubSubMessaging) RegisterHandler(topicID string, handler PubSubHandler) {
+	ps.handlers[topicID] = handler
+}
+
+// DeadLetterTopicID returns the namespaced topic that messages which could not be handled are published to
+func (ps ServicePubSubMessaging) DeadLetterTopicID() string {
+	return ps.AddPubSubNamespace(deadLetterTopicName)
+}
+
+// subscribedTopicIDs returns the topics that have a registered handler
+func (ps ServicePubSubMessaging) subscribedTopicIDs() []string {
+	topics := []string{}
+	for topicID := range ps.handlers {
+		topics = append(topics, topicID)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// registerDefaultHandlers registers the handlers of the events of other services that the
+// service reacts to
+func (ps ServicePubSubMessaging) registerDefaultHandlers() {
+	ps.RegisterHandler(
+		ps.AddEngagementPubsubNameSpace(AccountDeletionRequestedTopic),
+		ps.handleAccountDeletionRequested,
+	)
+}
+
+// handleAccountDeletionRequested removes the account of a user who asked another service to
+// delete it. An account that no longer exists has already been removed and is not an error
+func (ps ServicePubSubMessaging) handleAccountDeletionRequested(
+	ctx context.Context,
+	message *pubsubtools.PubSubMessage,
+) error {
+	ctx, span := tracer.Start(ctx, "handleAccountDeletionRequested")
+	defer span.End()
+
+	payload := domain.AccountDeletionRequestedEvent{}
+	if err := DecodeMessageData(message, &payload); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if payload.UID == "" && payload.PhoneNumber == nil {
+		return fmt.Errorf("%w: expected a uid or a phone number", ErrUnprocessableMessage)
+	}
+
+	account := &domain.UserAccount{UID: payload.UID}
+	if payload.UID != "" {
+		profile, err := ps.database.GetUserProfileByUID(ctx, payload.UID, true)
+		if err != nil && !exceptions.IsProfileNotFoundError(err) {
+			utils.RecordSpanError(span, err)
+			return err
+		}
+		account.Profile = profile
+	}
+	if account.Profile == nil && payload.PhoneNumber != nil {
+		phoneNumber, err := converterandformatter.NormalizeMSISDN(*payload.PhoneNumber)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnprocessableMessage, err)
+		}
+		profile, err := ps.database.GetUserProfileByPhoneNumber(ctx, *phoneNumber, true)
+		if err != nil && !exceptions.IsProfileNotFoundError(err) {
+			utils.RecordSpanError(span, err)
+			return err
+		}
+		account.Profile = profile
+	}
+	if account.Profile == nil {
+		return nil
+	}
+
+	// the auth user is removed together with the profile
+	if account.UID == "" && len(account.Profile.VerifiedUIDS) > 0 {
+		account.UID = account.Profile.VerifiedUIDS[0]
+	}
+	account.NewAuthUser = account.UID != ""
+
+	if err := ps.database.DeleteUserAccount(ctx, account); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package mock
 import (
 	"context"
 	"net/http"
+	"time"
+
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
 )
 
 // FakeServicePubSub ...
@@ -27,6 +30,10 @@ type FakeServicePubSub struct {
 		r *http.Request,
 	)
 	AddEngagementPubsubNameSpaceFn func(topic string) string
+	RelayOutboxEventsFn            func(ctx context.Context) (int, error)
+	StartOutboxRelayFn             func(ctx context.Context, interval time.Duration)
+	RegisterHandlerFn              func(topicID string, handler pubsubmessaging.PubSubHandler)
+	ReplayMessagesFn               func(ctx context.Context, subscriptionID string, since time.Time) (int, error)
 }
 
 // AddPubSubNamespace ...
@@ -84,3 +91,23 @@ func (m *FakeServicePubSub) ReceivePubSubPushMessages(
 func (m *FakeServicePubSub) AddEngagementPubsubNameSpace(topic string) string {
 	return m.AddEngagementPubsubNameSpaceFn(topic)
 }
+
+// RelayOutboxEvents ...
+func (m *FakeServicePubSub) RelayOutboxEvents(ctx context.Context) (int, error) {
+	return m.RelayOutboxEventsFn(ctx)
+}
+
+// StartOutboxRelay ...
+func (m *FakeServicePubSub) StartOutboxRelay(ctx context.Context, interval time.Duration) {
+	m.StartOutboxRelayFn(ctx, interval)
+}
+
+// RegisterHandler ...
+func (m *FakeServicePubSub) RegisterHandler(topicID string, handler pubsubmessaging.PubSubHandler) {
+	m.RegisterHandlerFn(topicID, handler)
+}
+
+// ReplayMessages ...
+func (m *FakeServicePubSub) ReplayMessages(ctx context.Context, subscriptionID string, since time.Time) (int, error) {
+	return m.ReplayMessagesFn(ctx, subscriptionID, since)
+}
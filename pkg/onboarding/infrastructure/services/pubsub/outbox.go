@@ -0,0 +1,80 @@
+package pubsubmessaging
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+)
+
+const (
+	// OutboxRelayInterval is how often the outbox relay looks for pending events
+	OutboxRelayInterval = 5 * time.Second
+
+	// outboxBatchSize is the number of events the relay publishes in a single run
+	outboxBatchSize = 100
+)
+
+// RelayOutboxEvents publishes the pending events in the outbox in the order they were written.
+// An event is marked as published only after pubsub has accepted it, so an event whose marking
+// fails is published again on the next run. Consumers should ignore events whose ID they have already seen.
+// The webhook deliveries of an event are enqueued when it is relayed.
+// It returns the number of events that were published
+func (ps ServicePubSubMessaging) RelayOutboxEvents(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "RelayOutboxEvents")
+	defer span.End()
+
+	events, err := ps.database.ListPendingOutboxEvents(ctx, outboxBatchSize)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		// the webhook deliveries are enqueued before the event is marked as published so that
+		// they are not lost. Enqueueing an event again has no effect
+		if err := ps.database.EnqueueWebhookDeliveries(ctx, event); err != nil {
+			utils.RecordSpanError(span, err)
+			if recordErr := ps.database.RecordOutboxEventFailure(ctx, event.ID, err.Error()); recordErr != nil {
+				utils.RecordSpanError(span, recordErr)
+				return published, recordErr
+			}
+			continue
+		}
+		topicID := ps.AddPubSubNamespace(string(event.Type))
+		if err := ps.PublishToPubsub(ctx, topicID, []byte(event.Payload)); err != nil {
+			utils.RecordSpanError(span, err)
+			if recordErr := ps.database.RecordOutboxEventFailure(ctx, event.ID, err.Error()); recordErr != nil {
+				utils.RecordSpanError(span, recordErr)
+				return published, recordErr
+			}
+			continue
+		}
+		if err := ps.database.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+			utils.RecordSpanError(span, err)
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// StartOutboxRelay relays the outbox events at the provided interval until the context is cancelled
+func (ps ServicePubSubMessaging) StartOutboxRelay(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ps.RelayOutboxEvents(ctx); err != nil {
+					log.Printf("unable to relay outbox events: %v", err)
+				}
+			}
+		}
+	}()
+}
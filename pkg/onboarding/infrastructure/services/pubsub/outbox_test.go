@@ -0,0 +1,194 @@
+package pubsubmessaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	extMock "github.com/savannahghi/onboarding/pkg/onboarding/application/extension/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPubSub(t *testing.T, ext *extMock.FakeBaseExtensionImpl, repo *memory.Repository) *pubsubmessaging.ServicePubSubMessaging {
+	setupFakeExtension(ext, "https://onboarding.example.com")
+
+	ps, err := pubsubmessaging.NewServicePubSubMessaging(nil, ext, repo)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	return ps
+}
+
+func setupFakeExtension(ext *extMock.FakeBaseExtensionImpl, serviceHost string) {
+	ext.GetRunningEnvironmentFn = func() string {
+		return "testing"
+	}
+	ext.NamespacePubsubIdentifierFn = func(serviceName, topicID, environment, version string) string {
+		return fmt.Sprintf("%s-%s-%s-%s", serviceName, topicID, environment, version)
+	}
+	ext.EnsureTopicsExistFn = func(ctx context.Context, pubsubClient *pubsub.Client, topicIDs []string) error {
+		return nil
+	}
+	ext.GetEnvVarFn = func(envName string) (string, error) {
+		return serviceHost, nil
+	}
+	ext.PubSubHandlerPathFn = func() string {
+		return "/pubsub"
+	}
+	ext.SubscriptionIDsFn = func(topicIDs []string) map[string]string {
+		return map[string]string{}
+	}
+	ext.EnsureSubscriptionsExistFn = func(
+		ctx context.Context,
+		pubsubClient *pubsub.Client,
+		topicSubscriptionMap map[string]string,
+		callbackURL string,
+	) error {
+		return nil
+	}
+	ext.GoogleCloudProjectIDEnvVarNameFn = func() (string, error) {
+		return "test-project", nil
+	}
+}
+
+func TestServicePubSubMessaging_TopicIDs(t *testing.T) {
+	ps := newTestPubSub(t, &extMock.FakeBaseExtensionImpl{}, memory.NewMemoryRepository())
+
+	topics := ps.TopicIDs()
+	assert.Len(t, topics, len(domain.AllEventTypes))
+	assert.Contains(t, topics, "onboarding-user.created-testing-v1")
+	assert.Contains(t, topics, "onboarding-contact.changed-testing-v1")
+}
+
+func TestServicePubSubMessaging_RelayOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	ext := &extMock.FakeBaseExtensionImpl{}
+	ps := newTestPubSub(t, ext, repo)
+
+	profile, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.SavePIN(ctx, &domain.PIN{ID: "pin-1", ProfileID: profile.ID}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// the PIN event can not be published, it stays in the outbox
+	published := map[string][]byte{}
+	ext.PublishToPubsubFn = func(
+		ctx context.Context,
+		pubsubClient *pubsub.Client,
+		topicID string,
+		environment string,
+		serviceName string,
+		version string,
+		payload []byte,
+	) error {
+		if topicID == "onboarding-pin.changed-testing-v1" {
+			return fmt.Errorf("topic not found")
+		}
+		published[topicID] = payload
+		return nil
+	}
+	count, err := ps.RelayOutboxEvents(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, count)
+
+	event := domain.Event{}
+	if err := json.Unmarshal(published["onboarding-user.created-testing-v1"], &event); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.EventTypeUserCreated, event.Type)
+	data := domain.UserCreatedEvent{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, profile.ID, data.ProfileID)
+	assert.Equal(t, "uid-1", data.UID)
+
+	pending, err := repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, pending, 1)
+	assert.Equal(t, domain.EventTypePINChanged, pending[0].Type)
+	assert.Equal(t, 1, pending[0].Attempts)
+
+	// the failed event is published on the next run
+	ext.PublishToPubsubFn = func(
+		ctx context.Context,
+		pubsubClient *pubsub.Client,
+		topicID string,
+		environment string,
+		serviceName string,
+		version string,
+		payload []byte,
+	) error {
+		published[topicID] = payload
+		return nil
+	}
+	count, err = ps.RelayOutboxEvents(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, count)
+	assert.Contains(t, published, "onboarding-pin.changed-testing-v1")
+
+	pending, err = repo.ListPendingOutboxEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Empty(t, pending)
+}
+
+func TestServicePubSubMessaging_RelayOutboxEventsEnqueuesWebhooks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	ext := &extMock.FakeBaseExtensionImpl{}
+	ps := newTestPubSub(t, ext, repo)
+	ext.PublishToPubsubFn = func(
+		ctx context.Context,
+		pubsubClient *pubsub.Client,
+		topicID string,
+		environment string,
+		serviceName string,
+		version string,
+		payload []byte,
+	) error {
+		return nil
+	}
+
+	endpoint := &domain.WebhookEndpoint{
+		ID:         "endpoint-1",
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "a-very-long-secret",
+		EventTypes: []domain.EventType{domain.EventTypeUserCreated},
+		Active:     true,
+	}
+	if err := repo.CreateWebhookEndpoint(ctx, endpoint); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if _, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1"); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	if _, err := ps.RelayOutboxEvents(ctx); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	deliveries, err := repo.ListWebhookDeliveries(ctx, endpoint.ID, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, domain.EventTypeUserCreated, deliveries[0].EventType)
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, deliveries[0].Status)
+}
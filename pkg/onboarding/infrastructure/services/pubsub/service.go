@@ -4,10 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"cloud.google.com/go/pubsub"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
+	"go.opentelemetry.io/otel"
+)
+
+// Package that generates trace information
+var tracer = otel.Tracer(
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub",
 )
 
 const (
@@ -45,13 +53,32 @@ type ServicePubSub interface {
 		r *http.Request,
 	)
 	AddEngagementPubsubNameSpace(topic string) string
+
+	// RelayOutboxEvents publishes the pending events in the outbox
+	RelayOutboxEvents(ctx context.Context) (int, error)
+
+	// StartOutboxRelay relays the outbox events at the provided interval in the background
+	StartOutboxRelay(ctx context.Context, interval time.Duration)
+
+	// RegisterHandler sets the handler of the messages received from a topic
+	RegisterHandler(topicID string, handler PubSubHandler)
+
+	// ReplayMessages pushes the messages of a subscription that were published since a time again
+	ReplayMessages(ctx context.Context, subscriptionID string, since time.Time) (int, error)
 }
 
-// ServicePubSubMessaging sends "real" (production) notifications
+// ServicePubSubMessaging sends "real" (production) notifications. It uses Google Cloud Pub/Sub
+// or, for local development, an in-process broker
 type ServicePubSubMessaging struct {
 	client   *pubsub.Client
 	baseExt  extension.BaseExtension
 	database database.Repository
+
+	// broker, when set, is the in-process broker that is used instead of Google Cloud Pub/Sub
+	broker *LocalBroker
+
+	// handlers maps the topics that the service subscribes to to the handlers of their messages
+	handlers map[string]PubSubHandler
 }
 
 // NewServicePubSubMessaging ...
@@ -64,22 +91,53 @@ func NewServicePubSubMessaging(
 		client:   client,
 		baseExt:  ext,
 		database: db,
+		handlers: map[string]PubSubHandler{},
 	}
-
-	ctx := context.Background()
-	if err := s.EnsureTopicsExist(
-		ctx,
-		s.TopicIDs(),
-	); err != nil {
+	if err := s.initialize(); err != nil {
 		return nil, err
 	}
+	return s, nil
+}
 
-	if err := s.EnsureSubscriptionsExist(ctx); err != nil {
+// NewLocalServicePubSubMessaging initializes the pubsub service with an in-process broker. It
+// needs no GCP project; the messages are pushed to the local PubSubHandlerPath
+func NewLocalServicePubSubMessaging(
+	ext extension.BaseExtension,
+	db database.Repository,
+	broker *LocalBroker,
+) (*ServicePubSubMessaging, error) {
+	s := &ServicePubSubMessaging{
+		baseExt:  ext,
+		database: db,
+		broker:   broker,
+		handlers: map[string]PubSubHandler{},
+	}
+	if err := s.initialize(); err != nil {
 		return nil, err
 	}
 	return s, nil
 }
 
+// initialize registers the default handlers and creates the topics and subscriptions of the service
+func (ps ServicePubSubMessaging) initialize() error {
+	ps.registerDefaultHandlers()
+
+	// the topics of other services are created here too, a subscription can only be
+	// created for a topic that exists
+	topicIDs := append(ps.TopicIDs(), ps.DeadLetterTopicID())
+	topicIDs = append(topicIDs, ps.subscribedTopicIDs()...)
+
+	ctx := context.Background()
+	if err := ps.EnsureTopicsExist(
+		ctx,
+		topicIDs,
+	); err != nil {
+		return err
+	}
+
+	return ps.EnsureSubscriptionsExist(ctx)
+}
+
 // AddEngagementPubsubNameSpace creates a namespaced topic that resembles the one in
 // engagement service, which is prepended with the word "engagement". This solves the problem
 // where namespaced topics from "onboarding" are different from the ones in engagement.
@@ -107,9 +165,14 @@ func (ps ServicePubSubMessaging) AddPubSubNamespace(topicName string) string {
 	)
 }
 
-// TopicIDs returns the known (registered) topic IDs
+// TopicIDs returns the known (registered) topic IDs. These are the topics that the
+// domain events are published to
 func (ps ServicePubSubMessaging) TopicIDs() []string {
-	return []string{}
+	topics := []string{}
+	for _, eventType := range domain.AllEventTypes {
+		topics = append(topics, ps.AddPubSubNamespace(string(eventType)))
+	}
+	return topics
 }
 
 // PublishToPubsub sends a message to a specifeid Topic
@@ -118,6 +181,11 @@ func (ps ServicePubSubMessaging) PublishToPubsub(
 	topicID string,
 	payload []byte,
 ) error {
+	if ps.broker != nil {
+		_, err := ps.broker.Publish(topicID, payload)
+		return err
+	}
+
 	environment, err := ps.baseExt.GoogleCloudProjectIDEnvVarName()
 	if err != nil {
 		return err
@@ -133,12 +201,33 @@ func (ps ServicePubSubMessaging) PublishToPubsub(
 	)
 }
 
+// ReplayMessages pushes the messages of the topic of a subscription that were published at or after
+// `since` to the subscription again. It returns the number of messages that are replayed. Only the
+// in-process broker keeps the messages for replays
+func (ps ServicePubSubMessaging) ReplayMessages(
+	ctx context.Context,
+	subscriptionID string,
+	since time.Time,
+) (int, error) {
+	if ps.broker == nil {
+		return 0, fmt.Errorf("replaying messages is only supported by the local pubsub broker")
+	}
+	return ps.broker.Replay(subscriptionID, since)
+}
+
 // EnsureTopicsExist creates the topic(s) in the suppplied list if they do not
 // already exist.
 func (ps ServicePubSubMessaging) EnsureTopicsExist(
 	ctx context.Context,
 	topicIDs []string,
 ) error {
+	if ps.broker != nil {
+		for _, topicID := range topicIDs {
+			ps.broker.CreateTopic(topicID)
+		}
+		return nil
+	}
+
 	return ps.baseExt.EnsureTopicsExist(
 		ctx,
 		ps.client,
@@ -162,6 +251,15 @@ func (ps ServicePubSubMessaging) EnsureSubscriptionsExist(
 		ps.baseExt.PubSubHandlerPath(),
 	)
 
+	if ps.broker != nil {
+		for topicID, subscriptionID := range ps.SubscriptionIDs() {
+			if err := ps.broker.CreateSubscription(subscriptionID, topicID, callbackURL); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	return ps.baseExt.EnsureSubscriptionsExist(
 		ctx,
 		ps.client,
@@ -170,7 +268,8 @@ func (ps ServicePubSubMessaging) EnsureSubscriptionsExist(
 	)
 }
 
-// SubscriptionIDs returns a map of topic IDs to subscription IDs
+// SubscriptionIDs returns a map of topic IDs to subscription IDs. The service only subscribes
+// to the topics that it has a handler for
 func (ps ServicePubSubMessaging) SubscriptionIDs() map[string]string {
-	return ps.baseExt.SubscriptionIDs(ps.TopicIDs())
+	return ps.baseExt.SubscriptionIDs(ps.subscribedTopicIDs())
 }
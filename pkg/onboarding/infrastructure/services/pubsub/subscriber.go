@@ -1,14 +1,27 @@
 package pubsubmessaging
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"time"
 
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/pubsubtools"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // ReceivePubSubPushMessages receives and processes a Pub/Sub push message.
+//
+// The message is handled by the handler registered for its topic. A message that has
+// already been handled is acknowledged without being handled again. A message whose handler
+// fails is not acknowledged so that pubsub delivers it again, until it has been attempted
+// MaxPubSubMessageAttempts times. It is then published to the dead letter topic and acknowledged
 func (ps ServicePubSubMessaging) ReceivePubSubPushMessages(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -16,7 +29,7 @@ func (ps ServicePubSubMessaging) ReceivePubSubPushMessages(
 	ctx := r.Context()
 	span := trace.SpanFromContext(ctx)
 
-	message, err := ps.baseExt.VerifyPubSubJWTAndDecodePayload(w, r)
+	message, err := ps.decodePushMessage(w, r)
 	if err != nil {
 		ps.baseExt.WriteJSONResponse(
 			w,
@@ -42,8 +55,16 @@ func (ps ServicePubSubMessaging) ReceivePubSubPushMessages(
 		attribute.String("topic", topicID),
 	))
 
-	switch topicID {
-
+	handler, ok := ps.handlers[topicID]
+	if ok {
+		if err := ps.handleMessage(ctx, topicID, &message.Message, handler); err != nil {
+			ps.baseExt.WriteJSONResponse(
+				w,
+				ps.baseExt.ErrorMap(err),
+				http.StatusInternalServerError,
+			)
+			return
+		}
 	}
 
 	resp := map[string]string{"status": "success"}
@@ -58,3 +79,106 @@ func (ps ServicePubSubMessaging) ReceivePubSubPushMessages(
 	}
 	_, _ = w.Write(marshalledSuccessMsg)
 }
+
+// decodePushMessage reads a pushed message. The messages pushed by Pub/Sub carry a Google signed JWT
+// that is verified. The in-process broker does not sign its messages, they carry its secret instead
+func (ps ServicePubSubMessaging) decodePushMessage(
+	w http.ResponseWriter,
+	r *http.Request,
+) (*pubsubtools.PubSubPayload, error) {
+	if ps.broker == nil {
+		return ps.baseExt.VerifyPubSubJWTAndDecodePayload(w, r)
+	}
+	if !ps.broker.IsPushedByBroker(r) {
+		return nil, fmt.Errorf("the pushed message was not sent by the local broker")
+	}
+
+	message := &pubsubtools.PubSubPayload{}
+	if err := json.NewDecoder(r.Body).Decode(message); err != nil {
+		return nil, fmt.Errorf("unable to decode pushed message: %w", err)
+	}
+	return message, nil
+}
+
+// handleMessage runs the handler of a message and records the outcome. It returns an error
+// when the message should be delivered again
+func (ps ServicePubSubMessaging) handleMessage(
+	ctx context.Context,
+	topicID string,
+	message *pubsubtools.PubSubMessage,
+	handler PubSubHandler,
+) error {
+	ctx, span := tracer.Start(ctx, "handleMessage")
+	defer span.End()
+
+	record, err := ps.database.GetPubSubMessage(ctx, message.MessageID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if record == nil {
+		record = &domain.PubSubMessage{
+			ID:      message.MessageID,
+			TopicID: topicID,
+			Data:    string(message.Data),
+			Created: now,
+		}
+	}
+	if record.Status == domain.PubSubMessageStatusProcessed ||
+		record.Status == domain.PubSubMessageStatusDeadLettered {
+		// a redelivery of a message that has already been handled
+		return nil
+	}
+
+	handlerErr := handler(ctx, message)
+	record.Attempts++
+	record.Updated = now
+	switch {
+	case handlerErr == nil:
+		record.Status = domain.PubSubMessageStatusProcessed
+		record.LastError = ""
+	case errors.Is(handlerErr, ErrUnprocessableMessage) || record.Attempts >= MaxPubSubMessageAttempts:
+		utils.RecordSpanError(span, handlerErr)
+		record.Status = domain.PubSubMessageStatusDeadLettered
+		record.LastError = handlerErr.Error()
+		if err := ps.publishDeadLetter(ctx, record, message); err != nil {
+			// the message is still listed with the failed messages
+			utils.RecordSpanError(span, err)
+			log.Printf("unable to dead letter pubsub message %s: %v", record.ID, err)
+		}
+	default:
+		utils.RecordSpanError(span, handlerErr)
+		record.Status = domain.PubSubMessageStatusFailed
+		record.LastError = handlerErr.Error()
+	}
+
+	if err := ps.database.SavePubSubMessage(ctx, record); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if record.Status == domain.PubSubMessageStatusFailed {
+		return handlerErr
+	}
+	return nil
+}
+
+// publishDeadLetter publishes a message that could not be handled to the dead letter topic
+func (ps ServicePubSubMessaging) publishDeadLetter(
+	ctx context.Context,
+	record *domain.PubSubMessage,
+	message *pubsubtools.PubSubMessage,
+) error {
+	payload, err := json.Marshal(DeadLetter{
+		MessageID:  record.ID,
+		TopicID:    record.TopicID,
+		Data:       message.Data,
+		Attributes: message.Attributes,
+		Attempts:   record.Attempts,
+		LastError:  record.LastError,
+	})
+	if err != nil {
+		return err
+	}
+	return ps.PublishToPubsub(ctx, ps.DeadLetterTopicID(), payload)
+}
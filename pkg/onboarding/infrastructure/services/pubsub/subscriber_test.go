@@ -0,0 +1,172 @@
+package pubsubmessaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	extMock "github.com/savannahghi/onboarding/pkg/onboarding/application/extension/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/savannahghi/pubsubtools"
+	"github.com/stretchr/testify/assert"
+)
+
+const accountDeletionTopic = "engagement-account.deletion.requested-testing-v1"
+
+// pushMessage delivers a message to the push handler the way pubsub does and returns the status code
+func pushMessage(
+	ps *pubsubmessaging.ServicePubSubMessaging,
+	ext *extMock.FakeBaseExtensionImpl,
+	message pubsubtools.PubSubMessage,
+) int {
+	ext.VerifyPubSubJWTAndDecodePayloadFn = func(w http.ResponseWriter, r *http.Request) (*pubsubtools.PubSubPayload, error) {
+		return &pubsubtools.PubSubPayload{Message: message}, nil
+	}
+	ext.GetPubSubTopicFn = func(m *pubsubtools.PubSubPayload) (string, error) {
+		return m.Message.Attributes["topicID"], nil
+	}
+	ext.ErrorMapFn = func(err error) map[string]string {
+		return map[string]string{"error": err.Error()}
+	}
+	ext.WriteJSONResponseFn = func(w http.ResponseWriter, source interface{}, status int) {
+		w.WriteHeader(status)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/pubsub", nil)
+	ps.ReceivePubSubPushMessages(w, r)
+	return w.Code
+}
+
+func TestServicePubSubMessaging_SubscriptionIDs(t *testing.T) {
+	ext := &extMock.FakeBaseExtensionImpl{}
+	ps := newTestPubSub(t, ext, memory.NewMemoryRepository())
+
+	var subscribed []string
+	ext.SubscriptionIDsFn = func(topicIDs []string) map[string]string {
+		subscribed = topicIDs
+		return map[string]string{}
+	}
+	ps.SubscriptionIDs()
+	assert.Equal(t, []string{accountDeletionTopic}, subscribed)
+	assert.Equal(t, "onboarding-dead-letter-testing-v1", ps.DeadLetterTopicID())
+}
+
+func TestServicePubSubMessaging_ReceiveAccountDeletionRequested(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	ext := &extMock.FakeBaseExtensionImpl{}
+	ps := newTestPubSub(t, ext, repo)
+
+	profile, err := repo.CreateUserProfile(ctx, "+254711223344", "uid-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	data, err := json.Marshal(domain.AccountDeletionRequestedEvent{UID: "uid-1", Reason: "user request"})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	message := pubsubtools.PubSubMessage{
+		MessageID:  "message-1",
+		Data:       data,
+		Attributes: map[string]string{"topicID": accountDeletionTopic},
+	}
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, message))
+
+	_, err = repo.GetUserProfileByID(ctx, profile.ID, true)
+	assert.NotNil(t, err)
+
+	// a redelivery of the message is not handled again
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, message))
+	record, err := repo.GetPubSubMessage(ctx, "message-1")
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.PubSubMessageStatusProcessed, record.Status)
+	assert.Equal(t, 1, record.Attempts)
+
+	// a message for an account that does not exist is acknowledged
+	message.MessageID = "message-2"
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, message))
+}
+
+func TestServicePubSubMessaging_ReceiveFailingMessages(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	ext := &extMock.FakeBaseExtensionImpl{}
+	ps := newTestPubSub(t, ext, repo)
+
+	deadLetters := []pubsubmessaging.DeadLetter{}
+	ext.PublishToPubsubFn = func(
+		ctx context.Context,
+		pubsubClient *pubsub.Client,
+		topicID string,
+		environment string,
+		serviceName string,
+		version string,
+		payload []byte,
+	) error {
+		assert.Equal(t, ps.DeadLetterTopicID(), topicID)
+		deadLetter := pubsubmessaging.DeadLetter{}
+		if err := json.Unmarshal(payload, &deadLetter); err != nil {
+			return err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+		return nil
+	}
+
+	handled := 0
+	ps.RegisterHandler("engagement-nudge.resolved-testing-v1", func(ctx context.Context, message *pubsubtools.PubSubMessage) error {
+		handled++
+		return fmt.Errorf("nudge service unavailable")
+	})
+	message := pubsubtools.PubSubMessage{
+		MessageID:  "message-1",
+		Data:       []byte(`{}`),
+		Attributes: map[string]string{"topicID": "engagement-nudge.resolved-testing-v1"},
+	}
+
+	// the message is retried until it is dead lettered
+	for i := 1; i < pubsubmessaging.MaxPubSubMessageAttempts; i++ {
+		assert.Equal(t, http.StatusInternalServerError, pushMessage(ps, ext, message))
+	}
+	assert.Empty(t, deadLetters)
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, message))
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, message))
+	assert.Equal(t, pubsubmessaging.MaxPubSubMessageAttempts, handled)
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, "message-1", deadLetters[0].MessageID)
+	assert.Equal(t, "nudge service unavailable", deadLetters[0].LastError)
+
+	// a message that can not be decoded is dead lettered without being retried
+	undecodable := pubsubtools.PubSubMessage{
+		MessageID:  "message-2",
+		Data:       []byte(`not json`),
+		Attributes: map[string]string{"topicID": accountDeletionTopic},
+	}
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, undecodable))
+	assert.Len(t, deadLetters, 2)
+
+	// messages of topics without a handler are acknowledged
+	unknown := pubsubtools.PubSubMessage{
+		MessageID:  "message-3",
+		Attributes: map[string]string{"topicID": "engagement-unknown-testing-v1"},
+	}
+	assert.Equal(t, http.StatusOK, pushMessage(ps, ext, unknown))
+
+	failed, err := repo.ListFailedPubSubMessages(ctx, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, failed, 2)
+	for _, message := range failed {
+		assert.Equal(t, domain.PubSubMessageStatusDeadLettered, message.Status)
+	}
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+// FakeServiceSocial ...
+type FakeServiceSocial struct {
+	VerifyIDTokenFn func(
+		ctx context.Context,
+		provider profileutils.LoginProviderType,
+		idToken string,
+	) (*domain.SocialIdentity, error)
+}
+
+// VerifyIDToken ...
+func (m *FakeServiceSocial) VerifyIDToken(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+	idToken string,
+) (*domain.SocialIdentity, error) {
+	return m.VerifyIDTokenFn(ctx, provider, idToken)
+}
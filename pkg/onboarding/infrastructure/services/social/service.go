@@ -0,0 +1,372 @@
+package social
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"go.opentelemetry.io/otel"
+)
+
+// Package that generates trace information
+var tracer = otel.Tracer(
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/social",
+)
+
+const (
+	// VerifierEnvVarName is the env var that selects how the ID tokens of social login providers
+	// are verified. They are verified with the keys that the providers publish when it is not set
+	VerifierEnvVarName = "SOCIAL_LOGIN_VERIFIER"
+
+	// LocalVerifier is the value of `SOCIAL_LOGIN_VERIFIER` that selects the local stand-in for the
+	// providers. It accepts ID tokens without checking their signature and should only be used in
+	// tests and local development
+	LocalVerifier = "local"
+
+	// GoogleClientIDsEnvVarName is the env var that lists the comma separated OAuth client IDs of
+	// the apps that users sign in to with Google. Google sign in is disabled when it is not set
+	GoogleClientIDsEnvVarName = "GOOGLE_CLIENT_IDS"
+
+	// AppleClientIDsEnvVarName is the env var that lists the comma separated service or bundle IDs
+	// of the apps that users sign in to with Apple. Apple sign in is disabled when it is not set
+	AppleClientIDsEnvVarName = "APPLE_CLIENT_IDS"
+
+	// FacebookAppIDsEnvVarName is the env var that lists the comma separated IDs of the Facebook
+	// apps that users sign in to with Facebook. Facebook sign in is disabled when it is not set
+	FacebookAppIDsEnvVarName = "FACEBOOK_APP_IDS"
+
+	// keysCacheDuration is how long the signing keys of a provider are used before they are
+	// fetched again
+	keysCacheDuration = time.Hour
+
+	// keysRefreshInterval is how soon the signing keys of a provider can be fetched again to find a
+	// key that was not known, so that tokens with unknown keys can't flood the provider
+	keysRefreshInterval = time.Minute
+
+	// keysFetchTimeout is how long a provider has to respond with its signing keys
+	keysFetchTimeout = 10 * time.Second
+)
+
+// ServiceSocial verifies the ID tokens that social login providers issue to users who sign in
+// with them
+type ServiceSocial interface {
+	VerifyIDToken(
+		ctx context.Context,
+		provider profileutils.LoginProviderType,
+		idToken string,
+	) (*domain.SocialIdentity, error)
+}
+
+// TokenVerifier verifies the ID tokens of a single social login provider
+type TokenVerifier interface {
+	Verify(ctx context.Context, idToken string) (*domain.SocialIdentity, error)
+}
+
+// ServiceSocialImpl verifies ID tokens with the verifier of their provider
+type ServiceSocialImpl struct {
+	verifiers map[profileutils.LoginProviderType]TokenVerifier
+}
+
+// NewServiceSocialImpl initializes the social login service. Users can only sign in with the
+// providers that have a verifier
+func NewServiceSocialImpl(verifiers map[profileutils.LoginProviderType]TokenVerifier) *ServiceSocialImpl {
+	return &ServiceSocialImpl{verifiers: verifiers}
+}
+
+// NewServiceSocial initializes the social login service with the verifiers that are selected by
+// the `SOCIAL_LOGIN_VERIFIER` env var. The providers are enabled by setting the client IDs of the
+// apps that users sign in to with them
+func NewServiceSocial() *ServiceSocialImpl {
+	if os.Getenv(VerifierEnvVarName) == LocalVerifier {
+		verifiers := map[profileutils.LoginProviderType]TokenVerifier{}
+		for _, provider := range utils.SocialLoginProviders {
+			verifiers[provider] = NewLocalTokenVerifier()
+		}
+		return NewServiceSocialImpl(verifiers)
+	}
+
+	verifiers := map[profileutils.LoginProviderType]TokenVerifier{}
+	if audiences := clientIDs(GoogleClientIDsEnvVarName); len(audiences) > 0 {
+		verifiers[profileutils.LoginProviderTypeSocialGoogle] = NewGoogleTokenVerifier(audiences)
+	}
+	if audiences := clientIDs(AppleClientIDsEnvVarName); len(audiences) > 0 {
+		verifiers[profileutils.LoginProviderTypeAppleFacebook] = NewAppleTokenVerifier(audiences)
+	}
+	if audiences := clientIDs(FacebookAppIDsEnvVarName); len(audiences) > 0 {
+		verifiers[profileutils.LoginProviderTypeSocialFacebook] = NewFacebookTokenVerifier(audiences)
+	}
+	return NewServiceSocialImpl(verifiers)
+}
+
+func clientIDs(envVarName string) []string {
+	ids := []string{}
+	for _, id := range strings.Split(os.Getenv(envVarName), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// VerifyIDToken returns the account that an ID token of a social login provider was issued for
+func (s *ServiceSocialImpl) VerifyIDToken(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+	idToken string,
+) (*domain.SocialIdentity, error) {
+	ctx, span := tracer.Start(ctx, "VerifyIDToken")
+	defer span.End()
+
+	verifier, ok := s.verifiers[provider]
+	if !ok {
+		err := exceptions.InvalidSocialTokenError(fmt.Errorf("signing in with %s is not enabled", provider))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	identity, err := verifier.Verify(ctx, idToken)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InvalidSocialTokenError(err)
+	}
+	identity.Provider = provider
+	return identity, nil
+}
+
+// OIDCTokenVerifier verifies the OpenID Connect ID tokens of a provider with the RSA keys that the
+// provider publishes. The keys are cached
+type OIDCTokenVerifier struct {
+	issuers    []string
+	keysURL    string
+	audiences  []string
+	trustEmail bool
+	client     *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewOIDCTokenVerifier initializes a verifier of the ID tokens that one of the issuers issued for
+// one of the audiences. The email address of a token is only taken to be verified if the token
+// says so, unless trustEmail is set for providers that only share verified email addresses
+func NewOIDCTokenVerifier(issuers []string, keysURL string, audiences []string, trustEmail bool) *OIDCTokenVerifier {
+	return &OIDCTokenVerifier{
+		issuers:    issuers,
+		keysURL:    keysURL,
+		audiences:  audiences,
+		trustEmail: trustEmail,
+		client:     &http.Client{Timeout: keysFetchTimeout},
+		keys:       map[string]*rsa.PublicKey{},
+	}
+}
+
+// NewGoogleTokenVerifier initializes a verifier of the ID tokens of Google sign in
+func NewGoogleTokenVerifier(audiences []string) *OIDCTokenVerifier {
+	return NewOIDCTokenVerifier(
+		[]string{"https://accounts.google.com", "accounts.google.com"},
+		"https://www.googleapis.com/oauth2/v3/certs",
+		audiences,
+		false,
+	)
+}
+
+// NewAppleTokenVerifier initializes a verifier of the ID tokens of Sign in with Apple
+func NewAppleTokenVerifier(audiences []string) *OIDCTokenVerifier {
+	return NewOIDCTokenVerifier(
+		[]string{"https://appleid.apple.com"},
+		"https://appleid.apple.com/auth/keys",
+		audiences,
+		false,
+	)
+}
+
+// NewFacebookTokenVerifier initializes a verifier of the ID tokens of Facebook Login. Facebook only
+// shares email addresses that the user has confirmed
+func NewFacebookTokenVerifier(audiences []string) *OIDCTokenVerifier {
+	return NewOIDCTokenVerifier(
+		[]string{"https://www.facebook.com", "https://limited.facebook.com"},
+		"https://www.facebook.com/.well-known/oauth/openid/jwks/",
+		audiences,
+		true,
+	)
+}
+
+// Verify checks the signature, issuer, audience and expiry of an ID token and returns the account
+// that it was issued for
+func (v *OIDCTokenVerifier) Verify(ctx context.Context, idToken string) (*domain.SocialIdentity, error) {
+	ctx, span := tracer.Start(ctx, "OIDCTokenVerifier.Verify")
+	defer span.End()
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, kid)
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("the token has no expiry")
+	}
+	if !verifyAny(v.issuers, func(issuer string) bool { return claims.VerifyIssuer(issuer, true) }) {
+		return nil, fmt.Errorf("the token was issued by an unexpected issuer")
+	}
+	if !verifyAny(v.audiences, func(audience string) bool { return claims.VerifyAudience(audience, true) }) {
+		return nil, fmt.Errorf("the token was issued for an unexpected audience")
+	}
+	return identityFromClaims(claims, v.trustEmail)
+}
+
+func verifyAny(values []string, verify func(value string) bool) bool {
+	for _, value := range values {
+		if verify(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the signing key with the provided ID. The keys are fetched again when they are
+// stale, or when the key is not known and they were not fetched recently
+func (v *OIDCTokenVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key, ok := v.keys[kid]
+	age := time.Since(v.fetchedAt)
+	if (ok && age < keysCacheDuration) || (!ok && age < keysRefreshInterval) {
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		if ok {
+			// a known key is still used while the provider can't be reached
+			return key, nil
+		}
+		return nil, err
+	}
+	v.keys = keys
+	v.fetchedAt = time.Now()
+
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// jsonWebKeySet is the set of public keys that a provider signs its ID tokens with
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func (v *OIDCTokenVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.keysURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the signing keys: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch the signing keys: status %d", resp.StatusCode)
+	}
+
+	set := jsonWebKeySet{}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("unable to read the signing keys: %w", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read signing key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read signing key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// LocalTokenVerifier stands in for a social login provider in tests and local development. It
+// accepts ID tokens without checking their signature, issuer or audience, but they must name the
+// account that they were issued for and not have expired
+type LocalTokenVerifier struct{}
+
+// NewLocalTokenVerifier initializes the local stand-in for a social login provider
+func NewLocalTokenVerifier() *LocalTokenVerifier {
+	return &LocalTokenVerifier{}
+}
+
+// Verify returns the account that an ID token names
+func (v *LocalTokenVerifier) Verify(ctx context.Context, idToken string) (*domain.SocialIdentity, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(idToken, claims); err != nil {
+		return nil, err
+	}
+	if err := claims.Valid(); err != nil {
+		return nil, err
+	}
+	return identityFromClaims(claims, false)
+}
+
+// identityFromClaims reads the account of a user from the claims of an ID token
+func identityFromClaims(claims jwt.MapClaims, trustEmail bool) (*domain.SocialIdentity, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("the token does not name an account")
+	}
+	identity := &domain.SocialIdentity{Subject: subject}
+	identity.EmailAddress, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+
+	// some providers send the verification of the email address as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	if trustEmail && identity.EmailAddress != "" {
+		identity.EmailVerified = true
+	}
+	return identity, nil
+}
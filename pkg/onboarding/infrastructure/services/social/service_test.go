@@ -0,0 +1,164 @@
+package social_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/savannahghi/errorcodeutil"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/social"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIssuer   = "https://accounts.example.com"
+	testAudience = "client-1.apps.example.com"
+)
+
+// provider is a social login provider that publishes the key that it signs ID tokens with
+type provider struct {
+	key     *rsa.PrivateKey
+	fetches int32
+}
+
+func newProvider(t *testing.T) (*provider, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate a key: %v", err)
+	}
+	p := &provider{key: key}
+	server := httptest.NewServer(p)
+	t.Cleanup(server.Close)
+	return p, server.URL
+}
+
+func (p *provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&p.fetches, 1)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "key-1",
+			"kty": "RSA",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *provider) idToken(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		t.Fatalf("unable to sign the token: %v", err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            testIssuer,
+		"aud":            testAudience,
+		"sub":            "110248495921238986420",
+		"email":          "wanjiku@example.com",
+		"email_verified": "true",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestOIDCTokenVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	p, keysURL := newProvider(t)
+	verifier := social.NewOIDCTokenVerifier([]string{testIssuer}, keysURL, []string{testAudience}, false)
+
+	identity, err := verifier.Verify(ctx, p.idToken(t, validClaims()))
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "110248495921238986420", identity.Subject)
+	assert.Equal(t, "wanjiku@example.com", identity.EmailAddress)
+	assert.True(t, identity.EmailVerified)
+
+	// the keys are cached
+	_, err = verifier.Verify(ctx, p.idToken(t, validClaims()))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&p.fetches))
+
+	tests := []struct {
+		name   string
+		change func(claims jwt.MapClaims)
+	}{
+		{name: "expired", change: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{name: "no expiry", change: func(claims jwt.MapClaims) { delete(claims, "exp") }},
+		{name: "another issuer", change: func(claims jwt.MapClaims) { claims["iss"] = "https://attacker.example.com" }},
+		{name: "another audience", change: func(claims jwt.MapClaims) { claims["aud"] = "client-2.apps.example.com" }},
+		{name: "no subject", change: func(claims jwt.MapClaims) { delete(claims, "sub") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.change(claims)
+			_, err := verifier.Verify(ctx, p.idToken(t, claims))
+			assert.NotNil(t, err)
+		})
+	}
+
+	// a token that is signed with another key is refused
+	other, _ := newProvider(t)
+	_, err = verifier.Verify(ctx, other.idToken(t, validClaims()))
+	assert.NotNil(t, err)
+
+	// an unsigned token is refused
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.Nil(t, err)
+	_, err = verifier.Verify(ctx, unsigned)
+	assert.NotNil(t, err)
+}
+
+func TestServiceSocialImpl_VerifyIDToken(t *testing.T) {
+	ctx := context.Background()
+	service := social.NewServiceSocialImpl(map[profileutils.LoginProviderType]social.TokenVerifier{
+		profileutils.LoginProviderTypeSocialGoogle: social.NewLocalTokenVerifier(),
+	})
+
+	// the local stand-in reads the account from a token without checking its signature
+	claims := validClaims()
+	claims["email_verified"] = true
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.Nil(t, err)
+	identity, err := service.VerifyIDToken(ctx, profileutils.LoginProviderTypeSocialGoogle, token)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, profileutils.LoginProviderTypeSocialGoogle, identity.Provider)
+	assert.Equal(t, "110248495921238986420", identity.Subject)
+	assert.True(t, identity.EmailVerified)
+
+	// a provider that has no verifier is not enabled
+	_, err = service.VerifyIDToken(ctx, profileutils.LoginProviderTypeSocialFacebook, token)
+	assert.NotNil(t, err)
+
+	claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.Nil(t, err)
+	_, err = service.VerifyIDToken(ctx, profileutils.LoginProviderTypeSocialGoogle, expired)
+	assert.NotNil(t, err)
+
+	_, err = service.VerifyIDToken(ctx, profileutils.LoginProviderTypeSocialGoogle, "not-a-token")
+	var customErr *errorcodeutil.CustomError
+	if assert.True(t, errors.As(err, &customErr)) {
+		assert.Equal(t, exceptions.InvalidSocialToken, customErr.Code)
+	}
+}
@@ -0,0 +1,56 @@
+package tokens
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// KeyEncryptionKeyEnvVarName is the env var with the key that the signing keys are encrypted
+// with at rest. It is a base64 encoded 256 bit AES key
+const KeyEncryptionKeyEnvVarName = utils.KeyEncryptionKeyEnvVarName
+
+// KeyEncryptionKey reads the key that the signing keys are encrypted with from the
+// `SIGNING_KEY_ENCRYPTION_KEY` env var. The in memory repository never saves the signing keys, so
+// a random key is used when it runs without one
+func KeyEncryptionKey() ([]byte, error) {
+	key, err := utils.KeyEncryptionKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to issue access tokens: %w", err)
+	}
+	return key, nil
+}
+
+// NewKeyEncryptionKey generates a random key to encrypt the signing keys with
+func NewKeyEncryptionKey() ([]byte, error) {
+	return utils.NewKeyEncryptionKey()
+}
+
+// sealSigningKey encrypts the private key of a signing key with a new data key, which is in turn
+// encrypted with the key encryption key. Both are bound to the ID of the signing key so that they
+// can't be swapped with those of another key
+func sealSigningKey(kek []byte, key *domain.SigningKey, privateKey *rsa.PrivateKey) error {
+	encryptedKey, encryptedDataKey, err := utils.SealEnvelope(
+		kek,
+		x509.MarshalPKCS1PrivateKey(privateKey),
+		key.ID,
+	)
+	if err != nil {
+		return err
+	}
+	key.EncryptedPrivateKey = encryptedKey
+	key.EncryptedDataKey = encryptedDataKey
+	return nil
+}
+
+// openSigningKey decrypts the private key of a stored signing key
+func openSigningKey(kek []byte, key *domain.SigningKey) (*rsa.PrivateKey, error) {
+	encoded, err := utils.OpenEnvelope(kek, key.EncryptedPrivateKey, key.EncryptedDataKey, key.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the private key: %w", err)
+	}
+	return x509.ParsePKCS1PrivateKey(encoded)
+}
@@ -0,0 +1,65 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"firebase.google.com/go/auth"
+	"github.com/savannahghi/firebasetools"
+)
+
+// IDTokenVerifier verifies the ID tokens that Firebase issues. The Firebase `auth.Client`
+// satisfies it
+type IDTokenVerifier interface {
+	VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*auth.Token, error)
+}
+
+// FirebaseIDTokenVerifier verifies Firebase ID tokens with a Firebase auth client that is built
+// the first time that a token is verified and reused afterwards. The service can start without
+// Firebase credentials as long as it does not see a Firebase ID token
+type FirebaseIDTokenVerifier struct {
+	mu     sync.Mutex
+	client *auth.Client
+}
+
+// NewFirebaseIDTokenVerifier initializes a verifier of Firebase ID tokens
+func NewFirebaseIDTokenVerifier() *FirebaseIDTokenVerifier {
+	return &FirebaseIDTokenVerifier{}
+}
+
+// VerifyIDTokenAndCheckRevoked verifies a Firebase ID token and checks that the refresh tokens of
+// its user were not revoked after it was issued
+func (v *FirebaseIDTokenVerifier) VerifyIDTokenAndCheckRevoked(
+	ctx context.Context,
+	idToken string,
+) (*auth.Token, error) {
+	client, err := v.authClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.VerifyIDTokenAndCheckRevoked(ctx, idToken)
+}
+
+// authClient returns the Firebase auth client, building it if it has not been built yet. A client
+// that fails to build is built again on the next call
+func (v *FirebaseIDTokenVerifier) authClient() (*auth.Client, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.client != nil {
+		return v.client, nil
+	}
+	fc := &firebasetools.FirebaseClient{}
+	firebaseApp, err := fc.InitFirebase()
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize Firebase: %w", err)
+	}
+	// the client outlives the request that it was built for
+	client, err := firebaseApp.Auth(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting Auth client: %w", err)
+	}
+	v.client = client
+	return client, nil
+}
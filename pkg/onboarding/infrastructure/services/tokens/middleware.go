@@ -0,0 +1,115 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/serverutils"
+)
+
+// AuthenticationMiddleware authenticates requests with a bearer token that is either an access
+// token signed by the service or a Firebase ID token. An access token signed by the service is
+// refused once its session is signed out. The user of the token is put in the context the same way
+// as `firebasetools.AuthenticationMiddleware` does, so the logged in user is found the same way
+// for both
+func AuthenticationMiddleware(
+	firebaseApp firebasetools.IFirebaseApp,
+	tokens ServiceTokens,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				authToken, errMap := authenticate(r, firebaseApp, tokens)
+				if authToken == nil {
+					serverutils.WriteJSONResponse(w, []map[string]string{errMap}, http.StatusUnauthorized)
+					return
+				}
+				ctx := context.WithValue(r.Context(), firebasetools.AuthTokenContextKey, authToken)
+				next.ServeHTTP(w, r.WithContext(ctx))
+			},
+		)
+	}
+}
+
+func authenticate(
+	r *http.Request,
+	firebaseApp firebasetools.IFirebaseApp,
+	tokens ServiceTokens,
+) (*auth.Token, map[string]string) {
+	bearerToken, err := firebasetools.ExtractBearerToken(r)
+	if err != nil {
+		return nil, serverutils.ErrorMap(err)
+	}
+
+	if tokens.Enabled() && IsServiceToken(bearerToken) {
+		claims, err := tokens.VerifyAccessToken(r.Context(), bearerToken)
+		if err != nil {
+			return nil, serverutils.ErrorMap(err)
+		}
+		active, err := tokens.SessionActive(r.Context(), claims.ProfileID, claims.SessionID)
+		if err != nil {
+			return nil, serverutils.ErrorMap(err)
+		}
+		if !active {
+			return nil, serverutils.ErrorMap(fmt.Errorf("the session of the access token is not signed in"))
+		}
+		return AuthToken(claims), nil
+	}
+
+	if firebaseApp == nil {
+		// the service runs without Firebase, e.g. on the in memory repository
+		return nil, serverutils.ErrorMap(fmt.Errorf("only the access tokens signed by the service are accepted"))
+	}
+	ok, errMap, authToken := firebasetools.HasValidFirebaseBearerToken(r, firebaseApp)
+	if !ok {
+		return nil, errMap
+	}
+	return authToken, nil
+}
+
+// IsServiceToken tells whether a bearer token claims to have been issued by the service. Its
+// signature is not checked
+func IsServiceToken(token string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return false
+	}
+	issuer, _ := claims["iss"].(string)
+	return issuer == Issuer
+}
+
+// AuthToken returns the Firebase auth token that stands in for a verified access token of the
+// service in the request context. The profile ID, flavour and scopes are kept as custom claims
+func AuthToken(claims *domain.AccessTokenClaims) *auth.Token {
+	return &auth.Token{
+		Issuer:   Issuer,
+		Audience: Audience,
+		Subject:  claims.UID,
+		UID:      claims.UID,
+		IssuedAt: claims.IssuedAt.Unix(),
+		AuthTime: claims.IssuedAt.Unix(),
+		Expires:  claims.ExpiresAt.Unix(),
+		Claims: map[string]interface{}{
+			"profileID": claims.ProfileID,
+			"flavour":   claims.Flavour.String(),
+			"sid":       claims.SessionID,
+			"scopes":    claims.Scopes,
+		},
+	}
+}
+
+// SessionID returns the profile and the session that a verified access token of the service was
+// issued for. It returns false for the tokens that the service did not sign
+func SessionID(authToken *auth.Token) (profileID string, sessionID string, ok bool) {
+	if authToken == nil || authToken.Issuer != Issuer {
+		return "", "", false
+	}
+	profileID, _ = authToken.Claims["profileID"].(string)
+	sessionID, _ = authToken.Claims["sid"].(string)
+	return profileID, sessionID, true
+}
@@ -0,0 +1,71 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// FakeServiceTokens ...
+type FakeServiceTokens struct {
+	EnabledFn func() bool
+
+	IssueAccessTokenFn func(ctx context.Context, claims *domain.AccessTokenClaims) (*domain.AccessToken, error)
+
+	VerifyAccessTokenFn func(ctx context.Context, token string) (*domain.AccessTokenClaims, error)
+
+	SessionActiveFn func(ctx context.Context, profileID string, sessionID string) (bool, error)
+
+	VerifyBearerTokenFn func(ctx context.Context, token string) (*auth.Token, error)
+
+	JWKSFn func(ctx context.Context) (*domain.JSONWebKeySet, error)
+
+	RotateSigningKeyFn func(ctx context.Context) (bool, error)
+
+	StartKeyRotationFn func(ctx context.Context, interval time.Duration)
+}
+
+// Enabled ...
+func (m *FakeServiceTokens) Enabled() bool {
+	return m.EnabledFn()
+}
+
+// IssueAccessToken ...
+func (m *FakeServiceTokens) IssueAccessToken(
+	ctx context.Context,
+	claims *domain.AccessTokenClaims,
+) (*domain.AccessToken, error) {
+	return m.IssueAccessTokenFn(ctx, claims)
+}
+
+// VerifyAccessToken ...
+func (m *FakeServiceTokens) VerifyAccessToken(ctx context.Context, token string) (*domain.AccessTokenClaims, error) {
+	return m.VerifyAccessTokenFn(ctx, token)
+}
+
+// SessionActive ...
+func (m *FakeServiceTokens) SessionActive(ctx context.Context, profileID string, sessionID string) (bool, error) {
+	return m.SessionActiveFn(ctx, profileID, sessionID)
+}
+
+// VerifyBearerToken ...
+func (m *FakeServiceTokens) VerifyBearerToken(ctx context.Context, token string) (*auth.Token, error) {
+	return m.VerifyBearerTokenFn(ctx, token)
+}
+
+// JWKS ...
+func (m *FakeServiceTokens) JWKS(ctx context.Context) (*domain.JSONWebKeySet, error) {
+	return m.JWKSFn(ctx)
+}
+
+// RotateSigningKey ...
+func (m *FakeServiceTokens) RotateSigningKey(ctx context.Context) (bool, error) {
+	return m.RotateSigningKeyFn(ctx)
+}
+
+// StartKeyRotation ...
+func (m *FakeServiceTokens) StartKeyRotation(ctx context.Context, interval time.Duration) {
+	m.StartKeyRotationFn(ctx, interval)
+}
@@ -0,0 +1,613 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
+	"github.com/savannahghi/pubsubtools"
+	"go.opentelemetry.io/otel"
+)
+
+// Package that generates trace information
+var tracer = otel.Tracer(
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens",
+)
+
+const (
+	// IssuerEnvVarName is the env var that selects who issues the access tokens that users log in
+	// with. Firebase issues them when it is not set, unless the service runs on the in memory
+	// repository
+	IssuerEnvVarName = "TOKEN_ISSUER"
+
+	// FirebaseIssuer is the value of `TOKEN_ISSUER` that leaves issuing access tokens to Firebase
+	FirebaseIssuer = "firebase"
+
+	// ServiceIssuer is the value of `TOKEN_ISSUER` that makes the service sign its own access tokens.
+	// They are verified with the keys published at `/.well-known/jwks.json`
+	ServiceIssuer = "service"
+
+	// Issuer is the `iss` claim of the access tokens that the service signs
+	Issuer = "onboarding"
+
+	// Audience is the `aud` claim of the access tokens that the service signs. Only the tokens
+	// that are meant for the service are accepted
+	Audience = "onboarding"
+
+	// AccessTokenLifetime is how long an access token signed by the service is valid
+	AccessTokenLifetime = time.Hour
+
+	// KeyRotationPeriod is how long a signing key signs new access tokens before it is replaced
+	KeyRotationPeriod = 30 * 24 * time.Hour
+
+	// KeyRotationInterval is how often the service checks whether its signing key is due for rotation
+	KeyRotationInterval = time.Hour
+
+	// signingAlgorithm is the algorithm that the access tokens are signed with
+	signingAlgorithm = "RS256"
+
+	// keyBits is the size of the RSA signing keys
+	keyBits = 2048
+
+	// keysCacheDuration is how long the signing keys are used before they are read again
+	keysCacheDuration = time.Minute
+
+	// keysRefreshInterval is how soon the signing keys can be read again to find a key that was not
+	// known, so that tokens with unknown keys can't flood the database
+	keysRefreshInterval = 10 * time.Second
+
+	// SessionCacheDuration is how long it is remembered whether the session of an access token
+	// signed by the service is signed in. A session that is signed out stops authenticating
+	// requests after at most this long
+	SessionCacheDuration = 30 * time.Second
+)
+
+// ServiceTokens issues and verifies the access tokens that users log in with, unless they are left
+// to Firebase
+type ServiceTokens interface {
+	// Enabled tells whether the service issues its own access tokens
+	Enabled() bool
+
+	IssueAccessToken(ctx context.Context, claims *domain.AccessTokenClaims) (*domain.AccessToken, error)
+
+	VerifyAccessToken(ctx context.Context, token string) (*domain.AccessTokenClaims, error)
+
+	// SessionActive tells whether the session that an access token signed by the service was
+	// issued for is still signed in
+	SessionActive(ctx context.Context, profileID string, sessionID string) (bool, error)
+
+	// VerifyBearerToken verifies a bearer token that is either an access token signed by the
+	// service or a Firebase ID token. Firebase ID tokens are also checked for revocation
+	VerifyBearerToken(ctx context.Context, token string) (*auth.Token, error)
+
+	JWKS(ctx context.Context) (*domain.JSONWebKeySet, error)
+
+	RotateSigningKey(ctx context.Context) (bool, error)
+
+	StartKeyRotation(ctx context.Context, interval time.Duration)
+}
+
+// NewServiceTokens initializes the token issuer that is selected by the `TOKEN_ISSUER` env var.
+// The service only issues its own access tokens when it has a key to encrypt its signing keys with
+func NewServiceTokens(db database.Repository) (ServiceTokens, error) {
+	issuer := os.Getenv(IssuerEnvVarName)
+	switch issuer {
+	case ServiceIssuer:
+		kek, err := KeyEncryptionKey()
+		if err != nil {
+			return nil, err
+		}
+		return NewServiceTokensImpl(db, kek, NewFirebaseIDTokenVerifier()), nil
+
+	case "":
+		// the credentials of the in memory repository can't be verified by Firebase
+		if os.Getenv(domain.Repo) == domain.MemoryRepository {
+			kek, err := KeyEncryptionKey()
+			if err != nil {
+				return nil, err
+			}
+			return NewServiceTokensImpl(db, kek, NewFirebaseIDTokenVerifier()), nil
+		}
+		return NewFirebaseTokens(NewFirebaseIDTokenVerifier()), nil
+
+	case FirebaseIssuer:
+		return NewFirebaseTokens(NewFirebaseIDTokenVerifier()), nil
+
+	default:
+		return nil, fmt.Errorf("unknown token issuer %q, expected one of %q or %q",
+			issuer,
+			FirebaseIssuer,
+			ServiceIssuer,
+		)
+	}
+}
+
+// accessTokenClaims are the claims of the access tokens that the service signs. The subject is
+// the UID of the user
+type accessTokenClaims struct {
+	ProfileID string          `json:"profileID"`
+	Flavour   feedlib.Flavour `json:"flavour,omitempty"`
+	SessionID string          `json:"sid,omitempty"`
+	Scopes    []string        `json:"scopes"`
+	jwt.StandardClaims
+}
+
+// signingKey is a stored signing key with its private key decrypted
+type signingKey struct {
+	id       string
+	key      *rsa.PrivateKey
+	created  time.Time
+	notAfter time.Time
+}
+
+// ServiceTokensImpl signs access tokens with RSA keys that are kept in the database, so that every
+// instance of the service signs with the same key and publishes the same keys. The newest key
+// signs new tokens and is replaced every `KeyRotationPeriod`. The private keys are stored
+// encrypted with a data key of their own, which is encrypted with the key encryption key
+type ServiceTokensImpl struct {
+	database         database.Repository
+	keyEncryptionKey []byte
+	idTokens         IDTokenVerifier
+
+	mu        sync.Mutex
+	keys      []*signingKey
+	fetchedAt time.Time
+
+	sessionsMu sync.Mutex
+	sessions   map[string]cachedSession
+	evictedAt  time.Time
+}
+
+// cachedSession is whether a session is signed in, which is reused until it expires
+type cachedSession struct {
+	active    bool
+	expiresAt time.Time
+}
+
+// NewServiceTokensImpl initializes the issuer of the service's own access tokens. The signing keys
+// are encrypted with the provided 256 bit key encryption key. The Firebase ID tokens of the users
+// that logged in before the service issued its own access tokens are checked with `idTokens`
+func NewServiceTokensImpl(
+	db database.Repository,
+	keyEncryptionKey []byte,
+	idTokens IDTokenVerifier,
+) *ServiceTokensImpl {
+	return &ServiceTokensImpl{
+		database:         db,
+		keyEncryptionKey: keyEncryptionKey,
+		idTokens:         idTokens,
+		sessions:         map[string]cachedSession{},
+	}
+}
+
+// Enabled tells whether the service issues its own access tokens
+func (s *ServiceTokensImpl) Enabled() bool {
+	return true
+}
+
+// IssueAccessToken signs an access token with the newest signing key. A key is created when there
+// is none that is published for as long as the token is valid
+func (s *ServiceTokensImpl) IssueAccessToken(
+	ctx context.Context,
+	claims *domain.AccessTokenClaims,
+) (*domain.AccessToken, error) {
+	ctx, span := tracer.Start(ctx, "IssueAccessToken")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	expiresAt := now.Add(AccessTokenLifetime)
+	key, err := s.activeKey(ctx, expiresAt)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, accessTokenClaims{
+		ProfileID: claims.ProfileID,
+		Flavour:   claims.Flavour,
+		SessionID: claims.SessionID,
+		Scopes:    claims.Scopes,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Issuer:    Issuer,
+			Audience:  Audience,
+			Subject:   claims.UID,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	})
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.key)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(fmt.Errorf("unable to sign the access token: %w", err))
+	}
+	return &domain.AccessToken{Token: signed, IssuedAt: now, ExpiresAt: expiresAt}, nil
+}
+
+// VerifyAccessToken checks the signature, issuer, audience and expiry of an access token signed by
+// the service and returns what it says about the user it was issued to
+func (s *ServiceTokensImpl) VerifyAccessToken(ctx context.Context, token string) (*domain.AccessTokenClaims, error) {
+	ctx, span := tracer.Start(ctx, "VerifyAccessToken")
+	defer span.End()
+
+	claims := &accessTokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != signingAlgorithm {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, err := s.publicKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("the token has no expiry")
+	}
+	if !claims.VerifyIssuer(Issuer, true) {
+		return nil, fmt.Errorf("the token was issued by an unexpected issuer")
+	}
+	if !claims.VerifyAudience(Audience, true) {
+		return nil, fmt.Errorf("the token was issued for another audience")
+	}
+	if claims.Subject == "" || claims.ProfileID == "" {
+		return nil, fmt.Errorf("the token does not name the user that it was issued to")
+	}
+	return &domain.AccessTokenClaims{
+		UID:       claims.Subject,
+		ProfileID: claims.ProfileID,
+		Flavour:   claims.Flavour,
+		SessionID: claims.SessionID,
+		Scopes:    claims.Scopes,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0).In(pubsubtools.TimeLocation),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).In(pubsubtools.TimeLocation),
+	}, nil
+}
+
+// SessionActive tells whether a session is in the sessions registry and has not been signed out.
+// The answer is cached for `SessionCacheDuration` so that every authenticated request does not
+// read the sessions of its user
+func (s *ServiceTokensImpl) SessionActive(ctx context.Context, profileID string, sessionID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "SessionActive")
+	defer span.End()
+
+	if profileID == "" || sessionID == "" {
+		return false, nil
+	}
+	key := profileID + ":" + sessionID
+	now := time.Now()
+
+	s.sessionsMu.Lock()
+	cached, ok := s.sessions[key]
+	s.sessionsMu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.active, nil
+	}
+
+	sessions, err := s.database.ListSessions(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	active := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			active = !session.IsRevoked()
+			break
+		}
+	}
+
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	if now.Sub(s.evictedAt) >= SessionCacheDuration {
+		for cachedKey, cached := range s.sessions {
+			if !now.Before(cached.expiresAt) {
+				delete(s.sessions, cachedKey)
+			}
+		}
+		s.evictedAt = now
+	}
+	s.sessions[key] = cachedSession{active: active, expiresAt: now.Add(SessionCacheDuration)}
+	return active, nil
+}
+
+// VerifyBearerToken verifies an access token signed by the service, or a Firebase ID token for
+// the users that logged in before the service issued its own access tokens. The session of an
+// access token signed by the service is not checked here, see `SessionID`
+func (s *ServiceTokensImpl) VerifyBearerToken(ctx context.Context, token string) (*auth.Token, error) {
+	if !IsServiceToken(token) {
+		return verifyFirebaseIDToken(ctx, s.idTokens, token)
+	}
+	claims, err := s.VerifyAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return AuthToken(claims), nil
+}
+
+// JWKS returns the public keys of the signing keys that are still published
+func (s *ServiceTokensImpl) JWKS(ctx context.Context) (*domain.JSONWebKeySet, error) {
+	ctx, span := tracer.Start(ctx, "JWKS")
+	defer span.End()
+
+	keys, err := s.signingKeys(ctx, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	set := &domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, domain.JSONWebKey{
+			KeyType:   "RSA",
+			Use:       "sig",
+			KeyID:     key.id,
+			Algorithm: signingAlgorithm,
+			N:         base64.RawURLEncoding.EncodeToString(key.key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.key.E)).Bytes()),
+		})
+	}
+	return set, nil
+}
+
+// RotateSigningKey creates a new signing key when there is none or the newest key has signed
+// tokens for `KeyRotationPeriod`. The replaced key is published until the tokens that it signed
+// have expired. It tells whether a key was created
+func (s *ServiceTokensImpl) RotateSigningKey(ctx context.Context) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RotateSigningKey")
+	defer span.End()
+
+	keys, err := s.signingKeys(ctx, true)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if len(keys) > 0 && now.Sub(keys[0].created) < KeyRotationPeriod {
+		return false, nil
+	}
+	if err := s.createSigningKey(ctx, now); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// StartKeyRotation rotates the signing key when it is due, right away and then at the provided
+// interval until the context is cancelled
+func (s *ServiceTokensImpl) StartKeyRotation(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := s.RotateSigningKey(ctx); err != nil {
+				log.Printf("unable to rotate the signing key: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// activeKey returns the newest signing key that is published until the provided time, creating
+// one when there is none
+func (s *ServiceTokensImpl) activeKey(ctx context.Context, until time.Time) (*signingKey, error) {
+	keys, err := s.signingKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 && keys[0].notAfter.After(until) {
+		return keys[0], nil
+	}
+
+	if err := s.createSigningKey(ctx, time.Now().In(pubsubtools.TimeLocation)); err != nil {
+		return nil, err
+	}
+	keys, err = s.signingKeys(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, exceptions.InternalServerError(fmt.Errorf("no signing key is available"))
+	}
+	return keys[0], nil
+}
+
+// publicKey returns the public key of the signing key with the provided ID. The keys are read
+// again when the key is not known and they were not read recently
+func (s *ServiceTokensImpl) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	keys, err := s.signingKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if key := findKey(keys, kid); key != nil {
+		return &key.key.PublicKey, nil
+	}
+
+	s.mu.Lock()
+	stale := time.Since(s.fetchedAt) >= keysRefreshInterval
+	s.mu.Unlock()
+	if stale {
+		keys, err = s.signingKeys(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		if key := findKey(keys, kid); key != nil {
+			return &key.key.PublicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func findKey(keys []*signingKey, kid string) *signingKey {
+	for _, key := range keys {
+		if key.id == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// signingKeys returns the published signing keys, newest first. They are read from the database
+// when they are stale or a refresh is asked for
+func (s *ServiceTokensImpl) signingKeys(ctx context.Context, refresh bool) ([]*signingKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if !refresh && now.Sub(s.fetchedAt) < keysCacheDuration {
+		return publishedKeys(s.keys, now), nil
+	}
+
+	stored, err := s.database.ListSigningKeys(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*signingKey{}
+	for _, key := range stored {
+		parsed, err := openSigningKey(s.keyEncryptionKey, key)
+		if err != nil {
+			return nil, exceptions.InternalServerError(
+				fmt.Errorf("unable to read signing key %s: %w", key.ID, err),
+			)
+		}
+		keys = append(keys, &signingKey{
+			id:       key.ID,
+			key:      parsed,
+			created:  key.Created,
+			notAfter: key.NotAfter,
+		})
+	}
+	s.keys = keys
+	s.fetchedAt = now
+	return keys, nil
+}
+
+// publishedKeys drops the cached keys that are no longer published
+func publishedKeys(keys []*signingKey, now time.Time) []*signingKey {
+	published := []*signingKey{}
+	for _, key := range keys {
+		if key.notAfter.After(now) {
+			published = append(published, key)
+		}
+	}
+	return published
+}
+
+// createSigningKey generates, encrypts and stores a new signing key. It signs tokens for
+// `KeyRotationPeriod` and is then published for as long as the tokens that it signed are valid.
+// The rotation interval is added since the key is only replaced when the rotation runs
+func (s *ServiceTokensImpl) createSigningKey(ctx context.Context, now time.Time) error {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return exceptions.InternalServerError(fmt.Errorf("unable to generate a signing key: %w", err))
+	}
+	signingKey := &domain.SigningKey{
+		ID:        uuid.New().String(),
+		Algorithm: signingAlgorithm,
+		Created:   now,
+		NotAfter:  now.Add(KeyRotationPeriod + KeyRotationInterval + AccessTokenLifetime),
+	}
+	if err := sealSigningKey(s.keyEncryptionKey, signingKey, key); err != nil {
+		return exceptions.InternalServerError(fmt.Errorf("unable to encrypt the signing key: %w", err))
+	}
+	if err := s.database.SaveSigningKey(ctx, signingKey); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	// the new key is read the next time that the keys are used
+	s.mu.Lock()
+	s.fetchedAt = time.Time{}
+	s.mu.Unlock()
+	return nil
+}
+
+// FirebaseTokens leaves issuing access tokens to Firebase. Users log in with Firebase ID tokens
+// and no keys are published
+type FirebaseTokens struct {
+	idTokens IDTokenVerifier
+}
+
+// NewFirebaseTokens initializes the issuer that leaves access tokens to Firebase. The ID tokens
+// are checked with `idTokens`
+func NewFirebaseTokens(idTokens IDTokenVerifier) *FirebaseTokens {
+	return &FirebaseTokens{idTokens: idTokens}
+}
+
+// Enabled tells whether the service issues its own access tokens
+func (f *FirebaseTokens) Enabled() bool {
+	return false
+}
+
+// IssueAccessToken fails since Firebase issues the access tokens
+func (f *FirebaseTokens) IssueAccessToken(
+	ctx context.Context,
+	claims *domain.AccessTokenClaims,
+) (*domain.AccessToken, error) {
+	return nil, fmt.Errorf("the service does not issue access tokens")
+}
+
+// VerifyAccessToken fails since Firebase issues the access tokens
+func (f *FirebaseTokens) VerifyAccessToken(ctx context.Context, token string) (*domain.AccessTokenClaims, error) {
+	return nil, fmt.Errorf("the service does not issue access tokens")
+}
+
+// SessionActive fails since Firebase issues the access tokens
+func (f *FirebaseTokens) SessionActive(ctx context.Context, profileID string, sessionID string) (bool, error) {
+	return false, fmt.Errorf("the service does not issue access tokens")
+}
+
+// VerifyBearerToken verifies a Firebase ID token and checks that it has not been revoked
+func (f *FirebaseTokens) VerifyBearerToken(ctx context.Context, token string) (*auth.Token, error) {
+	return verifyFirebaseIDToken(ctx, f.idTokens, token)
+}
+
+// verifyFirebaseIDToken verifies a Firebase ID token and checks that the refresh tokens of its
+// user were not revoked after it was issued, i.e. that the user was not signed out
+func verifyFirebaseIDToken(ctx context.Context, idTokens IDTokenVerifier, token string) (*auth.Token, error) {
+	verified, err := idTokens.VerifyIDTokenAndCheckRevoked(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth token: %w", err)
+	}
+	return verified, nil
+}
+
+// JWKS returns an empty key set
+func (f *FirebaseTokens) JWKS(ctx context.Context) (*domain.JSONWebKeySet, error) {
+	return &domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}, nil
+}
+
+// RotateSigningKey does nothing since there are no signing keys
+func (f *FirebaseTokens) RotateSigningKey(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// StartKeyRotation does nothing since there are no signing keys
+func (f *FirebaseTokens) StartKeyRotation(ctx context.Context, interval time.Duration) {}
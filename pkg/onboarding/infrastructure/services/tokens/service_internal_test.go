@@ -0,0 +1,74 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenSigningKey(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	kek, err := NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := NewServiceTokensImpl(repo, kek, nil).createSigningKey(ctx, time.Now()); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	keys, err := repo.ListSigningKeys(ctx, time.Now())
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("expected one signing key got %v %v", keys, err)
+	}
+
+	_, err = openSigningKey(kek, keys[0])
+	assert.Nil(t, err)
+
+	// the encrypted data key is bound to the signing key it was created for
+	moved := *keys[0]
+	moved.ID = "another-key"
+	_, err = openSigningKey(kek, &moved)
+	assert.NotNil(t, err)
+}
+
+func TestServiceTokensImpl_VerifyAccessToken_Audience(t *testing.T) {
+	ctx := context.Background()
+	kek, err := NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	service := NewServiceTokensImpl(memory.NewMemoryRepository(), kek, nil)
+	key, err := service.activeKey(ctx, time.Now().Add(AccessTokenLifetime))
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	sign := func(audience string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, accessTokenClaims{
+			ProfileID: "profile-1",
+			StandardClaims: jwt.StandardClaims{
+				Issuer:    Issuer,
+				Audience:  audience,
+				Subject:   "uid-1",
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			},
+		})
+		token.Header["kid"] = key.id
+		signed, err := token.SignedString(key.key)
+		if err != nil {
+			t.Fatalf("error not expected got %v", err)
+		}
+		return signed
+	}
+
+	_, err = service.VerifyAccessToken(ctx, sign(Audience))
+	assert.Nil(t, err)
+	for _, audience := range []string{"", "another-service"} {
+		_, err = service.VerifyAccessToken(ctx, sign(audience))
+		assert.NotNil(t, err, audience)
+	}
+}
@@ -0,0 +1,420 @@
+package tokens_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+func testClaims() *domain.AccessTokenClaims {
+	return &domain.AccessTokenClaims{
+		UID:       "uid-1",
+		ProfileID: "profile-1",
+		SessionID: "session-1",
+		Flavour:   feedlib.FlavourPro,
+		Scopes:    []string{"role.create", "role.view"},
+	}
+}
+
+func testKeyEncryptionKey(t *testing.T) []byte {
+	kek, err := tokens.NewKeyEncryptionKey()
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	return kek
+}
+
+func TestServiceTokensImpl_IssueAccessToken(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	kek := testKeyEncryptionKey(t)
+	service := tokens.NewServiceTokensImpl(repo, kek, nil)
+
+	token, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, tokens.AccessTokenLifetime, token.ExpiresAt.Sub(token.IssuedAt))
+	assert.True(t, tokens.IsServiceToken(token.Token))
+
+	claims, err := service.VerifyAccessToken(ctx, token.Token)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "uid-1", claims.UID)
+	assert.Equal(t, "profile-1", claims.ProfileID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, feedlib.FlavourPro, claims.Flavour)
+	assert.Equal(t, []string{"role.create", "role.view"}, claims.Scopes)
+
+	// the token is verified with the published key
+	set, err := service.JWKS(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, set.Keys, 1)
+	published := set.Keys[0]
+	n, _ := base64.RawURLEncoding.DecodeString(published.N)
+	e, _ := base64.RawURLEncoding.DecodeString(published.E)
+	publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	parsed, err := jwt.Parse(token.Token, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, published.KeyID, token.Header["kid"])
+		return publicKey, nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, parsed.Valid)
+
+	// another instance of the service signs with the same key
+	other := tokens.NewServiceTokensImpl(repo, kek, nil)
+	_, err = other.VerifyAccessToken(ctx, token.Token)
+	assert.Nil(t, err)
+	otherSet, err := other.JWKS(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, set, otherSet)
+
+	// the private key is only stored encrypted, and can't be read without the key encryption key
+	keys, err := repo.ListSigningKeys(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if assert.Len(t, keys, 1) {
+		assert.NotEmpty(t, keys[0].EncryptedPrivateKey)
+		assert.NotEmpty(t, keys[0].EncryptedDataKey)
+		assert.NotContains(t, keys[0].EncryptedPrivateKey, "PRIVATE KEY")
+	}
+	_, err = tokens.NewServiceTokensImpl(repo, testKeyEncryptionKey(t), nil).JWKS(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestServiceTokensImpl_VerifyAccessToken(t *testing.T) {
+	ctx := context.Background()
+	service := tokens.NewServiceTokensImpl(memory.NewMemoryRepository(), testKeyEncryptionKey(t), nil)
+	token, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	// a token that is signed by another service with the same issuer
+	forger := tokens.NewServiceTokensImpl(memory.NewMemoryRepository(), testKeyEncryptionKey(t), nil)
+	forged, err := forger.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	parts := strings.Split(token.Token, ".")
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"iss":       tokens.Issuer,
+		"sub":       "uid-1",
+		"profileID": "profile-1",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	})
+	none, _ := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	for name, invalid := range map[string]string{
+		"forged":   forged.Token,
+		"tampered": parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"uid-2"}`)) + "." + parts[2],
+		"unsigned": none,
+		"garbage":  "not-a-token",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.VerifyAccessToken(ctx, invalid)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// fakeIDTokens accepts the Firebase ID token "firebase-id-token" of uid-1
+type fakeIDTokens struct {
+	verified int
+}
+
+func (f *fakeIDTokens) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*auth.Token, error) {
+	f.verified++
+	if idToken != "firebase-id-token" {
+		return nil, fmt.Errorf("the ID token is not valid")
+	}
+	return &auth.Token{UID: "uid-1", Issuer: "https://securetoken.google.com/project"}, nil
+}
+
+func TestServiceTokensImpl_VerifyBearerToken(t *testing.T) {
+	ctx := context.Background()
+	idTokens := &fakeIDTokens{}
+	service := tokens.NewServiceTokensImpl(memory.NewMemoryRepository(), testKeyEncryptionKey(t), idTokens)
+	token, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	authToken, err := service.VerifyBearerToken(ctx, token.Token)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, "uid-1", authToken.UID)
+	assert.Equal(t, "profile-1", authToken.Claims["profileID"])
+	assert.Equal(t, feedlib.FlavourPro.String(), authToken.Claims["flavour"])
+	profileID, sessionID, ok := tokens.SessionID(authToken)
+	assert.True(t, ok)
+	assert.Equal(t, "profile-1", profileID)
+	assert.Equal(t, "session-1", sessionID)
+
+	// Firebase ID tokens are not tied to a session of the service
+	_, _, ok = tokens.SessionID(&auth.Token{UID: "uid-1", Issuer: "https://securetoken.google.com/project"})
+	assert.False(t, ok)
+
+	_, err = service.VerifyBearerToken(ctx, token.Token+"x")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, idTokens.verified)
+
+	// the users that logged in before the service issued its own access tokens use Firebase ID
+	// tokens
+	authToken, err = service.VerifyBearerToken(ctx, "firebase-id-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "uid-1", authToken.UID)
+	_, err = service.VerifyBearerToken(ctx, "revoked-id-token")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, idTokens.verified)
+}
+
+func TestFirebaseTokens_VerifyBearerToken(t *testing.T) {
+	ctx := context.Background()
+	service := tokens.NewFirebaseTokens(&fakeIDTokens{})
+
+	authToken, err := service.VerifyBearerToken(ctx, "firebase-id-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "uid-1", authToken.UID)
+
+	_, err = service.VerifyBearerToken(ctx, "revoked-id-token")
+	assert.NotNil(t, err)
+}
+
+func TestServiceTokensImpl_RotateSigningKey(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	kek := testKeyEncryptionKey(t)
+	service := tokens.NewServiceTokensImpl(repo, kek, nil)
+
+	rotated, err := service.RotateSigningKey(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, rotated)
+
+	// the key is not due for rotation
+	rotated, err = service.RotateSigningKey(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.False(t, rotated)
+
+	// a key that is due for rotation is replaced, and still published for the tokens that it signed
+	now := time.Now()
+	keys, err := repo.ListSigningKeys(ctx, now)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	due := *keys[0]
+	due.Created = now.Add(-tokens.KeyRotationPeriod)
+	dueRepo := memory.NewMemoryRepository()
+	if err := dueRepo.SaveSigningKey(ctx, &due); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	service = tokens.NewServiceTokensImpl(dueRepo, kek, nil)
+	token, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	rotated, err = service.RotateSigningKey(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.True(t, rotated)
+
+	set, err := service.JWKS(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Len(t, set.Keys, 2)
+	assert.Equal(t, due.ID, set.Keys[1].KeyID)
+	_, err = service.VerifyAccessToken(ctx, token.Token)
+	assert.Nil(t, err)
+
+	rotatedToken, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	parsed, _, err := new(jwt.Parser).ParseUnverified(rotatedToken.Token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, set.Keys[0].KeyID, parsed.Header["kid"])
+}
+
+func TestNewServiceTokens(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	t.Setenv(tokens.KeyEncryptionKeyEnvVarName, "")
+
+	t.Setenv(domain.Repo, domain.FirebaseRepository)
+	t.Setenv(tokens.IssuerEnvVarName, "")
+	service, err := tokens.NewServiceTokens(repo)
+	assert.Nil(t, err)
+	assert.False(t, service.Enabled())
+	set, err := service.JWKS(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, set.Keys)
+
+	// Firebase can't verify the credentials of the in memory repository
+	t.Setenv(domain.Repo, domain.MemoryRepository)
+	service, err = tokens.NewServiceTokens(repo)
+	assert.Nil(t, err)
+	assert.True(t, service.Enabled())
+
+	t.Setenv(tokens.IssuerEnvVarName, tokens.FirebaseIssuer)
+	service, err = tokens.NewServiceTokens(repo)
+	assert.Nil(t, err)
+	assert.False(t, service.Enabled())
+
+	t.Setenv(tokens.IssuerEnvVarName, tokens.ServiceIssuer)
+	service, err = tokens.NewServiceTokens(repo)
+	assert.Nil(t, err)
+	assert.True(t, service.Enabled())
+
+	// the signing keys of the other repositories are only stored encrypted
+	t.Setenv(domain.Repo, domain.FirebaseRepository)
+	_, err = tokens.NewServiceTokens(repo)
+	assert.NotNil(t, err)
+	t.Setenv(tokens.KeyEncryptionKeyEnvVarName, base64.StdEncoding.EncodeToString([]byte("too-short")))
+	_, err = tokens.NewServiceTokens(repo)
+	assert.NotNil(t, err)
+	t.Setenv(tokens.KeyEncryptionKeyEnvVarName, base64.StdEncoding.EncodeToString(testKeyEncryptionKey(t)))
+	service, err = tokens.NewServiceTokens(repo)
+	assert.Nil(t, err)
+	assert.True(t, service.Enabled())
+
+	t.Setenv(tokens.IssuerEnvVarName, "unknown")
+	_, err = tokens.NewServiceTokens(repo)
+	assert.NotNil(t, err)
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	kek := testKeyEncryptionKey(t)
+	service := tokens.NewServiceTokensImpl(repo, kek, nil)
+	token, err := service.IssueAccessToken(ctx, testClaims())
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	if err := repo.SaveSession(ctx, &domain.Session{ID: "session-1", ProfileID: "profile-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	var uid string
+	handler := tokens.AuthenticationMiddleware(nil, service)(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			uid, err = firebasetools.GetLoggedInUserUID(r.Context())
+			assert.Nil(t, err)
+			authToken, err := firebasetools.GetUserTokenFromContext(r.Context())
+			assert.Nil(t, err)
+			assert.Equal(t, "profile-1", authToken.Claims["profileID"])
+		},
+	))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "uid-1", uid)
+
+	// a tampered service token is not passed on to Firebase
+	req = httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer "+token.Token+"x")
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	// a token whose session is not in the sessions registry is refused
+	claims := testClaims()
+	claims.SessionID = "session-2"
+	unknown, err := service.IssueAccessToken(ctx, claims)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer "+unknown.Token)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	// the token of a session that is signed out is refused once the session is no longer cached
+	if err := repo.RevokeSessions(ctx, "profile-1", []string{"session-1"}, time.Now()); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	handler = tokens.AuthenticationMiddleware(nil, tokens.NewServiceTokensImpl(repo, kek, nil))(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("the request of a revoked session should not be handled")
+		},
+	))
+	req = httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestServiceTokensImpl_SessionActive(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	service := tokens.NewServiceTokensImpl(repo, testKeyEncryptionKey(t), nil)
+	if err := repo.SaveSession(ctx, &domain.Session{ID: "session-1", ProfileID: "profile-1"}); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+
+	active, err := service.SessionActive(ctx, "profile-1", "session-1")
+	assert.Nil(t, err)
+	assert.True(t, active)
+
+	for name, session := range map[string][2]string{
+		"unknown session": {"profile-1", "session-2"},
+		"another profile": {"profile-2", "session-1"},
+		"no session":      {"profile-1", ""},
+	} {
+		active, err := service.SessionActive(ctx, session[0], session[1])
+		assert.Nil(t, err, name)
+		assert.False(t, active, name)
+	}
+
+	// the answer is cached for a while, after which the sign out is seen
+	if err := repo.RevokeSessions(ctx, "profile-1", []string{"session-1"}, time.Now()); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	active, err = service.SessionActive(ctx, "profile-1", "session-1")
+	assert.Nil(t, err)
+	assert.True(t, active)
+
+	active, err = tokens.NewServiceTokensImpl(repo, testKeyEncryptionKey(t), nil).SessionActive(ctx, "profile-1", "session-1")
+	assert.Nil(t, err)
+	assert.False(t, active)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+)
+
+// FakeServiceWebhooks ...
+type FakeServiceWebhooks struct {
+	DeliverPendingWebhooksFn func(ctx context.Context) (int, error)
+	StartWebhookDispatcherFn func(ctx context.Context, interval time.Duration)
+	RedeliverWebhookFn       func(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+// DeliverPendingWebhooks ...
+func (m *FakeServiceWebhooks) DeliverPendingWebhooks(ctx context.Context) (int, error) {
+	return m.DeliverPendingWebhooksFn(ctx)
+}
+
+// StartWebhookDispatcher ...
+func (m *FakeServiceWebhooks) StartWebhookDispatcher(ctx context.Context, interval time.Duration) {
+	m.StartWebhookDispatcherFn(ctx, interval)
+}
+
+// RedeliverWebhook ...
+func (m *FakeServiceWebhooks) RedeliverWebhook(
+	ctx context.Context,
+	deliveryID string,
+) (*domain.WebhookDelivery, error) {
+	return m.RedeliverWebhookFn(ctx, deliveryID)
+}
@@ -0,0 +1,236 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
+	"github.com/savannahghi/pubsubtools"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/time/rate"
+)
+
+// Package that generates trace information
+var tracer = otel.Tracer(
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks",
+)
+
+const (
+	// DispatchInterval is how often the dispatcher looks for deliveries that are due
+	DispatchInterval = 10 * time.Second
+
+	// MaxWebhookDeliveryAttempts is the number of times a delivery is attempted before it fails.
+	// A failed delivery can still be redelivered manually
+	MaxWebhookDeliveryAttempts = 8
+
+	// retryBackoff is the delay before the first retry of a delivery, it doubles after every attempt
+	retryBackoff = 30 * time.Second
+
+	// maxRetryBackoff caps the delay between the attempts of a delivery
+	maxRetryBackoff = 6 * time.Hour
+
+	// deliveryTimeout is how long an endpoint has to respond to a delivery
+	deliveryTimeout = 15 * time.Second
+
+	// dispatchBatchSize is the number of deliveries the dispatcher attempts in a single run
+	dispatchBatchSize = 50
+
+	// deliveriesPerSecond limits the rate of requests made to the endpoints
+	deliveriesPerSecond = 10
+
+	// maxErrorLength is the number of characters of a response body that are kept in the delivery log
+	maxErrorLength = 512
+)
+
+// ServiceWebhooks delivers the domain events to the webhook endpoints of partner systems
+type ServiceWebhooks interface {
+	DeliverPendingWebhooks(ctx context.Context) (int, error)
+
+	StartWebhookDispatcher(ctx context.Context, interval time.Duration)
+
+	RedeliverWebhook(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+// ServiceWebhooksImpl delivers webhooks with a rate limited HTTP client
+type ServiceWebhooksImpl struct {
+	database database.Repository
+	client   *utils.RatedHTTPClient
+}
+
+// NewServiceWebhooksImpl initializes the webhooks service
+func NewServiceWebhooksImpl(db database.Repository) *ServiceWebhooksImpl {
+	return &ServiceWebhooksImpl{
+		database: db,
+		client:   utils.NewClient(rate.NewLimiter(rate.Limit(deliveriesPerSecond), deliveriesPerSecond)),
+	}
+}
+
+// DeliverPendingWebhooks attempts the pending deliveries that are due, oldest first.
+// It returns the number of deliveries that succeeded
+func (s *ServiceWebhooksImpl) DeliverPendingWebhooks(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "DeliverPendingWebhooks")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	deliveries, err := s.database.ListDueWebhookDeliveries(ctx, now, dispatchBatchSize)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		if err := s.attempt(ctx, delivery); err != nil {
+			utils.RecordSpanError(span, err)
+			return delivered, err
+		}
+		if delivery.Status == domain.WebhookDeliveryStatusSucceeded {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// StartWebhookDispatcher delivers the pending webhooks at the provided interval until the context is cancelled
+func (s *ServiceWebhooksImpl) StartWebhookDispatcher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.DeliverPendingWebhooks(ctx); err != nil {
+					log.Printf("unable to deliver webhooks: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// RedeliverWebhook attempts a delivery straight away, whatever its status. A delivery that
+// fails again is retried with a backoff, starting from its current number of attempts
+func (s *ServiceWebhooksImpl) RedeliverWebhook(
+	ctx context.Context,
+	deliveryID string,
+) (*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "RedeliverWebhook")
+	defer span.End()
+
+	delivery, err := s.database.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if err := s.attempt(ctx, delivery); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// attempt delivers an event to its endpoint and records the outcome on the delivery
+func (s *ServiceWebhooksImpl) attempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := tracer.Start(ctx, "attempt")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	delivery.Attempts++
+
+	endpoint, err := s.database.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	switch {
+	case err != nil:
+		utils.RecordSpanError(span, err)
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.ResponseStatus = 0
+		delivery.LastError = fmt.Sprintf("unable to get the webhook endpoint: %v", err)
+
+	case !endpoint.Active:
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.ResponseStatus = 0
+		delivery.LastError = "the webhook endpoint is not active"
+
+	default:
+		status, err := s.post(ctx, endpoint, delivery, now)
+		delivery.ResponseStatus = status
+		if err == nil {
+			delivery.Status = domain.WebhookDeliveryStatusSucceeded
+			delivery.LastError = ""
+			delivery.Delivered = &now
+			break
+		}
+		utils.RecordSpanError(span, err)
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= MaxWebhookDeliveryAttempts {
+			delivery.Status = domain.WebhookDeliveryStatusFailed
+			break
+		}
+		delivery.Status = domain.WebhookDeliveryStatusPending
+		delivery.NextAttempt = now.Add(RetryBackoff(delivery.Attempts))
+	}
+
+	if err := s.database.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// post sends a signed delivery to an endpoint. It returns the response status and an error
+// when the endpoint did not acknowledge the delivery with a 2xx response
+func (s *ServiceWebhooksImpl) post(
+	ctx context.Context,
+	endpoint *domain.WebhookEndpoint,
+	delivery *domain.WebhookDelivery,
+	now time.Time,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, exceptions.InternalServerError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(utils.WebhookSignatureHeader, utils.SignWebhookPayload(endpoint.Secret, now, payload))
+	req.Header.Set(utils.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(utils.WebhookDeliveryHeader, delivery.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("unable to reach the webhook endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return resp.StatusCode, fmt.Errorf(
+			"the webhook endpoint responded with status %d: %s", resp.StatusCode, string(body),
+		)
+	}
+	return resp.StatusCode, nil
+}
+
+// RetryBackoff is the delay before a delivery is attempted again after it has failed `attempts` times
+func RetryBackoff(attempts int) time.Duration {
+	backoff := retryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,153 @@
+package webhooks_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database/memory"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "a-very-long-secret"
+
+// partner is a webhook endpoint that records the deliveries whose signature is valid
+type partner struct {
+	mu       sync.Mutex
+	status   int
+	received []string
+	events   []string
+}
+
+func (p *partner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil || !utils.VerifyWebhookSignature(testSecret, r.Header.Get(utils.WebhookSignatureHeader), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if p.status != http.StatusOK {
+		w.WriteHeader(p.status)
+		return
+	}
+	p.received = append(p.received, r.Header.Get(utils.WebhookDeliveryHeader))
+	p.events = append(p.events, r.Header.Get(utils.WebhookEventHeader))
+}
+
+func setupWebhook(t *testing.T, p *partner) (*memory.Repository, *domain.WebhookDelivery) {
+	ctx := context.Background()
+	server := httptest.NewServer(p)
+	t.Cleanup(server.Close)
+
+	repo := memory.NewMemoryRepository()
+	endpoint := &domain.WebhookEndpoint{
+		ID:         "endpoint-1",
+		URL:        server.URL,
+		Secret:     testSecret,
+		EventTypes: []domain.EventType{domain.EventTypeUserCreated},
+		Active:     true,
+	}
+	if err := repo.CreateWebhookEndpoint(ctx, endpoint); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	event := &domain.OutboxEvent{ID: "event-1", Type: domain.EventTypeUserCreated, Payload: `{"id":"event-1"}`}
+	if err := repo.EnqueueWebhookDeliveries(ctx, event); err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	deliveries, err := repo.ListWebhookDeliveries(ctx, endpoint.ID, nil)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	return repo, deliveries[0]
+}
+
+func TestServiceWebhooksImpl_DeliverPendingWebhooks(t *testing.T) {
+	ctx := context.Background()
+	p := &partner{status: http.StatusOK}
+	repo, delivery := setupWebhook(t, p)
+	s := webhooks.NewServiceWebhooksImpl(repo)
+
+	delivered, err := s.DeliverPendingWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{delivery.ID}, p.received)
+	assert.Equal(t, []string{string(domain.EventTypeUserCreated)}, p.events)
+
+	stored, err := repo.GetWebhookDelivery(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.WebhookDeliveryStatusSucceeded, stored.Status)
+	assert.Equal(t, http.StatusOK, stored.ResponseStatus)
+	assert.NotNil(t, stored.Delivered)
+
+	// a delivery that succeeded is not attempted again
+	delivered, err = s.DeliverPendingWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 0, delivered)
+	assert.Len(t, p.received, 1)
+}
+
+func TestServiceWebhooksImpl_RetriesAndRedelivery(t *testing.T) {
+	ctx := context.Background()
+	p := &partner{status: http.StatusServiceUnavailable}
+	repo, delivery := setupWebhook(t, p)
+	s := webhooks.NewServiceWebhooksImpl(repo)
+
+	delivered, err := s.DeliverPendingWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 0, delivered)
+
+	stored, err := repo.GetWebhookDelivery(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.WebhookDeliveryStatusPending, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, stored.ResponseStatus)
+	assert.NotEmpty(t, stored.LastError)
+	assert.True(t, stored.NextAttempt.After(time.Now()), "the retry should be backed off")
+
+	// the delivery is not due yet
+	delivered, err = s.DeliverPendingWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, p.received)
+
+	// it can be redelivered manually once the endpoint has recovered
+	p.status = http.StatusOK
+	redelivered, err := s.RedeliverWebhook(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, domain.WebhookDeliveryStatusSucceeded, redelivered.Status)
+	assert.Equal(t, 2, redelivered.Attempts)
+	assert.Equal(t, []string{delivery.ID}, p.received)
+
+	_, err = s.RedeliverWebhook(ctx, "missing")
+	assert.NotNil(t, err)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhooks.RetryBackoff(1))
+	assert.Equal(t, time.Minute, webhooks.RetryBackoff(2))
+	assert.Equal(t, 2*time.Minute, webhooks.RetryBackoff(3))
+	assert.Equal(t, 6*time.Hour, webhooks.RetryBackoff(20))
+}
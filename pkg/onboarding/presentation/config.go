@@ -13,7 +13,12 @@ import (
 
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks"
 
 	"github.com/savannahghi/onboarding/pkg/onboarding/usecases"
 
@@ -42,18 +47,27 @@ var AllowedOrigins = []string{
 var allowedHeaders = []string{
 	"Authorization", "Accept", "Accept-Charset", "Accept-Language",
 	"Accept-Encoding", "Origin", "Host", "User-Agent", "Content-Length",
-	"Content-Type",
+	"Content-Type", utils.DeviceIDHeader, utils.PlatformHeader, utils.AppVersionHeader,
 }
 
 // Router sets up the ginContext router
 func Router(ctx context.Context) (*mux.Router, error) {
 	fc := &firebasetools.FirebaseClient{}
-	firebaseApp, err := fc.InitFirebase()
+	firebaseApp, err := initFirebase(fc)
 	if err != nil {
 		return nil, err
 	}
 	infrastructure := infrastructure.NewInfrastructureInteractor()
 
+	// publish the domain events written to the outbox
+	infrastructure.Pubsub.StartOutboxRelay(ctx, pubsubmessaging.OutboxRelayInterval)
+
+	// deliver the domain events to the registered webhook endpoints
+	infrastructure.Webhooks.StartWebhookDispatcher(ctx, webhooks.DispatchInterval)
+
+	// replace the key that the service signs its access tokens with when it is due
+	infrastructure.Tokens.StartKeyRotation(ctx, tokens.KeyRotationInterval)
+
 	// Initialize base (common) extension
 	baseExt := extension.NewBaseExtensionImpl(fc)
 	pinExt := extension.NewPINExtensionImpl()
@@ -75,11 +89,17 @@ func Router(ctx context.Context) (*mux.Router, error) {
 	// Add Middleware that records the metrics for HTTP routes
 	r.Use(serverutils.CustomHTTPRequestMetricsMiddleware())
 
-	SharedRoutes(h, r)
+	// Add Middleware that records the device and app of every request for the sessions registry
+	r.Use(utils.ClientInfoMiddleware())
+
+	// the authenticated routes accept the access tokens signed by the service as well as Firebase ID tokens
+	authenticate := tokens.AuthenticationMiddleware(firebaseApp, infrastructure.Tokens)
+
+	SharedRoutes(h, r, authenticate)
 
 	// Graphql route
 	authR := r.Path("/graphql").Subrouter()
-	authR.Use(firebasetools.AuthenticationMiddleware(firebaseApp))
+	authR.Use(authenticate)
 	authR.Methods(
 		http.MethodPost,
 		http.MethodGet,
@@ -88,6 +108,16 @@ func Router(ctx context.Context) (*mux.Router, error) {
 	return r, nil
 }
 
+// initFirebase initializes Firebase unless the service runs on the in memory repository, which
+// does not need a GCP project. Without Firebase, only the access tokens signed by the service
+// are accepted
+func initFirebase(fc *firebasetools.FirebaseClient) (firebasetools.IFirebaseApp, error) {
+	if os.Getenv(domain.Repo) == domain.MemoryRepository {
+		return nil, nil
+	}
+	return fc.InitFirebase()
+}
+
 // PrepareServer starts up a server
 func PrepareServer(ctx context.Context, port int, allowedOrigins []string) *http.Server {
 	// start up the router
@@ -0,0 +1,83 @@
+package presentation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
+	"github.com/savannahghi/onboarding/pkg/onboarding/presentation"
+	"github.com/savannahghi/serverutils"
+)
+
+// TestRouter_WithoutGCP starts the service on the in memory repository and the local pubsub
+// broker with none of the env vars of a GCP project set
+func TestRouter_WithoutGCP(t *testing.T) {
+	t.Setenv(domain.Repo, domain.MemoryRepository)
+	t.Setenv(pubsubmessaging.BrokerEnvVarName, pubsubmessaging.LocalPubSubBroker)
+	t.Setenv("ENVIRONMENT", "staging")
+	t.Setenv("SERVICE_HOST", "localhost")
+	for _, name := range []string{
+		serverutils.GoogleCloudProjectIDEnvVarName,
+		firebasetools.GoogleApplicationCredentialsEnvVarName,
+		firebasetools.FirebaseWebAPIKeyEnvVarName,
+	} {
+		// the original values are restored when the test is done
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := presentation.Router(ctx)
+	if err != nil {
+		t.Fatalf("unable to start the service without GCP: %v", err)
+	}
+
+	response := httptest.NewRecorder()
+	r.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if response.Code != http.StatusOK {
+		t.Errorf("expected the service to be healthy, got status %d", response.Code)
+	}
+
+	// the service signs its own access tokens since Firebase can't verify local credentials. Its
+	// first signing key is created in the background when it starts
+	keys := &domain.JSONWebKeySet{}
+	for deadline := time.Now().Add(10 * time.Second); len(keys.Keys) == 0 && time.Now().Before(deadline); {
+		time.Sleep(100 * time.Millisecond)
+		response = httptest.NewRecorder()
+		r.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		if response.Code != http.StatusOK {
+			t.Fatalf("expected the signing keys to be published, got status %d", response.Code)
+		}
+		if err := json.Unmarshal(response.Body.Bytes(), keys); err != nil {
+			t.Fatalf("unable to read the published keys: %v", err)
+		}
+	}
+	if len(keys.Keys) == 0 {
+		t.Errorf("expected a signing key to be published")
+	}
+
+	// the messages pushed by the local broker are routed to the pubsub handler, which refuses
+	// the ones that do not carry the secret of the broker
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/pubsub", strings.NewReader(`{}`)))
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("expected a push that is not sent by the broker to be refused, got status %d", response.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	request.Header.Set("Authorization", "Bearer not-a-token")
+	response = httptest.NewRecorder()
+	r.ServeHTTP(response, request)
+	if response.Code != http.StatusUnauthorized {
+		t.Errorf("expected a token that is not signed by the service to be rejected, got status %d", response.Code)
+	}
+}
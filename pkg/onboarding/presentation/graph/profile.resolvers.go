@@ -10,6 +10,7 @@ import (
 
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/presentation/graph/generated"
@@ -403,6 +404,216 @@ func (r *mutationResolver) DeactivateRole(ctx context.Context, roleID string) (*
 	return role, err
 }
 
+// RegisterWebhookEndpoint is the resolver for the registerWebhookEndpoint field.
+func (r *mutationResolver) RegisterWebhookEndpoint(ctx context.Context, input dto.WebhookEndpointInput) (*domain.WebhookEndpoint, error) {
+	startTime := time.Now()
+
+	endpoint, err := r.usecases.RegisterWebhookEndpoint(ctx, input)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "registerWebhookEndpoint", err)
+
+	return endpoint, err
+}
+
+// DeregisterWebhookEndpoint is the resolver for the deregisterWebhookEndpoint field.
+func (r *mutationResolver) DeregisterWebhookEndpoint(ctx context.Context, id string) (bool, error) {
+	startTime := time.Now()
+
+	status, err := r.usecases.DeregisterWebhookEndpoint(ctx, id)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "deregisterWebhookEndpoint", err)
+
+	return status, err
+}
+
+// RedeliverWebhook is the resolver for the redeliverWebhook field.
+func (r *mutationResolver) RedeliverWebhook(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+	startTime := time.Now()
+
+	delivery, err := r.usecases.RedeliverWebhook(ctx, deliveryID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "redeliverWebhook", err)
+
+	return delivery, err
+}
+
+// ReplayPubSubMessages is the resolver for the replayPubSubMessages field.
+func (r *mutationResolver) ReplayPubSubMessages(ctx context.Context, subscriptionID string, since time.Time) (int, error) {
+	startTime := time.Now()
+
+	replayed, err := r.usecases.ReplayPubSubMessages(ctx, subscriptionID, since)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "replayPubSubMessages", err)
+
+	return replayed, err
+}
+
+// UnlockUserPin is the resolver for the unlockUserPIN field.
+func (r *mutationResolver) UnlockUserPin(ctx context.Context, profileID string) (bool, error) {
+	startTime := time.Now()
+
+	unlocked, err := r.usecases.UnlockUserPIN(ctx, profileID)
+
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "unlockUserPIN", err)
+
+	return unlocked, err
+}
+
+// ReissueTempPin is the resolver for the reissueTempPIN field.
+func (r *mutationResolver) ReissueTempPin(ctx context.Context, profileID string) (bool, error) {
+	startTime := time.Now()
+
+	reissued, err := r.usecases.ReissueTempPIN(ctx, profileID)
+
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "reissueTempPIN", err)
+
+	return reissued, err
+}
+
+// RevokeSession is the resolver for the revokeSession field.
+func (r *mutationResolver) RevokeSession(ctx context.Context, sessionID string) (bool, error) {
+	startTime := time.Now()
+
+	revoked, err := r.usecases.RevokeSession(ctx, sessionID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "revokeSession", err)
+
+	return revoked, err
+}
+
+// RevokeAllSessions is the resolver for the revokeAllSessions field.
+func (r *mutationResolver) RevokeAllSessions(ctx context.Context) (bool, error) {
+	startTime := time.Now()
+
+	revoked, err := r.usecases.RevokeAllSessions(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "revokeAllSessions", err)
+
+	return revoked, err
+}
+
+// RevokeProfileSession is the resolver for the revokeProfileSession field.
+func (r *mutationResolver) RevokeProfileSession(ctx context.Context, profileID string, sessionID string) (bool, error) {
+	startTime := time.Now()
+
+	revoked, err := r.usecases.RevokeProfileSession(ctx, profileID, sessionID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "revokeProfileSession", err)
+
+	return revoked, err
+}
+
+// RevokeAllProfileSessions is the resolver for the revokeAllProfileSessions field.
+func (r *mutationResolver) RevokeAllProfileSessions(ctx context.Context, profileID string) (bool, error) {
+	startTime := time.Now()
+
+	revoked, err := r.usecases.RevokeAllProfileSessions(ctx, profileID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "revokeAllProfileSessions", err)
+
+	return revoked, err
+}
+
+// EnrolTotp is the resolver for the enrolTOTP field.
+func (r *mutationResolver) EnrolTotp(ctx context.Context) (*domain.TOTPProvisioning, error) {
+	startTime := time.Now()
+
+	provisioning, err := r.usecases.EnrolTOTP(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "enrolTOTP", err)
+
+	return provisioning, err
+}
+
+// ConfirmTOTPEnrolment is the resolver for the confirmTOTPEnrolment field.
+func (r *mutationResolver) ConfirmTOTPEnrolment(ctx context.Context, code string) (bool, error) {
+	startTime := time.Now()
+
+	confirmed, err := r.usecases.ConfirmTOTPEnrolment(ctx, code)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "confirmTOTPEnrolment", err)
+
+	return confirmed, err
+}
+
+// DisableTotp is the resolver for the disableTOTP field.
+func (r *mutationResolver) DisableTotp(ctx context.Context, code string) (bool, error) {
+	startTime := time.Now()
+
+	disabled, err := r.usecases.DisableTOTP(ctx, code)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "disableTOTP", err)
+
+	return disabled, err
+}
+
+// RegenerateRecoveryCodes is the resolver for the regenerateRecoveryCodes field.
+func (r *mutationResolver) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	startTime := time.Now()
+
+	codes, err := r.usecases.RegenerateRecoveryCodes(ctx, code)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "regenerateRecoveryCodes", err)
+
+	return codes, err
+}
+
+// ResetProfileTotp is the resolver for the resetProfileTOTP field.
+func (r *mutationResolver) ResetProfileTotp(ctx context.Context, profileID string) (bool, error) {
+	startTime := time.Now()
+
+	reset, err := r.usecases.ResetProfileTOTP(ctx, profileID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "resetProfileTOTP", err)
+
+	return reset, err
+}
+
+// SetTwoFactorRequiredScopes is the resolver for the setTwoFactorRequiredScopes field.
+func (r *mutationResolver) SetTwoFactorRequiredScopes(ctx context.Context, scopes []string) (*domain.TwoFactorPolicy, error) {
+	startTime := time.Now()
+
+	policy, err := r.usecases.SetTwoFactorRequiredScopes(ctx, scopes)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "setTwoFactorRequiredScopes", err)
+
+	return policy, err
+}
+
+// AddEmailLogin is the resolver for the addEmailLogin field.
+func (r *mutationResolver) AddEmailLogin(ctx context.Context, password string) (bool, error) {
+	startTime := time.Now()
+
+	added, err := r.usecases.AddEmailLogin(ctx, password)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "addEmailLogin", err)
+
+	return added, err
+}
+
+// LinkSocialAccount is the resolver for the linkSocialAccount field.
+func (r *mutationResolver) LinkSocialAccount(ctx context.Context, provider profileutils.LoginProviderType, idToken string) (bool, error) {
+	startTime := time.Now()
+
+	linked, err := r.usecases.LinkSocialAccount(ctx, provider, idToken)
+
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "linkSocialAccount", err)
+
+	return linked, err
+}
+
+// UnlinkSocialAccount is the resolver for the unlinkSocialAccount field.
+func (r *mutationResolver) UnlinkSocialAccount(ctx context.Context, provider profileutils.LoginProviderType) (bool, error) {
+	startTime := time.Now()
+
+	unlinked, err := r.usecases.UnlinkSocialAccount(ctx, provider)
+
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "unlinkSocialAccount", err)
+
+	return unlinked, err
+}
+
+// MergeUserProfiles is the resolver for the mergeUserProfiles field.
+func (r *mutationResolver) MergeUserProfiles(ctx context.Context, survivorID string, mergedProfileID string, reason string, dryRun *bool) (*domain.ProfileMerge, error) {
+	startTime := time.Now()
+
+	merge, err := r.usecases.MergeUserProfiles(
+		ctx,
+		survivorID,
+		mergedProfileID,
+		reason,
+		dryRun != nil && *dryRun,
+	)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "mergeUserProfiles", err)
+
+	return merge, err
+}
+
 // DummyQuery is the resolver for the dummyQuery field.
 func (r *queryResolver) DummyQuery(ctx context.Context) (*bool, error) {
 	dummy := true
@@ -499,6 +710,26 @@ func (r *queryResolver) GetAllRoles(ctx context.Context) ([]*dto.RoleOutput, err
 	return roles, err
 }
 
+// ListRoles is the resolver for the listRoles field.
+func (r *queryResolver) ListRoles(ctx context.Context, pagination *firebasetools.PaginationInput, filter *firebasetools.FilterInput, sort *firebasetools.SortInput) (*dto.RoleConnection, error) {
+	startTime := time.Now()
+
+	roles, err := r.usecases.ListRoles(ctx, pagination, filter, sort)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listRoles", err)
+
+	return roles, err
+}
+
+// ListUserProfiles is the resolver for the listUserProfiles field.
+func (r *queryResolver) ListUserProfiles(ctx context.Context, pagination *firebasetools.PaginationInput, filter *firebasetools.FilterInput, sort *firebasetools.SortInput) (*dto.UserProfileConnection, error) {
+	startTime := time.Now()
+
+	profiles, err := r.usecases.ListUserProfiles(ctx, pagination, filter, sort)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listUserProfiles", err)
+
+	return profiles, err
+}
+
 // FindRoleByName is the resolver for the findRoleByName field.
 func (r *queryResolver) FindRoleByName(ctx context.Context, roleName *string) ([]*dto.RoleOutput, error) {
 	startTime := time.Now()
@@ -549,6 +780,105 @@ func (r *queryResolver) GetNavigationActions(ctx context.Context) (*dto.GroupedN
 	return navActions, err
 }
 
+// ListFailedPubSubMessages is the resolver for the listFailedPubSubMessages field.
+func (r *queryResolver) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	startTime := time.Now()
+
+	messages, err := r.usecases.ListFailedPubSubMessages(ctx, topicID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listFailedPubSubMessages", err)
+
+	return messages, err
+}
+
+// ListWebhookEndpoints is the resolver for the listWebhookEndpoints field.
+func (r *queryResolver) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	startTime := time.Now()
+
+	endpoints, err := r.usecases.ListWebhookEndpoints(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listWebhookEndpoints", err)
+
+	return endpoints, err
+}
+
+// ListWebhookDeliveries is the resolver for the listWebhookDeliveries field.
+func (r *queryResolver) ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	startTime := time.Now()
+
+	deliveries, err := r.usecases.ListWebhookDeliveries(ctx, endpointID, status)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listWebhookDeliveries", err)
+
+	return deliveries, err
+}
+
+// ListSessions is the resolver for the listSessions field.
+func (r *queryResolver) ListSessions(ctx context.Context) ([]*domain.Session, error) {
+	startTime := time.Now()
+
+	sessions, err := r.usecases.ListSessions(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listSessions", err)
+
+	return sessions, err
+}
+
+// ListProfileSessions is the resolver for the listProfileSessions field.
+func (r *queryResolver) ListProfileSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	startTime := time.Now()
+
+	sessions, err := r.usecases.ListProfileSessions(ctx, profileID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listProfileSessions", err)
+
+	return sessions, err
+}
+
+// ListLoginEvents is the resolver for the listLoginEvents field.
+func (r *queryResolver) ListLoginEvents(ctx context.Context, from *time.Time, to *time.Time, outcome *domain.LoginOutcome, profileID *string, limit *int) ([]*domain.LoginEvent, error) {
+	startTime := time.Now()
+
+	filter := domain.LoginEventFilter{
+		From:      from,
+		To:        to,
+		Outcome:   outcome,
+		ProfileID: profileID,
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	events, err := r.usecases.ListLoginEvents(ctx, filter)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "listLoginEvents", err)
+
+	return events, err
+}
+
+// TwoFactorStatus is the resolver for the twoFactorStatus field.
+func (r *queryResolver) TwoFactorStatus(ctx context.Context) (*domain.TwoFactorStatus, error) {
+	startTime := time.Now()
+
+	status, err := r.usecases.GetTwoFactorStatus(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "twoFactorStatus", err)
+
+	return status, err
+}
+
+// TwoFactorPolicy is the resolver for the twoFactorPolicy field.
+func (r *queryResolver) TwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	startTime := time.Now()
+
+	policy, err := r.usecases.GetTwoFactorPolicy(ctx)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "twoFactorPolicy", err)
+
+	return policy, err
+}
+
+// ProfileTombstone is the resolver for the profileTombstone field.
+func (r *queryResolver) ProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	startTime := time.Now()
+
+	tombstone, err := r.usecases.GetProfileTombstone(ctx, profileID)
+	defer serverutils.RecordGraphqlResolverMetrics(ctx, startTime, "profileTombstone", err)
+
+	return tombstone, err
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -7,6 +7,7 @@ package graph
 import (
 	"context"
 
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/presentation/graph/generated"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
@@ -17,9 +18,35 @@ func (r *verifiedIdentifierResolver) Timestamp(ctx context.Context, obj *profile
 	return nil, nil
 }
 
+// EventType is the resolver for the eventType field.
+func (r *webhookDeliveryResolver) EventType(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return string(obj.EventType), nil
+}
+
+// EventTypes is the resolver for the eventTypes field.
+func (r *webhookEndpointResolver) EventTypes(ctx context.Context, obj *domain.WebhookEndpoint) ([]string, error) {
+	eventTypes := []string{}
+	for _, eventType := range obj.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return eventTypes, nil
+}
+
 // VerifiedIdentifier returns generated.VerifiedIdentifierResolver implementation.
 func (r *Resolver) VerifiedIdentifier() generated.VerifiedIdentifierResolver {
 	return &verifiedIdentifierResolver{r}
 }
 
+// WebhookDelivery returns generated.WebhookDeliveryResolver implementation.
+func (r *Resolver) WebhookDelivery() generated.WebhookDeliveryResolver {
+	return &webhookDeliveryResolver{r}
+}
+
+// WebhookEndpoint returns generated.WebhookEndpointResolver implementation.
+func (r *Resolver) WebhookEndpoint() generated.WebhookEndpointResolver {
+	return &webhookEndpointResolver{r}
+}
+
 type verifiedIdentifierResolver struct{ *Resolver }
+type webhookDeliveryResolver struct{ *Resolver }
+type webhookEndpointResolver struct{ *Resolver }
@@ -40,8 +40,20 @@ func NewUsecasesInteractor(
 	profile := usecases.NewProfileUseCase(infrastructure, baseExtension)
 	login := usecases.NewLoginUseCases(infrastructure, profile, baseExtension, pinsExtension)
 	roles := usecases.NewRoleUseCases(infrastructure, baseExtension)
-	pins := usecases.NewUserPinUseCase(infrastructure, profile, baseExtension, pinsExtension)
-	signup := usecases.NewSignUpUseCases(infrastructure, profile, pins, baseExtension)
+	pins := usecases.NewUserPinUseCase(
+		infrastructure,
+		profile,
+		baseExtension,
+		pinsExtension,
+		extension.NewDefaultPINPolicy(),
+	)
+	passwords := usecases.NewPasswordUseCases(
+		infrastructure,
+		baseExtension,
+		pinsExtension,
+		extension.NewDefaultPasswordPolicy(),
+	)
+	signup := usecases.NewSignUpUseCases(infrastructure, profile, pins, passwords, baseExtension)
 	surveys := usecases.NewSurveyUseCases(infrastructure, baseExtension)
 	services := admin.NewService(baseExtension)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
 	"github.com/savannahghi/onboarding/pkg/onboarding/usecases"
@@ -33,6 +34,14 @@ type HandlersInterfaces interface {
 	SendOTP() http.HandlerFunc
 	SendRetryOTP() http.HandlerFunc
 	RefreshToken() http.HandlerFunc
+	ReportLogin() http.HandlerFunc
+	EnrolTOTP() http.HandlerFunc
+	SignUpByEmail() http.HandlerFunc
+	LoginByEmail() http.HandlerFunc
+	ResetPassword() http.HandlerFunc
+	SignUpBySocial() http.HandlerFunc
+	LoginBySocial() http.HandlerFunc
+	UpgradeAnonymousUser() http.HandlerFunc
 	RemoveUserByPhoneNumber() http.HandlerFunc
 	GetUserProfileByUID() http.HandlerFunc
 	GetUserProfileByPhoneOrEmail() http.HandlerFunc
@@ -45,11 +54,17 @@ type HandlersInterfaces interface {
 	UpdateUserProfile() http.HandlerFunc
 	SwitchFlaggedFeaturesHandler() http.HandlerFunc
 	PollServices() http.HandlerFunc
+	JWKS() http.HandlerFunc
+	ReceivePubSubPushMessages() http.HandlerFunc
 	CheckHasPermission() http.HandlerFunc
 
+	IntrospectToken() http.HandlerFunc
+
 	CreateRole() http.HandlerFunc
 	AssignRole() http.HandlerFunc
 	RemoveRoleByName() http.HandlerFunc
+	ListRoles() http.HandlerFunc
+	ListUserProfiles() http.HandlerFunc
 
 	RegisterUser() http.HandlerFunc
 }
@@ -124,6 +139,31 @@ func (h *HandlersInterfacesImpl) CreateUserWithPhoneNumber() http.HandlerFunc {
 	}
 }
 
+// UpgradeAnonymousUser is an authenticated endpoint that is called by an anonymous user to create
+// an account with a verified phone number and PIN. The account keeps the UID of the anonymous user
+func (h *HandlersInterfacesImpl) UpgradeAnonymousUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.SignUpInput{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		response, err := h.usecases.UpgradeAnonymousUser(ctx, p)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		span.AddEvent("upgrade anonymous user")
+
+		serverutils.WriteJSONResponse(w, response, http.StatusCreated)
+	}
+}
+
 // UserRecoveryPhoneNumbers fetches the phone numbers associated with a profile for the purpose of account recovery.
 // The returned phone numbers slice should be masked. E.G +254700***123
 func (h *HandlersInterfacesImpl) UserRecoveryPhoneNumbers() http.HandlerFunc {
@@ -234,6 +274,10 @@ func (h *HandlersInterfacesImpl) LoginByPhone() http.HandlerFunc {
 			return
 		}
 
+		if p.TOTPCode != nil {
+			ctx = utils.WithSecondFactorCode(ctx, *p.TOTPCode)
+		}
+
 		response, err := h.usecases.LoginByPhone(
 			ctx,
 			*p.PhoneNumber,
@@ -242,7 +286,11 @@ func (h *HandlersInterfacesImpl) LoginByPhone() http.HandlerFunc {
 		)
 		if err != nil {
 			logrus.Println(err)
-			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			status := http.StatusBadRequest
+			if exceptions.IsPINLockedError(err) {
+				status = http.StatusTooManyRequests
+			}
+			serverutils.WriteJSONResponse(w, err, status)
 			return
 		}
 		span.AddEvent("login by phone response")
@@ -472,7 +520,11 @@ func (h *HandlersInterfacesImpl) RefreshToken() http.HandlerFunc {
 
 		response, err := h.usecases.RefreshToken(ctx, *p.RefreshToken)
 		if err != nil {
-			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			status := http.StatusBadRequest
+			if exceptions.IsSessionRevokedError(err) || exceptions.IsSessionNotFoundError(err) {
+				status = http.StatusUnauthorized
+			}
+			serverutils.WriteJSONResponse(w, err, status)
 			return
 		}
 
@@ -482,6 +534,295 @@ func (h *HandlersInterfacesImpl) RefreshToken() http.HandlerFunc {
 	}
 }
 
+// ReportLogin is an unauthenticated endpoint that handles the "this wasn't me" link of a login
+// alert. Opening the link only shows a page that asks the user to confirm the report, because
+// mail scanners and link previews fetch the links in messages. The report is made by posting the
+// token in the body, either from the form of that page or as JSON by the apps. The user is then
+// signed out of all their devices and has to reset their PIN before they can log in again
+func (h *HandlersInterfacesImpl) ReportLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		token := ""
+		fromForm := false
+		switch {
+		case r.Method == http.MethodGet:
+			token = r.URL.Query().Get("token")
+		case isFormRequest(r):
+			token = r.PostFormValue("token")
+			fromForm = true
+		default:
+			p := &dto.ReportLoginPayload{}
+			serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+			span.AddEvent("decode json payload to struct")
+
+			if p.Token != nil {
+				token = *p.Token
+			}
+		}
+
+		if token == "" {
+			err := fmt.Errorf("expected `token` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			writeReportLoginPage(w, reportLoginConfirmationPage, reportLoginPageData{
+				Action: r.URL.Path,
+				Token:  token,
+			}, http.StatusOK)
+			return
+		}
+
+		response, err := h.usecases.ReportLogin(ctx, token)
+		if err != nil {
+			if fromForm {
+				writeReportLoginPage(w, reportLoginResultPage, reportLoginPageData{}, http.StatusBadRequest)
+				return
+			}
+			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		span.AddEvent("login reported")
+
+		if fromForm {
+			writeReportLoginPage(w, reportLoginResultPage, reportLoginPageData{Reported: true}, http.StatusOK)
+			return
+		}
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
+
+// EnrolTOTP is an unauthenticated endpoint that starts setting up an authenticator app for a
+// user whose roles require one before they can log in. The user provides their phone number and
+// PIN, and confirms the app by logging in with a code from it
+func (h *HandlersInterfacesImpl) EnrolTOTP() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.EnrolTOTPPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		if p.PhoneNumber == nil || p.PIN == nil {
+			err := fmt.Errorf("expected `phoneNumber`, `pin` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		response, err := h.usecases.EnrolTOTPWithPIN(ctx, *p.PhoneNumber, *p.PIN)
+		if err != nil {
+			status := http.StatusBadRequest
+			if exceptions.IsPINLockedError(err) {
+				status = http.StatusTooManyRequests
+			}
+			serverutils.WriteJSONResponse(w, err, status)
+			return
+		}
+
+		span.AddEvent("authenticator app enrolment started")
+
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
+
+// SignUpByEmail is an unauthenticated endpoint that creates an account for a user who logs in with
+// their email address and a password. The email address is verified with an OTP that was sent to it
+func (h *HandlersInterfacesImpl) SignUpByEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.EmailSignUpInput{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		response, err := h.usecases.SignUpByEmail(ctx, p)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		span.AddEvent("create user by email")
+
+		serverutils.WriteJSONResponse(w, response, http.StatusCreated)
+	}
+}
+
+// LoginByEmail is an unauthenticated endpoint that collects an email address and password from
+// the user and returns auth credentials to allow the user to login when they match an email login
+func (h *HandlersInterfacesImpl) LoginByEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.EmailLoginPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		if p.EmailAddress == nil || p.Password == nil {
+			err := fmt.Errorf("expected `emailAddress`, `password` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if !p.Flavour.IsValid() {
+			err := fmt.Errorf("an invalid `flavour` defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if p.TOTPCode != nil {
+			ctx = utils.WithSecondFactorCode(ctx, *p.TOTPCode)
+		}
+
+		response, err := h.usecases.LoginByEmail(ctx, *p.EmailAddress, *p.Password, p.Flavour)
+		if err != nil {
+			status := http.StatusBadRequest
+			if exceptions.IsPasswordLockedError(err) {
+				status = http.StatusTooManyRequests
+			}
+			serverutils.WriteJSONResponse(w, err, status)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
+
+// ResetPassword is an unauthenticated endpoint that replaces the password of an email login after
+// verifying an OTP that was sent to the email address
+func (h *HandlersInterfacesImpl) ResetPassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.ResetPasswordPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		if p.EmailAddress == nil || p.OTP == nil || p.Password == nil {
+			err := fmt.Errorf("expected `emailAddress`, `otp`, `password` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		response, err := h.usecases.ResetPassword(ctx, *p.EmailAddress, *p.OTP, *p.Password)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		span.AddEvent("reset password")
+
+		serverutils.WriteJSONResponse(w, dto.NewOKResp(response), http.StatusOK)
+	}
+}
+
+// SignUpBySocial is an unauthenticated endpoint that creates an account for a user with an ID token
+// that a social login provider issued to them
+func (h *HandlersInterfacesImpl) SignUpBySocial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.SocialSignUpInput{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		response, err := h.usecases.SignUpBySocial(ctx, p)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, err, http.StatusBadRequest)
+			return
+		}
+
+		span.AddEvent("create user by social login")
+
+		serverutils.WriteJSONResponse(w, response, http.StatusCreated)
+	}
+}
+
+// LoginBySocial is an unauthenticated endpoint that collects an ID token that a social login
+// provider issued to the user and returns auth credentials to allow the user to login when the
+// account that it was issued for is linked to their profile
+func (h *HandlersInterfacesImpl) LoginBySocial() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		span := trace.SpanFromContext(ctx)
+
+		p := &dto.SocialLoginPayload{}
+		serverutils.DecodeJSONToTargetStruct(w, r, p)
+
+		span.AddEvent("decode json payload to struct")
+
+		if p.Provider == "" || p.IDToken == nil {
+			err := fmt.Errorf("expected `provider`, `idToken` to be defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if !p.Flavour.IsValid() {
+			err := fmt.Errorf("an invalid `flavour` defined")
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		if p.TOTPCode != nil {
+			ctx = utils.WithSecondFactorCode(ctx, *p.TOTPCode)
+		}
+
+		response, err := h.usecases.LoginBySocial(ctx, p.Provider, *p.IDToken, p.Flavour)
+		if err != nil {
+			status := http.StatusBadRequest
+			if exceptions.IsPINLockedError(err) {
+				status = http.StatusTooManyRequests
+			}
+			serverutils.WriteJSONResponse(w, err, status)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, response, http.StatusOK)
+	}
+}
+
 // RemoveUserByPhoneNumber is an unauthenticated endpoint that removes a user
 // whose phone number, either PRIMARY PHONE NUMBER or SECONDARY PHONE NUMBERS,matches the provided
 // phone number in the request. This endpoint will ONLY be available under testing environment
@@ -936,6 +1277,34 @@ func (h *HandlersInterfacesImpl) PollServices() http.HandlerFunc {
 	}
 }
 
+// JWKS is an unauthenticated endpoint that publishes the public keys of the keys that the service
+// signs its access tokens with, as a JSON Web Key Set. The set is empty when Firebase issues the
+// access tokens
+func (h *HandlersInterfacesImpl) JWKS() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		keys, err := h.infrastructure.Tokens.JWKS(ctx)
+		if err != nil {
+			serverutils.WriteJSONResponse(rw, err, http.StatusInternalServerError)
+			return
+		}
+
+		// other services cache the keys, the rotated keys are published long before they sign tokens
+		rw.Header().Set("Cache-Control", "public, max-age=300")
+		serverutils.WriteJSONResponse(rw, keys, http.StatusOK)
+	}
+}
+
+// ReceivePubSubPushMessages is an unauthenticated endpoint that receives the messages pushed to the
+// subscriptions of the service. The pushed messages carry their own proof of origin, which is
+// verified before they are handled
+func (h *HandlersInterfacesImpl) ReceivePubSubPushMessages() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		h.infrastructure.Pubsub.ReceivePubSubPushMessages(rw, r)
+	}
+}
+
 // CheckHasPermission checks if the user has a permission
 func (h *HandlersInterfacesImpl) CheckHasPermission() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
@@ -964,6 +1333,40 @@ func (h *HandlersInterfacesImpl) CheckHasPermission() http.HandlerFunc {
 	}
 }
 
+// IntrospectToken tells other services whether a bearer token is active, who its user is and
+// what they are allowed to do, so that a request is authorized with one call
+func (h *HandlersInterfacesImpl) IntrospectToken() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		p := &dto.TokenIntrospectionPayload{}
+		serverutils.DecodeJSONToTargetStruct(rw, r, p)
+
+		if p.Token == nil || *p.Token == "" {
+			serverutils.WriteJSONResponse(rw, nil, http.StatusBadRequest)
+			return
+		}
+		var flavour feedlib.Flavour
+		if p.Flavour != nil {
+			if !p.Flavour.IsValid() {
+				serverutils.WriteJSONResponse(rw, nil, http.StatusBadRequest)
+				return
+			}
+			flavour = *p.Flavour
+		}
+
+		introspection, err := h.usecases.IntrospectToken(ctx, *p.Token, flavour)
+		if err != nil {
+			serverutils.WriteJSONResponse(rw, err, http.StatusInternalServerError)
+			return
+		}
+
+		// the introspection is about a user, it must not be kept by shared caches
+		rw.Header().Set("Cache-Control", "no-store")
+		serverutils.WriteJSONResponse(rw, introspection, http.StatusOK)
+	}
+}
+
 // CreateRole creates a new role given the required role creation input
 func (h *HandlersInterfacesImpl) CreateRole() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
@@ -1039,6 +1442,55 @@ func (h *HandlersInterfacesImpl) RemoveRoleByName() http.HandlerFunc {
 	}
 }
 
+// ListRoles returns a page of roles
+func (h *HandlersInterfacesImpl) ListRoles() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		input := &dto.ListingInput{}
+		serverutils.DecodeJSONToTargetStruct(rw, r, input)
+
+		roles, err := h.usecases.ListRoles(ctx, input.Pagination, input.Filter, input.Sort)
+		if err != nil {
+			serverutils.WriteJSONResponse(rw, err, listingErrorStatus(err))
+			return
+		}
+
+		serverutils.WriteJSONResponse(rw, roles, http.StatusOK)
+	}
+}
+
+// ListUserProfiles returns a page of user profiles
+func (h *HandlersInterfacesImpl) ListUserProfiles() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		input := &dto.ListingInput{}
+		serverutils.DecodeJSONToTargetStruct(rw, r, input)
+
+		profiles, err := h.usecases.ListUserProfiles(ctx, input.Pagination, input.Filter, input.Sort)
+		if err != nil {
+			serverutils.WriteJSONResponse(rw, err, listingErrorStatus(err))
+			return
+		}
+
+		serverutils.WriteJSONResponse(rw, profiles, http.StatusOK)
+	}
+}
+
+// listingErrorStatus returns the status of a listing that failed. Listing parameters that are not
+// valid are the fault of the caller, and so is a user who is not allowed to see the listing
+func listingErrorStatus(err error) int {
+	switch {
+	case exceptions.IsInvalidInputError(err):
+		return http.StatusBadRequest
+	case exceptions.IsPermissionDeniedError(err):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // RegisterUser creates a new user profile using provided input
 func (h *HandlersInterfacesImpl) RegisterUser() http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
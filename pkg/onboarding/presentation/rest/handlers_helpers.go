@@ -3,15 +3,66 @@ package rest
 import (
 	"context"
 	"fmt"
+	"html/template"
+	"mime"
 	"net/http"
 
 	"firebase.google.com/go/auth"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/serverutils"
+	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// reportLoginConfirmationPage is shown when the "this wasn't me" link of a login alert is opened.
+// The login is only reported once the user posts its form
+var reportLoginConfirmationPage = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Report a login</title></head>
+<body>
+<p>If you did not log in to your account, report the login. You will be signed out of all your
+devices and will have to reset your PIN before you can log in again.</p>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">This wasn't me</button>
+</form>
+</body>
+</html>
+`))
+
+// reportLoginResultPage is shown after the form of the confirmation page is posted
+var reportLoginResultPage = template.Must(template.New("result").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Report a login</title></head>
+<body>
+{{if .Reported}}<p>The login has been reported. You have been signed out of all your devices.
+Reset your PIN to log in again.</p>
+{{else}}<p>The link is not valid or has expired.</p>
+{{end}}</body>
+</html>
+`))
+
+type reportLoginPageData struct {
+	Action   string
+	Token    string
+	Reported bool
+}
+
+func writeReportLoginPage(w http.ResponseWriter, page *template.Template, data reportLoginPageData, status int) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := page.Execute(w, data); err != nil {
+		logrus.Errorf("unable to write the report login page: %v", err)
+	}
+}
+
+// isFormRequest is true for a request whose body is an HTML form
+func isFormRequest(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/x-www-form-urlencoded"
+}
+
 func decodePhoneNumberPayload(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -9,14 +9,20 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"firebase.google.com/go/auth"
 	"github.com/google/uuid"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
@@ -29,6 +35,8 @@ import (
 	mockRepo "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/mock"
 	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
 	pubsubmessagingMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	tokensMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens/mock"
 	"github.com/savannahghi/onboarding/pkg/onboarding/presentation/rest"
 	"github.com/savannahghi/onboarding/pkg/onboarding/repository"
 	"github.com/savannahghi/onboarding/pkg/onboarding/usecases"
@@ -40,6 +48,7 @@ var fakeBaseExt extMock.FakeBaseExtensionImpl
 var fakePinExt extMock.PINExtensionImpl
 var serverUrl = "http://localhost:5000"
 var fakePubSub pubsubmessagingMock.FakeServicePubSub
+var fakeTokens tokensMock.FakeServiceTokens
 
 var ext extension.BaseExtension = &fakeBaseExt
 var pinExt extension.PINExtension = &fakePinExt
@@ -48,11 +57,47 @@ func InitializeFakeInfrastructure() infrastructure.Infrastructure {
 	var r repository.OnboardingRepository = &fakeRepo
 	var engagementSvc engagement.ServiceEngagement = &fakeEngagementSvs
 	var ps pubsubmessaging.ServicePubSub = &fakePubSub
+	var tk tokens.ServiceTokens = &fakeTokens
+
+	// every attempt to log in is recorded in the login audit trail
+	fakeRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		return nil
+	}
+	// the devices that a user logged in from before are checked for unusual logins
+	fakeRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{}, nil
+	}
+	// PRO users are checked for a second factor
+	fakeRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+		return nil, nil
+	}
+	fakeRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		return &domain.TwoFactorPolicy{}, nil
+	}
+	// attempts to use a PIN are reserved on the PIN that a test returns before it is compared
+	fakeRepo.ReservePINAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error) {
+		pin, err := fakeRepo.GetPINByProfileIDFn(ctx, profileID)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.ReservePINAttempt(pin, attemptedAt); err != nil {
+			return nil, err
+		}
+		return pin, nil
+	}
+	fakeRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+		return nil
+	}
+	// Firebase issues the access tokens unless a test says otherwise
+	fakeTokens.EnabledFn = func() bool {
+		return false
+	}
 
 	return infrastructure.Infrastructure{
 		Database:   r,
 		Engagement: engagementSvc,
 		Pubsub:     ps,
+		Tokens:     tk,
 	}
 }
 
@@ -63,15 +108,51 @@ func InitializeFakeOnboardingInteractor() (usecases.Interactor, error) {
 	var ext extension.BaseExtension = &fakeBaseExt
 	var pinExt extension.PINExtension = &fakePinExt
 	var ps pubsubmessaging.ServicePubSub = &fakePubSub
+	var tk tokens.ServiceTokens = &fakeTokens
 
 	infra := func() infrastructure.Infrastructure {
 		return infrastructure.Infrastructure{
 			Database:   r,
 			Engagement: engagementSvc,
 			Pubsub:     ps,
+			Tokens:     tk,
 		}
 	}()
 
+	// every attempt to log in is recorded in the login audit trail
+	fakeRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		return nil
+	}
+	// the devices that a user logged in from before are checked for unusual logins
+	fakeRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{}, nil
+	}
+	// PRO users are checked for a second factor
+	fakeRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+		return nil, nil
+	}
+	fakeRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		return &domain.TwoFactorPolicy{}, nil
+	}
+	// attempts to use a PIN are reserved on the PIN that a test returns before it is compared
+	fakeRepo.ReservePINAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error) {
+		pin, err := fakeRepo.GetPINByProfileIDFn(ctx, profileID)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.ReservePINAttempt(pin, attemptedAt); err != nil {
+			return nil, err
+		}
+		return pin, nil
+	}
+	fakeRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+		return nil
+	}
+	// Firebase issues the access tokens unless a test says otherwise
+	fakeTokens.EnabledFn = func() bool {
+		return false
+	}
+
 	i := usecases.NewUsecasesInteractor(infra, ext, pinExt)
 
 	return i, nil
@@ -285,6 +366,15 @@ func composeRefreshTokenPayload(t *testing.T, token *string) *bytes.Buffer {
 	return bytes.NewBuffer(bs)
 }
 
+func composeReportLoginPayload(t *testing.T, token *string) *bytes.Buffer {
+	reportLogin := &dto.ReportLoginPayload{Token: token}
+	bs, err := json.Marshal(reportLogin)
+	if err != nil {
+		t.Errorf("unable to marshal token string to JSON: %s", err)
+	}
+	return bytes.NewBuffer(bs)
+}
+
 func composeUIDPayload(t *testing.T, uid *string) *bytes.Buffer {
 	uidPayload := &dto.UIDPayload{UID: uid}
 	bs, err := json.Marshal(uidPayload)
@@ -558,6 +648,10 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 	phoneNumber6 := "+254721410589"
 	payload6 := composeSignupPayload(t, phoneNumber6, pin6, otp6, flavour6)
 
+	// payloads of the signups whose account is rolled back
+	payload7 := composeSignupPayload(t, "+254721410590", "2030", "9521", feedlib.FlavourConsumer)
+	payload8 := composeSignupPayload(t, "+254721410591", "2030", "9522", feedlib.FlavourConsumer)
+
 	type args struct {
 		url        string
 		httpMethod string
@@ -610,6 +704,26 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantErr:    true,
 		},
+		{
+			name: "invalid_generate_auth_credentials_roll_back_account",
+			args: args{
+				url:        fmt.Sprintf("%s/create_user_by_phone", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload7,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "invalid_roll_back_account_returns_error",
+			args: args{
+				url:        fmt.Sprintf("%s/create_user_by_phone", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload8,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -622,6 +736,7 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 
 			// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 			response := httptest.NewRecorder()
+			rolledBack := false
 			// we mock the required methods for a valid case
 			if tt.name == "valid:_successfully_create_user_by_phone" {
 				fakeRepo.CheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string) (bool, error) {
@@ -634,18 +749,9 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 					phone := "+254721123123"
 					return &phone, nil
 				}
-				fakeRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-					return &dto.CreatedUserResponse{
-						UID:         "1106f10f-bea6-4fa3-bdba-16b1e39bd318",
-						DisplayName: "kalulu juha",
-						Email:       "juha@gmail.com",
-						PhoneNumber: "0756232452",
-						PhotoURL:    "",
-						ProviderID:  "google.com",
-					}, nil
-				}
-				fakeRepo.CreateUserProfileFn = func(ctx context.Context, phoneNumber, uid string) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
+				fakeRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.UID = "1106f10f-bea6-4fa3-bdba-16b1e39bd318"
+					account.Profile = &profileutils.UserProfile{
 						ID: "123",
 						VerifiedIdentifiers: []profileutils.VerifiedIdentifier{
 							{
@@ -654,7 +760,12 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 							},
 						},
 						PrimaryPhone: &phoneNumber,
-					}, nil
+					}
+					return account, nil
+				}
+				fakeRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+					rolledBack = true
+					return nil
 				}
 				fakeRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
 					return &profileutils.AuthCredentialResponse{
@@ -755,11 +866,44 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 				fakeRepo.CheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string) (bool, error) {
 					return false, nil
 				}
-				fakeRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
+				fakeRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
 					return nil, fmt.Errorf("unable to create user")
 				}
 			}
 
+			// mock `GenerateAuthCredentials` to fail after the account is created
+			if tt.name == "invalid_generate_auth_credentials_roll_back_account" ||
+				tt.name == "invalid_roll_back_account_returns_error" {
+				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
+					return true, nil
+				}
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254721123123"
+					return &phone, nil
+				}
+				fakeRepo.CheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string) (bool, error) {
+					return false, nil
+				}
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "pass"
+				}
+				fakeRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.UID = "1106f10f-bea6-4fa3-bdba-16b1e39bd318"
+					account.Profile = &profileutils.UserProfile{ID: "123", PrimaryPhone: &phoneNumber}
+					return account, nil
+				}
+				fakeRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+					return nil, fmt.Errorf("unable to generate auth credentials")
+				}
+				fakeRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+					rolledBack = true
+					if tt.name == "invalid_roll_back_account_returns_error" {
+						return fmt.Errorf("unable to delete user account")
+					}
+					return nil
+				}
+			}
+
 			// Our handlers satisfy http.Handler, so we can call its ServeHTTP method
 			// directly and pass in our Request and ResponseRecorder.
 			svr := h.CreateUserWithPhoneNumber()
@@ -769,6 +913,10 @@ func TestHandlersInterfacesImpl_CreateUserWithPhoneNumber(t *testing.T) {
 				t.Errorf("expected status %d, got %d", tt.wantStatus, response.Code)
 				return
 			}
+			if wantRolledBack := strings.Contains(tt.name, "roll_back"); rolledBack != wantRolledBack {
+				t.Errorf("expected the account to be rolled back %v, got %v", wantRolledBack, rolledBack)
+				return
+			}
 
 			dataResponse, err := ioutil.ReadAll(response.Body)
 			if err != nil {
@@ -1192,6 +1340,9 @@ func TestHandlersInterfacesImpl_ResetPin(t *testing.T) {
 				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakeRepo.UpdatePINFn = func(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
 					return true, nil
 				}
@@ -1211,6 +1362,9 @@ func TestHandlersInterfacesImpl_ResetPin(t *testing.T) {
 				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakeRepo.UpdatePINFn = func(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
 					return false, fmt.Errorf("unable to update pin")
 				}
@@ -1253,6 +1407,9 @@ func TestHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 	token2 := "*"
 	payload2 := composeRefreshTokenPayload(t, &token2)
 
+	token3 := "2f0e6a8d-3c5b-4a1e-9d7f-6b8c0e2a4d19"
+	payload3 := composeRefreshTokenPayload(t, &token3)
+
 	type args struct {
 		url        string
 		httpMethod string
@@ -1295,6 +1452,16 @@ func TestHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantErr:    true,
 		},
+		{
+			name: "invalid:_refresh_token_of_revoked_session",
+			args: args{
+				url:        fmt.Sprintf("%s/refresh_token", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload3,
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1307,6 +1474,15 @@ func TestHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 
 			// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 			response := httptest.NewRecorder()
+			fakeRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+				return nil, nil
+			}
+			fakeRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: "123"}, nil
+			}
+			fakeRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+				return nil
+			}
 			if tt.name == "valid:_successfully_refresh_token" {
 				fakeRepo.ExchangeRefreshTokenForIDTokenFn = func(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error) {
 					return &profileutils.AuthCredentialResponse{
@@ -1322,6 +1498,13 @@ func TestHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid:_refresh_token_of_revoked_session" {
+				fakeRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+					revoked := time.Now()
+					return &domain.Session{ID: "session", Revoked: &revoked}, nil
+				}
+			}
+
 			svr := h.RefreshToken()
 			svr.ServeHTTP(response, req)
 
@@ -1343,6 +1526,177 @@ func TestHandlersInterfacesImpl_RefreshToken(t *testing.T) {
 	}
 }
 
+func TestHandlersInterfacesImpl_ReportLogin(t *testing.T) {
+
+	infra := InitializeFakeInfrastructure()
+
+	usecases := usecases.NewUsecasesInteractor(infra, ext, pinExt)
+
+	h := rest.NewHandlersInterfaces(infra, usecases)
+
+	token := "2Yk1b0qk3Qm7t0v4cXr8n6WzE5pLhA9sJdF2uG3iO1M"
+	payload := composeReportLoginPayload(t, &token)
+
+	payload1 := composeReportLoginPayload(t, nil)
+
+	unknown := "unknown"
+	payload2 := composeReportLoginPayload(t, &unknown)
+
+	type args struct {
+		url         string
+		httpMethod  string
+		body        io.Reader
+		contentType string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantStatus   int
+		wantErr      bool
+		wantReported bool
+	}{
+		{
+			name: "valid:_link_only_asks_for_confirmation",
+			args: args{
+				url:        fmt.Sprintf("%s/report_login?token=%s", serverUrl, token),
+				httpMethod: http.MethodGet,
+				body:       nil,
+			},
+			wantStatus:   http.StatusOK,
+			wantErr:      false,
+			wantReported: false,
+		},
+		{
+			name: "valid:_report_login_from_confirmation_form",
+			args: args{
+				url:         fmt.Sprintf("%s/report_login", serverUrl),
+				httpMethod:  http.MethodPost,
+				body:        strings.NewReader(url.Values{"token": {token}}.Encode()),
+				contentType: "application/x-www-form-urlencoded",
+			},
+			wantStatus:   http.StatusOK,
+			wantErr:      false,
+			wantReported: true,
+		},
+		{
+			name: "valid:_report_login_with_payload",
+			args: args{
+				url:        fmt.Sprintf("%s/report_login", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload,
+			},
+			wantStatus:   http.StatusOK,
+			wantErr:      false,
+			wantReported: true,
+		},
+		{
+			name: "invalid:_missing_token",
+			args: args{
+				url:        fmt.Sprintf("%s/report_login", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload1,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "invalid:_token_in_the_query_of_a_post",
+			args: args{
+				url:        fmt.Sprintf("%s/report_login?token=%s", serverUrl, token),
+				httpMethod: http.MethodPost,
+				body:       payload1,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "invalid:_unknown_token",
+			args: args{
+				url:        fmt.Sprintf("%s/report_login", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       payload2,
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a request to pass to our handler.
+			req, err := http.NewRequest(tt.args.httpMethod, tt.args.url, tt.args.body)
+			if err != nil {
+				t.Errorf("can't create new request: %v", err)
+				return
+			}
+			if tt.args.contentType != "" {
+				req.Header.Set("Content-Type", tt.args.contentType)
+			}
+
+			// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+			response := httptest.NewRecorder()
+			fakeRepo.GetLoginAlertByTokenHashFn = func(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+				if tokenHash != utils.HashLoginAlertToken(token) {
+					return nil, nil
+				}
+				return &domain.LoginAlert{
+					ID:        "alert",
+					ProfileID: "123",
+					TokenHash: tokenHash,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil
+			}
+			fakeRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: id, VerifiedUIDS: []string{"5550"}}, nil
+			}
+			fakeRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+				return []*domain.Session{{ID: "session", ProfileID: profileID, UID: "5550"}}, nil
+			}
+			fakeRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+				return nil
+			}
+			fakeRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+				return nil
+			}
+			reported := false
+			fakeRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+				return &domain.PIN{ID: "pin-1", ProfileID: profileID}, nil
+			}
+			fakeRepo.RequirePINResetFn = func(ctx context.Context, profileID string) error {
+				reported = true
+				return nil
+			}
+			fakeRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+				return nil, nil
+			}
+			fakeRepo.SaveLoginAlertFn = func(ctx context.Context, alert *domain.LoginAlert) error {
+				return nil
+			}
+
+			svr := h.ReportLogin()
+			svr.ServeHTTP(response, req)
+
+			if tt.wantStatus != response.Code {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, response.Code)
+				return
+			}
+			if tt.wantReported != reported {
+				t.Errorf("expected the login to be reported: %v, got %v", tt.wantReported, reported)
+				return
+			}
+
+			dataResponse, err := ioutil.ReadAll(response.Body)
+			if err != nil {
+				t.Errorf("can't read response body: %v", err)
+				return
+			}
+			if dataResponse == nil {
+				t.Errorf("nil response body data")
+				return
+			}
+		})
+	}
+}
+
 func TestHandlersInterfacesImpl_GetUserProfileByUID(t *testing.T) {
 
 	infra := InitializeFakeInfrastructure()
@@ -1805,6 +2159,15 @@ func TestHandlersInterfacesImpl_LoginByPhone(t *testing.T) {
 				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
 					return true
 				}
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "rehashed"
+				}
+				fakeRepo.UpdatePINHashFn = func(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+					return nil
+				}
+				fakeRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+					return nil
+				}
 				fakeRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
 					return &profileutils.AuthCredentialResponse{
 						UID: "5550",
@@ -2413,11 +2776,7 @@ func TestHandlersInterfacesImpl_SetPrimaryPhoneNumber(t *testing.T) {
 					}, nil
 				}
 
-				fakeRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
-					return nil
-				}
-
-				fakeRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
+				fakeRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return nil
 				}
 			}
@@ -2446,7 +2805,7 @@ func TestHandlersInterfacesImpl_SetPrimaryPhoneNumber(t *testing.T) {
 					}, nil
 				}
 
-				fakeRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
+				fakeRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return fmt.Errorf("failed to set a primary phone number")
 				}
 			}
@@ -3343,6 +3702,235 @@ func TestHandlers_CheckPermission(t *testing.T) {
 	}
 }
 
+func TestHandlers_IntrospectToken(t *testing.T) {
+	infra := InitializeFakeInfrastructure()
+
+	usecases := usecases.NewUsecasesInteractor(infra, ext, pinExt)
+
+	h := rest.NewHandlersInterfaces(infra, usecases)
+
+	token := uuid.NewString()
+	failingToken := uuid.NewString()
+	invalidFlavour := feedlib.Flavour("invalid")
+
+	emptyTokenPayload, err := json.Marshal(dto.TokenIntrospectionPayload{})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	invalidFlavourPayload, err := json.Marshal(dto.TokenIntrospectionPayload{Token: &token, Flavour: &invalidFlavour})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	failingPayload, err := json.Marshal(dto.TokenIntrospectionPayload{Token: &failingToken})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	validPayload, err := json.Marshal(dto.TokenIntrospectionPayload{Token: &token})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	type args struct {
+		url        string
+		httpMethod string
+		body       io.Reader
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name: "fail: empty token in payload",
+			args: args{
+				url:        fmt.Sprintf("%s/introspect_token", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(emptyTokenPayload),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    false,
+		},
+		{
+			name: "fail: invalid flavour in payload",
+			args: args{
+				url:        fmt.Sprintf("%s/introspect_token", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(invalidFlavourPayload),
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    false,
+		},
+		{
+			name: "fail: usecase error introspecting token",
+			args: args{
+				url:        fmt.Sprintf("%s/introspect_token", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(failingPayload),
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    false,
+		},
+		{
+			name: "success: token is introspected",
+			args: args{
+				url:        fmt.Sprintf("%s/introspect_token", serverUrl),
+				httpMethod: http.MethodPost,
+				body:       bytes.NewBuffer(validPayload),
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTokens.VerifyBearerTokenFn = func(ctx context.Context, token string) (*auth.Token, error) {
+				return &auth.Token{UID: token, Expires: time.Now().Add(time.Hour).Unix()}, nil
+			}
+			fakeRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+				if uid == failingToken {
+					return nil, fmt.Errorf("unable to read user profile")
+				}
+				return &profileutils.UserProfile{ID: uuid.NewString()}, nil
+			}
+			fakeRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+				return &[]profileutils.Role{}, nil
+			}
+
+			// Create a request to pass to our handler.
+			req, err := http.NewRequest(tt.args.httpMethod, tt.args.url, tt.args.body)
+			if err != nil {
+				t.Errorf("can't create new request: %v", err)
+				return
+			}
+
+			// We create a ResponseRecorder to record the response.
+			response := httptest.NewRecorder()
+
+			// call its ServeHTTP method and pass in our Request and ResponseRecorder.
+			svr := h.IntrospectToken()
+			svr.ServeHTTP(response, req)
+
+			if tt.wantStatus != response.Code {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, response.Code)
+				return
+			}
+
+			if tt.wantStatus == http.StatusOK {
+				introspection := &dto.TokenIntrospection{}
+				if err := json.Unmarshal(response.Body.Bytes(), introspection); err != nil {
+					t.Errorf("unable to decode the introspection: %v", err)
+					return
+				}
+				if !introspection.Active || introspection.UID != token {
+					t.Errorf("expected an active token of %s, got %v", token, introspection)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlers_ListRolesAndUsers(t *testing.T) {
+	infra := InitializeFakeInfrastructure()
+
+	usecases := usecases.NewUsecasesInteractor(infra, ext, pinExt)
+
+	h := rest.NewHandlersInterfaces(infra, usecases)
+
+	invalidPayload, err := json.Marshal(dto.ListingInput{
+		Pagination: &firebasetools.PaginationInput{First: 2, Last: 2},
+	})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	validPayload, err := json.Marshal(dto.ListingInput{
+		Pagination: &firebasetools.PaginationInput{First: 2},
+	})
+	if err != nil {
+		t.Errorf("unable to marshal payload to JSON: %s", err)
+		return
+	}
+
+	tests := []struct {
+		name       string
+		payload    []byte
+		allowed    bool
+		listErr    error
+		wantStatus int
+	}{
+		{
+			name:       "fail: invalid listing parameters",
+			payload:    invalidPayload,
+			allowed:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fail: user is not allowed to list roles",
+			payload:    validPayload,
+			allowed:    false,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "fail: unable to read the page",
+			payload:    validPayload,
+			allowed:    true,
+			listErr:    fmt.Errorf("unable to read the page"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "success: page is listed",
+			payload:    validPayload,
+			allowed:    true,
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		for _, handler := range []http.HandlerFunc{h.ListRoles(), h.ListUserProfiles()} {
+			t.Run(tt.name, func(t *testing.T) {
+				fakeBaseExt.GetLoggedInUserFn = func(ctx context.Context) (*dto.UserInfo, error) {
+					return &dto.UserInfo{UID: uuid.NewString()}, nil
+				}
+				fakeRepo.CheckIfUserHasPermissionFn = func(
+					ctx context.Context,
+					UID string,
+					requiredPermission profileutils.Permission,
+				) (bool, error) {
+					return tt.allowed, nil
+				}
+				fakeRepo.ListRolesPageFn = func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error) {
+					return []*profileutils.Role{}, tt.listErr
+				}
+				fakeRepo.ListUserProfilesPageFn = func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.UserProfile, error) {
+					return []*profileutils.UserProfile{}, tt.listErr
+				}
+
+				req, err := http.NewRequest(http.MethodPost, serverUrl, bytes.NewBuffer(tt.payload))
+				if err != nil {
+					t.Errorf("can't create new request: %v", err)
+					return
+				}
+
+				response := httptest.NewRecorder()
+				handler.ServeHTTP(response, req)
+
+				if tt.wantStatus != response.Code {
+					t.Errorf("expected status %d, got %d", tt.wantStatus, response.Code)
+				}
+			})
+		}
+	}
+}
+
 func TestHandlers_CreateRole(t *testing.T) {
 	infra := InitializeFakeInfrastructure()
 
@@ -5,16 +5,17 @@ import (
 
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/mux"
-	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/presentation/rest"
+	"github.com/savannahghi/pubsubtools"
 )
 
-// SharedRoutes return REST routes shared by open/closed onboarding services
-func SharedRoutes(handlers rest.HandlersInterfaces, r *mux.Router) {
+// SharedRoutes return REST routes shared by open/closed onboarding services. The authenticated
+// routes are protected by the provided authentication middleware
+func SharedRoutes(handlers rest.HandlersInterfaces, r *mux.Router, authenticate mux.MiddlewareFunc) {
 	SharedUnauthenticatedRoutes(handlers, r)
 	SharedAuthenticatedISCRoutes(handlers, r)
-	SharedAuthenticatedRoutes(handlers, r)
+	SharedAuthenticatedRoutes(handlers, r, authenticate)
 }
 
 // SharedUnauthenticatedRoutes return REST routes shared by open/closed onboarding services
@@ -31,6 +32,12 @@ func SharedUnauthenticatedRoutes(handlers rest.HandlersInterfaces, r *mux.Router
 	r.Path("/ide").HandlerFunc(playground.Handler("GraphQL IDE", "/graphql"))
 	r.Path("/health").HandlerFunc(HealthStatusCheck)
 
+	// the public keys that the access tokens signed by the service are verified with
+	r.Path("/.well-known/jwks.json").Methods(http.MethodGet).HandlerFunc(handlers.JWKS())
+
+	// the messages pushed to the subscriptions of the service
+	r.Path(pubsubtools.PubSubHandlerPath).Methods(http.MethodPost).HandlerFunc(handlers.ReceivePubSubPushMessages())
+
 	// Admin service polling
 	r.Path("/poll_services").Methods(http.MethodGet).HandlerFunc(handlers.PollServices())
 
@@ -65,6 +72,39 @@ func SharedUnauthenticatedRoutes(handlers rest.HandlersInterfaces, r *mux.Router
 		http.MethodPost,
 		http.MethodOptions).
 		HandlerFunc(handlers.RefreshToken())
+	r.Path("/report_login").Methods(
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.ReportLogin())
+	r.Path("/enrol_totp").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.EnrolTOTP())
+
+	// email login routes
+	r.Path("/signup_by_email").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.SignUpByEmail())
+	r.Path("/login_by_email").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.LoginByEmail())
+	r.Path("/reset_password").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.ResetPassword())
+
+	// social login routes
+	r.Path("/signup_by_social").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.SignUpBySocial())
+	r.Path("/login_by_social").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.LoginBySocial())
 
 	// PIN Routes
 	r.Path("/reset_pin").Methods(
@@ -106,14 +146,16 @@ func SharedUnauthenticatedRoutes(handlers rest.HandlersInterfaces, r *mux.Router
 	return r
 }
 
-// SharedAuthenticatedRoutes return REST routes shared by open/closed onboarding services
-func SharedAuthenticatedRoutes(handlers rest.HandlersInterfaces, r *mux.Router) *mux.Router {
-	fc := &firebasetools.FirebaseClient{}
-	firebaseApp, _ := fc.InitFirebase()
-
+// SharedAuthenticatedRoutes return REST routes shared by open/closed onboarding services. They
+// accept the same bearer tokens as the GraphQL route
+func SharedAuthenticatedRoutes(
+	handlers rest.HandlersInterfaces,
+	r *mux.Router,
+	authenticate mux.MiddlewareFunc,
+) *mux.Router {
 	// Authenticated routes
 	rs := r.PathPrefix("/roles").Subrouter()
-	rs.Use(firebasetools.AuthenticationMiddleware(firebaseApp))
+	rs.Use(authenticate)
 	rs.Path("/create_role").Methods(
 		http.MethodPost,
 		http.MethodOptions).
@@ -137,6 +179,24 @@ func SharedAuthenticatedRoutes(handlers rest.HandlersInterfaces, r *mux.Router)
 		http.MethodOptions).
 		HandlerFunc(handlers.RemoveRoleToUser())
 
+	rs.Path("/list_roles").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.ListRoles())
+
+	rs.Path("/list_users").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.ListUserProfiles())
+
+	// an anonymous user is authenticated with the credentials of their anonymous login
+	ra := r.PathPrefix("/anonymous").Subrouter()
+	ra.Use(authenticate)
+	ra.Path("/upgrade").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.UpgradeAnonymousUser())
+
 	return r
 
 }
@@ -166,6 +226,10 @@ func SharedAuthenticatedISCRoutes(handlers rest.HandlersInterfaces, r *mux.Route
 		http.MethodPost,
 		http.MethodOptions).
 		HandlerFunc(handlers.CheckHasPermission())
+	isc.Path("/introspect_token").Methods(
+		http.MethodPost,
+		http.MethodOptions).
+		HandlerFunc(handlers.IntrospectToken())
 
 	// Interservice Authenticated routes
 	// The reason for the below endpoints to be used for interservice communication
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
@@ -20,6 +21,10 @@ type FakeOnboardingRepository struct {
 
 	CreateDetailedUserProfileFn func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error)
 
+	CreateUserAccountFn func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error)
+
+	DeleteUserAccountFn func(ctx context.Context, account *domain.UserAccount) error
+
 	// fetches a user profile by uid
 	GetUserProfileByUIDFn func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error)
 
@@ -69,6 +74,13 @@ type FakeOnboardingRepository struct {
 	SavePINFn   func(ctx context.Context, pin *domain.PIN) (bool, error)
 	UpdatePINFn func(ctx context.Context, id string, pin *domain.PIN) (bool, error)
 
+	ReservePINAttemptFn      func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+	RecordFailedPINAttemptFn func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+	ClearPINAttemptsFn       func(ctx context.Context, profileID string) error
+	RequirePINResetFn        func(ctx context.Context, profileID string) error
+	SaveTempPINFn            func(ctx context.Context, pin *domain.PIN) error
+	UpdatePINHashFn          func(ctx context.Context, pin *domain.PIN, previousPINNumber string) error
+
 	ExchangeRefreshTokenForIDTokenFn func(
 		ctx context.Context,
 		token string,
@@ -100,6 +112,8 @@ type FakeOnboardingRepository struct {
 	UpdateUserNameFn                func(ctx context.Context, id string, phoneNumber string) error
 	UpdatePrimaryPhoneNumberFn      func(ctx context.Context, id string, phoneNumber string) error
 	UpdatePrimaryEmailAddressFn     func(ctx context.Context, id string, emailAddress string) error
+	ReplacePrimaryPhoneNumberFn     func(ctx context.Context, id string, phoneNumber string) error
+	ReplacePrimaryEmailAddressFn    func(ctx context.Context, id string, emailAddress string) error
 	UpdateSecondaryPhoneNumbersFn   func(ctx context.Context, id string, phoneNumbers []string) error
 	UpdateSecondaryEmailAddressesFn func(ctx context.Context, id string, emailAddresses []string) error
 	UpdateUserRoleIDsFn             func(ctx context.Context, id string, roleIDs []string) error
@@ -111,12 +125,16 @@ type FakeOnboardingRepository struct {
 	UpdateBioDataFn                 func(ctx context.Context, id string, data profileutils.BioData) error
 	UpdateVerifiedIdentifiersFn     func(ctx context.Context, id string, identifiers []profileutils.VerifiedIdentifier) error
 	UpdateVerifiedUIDSFn            func(ctx context.Context, id string, uids []string) error
+	RemoveVerifiedIdentifierFn      func(ctx context.Context, id string, uid string) error
+	LinkSocialAccountFn             func(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error
 	UpdateAddressesFn               func(ctx context.Context, id string, address profileutils.Address, addressType enumutils.AddressType) error
 	ListUserProfilesFn              func(ctx context.Context, role profileutils.RoleType) ([]*profileutils.UserProfile, error)
+	ListUserProfilesPageFn          func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.UserProfile, error)
 	UpdateOptOutFn                  func(ctx context.Context, option string, phoneNumber string) error
 	UpdateFavNavActionsFn           func(ctx context.Context, id string, favActions []string) error //roles
 	CreateRoleFn                    func(ctx context.Context, profileID string, role dto.RoleInput) (*profileutils.Role, error)
 	GetAllRolesFn                   func(ctx context.Context) (*[]profileutils.Role, error)
+	ListRolesPageFn                 func(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error)
 	UpdateRoleDetailsFn             func(ctx context.Context, profileID string, role profileutils.Role) (*profileutils.Role, error)
 	GetRolesByIDsFn                 func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error)
 	GetRoleByIDFn                   func(ctx context.Context, roleID string) (*profileutils.Role, error)
@@ -127,6 +145,47 @@ type FakeOnboardingRepository struct {
 	UpdateUserProfileEmailFn        func(ctx context.Context, phone string, email string) error
 	GetUserProfilesByRoleIDFn       func(ctx context.Context, role string) ([]*profileutils.UserProfile, error)
 	SaveRoleRevocationFn            func(ctx context.Context, userID string, revocation dto.RoleRevocationInput) error
+	ListPendingOutboxEventsFn       func(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+	MarkOutboxEventPublishedFn      func(ctx context.Context, id string) error
+	RecordOutboxEventFailureFn      func(ctx context.Context, id string, reason string) error
+	GetPubSubMessageFn              func(ctx context.Context, id string) (*domain.PubSubMessage, error)
+	SavePubSubMessageFn             func(ctx context.Context, message *domain.PubSubMessage) error
+	ListFailedPubSubMessagesFn      func(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error)
+	CreateWebhookEndpointFn         func(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	GetWebhookEndpointFn            func(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+	ListWebhookEndpointsFn          func(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+	DeleteWebhookEndpointFn         func(ctx context.Context, id string) error
+	EnqueueWebhookDeliveriesFn      func(ctx context.Context, event *domain.OutboxEvent) error
+	ListDueWebhookDeliveriesFn      func(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	GetWebhookDeliveryFn            func(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	UpdateWebhookDeliveryFn         func(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListWebhookDeliveriesFn         func(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error)
+	SaveSessionFn                   func(ctx context.Context, session *domain.Session) error
+	GetSessionByRefreshTokenHashFn  func(ctx context.Context, refreshTokenHash string) (*domain.Session, error)
+	ListSessionsFn                  func(ctx context.Context, profileID string) ([]*domain.Session, error)
+	RevokeSessionsFn                func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error
+	RevokeRefreshTokensFn           func(ctx context.Context, uid string) error
+	RecordLoginEventFn              func(ctx context.Context, event *domain.LoginEvent) error
+	ListLoginEventsFn               func(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error)
+	SaveLoginAlertFn                func(ctx context.Context, alert *domain.LoginAlert) error
+	GetLoginAlertByTokenHashFn      func(ctx context.Context, tokenHash string) (*domain.LoginAlert, error)
+	SaveTOTPEnrolmentFn             func(ctx context.Context, enrolment *domain.TOTPEnrolment) error
+	GetTOTPEnrolmentFn              func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error)
+	DeleteTOTPEnrolmentFn           func(ctx context.Context, profileID string) error
+	GetTwoFactorPolicyFn            func(ctx context.Context) (*domain.TwoFactorPolicy, error)
+	SaveTwoFactorPolicyFn           func(ctx context.Context, policy *domain.TwoFactorPolicy) error
+	CreateEmailUserAccountFn        func(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error)
+	SavePasswordFn                  func(ctx context.Context, password *domain.Password) error
+	ReservePasswordAttemptFn        func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.Password, error)
+	ClearPasswordAttemptsFn         func(ctx context.Context, profileID string) error
+	RequirePasswordResetFn          func(ctx context.Context, profileID string) error
+	UpdatePasswordHashFn            func(ctx context.Context, password *domain.Password, previousPasswordHash string) error
+	GetPasswordByEmailAddressFn     func(ctx context.Context, emailAddress string) (*domain.Password, error)
+	GetPasswordByProfileIDFn        func(ctx context.Context, profileID string) (*domain.Password, error)
+	MergeUserProfilesFn             func(ctx context.Context, merge *domain.ProfileMerge) error
+	GetProfileTombstoneFn           func(ctx context.Context, profileID string) (*domain.ProfileTombstone, error)
+	SaveSigningKeyFn                func(ctx context.Context, key *domain.SigningKey) error
+	ListSigningKeysFn               func(ctx context.Context, now time.Time) ([]*domain.SigningKey, error)
 }
 
 // CheckIfAdmin ...
@@ -284,6 +343,44 @@ func (f *FakeOnboardingRepository) UpdatePIN(
 	return f.UpdatePINFn(ctx, id, pin)
 }
 
+// ReservePINAttempt ...
+func (f *FakeOnboardingRepository) ReservePINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return f.ReservePINAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// RecordFailedPINAttempt ...
+func (f *FakeOnboardingRepository) RecordFailedPINAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.PIN, error) {
+	return f.RecordFailedPINAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// ClearPINAttempts ...
+func (f *FakeOnboardingRepository) ClearPINAttempts(ctx context.Context, profileID string) error {
+	return f.ClearPINAttemptsFn(ctx, profileID)
+}
+
+// RequirePINReset ...
+func (f *FakeOnboardingRepository) RequirePINReset(ctx context.Context, profileID string) error {
+	return f.RequirePINResetFn(ctx, profileID)
+}
+
+// SaveTempPIN ...
+func (f *FakeOnboardingRepository) SaveTempPIN(ctx context.Context, pin *domain.PIN) error {
+	return f.SaveTempPINFn(ctx, pin)
+}
+
+// UpdatePINHash ...
+func (f *FakeOnboardingRepository) UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+	return f.UpdatePINHashFn(ctx, pin, previousPINNumber)
+}
+
 // ExchangeRefreshTokenForIDToken ...
 func (f *FakeOnboardingRepository) ExchangeRefreshTokenForIDToken(
 	ctx context.Context,
@@ -319,6 +416,24 @@ func (f *FakeOnboardingRepository) UpdatePrimaryEmailAddress(
 	return f.UpdatePrimaryEmailAddressFn(ctx, id, emailAddress)
 }
 
+// ReplacePrimaryPhoneNumber ...
+func (f *FakeOnboardingRepository) ReplacePrimaryPhoneNumber(
+	ctx context.Context,
+	id string,
+	phoneNumber string,
+) error {
+	return f.ReplacePrimaryPhoneNumberFn(ctx, id, phoneNumber)
+}
+
+// ReplacePrimaryEmailAddress ...
+func (f *FakeOnboardingRepository) ReplacePrimaryEmailAddress(
+	ctx context.Context,
+	id string,
+	emailAddress string,
+) error {
+	return f.ReplacePrimaryEmailAddressFn(ctx, id, emailAddress)
+}
+
 // UpdateSecondaryPhoneNumbers ...
 func (f *FakeOnboardingRepository) UpdateSecondaryPhoneNumbers(
 	ctx context.Context,
@@ -409,6 +524,24 @@ func (f *FakeOnboardingRepository) UpdateVerifiedUIDS(
 	return f.UpdateVerifiedUIDSFn(ctx, id, uids)
 }
 
+// RemoveVerifiedIdentifier ...
+func (f *FakeOnboardingRepository) RemoveVerifiedIdentifier(
+	ctx context.Context,
+	id string,
+	uid string,
+) error {
+	return f.RemoveVerifiedIdentifierFn(ctx, id, uid)
+}
+
+// LinkSocialAccount ...
+func (f *FakeOnboardingRepository) LinkSocialAccount(
+	ctx context.Context,
+	id string,
+	identifier profileutils.VerifiedIdentifier,
+) error {
+	return f.LinkSocialAccountFn(ctx, id, identifier)
+}
+
 // GetOrCreatePhoneNumberUser ...
 func (f *FakeOnboardingRepository) GetOrCreatePhoneNumberUser(ctx context.Context,
 	phone string,
@@ -516,6 +649,14 @@ func (f *FakeOnboardingRepository) ListUserProfiles(
 	return f.ListUserProfilesFn(ctx, role)
 }
 
+// ListUserProfilesPage ...
+func (f *FakeOnboardingRepository) ListUserProfilesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.UserProfile, error) {
+	return f.ListUserProfilesPageFn(ctx, query)
+}
+
 // CreateDetailedUserProfile ...
 func (f *FakeOnboardingRepository) CreateDetailedUserProfile(
 	ctx context.Context,
@@ -525,6 +666,20 @@ func (f *FakeOnboardingRepository) CreateDetailedUserProfile(
 	return f.CreateDetailedUserProfileFn(ctx, phoneNumber, profile)
 }
 
+// CreateUserAccount ...
+func (f *FakeOnboardingRepository) CreateUserAccount(
+	ctx context.Context,
+	phoneNumber string,
+	account *domain.UserAccount,
+) (*domain.UserAccount, error) {
+	return f.CreateUserAccountFn(ctx, phoneNumber, account)
+}
+
+// DeleteUserAccount ...
+func (f *FakeOnboardingRepository) DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error {
+	return f.DeleteUserAccountFn(ctx, account)
+}
+
 // UpdateFavNavActions ...
 func (f *FakeOnboardingRepository) UpdateFavNavActions(
 	ctx context.Context,
@@ -567,6 +722,14 @@ func (f *FakeOnboardingRepository) GetAllRoles(
 	return f.GetAllRolesFn(ctx)
 }
 
+// ListRolesPage ...
+func (f *FakeOnboardingRepository) ListRolesPage(
+	ctx context.Context,
+	query *dto.ListingQuery,
+) ([]*profileutils.Role, error) {
+	return f.ListRolesPageFn(ctx, query)
+}
+
 // GetRolesByIDs ...
 func (f *FakeOnboardingRepository) GetRolesByIDs(
 	ctx context.Context,
@@ -630,3 +793,216 @@ func (f *FakeOnboardingRepository) SaveRoleRevocation(ctx context.Context, userI
 func (f *FakeOnboardingRepository) FetchAllUsers(ctx context.Context, callbackURL string) {
 	f.FetchAllUsersFn(ctx, callbackURL)
 }
+
+// ListPendingOutboxEvents ...
+func (f *FakeOnboardingRepository) ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error) {
+	return f.ListPendingOutboxEventsFn(ctx, limit)
+}
+
+// MarkOutboxEventPublished ...
+func (f *FakeOnboardingRepository) MarkOutboxEventPublished(ctx context.Context, id string) error {
+	return f.MarkOutboxEventPublishedFn(ctx, id)
+}
+
+// RecordOutboxEventFailure ...
+func (f *FakeOnboardingRepository) RecordOutboxEventFailure(ctx context.Context, id string, reason string) error {
+	return f.RecordOutboxEventFailureFn(ctx, id, reason)
+}
+
+// GetPubSubMessage ...
+func (f *FakeOnboardingRepository) GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error) {
+	return f.GetPubSubMessageFn(ctx, id)
+}
+
+// SavePubSubMessage ...
+func (f *FakeOnboardingRepository) SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error {
+	return f.SavePubSubMessageFn(ctx, message)
+}
+
+// ListFailedPubSubMessages ...
+func (f *FakeOnboardingRepository) ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error) {
+	return f.ListFailedPubSubMessagesFn(ctx, topicID)
+}
+
+// CreateWebhookEndpoint ...
+func (f *FakeOnboardingRepository) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return f.CreateWebhookEndpointFn(ctx, endpoint)
+}
+
+// GetWebhookEndpoint ...
+func (f *FakeOnboardingRepository) GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	return f.GetWebhookEndpointFn(ctx, id)
+}
+
+// ListWebhookEndpoints ...
+func (f *FakeOnboardingRepository) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return f.ListWebhookEndpointsFn(ctx)
+}
+
+// DeleteWebhookEndpoint ...
+func (f *FakeOnboardingRepository) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	return f.DeleteWebhookEndpointFn(ctx, id)
+}
+
+// EnqueueWebhookDeliveries ...
+func (f *FakeOnboardingRepository) EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error {
+	return f.EnqueueWebhookDeliveriesFn(ctx, event)
+}
+
+// ListDueWebhookDeliveries ...
+func (f *FakeOnboardingRepository) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return f.ListDueWebhookDeliveriesFn(ctx, now, limit)
+}
+
+// GetWebhookDelivery ...
+func (f *FakeOnboardingRepository) GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	return f.GetWebhookDeliveryFn(ctx, id)
+}
+
+// UpdateWebhookDelivery ...
+func (f *FakeOnboardingRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return f.UpdateWebhookDeliveryFn(ctx, delivery)
+}
+
+// ListWebhookDeliveries ...
+func (f *FakeOnboardingRepository) ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error) {
+	return f.ListWebhookDeliveriesFn(ctx, endpointID, status)
+}
+
+// SaveSession ...
+func (f *FakeOnboardingRepository) SaveSession(ctx context.Context, session *domain.Session) error {
+	return f.SaveSessionFn(ctx, session)
+}
+
+// GetSessionByRefreshTokenHash ...
+func (f *FakeOnboardingRepository) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+	return f.GetSessionByRefreshTokenHashFn(ctx, refreshTokenHash)
+}
+
+// ListSessions ...
+func (f *FakeOnboardingRepository) ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error) {
+	return f.ListSessionsFn(ctx, profileID)
+}
+
+// RevokeSessions ...
+func (f *FakeOnboardingRepository) RevokeSessions(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+	return f.RevokeSessionsFn(ctx, profileID, sessionIDs, revokedAt)
+}
+
+// RevokeRefreshTokens ...
+func (f *FakeOnboardingRepository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	return f.RevokeRefreshTokensFn(ctx, uid)
+}
+
+// RecordLoginEvent ...
+func (f *FakeOnboardingRepository) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error {
+	return f.RecordLoginEventFn(ctx, event)
+}
+
+// ListLoginEvents ...
+func (f *FakeOnboardingRepository) ListLoginEvents(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error) {
+	return f.ListLoginEventsFn(ctx, filter)
+}
+
+// SaveLoginAlert ...
+func (f *FakeOnboardingRepository) SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error {
+	return f.SaveLoginAlertFn(ctx, alert)
+}
+
+// GetLoginAlertByTokenHash ...
+func (f *FakeOnboardingRepository) GetLoginAlertByTokenHash(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+	return f.GetLoginAlertByTokenHashFn(ctx, tokenHash)
+}
+
+// SaveTOTPEnrolment ...
+func (f *FakeOnboardingRepository) SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+	return f.SaveTOTPEnrolmentFn(ctx, enrolment)
+}
+
+// GetTOTPEnrolment ...
+func (f *FakeOnboardingRepository) GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+	return f.GetTOTPEnrolmentFn(ctx, profileID)
+}
+
+// DeleteTOTPEnrolment ...
+func (f *FakeOnboardingRepository) DeleteTOTPEnrolment(ctx context.Context, profileID string) error {
+	return f.DeleteTOTPEnrolmentFn(ctx, profileID)
+}
+
+// GetTwoFactorPolicy ...
+func (f *FakeOnboardingRepository) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	return f.GetTwoFactorPolicyFn(ctx)
+}
+
+// SaveTwoFactorPolicy ...
+func (f *FakeOnboardingRepository) SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+	return f.SaveTwoFactorPolicyFn(ctx, policy)
+}
+
+// CreateEmailUserAccount ...
+func (f *FakeOnboardingRepository) CreateEmailUserAccount(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error) {
+	return f.CreateEmailUserAccountFn(ctx, emailAddress, account)
+}
+
+// SavePassword ...
+func (f *FakeOnboardingRepository) SavePassword(ctx context.Context, password *domain.Password) error {
+	return f.SavePasswordFn(ctx, password)
+}
+
+// ReservePasswordAttempt ...
+func (f *FakeOnboardingRepository) ReservePasswordAttempt(
+	ctx context.Context,
+	profileID string,
+	attemptedAt time.Time,
+) (*domain.Password, error) {
+	return f.ReservePasswordAttemptFn(ctx, profileID, attemptedAt)
+}
+
+// ClearPasswordAttempts ...
+func (f *FakeOnboardingRepository) ClearPasswordAttempts(ctx context.Context, profileID string) error {
+	return f.ClearPasswordAttemptsFn(ctx, profileID)
+}
+
+// RequirePasswordReset ...
+func (f *FakeOnboardingRepository) RequirePasswordReset(ctx context.Context, profileID string) error {
+	return f.RequirePasswordResetFn(ctx, profileID)
+}
+
+// UpdatePasswordHash ...
+func (f *FakeOnboardingRepository) UpdatePasswordHash(
+	ctx context.Context,
+	password *domain.Password,
+	previousPasswordHash string,
+) error {
+	return f.UpdatePasswordHashFn(ctx, password, previousPasswordHash)
+}
+
+// GetPasswordByEmailAddress ...
+func (f *FakeOnboardingRepository) GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error) {
+	return f.GetPasswordByEmailAddressFn(ctx, emailAddress)
+}
+
+// GetPasswordByProfileID ...
+func (f *FakeOnboardingRepository) GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error) {
+	return f.GetPasswordByProfileIDFn(ctx, profileID)
+}
+
+// MergeUserProfiles ...
+func (f *FakeOnboardingRepository) MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error {
+	return f.MergeUserProfilesFn(ctx, merge)
+}
+
+// GetProfileTombstone ...
+func (f *FakeOnboardingRepository) GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+	return f.GetProfileTombstoneFn(ctx, profileID)
+}
+
+// SaveSigningKey ...
+func (f *FakeOnboardingRepository) SaveSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	return f.SaveSigningKeyFn(ctx, key)
+}
+
+// ListSigningKeys ...
+func (f *FakeOnboardingRepository) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	return f.ListSigningKeysFn(ctx, now)
+}
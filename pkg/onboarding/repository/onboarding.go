@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
@@ -30,6 +31,26 @@ type CustomerRepository interface {
 type OnboardingRepository interface {
 	UserProfileRepository
 
+	OutboxRepository
+
+	PubSubMessageRepository
+
+	WebhookRepository
+
+	SessionRepository
+
+	LoginEventRepository
+
+	LoginAlertRepository
+
+	TwoFactorRepository
+
+	PasswordRepository
+
+	ProfileMergeRepository
+
+	SigningKeyRepository
+
 	SupplierRepository
 
 	CustomerRepository
@@ -49,6 +70,18 @@ type OnboardingRepository interface {
 		profile profileutils.UserProfile,
 	) (*profileutils.UserProfile, error)
 
+	// creates the auth user, profile, PIN and communications settings of a new user as a single unit.
+	// Either all of them are stored or none is
+	CreateUserAccount(
+		ctx context.Context,
+		phoneNumber string,
+		account *domain.UserAccount,
+	) (*domain.UserAccount, error)
+
+	// removes the records of an account created by CreateUserAccount. It is used to roll back
+	// a signup that fails after the account has been stored
+	DeleteUserAccount(ctx context.Context, account *domain.UserAccount) error
+
 	// fetches a user profile by uid
 	GetUserProfileByUID(
 		ctx context.Context,
@@ -132,6 +165,28 @@ type OnboardingRepository interface {
 	SavePIN(ctx context.Context, pin *domain.PIN) (bool, error)
 	UpdatePIN(ctx context.Context, id string, pin *domain.PIN) (bool, error)
 
+	// counts an attempt to use the PIN of a profile before the PIN is compared, refusing it when
+	// the PIN can't be attempted. It returns the updated PIN
+	ReservePINAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// counts a failed attempt to use the PIN of a profile, locking the PIN after too many
+	// failed attempts. It returns the updated PIN
+	RecordFailedPINAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error)
+
+	// forgets the failed attempts to use the PIN of a profile, unlocking the PIN
+	ClearPINAttempts(ctx context.Context, profileID string) error
+
+	// stops the PIN of a profile from being used until it is reset with an OTP
+	RequirePINReset(ctx context.Context, profileID string) error
+
+	// replaces the PIN of a profile with a temporary PIN, saving it when the profile has no PIN.
+	// The temporary flag of the PIN is kept
+	SaveTempPIN(ctx context.Context, pin *domain.PIN) error
+
+	// replaces the hash, salt and hashing scheme of the PIN of a profile with the ones of `pin`,
+	// unless the stored hash is no longer `previousPINNumber`
+	UpdatePINHash(ctx context.Context, pin *domain.PIN, previousPINNumber string) error
+
 	ExchangeRefreshTokenForIDToken(
 		ctx context.Context,
 		token string,
@@ -165,6 +220,8 @@ type UserProfileRepository interface {
 	UpdateUserName(ctx context.Context, id string, userName string) error
 	UpdatePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error
 	UpdatePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error
+	ReplacePrimaryPhoneNumber(ctx context.Context, id string, phoneNumber string) error
+	ReplacePrimaryEmailAddress(ctx context.Context, id string, emailAddress string) error
 	UpdateSecondaryPhoneNumbers(ctx context.Context, id string, phoneNumbers []string) error
 	UpdateSecondaryEmailAddresses(ctx context.Context, id string, emailAddresses []string) error
 	UpdateVerifiedIdentifiers(
@@ -173,6 +230,8 @@ type UserProfileRepository interface {
 		identifiers []profileutils.VerifiedIdentifier,
 	) error
 	UpdateVerifiedUIDS(ctx context.Context, id string, uids []string) error
+	RemoveVerifiedIdentifier(ctx context.Context, id string, uid string) error
+	LinkSocialAccount(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error
 	UpdateSuspended(ctx context.Context, id string, status bool) error
 	UpdatePhotoUploadID(ctx context.Context, id string, uploadID string) error
 	UpdatePushTokens(ctx context.Context, id string, pushToken []string) error
@@ -191,6 +250,10 @@ type UserProfileRepository interface {
 		ctx context.Context,
 		role profileutils.RoleType,
 	) ([]*profileutils.UserProfile, error)
+
+	// ListUserProfilesPage reads the user profiles of a page of a listing, in the order of the
+	// listing query. At most one profile more than the page size is read
+	ListUserProfilesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.UserProfile, error)
 }
 
 //RolesRepository interface that provide access to all persistent storage operations for roles
@@ -203,6 +266,10 @@ type RolesRepository interface {
 
 	GetAllRoles(ctx context.Context) (*[]profileutils.Role, error)
 
+	// ListRolesPage reads the roles of a page of a listing, in the order of the listing query.
+	// At most one role more than the page size is read
+	ListRolesPage(ctx context.Context, query *dto.ListingQuery) ([]*profileutils.Role, error)
+
 	GetRoleByID(ctx context.Context, roleID string) (*profileutils.Role, error)
 
 	GetRoleByName(ctx context.Context, roleName string) (*profileutils.Role, error)
@@ -226,3 +293,182 @@ type RolesRepository interface {
 
 	SaveRoleRevocation(ctx context.Context, userID string, revocation dto.RoleRevocationInput) error
 }
+
+// OutboxRepository defines signatures that relate to the outbox of domain events. The events are
+// written together with the state change they describe and read by the outbox relay
+type OutboxRepository interface {
+	// ListPendingOutboxEvents reads the oldest events that have not been published yet
+	ListPendingOutboxEvents(ctx context.Context, limit int) ([]*domain.OutboxEvent, error)
+
+	// MarkOutboxEventPublished records that an event has been published
+	MarkOutboxEventPublished(ctx context.Context, id string) error
+
+	// RecordOutboxEventFailure records a failed attempt to publish an event. The event stays pending
+	RecordOutboxEventFailure(ctx context.Context, id string, reason string) error
+}
+
+// PubSubMessageRepository defines signatures that relate to the messages received from pubsub.
+// A message is recorded by its ID so that it is handled once even when it is delivered again
+type PubSubMessageRepository interface {
+	// GetPubSubMessage reads the record of a received message. It returns nil if the message
+	// has not been received before
+	GetPubSubMessage(ctx context.Context, id string) (*domain.PubSubMessage, error)
+
+	// SavePubSubMessage creates or replaces the record of a received message
+	SavePubSubMessage(ctx context.Context, message *domain.PubSubMessage) error
+
+	// ListFailedPubSubMessages reads the messages that failed or were dead lettered, optionally of a single topic
+	ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error)
+}
+
+// WebhookRepository defines signatures that relate to the webhook endpoints of partner systems
+// and the deliveries of the domain events to them
+type WebhookRepository interface {
+	// CreateWebhookEndpoint stores a new webhook endpoint
+	CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+
+	// GetWebhookEndpoint reads a webhook endpoint
+	GetWebhookEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+
+	// ListWebhookEndpoints reads all the webhook endpoints
+	ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+
+	// DeleteWebhookEndpoint removes a webhook endpoint together with its deliveries
+	DeleteWebhookEndpoint(ctx context.Context, id string) error
+
+	// EnqueueWebhookDeliveries creates the pending deliveries of an event to the active endpoints
+	// that subscribe to it. An event that has already been enqueued is not enqueued again
+	EnqueueWebhookDeliveries(ctx context.Context, event *domain.OutboxEvent) error
+
+	// ListDueWebhookDeliveries reads the oldest pending deliveries that are due to be attempted
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+
+	// GetWebhookDelivery reads a webhook delivery
+	GetWebhookDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+
+	// UpdateWebhookDelivery records an attempt to deliver an event to a webhook endpoint
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListWebhookDeliveries reads the delivery log of a webhook endpoint, newest first, optionally
+	// with a single status
+	ListWebhookDeliveries(ctx context.Context, endpointID string, status *domain.WebhookDeliveryStatus) ([]*domain.WebhookDelivery, error)
+}
+
+// SessionRepository defines signatures that relate to the devices that users are signed in on
+type SessionRepository interface {
+	// SaveSession creates or replaces a session
+	SaveSession(ctx context.Context, session *domain.Session) error
+
+	// GetSessionByRefreshTokenHash reads the session that holds a refresh token. It returns nil
+	// if no session holds the token
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Session, error)
+
+	// ListSessions reads the sessions of a profile, most recently seen first
+	ListSessions(ctx context.Context, profileID string) ([]*domain.Session, error)
+
+	// RevokeSessions signs out the listed sessions of a profile, or all of its sessions when no
+	// session is listed. Sessions that are already revoked keep the time they were revoked at
+	RevokeSessions(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error
+
+	// RevokeRefreshTokens revokes all the refresh tokens that were issued to an auth user
+	RevokeRefreshTokens(ctx context.Context, uid string) error
+}
+
+// LoginEventRepository defines signatures that relate to the audit trail of attempts to log in.
+// Login events can only be appended
+type LoginEventRepository interface {
+	// RecordLoginEvent appends an attempt to log in to the audit trail
+	RecordLoginEvent(ctx context.Context, event *domain.LoginEvent) error
+
+	// ListLoginEvents reads the login events that match a filter, newest first
+	ListLoginEvents(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error)
+}
+
+// LoginAlertRepository defines signatures that relate to the alerts that users are sent when
+// they log in from a new device or network
+type LoginAlertRepository interface {
+	// SaveLoginAlert creates or replaces a login alert
+	SaveLoginAlert(ctx context.Context, alert *domain.LoginAlert) error
+
+	// GetLoginAlertByTokenHash finds the login alert whose "this wasn't me" token has the provided hash.
+	// It returns nil when there is no such alert
+	GetLoginAlertByTokenHash(ctx context.Context, tokenHash string) (*domain.LoginAlert, error)
+}
+
+// TwoFactorRepository defines signatures that relate to second factor authentication
+type TwoFactorRepository interface {
+	// SaveTOTPEnrolment creates or replaces the TOTP enrolment of a profile
+	SaveTOTPEnrolment(ctx context.Context, enrolment *domain.TOTPEnrolment) error
+
+	// GetTOTPEnrolment reads the TOTP enrolment of a profile. It returns nil when the profile has none
+	GetTOTPEnrolment(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error)
+
+	// DeleteTOTPEnrolment removes the TOTP enrolment of a profile
+	DeleteTOTPEnrolment(ctx context.Context, profileID string) error
+
+	// GetTwoFactorPolicy reads the two factor policy. It returns an empty policy when none has been saved
+	GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error)
+
+	// SaveTwoFactorPolicy replaces the two factor policy
+	SaveTwoFactorPolicy(ctx context.Context, policy *domain.TwoFactorPolicy) error
+}
+
+// PasswordRepository defines signatures that relate to email and password logins
+type PasswordRepository interface {
+	// CreateEmailUserAccount creates the auth user, profile, password and communications settings of a user
+	// who signs up with an email address as a single unit. Either all of them are stored or none is.
+	// The account is removed with DeleteUserAccount
+	CreateEmailUserAccount(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error)
+
+	// SavePassword creates or replaces the password of the email login of a profile
+	SavePassword(ctx context.Context, password *domain.Password) error
+
+	// ReservePasswordAttempt counts an attempt to use the password of the email login of a
+	// profile before the password is compared, locking the password after too many failed
+	// attempts. It refuses the attempt when the password is locked or was attempted too recently.
+	// It returns the updated password
+	ReservePasswordAttempt(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.Password, error)
+
+	// ClearPasswordAttempts forgets the failed attempts to use the password of the email login of
+	// a profile, unlocking the password
+	ClearPasswordAttempts(ctx context.Context, profileID string) error
+
+	// RequirePasswordReset stops the password of the email login of a profile from being used
+	// until it is reset with an OTP
+	RequirePasswordReset(ctx context.Context, profileID string) error
+
+	// UpdatePasswordHash replaces the hash, salt and hashing scheme of the password of a profile
+	// with the ones of a rehashed password, unless the password was changed since it was read
+	UpdatePasswordHash(ctx context.Context, password *domain.Password, previousPasswordHash string) error
+
+	// GetPasswordByEmailAddress reads the password of the email login of a normalized email address.
+	// It returns nil when the email address has no email login
+	GetPasswordByEmailAddress(ctx context.Context, emailAddress string) (*domain.Password, error)
+
+	// GetPasswordByProfileID reads the password of the email login of a profile. It returns nil when
+	// the profile has no email login
+	GetPasswordByProfileID(ctx context.Context, profileID string) (*domain.Password, error)
+}
+
+// ProfileMergeRepository defines signatures that relate to merging duplicate user profiles
+type ProfileMergeRepository interface {
+	// MergeUserProfiles stores the result of a merge as the surviving profile and removes the merged
+	// profile as a single unit. The PIN, password, communications settings and experiment participation
+	// of the merged profile are moved to the survivor unless it has its own. The write is rejected with
+	// a conflict error when either profile has changed since the merge was prepared
+	MergeUserProfiles(ctx context.Context, merge *domain.ProfileMerge) error
+
+	// GetProfileTombstone reads the tombstone of a user profile that has been merged into another one.
+	// It returns nil when the profile has not been merged
+	GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error)
+}
+
+// SigningKeyRepository defines signatures that relate to the keys that the service signs its access
+// tokens with
+type SigningKeyRepository interface {
+	// SaveSigningKey stores a new signing key
+	SaveSigningKey(ctx context.Context, key *domain.SigningKey) error
+
+	// ListSigningKeys reads the signing keys that are still published at the provided time, newest first
+	ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error)
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// IntrospectionCacheDuration is how long the introspection of a bearer token is reused. A role that
+// is removed, a profile that is suspended or a Firebase user that is signed out is reflected after
+// at most this long. The sessions of the access tokens signed by the service are checked every time
+const IntrospectionCacheDuration = 30 * time.Second
+
+// TokenIntrospectionUseCases represents the business logic involved in telling other services who
+// the user of a bearer token is and what they are allowed to do, in one call
+type TokenIntrospectionUseCases interface {
+	IntrospectToken(
+		ctx context.Context,
+		token string,
+		flavour feedlib.Flavour,
+	) (*dto.TokenIntrospection, error)
+}
+
+// cachedIntrospection is an introspection that is reused until it expires. The session of an
+// access token signed by the service is kept so that it is checked again when the introspection
+// is reused
+type cachedIntrospection struct {
+	introspection dto.TokenIntrospection
+	session       *tokenSession
+	expiresAt     time.Time
+}
+
+// tokenSession is the session in the sessions registry that an access token was issued for
+type tokenSession struct {
+	profileID string
+	sessionID string
+}
+
+// TokenIntrospectionUseCasesImpl represents the usecase implementation object
+type TokenIntrospectionUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+
+	mu        sync.Mutex
+	cache     map[string]cachedIntrospection
+	evictedAt time.Time
+}
+
+// NewTokenIntrospectionUseCases initializes a new token introspection usecase
+func NewTokenIntrospectionUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *TokenIntrospectionUseCasesImpl {
+	return &TokenIntrospectionUseCasesImpl{
+		infrastructure: infrastructure,
+		baseExt:        ext,
+		cache:          map[string]cachedIntrospection{},
+	}
+}
+
+// IntrospectToken verifies a bearer token and returns the UID, profile, flavour and the scopes of
+// the roles of its user. A token that can't be verified, or whose session has been signed out, is
+// not active, which is not an error. The flavour of an access token signed by the service is used
+// over the provided one, since Firebase ID tokens don't say which app the user logged in to.
+// Anonymous users have no profile and no scopes. Introspections are cached for
+// `IntrospectionCacheDuration`, or until the token expires
+func (t *TokenIntrospectionUseCasesImpl) IntrospectToken(
+	ctx context.Context,
+	token string,
+	flavour feedlib.Flavour,
+) (*dto.TokenIntrospection, error) {
+	ctx, span := tracer.Start(ctx, "IntrospectToken")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	key := utils.HashBearerToken(token) + ":" + flavour.String()
+	if cached, session, ok := t.cached(key, now); ok {
+		if !cached.Active || session == nil {
+			return cached, nil
+		}
+		// the cache is bypassed once the session of the token is signed out
+		active, err := t.sessionActive(ctx, session)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return nil, err
+		}
+		if active {
+			return cached, nil
+		}
+	}
+
+	introspection, session, err := t.introspect(ctx, token, flavour)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	expiresAt := now.Add(IntrospectionCacheDuration)
+	if introspection.ExpiresAt != nil && introspection.ExpiresAt.Before(expiresAt) {
+		expiresAt = *introspection.ExpiresAt
+	}
+	t.store(key, introspection, session, expiresAt, now)
+	return introspection, nil
+}
+
+func (t *TokenIntrospectionUseCasesImpl) introspect(
+	ctx context.Context,
+	token string,
+	flavour feedlib.Flavour,
+) (*dto.TokenIntrospection, *tokenSession, error) {
+	inactive := &dto.TokenIntrospection{Active: false}
+	authToken, err := t.infrastructure.Tokens.VerifyBearerToken(ctx, token)
+	if err != nil {
+		return inactive, nil, nil
+	}
+
+	// an access token signed by the service is only active while its session is
+	var session *tokenSession
+	if profileID, sessionID, ok := tokens.SessionID(authToken); ok {
+		session = &tokenSession{profileID: profileID, sessionID: sessionID}
+		active, err := t.sessionActive(ctx, session)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !active {
+			return inactive, nil, nil
+		}
+	}
+
+	expiresAt := time.Unix(authToken.Expires, 0).In(pubsubtools.TimeLocation)
+	introspection := &dto.TokenIntrospection{
+		Active:    true,
+		UID:       authToken.UID,
+		Flavour:   flavour,
+		Scopes:    []string{},
+		ExpiresAt: &expiresAt,
+	}
+	if tokenFlavour, ok := authToken.Claims["flavour"].(string); ok && tokenFlavour != "" {
+		introspection.Flavour = feedlib.Flavour(tokenFlavour)
+	}
+
+	profile, err := t.infrastructure.Database.GetUserProfileByUID(ctx, authToken.UID, true)
+	if err != nil {
+		if exceptions.IsProfileNotFoundError(err) {
+			// the user is anonymous
+			return introspection, session, nil
+		}
+		return nil, nil, err
+	}
+	introspection.ProfileID = profile.ID
+	if profile.Suspended {
+		introspection.Active = false
+		introspection.Suspended = true
+		return introspection, session, nil
+	}
+
+	roles, err := t.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
+	if err != nil {
+		if !strings.Contains(err.Error(), "role not found") {
+			return nil, nil, err
+		}
+		roles = &[]profileutils.Role{}
+	}
+	introspection.Scopes = utils.GetUserPermissions(*roles)
+	return introspection, session, nil
+}
+
+// sessionActive checks that the session that an access token of the service was issued for is in
+// the sessions registry and has not been signed out
+func (t *TokenIntrospectionUseCasesImpl) sessionActive(ctx context.Context, session *tokenSession) (bool, error) {
+	if session.profileID == "" || session.sessionID == "" {
+		return false, nil
+	}
+	sessions, err := t.infrastructure.Database.ListSessions(ctx, session.profileID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	for _, s := range sessions {
+		if s.ID == session.sessionID {
+			return !s.IsRevoked(), nil
+		}
+	}
+	return false, nil
+}
+
+// cached returns a copy of an introspection that has not expired, with the session of its token
+func (t *TokenIntrospectionUseCasesImpl) cached(
+	key string,
+	now time.Time,
+) (*dto.TokenIntrospection, *tokenSession, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cached, ok := t.cache[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil, nil, false
+	}
+	introspection := cached.introspection
+	return &introspection, cached.session, true
+}
+
+// store caches an introspection. The expired introspections are evicted at most once per
+// `IntrospectionCacheDuration`, so that the cache doesn't grow with every token that was seen
+func (t *TokenIntrospectionUseCasesImpl) store(
+	key string,
+	introspection *dto.TokenIntrospection,
+	session *tokenSession,
+	expiresAt time.Time,
+	now time.Time,
+) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.evictedAt) >= IntrospectionCacheDuration {
+		for cachedKey, cached := range t.cache {
+			if !now.Before(cached.expiresAt) {
+				delete(t.cache, cachedKey)
+			}
+		}
+		t.evictedAt = now
+	}
+	t.cache[key] = cachedIntrospection{introspection: *introspection, session: session, expiresAt: expiresAt}
+}
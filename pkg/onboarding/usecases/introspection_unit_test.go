@@ -0,0 +1,244 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	"github.com/savannahghi/profileutils"
+)
+
+func TestTokenIntrospectionUseCasesImpl_IntrospectToken(t *testing.T) {
+	ctx := context.Background()
+
+	expires := time.Now().Add(time.Hour).Unix()
+	serviceToken := func(sessionID string) *auth.Token {
+		return &auth.Token{
+			UID:     "uid-1",
+			Issuer:  tokens.Issuer,
+			Expires: expires,
+			Claims:  map[string]interface{}{"flavour": "PRO", "profileID": "profile-1", "sid": sessionID},
+		}
+	}
+	authTokens := map[string]*auth.Token{
+		"firebase":        {UID: "uid-1", Expires: expires},
+		"service":         serviceToken("session-1"),
+		"revoked_session": serviceToken("session-2"),
+		"unknown_session": serviceToken("session-3"),
+		"no_session":      serviceToken(""),
+		"anonymous":       {UID: "anonymous-uid", Expires: expires},
+		"suspended":       {UID: "suspended-uid", Expires: expires},
+		"failing":         {UID: "failing-uid", Expires: expires},
+	}
+	revoked := time.Now()
+	profiles := map[string]*profileutils.UserProfile{
+		"uid-1":         {ID: "profile-1", Roles: []string{"role-1", "role-2"}},
+		"suspended-uid": {ID: "profile-2", Suspended: true},
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		flavour       feedlib.Flavour
+		wantActive    bool
+		wantProfileID string
+		wantSuspended bool
+		wantFlavour   feedlib.Flavour
+		wantScopes    []string
+		wantErr       bool
+	}{
+		{
+			name:          "valid:firebase_id_token",
+			token:         "firebase",
+			flavour:       feedlib.FlavourConsumer,
+			wantActive:    true,
+			wantProfileID: "profile-1",
+			wantFlavour:   feedlib.FlavourConsumer,
+			wantScopes:    []string{"role.create"},
+		},
+		{
+			name:          "valid:flavour_of_service_access_token",
+			token:         "service",
+			flavour:       feedlib.FlavourConsumer,
+			wantActive:    true,
+			wantProfileID: "profile-1",
+			wantFlavour:   feedlib.FlavourPro,
+			wantScopes:    []string{"role.create"},
+		},
+		{
+			name:       "valid:revoked_session",
+			token:      "revoked_session",
+			flavour:    feedlib.FlavourConsumer,
+			wantActive: false,
+		},
+		{
+			name:       "valid:unknown_session",
+			token:      "unknown_session",
+			flavour:    feedlib.FlavourConsumer,
+			wantActive: false,
+		},
+		{
+			name:       "valid:service_access_token_without_session",
+			token:      "no_session",
+			flavour:    feedlib.FlavourConsumer,
+			wantActive: false,
+		},
+		{
+			name:       "valid:anonymous_user",
+			token:      "anonymous",
+			wantActive: true,
+			wantScopes: []string{},
+		},
+		{
+			name:          "valid:suspended_user",
+			token:         "suspended",
+			wantActive:    false,
+			wantProfileID: "profile-2",
+			wantSuspended: true,
+		},
+		{
+			name:       "valid:invalid_token",
+			token:      "invalid",
+			wantActive: false,
+		},
+		{
+			name:    "invalid:fail_to_get_profile",
+			token:   "failing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := InitializeFakeOnboardingInteractor()
+			if err != nil {
+				t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+				return
+			}
+
+			verified := 0
+			fakeTokens.VerifyBearerTokenFn = func(ctx context.Context, token string) (*auth.Token, error) {
+				verified++
+				authToken, ok := authTokens[token]
+				if !ok {
+					return nil, fmt.Errorf("invalid auth token")
+				}
+				return authToken, nil
+			}
+			fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+				return []*domain.Session{
+					{ID: "session-1", ProfileID: profileID},
+					{ID: "session-2", ProfileID: profileID, Revoked: &revoked},
+				}, nil
+			}
+			fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+				if uid == "failing-uid" {
+					return nil, exceptions.InternalServerError(fmt.Errorf("unable to read user profile"))
+				}
+				profile, ok := profiles[uid]
+				if !ok {
+					return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+				}
+				return profile, nil
+			}
+			fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+				return &[]profileutils.Role{
+					{ID: "role-1", Active: true, Scopes: []string{"role.create"}},
+					{ID: "role-2", Active: false, Scopes: []string{"role.view"}},
+				}, nil
+			}
+
+			got, err := i.IntrospectToken(ctx, tt.token, tt.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IntrospectToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Active != tt.wantActive {
+				t.Errorf("expected active to be %v, got %v", tt.wantActive, got.Active)
+			}
+			if got.ProfileID != tt.wantProfileID {
+				t.Errorf("expected profile %q, got %q", tt.wantProfileID, got.ProfileID)
+			}
+			if got.Suspended != tt.wantSuspended {
+				t.Errorf("expected suspended to be %v, got %v", tt.wantSuspended, got.Suspended)
+			}
+			if got.Flavour != tt.wantFlavour {
+				t.Errorf("expected flavour %q, got %q", tt.wantFlavour, got.Flavour)
+			}
+			if fmt.Sprint(got.Scopes) != fmt.Sprint(tt.wantScopes) {
+				t.Errorf("expected scopes %v, got %v", tt.wantScopes, got.Scopes)
+			}
+
+			// the introspection is cached
+			again, err := i.IntrospectToken(ctx, tt.token, tt.flavour)
+			if err != nil {
+				t.Errorf("error not expected got %v", err)
+				return
+			}
+			if verified != 1 || again.Active != got.Active || again.ProfileID != got.ProfileID {
+				t.Errorf("expected the cached introspection, the token was verified %d times", verified)
+			}
+		})
+	}
+}
+
+func TestTokenIntrospectionUseCasesImpl_IntrospectToken_RevokedSession(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	fakeTokens.VerifyBearerTokenFn = func(ctx context.Context, token string) (*auth.Token, error) {
+		return &auth.Token{
+			UID:     "uid-1",
+			Issuer:  tokens.Issuer,
+			Expires: time.Now().Add(time.Hour).Unix(),
+			Claims:  map[string]interface{}{"profileID": "profile-1", "sid": "session-1"},
+		}, nil
+	}
+	session := &domain.Session{ID: "session-1", ProfileID: "profile-1"}
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{session}, nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1"}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{}, nil
+	}
+
+	got, err := i.IntrospectToken(ctx, "token", feedlib.FlavourPro)
+	if err != nil || !got.Active {
+		t.Errorf("expected an active token, got %v %v", got, err)
+		return
+	}
+
+	// the cached introspection is not reused once the session is signed out
+	revoked := time.Now()
+	session.Revoked = &revoked
+	got, err = i.IntrospectToken(ctx, "token", feedlib.FlavourPro)
+	if err != nil {
+		t.Errorf("error not expected got %v", err)
+		return
+	}
+	if got.Active {
+		t.Errorf("expected the token of a revoked session to be inactive")
+	}
+
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return nil, exceptions.InternalServerError(fmt.Errorf("unable to list sessions"))
+	}
+	if _, err := i.IntrospectToken(ctx, "another-token", feedlib.FlavourPro); err == nil {
+		t.Errorf("expected an error when the sessions can't be read")
+	}
+}
@@ -3,14 +3,19 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
 	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+	"github.com/sirupsen/logrus"
 )
 
 // LoginUseCases represents all the business logic involved in logging in a user and managing their
@@ -25,6 +30,19 @@ type LoginUseCases interface {
 	RefreshToken(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error)
 	LoginAsAnonymous(ctx context.Context) (*profileutils.AuthCredentialResponse, error)
 	ResumeWithPin(ctx context.Context, pin string) (bool, error)
+	LoginByEmail(
+		ctx context.Context,
+		emailAddress string,
+		password string,
+		flavour feedlib.Flavour,
+	) (*profileutils.UserResponse, error)
+	LoginBySocial(
+		ctx context.Context,
+		provider profileutils.LoginProviderType,
+		idToken string,
+		flavour feedlib.Flavour,
+	) (*profileutils.UserResponse, error)
+	EnrolTOTPWithPIN(ctx context.Context, phone string, PIN string) (*domain.TOTPProvisioning, error)
 }
 
 // LoginUseCasesImpl represents the usecase implementation object
@@ -48,12 +66,30 @@ func NewLoginUseCases(
 }
 
 // LoginByPhone returns credentials that are used to log a user in
-// provided the phone number and pin supplied are correct
+// provided the phone number and pin supplied are correct. PRO users who have an authenticator app,
+// or whose roles require one, also provide a code from it in the context (see
+// `utils.WithSecondFactorCode`). Every attempt is recorded in the login audit trail
 func (l *LoginUseCasesImpl) LoginByPhone(
 	ctx context.Context,
 	phone string,
 	PIN string,
 	flavour feedlib.Flavour,
+) (*profileutils.UserResponse, error) {
+	event := utils.NewLoginEvent(domain.LoginMethodPhone, utils.GetClientInfo(ctx), time.Now())
+	event.Flavour = flavour
+	event.MaskedPhone = l.maskPhoneNumber(phone)
+
+	response, err := l.loginByPhone(ctx, phone, PIN, flavour, event)
+	l.recordLoginEvent(ctx, event, err)
+	return response, err
+}
+
+func (l *LoginUseCasesImpl) loginByPhone(
+	ctx context.Context,
+	phone string,
+	PIN string,
+	flavour feedlib.Flavour,
+	event *domain.LoginEvent,
 ) (*profileutils.UserResponse, error) {
 	ctx, span := tracer.Start(ctx, "LoginByPhone")
 	defer span.End()
@@ -67,6 +103,7 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 		utils.RecordSpanError(span, err)
 		return nil, exceptions.NormalizeMSISDNError(err)
 	}
+	event.MaskedPhone = l.maskPhoneNumber(*phoneNumber)
 
 	profile, err := l.infrastructure.Database.GetUserProfileByPrimaryPhoneNumber(
 		ctx,
@@ -78,6 +115,7 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 		// the error is wrapped already. No need to wrap it again
 		return nil, err
 	}
+	event.ProfileID = profile.ID
 
 	PINData, err := l.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
 	if err != nil {
@@ -86,17 +124,50 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 		return nil, err
 	}
 
-	matched := l.pinExt.ComparePIN(PIN, PINData.Salt, PINData.PINNumber, nil)
+	matched, err := l.comparePIN(ctx, PINData, PIN)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
 	if !matched {
 		return nil, exceptions.PinMismatchError(fmt.Errorf("wrong PIN credentials supplied"))
 
 	}
 
+	// the attempt that comparePIN reserved is only cleared after the second factor, so a wrong
+	// code is already counted as a failed attempt of the PIN
+	recordFailure := func(now time.Time) error {
+		if PINData.IsLocked(now) {
+			return exceptions.PINLockedError(*PINData.LockedUntil)
+		}
+		return nil
+	}
+	if err := checkSecondFactor(ctx, l.infrastructure, profile, recordFailure); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
+	if err := l.clearPINAttempts(ctx, PINData); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
 	auth, err := l.infrastructure.Database.GenerateAuthCredentials(ctx, *phoneNumber, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
+	event.UID = auth.UID
+
+	// a PIN that was hashed with an outdated scheme is hashed again while the raw PIN is known.
+	// Failing to do so should not stop the user from logging in
+	if err := l.rehashPIN(ctx, PINData, PIN); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to rehash the PIN of profile %s: %v", profile.ID, err)
+	}
 
 	// Check whether the PIN is temporary i.e OTP
 	// Update the auth response
@@ -104,6 +175,231 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 		auth.ChangePIN = true
 	}
 
+	// this is a wrapped error. No need to wrap it again
+	return l.completeLogin(ctx, profile, flavour, auth)
+}
+
+// LoginByEmail returns credentials that are used to log a user in provided the email address and
+// password supplied are correct. An email address that has no email login is refused in the same
+// way as a wrong password. PRO users who have an authenticator app, or whose roles require one,
+// also provide a code from it in the context (see `utils.WithSecondFactorCode`). Every attempt is
+// recorded in the login audit trail
+func (l *LoginUseCasesImpl) LoginByEmail(
+	ctx context.Context,
+	emailAddress string,
+	password string,
+	flavour feedlib.Flavour,
+) (*profileutils.UserResponse, error) {
+	event := utils.NewLoginEvent(domain.LoginMethodEmail, utils.GetClientInfo(ctx), time.Now())
+	event.Flavour = flavour
+
+	response, err := l.loginByEmail(ctx, emailAddress, password, flavour, event)
+	l.recordLoginEvent(ctx, event, err)
+	return response, err
+}
+
+func (l *LoginUseCasesImpl) loginByEmail(
+	ctx context.Context,
+	emailAddress string,
+	password string,
+	flavour feedlib.Flavour,
+	event *domain.LoginEvent,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "LoginByEmail")
+	defer span.End()
+
+	if ok := flavour.IsValid(); !ok {
+		return nil, exceptions.WrongEnumTypeError(flavour.String())
+	}
+
+	emailAddress = utils.NormalizeEmailAddress(emailAddress)
+	passwordData, err := l.infrastructure.Database.GetPasswordByEmailAddress(ctx, emailAddress)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	if passwordData == nil {
+		return nil, exceptions.PasswordMismatchError()
+	}
+	event.ProfileID = passwordData.ProfileID
+
+	matched, err := l.comparePassword(ctx, passwordData, password)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	if !matched {
+		return nil, exceptions.PasswordMismatchError()
+	}
+
+	profile, err := l.infrastructure.Database.GetUserProfileByID(ctx, passwordData.ProfileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
+	// the attempt that comparePassword reserved is only cleared after the second factor, so a
+	// wrong code is already counted as a failed attempt of the password
+	recordFailure := func(now time.Time) error {
+		if passwordData.IsLocked(now) {
+			return exceptions.PasswordLockedError(*passwordData.LockedUntil)
+		}
+		return nil
+	}
+	if err := checkSecondFactor(ctx, l.infrastructure, profile, recordFailure); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
+	auth, err := l.infrastructure.Database.GenerateAuthCredentials(ctx, emailAddress, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	event.UID = auth.UID
+
+	// the failed attempts are cleared and a password that was hashed with an outdated scheme is
+	// hashed again while the raw password is known. Failing to do so should not stop the user
+	// from logging in
+	if err := l.updatePasswordAfterLogin(ctx, passwordData, password); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to update the password of profile %s: %v", profile.ID, err)
+	}
+
+	// this is a wrapped error. No need to wrap it again
+	return l.completeLogin(ctx, profile, flavour, auth)
+}
+
+// LoginBySocial returns credentials that are used to log a user in provided the ID token that a
+// social login provider issued to them is valid and the account that it was issued for is linked
+// to their profile. PRO users who have an authenticator app, or whose roles require one, also
+// provide a code from it in the context (see `utils.WithSecondFactorCode`). Every attempt is
+// recorded in the login audit trail
+func (l *LoginUseCasesImpl) LoginBySocial(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+	idToken string,
+	flavour feedlib.Flavour,
+) (*profileutils.UserResponse, error) {
+	event := utils.NewLoginEvent(domain.LoginMethodSocial, utils.GetClientInfo(ctx), time.Now())
+	event.Flavour = flavour
+
+	response, err := l.loginBySocial(ctx, provider, idToken, flavour, event)
+	l.recordLoginEvent(ctx, event, err)
+	return response, err
+}
+
+func (l *LoginUseCasesImpl) loginBySocial(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+	idToken string,
+	flavour feedlib.Flavour,
+	event *domain.LoginEvent,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "LoginBySocial")
+	defer span.End()
+
+	if ok := flavour.IsValid(); !ok {
+		return nil, exceptions.WrongEnumTypeError(flavour.String())
+	}
+	if !utils.IsSocialLoginProvider(provider) {
+		return nil, exceptions.WrongEnumTypeError(string(provider))
+	}
+
+	identity, err := l.infrastructure.Social.VerifyIDToken(ctx, provider, idToken)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	uid := utils.SocialUID(identity.Provider, identity.Subject)
+
+	profile, err := l.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	event.ProfileID = profile.ID
+
+	// the wrong codes of an authenticator app count against the user's PIN as they do when they
+	// log in with their phone number, so a locked PIN stops them from guessing more codes
+	PINData, err := l.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
+	if err != nil && !exceptions.IsPINNotFoundError(err) {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	if PINData != nil && PINData.IsLocked(time.Now()) {
+		return nil, exceptions.PINLockedError(*PINData.LockedUntil)
+	}
+
+	// a login that the user reported requires them to reset their credentials before they can
+	// log in again, including with an account that only vouches for who they are
+	if PINData != nil && PINData.ResetRequired {
+		return nil, exceptions.PINResetRequiredError()
+	}
+	passwordData, err := l.infrastructure.Database.GetPasswordByProfileID(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	if passwordData != nil && passwordData.ResetRequired {
+		return nil, exceptions.PasswordResetRequiredError()
+	}
+	recordFailure := func(now time.Time) error {
+		return l.recordFailedSecondFactor(ctx, profile.ID, now)
+	}
+	if err := checkSecondFactor(ctx, l.infrastructure, profile, recordFailure); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
+	auth, err := l.infrastructure.Database.GenerateAuthCredentials(ctx, uid, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	event.UID = auth.UID
+
+	// this is a wrapped error. No need to wrap it again
+	return l.completeLogin(ctx, profile, flavour, auth)
+}
+
+// completeLogin registers the device of a login whose credentials have been checked, alerts the
+// user about it when it is from a new device and returns what the user needs to use the app.
+// When the service issues its own access tokens, one replaces the Firebase ID token
+func (l *LoginUseCasesImpl) completeLogin(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	flavour feedlib.Flavour,
+	auth *profileutils.AuthCredentialResponse,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "completeLogin")
+	defer span.End()
+
+	// the devices that the user logged in from before tell whether this login should be alerted
+	previous, err := l.infrastructure.Database.ListSessions(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to list the sessions of profile %s: %v", profile.ID, err)
+	}
+
+	// the device is registered so that the user can see it and sign it out later.
+	// A device that is not registered here is registered when its token is refreshed
+	session := utils.NewSession(profile.ID, auth.UID, auth.RefreshToken, utils.GetClientInfo(ctx), time.Now())
+	session.Flavour = flavour
+	if err := l.infrastructure.Database.SaveSession(ctx, session); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to save the session of profile %s: %v", profile.ID, err)
+	}
+
 	// fetch the user's communication settings
 	comms, err := l.infrastructure.Database.GetUserCommunicationsSettings(ctx, profile.ID)
 	if err != nil {
@@ -111,21 +407,27 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 		return nil, err
 	}
 
+	// the user is told about a login from a new device or network. Failing to tell them should
+	// not stop them from logging in
+	if err := alertLogin(ctx, l.infrastructure, profile, comms, session, previous); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to alert profile %s about a login: %v", profile.ID, err)
+	}
+
 	// get navigation actions
 	roles, err := l.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
 	if err != nil {
-		if strings.Contains(err.Error(), "role not found") {
-			roles = nil
-		} else {
+		if !strings.Contains(err.Error(), "role not found") {
+			utils.RecordSpanError(span, err)
 			return nil, err
 		}
+		roles = &[]profileutils.Role{}
 	}
-
-	navActions, err := utils.GetUserNavigationActions(ctx, *profile, *roles)
-	if err != nil {
-		return nil, err
+	if roles == nil {
+		roles = &[]profileutils.Role{}
 	}
 
+	navActions, err := utils.GetUserNavigationActions(ctx, *profile, *roles)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
@@ -134,6 +436,12 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 	// add scopes to auth credentials
 	auth.Scopes = utils.GetUserPermissions(*roles)
 
+	if err := issueAccessToken(ctx, l.infrastructure, session, auth); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
 	return &profileutils.UserResponse{
 		Profile:               profile,
 		Auth:                  *auth,
@@ -144,12 +452,165 @@ func (l *LoginUseCasesImpl) LoginByPhone(
 
 // RefreshToken takes a custom Firebase refresh token and tries to fetch
 // an ID token and returns auth credentials if successful
-// Otherwise, an error is returned
+// Otherwise, an error is returned. The refresh token of a session that has been signed out
+// can't be exchanged. Every attempt is recorded in the login audit trail. When the service issues
+// its own access tokens, the returned ID token is one of them
 func (l *LoginUseCasesImpl) RefreshToken(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error) {
+	event := utils.NewLoginEvent(domain.LoginMethodRefreshToken, utils.GetClientInfo(ctx), time.Now())
+
+	auth, err := l.refreshToken(ctx, token, event)
+	l.recordLoginEvent(ctx, event, err)
+	return auth, err
+}
+
+func (l *LoginUseCasesImpl) refreshToken(
+	ctx context.Context,
+	token string,
+	event *domain.LoginEvent,
+) (*profileutils.AuthCredentialResponse, error) {
 	ctx, span := tracer.Start(ctx, "RefreshToken")
 	defer span.End()
 
-	return l.infrastructure.Database.ExchangeRefreshTokenForIDToken(ctx, token)
+	session, err := l.infrastructure.Database.GetSessionByRefreshTokenHash(ctx, utils.HashRefreshToken(token))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if session != nil {
+		event.ProfileID = session.ProfileID
+		event.UID = session.UID
+	}
+	if session != nil && session.IsRevoked() {
+		err := exceptions.SessionRevokedError()
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	auth, err := l.infrastructure.Database.ExchangeRefreshTokenForIDToken(ctx, token)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	event.UID = auth.UID
+
+	if err := l.refreshAccessToken(ctx, session, auth); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	// Failing to record the device should not stop the user from refreshing their token
+	if err := l.touchSession(ctx, session, token, auth); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to save the session of user %s: %v", auth.UID, err)
+	}
+	return auth, nil
+}
+
+// refreshAccessToken replaces the refreshed Firebase ID token with an access token signed by the
+// service when it issues its own access tokens. The token carries the flavour that the session
+// was logged in to and the current scopes of the user, so a refresh token without a session that
+// records the flavour is refused. Anonymous users have no profile, so they keep their Firebase ID token
+func (l *LoginUseCasesImpl) refreshAccessToken(
+	ctx context.Context,
+	session *domain.Session,
+	auth *profileutils.AuthCredentialResponse,
+) error {
+	if !l.infrastructure.Tokens.Enabled() {
+		return nil
+	}
+
+	if session == nil {
+		if _, err := l.infrastructure.Database.GetUserProfileByUID(ctx, auth.UID, false); err != nil {
+			// the user is anonymous or has no profile
+			return nil
+		}
+		return exceptions.SessionNotFoundError()
+	}
+	if !session.Flavour.IsValid() {
+		return exceptions.SessionNotFoundError()
+	}
+
+	profile, err := l.infrastructure.Database.GetUserProfileByID(ctx, session.ProfileID, false)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	roles, err := l.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
+	if err != nil {
+		if !strings.Contains(err.Error(), "role not found") {
+			return err
+		}
+		roles = &[]profileutils.Role{}
+	}
+	if roles == nil {
+		roles = &[]profileutils.Role{}
+	}
+	auth.Scopes = utils.GetUserPermissions(*roles)
+
+	// this is a wrapped error. No need to wrap it again
+	return issueAccessToken(ctx, l.infrastructure, session, auth)
+}
+
+// issueAccessToken replaces the Firebase ID token of a user's credentials with an access token
+// signed by the service when it issues its own access tokens. The token carries the profile ID,
+// session, flavour and scopes of the user, so it stops being active when the session is signed out
+func issueAccessToken(
+	ctx context.Context,
+	infrastructure infrastructure.Infrastructure,
+	session *domain.Session,
+	auth *profileutils.AuthCredentialResponse,
+) error {
+	if !infrastructure.Tokens.Enabled() {
+		return nil
+	}
+
+	token, err := infrastructure.Tokens.IssueAccessToken(ctx, &domain.AccessTokenClaims{
+		UID:       auth.UID,
+		ProfileID: session.ProfileID,
+		Flavour:   session.Flavour,
+		SessionID: session.ID,
+		Scopes:    auth.Scopes,
+	})
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	auth.IDToken = &token.Token
+	auth.ExpiresIn = strconv.Itoa(int(token.ExpiresAt.Sub(token.IssuedAt).Seconds()))
+	return nil
+}
+
+// touchSession records that a session has been seen with a refreshed token. A session is created
+// for devices that logged in before sessions were recorded. Anonymous users have no profile,
+// so their sessions are not recorded
+func (l *LoginUseCasesImpl) touchSession(
+	ctx context.Context,
+	session *domain.Session,
+	token string,
+	auth *profileutils.AuthCredentialResponse,
+) error {
+	refreshToken := auth.RefreshToken
+	if refreshToken == "" {
+		refreshToken = token
+	}
+	now := time.Now()
+	info := utils.GetClientInfo(ctx)
+
+	if session == nil {
+		profile, err := l.infrastructure.Database.GetUserProfileByUID(ctx, auth.UID, false)
+		if err != nil {
+			// the user is anonymous or has no profile
+			return nil
+		}
+		session = utils.NewSession(profile.ID, auth.UID, refreshToken, info, now)
+	} else {
+		utils.TouchSession(session, refreshToken, info, now)
+	}
+	return l.infrastructure.Database.SaveSession(ctx, session)
 }
 
 // LoginAsAnonymous logs in a user as anonymous. This anonymous user will not have a userProfile
@@ -161,13 +622,33 @@ func (l *LoginUseCasesImpl) LoginAsAnonymous(
 	ctx, span := tracer.Start(ctx, "LoginAsAnonymous")
 	defer span.End()
 
-	return l.infrastructure.Database.GenerateAuthCredentialsForAnonymousUser(ctx)
+	event := utils.NewLoginEvent(domain.LoginMethodAnonymous, utils.GetClientInfo(ctx), time.Now())
+	auth, err := l.infrastructure.Database.GenerateAuthCredentialsForAnonymousUser(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+	} else {
+		event.UID = auth.UID
+	}
+	l.recordLoginEvent(ctx, event, err)
+	return auth, err
 }
 
 // ResumeWithPin called by the frontend check whether the currently logged in user is the one trying
 // to get
-// access to app
+// access to app. Every attempt is recorded in the login audit trail
 func (l *LoginUseCasesImpl) ResumeWithPin(ctx context.Context, pin string) (bool, error) {
+	event := utils.NewLoginEvent(domain.LoginMethodResumeWithPIN, utils.GetClientInfo(ctx), time.Now())
+
+	matched, err := l.resumeWithPin(ctx, pin, event)
+	outcome := err
+	if err == nil && !matched {
+		outcome = exceptions.PinMismatchError(fmt.Errorf("wrong PIN credentials supplied"))
+	}
+	l.recordLoginEvent(ctx, event, outcome)
+	return matched, err
+}
+
+func (l *LoginUseCasesImpl) resumeWithPin(ctx context.Context, pin string, event *domain.LoginEvent) (bool, error) {
 	ctx, span := tracer.Start(ctx, "ResumeWithPin")
 	defer span.End()
 
@@ -180,6 +661,10 @@ func (l *LoginUseCasesImpl) ResumeWithPin(ctx context.Context, pin string) (bool
 	if profile == nil {
 		return false, exceptions.ProfileNotFoundError(err)
 	}
+	event.ProfileID = profile.ID
+	if profile.PrimaryPhone != nil {
+		event.MaskedPhone = l.maskPhoneNumber(*profile.PrimaryPhone)
+	}
 	PINData, err := l.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
 	if err != nil {
 		utils.RecordSpanError(span, err)
@@ -188,11 +673,261 @@ func (l *LoginUseCasesImpl) ResumeWithPin(ctx context.Context, pin string) (bool
 	if PINData == nil {
 		return false, exceptions.PinNotFoundError(nil)
 	}
-	matched := l.pinExt.ComparePIN(pin, PINData.Salt, PINData.PINNumber, nil)
+	matched, err := l.comparePIN(ctx, PINData, pin)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return false, err
+	}
 	if !matched {
 		// if the pins don't match, return false and dont throw an error.
 		return false, nil
 
 	}
+	if err := l.clearPINAttempts(ctx, PINData); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// EnrolTOTPWithPIN starts adding an authenticator app for a user who can't log in because their
+// roles require one that they have not set up. The user proves who they are with their phone
+// number and PIN, then confirms the enrolment by logging in with a code from the app
+func (l *LoginUseCasesImpl) EnrolTOTPWithPIN(
+	ctx context.Context,
+	phone string,
+	PIN string,
+) (*domain.TOTPProvisioning, error) {
+	ctx, span := tracer.Start(ctx, "EnrolTOTPWithPIN")
+	defer span.End()
+
+	phoneNumber, err := l.baseExt.NormalizeMSISDN(phone)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.NormalizeMSISDNError(err)
+	}
+	profile, err := l.infrastructure.Database.GetUserProfileByPrimaryPhoneNumber(ctx, *phoneNumber, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	PINData, err := l.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	matched, err := l.comparePIN(ctx, PINData, PIN)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	if !matched {
+		return nil, exceptions.PinMismatchError(fmt.Errorf("wrong PIN credentials supplied"))
+	}
+	if err := l.clearPINAttempts(ctx, PINData); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+
+	provisioning, err := startTOTPEnrolment(ctx, l.infrastructure, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return nil, err
+	}
+	return provisioning, nil
+}
+
+// recordLoginEvent appends an attempt to log in to the login audit trail once it is over.
+// Failing to record it should not change the outcome of the attempt
+func (l *LoginUseCasesImpl) recordLoginEvent(ctx context.Context, event *domain.LoginEvent, loginErr error) {
+	ctx, span := tracer.Start(ctx, "recordLoginEvent")
+	defer span.End()
+
+	utils.SetLoginOutcome(event, loginErr)
+	if err := l.infrastructure.Database.RecordLoginEvent(ctx, event); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to record the %s login event %s: %v", event.Method, event.ID, err)
+	}
+}
+
+func (l *LoginUseCasesImpl) maskPhoneNumber(phone string) string {
+	if phone == "" {
+		return ""
+	}
+	return l.profile.MaskPhoneNumbers([]string{phone})[0]
+}
+
+// rehashPIN hashes a PIN that was stored with an outdated scheme again using the current scheme.
+// The raw PIN should already have been compared with the stored PIN
+func (l *LoginUseCasesImpl) rehashPIN(ctx context.Context, PINData *domain.PIN, pin string) error {
+	ctx, span := tracer.Start(ctx, "rehashPIN")
+	defer span.End()
+
+	options := extension.CurrentPINHashOptions()
+	if !extension.PINNeedsRehash(PINData.Scheme, options) {
+		return nil
+	}
+
+	salt, encryptedPin := l.pinExt.EncryptPIN(pin, options)
+	if encryptedPin == "" {
+		err := exceptions.EncryptPINError(fmt.Errorf("unable to hash the PIN with %s", options.Algorithm))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	rehashed := &domain.PIN{
+		ProfileID: PINData.ProfileID,
+		PINNumber: encryptedPin,
+		Salt:      salt,
+		Scheme:    extension.NewPINHashScheme(options),
+	}
+	if err := l.infrastructure.Database.UpdatePINHash(ctx, rehashed, PINData.PINNumber); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return err
+	}
+	return nil
+}
+
+// comparePIN checks a PIN that is entered by a user against their stored PIN. The attempt is
+// reserved, that is counted as failed, before the PIN is compared, so that concurrent attempts
+// can't get past a PIN that is locked or that was attempted too recently. PINData is updated with
+// the reserved PIN. A correct temporary PIN is refused once it has expired. The count is only
+// cleared by clearPINAttempts once every other check of the login has passed, so that a wrong
+// second factor code keeps counting towards the lock
+func (l *LoginUseCasesImpl) comparePIN(ctx context.Context, PINData *domain.PIN, pin string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "comparePIN")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	reserved, err := l.infrastructure.Database.ReservePINAttempt(ctx, PINData.ProfileID, now)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return false, err
+	}
+	*PINData = *reserved
+
+	options := extension.PINHashOptions(PINData.Scheme)
+	if !l.pinExt.ComparePIN(pin, PINData.Salt, PINData.PINNumber, options) {
+		if PINData.IsLocked(now) {
+			err := exceptions.PINLockedError(*PINData.LockedUntil)
+			utils.RecordSpanError(span, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	if PINData.IsExpired(now) {
+		err := exceptions.TempPINExpiredError()
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
 	return true, nil
 }
+
+// recordFailedSecondFactor counts a wrong second factor code as a failed PIN attempt. It returns
+// the error of a PIN that is locked as a result. Failing to count the attempt is only logged
+func (l *LoginUseCasesImpl) recordFailedSecondFactor(ctx context.Context, profileID string, now time.Time) error {
+	updated, err := l.infrastructure.Database.RecordFailedPINAttempt(ctx, profileID, now)
+	if err != nil {
+		logrus.Errorf("unable to record the failed second factor of profile %s: %v", profileID, err)
+		return nil
+	}
+	if updated.IsLocked(now) {
+		return exceptions.PINLockedError(*updated.LockedUntil)
+	}
+	return nil
+}
+
+// clearPINAttempts clears the failed attempts of a PIN after a successful login
+func (l *LoginUseCasesImpl) clearPINAttempts(ctx context.Context, PINData *domain.PIN) error {
+	if PINData.FailedAttempts == 0 && PINData.LockedUntil == nil {
+		return nil
+	}
+	// the error is wrapped already. No need to wrap it again
+	return l.infrastructure.Database.ClearPINAttempts(ctx, PINData.ProfileID)
+}
+
+// comparePassword checks a password that is entered by a user against the password of their email
+// login. As with a PIN, the attempt is reserved, that is counted as failed, before the password is
+// compared, so that concurrent attempts can't get past a password that is locked or that was
+// attempted too recently. passwordData is updated with the reserved password. The count is only
+// cleared once every other check of the login has passed, so that a wrong second factor code
+// keeps counting towards the lock
+func (l *LoginUseCasesImpl) comparePassword(
+	ctx context.Context,
+	passwordData *domain.Password,
+	password string,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "comparePassword")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	reserved, err := l.infrastructure.Database.ReservePasswordAttempt(ctx, passwordData.ProfileID, now)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return false, err
+	}
+	*passwordData = *reserved
+
+	options := extension.PINHashOptions(passwordData.Scheme)
+	if l.pinExt.ComparePIN(password, passwordData.Salt, passwordData.PasswordHash, options) {
+		return true, nil
+	}
+	if passwordData.IsLocked(now) {
+		err := exceptions.PasswordLockedError(*passwordData.LockedUntil)
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	return false, nil
+}
+
+// updatePasswordAfterLogin clears the failed attempts of a password after a successful login and
+// hashes a password that was stored with an outdated scheme again using the current scheme
+func (l *LoginUseCasesImpl) updatePasswordAfterLogin(
+	ctx context.Context,
+	passwordData *domain.Password,
+	password string,
+) error {
+	ctx, span := tracer.Start(ctx, "updatePasswordAfterLogin")
+	defer span.End()
+
+	if passwordData.FailedAttempts != 0 || passwordData.LockedUntil != nil {
+		if err := l.infrastructure.Database.ClearPasswordAttempts(ctx, passwordData.ProfileID); err != nil {
+			utils.RecordSpanError(span, err)
+			// the error is wrapped already. No need to wrap it again
+			return err
+		}
+	}
+
+	options := extension.CurrentPINHashOptions()
+	if !extension.PINNeedsRehash(passwordData.Scheme, options) {
+		return nil
+	}
+	salt, hash := l.pinExt.EncryptPIN(password, options)
+	if hash == "" {
+		err := exceptions.EncryptPINError(fmt.Errorf("unable to hash the password with %s", options.Algorithm))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	rehashed := &domain.Password{
+		ProfileID:    passwordData.ProfileID,
+		PasswordHash: hash,
+		Salt:         salt,
+		Scheme:       extension.NewPINHashScheme(options),
+	}
+	if err := l.infrastructure.Database.UpdatePasswordHash(ctx, rehashed, passwordData.PasswordHash); err != nil {
+		utils.RecordSpanError(span, err)
+		// the error is wrapped already. No need to wrap it again
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// LoginAlertUseCases represents the business logic involved in handling the "this wasn't me"
+// reports that users make from the alerts they are sent when they log in from a new device or network
+type LoginAlertUseCases interface {
+	ReportLogin(ctx context.Context, token string) (bool, error)
+}
+
+// LoginAlertUseCasesImpl represents the usecase implementation object
+type LoginAlertUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewLoginAlertUseCases initializes a new login alert usecase
+func NewLoginAlertUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *LoginAlertUseCasesImpl {
+	return &LoginAlertUseCasesImpl{infrastructure, ext}
+}
+
+// ReportLogin handles the "this wasn't me" link of a login alert. The user is signed out of all
+// their devices and neither their PIN nor their password can be used until they reset it with an
+// OTP. Reporting a login that has already been reported changes nothing
+func (a *LoginAlertUseCasesImpl) ReportLogin(ctx context.Context, token string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ReportLogin")
+	defer span.End()
+
+	if token == "" {
+		err := exceptions.InvalidLoginAlertTokenError(fmt.Errorf("no login alert token was provided"))
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	alert, err := a.infrastructure.Database.GetLoginAlertByTokenHash(ctx, utils.HashLoginAlertToken(token))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if alert == nil {
+		err := exceptions.InvalidLoginAlertTokenError(fmt.Errorf("no login alert has the provided token"))
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	if alert.Reported != nil {
+		return true, nil
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if alert.IsExpired(now) {
+		err := exceptions.InvalidLoginAlertTokenError(
+			fmt.Errorf("the login alert expired at %s", alert.ExpiresAt.Format(time.RFC3339)),
+		)
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	profile, err := a.infrastructure.Database.GetUserProfileByID(ctx, alert.ProfileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := revokeAllSessions(ctx, a.infrastructure, profile); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := requireCredentialResets(ctx, a.infrastructure, profile.ID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	// the alert is marked as reported last so that a report that fails part way can be retried
+	alert.Reported = &now
+	if err := a.infrastructure.Database.SaveLoginAlert(ctx, alert); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// requireCredentialResets stops the PIN and the password of a profile from being used until they
+// are reset with an OTP. A profile may have only one of them
+func requireCredentialResets(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profileID string,
+) error {
+	_, err := infra.Database.GetPINByProfileID(ctx, profileID)
+	switch {
+	case err == nil:
+		if err := infra.Database.RequirePINReset(ctx, profileID); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+	case !exceptions.IsPINNotFoundError(err):
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	password, err := infra.Database.GetPasswordByProfileID(ctx, profileID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if password == nil {
+		return nil
+	}
+	// this is a wrapped error. No need to wrap it again
+	return infra.Database.RequirePasswordReset(ctx, profileID)
+}
+
+// alertLogin tells a user about a login from a device or network that they had not logged in
+// from before, through the channels that their communications settings allow. `previous` are the
+// sessions that the user had before the login. The alert links to the "this wasn't me" flow
+func alertLogin(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profile *profileutils.UserProfile,
+	comms *profileutils.UserCommunicationsSetting,
+	session *domain.Session,
+	previous []*domain.Session,
+) error {
+	ctx, span := tracer.Start(ctx, "alertLogin")
+	defer span.End()
+
+	info := utils.GetClientInfo(ctx)
+	reasons := utils.LoginAlertReasons(previous, info)
+	if len(reasons) == 0 || comms == nil {
+		return nil
+	}
+	sendSMS := comms.AllowTextSMS && profile.PrimaryPhone != nil
+	sendEmail := comms.AllowEmail && profile.PrimaryEmailAddress != nil
+	sendPush := comms.AllowPush && len(profile.PushTokens) > 0
+	if !sendSMS && !sendEmail && !sendPush {
+		return nil
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	alert, token, err := utils.NewLoginAlert(profile.ID, session.ID, info, reasons, now)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return exceptions.InternalServerError(err)
+	}
+	if err := infra.Database.SaveLoginAlert(ctx, alert); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	firstName := ""
+	if profile.UserBioData.FirstName != nil {
+		firstName = *profile.UserBioData.FirstName
+	}
+	link := utils.LoginAlertLink(token)
+	message := utils.LoginAlertText(firstName, alert, link)
+
+	// every allowed channel is tried even when another one fails
+	failed := []string{}
+	if sendSMS {
+		if err := infra.Engagement.SendSMS(ctx, []string{*profile.PrimaryPhone}, message); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if sendEmail {
+		err := infra.Engagement.SendMail(ctx, *profile.PrimaryEmailAddress, message, domain.LoginAlertSubject)
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if sendPush {
+		data := map[string]string{"type": "login_alert", "link": link}
+		err := infra.Engagement.SendPushNotification(ctx, profile.PushTokens, domain.LoginAlertSubject, message, data)
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("unable to send the login alert: %s", strings.Join(failed, "; "))
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginUseCasesImpl_AlertsNewLogins(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	phone := "+254711223344"
+	email := "jane@example.com"
+	firstName := "Jane"
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		return &phone, nil
+	}
+	fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{
+			ID:                  "profile-1",
+			PrimaryPhone:        &phone,
+			PrimaryEmailAddress: &email,
+			PushTokens:          []string{"push-token-1"},
+			UserBioData:         profileutils.BioData{FirstName: &firstName},
+		}, nil
+	}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		return &domain.PIN{ID: "pin-1", ProfileID: profileID, Scheme: extension.NewPINHashScheme(extension.CurrentPINHashOptions())}, nil
+	}
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		return true
+	}
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: "uid-1", RefreshToken: "refresh-token-1"}, nil
+	}
+	fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+		return nil
+	}
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{{ID: "session-1", ProfileID: profileID, DeviceID: "device-1", IPAddress: "196.201.214.17"}}, nil
+	}
+	fakeInfraRepo.GetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string) (*profileutils.UserCommunicationsSetting, error) {
+		return &profileutils.UserCommunicationsSetting{ProfileID: profileID, AllowTextSMS: true, AllowPush: true}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{}, nil
+	}
+
+	var saved *domain.LoginAlert
+	fakeInfraRepo.SaveLoginAlertFn = func(ctx context.Context, alert *domain.LoginAlert) error {
+		saved = alert
+		return nil
+	}
+	var sms, push string
+	fakeEngagementSvs.SendSMSFn = func(ctx context.Context, phoneNumbers []string, message string) error {
+		sms = message
+		return nil
+	}
+	fakeEngagementSvs.SendMailFn = func(ctx context.Context, email string, message string, subject string) error {
+		return fmt.Errorf("email is not allowed by the communications settings")
+	}
+	fakeEngagementSvs.SendPushNotificationFn = func(ctx context.Context, pushTokens []string, title string, body string, data map[string]string) error {
+		push = data["link"]
+		return fmt.Errorf("unable to send push notification")
+	}
+
+	// a known device in a known network is not alerted
+	ctx := utils.WithClientInfo(context.Background(), domain.ClientInfo{DeviceID: "device-1", IPAddress: "196.201.214.90"})
+	if _, err := i.LoginByPhone(ctx, phone, "1234", feedlib.FlavourConsumer); err != nil {
+		t.Errorf("expected to log in, got %v", err)
+		return
+	}
+	if saved != nil || sms != "" {
+		t.Errorf("expected a known device not to be alerted")
+		return
+	}
+
+	// a new device is alerted through the allowed channels. Failing to send a push notification
+	// does not stop the user from logging in
+	ctx = utils.WithClientInfo(context.Background(), domain.ClientInfo{DeviceID: "device-2", Platform: "android", IPAddress: "196.201.214.90"})
+	if _, err := i.LoginByPhone(ctx, phone, "1234", feedlib.FlavourConsumer); err != nil {
+		t.Errorf("expected to log in, got %v", err)
+		return
+	}
+	if saved == nil {
+		t.Errorf("expected the login alert to be saved")
+		return
+	}
+	if len(saved.Reasons) != 1 || saved.Reasons[0] != domain.LoginAlertReasonNewDevice {
+		t.Errorf("expected a new device to be alerted, got %v", saved.Reasons)
+	}
+	if !strings.HasPrefix(sms, "Hi Jane,") || !strings.Contains(sms, "a new android device") {
+		t.Errorf("expected the alert to be sent by SMS, got %q", sms)
+	}
+	if push == "" || !strings.Contains(sms, push) {
+		t.Errorf("expected the push notification to link to the \"this wasn't me\" flow, got %q", push)
+	}
+}
+
+func TestLoginAlertUseCasesImpl_ReportLogin(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	now := time.Now()
+	newAlert := func() *domain.LoginAlert {
+		return &domain.LoginAlert{
+			ID:        "alert-1",
+			ProfileID: "profile-1",
+			TokenHash: utils.HashLoginAlertToken("token-1"),
+			Created:   now,
+			ExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "happy: report a login",
+			token:   "token-1",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "happy: the login has already been reported",
+			token:   "token-1",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: no token",
+			token:   "",
+			wantErr: true,
+		},
+		{
+			name:    "sad: unknown token",
+			token:   "token-2",
+			wantErr: true,
+		},
+		{
+			name:    "sad: the link has expired",
+			token:   "token-1",
+			wantErr: true,
+		},
+		{
+			name:    "sad: unable to require a PIN reset",
+			token:   "token-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := newAlert()
+			revoked, resetRequired := false, false
+
+			fakeInfraRepo.GetLoginAlertByTokenHashFn = func(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+				if tokenHash != alert.TokenHash {
+					return nil, nil
+				}
+				return alert, nil
+			}
+			fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: id, VerifiedUIDS: []string{"uid-1"}}, nil
+			}
+			fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+				return []*domain.Session{{ID: "session-1", ProfileID: profileID, UID: "uid-2"}}, nil
+			}
+			fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+				revoked = len(sessionIDs) == 0
+				return nil
+			}
+			fakeInfraRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+				return nil
+			}
+			fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+				return &domain.PIN{ID: "pin-1", ProfileID: profileID}, nil
+			}
+			fakeInfraRepo.RequirePINResetFn = func(ctx context.Context, profileID string) error {
+				resetRequired = true
+				return nil
+			}
+			fakeInfraRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+				return nil, nil
+			}
+			fakeInfraRepo.SaveLoginAlertFn = func(ctx context.Context, saved *domain.LoginAlert) error {
+				if saved.Reported == nil {
+					return fmt.Errorf("expected the login alert to be reported")
+				}
+				return nil
+			}
+
+			if tt.name == "happy: the login has already been reported" {
+				alert.Reported = &now
+			}
+			if tt.name == "sad: the link has expired" {
+				alert.ExpiresAt = now.Add(-time.Minute)
+			}
+			if tt.name == "sad: unable to require a PIN reset" {
+				fakeInfraRepo.RequirePINResetFn = func(ctx context.Context, profileID string) error {
+					return fmt.Errorf("unable to update the PIN")
+				}
+			}
+
+			got, err := i.ReportLogin(ctx, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReportLogin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ReportLogin() = %v, want %v", got, tt.want)
+			}
+			if tt.name == "happy: report a login" && (!revoked || !resetRequired) {
+				t.Errorf("expected the sessions to be revoked and a PIN reset to be required")
+			}
+			if tt.name == "happy: the login has already been reported" && (revoked || resetRequired) {
+				t.Errorf("expected a reported login not to be reported again")
+			}
+		})
+	}
+}
+
+// setupFakeReportedLogin sets up the reported login alert "token-1" of profile-1, which has the
+// provided PIN and password. Either of them may be nil. Requiring a reset updates them
+func setupFakeReportedLogin(pin *domain.PIN, password *domain.Password) {
+	phone := "+254711223344"
+	email := "wanjiku@example.com"
+	now := time.Now()
+	fakeInfraRepo.GetLoginAlertByTokenHashFn = func(ctx context.Context, tokenHash string) (*domain.LoginAlert, error) {
+		return &domain.LoginAlert{
+			ID:        "alert-1",
+			ProfileID: "profile-1",
+			TokenHash: tokenHash,
+			Created:   now,
+			ExpiresAt: now.Add(time.Hour),
+		}, nil
+	}
+	fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: id, PrimaryPhone: &phone, PrimaryEmailAddress: &email}, nil
+	}
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return nil, nil
+	}
+	fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+		return nil
+	}
+	fakeInfraRepo.SaveLoginAlertFn = func(ctx context.Context, alert *domain.LoginAlert) error {
+		return nil
+	}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		if pin == nil {
+			return nil, exceptions.PinNotFoundError(fmt.Errorf("profile %s has no PIN", profileID))
+		}
+		copied := *pin
+		return &copied, nil
+	}
+	fakeInfraRepo.RequirePINResetFn = func(ctx context.Context, profileID string) error {
+		if pin == nil {
+			return fmt.Errorf("profile %s has no PIN", profileID)
+		}
+		utils.RequirePINReset(pin)
+		return nil
+	}
+	fakeInfraRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+		if password == nil {
+			return nil, nil
+		}
+		copied := *password
+		return &copied, nil
+	}
+	fakeInfraRepo.RequirePasswordResetFn = func(ctx context.Context, profileID string) error {
+		if password == nil {
+			return fmt.Errorf("profile %s has no password", profileID)
+		}
+		utils.RequirePasswordReset(password)
+		return nil
+	}
+	fakeInfraRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+		return nil, nil
+	}
+	fakeInfraRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		return &domain.TwoFactorPolicy{}, nil
+	}
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		return nil
+	}
+}
+
+func TestLoginAlertUseCasesImpl_ReportLogin_LoginByPhone(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	phone := "+254711223344"
+	stored := setupFakeStoredPIN()
+	stored.Scheme = extension.NewPINHashScheme(extension.CurrentPINHashOptions())
+	setupFakeEmailLogin(nil)
+	setupFakeReportedLogin(stored, nil)
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		return &phone, nil
+	}
+	fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone}, nil
+	}
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		return rawPwd == "1234"
+	}
+
+	// a user without an email login only has their PIN reset
+	reported, err := i.ReportLogin(ctx, "token-1")
+	if err != nil || !reported {
+		t.Fatalf("expected the login to be reported, got %v", err)
+	}
+	assert.True(t, stored.ResetRequired)
+
+	_, err = i.LoginByPhone(ctx, phone, "1234", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PINResetRequired, errorCode(err))
+	assert.Equal(t, 0, stored.FailedAttempts)
+
+	stored.ResetRequired = false
+	_, err = i.LoginByPhone(ctx, phone, "1234", feedlib.FlavourConsumer)
+	assert.Nil(t, err)
+}
+
+func TestLoginAlertUseCasesImpl_ReportLogin_LoginByEmail(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	stored := &domain.Password{
+		ID:           "password-1",
+		ProfileID:    "profile-1",
+		EmailAddress: "wanjiku@example.com",
+		PasswordHash: "hash",
+		Scheme:       extension.NewPINHashScheme(extension.CurrentPINHashOptions()),
+	}
+	setupFakeEmailLogin(stored)
+	setupFakeReportedLogin(nil, stored)
+
+	// a user who signed up with their email address has no PIN to reset
+	reported, err := i.ReportLogin(ctx, "token-1")
+	if err != nil || !reported {
+		t.Fatalf("expected the login to be reported, got %v", err)
+	}
+	assert.True(t, stored.ResetRequired)
+
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PasswordResetRequired, errorCode(err))
+	assert.Equal(t, 0, stored.FailedAttempts)
+
+	stored.ResetRequired = false
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Nil(t, err)
+}
+
+func TestLoginAlertUseCasesImpl_ReportLogin_LoginBySocial(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	pin := &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}
+	password := &domain.Password{ID: "password-1", ProfileID: "profile-1", EmailAddress: "wanjiku@example.com"}
+	setupFakeSocialLogin(true, linkedSocialProfile)
+	setupFakeReportedLogin(pin, password)
+
+	reported, err := i.ReportLogin(ctx, "token-1")
+	if err != nil || !reported {
+		t.Fatalf("expected the login to be reported, got %v", err)
+	}
+	assert.True(t, pin.ResetRequired)
+	assert.True(t, password.ResetRequired)
+
+	// the social account is refused until every credential of the user has been reset
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PINResetRequired, errorCode(err))
+
+	pin.ResetRequired = false
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PasswordResetRequired, errorCode(err))
+
+	password.ResetRequired = false
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	assert.Nil(t, err)
+}
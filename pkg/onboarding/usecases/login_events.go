@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+)
+
+const (
+	// DefaultLoginEventsLimit is the number of login events that are listed when no limit is set
+	DefaultLoginEventsLimit = 100
+
+	// MaxLoginEventsLimit is the largest number of login events that can be listed at once
+	MaxLoginEventsLimit = 1000
+)
+
+// LoginEventUseCases represents the business logic involved in investigating the audit trail of
+// attempts to log in. It is restricted to admins
+type LoginEventUseCases interface {
+	ListLoginEvents(ctx context.Context, filter domain.LoginEventFilter) ([]*domain.LoginEvent, error)
+}
+
+// LoginEventUseCasesImpl represents the usecase implementation object
+type LoginEventUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewLoginEventUseCases initializes a new login event usecase
+func NewLoginEventUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *LoginEventUseCasesImpl {
+	return &LoginEventUseCasesImpl{infrastructure, ext}
+}
+
+// ListLoginEvents returns the attempts to log in that match a filter, newest first. At most
+// `MaxLoginEventsLimit` events are listed
+func (l *LoginEventUseCasesImpl) ListLoginEvents(
+	ctx context.Context,
+	filter domain.LoginEventFilter,
+) ([]*domain.LoginEvent, error) {
+	ctx, span := tracer.Start(ctx, "ListLoginEvents")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, l.infrastructure, l.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultLoginEventsLimit
+	}
+	if filter.Limit > MaxLoginEventsLimit {
+		filter.Limit = MaxLoginEventsLimit
+	}
+
+	events, err := l.infrastructure.Database.ListLoginEvents(ctx, &filter)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,133 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+func TestLoginUseCasesImpl_RecordsLoginEvents(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	var recorded *domain.LoginEvent
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		recorded = event
+		return nil
+	}
+	ctx := utils.WithClientInfo(context.Background(), domain.ClientInfo{IPAddress: "196.201.214.1", UserAgent: "bewell/2.1.0"})
+
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		phone := "+254711223344"
+		return &phone, nil
+	}
+	fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phoneNumber}, nil
+	}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		return &domain.PIN{ID: "pin-1", ProfileID: profileID}, nil
+	}
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		return false
+	}
+
+	if _, err := i.LoginByPhone(ctx, "0711223344", "2791", feedlib.FlavourConsumer); err == nil {
+		t.Errorf("expected an error when the PIN does not match")
+		return
+	}
+	if recorded == nil {
+		t.Errorf("expected the failed attempt to be recorded")
+		return
+	}
+	if recorded.Outcome != domain.LoginOutcomeFailed || recorded.Reason != domain.LoginFailureReasonPINMismatch {
+		t.Errorf("expected a PIN mismatch to be recorded, got %v %v", recorded.Outcome, recorded.Reason)
+	}
+	if recorded.ProfileID != "profile-1" || recorded.MaskedPhone != "+254711***344" {
+		t.Errorf("expected the masked phone of profile-1 to be recorded, got %v %v", recorded.ProfileID, recorded.MaskedPhone)
+	}
+	if recorded.Flavour != feedlib.FlavourConsumer || recorded.IPAddress != "196.201.214.1" {
+		t.Errorf("expected the flavour and IP address to be recorded, got %v %v", recorded.Flavour, recorded.IPAddress)
+	}
+
+	recorded = nil
+	fakeInfraRepo.GenerateAuthCredentialsForAnonymousUserFn = func(ctx context.Context) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: "anonymous-uid"}, nil
+	}
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		recorded = event
+		return fmt.Errorf("unable to record the login event")
+	}
+	if _, err := i.LoginAsAnonymous(ctx); err != nil {
+		t.Errorf("failing to record a login event should not fail the login, got %v", err)
+		return
+	}
+	if recorded == nil || recorded.Outcome != domain.LoginOutcomeSucceeded || recorded.UID != "anonymous-uid" {
+		t.Errorf("expected the anonymous login to be recorded, got %v", recorded)
+	}
+}
+
+func TestLoginEventUseCasesImpl_ListLoginEvents(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name      string
+		isAdmin   bool
+		limit     int
+		wantLimit int
+		wantErr   bool
+	}{
+		{
+			name:      "happy: list the login events",
+			isAdmin:   true,
+			wantLimit: 100,
+			wantErr:   false,
+		},
+		{
+			name:      "happy: the limit is capped",
+			isAdmin:   true,
+			limit:     5000,
+			wantLimit: 1000,
+			wantErr:   false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.ListLoginEventsFn = func(ctx context.Context, filter *domain.LoginEventFilter) ([]*domain.LoginEvent, error) {
+				if filter.Limit != tt.wantLimit {
+					return nil, fmt.Errorf("expected a limit of %d, got %d", tt.wantLimit, filter.Limit)
+				}
+				return []*domain.LoginEvent{{ID: "event-1"}}, nil
+			}
+
+			failed := domain.LoginOutcomeFailed
+			events, err := i.ListLoginEvents(ctx, domain.LoginEventFilter{Outcome: &failed, Limit: tt.limit})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListLoginEvents() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(events) != 1 {
+				t.Errorf("expected 1 login event, got %d", len(events))
+			}
+		})
+	}
+}
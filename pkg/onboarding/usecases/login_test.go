@@ -17,6 +17,7 @@ import (
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
 	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/database"
@@ -36,6 +37,14 @@ import (
 
 	pubsubmessaging "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub"
 	pubsubmessagingMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/pubsub/mock"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks"
+	webhooksMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/webhooks/mock"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/social"
+	socialMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/social/mock"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens"
+	tokensMock "github.com/savannahghi/onboarding/pkg/onboarding/infrastructure/services/tokens/mock"
 )
 
 var testUsecase interactor.Usecases
@@ -336,6 +345,9 @@ var fakeBaseExt extMock.FakeBaseExtensionImpl
 var fakePinExt extMock.PINExtensionImpl
 var fakeEngagementSvs engagementMock.FakeServiceEngagement
 var fakePubSub pubsubmessagingMock.FakeServicePubSub
+var fakeWebhooks webhooksMock.FakeServiceWebhooks
+var fakeSocial socialMock.FakeServiceSocial
+var fakeTokens tokensMock.FakeServiceTokens
 
 var fakeInfraRepo mockInfra.FakeInfrastructure
 
@@ -346,15 +358,55 @@ func InitializeFakeOnboardingInteractor() (usecases.Interactor, error) {
 	var ext extension.BaseExtension = &fakeBaseExt
 	var pinExt extension.PINExtension = &fakePinExt
 	var ps pubsubmessaging.ServicePubSub = &fakePubSub
+	var wh webhooks.ServiceWebhooks = &fakeWebhooks
+	var sc social.ServiceSocial = &fakeSocial
+	var tk tokens.ServiceTokens = &fakeTokens
 
 	infra := func() infrastructure.Infrastructure {
 		return infrastructure.Infrastructure{
 			Database:   r,
 			Engagement: engagementSvc,
 			Pubsub:     ps,
+			Webhooks:   wh,
+			Social:     sc,
+			Tokens:     tk,
 		}
 	}()
 
+	// every attempt to log in is recorded in the login audit trail
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		return nil
+	}
+	// the devices that a user logged in from before are checked for unusual logins
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{}, nil
+	}
+	// PRO users are checked for a second factor
+	fakeInfraRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+		return nil, nil
+	}
+	fakeInfraRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		return &domain.TwoFactorPolicy{}, nil
+	}
+	// attempts to use a PIN are reserved on the PIN that a test returns before it is compared
+	fakeInfraRepo.ReservePINAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error) {
+		pin, err := fakeInfraRepo.GetPINByProfileIDFn(ctx, profileID)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.ReservePINAttempt(pin, attemptedAt); err != nil {
+			return nil, err
+		}
+		return pin, nil
+	}
+	fakeInfraRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+		return nil
+	}
+	// Firebase issues the access tokens unless a test says otherwise
+	fakeTokens.EnabledFn = func() bool {
+		return false
+	}
+
 	i := usecases.NewUsecasesInteractor(infra, ext, pinExt)
 
 	return i, nil
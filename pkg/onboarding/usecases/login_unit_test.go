@@ -3,9 +3,12 @@ package usecases_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 
 	"github.com/google/uuid"
 	"github.com/savannahghi/feedlib"
@@ -251,6 +254,36 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid:outdated_pin_rehash_fails",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid:roles_not_found",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid:no_roles",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid:fail_to_normalize_phone",
 			args: args{
@@ -301,12 +334,43 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid:pin_is_locked",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:temporary_pin_expired",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:pin_mismatch_locks_pin",
+			args: args{
+				ctx:     ctx,
+				phone:   "+254761829103",
+				PIN:     "1234",
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			if tt.name == "valid:successfully_login_by_phone" {
+			if tt.name == "valid:successfully_login_by_phone" || tt.name == "valid:outdated_pin_rehash_fails" ||
+				tt.name == "valid:roles_not_found" || tt.name == "valid:no_roles" {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					phone := "+254777886622"
 					return &phone, nil
@@ -325,6 +389,21 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
 					return true
 				}
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "rehashed"
+				}
+				fakeInfraRepo.UpdatePINHashFn = func(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+					if pin.Scheme == nil || pin.PINNumber != "rehashed" {
+						return fmt.Errorf("the PIN was not rehashed with the current scheme")
+					}
+					return nil
+				}
+				fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+					if session.ProfileID != "123" || session.RefreshTokenHash == "" {
+						return fmt.Errorf("the session was not recorded for the profile")
+					}
+					return nil
+				}
 
 				fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
 					customToken := uuid.New().String()
@@ -366,6 +445,24 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 
 			}
 
+			if tt.name == "valid:outdated_pin_rehash_fails" {
+				fakeInfraRepo.UpdatePINHashFn = func(ctx context.Context, pin *domain.PIN, previousPINNumber string) error {
+					return fmt.Errorf("failed to update the PIN hash")
+				}
+			}
+
+			if tt.name == "valid:roles_not_found" {
+				fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+					return nil, fmt.Errorf("role not found")
+				}
+			}
+
+			if tt.name == "valid:no_roles" {
+				fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+					return nil, nil
+				}
+			}
+
 			if tt.name == "invalid:fail_to_normalize_phone" {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					return nil, fmt.Errorf("failed to normalize phone")
@@ -447,6 +544,71 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid:pin_is_locked" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254777886622"
+					return &phone, nil
+				}
+
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+
+				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+					lockedUntil := time.Now().Add(time.Minute)
+					return &domain.PIN{ID: "123", ProfileID: "456", FailedAttempts: 5, LockedUntil: &lockedUntil}, nil
+				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return true
+				}
+			}
+
+			if tt.name == "invalid:temporary_pin_expired" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254777886622"
+					return &phone, nil
+				}
+
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+
+				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+					expiresAt := time.Now().Add(-time.Hour)
+					return &domain.PIN{ID: "123", ProfileID: "456", IsOTP: true, ExpiresAt: &expiresAt}, nil
+				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return true
+				}
+			}
+
+			if tt.name == "invalid:pin_mismatch_locks_pin" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254777886622"
+					return &phone, nil
+				}
+
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+
+				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+					return &domain.PIN{ID: "123", ProfileID: "456", FailedAttempts: 4}, nil
+				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
+			}
+
 			got, err := i.LoginByPhone(
 				tt.args.ctx,
 				tt.args.phone,
@@ -478,6 +640,9 @@ func TestProfileUseCaseImpl_LoginByPhone(t *testing.T) {
 					return
 				}
 			}
+			if (tt.name == "valid:roles_not_found" || tt.name == "valid:no_roles") && len(got.Auth.Scopes) != 0 {
+				t.Errorf("expected a user without roles to have no scopes, got %v", got.Auth.Scopes)
+			}
 		})
 	}
 
@@ -508,6 +673,22 @@ func TestProfileUseCaseImpl_RefreshToken(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid:refresh_token_of_recorded_session",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid:service_issued_access_token",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid:invalid_refreshtoken",
 			args: args{
@@ -516,10 +697,123 @@ func TestProfileUseCaseImpl_RefreshToken(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid:revoked_session",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:fail_to_get_session",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:service_issued_access_token_without_session",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid:service_issued_access_token_without_flavour",
+			args: args{
+				ctx:   context.Background(),
+				token: uuid.New().String(),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			fakeTokens.EnabledFn = func() bool {
+				return false
+			}
+			fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+				return nil, nil
+			}
+			fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: "123"}, nil
+			}
+			fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+				return nil
+			}
+			fakeInfraRepo.ExchangeRefreshTokenForIDTokenFn = func(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error) {
+				idToken := uuid.New().String()
+				return &profileutils.AuthCredentialResponse{IDToken: &idToken, RefreshToken: token}, nil
+			}
+
+			if tt.name == "valid:refresh_token_of_recorded_session" {
+				fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+					return &domain.Session{ID: "session", ProfileID: "123", RefreshTokenHash: refreshTokenHash}, nil
+				}
+				fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+					if session.ID != "session" || session.LastSeen.IsZero() {
+						return fmt.Errorf("the recorded session was not seen again")
+					}
+					return nil
+				}
+			}
+
+			if tt.name == "valid:service_issued_access_token" {
+				fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+					return &domain.Session{ID: "session", ProfileID: "123", Flavour: feedlib.FlavourPro}, nil
+				}
+				fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{ID: id}, nil
+				}
+				fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+					return &[]profileutils.Role{}, nil
+				}
+				fakeTokens.EnabledFn = func() bool {
+					return true
+				}
+				fakeTokens.IssueAccessTokenFn = func(ctx context.Context, claims *domain.AccessTokenClaims) (*domain.AccessToken, error) {
+					if claims.ProfileID != "123" || claims.Flavour != feedlib.FlavourPro {
+						return nil, fmt.Errorf("unexpected access token claims")
+					}
+					now := time.Now()
+					return &domain.AccessToken{Token: "access-token", IssuedAt: now, ExpiresAt: now.Add(time.Hour)}, nil
+				}
+			}
+
+			if tt.name == "invalid:service_issued_access_token_without_session" ||
+				tt.name == "invalid:service_issued_access_token_without_flavour" {
+				if tt.name == "invalid:service_issued_access_token_without_flavour" {
+					fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+						return &domain.Session{ID: "session", ProfileID: "123"}, nil
+					}
+				}
+				fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+					return fmt.Errorf("a refused refresh should not record a session")
+				}
+				fakeTokens.EnabledFn = func() bool {
+					return true
+				}
+				fakeTokens.IssueAccessTokenFn = func(ctx context.Context, claims *domain.AccessTokenClaims) (*domain.AccessToken, error) {
+					return nil, fmt.Errorf("an access token should not be issued without a flavour")
+				}
+			}
+
+			if tt.name == "invalid:revoked_session" {
+				fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+					revoked := time.Now()
+					return &domain.Session{ID: "session", Revoked: &revoked}, nil
+				}
+			}
+
+			if tt.name == "invalid:fail_to_get_session" {
+				fakeInfraRepo.GetSessionByRefreshTokenHashFn = func(ctx context.Context, refreshTokenHash string) (*domain.Session, error) {
+					return nil, fmt.Errorf("failed to get session")
+				}
+			}
 
 			if tt.name == "valid:successfully_refreshToken" {
 				fakeInfraRepo.ExchangeRefreshTokenForIDTokenFn = func(ctx context.Context, token string) (*profileutils.AuthCredentialResponse, error) {
@@ -565,6 +859,19 @@ func TestProfileUseCaseImpl_RefreshToken(t *testing.T) {
 					return
 				}
 			}
+
+			if strings.HasPrefix(tt.name, "invalid:service_issued_access_token") && !exceptions.IsSessionNotFoundError(err) {
+				t.Errorf("expected a session not found error, got %v", err)
+			}
+
+			if tt.name == "valid:service_issued_access_token" {
+				if *got.IDToken != "access-token" || got.ExpiresIn != "3600" {
+					t.Errorf("expected the access token issued by the service, got %v", *got.IDToken)
+				}
+				fakeTokens.EnabledFn = func() bool {
+					return false
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+)
+
+// PubSubMessageUseCases represents the business logic involved in inspecting the messages
+// that the service has received from pubsub
+type PubSubMessageUseCases interface {
+	// ListFailedPubSubMessages returns the received messages that failed or were dead lettered.
+	// It is restricted to admins
+	ListFailedPubSubMessages(ctx context.Context, topicID *string) ([]*domain.PubSubMessage, error)
+
+	// ReplayPubSubMessages pushes the messages of a subscription that were published since a time
+	// again. It is restricted to admins
+	ReplayPubSubMessages(ctx context.Context, subscriptionID string, since time.Time) (int, error)
+}
+
+// PubSubMessageUseCasesImpl represents the usecase implementation object
+type PubSubMessageUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewPubSubMessageUseCases initializes a new pubsub message usecase
+func NewPubSubMessageUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *PubSubMessageUseCasesImpl {
+	return &PubSubMessageUseCasesImpl{infrastructure, ext}
+}
+
+// ListFailedPubSubMessages returns the received messages that failed or were dead lettered,
+// optionally of a single topic
+func (m *PubSubMessageUseCasesImpl) ListFailedPubSubMessages(
+	ctx context.Context,
+	topicID *string,
+) ([]*domain.PubSubMessage, error) {
+	ctx, span := tracer.Start(ctx, "ListFailedPubSubMessages")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, m.infrastructure, m.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	messages, err := m.infrastructure.Database.ListFailedPubSubMessages(ctx, topicID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ReplayPubSubMessages pushes the messages of the topic of a subscription that were published at
+// or after `since` to the subscription again, and returns how many were replayed. The messages
+// that have already been handled are not handled again
+func (m *PubSubMessageUseCasesImpl) ReplayPubSubMessages(
+	ctx context.Context,
+	subscriptionID string,
+	since time.Time,
+) (int, error) {
+	ctx, span := tracer.Start(ctx, "ReplayPubSubMessages")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, m.infrastructure, m.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return 0, err
+	}
+
+	replayed, err := m.infrastructure.Pubsub.ReplayMessages(ctx, subscriptionID, since)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return 0, exceptions.InternalServerError(err)
+	}
+	return replayed, nil
+}
+
+// checkLoggedInUserIsAdmin returns an error when the logged in user is not an admin
+func checkLoggedInUserIsAdmin(
+	ctx context.Context,
+	infrastructure infrastructure.Infrastructure,
+	baseExt extension.BaseExtension,
+) error {
+	uid, err := baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return exceptions.UserNotFoundError(err)
+	}
+	profile, err := infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if !infrastructure.Database.CheckIfAdmin(profile) {
+		return exceptions.LoggedInUserIsNotAdminError()
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// PasswordUseCases represents the business logic of the passwords that users log in with together
+// with their email address
+type PasswordUseCases interface {
+	// NewUserPassword validates and hashes the password of an email login without saving it
+	NewUserPassword(ctx context.Context, emailAddress string, password string) (*domain.Password, error)
+
+	// ResetPassword replaces the password of an email login after verifying an OTP that was sent
+	// to the email address. It also unlocks the email login and signs the profile out everywhere
+	ResetPassword(ctx context.Context, emailAddress string, otp string, password string) (bool, error)
+
+	// AddEmailLogin lets the logged in user log in with their verified primary email address and
+	// the provided password, or changes the password that they log in with
+	AddEmailLogin(ctx context.Context, password string) (bool, error)
+}
+
+// PasswordUseCasesImpl represents the usecase implementation object
+type PasswordUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+	pinExt         extension.PINExtension
+	policy         extension.PasswordPolicy
+}
+
+// NewPasswordUseCases initializes a new password usecase. The password policy decides which
+// passwords users can choose. Passwords are hashed in the same way as PINs
+func NewPasswordUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+	pin extension.PINExtension,
+	policy extension.PasswordPolicy,
+) PasswordUseCases {
+	return &PasswordUseCasesImpl{
+		infrastructure: infrastructure,
+		baseExt:        ext,
+		pinExt:         pin,
+		policy:         policy,
+	}
+}
+
+// NewUserPassword checks a password against the password policy and hashes it. The password is
+// not saved
+func (p *PasswordUseCasesImpl) NewUserPassword(
+	ctx context.Context,
+	emailAddress string,
+	password string,
+) (*domain.Password, error) {
+	_, span := tracer.Start(ctx, "NewUserPassword")
+	defer span.End()
+
+	emailAddress = utils.NormalizeEmailAddress(emailAddress)
+	err := p.policy.ValidatePassword(extension.PasswordPolicyInput{
+		Password:     password,
+		EmailAddress: emailAddress,
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	options := extension.CurrentPINHashOptions()
+	salt, hash := p.pinExt.EncryptPIN(password, options)
+	if hash == "" {
+		err := exceptions.EncryptPINError(fmt.Errorf("unable to hash the password with %s", options.Algorithm))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	return &domain.Password{
+		ID:           uuid.New().String(),
+		EmailAddress: emailAddress,
+		PasswordHash: hash,
+		Salt:         salt,
+		Scheme:       extension.NewPINHashScheme(options),
+		Created:      now,
+		Updated:      now,
+	}, nil
+}
+
+// ResetPassword verifies the OTP that was sent to an email address and replaces the password of
+// its email login. A locked email login is unlocked and the profile is signed out of all its
+// devices
+func (p *PasswordUseCasesImpl) ResetPassword(
+	ctx context.Context,
+	emailAddress string,
+	otp string,
+	password string,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ResetPassword")
+	defer span.End()
+
+	emailAddress = utils.NormalizeEmailAddress(emailAddress)
+	verified, err := p.infrastructure.Engagement.VerifyEmailOTP(ctx, emailAddress, otp)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.VerifyOTPError(err)
+	}
+	if !verified {
+		return false, exceptions.VerifyOTPError(nil)
+	}
+
+	existing, err := p.infrastructure.Database.GetPasswordByEmailAddress(ctx, emailAddress)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if existing == nil {
+		// an email address without an email login fails like a wrong OTP so that the reset can't
+		// be used to find out which email addresses have an email login
+		return false, exceptions.VerifyOTPError(nil)
+	}
+	profile, err := p.infrastructure.Database.GetUserProfileByID(ctx, existing.ProfileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	updated, err := p.NewUserPassword(ctx, emailAddress, password)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	updated.ID = existing.ID
+	updated.ProfileID = existing.ProfileID
+	updated.Created = existing.Created
+
+	// the new password is saved with no failed attempts, which clears the lockout
+	if err := p.infrastructure.Database.SavePassword(ctx, updated); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	// whoever knew the old password may still be signed in
+	if err := revokeAllSessions(ctx, p.infrastructure, profile); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// AddEmailLogin sets the password that the logged in user logs in with together with their
+// primary email address. The primary email address was verified when it was set. An email
+// address can only be the email login of one profile
+func (p *PasswordUseCasesImpl) AddEmailLogin(ctx context.Context, password string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AddEmailLogin")
+	defer span.End()
+
+	uid, err := p.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+	profile, err := p.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if profile.PrimaryEmailAddress == nil || *profile.PrimaryEmailAddress == "" {
+		err := exceptions.MissingInputError("primaryEmailAddress")
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	emailAddress := utils.NormalizeEmailAddress(*profile.PrimaryEmailAddress)
+
+	existing, err := p.infrastructure.Database.GetPasswordByEmailAddress(ctx, emailAddress)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if existing != nil && existing.ProfileID != profile.ID {
+		err := exceptions.CheckEmailExistError()
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	current, err := p.infrastructure.Database.GetPasswordByProfileID(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	updated, err := p.NewUserPassword(ctx, emailAddress, password)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	updated.ProfileID = profile.ID
+	if current != nil {
+		updated.ID = current.ID
+		updated.Created = current.Created
+	}
+
+	if err := p.infrastructure.Database.SavePassword(ctx, updated); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,369 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/errorcodeutil"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupFakeEmailLogin(stored *domain.Password) *[]*domain.Password {
+	saved := []*domain.Password{}
+	var mu sync.Mutex
+	fakeInfraRepo.GetPasswordByEmailAddressFn = func(ctx context.Context, emailAddress string) (*domain.Password, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stored == nil || stored.EmailAddress != emailAddress {
+			return nil, nil
+		}
+		copied := *stored
+		return &copied, nil
+	}
+	fakeInfraRepo.SavePasswordFn = func(ctx context.Context, password *domain.Password) error {
+		copied := *password
+		saved = append(saved, &copied)
+		return nil
+	}
+	fakeInfraRepo.ReservePasswordAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.Password, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := utils.ReservePasswordAttempt(stored, attemptedAt); err != nil {
+			return nil, err
+		}
+		copied := *stored
+		return &copied, nil
+	}
+	fakeInfraRepo.ClearPasswordAttemptsFn = func(ctx context.Context, profileID string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		utils.ClearPasswordAttempts(stored)
+		return nil
+	}
+	fakeInfraRepo.UpdatePasswordHashFn = func(ctx context.Context, password *domain.Password, previousPasswordHash string) error {
+		utils.ReplacePasswordHash(stored, previousPasswordHash, password)
+		return nil
+	}
+	fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+		email := "wanjiku@example.com"
+		return &profileutils.UserProfile{ID: id, PrimaryEmailAddress: &email}, nil
+	}
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		return rawPwd == "kettle-2-lantern"
+	}
+	fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+		return "salt", "hash:" + rawPwd
+	}
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: "uid-1", RefreshToken: "refresh-token-1"}, nil
+	}
+	fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+		return nil
+	}
+	fakeInfraRepo.GetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string) (*profileutils.UserCommunicationsSetting, error) {
+		return &profileutils.UserCommunicationsSetting{ProfileID: profileID}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{}, nil
+	}
+	return &saved
+}
+
+func TestSignUpUseCasesImpl_SignUpByEmail(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+	setupFakeEmailLogin(nil)
+
+	var created *domain.UserAccount
+	fakeInfraRepo.CreateEmailUserAccountFn = func(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error) {
+		account.UID = "uid-1"
+		account.Profile = &profileutils.UserProfile{ID: "profile-1", PrimaryEmailAddress: &emailAddress}
+		created = account
+		return account, nil
+	}
+	verified := true
+	fakeEngagementSvs.VerifyEmailOTPFn = func(ctx context.Context, email, OTP string) (bool, error) {
+		return verified, nil
+	}
+
+	email, password, otp := " Wanjiku@Example.com", "kettle-2-lantern", "123456"
+	input := &dto.EmailSignUpInput{EmailAddress: &email, Password: &password, OTP: &otp, Flavour: feedlib.FlavourConsumer}
+	response, err := i.SignUpByEmail(ctx, input)
+	if err != nil {
+		t.Errorf("error not expected got %v", err)
+		return
+	}
+	assert.Equal(t, "wanjiku@example.com", *response.Profile.PrimaryEmailAddress)
+	assert.Equal(t, "uid-1", response.Auth.UID)
+	assert.Equal(t, "wanjiku@example.com", created.Password.EmailAddress)
+	assert.Equal(t, "hash:kettle-2-lantern", created.Password.PasswordHash)
+	assert.NotNil(t, created.Password.Scheme)
+
+	// a password that breaks the policy is refused before the account is created
+	created = nil
+	weak := "password1"
+	input.Password = &weak
+	_, err = i.SignUpByEmail(ctx, input)
+	assert.Equal(t, exceptions.WeakPassword, errorCode(err))
+	assert.Nil(t, created)
+
+	// the email address has to be verified
+	verified = false
+	input.Password = &password
+	_, err = i.SignUpByEmail(ctx, input)
+	assert.NotNil(t, err)
+	assert.Nil(t, created)
+
+	invalid := "wanjiku"
+	input.EmailAddress = &invalid
+	_, err = i.SignUpByEmail(ctx, input)
+	assert.Equal(t, exceptions.InvalidEmailAddress, errorCode(err))
+}
+
+func TestLoginUseCasesImpl_LoginByEmail(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	stored := &domain.Password{
+		ID:           "password-1",
+		ProfileID:    "profile-1",
+		EmailAddress: "wanjiku@example.com",
+		PasswordHash: "hash",
+		Scheme:       extension.NewPINHashScheme(extension.CurrentPINHashOptions()),
+	}
+	saved := setupFakeEmailLogin(stored)
+	events := []*domain.LoginEvent{}
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		events = append(events, event)
+		return nil
+	}
+
+	response, err := i.LoginByEmail(ctx, "Wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	if err != nil {
+		t.Errorf("error not expected got %v", err)
+		return
+	}
+	assert.Equal(t, "profile-1", response.Profile.ID)
+	assert.Empty(t, *saved)
+	assert.Equal(t, domain.LoginMethodEmail, events[0].Method)
+	assert.Equal(t, domain.LoginOutcomeSucceeded, events[0].Outcome)
+
+	// an email address that has no email login is refused like a wrong password
+	_, err = i.LoginByEmail(ctx, "kamau@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PasswordMismatch, errorCode(err))
+	assert.Equal(t, domain.LoginFailureReasonPasswordMismatch, events[1].Reason)
+
+	// wrong passwords are counted until the email login is locked. Each attempt waits for the
+	// delay that follows the previous failed attempt
+	waited := time.Now().Add(-time.Minute)
+	for attempt := 1; attempt < 5; attempt++ {
+		stored.LastFailedAttempt = &waited
+		_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "wrong-password-1", feedlib.FlavourConsumer)
+		assert.Equal(t, exceptions.PasswordMismatch, errorCode(err))
+		assert.Equal(t, attempt, stored.FailedAttempts)
+	}
+
+	// an attempt that does not wait for the delay is refused without being compared
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.PasswordAttemptsThrottled, errorCode(err))
+	assert.Equal(t, 4, stored.FailedAttempts)
+
+	stored.LastFailedAttempt = &waited
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "wrong-password-1", feedlib.FlavourConsumer)
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+	assert.True(t, stored.IsLocked(time.Now()))
+
+	// the right password is not accepted while the email login is locked
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.True(t, exceptions.IsPasswordLockedError(err))
+	assert.Equal(t, domain.LoginFailureReasonPasswordLocked, events[len(events)-1].Reason)
+
+	// the attempts are cleared by the next successful login
+	expired := time.Now().Add(-time.Minute)
+	stored.LockedUntil = &expired
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, stored.FailedAttempts)
+	assert.Nil(t, stored.LockedUntil)
+
+	// a password that was hashed with an outdated scheme only has its hash replaced
+	stored.Scheme = nil
+	_, err = i.LoginByEmail(ctx, "wanjiku@example.com", "kettle-2-lantern", feedlib.FlavourConsumer)
+	assert.Nil(t, err)
+	assert.Equal(t, "hash:kettle-2-lantern", stored.PasswordHash)
+	assert.Empty(t, *saved)
+}
+
+func TestLoginUseCasesImpl_LoginByEmail_ConcurrentGuesses(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	stored := &domain.Password{
+		ID:           "password-1",
+		ProfileID:    "profile-1",
+		EmailAddress: "wanjiku@example.com",
+		PasswordHash: "hash",
+		Scheme:       extension.NewPINHashScheme(extension.CurrentPINHashOptions()),
+	}
+	setupFakeEmailLogin(stored)
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		return nil
+	}
+	var compared int32
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		atomic.AddInt32(&compared, 1)
+		return rawPwd == "kettle-2-lantern"
+	}
+
+	// a burst of guesses only gets the attempts that are not throttled compared, whatever the
+	// number of guesses
+	var wg sync.WaitGroup
+	for guess := 0; guess < 4*utils.MaxPINAttempts; guess++ {
+		wg.Add(1)
+		go func(guess int) {
+			defer wg.Done()
+			password := fmt.Sprintf("wrong-password-%d", guess)
+			if _, err := i.LoginByEmail(ctx, "wanjiku@example.com", password, feedlib.FlavourConsumer); err == nil {
+				t.Errorf("expected a wrong password to be refused")
+			}
+		}(guess)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&compared))
+	assert.Equal(t, 2, stored.FailedAttempts)
+}
+
+func TestPasswordUseCasesImpl_ResetPassword(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	lockedUntil := time.Now().Add(time.Hour)
+	stored := &domain.Password{
+		ID:             "password-1",
+		ProfileID:      "profile-1",
+		EmailAddress:   "wanjiku@example.com",
+		PasswordHash:   "hash",
+		FailedAttempts: 5,
+		LockedUntil:    &lockedUntil,
+	}
+	saved := setupFakeEmailLogin(stored)
+	verified := true
+	fakeEngagementSvs.VerifyEmailOTPFn = func(ctx context.Context, email, OTP string) (bool, error) {
+		return verified, nil
+	}
+	fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: id, VerifiedUIDS: []string{"uid-1"}}, nil
+	}
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{{ID: "session-1", ProfileID: profileID, UID: "uid-2"}}, nil
+	}
+	revokedSessions := []string{}
+	fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+		revokedSessions = append(revokedSessions, profileID)
+		return nil
+	}
+	revokedUIDs := []string{}
+	fakeInfraRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+		revokedUIDs = append(revokedUIDs, uid)
+		return nil
+	}
+
+	reset, err := i.ResetPassword(ctx, "wanjiku@example.com", "123456", "lantern-3-kettle")
+	assert.Nil(t, err)
+	assert.True(t, reset)
+	assert.Equal(t, []string{"profile-1"}, revokedSessions)
+	assert.ElementsMatch(t, []string{"uid-1", "uid-2"}, revokedUIDs)
+	if assert.Len(t, *saved, 1) {
+		updated := (*saved)[0]
+		assert.Equal(t, "password-1", updated.ID)
+		assert.Equal(t, "profile-1", updated.ProfileID)
+		assert.Equal(t, "hash:lantern-3-kettle", updated.PasswordHash)
+		assert.False(t, updated.IsLocked(time.Now()))
+		assert.Zero(t, updated.FailedAttempts)
+	}
+
+	_, err = i.ResetPassword(ctx, "wanjiku@example.com", "123456", "password1")
+	assert.Equal(t, exceptions.WeakPassword, errorCode(err))
+
+	// an email address without an email login fails in the same way as a wrong OTP
+	_, err = i.ResetPassword(ctx, "kamau@example.com", "123456", "lantern-3-kettle")
+	assert.Equal(t, int(errorcodeutil.OTPVerificationFailed), errorCode(err))
+	assert.NotContains(t, err.Error(), "kamau@example.com")
+
+	verified = false
+	_, err = i.ResetPassword(ctx, "wanjiku@example.com", "654321", "lantern-3-kettle")
+	assert.Equal(t, int(errorcodeutil.OTPVerificationFailed), errorCode(err))
+	assert.Len(t, *saved, 1)
+	assert.Len(t, revokedSessions, 1)
+}
+
+func TestPasswordUseCasesImpl_AddEmailLogin(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	other := &domain.Password{ID: "password-2", ProfileID: "profile-2", EmailAddress: "wanjiku@example.com"}
+	saved := setupFakeEmailLogin(nil)
+	email := "Wanjiku@Example.com"
+	fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+		return "uid-1", nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1", PrimaryEmailAddress: &email}, nil
+	}
+	fakeInfraRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+		return nil, nil
+	}
+
+	added, err := i.AddEmailLogin(ctx, "kettle-2-lantern")
+	assert.Nil(t, err)
+	assert.True(t, added)
+	if assert.Len(t, *saved, 1) {
+		assert.Equal(t, "profile-1", (*saved)[0].ProfileID)
+		assert.Equal(t, "wanjiku@example.com", (*saved)[0].EmailAddress)
+	}
+
+	// the email address is already the email login of another profile
+	fakeInfraRepo.GetPasswordByEmailAddressFn = func(ctx context.Context, emailAddress string) (*domain.Password, error) {
+		return other, nil
+	}
+	_, err = i.AddEmailLogin(ctx, "kettle-2-lantern")
+	assert.NotNil(t, err)
+	assert.Len(t, *saved, 1)
+
+	// the user has to have a verified primary email address
+	email = ""
+	_, err = i.AddEmailLogin(ctx, "kettle-2-lantern")
+	assert.NotNil(t, err)
+}
@@ -240,6 +240,13 @@ func (p *ProfileUseCaseImpl) UpdateUserName(ctx context.Context, userName string
 		utils.RecordSpanError(span, err)
 		return err
 	}
+
+	// the username is only updated if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdateUserName(ctx, profile.ID, userName)
 }
 
@@ -288,27 +295,17 @@ func (p *ProfileUseCaseImpl) UpdatePrimaryPhoneNumber(
 
 	}
 
-	previousPrimaryPhone := profile.PrimaryPhone
-	secondaryPhones := profile.SecondaryPhoneNumbers
-	if err := p.infrastructure.Database.UpdatePrimaryPhoneNumber(ctx, profile.ID, phone); err != nil {
+	// the previous primary phone number is moved to the secondary phone numbers of this read of the
+	// profile, in the same write that sets the new primary phone number
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
 		utils.RecordSpanError(span, err)
 		return err
 	}
-
-	// check if number to be set as primary exists in the list of secondary phones
-	index, exists := utils.FindItem(secondaryPhones, *phoneNumber)
-	if exists {
-		// remove the phoneNumber from the Secondary Phones slice
-		secondaryPhones = append(secondaryPhones[:index], secondaryPhones[index+1:]...)
-	}
-
-	secondaryPhones = append(secondaryPhones, *previousPrimaryPhone)
-
-	if len(secondaryPhones) >= 1 {
-		if err := p.infrastructure.Database.UpdateSecondaryPhoneNumbers(ctx, profile.ID, secondaryPhones); err != nil {
-			utils.RecordSpanError(span, err)
-			return err
-		}
+	if err := p.infrastructure.Database.ReplacePrimaryPhoneNumber(ctx, profile.ID, *phoneNumber); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
@@ -335,34 +332,21 @@ func (p *ProfileUseCaseImpl) UpdatePrimaryEmailAddress(
 		// this is a wrapped error. No need to wrap it again
 		return err
 	}
-	err = p.infrastructure.Database.UpdatePrimaryEmailAddress(ctx, profile.ID, emailAddress)
+
+	// the previous primary email address is moved to the secondary email addresses of this read of
+	// the profile, in the same write that sets the new primary email address
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return err
 	}
-
-	previousPrimaryEmail := profile.PrimaryEmailAddress
-	secondaryEmails := profile.SecondaryEmailAddresses
-
-	if profile.PrimaryEmailAddress != nil {
-		// Check if the email to be set as primary exists in the list
-		// of secondary emails.
-		index, exists := utils.FindItem(secondaryEmails, emailAddress)
-		if exists {
-			secondaryEmails = append(secondaryEmails[:index], secondaryEmails[index+1:]...)
-		}
-
-		secondaryEmails = append(secondaryEmails, *previousPrimaryEmail)
-
-		if len(secondaryEmails) >= 1 {
-			if err := p.infrastructure.Database.UpdateSecondaryEmailAddresses(ctx, profile.ID, secondaryEmails); err != nil {
-				return err
-			}
-		}
+	if err := p.infrastructure.Database.ReplacePrimaryEmailAddress(ctx, profile.ID, emailAddress); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
 	}
 
 	return nil
-
 }
 
 // UpdateSecondaryPhoneNumbers updates secondary phone numbers of a specific user profile
@@ -403,6 +387,12 @@ func (p *ProfileUseCaseImpl) UpdateSecondaryPhoneNumbers(
 			return err
 		}
 
+		// the phone numbers are only added if the profile has not been changed since it was read
+		ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return err
+		}
 		return p.infrastructure.Database.UpdateSecondaryPhoneNumbers(ctx, profile.ID, phoneNumbers)
 	}
 
@@ -446,6 +436,12 @@ func (p *ProfileUseCaseImpl) UpdateSecondaryEmailAddresses(
 		}
 
 		if profile.PrimaryEmailAddress != nil {
+			// the email addresses are only added if the profile has not been changed since it was read
+			ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+			if err != nil {
+				utils.RecordSpanError(span, err)
+				return err
+			}
 			return p.infrastructure.Database.UpdateSecondaryEmailAddresses(
 				ctx,
 				profile.ID,
@@ -484,6 +480,13 @@ func (p *ProfileUseCaseImpl) UpdateVerifiedUIDS(ctx context.Context, uids []stri
 		// this is a wrapped error. No need to wrap it again
 		return err
 	}
+
+	// the UIDs are only added if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdateVerifiedUIDS(ctx, profile.ID, uids)
 }
 
@@ -508,6 +511,12 @@ func (p *ProfileUseCaseImpl) UpdateVerifiedIdentifiers(
 		return err
 	}
 
+	// the identifiers are only added if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdateVerifiedIdentifiers(ctx, profile.ID, identifiers)
 }
 
@@ -599,6 +608,13 @@ func (p *ProfileUseCaseImpl) UpdatePushTokens(
 		return err
 	}
 
+	// the profile is only updated if it has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
 	if retire {
 		// check if supplied push token exist in the list of pushtokens
 		index, exist := utils.FindItem(profile.PushTokens, pushToken)
@@ -636,6 +652,13 @@ func (p *ProfileUseCaseImpl) UpdatePermissions(
 		// this is a wrapped error. No need to wrap it again
 		return err
 	}
+
+	// the permissions are only added if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdatePermissions(ctx, profile.ID, perms)
 }
 
@@ -660,6 +683,13 @@ func (p *ProfileUseCaseImpl) AddAdminPermsToUser(ctx context.Context, phone stri
 		return err
 	}
 	perms := profileutils.DefaultSuperAdminPermissions
+
+	// the permissions are only added if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdatePermissions(ctx, profile.ID, perms)
 }
 
@@ -688,6 +718,13 @@ func (p *ProfileUseCaseImpl) RemoveAdminPermsToUser(ctx context.Context, phone s
 	if len(permissions) >= 1 {
 		permissions = nil
 	}
+
+	// the permissions are only removed if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdatePermissions(ctx, profile.ID, permissions)
 }
 
@@ -720,6 +757,13 @@ func (p *ProfileUseCaseImpl) AddRoleToUser(
 			Message: fmt.Sprintf("Invalid role `%v` not available", role),
 		}
 	}
+
+	// the role is only updated if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdateRole(ctx, profile.ID, role)
 }
 
@@ -743,6 +787,13 @@ func (p *ProfileUseCaseImpl) RemoveRoleToUser(ctx context.Context, phone string)
 		utils.RecordSpanError(span, err)
 		return err
 	}
+
+	// the role is only removed if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
 	return p.infrastructure.Database.UpdateRole(ctx, profile.ID, "")
 }
 
@@ -1428,6 +1479,12 @@ func (p *ProfileUseCaseImpl) SaveFavoriteNavActions(
 		return false, exceptions.ProfileNotFoundError(err)
 	}
 
+	// the profile is only updated if it has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+
 	favActions := user.FavNavActions
 	// if user does not have such favorite action, add it.
 	if !utils.IsFavNavAction(user, title) {
@@ -1461,6 +1518,13 @@ func (p *ProfileUseCaseImpl) DeleteFavoriteNavActions(
 	if err != nil {
 		return false, exceptions.ProfileNotFoundError(err)
 	}
+
+	// the profile is only updated if it has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, user)
+	if err != nil {
+		return false, err
+	}
+
 	var favActions []string
 	for _, t := range user.FavNavActions {
 		// retain the favorite action if it's not the one removed by user
@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// ProfileMergeUseCases represents the business logic involved in merging the duplicate user
+// profiles of a person, e.g. after they registered again with a new phone number. It is
+// restricted to admins
+type ProfileMergeUseCases interface {
+	MergeUserProfiles(
+		ctx context.Context,
+		survivorID string,
+		mergedProfileID string,
+		reason string,
+		dryRun bool,
+	) (*domain.ProfileMerge, error)
+	GetProfileTombstone(ctx context.Context, profileID string) (*domain.ProfileTombstone, error)
+}
+
+// ProfileMergeUseCasesImpl represents the usecase implementation object
+type ProfileMergeUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewProfileMergeUseCases initializes a new profile merge usecase
+func NewProfileMergeUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *ProfileMergeUseCasesImpl {
+	return &ProfileMergeUseCasesImpl{infrastructure, ext}
+}
+
+// MergeUserProfiles merges a duplicate user profile into the surviving one. The survivor takes
+// over the contacts, logins, roles and push tokens of the duplicate as well as its PIN,
+// communications settings and experiment participation where it has none of its own. The
+// duplicate is removed and leaves a tombstone that points to the survivor.
+// A dry run returns the merge, including the resulting profile, without storing anything
+func (m *ProfileMergeUseCasesImpl) MergeUserProfiles(
+	ctx context.Context,
+	survivorID string,
+	mergedProfileID string,
+	reason string,
+	dryRun bool,
+) (*domain.ProfileMerge, error) {
+	ctx, span := tracer.Start(ctx, "MergeUserProfiles")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, m.infrastructure, m.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	admin, err := m.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	if survivorID == mergedProfileID {
+		err := exceptions.InvalidProfileMergeError(fmt.Errorf("a user profile can't be merged into itself"))
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	for _, profileID := range []string{survivorID, mergedProfileID} {
+		tombstone, err := m.infrastructure.Database.GetProfileTombstone(ctx, profileID)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return nil, err
+		}
+		if tombstone != nil {
+			err := exceptions.InvalidProfileMergeError(
+				fmt.Errorf("user profile %s has been merged into %s", profileID, tombstone.MergedInto),
+			)
+			utils.RecordSpanError(span, err)
+			return nil, err
+		}
+	}
+
+	survivor, err := m.infrastructure.Database.GetUserProfileByID(ctx, survivorID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	merged, err := m.infrastructure.Database.GetUserProfileByID(ctx, mergedProfileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	result, err := utils.MergeUserProfiles(survivor, merged)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+
+	merge := &domain.ProfileMerge{
+		ID:              uuid.New().String(),
+		SurvivorID:      survivor.ID,
+		MergedProfileID: merged.ID,
+		Survivor:        survivor,
+		MergedProfile:   merged,
+		Result:          result,
+		Reason:          reason,
+		DryRun:          dryRun,
+		MergedBy:        admin.ID,
+		Created:         time.Now().In(pubsubtools.TimeLocation),
+	}
+	if dryRun {
+		return merge, nil
+	}
+	if err := m.infrastructure.Database.MergeUserProfiles(ctx, merge); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return merge, nil
+}
+
+// GetProfileTombstone returns the tombstone of a user profile that has been merged into another
+// one. It returns nil if the profile has not been merged
+func (m *ProfileMergeUseCasesImpl) GetProfileTombstone(
+	ctx context.Context,
+	profileID string,
+) (*domain.ProfileTombstone, error) {
+	ctx, span := tracer.Start(ctx, "GetProfileTombstone")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, m.infrastructure, m.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	tombstone, err := m.infrastructure.Database.GetProfileTombstone(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return tombstone, nil
+}
+
+func (m *ProfileMergeUseCasesImpl) loggedInProfile(ctx context.Context) (*profileutils.UserProfile, error) {
+	uid, err := m.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.UserNotFoundError(err)
+	}
+	// this is a wrapped error. No need to wrap it again
+	return m.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+}
@@ -0,0 +1,138 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+func TestProfileMergeUseCasesImpl_MergeUserProfiles(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	survivorPhone := "+254711223344"
+	mergedPhone := "+254722334455"
+	profiles := map[string]*profileutils.UserProfile{
+		"survivor": {ID: "survivor", PrimaryPhone: &survivorPhone, VerifiedUIDS: []string{"uid-1"}},
+		"merged":   {ID: "merged", PrimaryPhone: &mergedPhone, VerifiedUIDS: []string{"uid-2"}, Roles: []string{"role-1"}},
+	}
+	fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+		profile, ok := profiles[id]
+		if !ok {
+			return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+		}
+		copied := *profile
+		return &copied, nil
+	}
+
+	tests := []struct {
+		name            string
+		isAdmin         bool
+		survivorID      string
+		mergedProfileID string
+		tombstoned      bool
+		dryRun          bool
+		wantStored      bool
+		wantCode        int
+		wantErr         bool
+	}{
+		{
+			name:            "happy: merge the profiles",
+			isAdmin:         true,
+			survivorID:      "survivor",
+			mergedProfileID: "merged",
+			wantStored:      true,
+		},
+		{
+			name:            "happy: a dry run is not stored",
+			isAdmin:         true,
+			survivorID:      "survivor",
+			mergedProfileID: "merged",
+			dryRun:          true,
+		},
+		{
+			name:            "sad: the logged in user is not an admin",
+			survivorID:      "survivor",
+			mergedProfileID: "merged",
+			wantErr:         true,
+		},
+		{
+			name:            "sad: a profile is merged into itself",
+			isAdmin:         true,
+			survivorID:      "survivor",
+			mergedProfileID: "survivor",
+			wantCode:        exceptions.InvalidProfileMerge,
+			wantErr:         true,
+		},
+		{
+			name:            "sad: a profile has already been merged",
+			isAdmin:         true,
+			survivorID:      "survivor",
+			mergedProfileID: "merged",
+			tombstoned:      true,
+			wantCode:        exceptions.InvalidProfileMerge,
+			wantErr:         true,
+		},
+		{
+			name:            "sad: a profile does not exist",
+			isAdmin:         true,
+			survivorID:      "survivor",
+			mergedProfileID: "missing",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.GetProfileTombstoneFn = func(ctx context.Context, profileID string) (*domain.ProfileTombstone, error) {
+				if tt.tombstoned && profileID == "merged" {
+					return &domain.ProfileTombstone{ProfileID: profileID, MergedInto: "other", Created: time.Now()}, nil
+				}
+				return nil, nil
+			}
+			var stored *domain.ProfileMerge
+			fakeInfraRepo.MergeUserProfilesFn = func(ctx context.Context, merge *domain.ProfileMerge) error {
+				stored = merge
+				return nil
+			}
+
+			merge, err := i.MergeUserProfiles(ctx, tt.survivorID, tt.mergedProfileID, "registered twice", tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MergeUserProfiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantCode != 0 && errorCode(err) != tt.wantCode {
+				t.Errorf("expected error code %d, got %v", tt.wantCode, err)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (stored != nil) != tt.wantStored {
+				t.Errorf("expected the merge to be stored: %v, got %v", tt.wantStored, stored)
+				return
+			}
+			if merge.MergedBy != "profile-1" || merge.DryRun != tt.dryRun {
+				t.Errorf("expected the merge by profile-1 to be recorded, got %v %v", merge.MergedBy, merge.DryRun)
+				return
+			}
+			result := merge.Result
+			if result.ID != "survivor" || len(result.VerifiedUIDS) != 2 || len(result.Roles) != 1 {
+				t.Errorf("expected the survivor to take over the UIDs and roles, got %v", result)
+				return
+			}
+			if len(result.SecondaryPhoneNumbers) != 1 || result.SecondaryPhoneNumbers[0] != mergedPhone {
+				t.Errorf("expected the merged phone number to be a secondary one, got %v", result.SecondaryPhoneNumbers)
+			}
+		})
+	}
+}
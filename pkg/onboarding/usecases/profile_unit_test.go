@@ -15,10 +15,12 @@ import (
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/onboarding/pkg/onboarding/usecases"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestProfileUseCaseImpl_UpdateVerifiedUIDS(t *testing.T) {
@@ -254,6 +256,55 @@ func TestProfileUseCaseImpl_UpdateSecondaryEmailAddresses(t *testing.T) {
 	}
 }
 
+func TestProfileUseCaseImpl_UpdateSecondaryPhoneNumbers_LostUpdate(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	primaryPhone := "+254711223344"
+	read := &profileutils.UserProfile{
+		ID:                    "profile-1",
+		PrimaryPhone:          &primaryPhone,
+		SecondaryPhoneNumbers: []string{"+254722334455"},
+	}
+	stored := *read
+
+	fakeBaseExt.GetLoggedInUserFn = func(ctx context.Context) (*dto.UserInfo, error) {
+		return &dto.UserInfo{UID: "uid-1"}, nil
+	}
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		return &msisdn, nil
+	}
+	fakeInfraRepo.CheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string) (bool, error) {
+		return false, nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return read, nil
+	}
+	written := 0
+	fakeInfraRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
+		// the repository refuses writes that are not based on the stored version of the profile
+		if err := utils.RequireProfileVersion(ctx, &stored); err != nil {
+			return err
+		}
+		written++
+		return nil
+	}
+
+	err = i.UpdateSecondaryPhoneNumbers(ctx, []string{"+254733445566"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, written)
+
+	// another request adds a phone number after the profile was read
+	stored.SecondaryPhoneNumbers = []string{"+254722334455", "+254744556677"}
+	err = i.UpdateSecondaryPhoneNumbers(ctx, []string{"+254733445566"})
+	assert.True(t, exceptions.IsConflictError(err))
+	assert.Equal(t, 1, written)
+}
+
 func TestProfileUseCaseImpl_UpdateUserName(t *testing.T) {
 	ctx := context.Background()
 	i, err := InitializeFakeOnboardingInteractor()
@@ -501,14 +552,6 @@ func TestProfileUseCaseImpl_UpdatePrimaryEmailAddress(t *testing.T) {
 			},
 			wantErr: true,
 		},
-		{
-			name: "invalid:_unable_to_update_secondary_email_address",
-			args: args{
-				ctx:          ctx,
-				emailAddress: "juha@gmail.com",
-			},
-			wantErr: true,
-		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -528,11 +571,7 @@ func TestProfileUseCaseImpl_UpdatePrimaryEmailAddress(t *testing.T) {
 						PrimaryPhone:        &primaryPhone,
 					}, nil
 				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryEmailAddressesFn = func(ctx context.Context, id string, emailAddresses []string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return nil
 				}
 			}
@@ -552,38 +591,11 @@ func TestProfileUseCaseImpl_UpdatePrimaryEmailAddress(t *testing.T) {
 						PrimaryPhone:        &primaryPhone,
 					}, nil
 				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return fmt.Errorf("unable to update primary address")
 				}
 			}
 
-			if tt.name == "invalid:_unable_to_update_secondary_email_address" {
-				fakeBaseExt.GetLoggedInUserFn = func(ctx context.Context) (*dto.UserInfo, error) {
-					return &dto.UserInfo{
-						UID:         "12233",
-						Email:       "test@example.com",
-						PhoneNumber: "0721568526",
-					}, nil
-				}
-				fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:                  "f4f39af7-5b64-4c2f-91bd-42b3af315a4e",
-						PrimaryEmailAddress: &primaryEmail,
-						PrimaryPhone:        &primaryPhone,
-						SecondaryEmailAddresses: []string{
-							"", "lulu@gmail.com",
-						},
-					}, nil
-				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryEmailAddressesFn = func(ctx context.Context, id string, emailAddresses []string) error {
-					return fmt.Errorf("unable to update secondary email")
-				}
-			}
-
 			if tt.name == "invalid:_unable_to_get_logged_in_user" {
 				fakeBaseExt.GetLoggedInUserFn = func(ctx context.Context) (*dto.UserInfo, error) {
 					return nil, fmt.Errorf("unable to get logged user")
@@ -729,11 +741,7 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryEmailAddressesFn = func(ctx context.Context, id string, emailAddress []string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return nil
 				}
 
@@ -823,7 +831,7 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 				fakeEngagementSvs.VerifyEmailOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return fmt.Errorf("unable to update primary email")
 				}
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -842,7 +850,7 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 				fakeEngagementSvs.VerifyEmailOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return nil
 				}
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -855,9 +863,6 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 						PrimaryPhone:        &phone,
 					}, nil
 				}
-				fakeInfraRepo.UpdateSecondaryEmailAddressesFn = func(ctx context.Context, id string, emailAddresses []string) error {
-					return nil
-				}
 
 				fakePubSub.TopicIDsFn = func() []string {
 					return []string{uuid.New().String()}
@@ -892,7 +897,7 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 				fakeEngagementSvs.VerifyEmailOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
-				fakeInfraRepo.UpdatePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
+				fakeInfraRepo.ReplacePrimaryEmailAddressFn = func(ctx context.Context, id string, emailAddress string) error {
 					return nil
 				}
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -905,9 +910,6 @@ func TestProfileUseCaseImpl_SetPrimaryEmailAddress(t *testing.T) {
 						PrimaryPhone:        &phone,
 					}, nil
 				}
-				fakeInfraRepo.UpdateSecondaryEmailAddressesFn = func(ctx context.Context, id string, emailAddresses []string) error {
-					return nil
-				}
 
 				fakePubSub.TopicIDsFn = func() []string {
 					return []string{uuid.New().String()}
@@ -2136,15 +2138,6 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 			},
 			wantErr: true,
 		},
-		{
-			name: "invalid:_unable_to_update_secondary_phonenumber",
-			args: args{
-				ctx:        ctx,
-				phone:      "+25463728192",
-				useContext: false,
-			},
-			wantErr: true,
-		},
 		{
 			name: "invalid:_unable_to_update_primary_phonenumber",
 			args: args{
@@ -2174,11 +2167,7 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
+				fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return nil
 				}
 			}
@@ -2207,11 +2196,7 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
+				fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return nil
 				}
 			}
@@ -2257,7 +2242,7 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 				}
 			}
 
-			if tt.name == "invalid:_unable_to_update_secondary_phonenumber" {
+			if tt.name == "invalid:_unable_to_update_primary_phonenumber" {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					phone := "+254755889922"
 					return &phone, nil
@@ -2273,32 +2258,7 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
-					return fmt.Errorf("unable to update secondary phonenumber")
-				}
-			}
-
-			if tt.name == "invalid:_unable_to_update_secondary_phonenumber" {
-				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
-					phone := "+254755889922"
-					return &phone, nil
-				}
-
-				fakeInfraRepo.GetUserProfileByPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           "ABCDE",
-						PrimaryPhone: &phoneNumber,
-						SecondaryPhoneNumbers: []string{
-							"0765839203", "0789437282",
-						},
-					}, nil
-				}
-
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
+				fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return fmt.Errorf("unable to update primary phonenumber")
 				}
 
@@ -2325,6 +2285,52 @@ func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber(t *testing.T) {
 	}
 }
 
+func TestProfileUseCaseImpl_UpdatePrimaryPhoneNumber_LostUpdate(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	primaryPhone := "+254711223344"
+	read := &profileutils.UserProfile{
+		ID:                    "profile-1",
+		PrimaryPhone:          &primaryPhone,
+		SecondaryPhoneNumbers: []string{"+254722334455"},
+	}
+	stored := *read
+
+	fakeBaseExt.GetLoggedInUserFn = func(ctx context.Context) (*dto.UserInfo, error) {
+		return &dto.UserInfo{UID: "uid-1"}, nil
+	}
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		return &msisdn, nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return read, nil
+	}
+	written := 0
+	fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
+		// the repository refuses writes that are not based on the stored version of the profile
+		if err := utils.RequireProfileVersion(ctx, &stored); err != nil {
+			return err
+		}
+		written++
+		return nil
+	}
+
+	err = i.UpdatePrimaryPhoneNumber(ctx, "+254722334455", true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, written)
+
+	// another request adds a phone number after the profile was read
+	stored.SecondaryPhoneNumbers = []string{"+254722334455", "+254744556677"}
+	err = i.UpdatePrimaryPhoneNumber(ctx, "+254722334455", true)
+	assert.True(t, exceptions.IsConflictError(err))
+	assert.Equal(t, 1, written)
+}
+
 func TestProfileUseCase_UpdateBioData(t *testing.T) {
 	ctx := context.Background()
 	i, err := InitializeFakeOnboardingInteractor()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
@@ -21,6 +22,23 @@ type RoleUseCase interface {
 
 	GetAllRoles(ctx context.Context) ([]*dto.RoleOutput, error)
 
+	// ListRoles returns a page of roles. The users of the roles are not loaded; they are
+	// listed with ListUserProfiles filtered by role
+	ListRoles(
+		ctx context.Context,
+		pagination *firebasetools.PaginationInput,
+		filter *firebasetools.FilterInput,
+		sort *firebasetools.SortInput,
+	) (*dto.RoleConnection, error)
+
+	// ListUserProfiles returns a page of user profiles
+	ListUserProfiles(
+		ctx context.Context,
+		pagination *firebasetools.PaginationInput,
+		filter *firebasetools.FilterInput,
+		sort *firebasetools.SortInput,
+	) (*dto.UserProfileConnection, error)
+
 	FindRoleByName(ctx context.Context, roleName *string) ([]*dto.RoleOutput, error)
 
 	GetAllPermissions(ctx context.Context) ([]*profileutils.Permission, error)
@@ -280,6 +298,91 @@ func (r *RoleUseCaseImpl) GetAllRoles(ctx context.Context) ([]*dto.RoleOutput, e
 	return roleOutput, nil
 }
 
+// ListRoles returns a page of roles
+func (r *RoleUseCaseImpl) ListRoles(
+	ctx context.Context,
+	pagination *firebasetools.PaginationInput,
+	filter *firebasetools.FilterInput,
+	sort *firebasetools.SortInput,
+) (*dto.RoleConnection, error) {
+	ctx, span := tracer.Start(ctx, "ListRoles")
+	defer span.End()
+
+	if err := r.checkLoggedInUserCanViewRoles(ctx); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	query, err := utils.NewRoleListingQuery(pagination, filter, sort)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	roles, err := r.infrastructure.Database.ListRolesPage(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	connection, err := utils.NewRoleConnection(ctx, query, roles)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	return connection, nil
+}
+
+// ListUserProfiles returns a page of user profiles
+func (r *RoleUseCaseImpl) ListUserProfiles(
+	ctx context.Context,
+	pagination *firebasetools.PaginationInput,
+	filter *firebasetools.FilterInput,
+	sort *firebasetools.SortInput,
+) (*dto.UserProfileConnection, error) {
+	ctx, span := tracer.Start(ctx, "ListUserProfiles")
+	defer span.End()
+
+	if err := r.checkLoggedInUserCanViewRoles(ctx); err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	query, err := utils.NewUserProfileListingQuery(pagination, filter, sort)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	profiles, err := r.infrastructure.Database.ListUserProfilesPage(ctx, query)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	return utils.NewUserProfileConnection(query, profiles), nil
+}
+
+// checkLoggedInUserCanViewRoles checks that the logged in user can view roles and the users
+// they have been assigned to
+func (r *RoleUseCaseImpl) checkLoggedInUserCanViewRoles(ctx context.Context) error {
+	user, err := r.baseExt.GetLoggedInUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := r.infrastructure.Database.CheckIfUserHasPermission(ctx, user.UID, profileutils.CanViewRole)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return exceptions.RoleNotValid(
+			fmt.Errorf("error: logged in user does not have permissions to list roles"),
+		)
+	}
+	return nil
+}
+
 // FindRoleByName returns a list of roles filtered by name
 func (r *RoleUseCaseImpl) FindRoleByName(
 	ctx context.Context,
@@ -590,6 +693,13 @@ func (r *RoleUseCaseImpl) AssignRole(
 		return false, err
 	}
 
+	// the roles are only updated if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
 	for _, r := range profile.Roles {
 		// check if role exists first
 		if r == role.ID {
@@ -648,6 +758,13 @@ func (r *RoleUseCaseImpl) RevokeRole(
 		return false, err
 	}
 
+	// the roles are only updated if the profile has not been changed since it was read
+	ctx, err = utils.WithExpectedProfileVersion(ctx, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
 	var exist bool
 	for _, r := range profile.Roles {
 		// check if role exists first
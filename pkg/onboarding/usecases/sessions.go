@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// SessionUseCases represents the business logic involved in listing the devices that a user is
+// signed in on and signing them out remotely. Admins can do the same for any profile
+type SessionUseCases interface {
+	ListSessions(ctx context.Context) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, sessionID string) (bool, error)
+	RevokeAllSessions(ctx context.Context) (bool, error)
+
+	ListProfileSessions(ctx context.Context, profileID string) ([]*domain.Session, error)
+	RevokeProfileSession(ctx context.Context, profileID string, sessionID string) (bool, error)
+	RevokeAllProfileSessions(ctx context.Context, profileID string) (bool, error)
+}
+
+// SessionUseCasesImpl represents the usecase implementation object
+type SessionUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewSessionUseCases initializes a new session usecase
+func NewSessionUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *SessionUseCasesImpl {
+	return &SessionUseCasesImpl{infrastructure, ext}
+}
+
+// ListSessions returns the devices that the logged in user is signed in on, most recently seen first
+func (s *SessionUseCasesImpl) ListSessions(ctx context.Context) ([]*domain.Session, error) {
+	ctx, span := tracer.Start(ctx, "ListSessions")
+	defer span.End()
+
+	profile, err := s.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	sessions, err := s.infrastructure.Database.ListSessions(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs the logged in user out of one of their devices
+func (s *SessionUseCasesImpl) RevokeSession(ctx context.Context, sessionID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeSession")
+	defer span.End()
+
+	profile, err := s.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := s.revokeSession(ctx, profile, sessionID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// RevokeAllSessions signs the logged in user out of all their devices, including the current one
+func (s *SessionUseCasesImpl) RevokeAllSessions(ctx context.Context) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeAllSessions")
+	defer span.End()
+
+	profile, err := s.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := revokeAllSessions(ctx, s.infrastructure, profile); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// ListProfileSessions returns the devices that a user is signed in on. It is restricted to admins
+func (s *SessionUseCasesImpl) ListProfileSessions(
+	ctx context.Context,
+	profileID string,
+) ([]*domain.Session, error) {
+	ctx, span := tracer.Start(ctx, "ListProfileSessions")
+	defer span.End()
+
+	profile, err := s.adminManagedProfile(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	sessions, err := s.infrastructure.Database.ListSessions(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeProfileSession signs a user out of one of their devices. It is restricted to admins
+func (s *SessionUseCasesImpl) RevokeProfileSession(
+	ctx context.Context,
+	profileID string,
+	sessionID string,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeProfileSession")
+	defer span.End()
+
+	profile, err := s.adminManagedProfile(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := s.revokeSession(ctx, profile, sessionID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// RevokeAllProfileSessions signs a user out of all their devices. It is restricted to admins
+func (s *SessionUseCasesImpl) RevokeAllProfileSessions(ctx context.Context, profileID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeAllProfileSessions")
+	defer span.End()
+
+	profile, err := s.adminManagedProfile(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := revokeAllSessions(ctx, s.infrastructure, profile); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *SessionUseCasesImpl) loggedInProfile(ctx context.Context) (*profileutils.UserProfile, error) {
+	uid, err := s.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.UserNotFoundError(err)
+	}
+	// this is a wrapped error. No need to wrap it again
+	return s.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+}
+
+func (s *SessionUseCasesImpl) adminManagedProfile(
+	ctx context.Context,
+	profileID string,
+) (*profileutils.UserProfile, error) {
+	if err := checkLoggedInUserIsAdmin(ctx, s.infrastructure, s.baseExt); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	// this is a wrapped error. No need to wrap it again
+	return s.infrastructure.Database.GetUserProfileByID(ctx, profileID, false)
+}
+
+// revokeSession marks a single session as revoked so that its refresh token is refused.
+// Firebase can only revoke all the refresh tokens of a user, so the ID token that the device
+// already holds stays valid until it expires
+func (s *SessionUseCasesImpl) revokeSession(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	sessionID string,
+) error {
+	sessions, err := s.infrastructure.Database.ListSessions(ctx, profile.ID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	found := false
+	for _, session := range sessions {
+		found = found || session.ID == sessionID
+	}
+	if !found {
+		return exceptions.RecordDoesNotExistError(fmt.Errorf("session %s not found", sessionID))
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	// this is a wrapped error. No need to wrap it again
+	return s.infrastructure.Database.RevokeSessions(ctx, profile.ID, []string{sessionID}, now)
+}
+
+// revokeAllSessions marks all the sessions of a profile as revoked and revokes the refresh tokens
+// of every auth user that the profile signs in with
+func revokeAllSessions(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profile *profileutils.UserProfile,
+) error {
+	sessions, err := infra.Database.ListSessions(ctx, profile.ID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if err := infra.Database.RevokeSessions(ctx, profile.ID, nil, now); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	uids := []string{}
+	seen := map[string]bool{}
+	for _, uid := range profile.VerifiedUIDS {
+		if !seen[uid] {
+			seen[uid] = true
+			uids = append(uids, uid)
+		}
+	}
+	for _, session := range sessions {
+		if session.UID != "" && !seen[session.UID] {
+			seen[session.UID] = true
+			uids = append(uids, session.UID)
+		}
+	}
+	for _, uid := range uids {
+		if err := infra.Database.RevokeRefreshTokens(ctx, uid); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+func setupFakeSessions() {
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{
+			{ID: "session-1", ProfileID: profileID, UID: "uid-1"},
+			{ID: "session-2", ProfileID: profileID, UID: "uid-2"},
+		}, nil
+	}
+}
+
+func TestSessionUseCasesImpl_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		wantErr   bool
+	}{
+		{
+			name:      "happy: sign out of a device",
+			sessionID: "session-2",
+			wantErr:   false,
+		},
+		{
+			name:      "sad: the session belongs to another profile",
+			sessionID: "session-3",
+			wantErr:   true,
+		},
+		{
+			name:      "sad: unable to revoke the session",
+			sessionID: "session-1",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(false)
+			setupFakeSessions()
+			fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+				if tt.name == "sad: unable to revoke the session" {
+					return fmt.Errorf("unable to revoke the session")
+				}
+				if profileID != "profile-1" || len(sessionIDs) != 1 || sessionIDs[0] != tt.sessionID {
+					return fmt.Errorf("unexpected sessions revoked: %v", sessionIDs)
+				}
+				return nil
+			}
+			fakeInfraRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+				return fmt.Errorf("the refresh tokens of the user should not be revoked")
+			}
+
+			revoked, err := i.RevokeSession(ctx, tt.sessionID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !revoked {
+				t.Errorf("expected the session to be revoked")
+			}
+		})
+	}
+}
+
+func TestSessionUseCasesImpl_RevokeAllSessions(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "happy: sign out of all devices",
+			wantErr: false,
+		},
+		{
+			name:    "sad: unable to revoke the refresh tokens",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(false)
+			setupFakeSessions()
+			fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: "profile-1", VerifiedUIDS: []string{"uid-1", "uid-3"}}, nil
+			}
+			fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+				if len(sessionIDs) != 0 {
+					return fmt.Errorf("expected all the sessions to be revoked")
+				}
+				return nil
+			}
+			uids := []string{}
+			fakeInfraRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+				if tt.name == "sad: unable to revoke the refresh tokens" {
+					return fmt.Errorf("unable to revoke the refresh tokens")
+				}
+				uids = append(uids, uid)
+				return nil
+			}
+
+			revoked, err := i.RevokeAllSessions(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RevokeAllSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				sort.Strings(uids)
+				if !revoked || fmt.Sprint(uids) != "[uid-1 uid-2 uid-3]" {
+					t.Errorf("expected the refresh tokens of every UID to be revoked once, got %v", uids)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionUseCasesImpl_RevokeProfileSession(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+		wantErr bool
+	}{
+		{
+			name:    "happy: an admin signs a user out of a device",
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			setupFakeSessions()
+			fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+				return &profileutils.UserProfile{ID: id}, nil
+			}
+			fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+				if profileID != "profile-2" {
+					return fmt.Errorf("the session of the wrong profile was revoked")
+				}
+				return nil
+			}
+
+			revoked, err := i.RevokeProfileSession(ctx, "profile-2", "session-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RevokeProfileSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !revoked {
+				t.Errorf("expected the session to be revoked")
+			}
+		})
+	}
+}
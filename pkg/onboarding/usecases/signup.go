@@ -16,6 +16,7 @@ import (
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/pubsubtools"
 	"github.com/savannahghi/scalarutils"
+	"github.com/sirupsen/logrus"
 )
 
 // SignUpUseCases represents all the business logic involved in setting up a user
@@ -27,6 +28,18 @@ type SignUpUseCases interface {
 	// NUMBER
 	CreateUserByPhone(ctx context.Context, input *dto.SignUpInput) (*profileutils.UserResponse, error)
 
+	// creates an account for the logged in anonymous user, setting the provided phone number as
+	// the PRIMARY PHONE NUMBER. The account keeps the UID of the anonymous user
+	UpgradeAnonymousUser(ctx context.Context, input *dto.SignUpInput) (*profileutils.UserResponse, error)
+
+	// creates an account for the user, setting the provided email address as the PRIMARY EMAIL
+	// ADDRESS that they log in with together with a password
+	SignUpByEmail(ctx context.Context, input *dto.EmailSignUpInput) (*profileutils.UserResponse, error)
+
+	// creates an account for the user with an ID token of a social login provider, setting the
+	// email address of their account with the provider as the PRIMARY EMAIL ADDRESS
+	SignUpBySocial(ctx context.Context, input *dto.SocialSignUpInput) (*profileutils.UserResponse, error)
+
 	// updates the user profile of the currently logged in user
 	UpdateUserProfile(
 		ctx context.Context,
@@ -63,6 +76,7 @@ type SignUpUseCasesImpl struct {
 	infrastructure infrastructure.Infrastructure
 	profileUsecase ProfileUseCase
 	pinUsecase     UserPINUseCases
+	passwords      PasswordUseCases
 	baseExt        extension.BaseExtension
 }
 
@@ -71,12 +85,14 @@ func NewSignUpUseCases(
 	infrastructure infrastructure.Infrastructure,
 	profile ProfileUseCase,
 	pin UserPINUseCases,
+	passwords PasswordUseCases,
 	ext extension.BaseExtension,
 ) SignUpUseCases {
 	return &SignUpUseCasesImpl{
 		infrastructure: infrastructure,
 		profileUsecase: profile,
 		pinUsecase:     pin,
+		passwords:      passwords,
 		baseExt:        ext,
 	}
 }
@@ -130,6 +146,69 @@ func (s *SignUpUseCasesImpl) CreateUserByPhone(
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
+	resp, err := s.createPhoneUserAccount(ctx, userData, "")
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpgradeAnonymousUser creates an account for the logged in anonymous user, setting the provided
+// phone number as the PRIMARY PHONE NUMBER. The phone number and PIN are added to the anonymous
+// user so that the UID it signed in with does not change, and a `user.upgraded` event is
+// published for the data that was kept against the UID
+func (s *SignUpUseCasesImpl) UpgradeAnonymousUser(
+	ctx context.Context,
+	input *dto.SignUpInput,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UpgradeAnonymousUser")
+	defer span.End()
+
+	uid, err := s.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+	if !utils.IsAnonymousUID(uid) {
+		return nil, exceptions.NotAnonymousUserError()
+	}
+	// an anonymous user that already has an account logs in with it instead
+	_, err = s.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+	if err == nil {
+		return nil, exceptions.NotAnonymousUserError()
+	}
+	if !exceptions.IsProfileNotFoundError(err) {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	userData, err := utils.ValidateSignUpInput(input)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	resp, err := s.createPhoneUserAccount(ctx, userData, uid)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return resp, nil
+}
+
+// createPhoneUserAccount verifies the phone number of a validated signup and creates the account.
+// The account is created for the anonymous user with the provided UID, if any
+func (s *SignUpUseCasesImpl) createPhoneUserAccount(
+	ctx context.Context,
+	userData *dto.SignUpInput,
+	anonymousUID string,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "createPhoneUserAccount")
+	defer span.End()
+
 	verified, err := s.infrastructure.Engagement.VerifyOTP(
 		ctx,
 		*userData.PhoneNumber,
@@ -144,77 +223,260 @@ func (s *SignUpUseCasesImpl) CreateUserByPhone(
 		return nil, exceptions.VerifyOTPError(nil)
 	}
 
-	// get or create user via their phone number
-	user, err := s.infrastructure.Database.GetOrCreatePhoneNumberUser(ctx, *userData.PhoneNumber)
+	// the PIN is validated before any of the user's records is created
+	pin, err := s.pinUsecase.NewUserPIN(ctx, *userData.PIN)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
 
-	// create a user profile
-	profile, err := s.infrastructure.Database.CreateUserProfile(
+	// create the user, their profile, PIN and default communications settings as a single unit
+	defaultCommunicationSetting := true
+	account, err := s.infrastructure.Database.CreateUserAccount(
 		ctx,
 		*userData.PhoneNumber,
-		user.UID,
+		&domain.UserAccount{
+			AnonymousUID: anonymousUID,
+			PIN:          pin,
+			CommunicationsSettings: &profileutils.UserCommunicationsSetting{
+				AllowWhatsApp: defaultCommunicationSetting,
+				AllowTextSMS:  defaultCommunicationSetting,
+				AllowPush:     defaultCommunicationSetting,
+				AllowEmail:    defaultCommunicationSetting,
+			},
+		},
 	)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return nil, exceptions.InternalServerError(err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
 	}
+	profile := account.Profile
+
 	// generate auth credentials
 	auth, err := s.infrastructure.Database.GenerateAuthCredentials(
 		ctx,
 		*userData.PhoneNumber,
 		profile,
 	)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	// get navigation actions
+	roles, err := s.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
+	if err != nil {
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	navActions, err := utils.GetUserNavigationActions(ctx, *profile, *roles)
+	if err != nil {
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	return &profileutils.UserResponse{
+		Profile:               profile,
+		CommunicationSettings: account.CommunicationsSettings,
+		Auth:                  *auth,
+		NavActions:            utils.NewActionsMapper(ctx, navActions),
+	}, nil
+}
+
+// SignUpByEmail creates an account for the user, setting the provided email address as the
+// PRIMARY EMAIL ADDRESS. The user logs in with the email address and password. The email address
+// is verified with the OTP that was sent to it
+func (s *SignUpUseCasesImpl) SignUpByEmail(
+	ctx context.Context,
+	input *dto.EmailSignUpInput,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "SignUpByEmail")
+	defer span.End()
+
+	userData, err := utils.ValidateEmailSignUpInput(input)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
-	// save the user pin
-	_, err = s.pinUsecase.SetUserPIN(
+	verified, err := s.infrastructure.Engagement.VerifyEmailOTP(
 		ctx,
-		*userData.PIN,
-		profile.ID,
+		*userData.EmailAddress,
+		*userData.OTP,
 	)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.VerifyOTPError(err)
+	}
+
+	if !verified {
+		return nil, exceptions.VerifyOTPError(nil)
+	}
+
+	// the password is validated before any of the user's records is created
+	password, err := s.passwords.NewUserPassword(ctx, *userData.EmailAddress, *userData.Password)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
-	// set the user default communications settings
+
+	// create the user, their profile, password and default communications settings as a single unit
 	defaultCommunicationSetting := true
-	comms, err := s.infrastructure.Database.SetUserCommunicationsSettings(
+	account, err := s.infrastructure.Database.CreateEmailUserAccount(
 		ctx,
-		profile.ID,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
+		*userData.EmailAddress,
+		&domain.UserAccount{
+			Password: password,
+			CommunicationsSettings: &profileutils.UserCommunicationsSetting{
+				AllowWhatsApp: defaultCommunicationSetting,
+				AllowTextSMS:  defaultCommunicationSetting,
+				AllowPush:     defaultCommunicationSetting,
+				AllowEmail:    defaultCommunicationSetting,
+			},
+		},
 	)
 	if err != nil {
 		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	profile := account.Profile
+
+	// generate auth credentials
+	auth, err := s.infrastructure.Database.GenerateAuthCredentials(
+		ctx,
+		*userData.EmailAddress,
+		profile,
+	)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		s.rollbackUserAccount(ctx, account)
 		return nil, err
 	}
 
 	// get navigation actions
 	roles, err := s.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
 	if err != nil {
+		s.rollbackUserAccount(ctx, account)
 		return nil, err
 	}
 
 	navActions, err := utils.GetUserNavigationActions(ctx, *profile, *roles)
 	if err != nil {
+		s.rollbackUserAccount(ctx, account)
 		return nil, err
 	}
 
 	return &profileutils.UserResponse{
 		Profile:               profile,
-		CommunicationSettings: comms,
+		CommunicationSettings: account.CommunicationsSettings,
 		Auth:                  *auth,
 		NavActions:            utils.NewActionsMapper(ctx, navActions),
 	}, nil
 }
 
+// SignUpBySocial creates an account for a user with an ID token that a social login provider issued
+// to them. The provider must have verified the email address of the user's account with it, which
+// becomes their primary email address. The account with the provider is linked to the new profile
+// so that the user logs in with it
+func (s *SignUpUseCasesImpl) SignUpBySocial(
+	ctx context.Context,
+	input *dto.SocialSignUpInput,
+) (*profileutils.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "SignUpBySocial")
+	defer span.End()
+
+	userData, err := utils.ValidateSocialSignUpInput(input)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	identity, err := s.infrastructure.Social.VerifyIDToken(ctx, userData.Provider, *userData.IDToken)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if identity.EmailAddress == "" || !identity.EmailVerified {
+		return nil, exceptions.SocialEmailRequiredError()
+	}
+	uid := utils.SocialUID(identity.Provider, identity.Subject)
+
+	// an account with the provider that is linked to a profile logs in instead
+	owner, err := socialAccountProfile(ctx, s.infrastructure, uid)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if owner != nil {
+		return nil, exceptions.SocialAccountInUseError()
+	}
+
+	// create the user, their profile and default communications settings as a single unit
+	defaultCommunicationSetting := true
+	account, err := s.infrastructure.Database.CreateEmailUserAccount(
+		ctx,
+		utils.NormalizeEmailAddress(identity.EmailAddress),
+		&domain.UserAccount{
+			CommunicationsSettings: &profileutils.UserCommunicationsSetting{
+				AllowWhatsApp: defaultCommunicationSetting,
+				AllowTextSMS:  defaultCommunicationSetting,
+				AllowPush:     defaultCommunicationSetting,
+				AllowEmail:    defaultCommunicationSetting,
+			},
+		},
+	)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	profile := account.Profile
+
+	// generating the auth credentials of the social login UID links it to the profile
+	auth, err := s.infrastructure.Database.GenerateAuthCredentials(ctx, uid, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	// get navigation actions
+	roles, err := s.infrastructure.Database.GetRolesByIDs(ctx, profile.Roles)
+	if err != nil {
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	navActions, err := utils.GetUserNavigationActions(ctx, *profile, *roles)
+	if err != nil {
+		s.rollbackUserAccount(ctx, account)
+		return nil, err
+	}
+
+	return &profileutils.UserResponse{
+		Profile:               profile,
+		CommunicationSettings: account.CommunicationsSettings,
+		Auth:                  *auth,
+		NavActions:            utils.NewActionsMapper(ctx, navActions),
+	}, nil
+}
+
+// rollbackUserAccount removes an account whose signup could not be completed.
+// The caller returns the error that stopped the signup, so a failed rollback is only logged
+func (s *SignUpUseCasesImpl) rollbackUserAccount(ctx context.Context, account *domain.UserAccount) {
+	ctx, span := tracer.Start(ctx, "rollbackUserAccount")
+	defer span.End()
+
+	if err := s.infrastructure.Database.DeleteUserAccount(ctx, account); err != nil {
+		utils.RecordSpanError(span, err)
+		logrus.Errorf("unable to roll back the account of user %s: %v", account.UID, err)
+	}
+}
+
 // UpdateUserProfile  updates the user profile of the currently logged in user
 func (s *SignUpUseCasesImpl) UpdateUserProfile(
 	ctx context.Context,
@@ -427,33 +689,33 @@ func (s *SignUpUseCasesImpl) RegisterUser(ctx context.Context, input dto.Registe
 		Roles:       input.RoleIDs,
 	}
 
-	createdProfile, err := s.infrastructure.Database.CreateDetailedUserProfile(ctx, *phoneNumber, userProfile)
+	pin, otp, err := s.pinUsecase.NewUserTempPIN(ctx)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
 
-	// set the user default communications settings
+	// create the user, their profile, temporary PIN and default communications settings as a single unit
 	defaultCommunicationSetting := true
-	_, err = s.infrastructure.Database.SetUserCommunicationsSettings(
+	account, err := s.infrastructure.Database.CreateUserAccount(
 		ctx,
-		createdProfile.ID,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
-		&defaultCommunicationSetting,
+		*phoneNumber,
+		&domain.UserAccount{
+			Profile: &userProfile,
+			PIN:     pin,
+			CommunicationsSettings: &profileutils.UserCommunicationsSetting{
+				AllowWhatsApp: defaultCommunicationSetting,
+				AllowTextSMS:  defaultCommunicationSetting,
+				AllowPush:     defaultCommunicationSetting,
+				AllowEmail:    defaultCommunicationSetting,
+			},
+		},
 	)
 	if err != nil {
 		utils.RecordSpanError(span, err)
 		return nil, err
 	}
 
-	otp, err := s.pinUsecase.SetUserTempPIN(ctx, createdProfile.ID)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return nil, err
-	}
-
 	message := input.WelcomeMessage
 	if message == nil {
 		message = &domain.WelcomeMessage
@@ -462,8 +724,10 @@ func (s *SignUpUseCasesImpl) RegisterUser(ctx context.Context, input dto.Registe
 	formartedMessage := fmt.Sprintf(*message, *input.FirstName, otp)
 
 	if err := s.infrastructure.Engagement.SendSMS(ctx, []string{*phoneNumber}, formartedMessage); err != nil {
+		// the user can not sign in without the temporary PIN
+		s.rollbackUserAccount(ctx, account)
 		return nil, fmt.Errorf("unable to send consumer registration message: %w", err)
 	}
 
-	return createdProfile, nil
+	return account.Profile, nil
 }
@@ -11,10 +11,13 @@ import (
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
 	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
 	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSignUpUseCasesImpl_RetirePushToken(t *testing.T) {
@@ -224,7 +227,7 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "invalid:fail_to_create_user_profile",
+			name: "invalid:fail_to_create_user_account",
 			args: args{
 				ctx:   ctx,
 				input: validSignUpInput,
@@ -240,7 +243,7 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "invalid:fail_to_set_userPin",
+			name: "invalid:fail_to_roll_back_user_account",
 			args: args{
 				ctx:   ctx,
 				input: validSignUpInput,
@@ -250,26 +253,24 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			rolledBack := false
 
 			if tt.name == "valid:successfully_create_user_by_phone" {
 				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
 
-				fakeInfraRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-					return &dto.CreatedUserResponse{
-						UID:         "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-						DisplayName: "John Doe",
-						Email:       "johndoe@gmail.com",
-						PhoneNumber: phoneNumber,
-					}, nil
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "password"
 				}
 
-				fakeInfraRepo.CreateUserProfileFn = func(ctx context.Context, phoneNumber, uid string) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.UID = "5cf354a2-1d3e-400d-8716-7e2aead29f2c"
+					account.Profile = &profileutils.UserProfile{
 						ID:           "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
 						PrimaryPhone: &phone,
-					}, nil
+					}
+					return account, nil
 				}
 
 				fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
@@ -283,38 +284,6 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 					}, nil
 				}
 
-				// Mock SetUserPin begins here
-				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
-					phone := "+254777886622"
-					return &phone, nil
-				}
-
-				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           "123",
-						PrimaryPhone: &phoneNumber,
-					}, nil
-				}
-
-				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
-					return "salt", "password"
-				}
-
-				fakeInfraRepo.SavePINFn = func(ctx context.Context, pin *domain.PIN) (bool, error) {
-					return true, nil
-				}
-				// Finished mocking SetUserPin
-				fakeInfraRepo.SetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string,
-					allowWhatsApp *bool, allowTextSms *bool, allowPush *bool, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-					return &profileutils.UserCommunicationsSetting{
-						ID:            uuid.New().String(),
-						AllowWhatsApp: true,
-						AllowTextSMS:  true,
-						AllowEmail:    true,
-						AllowPush:     true,
-					}, nil
-				}
-
 				fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
 					roles := []profileutils.Role{}
 					return &roles, nil
@@ -333,32 +302,17 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 				}
 			}
 
-			if tt.name == "invalid:fail_to_check_ifPhoneNumberExists" {
-				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
-					return true, nil
-				}
-
-				fakeInfraRepo.CheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string) (bool, error) {
-					return true, nil
-				}
-			}
-
-			if tt.name == "invalid:fail_to_create_user_profile" {
+			if tt.name == "invalid:fail_to_create_user_account" {
 				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
 
-				fakeInfraRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-					return &dto.CreatedUserResponse{
-						UID:         "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-						DisplayName: "John Doe",
-						Email:       "johndoe@gmail.com",
-						PhoneNumber: phoneNumber,
-					}, nil
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "password"
 				}
 
-				fakeInfraRepo.CreateUserProfileFn = func(ctx context.Context, phoneNumber, uid string) (*profileutils.UserProfile, error) {
-					return nil, fmt.Errorf("fail to create user profile")
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					return nil, fmt.Errorf("fail to create user account")
 				}
 			}
 
@@ -367,77 +321,50 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 					return true, nil
 				}
 
-				fakeInfraRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-					return &dto.CreatedUserResponse{
-						UID:         "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-						DisplayName: "John Doe",
-						Email:       "johndoe@gmail.com",
-						PhoneNumber: phoneNumber,
-					}, nil
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "password"
 				}
 
-				fakeInfraRepo.CreateUserProfileFn = func(ctx context.Context, phoneNumber, uid string) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.Profile = &profileutils.UserProfile{
 						ID: "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-					}, nil
+					}
+					return account, nil
 				}
 
 				fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
 					return nil, fmt.Errorf("failed to generate auth credentials")
 				}
+
+				fakeInfraRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+					rolledBack = true
+					return nil
+				}
 			}
 
-			if tt.name == "invalid:fail_to_set_userPin" {
+			if tt.name == "invalid:fail_to_roll_back_user_account" {
 				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
 					return true, nil
 				}
 
-				fakeInfraRepo.GetOrCreatePhoneNumberUserFn = func(ctx context.Context, phone string) (*dto.CreatedUserResponse, error) {
-					return &dto.CreatedUserResponse{
-						UID:         "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-						DisplayName: "John Doe",
-						Email:       "johndoe@gmail.com",
-						PhoneNumber: phoneNumber,
-					}, nil
+				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+					return "salt", "password"
 				}
 
-				fakeInfraRepo.CreateUserProfileFn = func(ctx context.Context, phoneNumber, uid string) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
-						PrimaryPhone: &phone,
-					}, nil
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.Profile = &profileutils.UserProfile{
+						ID: "5cf354a2-1d3e-400d-8716-7e2aead29f2c",
+					}
+					return account, nil
 				}
 
 				fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
-					customToken := uuid.New().String()
-					idToken := uuid.New().String()
-					refreshToken := uuid.New().String()
-					return &profileutils.AuthCredentialResponse{
-						CustomToken:  &customToken,
-						IDToken:      &idToken,
-						RefreshToken: refreshToken,
-					}, nil
-				}
-
-				// Mock SetUserPin begins here
-				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
-					phone := "+254777886622"
-					return &phone, nil
-				}
-
-				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           "123",
-						PrimaryPhone: &phoneNumber,
-					}, nil
-				}
-
-				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
-					return "salt", "password"
+					return nil, fmt.Errorf("failed to generate auth credentials")
 				}
 
-				fakeInfraRepo.SavePINFn = func(ctx context.Context, pin *domain.PIN) (bool, error) {
-					return false, fmt.Errorf("failed to save user pin")
+				fakeInfraRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+					rolledBack = true
+					return fmt.Errorf("failed to delete user account")
 				}
 			}
 
@@ -451,6 +378,10 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 				return
 			}
 
+			if tt.name == "invalid:fail_to_generate_auth_credentials" && !rolledBack {
+				t.Errorf("expected the user account to be rolled back")
+			}
+
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("error expected got %v", err)
@@ -467,6 +398,76 @@ func TestSignUpUseCasesImpl_CreateUserByPhone(t *testing.T) {
 	}
 }
 
+func TestSignUpUseCasesImpl_UpgradeAnonymousUser(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	phoneNumber := "+254777886622"
+	pin := "1234"
+	otp := "678251"
+	input := &dto.SignUpInput{
+		PhoneNumber: &phoneNumber,
+		PIN:         &pin,
+		Flavour:     feedlib.FlavourConsumer,
+		OTP:         &otp,
+	}
+
+	anonymousUID := utils.AnonymousUID()
+	loggedInUID := anonymousUID
+	fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+		return loggedInUID, nil
+	}
+	var upgraded *profileutils.UserProfile
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		if upgraded != nil && upgraded.VerifiedUIDS[0] == uid {
+			return upgraded, nil
+		}
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+	fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
+		return true, nil
+	}
+	fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+		return "salt", "password"
+	}
+	fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+		account.UID = account.AnonymousUID
+		account.Profile = &profileutils.UserProfile{
+			ID:           "profile-1",
+			PrimaryPhone: &phoneNumber,
+			VerifiedUIDS: []string{account.AnonymousUID},
+		}
+		return account, nil
+	}
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: profile.VerifiedUIDS[0], RefreshToken: "refresh-token-1"}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{}, nil
+	}
+
+	resp, err := i.UpgradeAnonymousUser(ctx, input)
+	if err != nil {
+		t.Fatalf("error not expected got %v", err)
+	}
+	assert.Equal(t, anonymousUID, resp.Auth.UID)
+	assert.Equal(t, "profile-1", resp.Profile.ID)
+
+	// an anonymous user is only upgraded once
+	upgraded = resp.Profile
+	_, err = i.UpgradeAnonymousUser(ctx, input)
+	assert.Equal(t, exceptions.NotAnonymousUser, errorCode(err))
+
+	// a user that is not anonymous signs up instead
+	loggedInUID = "uid-1"
+	_, err = i.UpgradeAnonymousUser(ctx, input)
+	assert.Equal(t, exceptions.NotAnonymousUser, errorCode(err))
+}
+
 func TestSignUpUseCasesImpl_VerifyPhoneNumber(t *testing.T) {
 	ctx := context.Background()
 	i, err := InitializeFakeOnboardingInteractor()
@@ -791,11 +792,7 @@ func TestSignUpUseCasesImpl_SetPhoneAsPrimary(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
-					return nil
-				}
-
-				fakeInfraRepo.UpdateSecondaryPhoneNumbersFn = func(ctx context.Context, id string, phoneNumbers []string) error {
+				fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return nil
 				}
 			}
@@ -833,7 +830,7 @@ func TestSignUpUseCasesImpl_SetPhoneAsPrimary(t *testing.T) {
 					}, nil
 				}
 
-				fakeInfraRepo.UpdatePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
+				fakeInfraRepo.ReplacePrimaryPhoneNumberFn = func(ctx context.Context, id string, phoneNumber string) error {
 					return fmt.Errorf("failed to update primary phone")
 				}
 			}
@@ -1570,16 +1567,7 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad: unable to create user profile",
-			args: args{
-				ctx:   ctx,
-				input: input,
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name: "sad: unable to create communication settings",
+			name: "sad: unable to create otp",
 			args: args{
 				ctx:   ctx,
 				input: input,
@@ -1588,7 +1576,7 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad: unable to create otp",
+			name: "sad: unable to create user account",
 			args: args{
 				ctx:   ctx,
 				input: input,
@@ -1617,6 +1605,8 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			rolledBack := false
+
 			if tt.name == "sad: unable to get logged in user" {
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
 					return "", fmt.Errorf("unable to get logged in user")
@@ -1635,21 +1625,7 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 				}
 			}
 
-			if tt.name == "sad: unable to create user profile" {
-				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
-					return uuid.NewString(), nil
-				}
-				fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{ID: uuid.NewString()}, nil
-				}
-				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
-					return &phoneNumber, nil
-				}
-				fakeInfraRepo.CreateDetailedUserProfileFn = func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error) {
-					return nil, fmt.Errorf("unable to create user profile")
-				}
-			}
-			if tt.name == "sad: unable to create communication settings" {
+			if tt.name == "sad: unable to create otp" {
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
 					return uuid.NewString(), nil
 				}
@@ -1659,18 +1635,12 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					return &phoneNumber, nil
 				}
-				fakeInfraRepo.CreateDetailedUserProfileFn = func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           uuid.NewString(),
-						PrimaryPhone: &phoneNumber,
-					}, nil
-				}
-				fakeInfraRepo.SetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string, allowWhatsApp, allowTextSms, allowPush, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-					return nil, fmt.Errorf("unable to create communication settings")
+				fakePinExt.GenerateTempPINFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("unable to create otp")
 				}
 			}
 
-			if tt.name == "sad: unable to create otp" {
+			if tt.name == "sad: unable to create user account" {
 				fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
 					return uuid.NewString(), nil
 				}
@@ -1680,23 +1650,14 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					return &phoneNumber, nil
 				}
-				fakeInfraRepo.CreateDetailedUserProfileFn = func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           uuid.NewString(),
-						PrimaryPhone: &phoneNumber,
-					}, nil
-				}
-				fakeInfraRepo.SetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string, allowWhatsApp, allowTextSms, allowPush, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-					return &profileutils.UserCommunicationsSetting{}, nil
-				}
 				fakePinExt.GenerateTempPINFn = func(ctx context.Context) (string, error) {
 					return "123", nil
 				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "pin", "sha"
 				}
-				fakeInfraRepo.SavePINFn = func(ctx context.Context, pin *domain.PIN) (bool, error) {
-					return false, fmt.Errorf("unable to create otp")
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					return nil, fmt.Errorf("unable to create user account")
 				}
 			}
 
@@ -1710,27 +1671,24 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					return &phoneNumber, nil
 				}
-				fakeInfraRepo.CreateDetailedUserProfileFn = func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID:           uuid.NewString(),
-						PrimaryPhone: &phoneNumber,
-					}, nil
-				}
-				fakeInfraRepo.SetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string, allowWhatsApp, allowTextSms, allowPush, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-					return &profileutils.UserCommunicationsSetting{}, nil
-				}
 				fakePinExt.GenerateTempPINFn = func(ctx context.Context) (string, error) {
 					return "123", nil
 				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "pin", "sha"
 				}
-				fakeInfraRepo.SavePINFn = func(ctx context.Context, pin *domain.PIN) (bool, error) {
-					return true, nil
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.Profile.ID = uuid.NewString()
+					account.Profile.PrimaryPhone = &phoneNumber
+					return account, nil
 				}
 				fakeEngagementSvs.SendSMSFn = func(ctx context.Context, phoneNumbers []string, message string) error {
 					return fmt.Errorf("unable to send otp sms")
 				}
+				fakeInfraRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+					rolledBack = true
+					return nil
+				}
 			}
 
 			if tt.name == "happy: registered consumer" {
@@ -1744,28 +1702,16 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					return &phoneNumber, nil
 				}
-				fakeInfraRepo.CreateDetailedUserProfileFn = func(ctx context.Context, phoneNumber string, profile profileutils.UserProfile) (*profileutils.UserProfile, error) {
-					return &profileutils.UserProfile{
-						ID: uuid.NewString(),
-						UserBioData: profileutils.BioData{
-							FirstName: &fName,
-							LastName:  &lName,
-						},
-						PrimaryPhone:        &phoneNumber,
-						PrimaryEmailAddress: &email,
-					}, nil
-				}
-				fakeInfraRepo.SetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string, allowWhatsApp, allowTextSms, allowPush, allowEmail *bool) (*profileutils.UserCommunicationsSetting, error) {
-					return &profileutils.UserCommunicationsSetting{}, nil
-				}
 				fakePinExt.GenerateTempPINFn = func(ctx context.Context) (string, error) {
 					return "123", nil
 				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "pin", "sha"
 				}
-				fakeInfraRepo.SavePINFn = func(ctx context.Context, pin *domain.PIN) (bool, error) {
-					return true, nil
+				fakeInfraRepo.CreateUserAccountFn = func(ctx context.Context, phoneNumber string, account *domain.UserAccount) (*domain.UserAccount, error) {
+					account.Profile.ID = uuid.NewString()
+					account.Profile.PrimaryPhone = &phoneNumber
+					return account, nil
 				}
 				fakeEngagementSvs.SendSMSFn = func(ctx context.Context, phoneNumbers []string, message string) error {
 					return nil
@@ -1780,6 +1726,9 @@ func TestSignUpUseCasesImpl_RegisterUser(t *testing.T) {
 			if !tt.wantErr && got == nil {
 				t.Errorf("SignUpUseCasesImpl.RegisterUser() = %v, want %v", got, tt.want)
 			}
+			if tt.name == "sad: unable to send otp sms" && !rolledBack {
+				t.Errorf("expected the user account to be rolled back")
+			}
 		})
 	}
 }
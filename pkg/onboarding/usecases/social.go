@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// SocialAccountUseCases represents the business logic involved in linking the accounts that users
+// have with social login providers to their profile, so that they can log in with them
+type SocialAccountUseCases interface {
+	// LinkSocialAccount lets the logged in user log in with the account that an ID token of a
+	// social login provider was issued for
+	LinkSocialAccount(
+		ctx context.Context,
+		provider profileutils.LoginProviderType,
+		idToken string,
+	) (bool, error)
+
+	// UnlinkSocialAccount stops the logged in user from logging in with their account with a
+	// social login provider. The user must be left with another way to log in
+	UnlinkSocialAccount(ctx context.Context, provider profileutils.LoginProviderType) (bool, error)
+}
+
+// SocialAccountUseCasesImpl represents the usecase implementation object
+type SocialAccountUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewSocialAccountUseCases initializes a new social account usecase
+func NewSocialAccountUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *SocialAccountUseCasesImpl {
+	return &SocialAccountUseCasesImpl{infrastructure, ext}
+}
+
+// LinkSocialAccount verifies an ID token of a social login provider and links the account that it
+// was issued for to the profile of the logged in user. An account can only be linked to one
+// profile, and a profile to one account of each provider
+func (s *SocialAccountUseCasesImpl) LinkSocialAccount(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+	idToken string,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "LinkSocialAccount")
+	defer span.End()
+
+	profile, err := s.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if !utils.IsSocialLoginProvider(provider) {
+		return false, exceptions.WrongEnumTypeError(string(provider))
+	}
+	identity, err := s.infrastructure.Social.VerifyIDToken(ctx, provider, idToken)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	uid := utils.SocialUID(identity.Provider, identity.Subject)
+
+	owner, err := socialAccountProfile(ctx, s.infrastructure, uid)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if owner != nil {
+		if owner.ID == profile.ID {
+			return true, nil
+		}
+		return false, exceptions.SocialAccountInUseError()
+	}
+
+	// the identifier, the UID and the reservation of the UID are written together so that a
+	// concurrent link of the same account to another profile is refused
+	err = s.infrastructure.Database.LinkSocialAccount(ctx, profile.ID, profileutils.VerifiedIdentifier{
+		UID:           uid,
+		LoginProvider: provider,
+		Timestamp:     time.Now().In(pubsubtools.TimeLocation),
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// UnlinkSocialAccount removes the account of a social login provider from the profile of the
+// logged in user and signs out the devices that were logged in with it. It is refused when the
+// account is the only way that the user can log in
+func (s *SocialAccountUseCasesImpl) UnlinkSocialAccount(
+	ctx context.Context,
+	provider profileutils.LoginProviderType,
+) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UnlinkSocialAccount")
+	defer span.End()
+
+	profile, err := s.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	linked := socialIdentifiers(profile, provider)
+	if len(linked) == 0 {
+		return false, exceptions.SocialAccountNotLinkedError(string(provider))
+	}
+
+	methods, err := s.otherLoginMethods(ctx, profile, provider)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if methods == 0 {
+		return false, exceptions.LastLoginMethodError()
+	}
+
+	for _, uid := range linked {
+		if err := s.signOutUID(ctx, profile.ID, uid); err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return false, err
+		}
+		if err := s.infrastructure.Database.RemoveVerifiedIdentifier(ctx, profile.ID, uid); err != nil {
+			utils.RecordSpanError(span, err)
+			return false, exceptions.UpdateProfileError(err)
+		}
+	}
+	return true, nil
+}
+
+// otherLoginMethods counts the ways that a user can log in apart from their accounts with a social
+// login provider: a PIN with their primary phone number, a password with their email address
+// and accounts with other social login providers
+func (s *SocialAccountUseCasesImpl) otherLoginMethods(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	provider profileutils.LoginProviderType,
+) (int, error) {
+	methods := 0
+	for _, p := range utils.SocialLoginProviders {
+		if p != provider && len(socialIdentifiers(profile, p)) > 0 {
+			methods++
+		}
+	}
+
+	if profile.PrimaryPhone != nil && *profile.PrimaryPhone != "" {
+		_, err := s.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
+		switch {
+		case err == nil:
+			methods++
+		case !exceptions.IsPINNotFoundError(err):
+			// this is a wrapped error. No need to wrap it again
+			return 0, err
+		}
+	}
+
+	password, err := s.infrastructure.Database.GetPasswordByProfileID(ctx, profile.ID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return 0, err
+	}
+	if password != nil {
+		methods++
+	}
+	return methods, nil
+}
+
+// signOutUID signs out the devices that were logged in with a UID and revokes its refresh tokens
+func (s *SocialAccountUseCasesImpl) signOutUID(ctx context.Context, profileID string, uid string) error {
+	sessions, err := s.infrastructure.Database.ListSessions(ctx, profileID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	sessionIDs := []string{}
+	for _, session := range sessions {
+		if session.UID == uid && !session.IsRevoked() {
+			sessionIDs = append(sessionIDs, session.ID)
+		}
+	}
+	if len(sessionIDs) > 0 {
+		now := time.Now().In(pubsubtools.TimeLocation)
+		if err := s.infrastructure.Database.RevokeSessions(ctx, profileID, sessionIDs, now); err != nil {
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+	}
+	// this is a wrapped error. No need to wrap it again
+	return s.infrastructure.Database.RevokeRefreshTokens(ctx, uid)
+}
+
+func (s *SocialAccountUseCasesImpl) loggedInProfile(ctx context.Context) (*profileutils.UserProfile, error) {
+	uid, err := s.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.UserNotFoundError(err)
+	}
+	// this is a wrapped error. No need to wrap it again
+	return s.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+}
+
+// socialAccountProfile returns the profile that the account with the provided social login UID is
+// linked to, or nil when it is not linked to any profile
+func socialAccountProfile(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	uid string,
+) (*profileutils.UserProfile, error) {
+	profile, err := infra.Database.GetUserProfileByUID(ctx, uid, false)
+	if exceptions.IsProfileNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return profile, nil
+}
+
+// socialIdentifiers returns the UIDs of the accounts with a social login provider that are linked
+// to a profile
+func socialIdentifiers(profile *profileutils.UserProfile, provider profileutils.LoginProviderType) []string {
+	uids := []string{}
+	for _, identifier := range profile.VerifiedIdentifiers {
+		if identifier.LoginProvider != provider {
+			continue
+		}
+		if p, ok := utils.SocialLoginProvider(identifier.UID); ok && p == provider {
+			uids = append(uids, identifier.UID)
+		}
+	}
+	return uids
+}
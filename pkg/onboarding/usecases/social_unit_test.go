@@ -0,0 +1,319 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSocialSubject = "110248495921238986420"
+
+var testSocialUID = utils.SocialUID(profileutils.LoginProviderTypeSocialGoogle, testSocialSubject)
+
+// setupFakeSocialLogin accepts the ID token "valid-token" of a Google account. The account is
+// linked to the profile that linkedProfile returns, if any
+func setupFakeSocialLogin(emailVerified bool, linkedProfile func() *profileutils.UserProfile) {
+	fakeSocial.VerifyIDTokenFn = func(
+		ctx context.Context,
+		provider profileutils.LoginProviderType,
+		idToken string,
+	) (*domain.SocialIdentity, error) {
+		if idToken != "valid-token" {
+			return nil, exceptions.InvalidSocialTokenError(fmt.Errorf("invalid token"))
+		}
+		return &domain.SocialIdentity{
+			Provider:      provider,
+			Subject:       testSocialSubject,
+			EmailAddress:  "Wanjiku@example.com",
+			EmailVerified: emailVerified,
+		}, nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		if profile := linkedProfile(); profile != nil && utils.CheckIdentifierExists(profile, uid) {
+			return profile, nil
+		}
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+	fakeInfraRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+		return nil, nil
+	}
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, uid string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: uid, RefreshToken: "refresh-token-1"}, nil
+	}
+	fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+		return nil
+	}
+	fakeInfraRepo.GetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string) (*profileutils.UserCommunicationsSetting, error) {
+		return &profileutils.UserCommunicationsSetting{ProfileID: profileID}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{}, nil
+	}
+}
+
+func linkedSocialProfile() *profileutils.UserProfile {
+	return &profileutils.UserProfile{
+		ID:           "profile-1",
+		VerifiedUIDS: []string{"uid-1", testSocialUID},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{
+			{UID: "uid-1", LoginProvider: domain.LoginProviderTypeEmail},
+			{UID: testSocialUID, LoginProvider: profileutils.LoginProviderTypeSocialGoogle},
+		},
+	}
+}
+
+func TestSignUpUseCasesImpl_SignUpBySocial(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	var linked *profileutils.UserProfile
+	setupFakeSocialLogin(true, func() *profileutils.UserProfile { return linked })
+	var createdEmail string
+	fakeInfraRepo.CreateEmailUserAccountFn = func(ctx context.Context, emailAddress string, account *domain.UserAccount) (*domain.UserAccount, error) {
+		createdEmail = emailAddress
+		account.UID = "uid-1"
+		account.Profile = &profileutils.UserProfile{ID: "profile-1", PrimaryEmailAddress: &emailAddress}
+		return account, nil
+	}
+	deleted := false
+	fakeInfraRepo.DeleteUserAccountFn = func(ctx context.Context, account *domain.UserAccount) error {
+		deleted = true
+		return nil
+	}
+
+	token := "valid-token"
+	input := &dto.SocialSignUpInput{
+		Provider: profileutils.LoginProviderTypeSocialGoogle,
+		IDToken:  &token,
+		Flavour:  feedlib.FlavourConsumer,
+	}
+	response, err := i.SignUpBySocial(ctx, input)
+	if err != nil {
+		t.Errorf("error not expected got %v", err)
+		return
+	}
+	assert.Equal(t, "wanjiku@example.com", createdEmail)
+	assert.Equal(t, testSocialUID, response.Auth.UID)
+
+	// an account that is linked to a profile can't sign up again
+	linked = linkedSocialProfile()
+	createdEmail = ""
+	_, err = i.SignUpBySocial(ctx, input)
+	assert.Equal(t, exceptions.SocialAccountInUse, errorCode(err))
+	assert.Empty(t, createdEmail)
+	linked = nil
+
+	// the account is removed when it can't be linked
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, uid string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return nil, exceptions.UpdateProfileError(fmt.Errorf("unable to link"))
+	}
+	_, err = i.SignUpBySocial(ctx, input)
+	assert.NotNil(t, err)
+	assert.True(t, deleted)
+
+	// the provider must have verified the email address
+	setupFakeSocialLogin(false, func() *profileutils.UserProfile { return nil })
+	_, err = i.SignUpBySocial(ctx, input)
+	assert.Equal(t, exceptions.SocialEmailRequired, errorCode(err))
+
+	invalid := "invalid-token"
+	input.IDToken = &invalid
+	_, err = i.SignUpBySocial(ctx, input)
+	assert.Equal(t, exceptions.InvalidSocialToken, errorCode(err))
+
+	input.Provider = profileutils.LoginProviderTypePhone
+	_, err = i.SignUpBySocial(ctx, input)
+	assert.NotNil(t, err)
+}
+
+func TestLoginUseCasesImpl_LoginBySocial(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	linked := linkedSocialProfile()
+	setupFakeSocialLogin(true, func() *profileutils.UserProfile { return linked })
+	events := []*domain.LoginEvent{}
+	fakeInfraRepo.RecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent) error {
+		events = append(events, event)
+		return nil
+	}
+
+	response, err := i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	if err != nil {
+		t.Errorf("error not expected got %v", err)
+		return
+	}
+	assert.Equal(t, "profile-1", response.Profile.ID)
+	assert.Equal(t, testSocialUID, response.Auth.UID)
+	assert.Equal(t, domain.LoginMethodSocial, events[0].Method)
+	assert.Equal(t, domain.LoginOutcomeSucceeded, events[0].Outcome)
+
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "invalid-token", feedlib.FlavourConsumer)
+	assert.Equal(t, exceptions.InvalidSocialToken, errorCode(err))
+	assert.Equal(t, domain.LoginFailureReasonSocialToken, events[1].Reason)
+
+	// the account has to be linked to a profile
+	linked = nil
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	assert.True(t, exceptions.IsProfileNotFoundError(err))
+	assert.Equal(t, domain.LoginFailureReasonNotFound, events[2].Reason)
+
+	// a user whose PIN is locked can't guess the codes of their authenticator app
+	linked = linkedSocialProfile()
+	lockedUntil := time.Now().Add(time.Hour)
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		return &domain.PIN{ProfileID: profileID, FailedAttempts: 5, LockedUntil: &lockedUntil}, nil
+	}
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourPro)
+	assert.True(t, exceptions.IsPINLockedError(err))
+	_, err = i.LoginBySocial(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token", feedlib.FlavourConsumer)
+	assert.True(t, exceptions.IsPINLockedError(err))
+}
+
+func TestSocialAccountUseCasesImpl_LinkSocialAccount(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	profile := &profileutils.UserProfile{
+		ID:                  "profile-1",
+		VerifiedUIDS:        []string{"uid-1"},
+		VerifiedIdentifiers: []profileutils.VerifiedIdentifier{{UID: "uid-1", LoginProvider: profileutils.LoginProviderTypePhone}},
+	}
+	var owner *profileutils.UserProfile
+	setupFakeSocialLogin(true, func() *profileutils.UserProfile { return owner })
+	fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+		return "uid-1", nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		if uid == "uid-1" || utils.CheckIdentifierExists(profile, uid) {
+			return profile, nil
+		}
+		if owner != nil && utils.CheckIdentifierExists(owner, uid) {
+			return owner, nil
+		}
+		return nil, exceptions.ProfileNotFoundError(fmt.Errorf("user profile not found"))
+	}
+	identifiers := []profileutils.VerifiedIdentifier{}
+	fakeInfraRepo.LinkSocialAccountFn = func(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error {
+		identifiers = append(identifiers, identifier)
+		_, err := utils.LinkSocialAccount(profile, identifier)
+		return err
+	}
+
+	linked, err := i.LinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token")
+	assert.Nil(t, err)
+	assert.True(t, linked)
+	if assert.Len(t, identifiers, 1) {
+		assert.Equal(t, testSocialUID, identifiers[0].UID)
+		assert.Equal(t, profileutils.LoginProviderTypeSocialGoogle, identifiers[0].LoginProvider)
+	}
+	assert.Contains(t, profile.VerifiedUIDS, testSocialUID)
+
+	// the account is already linked to the profile
+	linked, err = i.LinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token")
+	assert.Nil(t, err)
+	assert.True(t, linked)
+	assert.Len(t, identifiers, 1)
+
+	// the account is linked to another profile
+	profile.VerifiedUIDS = []string{"uid-1"}
+	profile.VerifiedIdentifiers = profile.VerifiedIdentifiers[:1]
+	owner = linkedSocialProfile()
+	owner.ID = "profile-2"
+	_, err = i.LinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token")
+	assert.Equal(t, exceptions.SocialAccountInUse, errorCode(err))
+	assert.Len(t, identifiers, 1)
+
+	// the account was linked to another profile after it was looked up
+	owner = nil
+	fakeInfraRepo.LinkSocialAccountFn = func(ctx context.Context, id string, identifier profileutils.VerifiedIdentifier) error {
+		return exceptions.SocialAccountInUseError()
+	}
+	_, err = i.LinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle, "valid-token")
+	assert.Equal(t, exceptions.SocialAccountInUse, errorCode(err))
+
+	_, err = i.LinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle, "invalid-token")
+	assert.Equal(t, exceptions.InvalidSocialToken, errorCode(err))
+}
+
+func TestSocialAccountUseCasesImpl_UnlinkSocialAccount(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	ctx := context.Background()
+
+	profile := linkedSocialProfile()
+	fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+		return "uid-1", nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return profile, nil
+	}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		return nil, exceptions.PinNotFoundError(fmt.Errorf("failed to get a user pin"))
+	}
+	var password *domain.Password
+	fakeInfraRepo.GetPasswordByProfileIDFn = func(ctx context.Context, profileID string) (*domain.Password, error) {
+		return password, nil
+	}
+	fakeInfraRepo.ListSessionsFn = func(ctx context.Context, profileID string) ([]*domain.Session, error) {
+		return []*domain.Session{
+			{ID: "session-1", ProfileID: profileID, UID: testSocialUID},
+			{ID: "session-2", ProfileID: profileID, UID: "uid-1"},
+		}, nil
+	}
+	revokedSessions := []string{}
+	fakeInfraRepo.RevokeSessionsFn = func(ctx context.Context, profileID string, sessionIDs []string, revokedAt time.Time) error {
+		revokedSessions = append(revokedSessions, sessionIDs...)
+		return nil
+	}
+	revokedUIDs := []string{}
+	fakeInfraRepo.RevokeRefreshTokensFn = func(ctx context.Context, uid string) error {
+		revokedUIDs = append(revokedUIDs, uid)
+		return nil
+	}
+	removed := []string{}
+	fakeInfraRepo.RemoveVerifiedIdentifierFn = func(ctx context.Context, id string, uid string) error {
+		removed = append(removed, uid)
+		return nil
+	}
+
+	// the account is the only way that the user can log in
+	_, err = i.UnlinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle)
+	assert.Equal(t, exceptions.LastLoginMethod, errorCode(err))
+	assert.Empty(t, removed)
+
+	password = &domain.Password{ID: "password-1", ProfileID: "profile-1"}
+	unlinked, err := i.UnlinkSocialAccount(ctx, profileutils.LoginProviderTypeSocialGoogle)
+	assert.Nil(t, err)
+	assert.True(t, unlinked)
+	assert.Equal(t, []string{testSocialUID}, removed)
+	assert.Equal(t, []string{"session-1"}, revokedSessions)
+	assert.Equal(t, []string{testSocialUID}, revokedUIDs)
+
+	_, err = i.UnlinkSocialAccount(ctx, profileutils.LoginProviderTypeAppleFacebook)
+	assert.Equal(t, exceptions.SocialAccountNotLinked, errorCode(err))
+}
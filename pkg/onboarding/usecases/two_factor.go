@@ -0,0 +1,549 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/profileutils"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// TwoFactorUseCases represents the business logic involved in setting up and managing a second
+// factor (an authenticator app) for logging in. Admins decide which roles require it
+type TwoFactorUseCases interface {
+	EnrolTOTP(ctx context.Context) (*domain.TOTPProvisioning, error)
+	ConfirmTOTPEnrolment(ctx context.Context, code string) (bool, error)
+	DisableTOTP(ctx context.Context, code string) (bool, error)
+	RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error)
+	GetTwoFactorStatus(ctx context.Context) (*domain.TwoFactorStatus, error)
+
+	ResetProfileTOTP(ctx context.Context, profileID string) (bool, error)
+	GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error)
+	SetTwoFactorRequiredScopes(ctx context.Context, scopes []string) (*domain.TwoFactorPolicy, error)
+}
+
+// TwoFactorUseCasesImpl represents the usecase implementation object
+type TwoFactorUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewTwoFactorUseCases initializes a new two factor usecase
+func NewTwoFactorUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *TwoFactorUseCasesImpl {
+	return &TwoFactorUseCasesImpl{infrastructure, ext}
+}
+
+// EnrolTOTP starts adding an authenticator app for the logged in user. The enrolment is pending
+// until it is confirmed with a code from the app. Starting again replaces a pending enrolment
+func (t *TwoFactorUseCasesImpl) EnrolTOTP(ctx context.Context) (*domain.TOTPProvisioning, error) {
+	ctx, span := tracer.Start(ctx, "EnrolTOTP")
+	defer span.End()
+
+	profile, err := t.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	provisioning, err := startTOTPEnrolment(ctx, t.infrastructure, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return provisioning, nil
+}
+
+// ConfirmTOTPEnrolment confirms the pending enrolment of the logged in user with a code from their
+// authenticator app. The app is required for their logins afterwards
+func (t *TwoFactorUseCasesImpl) ConfirmTOTPEnrolment(ctx context.Context, code string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmTOTPEnrolment")
+	defer span.End()
+
+	profile, err := t.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	enrolment, err := t.infrastructure.Database.GetTOTPEnrolment(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if enrolment == nil {
+		err := exceptions.TOTPNotEnrolledError()
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+	if enrolment.IsConfirmed() {
+		err := exceptions.TOTPAlreadyEnrolledError()
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	var confirmedAt time.Time
+	if err := checkCountedCode(ctx, t.infrastructure, profile.ID, func(now time.Time) bool {
+		step, ok := utils.VerifyTOTPCode(enrolment, code, now)
+		if ok {
+			enrolment.LastUsedStep = step
+			confirmedAt = now
+		}
+		return ok
+	}); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	enrolment.Confirmed = &confirmedAt
+	if err := t.infrastructure.Database.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// DisableTOTP removes the authenticator app of the logged in user after checking a code from it or
+// a recovery code. A user whose roles require a second factor can't remove it
+func (t *TwoFactorUseCasesImpl) DisableTOTP(ctx context.Context, code string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "DisableTOTP")
+	defer span.End()
+
+	profile, enrolment, err := t.checkedEnrolment(ctx, code)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	required, err := twoFactorRequired(ctx, t.infrastructure, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if required {
+		err := exceptions.TwoFactorEnrolmentRequiredError(
+			fmt.Errorf("the roles of profile %s require a second factor", profile.ID),
+		)
+		utils.RecordSpanError(span, err)
+		return false, err
+	}
+
+	if err := t.infrastructure.Database.DeleteTOTPEnrolment(ctx, enrolment.ProfileID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the logged in user after checking a code
+// from their authenticator app or one of their recovery codes. The old recovery codes stop working
+func (t *TwoFactorUseCasesImpl) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "RegenerateRecoveryCodes")
+	defer span.End()
+
+	_, enrolment, err := t.checkedEnrolment(ctx, code)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	codes, hashes, err := utils.NewRecoveryCodes()
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InternalServerError(err)
+	}
+	enrolment.RecoveryCodeHashes = hashes
+	if err := t.infrastructure.Database.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return codes, nil
+}
+
+// GetTwoFactorStatus returns whether the logged in user has an authenticator app and whether their
+// roles require one
+func (t *TwoFactorUseCasesImpl) GetTwoFactorStatus(ctx context.Context) (*domain.TwoFactorStatus, error) {
+	ctx, span := tracer.Start(ctx, "GetTwoFactorStatus")
+	defer span.End()
+
+	profile, err := t.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	enrolment, err := t.infrastructure.Database.GetTOTPEnrolment(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	required, err := twoFactorRequired(ctx, t.infrastructure, profile)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	status := &domain.TwoFactorStatus{Required: required}
+	if enrolment != nil && enrolment.IsConfirmed() {
+		status.Enrolled = true
+		status.RecoveryCodesLeft = len(enrolment.RecoveryCodeHashes)
+	}
+	return status, nil
+}
+
+// ResetProfileTOTP removes the authenticator app of a user who has lost it together with their
+// recovery codes. A user whose roles require a second factor enrols a new app when they next log
+// in. It is restricted to admins
+func (t *TwoFactorUseCasesImpl) ResetProfileTOTP(ctx context.Context, profileID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ResetProfileTOTP")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, t.infrastructure, t.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	profile, err := t.infrastructure.Database.GetUserProfileByID(ctx, profileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if err := t.infrastructure.Database.DeleteTOTPEnrolment(ctx, profile.ID); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// GetTwoFactorPolicy returns the scopes whose roles require a second factor. It is restricted to admins
+func (t *TwoFactorUseCasesImpl) GetTwoFactorPolicy(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+	ctx, span := tracer.Start(ctx, "GetTwoFactorPolicy")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, t.infrastructure, t.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	policy, err := t.infrastructure.Database.GetTwoFactorPolicy(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetTwoFactorRequiredScopes requires users whose roles hold any of the provided scopes to log in
+// with a second factor. An empty list makes the second factor optional for
+// everyone. It is restricted to admins
+func (t *TwoFactorUseCasesImpl) SetTwoFactorRequiredScopes(
+	ctx context.Context,
+	scopes []string,
+) (*domain.TwoFactorPolicy, error) {
+	ctx, span := tracer.Start(ctx, "SetTwoFactorRequiredScopes")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, t.infrastructure, t.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	admin, err := t.loggedInProfile(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	required := []string{}
+	for _, scope := range utils.RemoveDuplicateStrings(scopes) {
+		permission, err := profileutils.GetPermissionByScope(ctx, scope)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, err
+		}
+		required = append(required, permission.Scope)
+	}
+
+	policy := &domain.TwoFactorPolicy{
+		ID:             domain.TwoFactorPolicyID,
+		RequiredScopes: required,
+		Updated:        time.Now().In(pubsubtools.TimeLocation),
+		UpdatedBy:      admin.ID,
+	}
+	if err := t.infrastructure.Database.SaveTwoFactorPolicy(ctx, policy); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (t *TwoFactorUseCasesImpl) loggedInProfile(ctx context.Context) (*profileutils.UserProfile, error) {
+	uid, err := t.baseExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.UserNotFoundError(err)
+	}
+	// this is a wrapped error. No need to wrap it again
+	return t.infrastructure.Database.GetUserProfileByUID(ctx, uid, false)
+}
+
+// checkedEnrolment returns the confirmed enrolment of the logged in user once the provided code
+// has been accepted for it. The code can't be used again
+func (t *TwoFactorUseCasesImpl) checkedEnrolment(
+	ctx context.Context,
+	code string,
+) (*profileutils.UserProfile, *domain.TOTPEnrolment, error) {
+	profile, err := t.loggedInProfile(ctx)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	enrolment, err := t.infrastructure.Database.GetTOTPEnrolment(ctx, profile.ID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	if enrolment == nil || !enrolment.IsConfirmed() {
+		return nil, nil, exceptions.TOTPNotEnrolledError()
+	}
+
+	if err := checkCountedCode(ctx, t.infrastructure, profile.ID, func(now time.Time) bool {
+		return utils.CheckSecondFactorCode(enrolment, code, now)
+	}); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	if err := t.infrastructure.Database.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, nil, err
+	}
+	return profile, enrolment, nil
+}
+
+// checkCountedCode checks a code that a logged in user enters to confirm or manage their
+// authenticator app. Like a wrong code of a login, a wrong code counts as a failed attempt of their
+// PIN, or of their password when they have no PIN. The attempt is reserved before the code is
+// checked, so the codes are throttled and refused by a locked PIN or password as the PIN and password
+// themselves are. The count is cleared once the code is accepted. The codes of a user with neither,
+// who logs in with a social account, are not counted, as they are not when such a user logs in
+func checkCountedCode(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profileID string,
+	check func(now time.Time) bool,
+) error {
+	ctx, span := tracer.Start(ctx, "checkCountedCode")
+	defer span.End()
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	PINData, err := infra.Database.GetPINByProfileID(ctx, profileID)
+	if err != nil && !exceptions.IsPINNotFoundError(err) {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if PINData != nil {
+		reserved, err := infra.Database.ReservePINAttempt(ctx, profileID, now)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		if !check(now) {
+			if reserved.IsLocked(now) {
+				return exceptions.PINLockedError(*reserved.LockedUntil)
+			}
+			return exceptions.InvalidTwoFactorCodeError()
+		}
+		// this is a wrapped error. No need to wrap it again
+		return infra.Database.ClearPINAttempts(ctx, profileID)
+	}
+
+	passwordData, err := infra.Database.GetPasswordByProfileID(ctx, profileID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if passwordData != nil {
+		reserved, err := infra.Database.ReservePasswordAttempt(ctx, profileID, now)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		if !check(now) {
+			if reserved.IsLocked(now) {
+				return exceptions.PasswordLockedError(*reserved.LockedUntil)
+			}
+			return exceptions.InvalidTwoFactorCodeError()
+		}
+		// this is a wrapped error. No need to wrap it again
+		return infra.Database.ClearPasswordAttempts(ctx, profileID)
+	}
+
+	if !check(now) {
+		return exceptions.InvalidTwoFactorCodeError()
+	}
+	return nil
+}
+
+// startTOTPEnrolment creates a pending TOTP enrolment for a profile that has no confirmed one
+func startTOTPEnrolment(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profile *profileutils.UserProfile,
+) (*domain.TOTPProvisioning, error) {
+	existing, err := infra.Database.GetTOTPEnrolment(ctx, profile.ID)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	if existing != nil && existing.IsConfirmed() {
+		return nil, exceptions.TOTPAlreadyEnrolledError()
+	}
+
+	enrolment, codes, err := utils.NewTOTPEnrolment(profile.ID, time.Now().In(pubsubtools.TimeLocation))
+	if err != nil {
+		return nil, exceptions.InternalServerError(err)
+	}
+	if err := infra.Database.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	accountName := profile.ID
+	if profile.PrimaryPhone != nil {
+		accountName = *profile.PrimaryPhone
+	}
+	return &domain.TOTPProvisioning{
+		Secret:        enrolment.Secret,
+		URI:           utils.TOTPProvisioningURI(enrolment.Secret, accountName),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// twoFactorRequired checks whether the roles of a profile require it to log in with a second factor
+func twoFactorRequired(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profile *profileutils.UserProfile,
+) (bool, error) {
+	policy, err := infra.Database.GetTwoFactorPolicy(ctx)
+	if err != nil {
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if len(policy.RequiredScopes) == 0 || len(profile.Roles) == 0 {
+		return false, nil
+	}
+
+	roles, err := infra.Database.GetRolesByIDs(ctx, profile.Roles)
+	if err != nil {
+		if strings.Contains(err.Error(), "role not found") {
+			return false, nil
+		}
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return utils.RequiresTwoFactor(policy, *roles), nil
+}
+
+// checkSecondFactor checks the second factor of a login whose PIN, password or social identity
+// has been accepted. It is checked whatever the flavour of the login, because the client chooses
+// the flavour. A user who has an authenticator app, or whose roles require one, must log in with
+// a code from it or one of their recovery codes. A user whose roles require an app that they have
+// not set up can only log in with a code from a pending enrolment, which confirms it. A wrong code
+// is counted by recordFailure as a failed attempt of the first factor so that codes can't be
+// guessed faster than PINs or passwords. recordFailure returns the error of a first factor that
+// is locked as a result
+func checkSecondFactor(
+	ctx context.Context,
+	infra infrastructure.Infrastructure,
+	profile *profileutils.UserProfile,
+	recordFailure func(now time.Time) error,
+) error {
+	ctx, span := tracer.Start(ctx, "checkSecondFactor")
+	defer span.End()
+
+	enrolment, err := infra.Database.GetTOTPEnrolment(ctx, profile.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	if enrolment == nil || !enrolment.IsConfirmed() {
+		required, err := twoFactorRequired(ctx, infra, profile)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			// this is a wrapped error. No need to wrap it again
+			return err
+		}
+		if !required {
+			return nil
+		}
+		if enrolment == nil {
+			err := exceptions.TwoFactorEnrolmentRequiredError(
+				fmt.Errorf("the roles of profile %s require a second factor", profile.ID),
+			)
+			utils.RecordSpanError(span, err)
+			return err
+		}
+	}
+
+	code := utils.GetSecondFactorCode(ctx)
+	if code == "" {
+		err := exceptions.TwoFactorRequiredError()
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
+	now := time.Now().In(pubsubtools.TimeLocation)
+	if !utils.CheckSecondFactorCode(enrolment, code, now) {
+		err := exceptions.InvalidTwoFactorCodeError()
+		utils.RecordSpanError(span, err)
+
+		if lockErr := recordFailure(now); lockErr != nil {
+			return lockErr
+		}
+		return err
+	}
+
+	if !enrolment.IsConfirmed() {
+		enrolment.Confirmed = &now
+	}
+	if err := infra.Database.SaveTOTPEnrolment(ctx, enrolment); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,474 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/savannahghi/errorcodeutil"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+func errorCode(err error) int {
+	customErr, ok := err.(*errorcodeutil.CustomError)
+	if !ok {
+		return -1
+	}
+	return customErr.Code
+}
+
+func setupFakeProLogin(roles []string) {
+	phone := "+254711223344"
+	fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+		return &phone, nil
+	}
+	fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1", PrimaryPhone: &phone, Roles: roles}, nil
+	}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		return &domain.PIN{ID: "pin-1", ProfileID: profileID, Scheme: extension.NewPINHashScheme(extension.CurrentPINHashOptions())}, nil
+	}
+	fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+		return true
+	}
+	fakeInfraRepo.GenerateAuthCredentialsFn = func(ctx context.Context, phone string, profile *profileutils.UserProfile) (*profileutils.AuthCredentialResponse, error) {
+		return &profileutils.AuthCredentialResponse{UID: "uid-1", RefreshToken: "refresh-token-1"}, nil
+	}
+	fakeInfraRepo.SaveSessionFn = func(ctx context.Context, session *domain.Session) error {
+		return nil
+	}
+	fakeInfraRepo.GetUserCommunicationsSettingsFn = func(ctx context.Context, profileID string) (*profileutils.UserCommunicationsSetting, error) {
+		return &profileutils.UserCommunicationsSetting{ProfileID: profileID}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		roles := []profileutils.Role{}
+		for _, id := range roleIDs {
+			roles = append(roles, profileutils.Role{ID: id, Active: true, Scopes: []string{"role.assign"}})
+		}
+		return &roles, nil
+	}
+}
+
+func TestLoginUseCasesImpl_LoginByPhoneWithSecondFactor(t *testing.T) {
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	now := time.Now()
+	confirmed := now.Add(-time.Hour)
+	enrolment, recoveryCodes, err := utils.NewTOTPEnrolment("profile-1", now)
+	if err != nil {
+		t.Errorf("unable to create a TOTP enrolment: %v", err)
+		return
+	}
+	code, _ := utils.TOTPCode(enrolment.Secret, utils.TOTPStep(now))
+
+	tests := []struct {
+		name      string
+		flavour   feedlib.Flavour
+		roles     []string
+		enrolled  *bool
+		code      string
+		wantCode  int
+		wantSaved bool
+	}{
+		{
+			name:    "happy: consumers without a required app are not asked for a second factor",
+			flavour: feedlib.FlavourConsumer,
+		},
+		{
+			name:     "sad: a consumer login can't skip the authenticator app",
+			flavour:  feedlib.FlavourConsumer,
+			enrolled: &[]bool{true}[0],
+			wantCode: exceptions.TwoFactorRequired,
+		},
+		{
+			name:     "sad: a consumer login can't skip the app that the roles require",
+			flavour:  feedlib.FlavourConsumer,
+			roles:    []string{"role-1"},
+			wantCode: exceptions.TwoFactorEnrolmentRequired,
+		},
+		{
+			name:    "happy: the second factor is optional",
+			flavour: feedlib.FlavourPro,
+		},
+		{
+			name:      "happy: log in with a code from the authenticator app",
+			flavour:   feedlib.FlavourPro,
+			enrolled:  &[]bool{true}[0],
+			code:      code,
+			wantSaved: true,
+		},
+		{
+			name:      "happy: log in with a recovery code",
+			flavour:   feedlib.FlavourPro,
+			enrolled:  &[]bool{true}[0],
+			code:      recoveryCodes[0],
+			wantSaved: true,
+		},
+		{
+			name:      "happy: a pending enrolment is confirmed by logging in with it",
+			flavour:   feedlib.FlavourPro,
+			roles:     []string{"role-1"},
+			enrolled:  &[]bool{false}[0],
+			code:      code,
+			wantSaved: true,
+		},
+		{
+			name:     "sad: no code",
+			flavour:  feedlib.FlavourPro,
+			enrolled: &[]bool{true}[0],
+			wantCode: exceptions.TwoFactorRequired,
+		},
+		{
+			name:     "sad: wrong code",
+			flavour:  feedlib.FlavourPro,
+			enrolled: &[]bool{true}[0],
+			code:     "000000",
+			wantCode: exceptions.InvalidTwoFactorCode,
+		},
+		{
+			name:     "sad: the roles require an authenticator app that is not set up",
+			flavour:  feedlib.FlavourPro,
+			roles:    []string{"role-1"},
+			wantCode: exceptions.TwoFactorEnrolmentRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeProLogin(tt.roles)
+			fakeInfraRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+				return &domain.TwoFactorPolicy{RequiredScopes: []string{"role.assign"}}, nil
+			}
+			fakeInfraRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+				if tt.enrolled == nil {
+					return nil, nil
+				}
+				stored := *enrolment
+				stored.RecoveryCodeHashes = append([]string{}, enrolment.RecoveryCodeHashes...)
+				if *tt.enrolled {
+					stored.Confirmed = &confirmed
+				}
+				return &stored, nil
+			}
+			var saved *domain.TOTPEnrolment
+			fakeInfraRepo.SaveTOTPEnrolmentFn = func(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+				saved = enrolment
+				return nil
+			}
+			failedAttempts := 0
+			fakeInfraRepo.ReservePINAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error) {
+				failedAttempts++
+				return &domain.PIN{ID: "pin-1", ProfileID: profileID, FailedAttempts: failedAttempts}, nil
+			}
+			cleared := false
+			fakeInfraRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+				cleared = true
+				return nil
+			}
+
+			ctx := context.Background()
+			if tt.code != "" {
+				ctx = utils.WithSecondFactorCode(ctx, tt.code)
+			}
+			_, err := i.LoginByPhone(ctx, "0711223344", "1234", tt.flavour)
+			if tt.wantCode != 0 {
+				if errorCode(err) != tt.wantCode {
+					t.Errorf("LoginByPhone() error = %v, want code %v", err, tt.wantCode)
+				}
+				if tt.wantCode == exceptions.InvalidTwoFactorCode && (failedAttempts != 1 || cleared) {
+					t.Errorf("expected the wrong code to count as a failed PIN attempt")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("LoginByPhone() unexpected error = %v", err)
+				return
+			}
+			if tt.wantSaved && (saved == nil || !saved.IsConfirmed()) {
+				t.Errorf("expected the used code to be saved on a confirmed enrolment")
+			}
+			if !tt.wantSaved && saved != nil {
+				t.Errorf("expected the enrolment not to be saved")
+			}
+		})
+	}
+}
+
+// setupFakeStoredPIN stores a PIN whose attempts are reserved and cleared as the repository does
+func setupFakeStoredPIN() *domain.PIN {
+	stored := &domain.PIN{ID: "pin-1", ProfileID: "profile-1"}
+	fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+		copied := *stored
+		return &copied, nil
+	}
+	fakeInfraRepo.ReservePINAttemptFn = func(ctx context.Context, profileID string, attemptedAt time.Time) (*domain.PIN, error) {
+		if err := utils.ReservePINAttempt(stored, attemptedAt); err != nil {
+			return nil, err
+		}
+		copied := *stored
+		return &copied, nil
+	}
+	fakeInfraRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+		utils.ClearPINAttempts(stored)
+		return nil
+	}
+	return stored
+}
+
+func TestTwoFactorUseCasesImpl_Enrolment(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	setupFakeAdmin(false)
+
+	var stored *domain.TOTPEnrolment
+	fakeInfraRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+		if stored == nil {
+			return nil, nil
+		}
+		copied := *stored
+		return &copied, nil
+	}
+	fakeInfraRepo.SaveTOTPEnrolmentFn = func(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+		stored = enrolment
+		return nil
+	}
+	fakeInfraRepo.DeleteTOTPEnrolmentFn = func(ctx context.Context, profileID string) error {
+		stored = nil
+		return nil
+	}
+	required := false
+	fakeInfraRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		if required {
+			return &domain.TwoFactorPolicy{RequiredScopes: []string{"role.assign"}}, nil
+		}
+		return &domain.TwoFactorPolicy{}, nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1", Roles: []string{"role-1"}}, nil
+	}
+	fakeInfraRepo.GetRolesByIDsFn = func(ctx context.Context, roleIDs []string) (*[]profileutils.Role, error) {
+		return &[]profileutils.Role{{ID: "role-1", Active: true, Scopes: []string{"role.assign"}}}, nil
+	}
+	pin := setupFakeStoredPIN()
+
+	if _, err := i.ConfirmTOTPEnrolment(ctx, "123456"); errorCode(err) != exceptions.TOTPNotEnrolled {
+		t.Errorf("expected an enrolment to be needed before it is confirmed, got %v", err)
+		return
+	}
+
+	provisioning, err := i.EnrolTOTP(ctx)
+	if err != nil {
+		t.Errorf("EnrolTOTP() unexpected error = %v", err)
+		return
+	}
+	if provisioning.Secret != stored.Secret || len(provisioning.RecoveryCodes) != utils.RecoveryCodeCount {
+		t.Errorf("expected the secret and recovery codes of the saved enrolment, got %v", provisioning)
+		return
+	}
+
+	if _, err := i.ConfirmTOTPEnrolment(ctx, "000000"); errorCode(err) != exceptions.InvalidTwoFactorCode {
+		t.Errorf("expected a wrong code not to confirm the enrolment, got %v", err)
+		return
+	}
+	if pin.FailedAttempts != 1 {
+		t.Errorf("expected the wrong code to count as a failed PIN attempt, got %v", pin.FailedAttempts)
+		return
+	}
+	code, _ := utils.TOTPCode(provisioning.Secret, utils.TOTPStep(time.Now()))
+	if confirmed, err := i.ConfirmTOTPEnrolment(ctx, code); err != nil || !confirmed {
+		t.Errorf("ConfirmTOTPEnrolment() = %v, %v", confirmed, err)
+		return
+	}
+	if pin.FailedAttempts != 0 {
+		t.Errorf("expected the accepted code to clear the failed PIN attempts, got %v", pin.FailedAttempts)
+		return
+	}
+	if _, err := i.EnrolTOTP(ctx); errorCode(err) != exceptions.TOTPAlreadyEnrolled {
+		t.Errorf("expected a confirmed enrolment not to be replaced, got %v", err)
+		return
+	}
+
+	status, err := i.GetTwoFactorStatus(ctx)
+	if err != nil || !status.Enrolled || status.Required || status.RecoveryCodesLeft != utils.RecoveryCodeCount {
+		t.Errorf("GetTwoFactorStatus() = %v, %v", status, err)
+		return
+	}
+
+	codes, err := i.RegenerateRecoveryCodes(ctx, provisioning.RecoveryCodes[0])
+	if err != nil || len(codes) != utils.RecoveryCodeCount {
+		t.Errorf("RegenerateRecoveryCodes() = %v, %v", codes, err)
+		return
+	}
+	if _, err := i.DisableTOTP(ctx, provisioning.RecoveryCodes[1]); errorCode(err) != exceptions.InvalidTwoFactorCode {
+		t.Errorf("expected the old recovery codes to stop working, got %v", err)
+		return
+	}
+
+	required = true
+	if _, err := i.DisableTOTP(ctx, codes[0]); errorCode(err) != exceptions.TwoFactorEnrolmentRequired {
+		t.Errorf("expected a required authenticator app not to be removed, got %v", err)
+		return
+	}
+	required = false
+	if disabled, err := i.DisableTOTP(ctx, codes[1]); err != nil || !disabled || stored != nil {
+		t.Errorf("DisableTOTP() = %v, %v", disabled, err)
+	}
+}
+
+func TestTwoFactorUseCasesImpl_WrongCodesLockThePIN(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+	setupFakeAdmin(false)
+
+	secret := "JBSWY3DPEHPK3PXP"
+	confirmed := time.Now().Add(-time.Hour)
+	enrolments := map[bool]*domain.TOTPEnrolment{
+		false: {ProfileID: "profile-1", Secret: secret},
+		true:  {ProfileID: "profile-1", Secret: secret, Confirmed: &confirmed},
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1"}, nil
+	}
+	fakeInfraRepo.SaveTOTPEnrolmentFn = func(ctx context.Context, enrolment *domain.TOTPEnrolment) error {
+		return nil
+	}
+	fakeInfraRepo.GetTwoFactorPolicyFn = func(ctx context.Context) (*domain.TwoFactorPolicy, error) {
+		return &domain.TwoFactorPolicy{}, nil
+	}
+
+	for _, isConfirmed := range []bool{false, true} {
+		enrolment := enrolments[isConfirmed]
+		fakeInfraRepo.GetTOTPEnrolmentFn = func(ctx context.Context, profileID string) (*domain.TOTPEnrolment, error) {
+			copied := *enrolment
+			return &copied, nil
+		}
+		pin := setupFakeStoredPIN()
+		guess := func() error {
+			if isConfirmed {
+				_, err := i.DisableTOTP(ctx, "000000")
+				return err
+			}
+			_, err := i.ConfirmTOTPEnrolment(ctx, "000000")
+			return err
+		}
+
+		// the wrong codes are throttled as wrong PINs are
+		if err := guess(); errorCode(err) != exceptions.InvalidTwoFactorCode {
+			t.Errorf("expected a wrong code to be refused, got %v", err)
+			return
+		}
+		if err := guess(); errorCode(err) != exceptions.InvalidTwoFactorCode {
+			t.Errorf("expected a wrong code to be refused, got %v", err)
+			return
+		}
+		if err := guess(); errorCode(err) != exceptions.PINAttemptsThrottled {
+			t.Errorf("expected the codes to be throttled, got %v", err)
+			return
+		}
+
+		for attempt := 3; attempt <= utils.MaxPINAttempts; attempt++ {
+			earlier := time.Now().Add(-time.Minute)
+			pin.LastFailedAttempt = &earlier
+			err = guess()
+		}
+		if errorCode(err) != exceptions.PINLocked {
+			t.Errorf("expected the last wrong code to lock the PIN, got %v", err)
+			return
+		}
+
+		// a locked PIN refuses the right code too
+		code, _ := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+		if isConfirmed {
+			_, err = i.DisableTOTP(ctx, code)
+		} else {
+			_, err = i.ConfirmTOTPEnrolment(ctx, code)
+		}
+		if errorCode(err) != exceptions.PINLocked {
+			t.Errorf("expected a locked PIN to refuse the code, got %v", err)
+			return
+		}
+	}
+}
+
+func TestTwoFactorUseCasesImpl_SetTwoFactorRequiredScopes(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+		scopes  []string
+		saveErr error
+		wantErr bool
+	}{
+		{
+			name:    "happy: require a second factor for roles that can assign roles",
+			isAdmin: true,
+			scopes:  []string{"role.assign", "role.create", "role.assign"},
+			wantErr: false,
+		},
+		{
+			name:    "happy: make the second factor optional",
+			isAdmin: true,
+			scopes:  []string{},
+			wantErr: false,
+		},
+		{
+			name:    "sad: unknown scope",
+			isAdmin: true,
+			scopes:  []string{"role.fly"},
+			wantErr: true,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			scopes:  []string{"role.assign"},
+			wantErr: true,
+		},
+		{
+			name:    "sad: unable to save the policy",
+			isAdmin: true,
+			scopes:  []string{"role.assign"},
+			saveErr: fmt.Errorf("unable to save the policy"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.SaveTwoFactorPolicyFn = func(ctx context.Context, policy *domain.TwoFactorPolicy) error {
+				return tt.saveErr
+			}
+
+			policy, err := i.SetTwoFactorRequiredScopes(ctx, tt.scopes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetTwoFactorRequiredScopes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(policy.RequiredScopes) != len(utils.RemoveDuplicateStrings(tt.scopes)) || policy.UpdatedBy != "profile-1") {
+				t.Errorf("SetTwoFactorRequiredScopes() = %v", policy)
+			}
+		})
+	}
+}
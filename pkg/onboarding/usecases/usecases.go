@@ -14,6 +14,16 @@ type Interactor struct {
 	SignUpUseCases
 	SurveyUseCases
 	UserPINUseCases
+	PubSubMessageUseCases
+	WebhookUseCases
+	SessionUseCases
+	LoginEventUseCases
+	LoginAlertUseCases
+	TwoFactorUseCases
+	PasswordUseCases
+	SocialAccountUseCases
+	ProfileMergeUseCases
+	TokenIntrospectionUseCases
 	admin.Usecase
 }
 
@@ -23,9 +33,19 @@ func NewUsecasesInteractor(infrastructure infrastructure.Infrastructure, baseExt
 	profile := NewProfileUseCase(infrastructure, baseExtension)
 	login := NewLoginUseCases(infrastructure, profile, baseExtension, pinsExtension)
 	roles := NewRoleUseCases(infrastructure, baseExtension)
-	pins := NewUserPinUseCase(infrastructure, profile, baseExtension, pinsExtension)
-	signup := NewSignUpUseCases(infrastructure, profile, pins, baseExtension)
+	pins := NewUserPinUseCase(infrastructure, profile, baseExtension, pinsExtension, extension.NewDefaultPINPolicy())
+	passwords := NewPasswordUseCases(infrastructure, baseExtension, pinsExtension, extension.NewDefaultPasswordPolicy())
+	signup := NewSignUpUseCases(infrastructure, profile, pins, passwords, baseExtension)
 	surveys := NewSurveyUseCases(infrastructure, baseExtension)
+	messages := NewPubSubMessageUseCases(infrastructure, baseExtension)
+	webhooks := NewWebhookUseCases(infrastructure, baseExtension)
+	sessions := NewSessionUseCases(infrastructure, baseExtension)
+	loginEvents := NewLoginEventUseCases(infrastructure, baseExtension)
+	loginAlerts := NewLoginAlertUseCases(infrastructure, baseExtension)
+	twoFactor := NewTwoFactorUseCases(infrastructure, baseExtension)
+	socialAccounts := NewSocialAccountUseCases(infrastructure, baseExtension)
+	profileMerges := NewProfileMergeUseCases(infrastructure, baseExtension)
+	introspection := NewTokenIntrospectionUseCases(infrastructure, baseExtension)
 	services := admin.NewService(baseExtension)
 
 	impl := Interactor{
@@ -35,6 +55,16 @@ func NewUsecasesInteractor(infrastructure infrastructure.Infrastructure, baseExt
 		signup,
 		surveys,
 		pins,
+		messages,
+		webhooks,
+		sessions,
+		loginEvents,
+		loginAlerts,
+		twoFactor,
+		passwords,
+		socialAccounts,
+		profileMerges,
+		introspection,
 		services,
 	}
 
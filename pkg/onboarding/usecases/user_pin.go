@@ -3,9 +3,11 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/savannahghi/errorcodeutil"
+	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
 	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
@@ -19,6 +21,10 @@ type UserPINUseCases interface {
 	SetUserPIN(ctx context.Context, pin string, profileID string) (bool, error)
 	// SetUserTempPIN is used to set a temporary PIN for a created user.
 	SetUserTempPIN(ctx context.Context, profileID string) (string, error)
+	// NewUserPIN validates and encrypts a PIN without saving it
+	NewUserPIN(ctx context.Context, pin string) (*domain.PIN, error)
+	// NewUserTempPIN generates and encrypts a temporary PIN without saving it
+	NewUserTempPIN(ctx context.Context) (*domain.PIN, string, error)
 	ResetUserPIN(
 		ctx context.Context,
 		phone string,
@@ -28,6 +34,11 @@ type UserPINUseCases interface {
 	ChangeUserPIN(ctx context.Context, phone string, pin string) (bool, error)
 	RequestPINReset(ctx context.Context, phone string, appID *string) (*profileutils.OtpResponse, error)
 	CheckHasPIN(ctx context.Context, profileID string) (bool, error)
+	// UnlockUserPIN unlocks a PIN that was locked after too many failed attempts. It is restricted to admins
+	UnlockUserPIN(ctx context.Context, profileID string) (bool, error)
+	// ReissueTempPIN replaces the PIN of a user with a new temporary PIN and sends it to them by SMS.
+	// It is restricted to admins
+	ReissueTempPIN(ctx context.Context, profileID string) (bool, error)
 }
 
 // UserPinUseCaseImpl represents usecase implementation object
@@ -36,20 +47,24 @@ type UserPinUseCaseImpl struct {
 	profileUseCases ProfileUseCase
 	baseExt         extension.BaseExtension
 	pinExt          extension.PINExtension
+	pinPolicy       extension.PINPolicy
 }
 
-// NewUserPinUseCase returns a new UserPin usecase
+// NewUserPinUseCase returns a new UserPin usecase. The PIN policy decides which PINs users can
+// choose when they set, change or reset their PIN
 func NewUserPinUseCase(
 	infrastructure infrastructure.Infrastructure,
 	p ProfileUseCase,
 	ext extension.BaseExtension,
 	pin extension.PINExtension,
+	policy extension.PINPolicy,
 ) UserPINUseCases {
 	return &UserPinUseCaseImpl{
 		infrastructure:  infrastructure,
 		profileUseCases: p,
 		baseExt:         ext,
 		pinExt:          pin,
+		pinPolicy:       policy,
 	}
 }
 
@@ -62,31 +77,59 @@ func (u *UserPinUseCaseImpl) SetUserPIN(
 	ctx, span := tracer.Start(ctx, "SetUserPIN")
 	defer span.End()
 
+	profile, err := u.infrastructure.Database.GetUserProfileByID(ctx, profileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if err := u.checkPINPolicy(profile, pin); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	pinPayload, err := u.NewUserPIN(ctx, pin)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	pinPayload.ProfileID = profileID
+
+	if _, err := u.infrastructure.Database.SavePIN(ctx, pinPayload); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.SaveUserPinError(err)
+	}
+
+	return true, nil
+}
+
+// NewUserPIN validates and encrypts a PIN. The PIN is not saved
+func (u *UserPinUseCaseImpl) NewUserPIN(ctx context.Context, pin string) (*domain.PIN, error) {
+	_, span := tracer.Start(ctx, "NewUserPIN")
+	defer span.End()
+
 	if err := extension.ValidatePINLength(pin); err != nil {
 		utils.RecordSpanError(span, err)
-		return false, exceptions.ValidatePINLengthError(err)
+		return nil, exceptions.ValidatePINLengthError(err)
 	}
 
 	if err := extension.ValidatePINDigits(pin); err != nil {
 		utils.RecordSpanError(span, err)
-		return false, exceptions.ValidatePINDigitsError(err)
+		return nil, exceptions.ValidatePINDigitsError(err)
 	}
 
 	// EncryptPIN the PIN
-	salt, encryptedPin := u.pinExt.EncryptPIN(pin, nil)
+	options := extension.CurrentPINHashOptions()
+	salt, encryptedPin := u.pinExt.EncryptPIN(pin, options)
 
-	pinPayload := &domain.PIN{
+	return &domain.PIN{
 		ID:        uuid.New().String(),
-		ProfileID: profileID,
 		PINNumber: encryptedPin,
 		Salt:      salt,
-	}
-	if _, err := u.infrastructure.Database.SavePIN(ctx, pinPayload); err != nil {
-		utils.RecordSpanError(span, err)
-		return false, exceptions.SaveUserPinError(err)
-	}
-
-	return true, nil
+		Scheme:    extension.NewPINHashScheme(options),
+	}, nil
 }
 
 // RequestPINReset sends a request given an existing user's phone number,
@@ -164,25 +207,10 @@ func (u *UserPinUseCaseImpl) ResetUserPIN(
 		return false, err
 	}
 
-	_, err = u.CheckHasPIN(ctx, profile.ID)
-	if err != nil {
-		utils.RecordSpanError(span, err)
-		return false, exceptions.EncryptPINError(err)
-	}
-
-	// EncryptPIN the PIN
-	salt, encryptedPin := u.pinExt.EncryptPIN(PIN, nil)
-
-	pinPayload := &domain.PIN{
-		ID:        uuid.New().String(),
-		ProfileID: profile.ID,
-		PINNumber: encryptedPin,
-		Salt:      salt,
-	}
-	_, err = u.infrastructure.Database.UpdatePIN(ctx, profile.ID, pinPayload)
-	if err != nil {
+	if err := u.replacePIN(ctx, profile, PIN); err != nil {
 		utils.RecordSpanError(span, err)
-		return false, exceptions.InternalServerError(err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
 	}
 	return true, nil
 }
@@ -213,26 +241,102 @@ func (u *UserPinUseCaseImpl) ChangeUserPIN(
 		return false, err
 	}
 
-	_, err = u.CheckHasPIN(ctx, profile.ID)
+	if err := u.replacePIN(ctx, profile, pin); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// replacePIN encrypts a new PIN and saves it in place of the current PIN of a profile.
+// Every change to an existing PIN goes through it so that a PIN that breaks the PIN policy, or
+// that matches the current PIN or one of the previous PINs, is always rejected
+func (u *UserPinUseCaseImpl) replacePIN(
+	ctx context.Context,
+	profile *profileutils.UserProfile,
+	pin string,
+) error {
+	ctx, span := tracer.Start(ctx, "replacePIN")
+	defer span.End()
+
+	if err := u.checkPINPolicy(profile, pin); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return err
+	}
+
+	current, err := u.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return false, exceptions.EncryptPINError(err)
+		return exceptions.EncryptPINError(err)
+	}
+	if current == nil {
+		return exceptions.EncryptPINError(fmt.Errorf("%v", errorcodeutil.PINNotFound))
 	}
 
-	salt, encryptedPin := u.pinExt.EncryptPIN(pin, nil)
+	historySize := utils.PINHistorySize()
+	if u.isRecentPIN(current, historySize, pin) {
+		err := exceptions.PINReusedError()
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
+	options := extension.CurrentPINHashOptions()
+	salt, encryptedPin := u.pinExt.EncryptPIN(pin, options)
 
 	pinPayload := &domain.PIN{
 		ID:        uuid.New().String(),
 		ProfileID: profile.ID,
 		PINNumber: encryptedPin,
 		Salt:      salt,
+		Scheme:    extension.NewPINHashScheme(options),
+		History:   utils.RotatePINHistory(current, historySize, time.Now()),
 	}
 	_, err = u.infrastructure.Database.UpdatePIN(ctx, profile.ID, pinPayload)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return false, exceptions.InternalServerError(err)
+		return exceptions.InternalServerError(err)
 	}
-	return true, nil
+	return nil
+}
+
+// checkPINPolicy checks a PIN that a user chooses against the PIN policy. The PIN can't be
+// derived from the user's date of birth or phone number, and has the minimum length of the
+// flavours that the user has signed in to
+func (u *UserPinUseCaseImpl) checkPINPolicy(profile *profileutils.UserProfile, pin string) error {
+	input := extension.PINPolicyInput{
+		PIN:         pin,
+		DateOfBirth: profile.UserBioData.DateOfBirth,
+	}
+	if profile.PrimaryPhone != nil {
+		input.PhoneNumber = *profile.PrimaryPhone
+	}
+	for _, flavour := range []feedlib.Flavour{feedlib.FlavourPro, feedlib.FlavourConsumer} {
+		if utils.UserProfileHasFlavour(profile, flavour) {
+			input.Flavours = append(input.Flavours, flavour)
+		}
+	}
+	return u.pinPolicy.ValidatePIN(input)
+}
+
+// isRecentPIN checks whether a raw PIN matches the current PIN or one of the `historySize`
+// PINs that were used before it
+func (u *UserPinUseCaseImpl) isRecentPIN(current *domain.PIN, historySize int, pin string) bool {
+	options := extension.PINHashOptions(current.Scheme)
+	if u.pinExt.ComparePIN(pin, current.Salt, current.PINNumber, options) {
+		return true
+	}
+	for i, previous := range current.History {
+		if i >= historySize {
+			break
+		}
+		options := extension.PINHashOptions(previous.Scheme)
+		if u.pinExt.ComparePIN(pin, previous.Salt, previous.PINNumber, options) {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckHasPIN given a phone number checks if the phonenumber is present in our collections
@@ -260,26 +364,124 @@ func (u *UserPinUseCaseImpl) SetUserTempPIN(ctx context.Context, profileID strin
 	ctx, span := tracer.Start(ctx, "SetUserTempPIN")
 	defer span.End()
 
+	pinPayload, pin, err := u.NewUserTempPIN(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return "", err
+	}
+	pinPayload.ProfileID = profileID
+
+	if _, err := u.infrastructure.Database.SavePIN(ctx, pinPayload); err != nil {
+		utils.RecordSpanError(span, err)
+		return "", exceptions.SaveUserPinError(err)
+	}
+
+	return pin, nil
+}
+
+// NewUserTempPIN generates and encrypts a random one time pin. The PIN is not saved.
+// It returns the encrypted PIN together with the generated PIN
+func (u *UserPinUseCaseImpl) NewUserTempPIN(ctx context.Context) (*domain.PIN, string, error) {
+	ctx, span := tracer.Start(ctx, "NewUserTempPIN")
+	defer span.End()
+
 	pin, err := u.pinExt.GenerateTempPIN(ctx)
 	if err != nil {
 		utils.RecordSpanError(span, err)
-		return "", exceptions.GeneratePinError(err)
+		return nil, "", exceptions.GeneratePinError(err)
 	}
 
 	// Encrypt the PIN
-	salt, encryptedPin := u.pinExt.EncryptPIN(pin, nil)
+	options := extension.CurrentPINHashOptions()
+	salt, encryptedPin := u.pinExt.EncryptPIN(pin, options)
 
-	pinPayload := &domain.PIN{
+	issued := time.Now()
+	expiresAt := issued.Add(utils.TempPINTTL())
+	return &domain.PIN{
 		ID:        uuid.New().String(),
-		ProfileID: profileID,
 		PINNumber: encryptedPin,
 		Salt:      salt,
+		Scheme:    extension.NewPINHashScheme(options),
 		IsOTP:     true,
+		Issued:    &issued,
+		ExpiresAt: &expiresAt,
+	}, pin, nil
+}
+
+// UnlockUserPIN unlocks a PIN that was locked after too many failed attempts, without changing it.
+// Users can also unlock their PIN by resetting it with an OTP
+func (u *UserPinUseCaseImpl) UnlockUserPIN(ctx context.Context, profileID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UnlockUserPIN")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, u.infrastructure, u.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
 	}
-	if _, err := u.infrastructure.Database.SavePIN(ctx, pinPayload); err != nil {
+
+	if err := u.infrastructure.Database.ClearPINAttempts(ctx, profileID); err != nil {
 		utils.RecordSpanError(span, err)
-		return "", exceptions.SaveUserPinError(err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
 	}
+	return true, nil
+}
 
-	return pin, nil
+// ReissueTempPIN replaces the PIN of a user with a new temporary PIN and sends it to their primary
+// phone number. It is used when a temporary PIN has expired or was never received.
+// The replaced PIN is kept in the PIN history
+func (u *UserPinUseCaseImpl) ReissueTempPIN(ctx context.Context, profileID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ReissueTempPIN")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, u.infrastructure, u.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	profile, err := u.infrastructure.Database.GetUserProfileByID(ctx, profileID, false)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	if profile.PrimaryPhone == nil {
+		err := fmt.Errorf("the user profile does not have a primary phone number")
+		utils.RecordSpanError(span, err)
+		return false, exceptions.NormalizeMSISDNError(err)
+	}
+
+	pinPayload, pin, err := u.NewUserTempPIN(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	pinPayload.ProfileID = profile.ID
+
+	// a profile that never set a PIN has no PIN to keep in the history
+	current, err := u.infrastructure.Database.GetPINByProfileID(ctx, profile.ID)
+	if err == nil {
+		pinPayload.History = utils.RotatePINHistory(current, utils.PINHistorySize(), time.Now())
+	}
+
+	if err := u.infrastructure.Database.SaveTempPIN(ctx, pinPayload); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, exceptions.SaveUserPinError(err)
+	}
+
+	firstName := ""
+	if profile.UserBioData.FirstName != nil {
+		firstName = *profile.UserBioData.FirstName
+	}
+	message := fmt.Sprintf(domain.TempPINReissueMessage, firstName, pin)
+	if err := u.infrastructure.Engagement.SendSMS(ctx, []string{*profile.PrimaryPhone}, message); err != nil {
+		utils.RecordSpanError(span, err)
+		return false, fmt.Errorf("unable to send the temporary PIN: %w", err)
+	}
+
+	return true, nil
 }
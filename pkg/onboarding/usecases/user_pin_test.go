@@ -36,7 +36,7 @@ func TestUserPinUseCaseUnitTest_SetUserPIN(t *testing.T) {
 			name: "valid:_set_user_pin",
 			args: args{
 				ctx:       ctx,
-				pin:       "1234",
+				pin:       "2791",
 				profileID: uuid.New().String(),
 			},
 			want:    true,
@@ -62,11 +62,21 @@ func TestUserPinUseCaseUnitTest_SetUserPIN(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "invalid:_weak_pin",
+			args: args{
+				ctx:       ctx,
+				pin:       "1111",
+				profileID: uuid.New().String(),
+			},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "invalid:_unable_to_save_pin",
 			args: args{
 				ctx:       ctx,
-				pin:       "1234",
+				pin:       "2791",
 				profileID: uuid.New().String(),
 			},
 			want:    false,
@@ -75,6 +85,14 @@ func TestUserPinUseCaseUnitTest_SetUserPIN(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+				phone := "+254721123123"
+				return &profileutils.UserProfile{
+					ID:           id,
+					PrimaryPhone: &phone,
+				}, nil
+			}
+
 			if tt.name == "valid:_set_user_pin" {
 				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
 					phone := "+254721123123"
@@ -165,7 +183,7 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    true,
@@ -176,7 +194,7 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    false,
@@ -187,7 +205,7 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    false,
@@ -198,7 +216,7 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    false,
@@ -209,7 +227,7 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    false,
@@ -220,7 +238,18 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				PIN:   interserviceclient.TestUserPin,
+				PIN:   "2791",
+				OTP:   "588214",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid:_pin_reused",
+			args: args{
+				ctx:   ctx,
+				phone: "+254721456789",
+				PIN:   "2791",
 				OTP:   "588214",
 			},
 			want:    false,
@@ -247,6 +276,9 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
 					return &domain.PIN{ID: "123", ProfileID: "456"}, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "salt", "passw"
 				}
@@ -272,6 +304,9 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
 					return &domain.PIN{ID: "123", ProfileID: "456"}, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "salt", "passw"
 				}
@@ -333,6 +368,37 @@ func TestUserPinUseCaseUnitTest_ResetUserPIN(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid:_pin_reused" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254721123123"
+					return &phone, nil
+				}
+				fakeEngagementSvs.VerifyOTPFn = func(ctx context.Context, phone, OTP string) (bool, error) {
+					return true, nil
+				}
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+					return &domain.PIN{
+						ID:        "123",
+						ProfileID: "456",
+						PINNumber: "current",
+						Salt:      "salt",
+						History:   []domain.PINHistoryEntry{{PINNumber: "previous", Salt: "salt"}},
+					}, nil
+				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return encodedPwd == "previous"
+				}
+				fakeInfraRepo.UpdatePINFn = func(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
+					return true, nil
+				}
+			}
+
 			got, err := i.ResetUserPIN(tt.args.ctx, tt.args.phone, tt.args.PIN, tt.args.OTP)
 
 			if tt.wantErr {
@@ -383,7 +449,7 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				pin:   interserviceclient.TestUserPin,
+				pin:   "2791",
 			},
 			want:    true,
 			wantErr: false,
@@ -393,7 +459,7 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				pin:   interserviceclient.TestUserPin,
+				pin:   "2791",
 			},
 			want:    false,
 			wantErr: true,
@@ -403,7 +469,7 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				pin:   interserviceclient.TestUserPin,
+				pin:   "2791",
 			},
 			want:    false,
 			wantErr: true,
@@ -413,7 +479,7 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				pin:   interserviceclient.TestUserPin,
+				pin:   "2791",
 			},
 			want:    false,
 			wantErr: true,
@@ -423,7 +489,27 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 			args: args{
 				ctx:   ctx,
 				phone: "+254721456789",
-				pin:   interserviceclient.TestUserPin,
+				pin:   "2791",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid:_weak_pin",
+			args: args{
+				ctx:   ctx,
+				phone: "+254721456789",
+				pin:   "0000",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid:_pin_reused",
+			args: args{
+				ctx:   ctx,
+				phone: "+254721456789",
+				pin:   "2791",
 			},
 			want:    false,
 			wantErr: true,
@@ -447,6 +533,9 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
 					return &domain.PIN{ID: "123", ProfileID: "456"}, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "salt", "passw"
 				}
@@ -506,6 +595,9 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
 					return &domain.PIN{ID: "123", ProfileID: "456"}, nil
 				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return false
+				}
 				fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
 					return "salt", "passw"
 				}
@@ -514,6 +606,50 @@ func TestUserPinUseCaseImpl_ChangeUserPINUnitTest(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid:_pin_reused" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254721123123"
+					return &phone, nil
+				}
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+				fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+					return &domain.PIN{
+						ID:        "123",
+						ProfileID: "456",
+						PINNumber: "current",
+						Salt:      "salt",
+						History:   []domain.PINHistoryEntry{{PINNumber: "previous", Salt: "salt"}},
+					}, nil
+				}
+				fakePinExt.ComparePINFn = func(rawPwd string, salt string, encodedPwd string, options *extension.Options) bool {
+					return encodedPwd == "previous"
+				}
+				fakeInfraRepo.UpdatePINFn = func(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
+					return true, nil
+				}
+			}
+
+			if tt.name == "invalid:_weak_pin" {
+				fakeBaseExt.NormalizeMSISDNFn = func(msisdn string) (*string, error) {
+					phone := "+254721123123"
+					return &phone, nil
+				}
+				fakeInfraRepo.GetUserProfileByPrimaryPhoneNumberFn = func(ctx context.Context, phoneNumber string, suspended bool) (*profileutils.UserProfile, error) {
+					return &profileutils.UserProfile{
+						ID:           "123",
+						PrimaryPhone: &phoneNumber,
+					}, nil
+				}
+				fakeInfraRepo.UpdatePINFn = func(ctx context.Context, id string, pin *domain.PIN) (bool, error) {
+					return true, nil
+				}
+			}
+
 			got, err := i.ChangeUserPIN(tt.args.ctx, tt.args.phone, tt.args.pin)
 
 			if tt.wantErr {
@@ -896,3 +1032,158 @@ func TestUserPinUseCaseImpl_SetUserTempPIN(t *testing.T) {
 		})
 	}
 }
+
+func TestUserPinUseCaseUnitTest_UnlockUserPIN(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name     string
+		isAdmin  bool
+		clearErr error
+		wantErr  bool
+	}{
+		{
+			name:    "happy: unlock a user PIN",
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			wantErr: true,
+		},
+		{
+			name:     "sad: unable to clear the failed attempts",
+			isAdmin:  true,
+			clearErr: fmt.Errorf("failed to get a user pin"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.ClearPINAttemptsFn = func(ctx context.Context, profileID string) error {
+				return tt.clearErr
+			}
+
+			unlocked, err := i.UnlockUserPIN(ctx, "profile-2")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnlockUserPIN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if unlocked == tt.wantErr {
+				t.Errorf("UnlockUserPIN() = %v, wantErr %v", unlocked, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserPinUseCaseUnitTest_ReissueTempPIN(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	phone := "+254721123123"
+	firstName := "Jane"
+
+	tests := []struct {
+		name       string
+		isAdmin    bool
+		profileErr error
+		saveErr    error
+		smsErr     error
+		wantErr    bool
+	}{
+		{
+			name:    "happy: reissue a temporary PIN",
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			wantErr: true,
+		},
+		{
+			name:       "sad: the user profile does not exist",
+			isAdmin:    true,
+			profileErr: fmt.Errorf("failed to get a user profile"),
+			wantErr:    true,
+		},
+		{
+			name:    "sad: unable to save the temporary PIN",
+			isAdmin: true,
+			saveErr: fmt.Errorf("unable to save pin"),
+			wantErr: true,
+		},
+		{
+			name:    "sad: unable to send the temporary PIN",
+			isAdmin: true,
+			smsErr:  fmt.Errorf("unable to send sms"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.GetUserProfileByIDFn = func(ctx context.Context, id string, suspended bool) (*profileutils.UserProfile, error) {
+				if tt.profileErr != nil {
+					return nil, tt.profileErr
+				}
+				return &profileutils.UserProfile{
+					ID:           id,
+					PrimaryPhone: &phone,
+					UserBioData:  profileutils.BioData{FirstName: &firstName},
+				}, nil
+			}
+			fakeInfraRepo.GetPINByProfileIDFn = func(ctx context.Context, profileID string) (*domain.PIN, error) {
+				return &domain.PIN{ID: "123", ProfileID: profileID, PINNumber: "current", Salt: "salt"}, nil
+			}
+			fakePinExt.GenerateTempPINFn = func(ctx context.Context) (string, error) {
+				return "1234", nil
+			}
+			fakePinExt.EncryptPINFn = func(rawPwd string, options *extension.Options) (string, string) {
+				return "salt", "passw"
+			}
+			var saved *domain.PIN
+			fakeInfraRepo.SaveTempPINFn = func(ctx context.Context, pin *domain.PIN) error {
+				saved = pin
+				return tt.saveErr
+			}
+			var sent string
+			fakeEngagementSvs.SendSMSFn = func(ctx context.Context, phoneNumbers []string, message string) error {
+				sent = message
+				return tt.smsErr
+			}
+
+			got, err := i.ReissueTempPIN(ctx, "profile-2")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReissueTempPIN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got {
+				t.Errorf("ReissueTempPIN() = %v, want true", got)
+			}
+			if !saved.IsOTP || saved.ExpiresAt == nil || saved.ProfileID != "profile-2" {
+				t.Errorf("expected an expiring temporary PIN for the profile, got %v", saved)
+			}
+			if len(saved.History) != 1 || saved.History[0].PINNumber != "current" {
+				t.Errorf("expected the replaced PIN in the history, got %v", saved.History)
+			}
+			if sent != fmt.Sprintf(domain.TempPINReissueMessage, firstName, "1234") {
+				t.Errorf("unexpected message %q", sent)
+			}
+		})
+	}
+}
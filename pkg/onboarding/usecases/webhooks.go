@@ -0,0 +1,217 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/exceptions"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/extension"
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/utils"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/onboarding/pkg/onboarding/infrastructure"
+	"github.com/savannahghi/pubsubtools"
+)
+
+// MinWebhookSecretLength is the minimum number of characters of the secret of a webhook endpoint
+const MinWebhookSecretLength = 16
+
+// WebhookUseCases represents the business logic involved in delivering the domain events to
+// the webhook endpoints of partner systems. It is restricted to admins
+type WebhookUseCases interface {
+	RegisterWebhookEndpoint(
+		ctx context.Context,
+		input dto.WebhookEndpointInput,
+	) (*domain.WebhookEndpoint, error)
+
+	DeregisterWebhookEndpoint(ctx context.Context, id string) (bool, error)
+
+	ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+
+	ListWebhookDeliveries(
+		ctx context.Context,
+		endpointID string,
+		status *domain.WebhookDeliveryStatus,
+	) ([]*domain.WebhookDelivery, error)
+
+	RedeliverWebhook(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+// WebhookUseCasesImpl represents the usecase implementation object
+type WebhookUseCasesImpl struct {
+	infrastructure infrastructure.Infrastructure
+	baseExt        extension.BaseExtension
+}
+
+// NewWebhookUseCases initializes a new webhook usecase
+func NewWebhookUseCases(
+	infrastructure infrastructure.Infrastructure,
+	ext extension.BaseExtension,
+) *WebhookUseCasesImpl {
+	return &WebhookUseCasesImpl{infrastructure, ext}
+}
+
+// RegisterWebhookEndpoint registers an endpoint that the subscribed events are delivered to
+func (w *WebhookUseCasesImpl) RegisterWebhookEndpoint(
+	ctx context.Context,
+	input dto.WebhookEndpointInput,
+) (*domain.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "RegisterWebhookEndpoint")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, w.infrastructure, w.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	eventTypes, err := validateWebhookEndpointInput(input)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, exceptions.InvalidWebhookEndpointError(err)
+	}
+
+	endpoint := &domain.WebhookEndpoint{
+		ID:         uuid.New().String(),
+		URL:        input.URL,
+		Secret:     input.Secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		Created:    time.Now().In(pubsubtools.TimeLocation),
+	}
+	if err := w.infrastructure.Database.CreateWebhookEndpoint(ctx, endpoint); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// DeregisterWebhookEndpoint removes a webhook endpoint together with its delivery log
+func (w *WebhookUseCasesImpl) DeregisterWebhookEndpoint(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "DeregisterWebhookEndpoint")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, w.infrastructure, w.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+
+	if err := w.infrastructure.Database.DeleteWebhookEndpoint(ctx, id); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return false, err
+	}
+	return true, nil
+}
+
+// ListWebhookEndpoints returns the registered webhook endpoints
+func (w *WebhookUseCasesImpl) ListWebhookEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookEndpoints")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, w.infrastructure, w.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	endpoints, err := w.infrastructure.Database.ListWebhookEndpoints(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook endpoint, optionally of the
+// deliveries in a single state
+func (w *WebhookUseCasesImpl) ListWebhookDeliveries(
+	ctx context.Context,
+	endpointID string,
+	status *domain.WebhookDeliveryStatus,
+) ([]*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookDeliveries")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, w.infrastructure, w.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	deliveries, err := w.infrastructure.Database.ListWebhookDeliveries(ctx, endpointID, status)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhook delivers an event to a webhook endpoint again, e.g after a delivery has failed
+func (w *WebhookUseCasesImpl) RedeliverWebhook(
+	ctx context.Context,
+	deliveryID string,
+) (*domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "RedeliverWebhook")
+	defer span.End()
+
+	if err := checkLoggedInUserIsAdmin(ctx, w.infrastructure, w.baseExt); err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+
+	delivery, err := w.infrastructure.Webhooks.RedeliverWebhook(ctx, deliveryID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		// this is a wrapped error. No need to wrap it again
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// validateWebhookEndpointInput checks that a webhook endpoint has an HTTPS URL, a long enough
+// secret and known event types. Plain HTTP is only allowed for local endpoints
+func validateWebhookEndpointInput(input dto.WebhookEndpointInput) ([]domain.EventType, error) {
+	endpointURL, err := url.Parse(input.URL)
+	if err != nil || endpointURL.Host == "" {
+		return nil, fmt.Errorf("%q is not a valid URL", input.URL)
+	}
+	isLocal := endpointURL.Hostname() == "localhost" || endpointURL.Hostname() == "127.0.0.1"
+	if endpointURL.Scheme != "https" && !(endpointURL.Scheme == "http" && isLocal) {
+		return nil, fmt.Errorf("the webhook URL %q must use https", input.URL)
+	}
+
+	if len(input.Secret) < MinWebhookSecretLength {
+		return nil, fmt.Errorf("the webhook secret must have at least %d characters", MinWebhookSecretLength)
+	}
+
+	if len(input.EventTypes) == 0 {
+		return nil, fmt.Errorf("at least one event type should be subscribed to")
+	}
+	eventTypes := []domain.EventType{}
+	for _, value := range input.EventTypes {
+		eventType, ok := knownEventType(value)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a known event type", value)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes, nil
+}
+
+func knownEventType(value string) (domain.EventType, bool) {
+	for _, eventType := range domain.AllEventTypes {
+		if string(eventType) == value {
+			return eventType, true
+		}
+	}
+	return "", false
+}
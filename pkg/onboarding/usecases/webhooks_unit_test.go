@@ -0,0 +1,161 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/savannahghi/onboarding/pkg/onboarding/application/dto"
+	"github.com/savannahghi/onboarding/pkg/onboarding/domain"
+	"github.com/savannahghi/profileutils"
+)
+
+func setupFakeAdmin(isAdmin bool) {
+	fakeBaseExt.GetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+		return "uid-1", nil
+	}
+	fakeInfraRepo.GetUserProfileByUIDFn = func(ctx context.Context, uid string, suspended bool) (*profileutils.UserProfile, error) {
+		return &profileutils.UserProfile{ID: "profile-1"}, nil
+	}
+	fakeInfraRepo.CheckIfAdminFn = func(profile *profileutils.UserProfile) bool {
+		return isAdmin
+	}
+}
+
+func TestWebhookUseCasesImpl_RegisterWebhookEndpoint(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	validInput := dto.WebhookEndpointInput{
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "a-very-long-secret",
+		EventTypes: []string{"user.created", "role.assigned"},
+	}
+
+	tests := []struct {
+		name    string
+		input   dto.WebhookEndpointInput
+		isAdmin bool
+		wantErr bool
+	}{
+		{
+			name:    "happy: register a webhook endpoint",
+			input:   validInput,
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "happy: plain http for a local endpoint",
+			input:   dto.WebhookEndpointInput{URL: "http://localhost:9000/hooks", Secret: validInput.Secret, EventTypes: validInput.EventTypes},
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			input:   validInput,
+			isAdmin: false,
+			wantErr: true,
+		},
+		{
+			name:    "sad: plain http",
+			input:   dto.WebhookEndpointInput{URL: "http://partner.example.com/hooks", Secret: validInput.Secret, EventTypes: validInput.EventTypes},
+			isAdmin: true,
+			wantErr: true,
+		},
+		{
+			name:    "sad: short secret",
+			input:   dto.WebhookEndpointInput{URL: validInput.URL, Secret: "secret", EventTypes: validInput.EventTypes},
+			isAdmin: true,
+			wantErr: true,
+		},
+		{
+			name:    "sad: unknown event type",
+			input:   dto.WebhookEndpointInput{URL: validInput.URL, Secret: validInput.Secret, EventTypes: []string{"user.deleted"}},
+			isAdmin: true,
+			wantErr: true,
+		},
+		{
+			name:    "sad: no event types",
+			input:   dto.WebhookEndpointInput{URL: validInput.URL, Secret: validInput.Secret},
+			isAdmin: true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeInfraRepo.CreateWebhookEndpointFn = func(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+				return nil
+			}
+
+			endpoint, err := i.RegisterWebhookEndpoint(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterWebhookEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if endpoint.ID == "" || !endpoint.Active {
+					t.Errorf("expected an active endpoint with an ID, got %v", endpoint)
+				}
+				if len(endpoint.EventTypes) != len(tt.input.EventTypes) {
+					t.Errorf("expected %d event types, got %d", len(tt.input.EventTypes), len(endpoint.EventTypes))
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookUseCasesImpl_RedeliverWebhook(t *testing.T) {
+	ctx := context.Background()
+	i, err := InitializeFakeOnboardingInteractor()
+	if err != nil {
+		t.Errorf("failed to fake initialize onboarding interactor: %v", err)
+		return
+	}
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+		wantErr bool
+	}{
+		{
+			name:    "happy: redeliver a webhook",
+			isAdmin: true,
+			wantErr: false,
+		},
+		{
+			name:    "sad: the logged in user is not an admin",
+			isAdmin: false,
+			wantErr: true,
+		},
+		{
+			name:    "sad: the delivery does not exist",
+			isAdmin: true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupFakeAdmin(tt.isAdmin)
+			fakeWebhooks.RedeliverWebhookFn = func(ctx context.Context, deliveryID string) (*domain.WebhookDelivery, error) {
+				if tt.name == "sad: the delivery does not exist" {
+					return nil, fmt.Errorf("webhook delivery %s not found", deliveryID)
+				}
+				return &domain.WebhookDelivery{ID: deliveryID, Status: domain.WebhookDeliveryStatusSucceeded}, nil
+			}
+
+			delivery, err := i.RedeliverWebhook(ctx, "endpoint-1-event-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RedeliverWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && delivery.Status != domain.WebhookDeliveryStatusSucceeded {
+				t.Errorf("expected the delivery to succeed, got %v", delivery.Status)
+			}
+		})
+	}
+}